@@ -16,6 +16,7 @@ import (
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/configsvc"
 	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/leader"
 	"github.com/kuhlman-labs/github-migrator/internal/logging"
 	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
@@ -90,6 +91,17 @@ func main() {
 	// Start MCP server for AI tool access (runs in background goroutine)
 	server.StartMCPServer()
 
+	// Enable the rollback endpoints (destination client required)
+	initializeRollbacker(server, cfg, cfgSvc, destDualClient, db, logger)
+
+	// Enable the dependency-aware wave plan endpoint
+	organizer, err := batch.NewOrganizer(batch.OrganizerConfig{Storage: db, Logger: logger})
+	if err != nil {
+		slog.Error("Failed to create batch organizer for wave planning", "error", err)
+	} else {
+		server.SetWavePlanner(organizer)
+	}
+
 	// Create cancellable context for all background workers (must be created before callback registration)
 	workerCtx, cancelWorkers := context.WithCancel(context.Background())
 	defer cancelWorkers()
@@ -135,6 +147,7 @@ func main() {
 				logger,
 			)
 			if newDestClient != nil {
+				initializeRollbacker(server, cfg, cfgSvc, newDestClient, db, logger)
 				migrationWorker = initializeMigrationWorker(workerCtx, cfg, cfgSvc, newDestClient, db, logger)
 				if migrationWorker != nil {
 					slog.Info("Migration worker started after destination configuration")
@@ -165,7 +178,7 @@ func main() {
 	})
 
 	// Initialize and start batch status updater
-	statusUpdater := initializeBatchStatusUpdater(db, logger)
+	statusUpdater := initializeBatchStatusUpdater(cfg, db, logger)
 
 	if statusUpdater != nil {
 		go statusUpdater.Start(workerCtx)
@@ -383,6 +396,8 @@ func initializeMigrationWorker(ctx context.Context, cfg *config.Config, cfgSvc *
 		Logger:          logger,
 		PollInterval:    pollInterval,
 		Workers:         cfg.Migration.Workers,
+		InstanceID:      cfg.Migration.InstanceID,
+		LeaseTTL:        time.Duration(cfg.Migration.LeaseTTLSeconds) * time.Second,
 	})
 	if err != nil {
 		slog.Error("Failed to create migration worker", "error", err)
@@ -402,6 +417,26 @@ func initializeMigrationWorker(ctx context.Context, cfg *config.Config, cfgSvc *
 	return migrationWorker
 }
 
+// initializeRollbacker sets the executor used by the rollback and reference pull request endpoints.
+// Both act on destination repositories, so they are unavailable until a destination is configured.
+func initializeRollbacker(server *api.Server, cfg *config.Config, cfgSvc *configsvc.Service, destDualClient *github.DualClient, db *storage.Database, logger *slog.Logger) {
+	if destDualClient == nil {
+		logger.Info("Rollback endpoints disabled - destination GitHub client not configured")
+		return
+	}
+
+	executorFactory, err := createExecutorFactory(cfg, cfgSvc, destDualClient, db, logger)
+	if err != nil {
+		slog.Error("Failed to create executor factory for rollbacks", "error", err)
+		return
+	}
+
+	server.SetRollbacker(executorFactory)
+	server.SetReferenceRewriter(executorFactory)
+	server.SetCodeownersRewriter(executorFactory)
+	server.SetCollaboratorGranter(executorFactory)
+}
+
 // createExecutorFactory creates an executor factory with the shared configuration.
 // Uses ConfigService for dynamic settings from database, falling back to static config.
 func createExecutorFactory(cfg *config.Config, cfgSvc *configsvc.Service, destDualClient *github.DualClient, db *storage.Database, logger *slog.Logger) (*migration.ExecutorFactory, error) {
@@ -442,29 +477,94 @@ func createExecutorFactory(cfg *config.Config, cfgSvc *configsvc.Service, destDu
 		InternalRepos: migCfg.VisibilityInternal,
 	}
 
+	// Parse CODEOWNERS rewrite mode, leaving CODEOWNERS unchanged if invalid
+	codeownersRewrite, err := migration.ParseCodeownersRewriteMode(cfg.Migration.CodeownersRewrite)
+	if err != nil {
+		logger.Warn("Invalid CODEOWNERS rewrite mode, defaulting to off", "error", err)
+		codeownersRewrite = migration.CodeownersRewriteOff
+	}
+
+	// Parse collaborator grant mode, leaving collaborator permissions to be granted manually if invalid
+	collaboratorGrants, err := migration.ParseCollaboratorGrantMode(cfg.Migration.CollaboratorGrants)
+	if err != nil {
+		logger.Warn("Invalid collaborator grant mode, defaulting to off", "error", err)
+		collaboratorGrants = migration.CollaboratorGrantsOff
+	}
+
+	// Parse the settings sync allow list, syncing no settings if invalid
+	settingsSync, err := migration.ParseSettingsSync(cfg.Migration.SettingsSync)
+	if err != nil {
+		logger.Warn("Invalid settings sync allow list, disabling settings sync", "error", err)
+		settingsSync = nil
+	}
+
+	// Create ELM client if an Enterprise Live Migrator endpoint is configured
+	var elmClient *migration.ELMClient
+	if cfg.Migration.ELM.BaseURL != "" {
+		var err error
+		elmClient, err = migration.NewELMClient(migration.ELMClientConfig{
+			BaseURL: cfg.Migration.ELM.BaseURL,
+			Token:   cfg.Migration.ELM.Token,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create ELM client: %w", err)
+		}
+	}
+
 	logger.Info("Creating migration executor factory",
 		"visibility_public_to", visibilityHandling.PublicRepos,
 		"visibility_internal_to", visibilityHandling.InternalRepos,
 		"post_migration_mode", postMigMode,
-		"dest_repo_exists_action", destRepoAction)
+		"deep_validation", cfg.Migration.DeepValidation,
+		"reference_rewrite_prs", cfg.Migration.ReferenceRewritePRs,
+		"codeowners_rewrite", codeownersRewrite,
+		"collaborator_grants", collaboratorGrants,
+		"settings_sync", settingsSync,
+		"protection_replay", cfg.Migration.ProtectionReplay,
+		"webhook_replay", cfg.Migration.WebhookReplay,
+		"actions_replay", cfg.Migration.ActionsReplay,
+		"dest_repo_exists_action", destRepoAction,
+		"elm_enabled", elmClient != nil,
+		"git_mirror_include_lfs", cfg.Migration.GitMirror.IncludeLFS,
+		"git_mirror_ref_filters", cfg.Migration.GitMirror.RefFilters)
 
 	return migration.NewExecutorFactory(migration.ExecutorFactoryConfig{
 		Storage:              db,
 		DestClient:           destDualClient.MigrationClient(),
 		Logger:               logger,
 		PostMigrationMode:    postMigMode,
+		DeepValidation:       cfg.Migration.DeepValidation,
+		ReferenceRewritePRs:  cfg.Migration.ReferenceRewritePRs,
+		CodeownersRewrite:    codeownersRewrite,
+		CollaboratorGrants:   collaboratorGrants,
+		SettingsSync:         settingsSync,
+		ProtectionReplay:     cfg.Migration.ProtectionReplay,
+		WebhookReplay:        cfg.Migration.WebhookReplay,
+		ActionsReplay:        cfg.Migration.ActionsReplay,
 		DestRepoExistsAction: destRepoAction,
 		VisibilityHandling:   visibilityHandling,
 		ConfigProvider:       cfgSvc, // Dynamic config provider for live setting updates
+		ELMClient:            elmClient,
+		GitMirror: migration.GitMirrorOptions{
+			IncludeLFS: cfg.Migration.GitMirror.IncludeLFS,
+			RefFilters: cfg.Migration.GitMirror.RefFilters,
+		},
 	})
 }
 
 // initializeBatchStatusUpdater creates the batch status updater service
-func initializeBatchStatusUpdater(db *storage.Database, logger *slog.Logger) *batch.StatusUpdater {
+func initializeBatchStatusUpdater(cfg *config.Config, db *storage.Database, logger *slog.Logger) *batch.StatusUpdater {
+	elector, err := newLeaderElector(cfg, db, logger, leader.LockBatchStatusUpdater)
+	if err != nil {
+		slog.Error("Failed to create leader elector for batch status updater", "error", err)
+		return nil
+	}
+
 	statusUpdater, err := batch.NewStatusUpdater(batch.StatusUpdaterConfig{
 		Storage:  db,
 		Logger:   logger,
 		Interval: 30 * time.Second, // Update every 30 seconds
+		Elector:  elector,
 	})
 	if err != nil {
 		slog.Error("Failed to create batch status updater", "error", err)
@@ -475,6 +575,18 @@ func initializeBatchStatusUpdater(db *storage.Database, logger *slog.Logger) *ba
 	return statusUpdater
 }
 
+// newLeaderElector creates a leader elector for a singleton background loop.
+// Only the replica holding the named lock runs the loop when several replicas share the database.
+func newLeaderElector(cfg *config.Config, db *storage.Database, logger *slog.Logger, name string) (*leader.Elector, error) {
+	return leader.NewElector(leader.ElectorConfig{
+		Storage:    db,
+		Logger:     logger,
+		Name:       name,
+		InstanceID: cfg.Migration.InstanceID,
+		TTL:        time.Duration(cfg.Migration.LeaseTTLSeconds) * time.Second,
+	})
+}
+
 // initializeSchedulerWorker creates the scheduler worker for scheduled batches.
 // Uses ExecutorFactory for dynamic multi-source support.
 func initializeSchedulerWorker(cfg *config.Config, cfgSvc *configsvc.Service, destDualClient *github.DualClient, db *storage.Database, logger *slog.Logger) *worker.SchedulerWorker {
@@ -502,9 +614,17 @@ func initializeSchedulerWorker(cfg *config.Config, cfgSvc *configsvc.Service, de
 		slog.Error("Failed to create batch orchestrator", "error", err)
 		return nil
 	}
+	orchestrator.SetRequireApproval(cfg.Migration.RequireBatchApproval)
+
+	elector, err := newLeaderElector(cfg, db, logger, leader.LockBatchScheduler)
+	if err != nil {
+		slog.Error("Failed to create leader elector for scheduler", "error", err)
+		return nil
+	}
 
 	// Create scheduler worker
 	schedulerWorker := worker.NewSchedulerWorker(orchestrator, logger)
+	schedulerWorker.SetElector(elector)
 	slog.Info("Scheduler worker initialized - will check for scheduled batches every minute")
 
 	return schedulerWorker
@@ -7,13 +7,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/forecast"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
 
 func (h *Handler) exportExecutiveReportCSV(w http.ResponseWriter, sourceType string, total, migrated, inProgress, pending, failed int,
 	completionRate, successRate float64, estimatedCompletionDate string, daysRemaining int,
-	velocity *storage.MigrationVelocity, avgMigrationTime, medianMigrationTime int,
+	velocity *storage.MigrationVelocity, avgMigrationTime, medianMigrationTime int, remainingForecast *forecast.Forecast,
 	orgStats []*storage.MigrationCompletionStats, complexityDist []*storage.ComplexityDistribution,
 	sizeDist []*storage.SizeDistribution, featureStats *storage.FeatureStats,
 	statusBreakdown map[string]int, completedBatches, inProgressBatches, pendingBatches int) {
@@ -147,6 +148,10 @@ func (h *Handler) exportExecutiveReportCSV(w http.ResponseWriter, sourceType str
 	if medianMigrationTime > 0 {
 		output.WriteString(fmt.Sprintf("Median Migration Time,%d minutes\n", medianMigrationTime/60))
 	}
+	if remainingForecast != nil && remainingForecast.Repositories > 0 {
+		output.WriteString(fmt.Sprintf("Forecast Remaining Duration,%.1f hours (%d repositories on %d workers)\n",
+			remainingForecast.WallClock().Hours(), remainingForecast.Repositories, remainingForecast.Workers))
+	}
 	output.WriteString("\n")
 
 	output.WriteString("--- BATCH EXECUTION PERFORMANCE ---\n")
@@ -182,7 +187,7 @@ func (h *Handler) exportExecutiveReportCSV(w http.ResponseWriter, sourceType str
 
 func (h *Handler) exportExecutiveReportJSON(w http.ResponseWriter, sourceType string, total, migrated, inProgress, pending, failed int,
 	completionRate, successRate float64, estimatedCompletionDate string, daysRemaining int,
-	velocity *storage.MigrationVelocity, avgMigrationTime, medianMigrationTime int,
+	velocity *storage.MigrationVelocity, avgMigrationTime, medianMigrationTime int, remainingForecast *forecast.Forecast,
 	orgStats []*storage.MigrationCompletionStats, complexityDist []*storage.ComplexityDistribution,
 	sizeDist []*storage.SizeDistribution, featureStats *storage.FeatureStats,
 	statusBreakdown map[string]int, completedBatches, inProgressBatches, pendingBatches int) {
@@ -241,6 +246,7 @@ func (h *Handler) exportExecutiveReportJSON(w http.ResponseWriter, sourceType st
 				"average_duration_sec": avgMigrationTime,
 				"median_duration_sec":  medianMigrationTime,
 			},
+			"forecast": remainingForecast,
 			"batches": map[string]any{
 				"total":       completedBatches + inProgressBatches + pendingBatches,
 				"completed":   completedBatches,
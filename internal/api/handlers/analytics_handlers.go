@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/forecast"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
@@ -329,6 +330,7 @@ func (h *Handler) GetExecutiveReport(w http.ResponseWriter, r *http.Request) {
 			},
 			"status_breakdown": stats,
 			"velocity":         map[string]any{"repos_per_day": migrationVelocity.ReposPerDay, "repos_per_week": migrationVelocity.ReposPerWeek, "average_duration_sec": avgMigrationTime, "median_duration_sec": medianMigrationTime, "trend": migrationTimeSeries},
+			"forecast":         h.remainingForecast(ctx, orgFilter, projectFilter, batchFilter, sourceID),
 			"batches":          map[string]any{"total": len(batches), "completed": completedBatches, "in_progress": inProgressBatches, "pending": pendingBatches},
 			"risk_factors":     map[string]any{"high_complexity_pending": highComplexityPending, "very_large_pending": veryLargePending, "failed_migrations": failed},
 		},
@@ -459,20 +461,45 @@ func (h *Handler) ExportExecutiveReport(w http.ResponseWriter, r *http.Request)
 	// This prevents truncation errors when converting to minutes (e.g., 90.7s → 91s → 1 min)
 	avgMigrationTimeInt := int(math.Round(avgMigrationTime))
 	medianMigrationTimeInt := int(math.Round(medianMigrationTime))
+	remainingForecast := h.remainingForecast(ctx, orgFilter, projectFilter, batchFilter, sourceID)
 
 	if format == formatCSV {
 		h.exportExecutiveReportCSV(w, h.sourceType, total, migrated, inProgress, pending, failed, completionRate, successRate,
-			estimatedCompletionDate, daysRemaining, migrationVelocity, avgMigrationTimeInt, medianMigrationTimeInt,
+			estimatedCompletionDate, daysRemaining, migrationVelocity, avgMigrationTimeInt, medianMigrationTimeInt, remainingForecast,
 			migrationCompletionStats, complexityDistribution, sizeDistribution, featureStats,
 			stats, completedBatches, inProgressBatches, pendingBatches)
 	} else {
 		h.exportExecutiveReportJSON(w, h.sourceType, total, migrated, inProgress, pending, failed, completionRate, successRate,
-			estimatedCompletionDate, daysRemaining, migrationVelocity, avgMigrationTimeInt, medianMigrationTimeInt,
+			estimatedCompletionDate, daysRemaining, migrationVelocity, avgMigrationTimeInt, medianMigrationTimeInt, remainingForecast,
 			migrationCompletionStats, complexityDistribution, sizeDistribution, featureStats,
 			stats, completedBatches, inProgressBatches, pendingBatches)
 	}
 }
 
+// remainingForecast projects the wall-clock time of migrating the repositories in an executive
+// report that are not yet migrated. Per-repository estimates are left out of the report.
+func (h *Handler) remainingForecast(ctx context.Context, orgFilter, projectFilter, batchFilter string, sourceID *int64) *forecast.Forecast {
+	filters := map[string]any{}
+	if orgFilter != "" {
+		filters["organization"] = orgFilter
+	}
+	if projectFilter != "" {
+		filters["ado_project"] = projectFilter
+	}
+	if batchID, err := strconv.ParseInt(batchFilter, 10, 64); err == nil {
+		filters["batch_id"] = batchID
+	}
+	if sourceID != nil {
+		filters["source_id"] = *sourceID
+	}
+
+	result := h.forecastRepositories(ctx, filters)
+	if result != nil {
+		result.Estimates = nil
+	}
+	return result
+}
+
 // ExportDetailedDiscoveryReport handles GET /api/v1/analytics/detailed-discovery-report/export
 func (h *Handler) ExportDetailedDiscoveryReport(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -656,6 +683,8 @@ func formatSourceForDisplay(source string) string {
 		return "Azure DevOps"
 	case models.SourceTypeGitLab:
 		return "GitLab"
+	case models.SourceTypeBitbucket:
+		return "Bitbucket"
 	case models.SourceTypeGHES:
 		return "GitHub Enterprise Server"
 	default:
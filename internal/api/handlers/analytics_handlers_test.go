@@ -157,6 +157,13 @@ func TestGetExecutiveReport(t *testing.T) {
 
 		// Log the actual response fields for debugging
 		t.Logf("Executive report response keys: %v", getMapKeys(response))
+
+		// The forecast covers the three repositories not yet migrated, without per-repository estimates
+		analytics, _ := response["migration_analytics"].(map[string]any)
+		forecast, _ := analytics["forecast"].(map[string]any)
+		if forecast == nil || forecast["repositories"] != float64(3) || forecast["estimates"] != nil {
+			t.Errorf("Unexpected forecast %v", forecast)
+		}
 	})
 
 	t.Run("executive report with organization filter", func(t *testing.T) {
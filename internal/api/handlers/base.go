@@ -15,9 +15,13 @@ import (
 
 	"github.com/kuhlman-labs/github-migrator/internal/auth"
 	"github.com/kuhlman-labs/github-migrator/internal/azuredevops"
+	"github.com/kuhlman-labs/github-migrator/internal/batch"
+	"github.com/kuhlman-labs/github-migrator/internal/bitbucket"
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/discovery"
 	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/source"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
@@ -44,8 +48,16 @@ type Handler struct {
 	logger         *slog.Logger
 	destDualClient *github.DualClient
 	collector      *discovery.Collector
-	sourceType     string      // Source type: models.SourceTypeGitHub or models.SourceTypeAzureDevOps
-	adoHandler     *ADOHandler // ADO-specific handler (set by server if ADO is configured)
+	sourceType     string               // Source type: models.SourceTypeGitHub or models.SourceTypeAzureDevOps
+	adoHandler     *ADOHandler          // ADO-specific handler (set by server if ADO is configured)
+	instanceID     string               // ID of this server replica, reported by /health
+	rollbacker     RepositoryRollbacker // Rolls back completed migrations (nil until a destination is configured)
+	refRewriter    ReferenceRewriter    // Opens reference rewrite pull requests (nil until a destination is configured)
+	codeowners     CodeownersRewriter   // Rewrites CODEOWNERS on destinations (nil until a destination is configured)
+	collabGranter  CollaboratorGranter  // Re-grants collaborator permissions on destinations (nil until a destination is configured)
+	wavePlanner    WavePlanner          // Proposes dependency-aware migration waves
+	// Production batch starts need a request approved by a second admin
+	requireBatchApproval bool
 
 	// Discovery cancellation tracking
 	discoveryCancel map[int64]context.CancelFunc // progressID -> cancel function
@@ -57,6 +69,71 @@ func (h *Handler) SetADOHandler(adoHandler *ADOHandler) {
 	h.adoHandler = adoHandler
 }
 
+// RepositoryRollbacker rolls back completed migrations.
+// Implemented by migration.ExecutorFactory.
+type RepositoryRollbacker interface {
+	Rollback(ctx context.Context, repo *models.Repository, opts migration.RollbackOptions) (*migration.RollbackResult, error)
+}
+
+// SetRollbacker sets the rollback executor used by the rollback endpoints
+func (h *Handler) SetRollbacker(rollbacker RepositoryRollbacker) {
+	h.rollbacker = rollbacker
+}
+
+// ReferenceRewriter opens pull requests rewriting cross-repo references in migrated repositories.
+// Implemented by migration.ExecutorFactory.
+type ReferenceRewriter interface {
+	OpenReferencePR(ctx context.Context, repo *models.Repository) (*models.ReferencePullRequest, error)
+}
+
+// SetReferenceRewriter sets the executor used by the reference pull request endpoint
+func (h *Handler) SetReferenceRewriter(rewriter ReferenceRewriter) {
+	h.refRewriter = rewriter
+}
+
+// CodeownersRewriter rewrites CODEOWNERS in migrated repositories with the team and user mappings.
+// Implemented by migration.ExecutorFactory.
+type CodeownersRewriter interface {
+	RewriteCodeowners(ctx context.Context, repo *models.Repository, mode migration.CodeownersRewriteMode) (*models.CodeownersRewrite, error)
+}
+
+// SetCodeownersRewriter sets the executor used by the CODEOWNERS rewrite endpoint
+func (h *Handler) SetCodeownersRewriter(rewriter CodeownersRewriter) {
+	h.codeowners = rewriter
+}
+
+// CollaboratorGranter re-grants direct collaborator permissions in migrated repositories
+// through the user mappings. Implemented by migration.ExecutorFactory.
+type CollaboratorGranter interface {
+	GrantCollaborators(ctx context.Context, repo *models.Repository, mode migration.CollaboratorGrantMode) ([]*models.CollaboratorGrant, error)
+}
+
+// SetCollaboratorGranter sets the executor used by the collaborator grant endpoint
+func (h *Handler) SetCollaboratorGranter(granter CollaboratorGranter) {
+	h.collabGranter = granter
+}
+
+// WavePlanner proposes migration waves that keep dependent repositories together.
+// Implemented by batch.Organizer.
+type WavePlanner interface {
+	PlanWaves(ctx context.Context, criteria batch.WaveCriteria) (*batch.WavePlan, error)
+}
+
+// SetWavePlanner sets the planner used by the wave plan endpoint
+func (h *Handler) SetWavePlanner(planner WavePlanner) {
+	h.wavePlanner = planner
+}
+
+// SetRequireBatchApproval sets whether production batch starts need an approved request
+func (h *Handler) SetRequireBatchApproval(required bool) {
+	h.requireBatchApproval = required
+}
+
+// SetInstanceID sets the ID of this server replica reported by the health check
+func (h *Handler) SetInstanceID(instanceID string) {
+	h.instanceID = instanceID
+}
+
 // NewHandler creates a new Handler instance
 // sourceProvider can be nil if discovery is not needed
 // sourceBaseConfig is used for per-org client creation in enterprise discovery (can be nil for PAT-only mode)
@@ -107,11 +184,35 @@ func NewHandlerWithDataStore(db DataStore, logger *slog.Logger, sourceDualClient
 }
 
 // Health handles GET /health
+// Reports the replica's instance ID and the current holder of each leader lock.
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
-	h.sendJSON(w, http.StatusOK, map[string]string{
+	response := map[string]any{
 		"status": "healthy",
 		"time":   time.Now().Format(time.RFC3339),
-	})
+	}
+	if h.instanceID != "" {
+		response["instance_id"] = h.instanceID
+	}
+
+	if db, ok := h.db.(*storage.Database); ok {
+		locks, err := db.ListActiveLeaderLocks(r.Context())
+		if err != nil {
+			// Leader information is informational - the server is still healthy
+			h.logger.Warn("Failed to list leader locks for health check", "error", err)
+		} else {
+			leaders := make(map[string]any, len(locks))
+			for _, lock := range locks {
+				leaders[lock.Name] = map[string]any{
+					"owner_id":      lock.OwnerID,
+					"fencing_token": lock.FencingToken,
+					"expires_at":    lock.ExpiresAt.Format(time.RFC3339),
+				}
+			}
+			response["leaders"] = leaders
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, response)
 }
 
 // GetConfig handles GET /api/v1/config
@@ -124,8 +225,9 @@ func (h *Handler) GetConfig(w http.ResponseWriter, r *http.Request) {
 	}
 
 	response := map[string]any{
-		"source_type":  sourceType,
-		"auth_enabled": h.authConfig != nil && h.authConfig.Enabled,
+		"source_type":             sourceType,
+		"auth_enabled":            h.authConfig != nil && h.authConfig.Enabled,
+		"batch_approval_required": h.requireBatchApproval,
 	}
 
 	h.sendJSON(w, http.StatusOK, response)
@@ -377,6 +479,102 @@ func (h *Handler) getADOCollectorForSource(ctx context.Context, sourceID *int64)
 	return adoCollector, adoClient, nil
 }
 
+// getGitLabCollectorForSource returns a GitLab collector for the given source ID.
+// It creates a collector dynamically from the source's credentials in the database.
+func (h *Handler) getGitLabCollectorForSource(ctx context.Context, sourceID *int64) (*discovery.GitLabCollector, error) {
+	if sourceID == nil {
+		return nil, fmt.Errorf("no source_id provided for GitLab discovery")
+	}
+
+	// Get the database - need to type assert to *storage.Database
+	db, ok := h.db.(*storage.Database)
+	if !ok {
+		return nil, fmt.Errorf("database type assertion failed - cannot create dynamic GitLab collector")
+	}
+
+	src, err := db.GetSource(ctx, *sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source %d: %w", *sourceID, err)
+	}
+	if src == nil {
+		return nil, fmt.Errorf("source %d not found", *sourceID)
+	}
+
+	// Only GitLab sources are supported for GitLab discovery
+	if !src.IsGitLab() {
+		return nil, fmt.Errorf("source %d is not a GitLab source (type: %s)", *sourceID, src.Type)
+	}
+
+	client, err := gitlab.NewClient(gitlab.ClientConfig{
+		BaseURL: src.BaseURL,
+		Token:   src.Token,
+		Logger:  h.logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitLab client for source %d: %w", *sourceID, err)
+	}
+
+	provider, err := source.NewGitLabProvider(src.BaseURL, src.Token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitLab provider for source %d: %w", *sourceID, err)
+	}
+
+	collector := discovery.NewGitLabCollector(client, db, h.logger, provider)
+	collector.SetSourceID(sourceID)
+
+	h.logger.Info("Created dynamic GitLab collector", "source_id", *sourceID, "source_name", src.Name)
+
+	return collector, nil
+}
+
+// getBitbucketCollectorForSource returns a Bitbucket collector for the given source ID.
+// It creates a collector dynamically from the source's credentials in the database.
+func (h *Handler) getBitbucketCollectorForSource(ctx context.Context, sourceID *int64) (*discovery.BitbucketCollector, error) {
+	if sourceID == nil {
+		return nil, fmt.Errorf("no source_id provided for Bitbucket discovery")
+	}
+
+	// Get the database - need to type assert to *storage.Database
+	db, ok := h.db.(*storage.Database)
+	if !ok {
+		return nil, fmt.Errorf("database type assertion failed - cannot create dynamic Bitbucket collector")
+	}
+
+	src, err := db.GetSource(ctx, *sourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source %d: %w", *sourceID, err)
+	}
+	if src == nil {
+		return nil, fmt.Errorf("source %d not found", *sourceID)
+	}
+
+	// Only Bitbucket sources are supported for Bitbucket discovery
+	if !src.IsBitbucket() {
+		return nil, fmt.Errorf("source %d is not a Bitbucket source (type: %s)", *sourceID, src.Type)
+	}
+
+	client, err := bitbucket.NewClient(bitbucket.ClientConfig{
+		BaseURL: src.BaseURL,
+		Token:   src.Token,
+		Logger:  h.logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Bitbucket client for source %d: %w", *sourceID, err)
+	}
+
+	provider, err := source.NewBitbucketProvider(src.BaseURL, src.Token, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Bitbucket provider for source %d: %w", *sourceID, err)
+	}
+
+	collector := discovery.NewBitbucketCollector(client, db, h.logger, provider)
+	collector.SetSourceID(sourceID)
+
+	h.logger.Info("Created dynamic Bitbucket collector", "source_id", *sourceID, "source_name", src.Name)
+
+	return collector, nil
+}
+
 // PaginationParams holds parsed pagination parameters
 type PaginationParams struct {
 	Limit  int
@@ -471,6 +669,16 @@ func canMigrate(status string) bool {
 	return statusIn(status, migrationAllowedStatuses)
 }
 
+// canMigrateInBatch extends canMigrate for the batch's migration API.
+// Git mirror batches are not subject to GEI limits, so repositories that need
+// remediation for GEI can still be migrated.
+func canMigrateInBatch(status string, batch *models.Batch) bool {
+	if batch.UsesGitMirror() && status == string(models.StatusRemediationRequired) {
+		return true
+	}
+	return canMigrate(status)
+}
+
 func isEligibleForBatch(status string) bool {
 	return statusIn(status, batchEligibleStatuses)
 }
@@ -486,14 +694,20 @@ func getInitiatingUser(ctx context.Context) *string {
 	return &username
 }
 
-func isRepositoryEligibleForBatch(repo *models.Repository) (bool, string) {
+// isRepositoryEligibleForBatch checks whether a repository can be assigned to the batch.
+// GEI limits only apply when the batch does not use git mirror migrations.
+func isRepositoryEligibleForBatch(repo *models.Repository, batch *models.Batch) (bool, string) {
 	// Check if already in a batch
 	if repo.BatchID != nil {
 		return false, "repository is already assigned to a batch"
 	}
 
-	// Check if repository exceeds GitHub's 40 GiB size limit
-	if repo.HasOversizedRepository() {
+	if batch.UsesGitMirror() {
+		if repo.Status == string(models.StatusRemediationRequired) {
+			return true, ""
+		}
+	} else if repo.HasOversizedRepository() {
+		// Check if repository exceeds GitHub's 40 GiB size limit
 		return false, "repository exceeds GitHub's 40 GiB size limit and requires remediation"
 	}
 
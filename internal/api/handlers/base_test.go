@@ -1,12 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
@@ -44,7 +46,12 @@ func TestNewHandler(t *testing.T) {
 }
 
 func TestHealth(t *testing.T) {
-	h, _ := setupTestHandler(t)
+	h, db := setupTestHandler(t)
+	h.SetInstanceID("replica-a")
+
+	if _, err := db.AcquireLeaderLock(context.Background(), "batch_scheduler", "replica-b", time.Minute); err != nil {
+		t.Fatalf("Failed to acquire leader lock: %v", err)
+	}
 
 	req := httptest.NewRequest(http.MethodGet, "/health", nil)
 	w := httptest.NewRecorder()
@@ -55,18 +62,38 @@ func TestHealth(t *testing.T) {
 		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
 	}
 
-	var response map[string]string
+	var response struct {
+		Status     string `json:"status"`
+		Time       string `json:"time"`
+		InstanceID string `json:"instance_id"`
+		Leaders    map[string]struct {
+			OwnerID      string `json:"owner_id"`
+			FencingToken int64  `json:"fencing_token"`
+		} `json:"leaders"`
+	}
 	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
-	if response["status"] != "healthy" {
-		t.Errorf("Expected status 'healthy', got '%s'", response["status"])
+	if response.Status != "healthy" {
+		t.Errorf("Expected status 'healthy', got '%s'", response.Status)
 	}
 
-	if response["time"] == "" {
+	if response.Time == "" {
 		t.Error("Expected time to be set")
 	}
+
+	if response.InstanceID != "replica-a" {
+		t.Errorf("Expected instance_id 'replica-a', got '%s'", response.InstanceID)
+	}
+
+	scheduler, ok := response.Leaders["batch_scheduler"]
+	if !ok {
+		t.Fatalf("Expected batch_scheduler leader, got %+v", response.Leaders)
+	}
+	if scheduler.OwnerID != "replica-b" || scheduler.FencingToken != 1 {
+		t.Errorf("Expected batch_scheduler led by replica-b with token 1, got %+v", scheduler)
+	}
 }
 
 func TestSendJSON(t *testing.T) {
@@ -141,3 +168,64 @@ func TestCanMigrate(t *testing.T) {
 		})
 	}
 }
+
+func TestCanMigrateInBatch(t *testing.T) {
+	geiBatch := &models.Batch{MigrationAPI: models.MigrationAPIGEI}
+	gitBatch := &models.Batch{MigrationAPI: models.MigrationAPIGit}
+
+	tests := []struct {
+		name     string
+		status   models.MigrationStatus
+		batch    *models.Batch
+		expected bool
+	}{
+		{"pending in GEI batch", models.StatusPending, geiBatch, true},
+		{"remediation required in GEI batch", models.StatusRemediationRequired, geiBatch, false},
+		{"remediation required in git mirror batch", models.StatusRemediationRequired, gitBatch, true},
+		{"complete in git mirror batch", models.StatusComplete, gitBatch, false},
+		{"remediation required without batch", models.StatusRemediationRequired, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canMigrateInBatch(string(tt.status), tt.batch); got != tt.expected {
+				t.Errorf("canMigrateInBatch(%s) = %v, want %v", tt.status, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsRepositoryEligibleForBatch(t *testing.T) {
+	oversized := func(status models.MigrationStatus) *models.Repository {
+		r := &models.Repository{Status: string(status)}
+		r.SetHasOversizedRepository(true)
+		return r
+	}
+	batchID := int64(7)
+	geiBatch := &models.Batch{MigrationAPI: models.MigrationAPIGEI}
+	gitBatch := &models.Batch{MigrationAPI: models.MigrationAPIGit}
+
+	tests := []struct {
+		name         string
+		repo         *models.Repository
+		batch        *models.Batch
+		wantEligible bool
+	}{
+		{"pending repo", &models.Repository{Status: string(models.StatusPending)}, geiBatch, true},
+		{"already in a batch", &models.Repository{Status: string(models.StatusPending), BatchID: &batchID}, gitBatch, false},
+		{"oversized repo in GEI batch", oversized(models.StatusPending), geiBatch, false},
+		{"remediation required in GEI batch", oversized(models.StatusRemediationRequired), geiBatch, false},
+		{"oversized repo in git mirror batch", oversized(models.StatusPending), gitBatch, true},
+		{"remediation required in git mirror batch", oversized(models.StatusRemediationRequired), gitBatch, true},
+		{"complete repo in git mirror batch", &models.Repository{Status: string(models.StatusComplete)}, gitBatch, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eligible, reason := isRepositoryEligibleForBatch(tt.repo, tt.batch)
+			if eligible != tt.wantEligible {
+				t.Errorf("isRepositoryEligibleForBatch() = %v (%s), want %v", eligible, reason, tt.wantEligible)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/batch"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// approvalAnonymousActor is recorded in the approval log when a batch is started without a user
+const approvalAnonymousActor = "anonymous"
+
+// BatchApprovalRequest is the body of approval requests, approvals and rejections
+type BatchApprovalRequest struct {
+	Comment string `json:"comment,omitempty"` // Change ticket or note; required for rejections
+}
+
+// ListBatchApprovals handles GET /api/v1/batches/{id}/approvals
+// Returns the approval audit log of a batch, oldest first
+func (h *Handler) ListBatchApprovals(w http.ResponseWriter, r *http.Request) {
+	b, ok := h.approvalBatch(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	approvals, err := h.db.ListBatchApprovals(ctx, b.ID)
+	if err != nil {
+		if h.handleContextError(ctx, err, "list batch approvals", r) {
+			return
+		}
+		h.logger.Error("Failed to list batch approvals", "error", err, "batch_id", b.ID)
+		WriteError(w, ErrDatabaseFetch.WithDetails("batch approvals"))
+		return
+	}
+
+	state := "none"
+	if len(approvals) > 0 {
+		state = approvals[len(approvals)-1].Action
+	}
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"batch_id":          b.ID,
+		"approval_required": h.requireBatchApproval,
+		"state":             state,
+		"approvals":         approvals,
+	})
+}
+
+// ListPendingBatchApprovals handles GET /api/v1/batches/pending-approvals
+// Returns the batches awaiting approval with their requests
+func (h *Handler) ListPendingBatchApprovals(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	batches, err := h.db.ListBatches(ctx)
+	if err != nil {
+		if h.handleContextError(ctx, err, "list batches", r) {
+			return
+		}
+		h.logger.Error("Failed to list batches", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("batches"))
+		return
+	}
+
+	type pendingApproval struct {
+		Batch   *models.Batch         `json:"batch"`
+		Request *models.BatchApproval `json:"request"`
+	}
+	pending := []pendingApproval{}
+	for _, b := range batches {
+		if b.Status != models.BatchStatusReady && b.Status != models.BatchStatusPending {
+			continue
+		}
+		latest, err := batch.LatestApproval(ctx, h.db, b.ID)
+		if err != nil {
+			h.logger.Error("Failed to get batch approval", "error", err, "batch_id", b.ID)
+			WriteError(w, ErrDatabaseFetch.WithDetails("batch approvals"))
+			return
+		}
+		if latest != nil && latest.Action == models.BatchApprovalRequested {
+			pending = append(pending, pendingApproval{Batch: b, Request: latest})
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"pending": pending,
+		"total":   len(pending),
+	})
+}
+
+// RequestBatchApproval handles POST /api/v1/batches/{id}/approval-request
+// Submits a batch for approval of its production migration with a summary of its dry run
+func (h *Handler) RequestBatchApproval(w http.ResponseWriter, r *http.Request) {
+	b, ok := h.approvalBatch(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeBatchApprovalRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	repos, err := h.db.ListRepositories(ctx, map[string]any{"batch_id": b.ID})
+	if err != nil {
+		h.logger.Error("Failed to get batch repositories", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("repositories"))
+		return
+	}
+	repoFullNames := make([]string, len(repos))
+	for i, repo := range repos {
+		repoFullNames[i] = repo.FullName
+	}
+	if err := h.CheckRepositoriesAccess(ctx, repoFullNames); err != nil {
+		h.logger.Warn("Batch approval request access denied", "batch_id", b.ID, "error", err)
+		WriteError(w, ErrForbidden.WithDetails(err.Error()))
+		return
+	}
+
+	approval, err := batch.RequestApproval(ctx, h.db, b, approvalActor(ctx), optionalComment(req.Comment))
+	if !h.handleApprovalError(w, err, b) {
+		return
+	}
+
+	h.logger.Info("Batch submitted for approval", "batch_id", b.ID, "batch_name", b.Name, "requested_by", approval.Actor)
+	h.sendJSON(w, http.StatusCreated, approval)
+}
+
+// ApproveBatch handles POST /api/v1/batches/{id}/approve
+// Approves a batch awaiting approval; the approver must not be the requester
+func (h *Handler) ApproveBatch(w http.ResponseWriter, r *http.Request) {
+	h.reviewBatch(w, r, true)
+}
+
+// RejectBatch handles POST /api/v1/batches/{id}/reject
+// Rejects a batch awaiting approval with a reason
+func (h *Handler) RejectBatch(w http.ResponseWriter, r *http.Request) {
+	h.reviewBatch(w, r, false)
+}
+
+func (h *Handler) reviewBatch(w http.ResponseWriter, r *http.Request, approve bool) {
+	b, ok := h.approvalBatch(w, r)
+	if !ok {
+		return
+	}
+	req, ok := decodeBatchApprovalRequest(w, r)
+	if !ok {
+		return
+	}
+	if !approve && strings.TrimSpace(req.Comment) == "" {
+		WriteError(w, ErrMissingField.WithDetails("comment is required to reject a batch"))
+		return
+	}
+
+	ctx := r.Context()
+	approval, err := batch.ReviewApproval(ctx, h.db, b, approvalActor(ctx), approve, optionalComment(req.Comment))
+	if !h.handleApprovalError(w, err, b) {
+		return
+	}
+
+	h.logger.Info("Batch approval reviewed", "batch_id", b.ID, "batch_name", b.Name, "action", approval.Action, "reviewed_by", approval.Actor)
+	h.sendJSON(w, http.StatusCreated, approval)
+}
+
+// approvalBatch loads the batch named by the path, writing an error response if it cannot
+func (h *Handler) approvalBatch(w http.ResponseWriter, r *http.Request) (*models.Batch, bool) {
+	batchID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		WriteError(w, ErrInvalidField.WithDetails("Invalid batch ID"))
+		return nil, false
+	}
+
+	ctx := r.Context()
+	b, err := h.db.GetBatch(ctx, batchID)
+	if err != nil {
+		if h.handleContextError(ctx, err, "get batch", r) {
+			return nil, false
+		}
+		h.logger.Error("Failed to get batch", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("batch"))
+		return nil, false
+	}
+	if b == nil {
+		WriteError(w, ErrBatchNotFound)
+		return nil, false
+	}
+	return b, true
+}
+
+// handleApprovalError writes the response for an error from an approval step and reports whether
+// the step succeeded
+func (h *Handler) handleApprovalError(w http.ResponseWriter, err error, b *models.Batch) bool {
+	if err == nil {
+		return true
+	}
+	if batch.IsApprovalError(err) {
+		WriteError(w, ErrBatchApprovalState.WithDetails(err.Error()))
+		return false
+	}
+	h.logger.Error("Failed to record batch approval", "error", err, "batch_id", b.ID)
+	WriteError(w, ErrDatabaseSave.WithDetails("batch approval"))
+	return false
+}
+
+// latestBatchApproval returns the latest step of a batch's approval. Returns nil if there is none
+// or it cannot be read, since it is supplementary.
+func (h *Handler) latestBatchApproval(ctx context.Context, batchID int64) *models.BatchApproval {
+	approval, err := batch.LatestApproval(ctx, h.db, batchID)
+	if err != nil {
+		h.logger.Warn("Failed to get batch approval", "batch_id", batchID, "error", err)
+		return nil
+	}
+	return approval
+}
+
+// useBatchApproval writes an error response and returns false if approval is required and the
+// batch's production migration is not approved. Otherwise the approval is used up by this start.
+func (h *Handler) useBatchApproval(w http.ResponseWriter, r *http.Request, b *models.Batch) bool {
+	if !h.requireBatchApproval {
+		return true
+	}
+	ctx := r.Context()
+	actor := approvalActor(ctx)
+	if actor == "" {
+		actor = approvalAnonymousActor
+	}
+	if err := batch.UseApproval(ctx, h.db, b, actor); err != nil {
+		if batch.IsApprovalError(err) {
+			WriteError(w, ErrBatchApprovalRequired.WithDetails(err.Error()))
+			return false
+		}
+		h.logger.Error("Failed to use batch approval", "error", err, "batch_id", b.ID)
+		WriteError(w, ErrDatabaseSave.WithDetails("batch approval"))
+		return false
+	}
+	return true
+}
+
+// checkRepositoryApprovals writes an error response and returns false if approval is required and
+// any of the repositories is in a batch whose approved request does not cover it
+func (h *Handler) checkRepositoryApprovals(w http.ResponseWriter, r *http.Request, repos []*models.Repository) bool {
+	if !h.requireBatchApproval {
+		return true
+	}
+	ctx := r.Context()
+	batches := make(map[int64]*models.Batch)
+	for _, repo := range repos {
+		if repo.BatchID == nil {
+			continue
+		}
+		b, ok := batches[*repo.BatchID]
+		if !ok {
+			var err error
+			if b, err = h.db.GetBatch(ctx, *repo.BatchID); err != nil {
+				h.logger.Error("Failed to get batch", "error", err, "batch_id", *repo.BatchID)
+				WriteError(w, ErrDatabaseFetch.WithDetails("batch"))
+				return false
+			}
+			batches[*repo.BatchID] = b
+		}
+		if b == nil {
+			continue
+		}
+		if err := batch.CheckRepositoryApproval(ctx, h.db, b, repo.ID); err != nil {
+			if batch.IsApprovalError(err) {
+				WriteError(w, ErrBatchApprovalRequired.WithDetails(fmt.Sprintf("%s: %s", repo.FullName, err.Error())))
+				return false
+			}
+			h.logger.Error("Failed to check batch approval", "error", err, "batch_id", b.ID)
+			WriteError(w, ErrDatabaseFetch.WithDetails("batch approval"))
+			return false
+		}
+	}
+	return true
+}
+
+// decodeBatchApprovalRequest reads the optional body of an approval step
+func decodeBatchApprovalRequest(w http.ResponseWriter, r *http.Request) (BatchApprovalRequest, bool) {
+	var req BatchApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		WriteError(w, ErrInvalidJSON)
+		return req, false
+	}
+	return req, true
+}
+
+// approvalActor returns the login of the authenticated user, or "" if there is none
+func approvalActor(ctx context.Context) string {
+	if user := getInitiatingUser(ctx); user != nil {
+		return *user
+	}
+	return ""
+}
+
+func optionalComment(comment string) *string {
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return nil
+	}
+	return &comment
+}
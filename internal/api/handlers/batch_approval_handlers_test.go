@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/auth"
+	"github.com/kuhlman-labs/github-migrator/internal/batch"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestBatchApprovalWorkflow(t *testing.T) {
+	h, db := setupTestHandler(t)
+	h.SetRequireBatchApproval(true)
+	ctx := context.Background()
+
+	dryRunAt := time.Now().Add(-time.Hour)
+	b := &models.Batch{Name: "Wave 1", Type: "wave", Status: models.BatchStatusReady, LastDryRunAt: &dryRunAt, CreatedAt: time.Now()}
+	if err := db.CreateBatch(ctx, b); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	repo := &models.Repository{FullName: "org/api", SourceURL: "https://github.com/org/api", Status: string(models.StatusDryRunComplete), BatchID: &b.ID}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	id := fmt.Sprintf("%d", b.ID)
+
+	send := func(handler http.HandlerFunc, path, login string, body any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(payload))
+		req.SetPathValue("id", id)
+		if login != "" {
+			req = req.WithContext(context.WithValue(req.Context(), auth.ContextKeyUser, &auth.GitHubUser{Login: login}))
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	if w := send(h.StartBatch, "/api/v1/batches/"+id+"/start", "alice", nil); w.Code != http.StatusConflict {
+		t.Fatalf("Expected an unapproved batch not to start, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := send(h.RequestBatchApproval, "/api/v1/batches/"+id+"/approval-request", "alice", map[string]string{"comment": "CHG-1042"})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var request models.BatchApproval
+	if err := json.NewDecoder(w.Body).Decode(&request); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if request.Action != models.BatchApprovalRequested || request.Actor != "alice" || !strings.Contains(string(request.DryRunSummary), `"succeeded":1`) {
+		t.Errorf("Expected a request with the dry run summary, got %+v", request)
+	}
+
+	w = httptest.NewRecorder()
+	h.ListPendingBatchApprovals(w, httptest.NewRequest(http.MethodGet, "/api/v1/batches/pending-approvals", nil))
+	if !strings.Contains(w.Body.String(), `"total":1`) {
+		t.Errorf("Expected the batch to be awaiting approval, got %s", w.Body.String())
+	}
+
+	if w := send(h.ApproveBatch, "/api/v1/batches/"+id+"/approve", "alice", nil); w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "someone other than alice") {
+		t.Errorf("Expected the requester not to approve, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(h.RejectBatch, "/api/v1/batches/"+id+"/reject", "bob", nil); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected a rejection without a reason to be refused, got %d", w.Code)
+	}
+	if w := send(h.ApproveBatch, "/api/v1/batches/"+id+"/approve", "bob", map[string]string{"comment": "CAB approved"}); w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	if w := send(h.StartBatch, "/api/v1/batches/"+id+"/start", "alice", nil); w.Code != http.StatusAccepted {
+		t.Fatalf("Expected the approved batch to start, got %d: %s", w.Code, w.Body.String())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/batches/"+id+"/approvals", nil)
+	req.SetPathValue("id", id)
+	w = httptest.NewRecorder()
+	h.ListBatchApprovals(w, req)
+	var response struct {
+		State     string                  `json:"state"`
+		Approvals []*models.BatchApproval `json:"approvals"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.State != models.BatchApprovalStarted || len(response.Approvals) != 3 ||
+		response.Approvals[1].Actor != "bob" || response.Approvals[2].Actor != "alice" {
+		t.Errorf("Expected the request, approval and start in the audit log, got %+v", response)
+	}
+}
+
+func TestRetryBatchFailures_RequiresBatchApproval(t *testing.T) {
+	h, db := setupTestHandler(t)
+	h.SetRequireBatchApproval(true)
+	ctx := context.Background()
+
+	dryRunAt := time.Now().Add(-time.Hour)
+	b := &models.Batch{Name: "Wave 1", Type: "wave", Status: models.BatchStatusReady, LastDryRunAt: &dryRunAt, CreatedAt: time.Now()}
+	if err := db.CreateBatch(ctx, b); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	migrationFailed := &models.Repository{FullName: "org/api", SourceURL: "https://github.com/org/api", Status: string(models.StatusMigrationFailed), BatchID: &b.ID}
+	dryRunFailed := &models.Repository{FullName: "org/web", SourceURL: "https://github.com/org/web", Status: string(models.StatusDryRunFailed), BatchID: &b.ID}
+	for _, repo := range []*models.Repository{migrationFailed, dryRunFailed} {
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to save repository: %v", err)
+		}
+	}
+	migrationFailed, _ = db.GetRepository(ctx, migrationFailed.FullName)
+	dryRunFailed, _ = db.GetRepository(ctx, dryRunFailed.FullName)
+
+	retry := func(ids ...int64) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(RetryBatchRequest{RepositoryIDs: ids})
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/batches/%d/retry", b.ID), bytes.NewReader(payload))
+		req.SetPathValue("id", fmt.Sprintf("%d", b.ID))
+		w := httptest.NewRecorder()
+		h.RetryBatchFailures(w, req)
+		return w
+	}
+
+	if w := retry(); w.Code != http.StatusConflict {
+		t.Errorf("Expected a production retry to need the batch's approval, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo, _ := db.GetRepository(ctx, dryRunFailed.FullName); repo.Status != string(models.StatusDryRunFailed) {
+		t.Errorf("Expected a refused retry to queue nothing, got status %s", repo.Status)
+	}
+
+	// Failed dry runs are retried as dry runs, which are not gated
+	if w := retry(dryRunFailed.ID); w.Code != http.StatusAccepted {
+		t.Errorf("Expected a dry run retry not to need approval, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo, _ := db.GetRepository(ctx, dryRunFailed.FullName); repo.Status != string(models.StatusDryRunQueued) {
+		t.Errorf("Expected a failed dry run to be queued as a dry run, got status %s", repo.Status)
+	}
+
+	dryRunFailed.Status = string(models.StatusDryRunFailed)
+	if err := db.UpdateRepository(ctx, dryRunFailed); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+	if _, err := batch.RequestApproval(ctx, db, b, "alice", nil); err != nil {
+		t.Fatalf("RequestApproval() error = %v", err)
+	}
+	if _, err := batch.ReviewApproval(ctx, db, b, "bob", true, nil); err != nil {
+		t.Fatalf("ReviewApproval() error = %v", err)
+	}
+	if w := retry(migrationFailed.ID); w.Code != http.StatusAccepted {
+		t.Errorf("Expected an approved batch's repository to be retried, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo, _ := db.GetRepository(ctx, migrationFailed.FullName); repo.Status != string(models.StatusQueuedForMigration) {
+		t.Errorf("Expected a failed migration to be queued for migration, got status %s", repo.Status)
+	}
+}
+
+func TestStartMigration_RequiresBatchApproval(t *testing.T) {
+	h, db := setupTestHandler(t)
+	h.SetRequireBatchApproval(true)
+	ctx := context.Background()
+
+	dryRunAt := time.Now().Add(-time.Hour)
+	b := &models.Batch{Name: "Wave 1", Type: "wave", Status: models.BatchStatusReady, LastDryRunAt: &dryRunAt, CreatedAt: time.Now()}
+	if err := db.CreateBatch(ctx, b); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	batched := &models.Repository{FullName: "org/api", SourceURL: "https://github.com/org/api", Status: string(models.StatusDryRunComplete), BatchID: &b.ID}
+	unbatched := &models.Repository{FullName: "org/web", SourceURL: "https://github.com/org/web", Status: string(models.StatusDryRunComplete)}
+	for _, repo := range []*models.Repository{batched, unbatched} {
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to save repository: %v", err)
+		}
+	}
+	batched, _ = db.GetRepository(ctx, batched.FullName)
+	unbatched, _ = db.GetRepository(ctx, unbatched.FullName)
+
+	start := func(body StartMigrationRequest) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/migrations/start", bytes.NewReader(payload))
+		req = req.WithContext(context.WithValue(req.Context(), auth.ContextKeyUser, &auth.GitHubUser{Login: "alice"}))
+		w := httptest.NewRecorder()
+		h.StartMigration(w, req)
+		return w
+	}
+
+	if w := start(StartMigrationRequest{RepositoryIDs: []int64{batched.ID}}); w.Code != http.StatusConflict {
+		t.Errorf("Expected a production start by ID to need the batch's approval, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := start(StartMigrationRequest{FullNames: []string{unbatched.FullName, batched.FullName}}); w.Code != http.StatusConflict {
+		t.Errorf("Expected a production start by name to need the batch's approval, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo, _ := db.GetRepository(ctx, unbatched.FullName); repo.Status != string(models.StatusDryRunComplete) {
+		t.Errorf("Expected a refused start to queue nothing, got status %s", repo.Status)
+	}
+	if w := start(StartMigrationRequest{RepositoryIDs: []int64{unbatched.ID}}); w.Code != http.StatusAccepted {
+		t.Errorf("Expected a repository outside batches to start, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := start(StartMigrationRequest{RepositoryIDs: []int64{batched.ID}, DryRun: true}); w.Code != http.StatusAccepted {
+		t.Errorf("Expected a dry run not to need approval, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Once approved, the batch's repositories can be started one by one
+	batched.Status = string(models.StatusDryRunComplete)
+	if err := db.UpdateRepository(ctx, batched); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+	if _, err := batch.RequestApproval(ctx, db, b, "alice", nil); err != nil {
+		t.Fatalf("RequestApproval() error = %v", err)
+	}
+	if _, err := batch.ReviewApproval(ctx, db, b, "bob", true, nil); err != nil {
+		t.Fatalf("ReviewApproval() error = %v", err)
+	}
+	if w := start(StartMigrationRequest{RepositoryIDs: []int64{batched.ID}}); w.Code != http.StatusAccepted {
+		t.Errorf("Expected an approved batch's repository to start, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestHandleSelfServiceMigration_RequiresBatchApproval(t *testing.T) {
+	h, _ := setupTestHandler(t)
+	h.SetRequireBatchApproval(true)
+
+	payload, _ := json.Marshal(SelfServiceMigrationRequest{Repositories: []string{"org/api"}})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/self-service/migrate", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+	h.HandleSelfServiceMigration(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected a self-service production migration to need approval, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -260,7 +260,9 @@ type addRepoToBatchResult struct {
 }
 
 // addSingleRepoToBatch attempts to add a single repository to a batch
-func (h *BatchHandler) addSingleRepoToBatch(ctx context.Context, repoID, batchID int64) addRepoToBatchResult {
+func (h *BatchHandler) addSingleRepoToBatch(ctx context.Context, repoID int64, batch *models.Batch) addRepoToBatchResult {
+	batchID := batch.ID
+
 	repo, err := h.repoStore.GetRepositoryByID(ctx, repoID)
 	if err != nil {
 		return addRepoToBatchResult{err: fmt.Sprintf("Repository %d: not found", repoID)}
@@ -273,7 +275,7 @@ func (h *BatchHandler) addSingleRepoToBatch(ctx context.Context, repoID, batchID
 		return addRepoToBatchResult{err: fmt.Sprintf("Repository %s: already in another batch", repo.FullName)}
 	}
 
-	eligible, reason := isRepositoryEligibleForBatch(repo)
+	eligible, reason := isRepositoryEligibleForBatch(repo, batch)
 	if !eligible {
 		return addRepoToBatchResult{err: fmt.Sprintf("Repository %s: %s", repo.FullName, reason)}
 	}
@@ -334,7 +336,7 @@ func (h *BatchHandler) AddRepositoriesToBatch(w http.ResponseWriter, r *http.Req
 	errors := make([]string, 0)
 
 	for _, repoID := range addReq.RepositoryIDs {
-		result := h.addSingleRepoToBatch(ctx, repoID, id)
+		result := h.addSingleRepoToBatch(ctx, repoID, batch)
 		if result.added {
 			added++
 		} else if result.err != "" {
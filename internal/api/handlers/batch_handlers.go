@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/batch"
+	"github.com/kuhlman-labs/github-migrator/internal/forecast"
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 )
 
@@ -66,6 +70,102 @@ func (h *Handler) ListBatches(w http.ResponseWriter, r *http.Request) {
 	h.sendJSON(w, http.StatusOK, result)
 }
 
+// GetWavePlan proposes an organization of unbatched pending repositories into waves that keeps
+// repositories depending on each other together, explaining each placement. Nothing is changed.
+// GET /api/v1/batches/wave-plan?wave_size=50&sort_by=org
+func (h *Handler) GetWavePlan(w http.ResponseWriter, r *http.Request) {
+	if h.wavePlanner == nil {
+		WriteError(w, ErrServiceUnavailable.WithDetails("Wave planner not configured"))
+		return
+	}
+
+	criteria := batch.DefaultWaveCriteria()
+	if waveSize := r.URL.Query().Get("wave_size"); waveSize != "" {
+		size, err := strconv.Atoi(waveSize)
+		if err != nil || size <= 0 {
+			WriteError(w, ErrInvalidField.WithDetails("Invalid wave_size. Must be a positive number"))
+			return
+		}
+		criteria.WaveSize = size
+	}
+	if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+		if sortBy != "size" && sortBy != "name" && sortBy != "org" {
+			WriteError(w, ErrInvalidField.WithDetails("Invalid sort_by. Must be 'size', 'name' or 'org'"))
+			return
+		}
+		criteria.SortBy = sortBy
+	}
+
+	ctx := r.Context()
+	plan, err := h.wavePlanner.PlanWaves(ctx, criteria)
+	if err != nil {
+		if h.handleContextError(ctx, err, "plan waves", r) {
+			return
+		}
+		h.logger.Error("Failed to plan waves", "error", err)
+		WriteError(w, ErrInternal.WithDetails("Failed to plan waves"))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, plan)
+}
+
+// ChainBatches makes each listed batch depend on the one before it, so the scheduler starts them one
+// after another as each completes its migration with at most max_dependency_failures failures
+// POST /api/v1/batches/chain
+func (h *Handler) ChainBatches(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		BatchIDs              []int64 `json:"batch_ids"`
+		MaxDependencyFailures *int    `json:"max_dependency_failures,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, ErrInvalidJSON)
+		return
+	}
+	if len(req.BatchIDs) < 2 {
+		WriteError(w, ErrInvalidField.WithDetails("batch_ids must list at least two batches"))
+		return
+	}
+	if req.MaxDependencyFailures != nil && *req.MaxDependencyFailures < 0 {
+		WriteError(w, ErrInvalidField.WithDetails("max_dependency_failures cannot be negative"))
+		return
+	}
+
+	ctx := r.Context()
+	for _, id := range req.BatchIDs {
+		b, err := h.db.GetBatch(ctx, id)
+		if err != nil {
+			if h.handleContextError(ctx, err, "get batch", r) {
+				return
+			}
+			h.logger.Error("Failed to get batch", "error", err, "batch_id", id)
+			WriteError(w, ErrDatabaseFetch.WithDetails("batch"))
+			return
+		}
+		if b == nil {
+			WriteError(w, ErrBatchNotFound.WithDetails(fmt.Sprintf("Batch %d not found", id)))
+			return
+		}
+		if b.Status != models.BatchStatusReady && b.Status != models.BatchStatusPending {
+			WriteError(w, ErrBadRequest.WithDetails(fmt.Sprintf("Batch '%s' has already started; only 'pending' or 'ready' batches can be chained", b.Name)))
+			return
+		}
+	}
+
+	batches, err := batch.ChainBatches(ctx, h.db, req.BatchIDs, req.MaxDependencyFailures)
+	if err != nil {
+		h.logger.Warn("Failed to chain batches", "batch_ids", req.BatchIDs, "error", err)
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	h.logger.Info("Batches chained", "batch_ids", req.BatchIDs, "max_dependency_failures", req.MaxDependencyFailures)
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"batches": batches,
+		"message": fmt.Sprintf("Chained %d batches", len(batches)),
+	})
+}
+
 // CreateBatch handles POST /api/v1/batches
 func (h *Handler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 	var batch models.Batch
@@ -79,8 +179,8 @@ func (h *Handler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if batch.MigrationAPI != "" && batch.MigrationAPI != models.MigrationAPIGEI && batch.MigrationAPI != models.MigrationAPIELM {
-		WriteError(w, ErrInvalidField.WithDetails("Invalid migration_api. Must be 'GEI' or 'ELM'"))
+	if batch.MigrationAPI != "" && !models.IsValidMigrationAPI(batch.MigrationAPI) {
+		WriteError(w, ErrInvalidField.WithDetails("Invalid migration_api. Must be 'GEI', 'ELM' or 'GIT'"))
 		return
 	}
 
@@ -88,6 +188,26 @@ func (h *Handler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 		batch.MigrationAPI = models.MigrationAPIGEI
 	}
 
+	if details := deltaSyncError(&batch); details != "" {
+		WriteError(w, ErrInvalidField.WithDetails(details))
+		return
+	}
+
+	if err := normalizeCompletionActions(&batch); err != nil {
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	if details := h.dependencyError(r.Context(), &batch); details != "" {
+		WriteError(w, ErrInvalidField.WithDetails(details))
+		return
+	}
+
+	// A new batch has no repositories yet, so only windows for all organizations apply
+	if !h.scheduleInMigrationWindows(w, r, &batch) {
+		return
+	}
+
 	ctx := r.Context()
 	batch.CreatedAt = time.Now()
 	batch.Status = models.BatchStatusPending
@@ -108,6 +228,40 @@ func (h *Handler) CreateBatch(w http.ResponseWriter, r *http.Request) {
 	h.sendJSON(w, http.StatusCreated, batch)
 }
 
+// deltaSyncError returns why a batch cannot use delta sync, or an empty string if it can.
+// The cutover runs at the batch's scheduled time, and ELM migrations drive their own cutover.
+func deltaSyncError(batch *models.Batch) string {
+	if !batch.DeltaSync {
+		return ""
+	}
+	if batch.ScheduledAt == nil {
+		return "delta_sync requires scheduled_at to be set to the cutover window"
+	}
+	if batch.MigrationAPI == models.MigrationAPIELM {
+		return "delta_sync is not supported with the ELM migration API"
+	}
+	return ""
+}
+
+// normalizeCompletionActions validates a batch's completion actions and stores them in run order,
+// clearing them when none are selected
+func normalizeCompletionActions(batch *models.Batch) error {
+	if batch.CompletionActions == nil {
+		return nil
+	}
+	actions, err := migration.ParseCompletionActions(strings.Split(*batch.CompletionActions, ","))
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		batch.CompletionActions = nil
+		return nil
+	}
+	joined := strings.Join(actions, ",")
+	batch.CompletionActions = &joined
+	return nil
+}
+
 // GetBatch handles GET /api/v1/batches/{id}
 func (h *Handler) GetBatch(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -145,10 +299,56 @@ func (h *Handler) GetBatch(w http.ResponseWriter, r *http.Request) {
 		"batch":        batch,
 		"repositories": repos,
 	}
+	if batchForecast := h.forecastRepositories(ctx, map[string]any{"batch_id": batchID}); batchForecast != nil {
+		response["forecast"] = batchForecast
+	}
+	if dependency := h.batchDependency(ctx, batch); dependency != nil {
+		response["dependency"] = dependency
+	}
+	if approval := h.latestBatchApproval(ctx, batchID); approval != nil {
+		response["approval"] = approval
+	}
 
 	h.sendJSON(w, http.StatusOK, response)
 }
 
+// forecastRepositories estimates the migration duration of the repositories matching filters with
+// the configured workers. Returns nil if the forecast cannot be made, since it is supplementary.
+func (h *Handler) forecastRepositories(ctx context.Context, filters map[string]any) *forecast.Forecast {
+	filters["include_details"] = true
+	repos, err := h.db.ListRepositories(ctx, filters)
+	if err != nil {
+		h.logger.Warn("Failed to list repositories for duration forecast", "error", err)
+		return nil
+	}
+
+	result, err := forecast.NewForecaster(h.db).Forecast(ctx, repos, 0)
+	if err != nil {
+		h.logger.Warn("Failed to forecast migration duration", "error", err)
+		return nil
+	}
+	return result
+}
+
+// batchDependency evaluates whether a batch's dependency allows it to start. Returns nil if the
+// batch has no dependency or it cannot be evaluated, since it is supplementary.
+func (h *Handler) batchDependency(ctx context.Context, b *models.Batch) *batch.DependencyStatus {
+	dependency, err := batch.CheckDependency(ctx, h.db, b)
+	if err != nil {
+		h.logger.Warn("Failed to check batch dependency", "batch_id", b.ID, "error", err)
+		return nil
+	}
+	return dependency
+}
+
+// dependencyError returns why a batch's dependency cannot be saved, or an empty string if it can
+func (h *Handler) dependencyError(ctx context.Context, b *models.Batch) string {
+	if err := batch.ValidateDependency(ctx, h.db, b); err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
 // DryRunBatch handles POST /api/v1/batches/{id}/dry-run
 //
 //nolint:gocyclo // HTTP handler with multiple validation and processing steps
@@ -216,7 +416,8 @@ func (h *Handler) DryRunBatch(w http.ResponseWriter, r *http.Request) {
 			needsDryRun := repo.Status == string(models.StatusPending) ||
 				repo.Status == string(models.StatusDryRunFailed) ||
 				repo.Status == string(models.StatusMigrationFailed) ||
-				repo.Status == string(models.StatusRolledBack)
+				repo.Status == string(models.StatusRolledBack) ||
+				(batch.UsesGitMirror() && repo.Status == string(models.StatusRemediationRequired))
 
 			if !needsDryRun {
 				skippedCount++
@@ -356,6 +557,10 @@ func (h *Handler) StartBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.useBatchApproval(w, r, batch) {
+		return
+	}
+
 	priority := 0
 	if batch.Type == models.BatchTypePilot {
 		priority = 1
@@ -363,7 +568,7 @@ func (h *Handler) StartBatch(w http.ResponseWriter, r *http.Request) {
 
 	migrationIDs := make([]int64, 0, len(repos))
 	for _, repo := range repos {
-		if !canMigrate(repo.Status) {
+		if !canMigrateInBatch(repo.Status, batch) {
 			continue
 		}
 
@@ -442,14 +647,18 @@ func (h *Handler) UpdateBatch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var updates struct {
-		Name               *string    `json:"name,omitempty"`
-		Description        *string    `json:"description,omitempty"`
-		Type               *string    `json:"type,omitempty"`
-		ScheduledAt        *time.Time `json:"scheduled_at,omitempty"`
-		DestinationOrg     *string    `json:"destination_org,omitempty"`
-		MigrationAPI       *string    `json:"migration_api,omitempty"`
-		ExcludeReleases    *bool      `json:"exclude_releases,omitempty"`
-		ExcludeAttachments *bool      `json:"exclude_attachments,omitempty"`
+		Name                  *string    `json:"name,omitempty"`
+		Description           *string    `json:"description,omitempty"`
+		Type                  *string    `json:"type,omitempty"`
+		ScheduledAt           *time.Time `json:"scheduled_at,omitempty"`
+		DestinationOrg        *string    `json:"destination_org,omitempty"`
+		MigrationAPI          *string    `json:"migration_api,omitempty"`
+		ExcludeReleases       *bool      `json:"exclude_releases,omitempty"`
+		ExcludeAttachments    *bool      `json:"exclude_attachments,omitempty"`
+		DeltaSync             *bool      `json:"delta_sync,omitempty"`
+		CompletionActions     *string    `json:"completion_actions,omitempty"`      // Empty string clears them
+		DependsOnBatchID      *int64     `json:"depends_on_batch_id,omitempty"`     // 0 clears the dependency
+		MaxDependencyFailures *int       `json:"max_dependency_failures,omitempty"` // Negative removes the limit
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
@@ -457,8 +666,8 @@ func (h *Handler) UpdateBatch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if updates.MigrationAPI != nil && *updates.MigrationAPI != models.MigrationAPIGEI && *updates.MigrationAPI != models.MigrationAPIELM {
-		WriteError(w, ErrInvalidField.WithDetails("Invalid migration_api. Must be 'GEI' or 'ELM'"))
+	if updates.MigrationAPI != nil && !models.IsValidMigrationAPI(*updates.MigrationAPI) {
+		WriteError(w, ErrInvalidField.WithDetails("Invalid migration_api. Must be 'GEI', 'ELM' or 'GIT'"))
 		return
 	}
 
@@ -501,6 +710,47 @@ func (h *Handler) UpdateBatch(w http.ResponseWriter, r *http.Request) {
 	if updates.ExcludeAttachments != nil {
 		batch.ExcludeAttachments = *updates.ExcludeAttachments
 	}
+	if updates.DeltaSync != nil {
+		batch.DeltaSync = *updates.DeltaSync
+	}
+
+	if updates.CompletionActions != nil {
+		batch.CompletionActions = updates.CompletionActions
+	}
+	if updates.DependsOnBatchID != nil {
+		if *updates.DependsOnBatchID == 0 {
+			batch.DependsOnBatchID = nil
+			batch.MaxDependencyFailures = nil
+		} else {
+			batch.DependsOnBatchID = updates.DependsOnBatchID
+		}
+	}
+	if updates.MaxDependencyFailures != nil {
+		if *updates.MaxDependencyFailures < 0 {
+			batch.MaxDependencyFailures = nil
+		} else {
+			batch.MaxDependencyFailures = updates.MaxDependencyFailures
+		}
+	}
+
+	if details := deltaSyncError(batch); details != "" {
+		WriteError(w, ErrInvalidField.WithDetails(details))
+		return
+	}
+
+	if err := normalizeCompletionActions(batch); err != nil {
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	if details := h.dependencyError(ctx, batch); details != "" {
+		WriteError(w, ErrInvalidField.WithDetails(details))
+		return
+	}
+
+	if updates.ScheduledAt != nil && !h.scheduleInMigrationWindows(w, r, batch) {
+		return
+	}
 
 	if err := h.db.UpdateBatch(ctx, batch); err != nil {
 		h.logger.Error("Failed to update batch", "error", err)
@@ -687,7 +937,7 @@ func (h *Handler) AddRepositoriesToBatch(w http.ResponseWriter, r *http.Request)
 	ineligibleReasons := make(map[string]string)
 
 	for _, repo := range repos {
-		if eligible, reason := isRepositoryEligibleForBatch(repo); !eligible {
+		if eligible, reason := isRepositoryEligibleForBatch(repo, batch); !eligible {
 			ineligibleRepos = append(ineligibleRepos, repo.FullName)
 			ineligibleReasons[repo.FullName] = reason
 		} else {
@@ -952,10 +1202,28 @@ func (h *Handler) RetryBatchFailures(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Failed dry runs are retried as dry runs; failed migrations are production retries, which
+	// need the batch's approval when approval is required
+	var productionRetries []*models.Repository
+	for _, repo := range reposToRetry {
+		if repo.Status == string(models.StatusMigrationFailed) {
+			productionRetries = append(productionRetries, repo)
+		}
+	}
+	if !h.checkRepositoryApprovals(w, r, productionRetries) {
+		return
+	}
+
 	retriedIDs := make([]int64, 0, len(reposToRetry))
 	initiatingUser := getInitiatingUser(ctx)
 	for _, repo := range reposToRetry {
-		repo.Status = string(models.StatusQueuedForMigration)
+		message := "Migration retry queued"
+		if repo.Status == string(models.StatusDryRunFailed) {
+			repo.Status = string(models.StatusDryRunQueued)
+			message = "Dry run retry queued"
+		} else {
+			repo.Status = string(models.StatusQueuedForMigration)
+		}
 		if err := h.db.UpdateRepository(ctx, repo); err != nil {
 			h.logger.Error("Failed to update repository", "error", err, "repo", repo.FullName)
 			continue
@@ -966,7 +1234,7 @@ func (h *Handler) RetryBatchFailures(w http.ResponseWriter, r *http.Request) {
 			Level:        "INFO",
 			Phase:        "migration",
 			Operation:    "retry",
-			Message:      "Migration retry queued",
+			Message:      message,
 			InitiatedBy:  initiatingUser,
 		}
 		if err := h.db.CreateMigrationLog(ctx, logEntry); err != nil {
@@ -984,3 +1252,149 @@ func (h *Handler) RetryBatchFailures(w http.ResponseWriter, r *http.Request) {
 		"message":       fmt.Sprintf("Queued %d repositories for retry", len(retriedIDs)),
 	})
 }
+
+// RollbackBatch handles POST /api/v1/batches/{id}/rollback
+// Rolls back every completed repository in the batch (or the selected ones).
+// With dry_run set, returns the planned steps for each repository without changing anything.
+func (h *Handler) RollbackBatch(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	batchID, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		WriteError(w, ErrInvalidField.WithDetails("Invalid batch ID"))
+		return
+	}
+
+	ctx := r.Context()
+
+	batch, err := h.db.GetBatch(ctx, batchID)
+	if err != nil {
+		if h.handleContextError(ctx, err, "get batch", r) {
+			return
+		}
+		h.logger.Error("Failed to get batch", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("batch"))
+		return
+	}
+
+	if batch == nil {
+		WriteError(w, ErrBatchNotFound)
+		return
+	}
+
+	var req RollbackBatchRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		req = RollbackBatchRequest{}
+	}
+
+	mode, err := migration.ParseRollbackMode(req.Mode)
+	if err != nil {
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	var reposToRollback []*models.Repository
+
+	if len(req.RepositoryIDs) > 0 {
+		reposToRollback, err = h.db.GetRepositoriesByIDs(ctx, req.RepositoryIDs)
+		if err != nil {
+			h.logger.Error("Failed to get repositories", "error", err)
+			WriteError(w, ErrDatabaseFetch.WithDetails("repositories"))
+			return
+		}
+
+		for _, repo := range reposToRollback {
+			if repo.BatchID == nil || *repo.BatchID != batchID {
+				WriteError(w, ErrBadRequest.WithDetails(
+					fmt.Sprintf("Repository %s is not in this batch", repo.FullName)))
+				return
+			}
+			if repo.Status != string(models.StatusComplete) {
+				WriteError(w, ErrBadRequest.WithDetails(
+					fmt.Sprintf("Repository %s has not completed migration", repo.FullName)))
+				return
+			}
+		}
+	} else {
+		filters := map[string]any{
+			"batch_id": batchID,
+			"status":   string(models.StatusComplete),
+		}
+		reposToRollback, err = h.db.ListRepositories(ctx, filters)
+		if err != nil {
+			h.logger.Error("Failed to get completed repositories", "error", err)
+			WriteError(w, ErrDatabaseFetch.WithDetails("completed repositories"))
+			return
+		}
+	}
+
+	if len(reposToRollback) == 0 {
+		WriteError(w, ErrBadRequest.WithDetails("No completed repositories to roll back"))
+		return
+	}
+
+	repoFullNames := make([]string, len(reposToRollback))
+	for i, repo := range reposToRollback {
+		repoFullNames[i] = repo.FullName
+	}
+	if err := h.CheckRepositoriesAccess(ctx, repoFullNames); err != nil {
+		h.logger.Warn("Rollback batch access denied", "batch_id", batchID, "error", err)
+		WriteError(w, ErrForbidden.WithDetails(err.Error()))
+		return
+	}
+
+	if h.rollbacker == nil {
+		WriteError(w, ErrClientNotConfigured.WithDetails("A destination must be configured to roll back migrations"))
+		return
+	}
+
+	reason := req.Reason
+	if reason == "" {
+		reason = fmt.Sprintf("Rolled back with batch %s", batch.Name)
+	}
+
+	results := make([]*migration.RollbackResult, 0, len(reposToRollback))
+	errors := make([]string, 0)
+	rolledBackIDs := make([]int64, 0, len(reposToRollback))
+	initiatingUser := getInitiatingUser(ctx)
+	for _, repo := range reposToRollback {
+		result, err := h.rollbacker.Rollback(ctx, repo, migration.RollbackOptions{
+			Mode:        mode,
+			Reason:      reason,
+			DryRun:      req.DryRun,
+			InitiatedBy: initiatingUser,
+		})
+		if result != nil {
+			results = append(results, result)
+		}
+		if err != nil {
+			h.logger.Warn("Failed to rollback repository", "repo", repo.FullName, "batch_id", batchID, "error", err)
+			errors = append(errors, fmt.Sprintf("%s: %s", repo.FullName, err.Error()))
+			continue
+		}
+		rolledBackIDs = append(rolledBackIDs, repo.ID)
+	}
+
+	message := fmt.Sprintf("Rolled back %d of %d repositories", len(rolledBackIDs), len(reposToRollback))
+	if req.DryRun {
+		message = fmt.Sprintf("Rollback preview for %d repositories - no changes were made", len(reposToRollback))
+	}
+
+	statusCode := http.StatusOK
+	if len(errors) > 0 {
+		statusCode = http.StatusMultiStatus
+	}
+
+	h.sendJSON(w, statusCode, map[string]any{
+		"batch_id":          batchID,
+		"batch_name":        batch.Name,
+		"mode":              mode,
+		"dry_run":           req.DryRun,
+		"rolled_back_count": len(rolledBackIDs),
+		"rolled_back_ids":   rolledBackIDs,
+		"failed_count":      len(errors),
+		"results":           results,
+		"errors":            errors,
+		"message":           message,
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/batch"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 )
 
@@ -43,6 +44,73 @@ func TestListBatches(t *testing.T) {
 	}
 }
 
+func TestGetWavePlan(t *testing.T) {
+	h, db := setupTestHandler(t)
+	ctx := context.Background()
+
+	plan := func(query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/batches/wave-plan"+query, nil)
+		w := httptest.NewRecorder()
+		h.GetWavePlan(w, req)
+		return w
+	}
+
+	if w := plan(""); w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 without a planner, got %d", w.Code)
+	}
+
+	organizer, err := batch.NewOrganizer(batch.OrganizerConfig{Storage: db, Logger: h.logger})
+	if err != nil {
+		t.Fatalf("NewOrganizer() error = %v", err)
+	}
+	h.SetWavePlanner(organizer)
+
+	for _, name := range []string{"org/api", "org/lib", "org/web"} {
+		repo := &models.Repository{FullName: name, Source: "github", SourceURL: "https://github.com/" + name,
+			Status: string(models.StatusPending), DiscoveredAt: time.Now(), UpdatedAt: time.Now()}
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("SaveRepository() error = %v", err)
+		}
+	}
+	api, _ := db.GetRepository(ctx, "org/api")
+	if err := db.SaveRepositoryDependencies(ctx, api.ID, []*models.RepositoryDependency{
+		{DependencyFullName: "org/web", DependencyType: models.DependencyTypeSubmodule, DependencyURL: "https://github.com/org/web", IsLocal: true},
+	}); err != nil {
+		t.Fatalf("SaveRepositoryDependencies() error = %v", err)
+	}
+
+	for _, query := range []string{"?wave_size=0", "?wave_size=ten", "?sort_by=stars"} {
+		if w := plan(query); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for %s, got %d", query, w.Code)
+		}
+	}
+
+	w := plan("?wave_size=1&sort_by=name")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var result batch.WavePlan
+	if err := json.NewDecoder(w.Body).Decode(&result); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	var order []string
+	for _, wave := range result.Waves {
+		for _, placement := range wave.Repositories {
+			order = append(order, placement.FullName)
+			if placement.Reason == "" {
+				t.Errorf("Expected a reason for %s", placement.FullName)
+			}
+		}
+	}
+	if strings.Join(order, ",") != "org/lib,org/web,org/api" {
+		t.Errorf("Expected org/api after its dependency org/web, got %v", order)
+	}
+
+	if batches, _ := db.ListBatches(ctx); len(batches) != 0 {
+		t.Errorf("Expected planning not to create batches, got %d", len(batches))
+	}
+}
+
 //nolint:gocyclo // Test function with multiple test cases naturally has high complexity
 func TestCreateBatch(t *testing.T) {
 	h, _ := setupTestHandler(t)
@@ -139,6 +207,31 @@ func TestCreateBatch(t *testing.T) {
 		}
 	})
 
+	t.Run("delta sync batch", func(t *testing.T) {
+		cutover := time.Now().Add(72 * time.Hour)
+		tests := []struct {
+			name       string
+			batch      models.Batch
+			wantStatus int
+		}{
+			{"scheduled", models.Batch{Name: "Delta Scheduled", Type: "wave", DeltaSync: true, ScheduledAt: &cutover}, http.StatusCreated},
+			{"not scheduled", models.Batch{Name: "Delta Unscheduled", Type: "wave", DeltaSync: true}, http.StatusBadRequest},
+			{"ELM", models.Batch{Name: "Delta ELM", Type: "wave", DeltaSync: true, ScheduledAt: &cutover, MigrationAPI: models.MigrationAPIELM}, http.StatusBadRequest},
+		}
+
+		for _, tt := range tests {
+			body, _ := json.Marshal(tt.batch)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/batches", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			h.CreateBatch(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("%s: expected status %d, got %d: %s", tt.name, tt.wantStatus, w.Code, w.Body.String())
+			}
+		}
+	})
+
 	t.Run("empty batch name", func(t *testing.T) {
 		batch := models.Batch{
 			Name: "   ", // Empty/whitespace-only name
@@ -243,6 +336,13 @@ func TestGetBatch(t *testing.T) {
 
 	batch := &models.Batch{Name: "Test Batch", Type: "pilot", Status: "ready", CreatedAt: time.Now()}
 	_ = db.CreateBatch(ctx, batch)
+	for _, name := range []string{"org/pending", "org/migrated"} {
+		repo := &models.Repository{FullName: name, Status: string(models.StatusPending), BatchID: &batch.ID}
+		if name == "org/migrated" {
+			repo.Status = string(models.StatusComplete)
+		}
+		_ = db.SaveRepository(ctx, repo)
+	}
 
 	t.Run("existing batch", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/api/v1/batches/1", nil)
@@ -268,6 +368,11 @@ func TestGetBatch(t *testing.T) {
 		if !hasRepos {
 			t.Error("Expected repositories in response")
 		}
+
+		forecast, _ := response["forecast"].(map[string]any)
+		if forecast == nil || forecast["repositories"] != float64(1) || forecast["workers"] != float64(5) {
+			t.Errorf("Expected a forecast for the unmigrated repository, got %v", forecast)
+		}
 	})
 
 	t.Run("batch not found", func(t *testing.T) {
@@ -423,6 +528,67 @@ func TestUpdateBatch(t *testing.T) {
 		}
 	})
 
+	t.Run("delta sync requires a schedule", func(t *testing.T) {
+		send := func(updates map[string]any) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(updates)
+			req := httptest.NewRequest("PATCH", fmt.Sprintf("/api/v1/batches/%d", batch.ID), bytes.NewReader(body))
+			req.SetPathValue("id", fmt.Sprintf("%d", batch.ID))
+			w := httptest.NewRecorder()
+			h.UpdateBatch(w, req)
+			return w
+		}
+
+		if w := send(map[string]any{"delta_sync": true}); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d without scheduled_at, got %d", http.StatusBadRequest, w.Code)
+		}
+
+		w := send(map[string]any{"delta_sync": true, "scheduled_at": time.Now().Add(24 * time.Hour)})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		updated, err := db.GetBatch(ctx, batch.ID)
+		if err != nil {
+			t.Fatalf("Failed to get batch: %v", err)
+		}
+		if !updated.DeltaSync {
+			t.Error("Expected delta_sync to be saved")
+		}
+	})
+
+	t.Run("completion actions are validated and normalized", func(t *testing.T) {
+		send := func(updates map[string]any) *httptest.ResponseRecorder {
+			body, _ := json.Marshal(updates)
+			req := httptest.NewRequest("PATCH", fmt.Sprintf("/api/v1/batches/%d", batch.ID), bytes.NewReader(body))
+			req.SetPathValue("id", fmt.Sprintf("%d", batch.ID))
+			w := httptest.NewRecorder()
+			h.UpdateBatch(w, req)
+			return w
+		}
+
+		if w := send(map[string]any{"completion_actions": "archive,delete"}); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for an unknown action, got %d", http.StatusBadRequest, w.Code)
+		}
+
+		if w := send(map[string]any{"completion_actions": "archive, readme_notice"}); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		updated, err := db.GetBatch(ctx, batch.ID)
+		if err != nil {
+			t.Fatalf("Failed to get batch: %v", err)
+		}
+		if updated.CompletionActions == nil || *updated.CompletionActions != "readme_notice,archive" {
+			t.Errorf("Expected completion actions in run order, got %v", updated.CompletionActions)
+		}
+
+		if w := send(map[string]any{"completion_actions": ""}); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+		}
+		updated, _ = db.GetBatch(ctx, batch.ID)
+		if updated.CompletionActions != nil {
+			t.Errorf("Expected an empty list to clear the completion actions, got %q", *updated.CompletionActions)
+		}
+	})
+
 	t.Run("cannot update non-ready batch", func(t *testing.T) {
 		// Create a batch with in_progress status
 		ipBatch := &models.Batch{
@@ -831,3 +997,189 @@ func TestRetryBatchFailures(t *testing.T) {
 		}
 	})
 }
+
+func TestRollbackBatch(t *testing.T) {
+	h, db := setupTestHandler(t)
+	ctx := context.Background()
+
+	batch := &models.Batch{
+		Name:      "Test Batch",
+		Type:      "pilot",
+		Status:    "completed",
+		CreatedAt: time.Now(),
+	}
+	if err := db.CreateBatch(ctx, batch); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+
+	statuses := []models.MigrationStatus{models.StatusComplete, models.StatusComplete, models.StatusMigrationFailed}
+	repoIDs := make([]int64, 0, len(statuses))
+	for i, status := range statuses {
+		repo := &models.Repository{
+			FullName:     fmt.Sprintf("org/repo%d", i),
+			Source:       "ghes",
+			SourceURL:    fmt.Sprintf("https://github.com/org/repo%d", i),
+			Status:       string(status),
+			Visibility:   "private",
+			BatchID:      &batch.ID,
+			DiscoveredAt: time.Now(),
+			UpdatedAt:    time.Now(),
+		}
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("SaveRepository() error = %v", err)
+		}
+		saved, _ := db.GetRepository(ctx, repo.FullName)
+		repoIDs = append(repoIDs, saved.ID)
+	}
+
+	rollbacker := &fakeRollbacker{db: db}
+	h.SetRollbacker(rollbacker)
+
+	rollback := func(body map[string]any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest("POST", fmt.Sprintf("/api/v1/batches/%d/rollback", batch.ID), bytes.NewReader(data))
+		req.SetPathValue("id", fmt.Sprintf("%d", batch.ID))
+		w := httptest.NewRecorder()
+		h.RollbackBatch(w, req)
+		return w
+	}
+
+	t.Run("rejects repositories that have not completed", func(t *testing.T) {
+		w := rollback(map[string]any{"repository_ids": []int64{repoIDs[2]}})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("dry run previews every completed repository", func(t *testing.T) {
+		w := rollback(map[string]any{"mode": "delete", "dry_run": true})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var response map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if results, _ := response["results"].([]any); len(results) != 2 {
+			t.Errorf("Expected 2 rollback previews, got %v", response["results"])
+		}
+
+		repo, _ := db.GetRepositoryByID(ctx, repoIDs[0])
+		if repo.Status != string(models.StatusComplete) {
+			t.Errorf("Expected status to be unchanged by a dry run, got %s", repo.Status)
+		}
+	})
+
+	t.Run("rolls back every completed repository", func(t *testing.T) {
+		w := rollback(map[string]any{})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		var response map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if int(response["rolled_back_count"].(float64)) != 2 {
+			t.Errorf("Expected 2 repositories rolled back, got %v", response["rolled_back_count"])
+		}
+
+		for _, id := range repoIDs[:2] {
+			repo, _ := db.GetRepositoryByID(ctx, id)
+			if repo.Status != string(models.StatusRolledBack) {
+				t.Errorf("Expected %s to be rolled back, got %s", repo.FullName, repo.Status)
+			}
+		}
+		failed, _ := db.GetRepositoryByID(ctx, repoIDs[2])
+		if failed.Status != string(models.StatusMigrationFailed) {
+			t.Errorf("Expected failed repository to be untouched, got %s", failed.Status)
+		}
+	})
+
+	t.Run("nothing left to roll back", func(t *testing.T) {
+		w := rollback(map[string]any{})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func TestBatchDependencies(t *testing.T) {
+	h, db := setupTestHandler(t)
+	ctx := context.Background()
+
+	var waves []*models.Batch
+	for _, name := range []string{"wave_1", "wave_2", "wave_3"} {
+		b := &models.Batch{Name: name, Type: "wave", Status: models.BatchStatusReady, CreatedAt: time.Now()}
+		if err := db.CreateBatch(ctx, b); err != nil {
+			t.Fatalf("Failed to create batch: %v", err)
+		}
+		waves = append(waves, b)
+	}
+
+	chain := func(body map[string]any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		w := httptest.NewRecorder()
+		h.ChainBatches(w, httptest.NewRequest("POST", "/api/v1/batches/chain", bytes.NewReader(payload)))
+		return w
+	}
+	update := func(id int64, body map[string]any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest("PATCH", fmt.Sprintf("/api/v1/batches/%d", id), bytes.NewReader(payload))
+		req.SetPathValue("id", fmt.Sprintf("%d", id))
+		w := httptest.NewRecorder()
+		h.UpdateBatch(w, req)
+		return w
+	}
+
+	t.Run("chain batches", func(t *testing.T) {
+		if w := chain(map[string]any{"batch_ids": []int64{waves[0].ID}}); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d for a single batch, got %d", http.StatusBadRequest, w.Code)
+		}
+		w := chain(map[string]any{"batch_ids": []int64{waves[0].ID, waves[1].ID, waves[2].ID}, "max_dependency_failures": 2})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		saved, _ := db.GetBatch(ctx, waves[2].ID)
+		if saved.DependsOnBatchID == nil || *saved.DependsOnBatchID != waves[1].ID || *saved.MaxDependencyFailures != 2 {
+			t.Errorf("Expected wave_3 to depend on wave_2 allowing 2 failures, got %v, %v", saved.DependsOnBatchID, saved.MaxDependencyFailures)
+		}
+	})
+
+	t.Run("update rejects cycles and clears dependencies", func(t *testing.T) {
+		w := update(waves[0].ID, map[string]any{"depends_on_batch_id": waves[2].ID})
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "already depends on this batch") {
+			t.Errorf("Expected a cycle to be rejected, got %d: %s", w.Code, w.Body.String())
+		}
+		if w := update(waves[2].ID, map[string]any{"max_dependency_failures": -1}); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if saved, _ := db.GetBatch(ctx, waves[2].ID); saved.DependsOnBatchID == nil || saved.MaxDependencyFailures != nil {
+			t.Errorf("Expected only the failure limit to be removed, got %v, %v", saved.DependsOnBatchID, saved.MaxDependencyFailures)
+		}
+		if w := update(waves[1].ID, map[string]any{"depends_on_batch_id": 0}); w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		if saved, _ := db.GetBatch(ctx, waves[1].ID); saved.DependsOnBatchID != nil || saved.MaxDependencyFailures != nil {
+			t.Errorf("Expected the dependency to be cleared, got %v, %v", saved.DependsOnBatchID, saved.MaxDependencyFailures)
+		}
+	})
+
+	t.Run("batch details include the dependency state", func(t *testing.T) {
+		req := httptest.NewRequest("GET", fmt.Sprintf("/api/v1/batches/%d", waves[2].ID), nil)
+		req.SetPathValue("id", fmt.Sprintf("%d", waves[2].ID))
+		w := httptest.NewRecorder()
+		h.GetBatch(w, req)
+
+		var response struct {
+			Dependency *batch.DependencyStatus `json:"dependency"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Dependency == nil || response.Dependency.BatchName != "wave_2" || response.Dependency.State != batch.DependencyWaiting {
+			t.Errorf("Expected wave_3 to wait for wave_2, got %+v", response.Dependency)
+		}
+	})
+}
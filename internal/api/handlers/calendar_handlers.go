@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/calendar"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+const (
+	// defaultCalendarDays is the range of the migration calendar when no end is given
+	defaultCalendarDays = 30
+	// maxCalendarDays is the longest range the migration calendar returns
+	maxCalendarDays = 366
+)
+
+// calendarBatch is a scheduled batch on the migration calendar
+type calendarBatch struct {
+	ID            int64      `json:"id"`
+	Name          string     `json:"name"`
+	Status        string     `json:"status"`
+	ScheduledAt   time.Time  `json:"scheduled_at"`
+	Organizations []string   `json:"organizations"`
+	Open          bool       `json:"open"`                // Whether migrations are allowed at the scheduled time
+	Conflict      string     `json:"conflict,omitempty"`  // Why they are not
+	Refused       bool       `json:"refused,omitempty"`   // The batch will be unscheduled rather than deferred
+	NextOpen      *time.Time `json:"next_open,omitempty"` // When the batch will be deferred to
+}
+
+// ListMigrationWindows handles GET /api/v1/migration-windows
+func (h *Handler) ListMigrationWindows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	windows, err := h.db.ListMigrationWindows(ctx)
+	if err != nil {
+		if h.handleContextError(ctx, err, "list migration windows", r) {
+			return
+		}
+		h.logger.Error("Failed to list migration windows", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("migration windows"))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"windows": windows,
+		"total":   len(windows),
+	})
+}
+
+// CreateMigrationWindow handles POST /api/v1/migration-windows
+func (h *Handler) CreateMigrationWindow(w http.ResponseWriter, r *http.Request) {
+	var window models.MigrationWindow
+	if err := json.NewDecoder(r.Body).Decode(&window); err != nil {
+		WriteError(w, ErrInvalidJSON)
+		return
+	}
+	if err := calendar.Validate(&window); err != nil {
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	window.ID = 0
+	if err := h.db.CreateMigrationWindow(r.Context(), &window); err != nil {
+		h.logger.Error("Failed to create migration window", "error", err, "name", window.Name)
+		WriteError(w, ErrDatabaseSave.WithDetails("migration window"))
+		return
+	}
+
+	h.logger.Info("Migration window created", "window_id", window.ID, "name", window.Name, "kind", window.Kind)
+	h.sendJSON(w, http.StatusCreated, window)
+}
+
+// UpdateMigrationWindow handles PUT /api/v1/migration-windows/{id}, replacing the window
+func (h *Handler) UpdateMigrationWindow(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		WriteError(w, ErrInvalidID.WithDetails("migration window ID"))
+		return
+	}
+
+	ctx := r.Context()
+	existing, err := h.db.GetMigrationWindow(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get migration window", "error", err, "window_id", id)
+		WriteError(w, ErrDatabaseFetch.WithDetails("migration window"))
+		return
+	}
+	if existing == nil {
+		WriteError(w, ErrNotFound.WithDetails("migration window"))
+		return
+	}
+
+	var window models.MigrationWindow
+	if err := json.NewDecoder(r.Body).Decode(&window); err != nil {
+		WriteError(w, ErrInvalidJSON)
+		return
+	}
+	if err := calendar.Validate(&window); err != nil {
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	window.ID = existing.ID
+	window.CreatedAt = existing.CreatedAt
+	if err := h.db.UpdateMigrationWindow(ctx, &window); err != nil {
+		h.logger.Error("Failed to update migration window", "error", err, "window_id", id)
+		WriteError(w, ErrDatabaseUpdate.WithDetails("migration window"))
+		return
+	}
+
+	h.logger.Info("Migration window updated", "window_id", window.ID, "name", window.Name)
+	h.sendJSON(w, http.StatusOK, window)
+}
+
+// DeleteMigrationWindow handles DELETE /api/v1/migration-windows/{id}
+func (h *Handler) DeleteMigrationWindow(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		WriteError(w, ErrInvalidID.WithDetails("migration window ID"))
+		return
+	}
+
+	ctx := r.Context()
+	existing, err := h.db.GetMigrationWindow(ctx, id)
+	if err != nil {
+		h.logger.Error("Failed to get migration window", "error", err, "window_id", id)
+		WriteError(w, ErrDatabaseFetch.WithDetails("migration window"))
+		return
+	}
+	if existing == nil {
+		WriteError(w, ErrNotFound.WithDetails("migration window"))
+		return
+	}
+
+	if err := h.db.DeleteMigrationWindow(ctx, id); err != nil {
+		h.logger.Error("Failed to delete migration window", "error", err, "window_id", id)
+		WriteError(w, ErrDatabaseDelete.WithDetails("migration window"))
+		return
+	}
+
+	h.logger.Info("Migration window deleted", "window_id", id, "name", existing.Name)
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"message": "Migration window deleted successfully",
+	})
+}
+
+// GetMigrationCalendar lists the migration windows and the batches scheduled between from and to
+// (RFC 3339, defaulting to the next 30 days), with whether each batch falls inside a closed window
+// GET /api/v1/calendar
+func (h *Handler) GetMigrationCalendar(w http.ResponseWriter, r *http.Request) {
+	from := time.Now().UTC()
+	if value := r.URL.Query().Get("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			WriteError(w, ErrInvalidField.WithDetails("from must be an RFC 3339 time"))
+			return
+		}
+		from = parsed
+	}
+	to := from.AddDate(0, 0, defaultCalendarDays)
+	if value := r.URL.Query().Get("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			WriteError(w, ErrInvalidField.WithDetails("to must be an RFC 3339 time"))
+			return
+		}
+		to = parsed
+	}
+	if !to.After(from) || to.Sub(from) > maxCalendarDays*24*time.Hour {
+		WriteError(w, ErrInvalidField.WithDetails("to must be after from and at most 366 days later"))
+		return
+	}
+
+	ctx := r.Context()
+	cal, err := calendar.Load(ctx, h.db)
+	if err != nil {
+		if h.handleContextError(ctx, err, "load migration windows", r) {
+			return
+		}
+		h.logger.Error("Failed to load migration windows", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("migration windows"))
+		return
+	}
+
+	batches, err := h.db.ListBatches(ctx)
+	if err != nil {
+		if h.handleContextError(ctx, err, "list batches", r) {
+			return
+		}
+		h.logger.Error("Failed to list batches", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("batches"))
+		return
+	}
+
+	scheduled := []calendarBatch{}
+	for _, batch := range batches {
+		if batch.ScheduledAt == nil || batch.ScheduledAt.Before(from) || !batch.ScheduledAt.Before(to) {
+			continue
+		}
+		orgs, err := calendar.BatchOrganizations(ctx, h.db, batch.ID)
+		if err != nil {
+			h.logger.Error("Failed to list batch organizations", "error", err, "batch_id", batch.ID)
+			WriteError(w, ErrDatabaseFetch.WithDetails("batch repositories"))
+			return
+		}
+
+		entry := calendarBatch{
+			ID:            batch.ID,
+			Name:          batch.Name,
+			Status:        batch.Status,
+			ScheduledAt:   *batch.ScheduledAt,
+			Organizations: orgs,
+		}
+		if entry.Organizations == nil {
+			entry.Organizations = []string{}
+		}
+		decision := cal.Check(orgs, *batch.ScheduledAt)
+		entry.Open = decision.Open
+		if !decision.Open {
+			entry.Conflict = decision.Reason
+			entry.Refused = decision.Refused
+			if next, ok := cal.NextOpen(orgs, *batch.ScheduledAt); ok && !decision.Refused {
+				entry.NextOpen = &next
+			}
+		}
+		scheduled = append(scheduled, entry)
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"from":    from,
+		"to":      to,
+		"windows": cal.Occurrences(from, to),
+		"batches": scheduled,
+	})
+}
+
+// scheduleInMigrationWindows moves a batch's scheduled time to the next time migrations are
+// allowed for its organizations, if it falls inside a blackout or outside the allowed windows.
+// It writes an error response and returns false if the time is refused.
+func (h *Handler) scheduleInMigrationWindows(w http.ResponseWriter, r *http.Request, batch *models.Batch) bool {
+	if batch.ScheduledAt == nil {
+		return true
+	}
+
+	ctx := r.Context()
+	scheduledAt, err := calendar.ScheduleBatch(ctx, h.db, batch.ID, *batch.ScheduledAt)
+	if err != nil {
+		if calendar.IsClosed(err) {
+			WriteError(w, ErrMigrationWindowClosed.WithDetails(err.Error()))
+			return false
+		}
+		h.logger.Error("Failed to check migration windows", "error", err, "batch_id", batch.ID)
+		WriteError(w, ErrDatabaseFetch.WithDetails("migration windows"))
+		return false
+	}
+
+	if !scheduledAt.Equal(*batch.ScheduledAt) {
+		h.logger.Info("Deferring batch outside the migration windows",
+			"batch_id", batch.ID,
+			"requested_at", *batch.ScheduledAt,
+			"scheduled_at", scheduledAt)
+		batch.ScheduledAt = &scheduledAt
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestMigrationWindowCRUD(t *testing.T) {
+	h, _ := setupTestHandler(t)
+
+	send := func(handler http.HandlerFunc, method, path, id string, body any) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+		if id != "" {
+			req.SetPathValue("id", id)
+		}
+		w := httptest.NewRecorder()
+		handler(w, req)
+		return w
+	}
+
+	w := send(h.CreateMigrationWindow, "POST", "/api/v1/migration-windows", "", map[string]any{
+		"name": "Nightly", "kind": "allowed", "time_zone": "Europe/Berlin",
+		"weekdays": "mon,tue,wed,thu,fri", "start_time": "20:00", "end_time": "06:00",
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var created models.MigrationWindow
+	if err := json.NewDecoder(w.Body).Decode(&created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if created.ID == 0 || created.Policy != models.MigrationWindowPolicyDefer {
+		t.Errorf("Expected a saved window with the default policy, got %+v", created)
+	}
+
+	w = send(h.CreateMigrationWindow, "POST", "/api/v1/migration-windows", "", map[string]any{
+		"name": "Broken", "kind": "blackout", "weekdays": "mon", "start_time": "9am", "end_time": "17:00",
+	})
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "HH:MM") {
+		t.Errorf("Expected an invalid time to be rejected, got %d: %s", w.Code, w.Body.String())
+	}
+
+	id := fmt.Sprintf("%d", created.ID)
+	w = send(h.UpdateMigrationWindow, "PUT", "/api/v1/migration-windows/"+id, id, map[string]any{
+		"name": "Nightly", "kind": "allowed", "organization": "acme", "time_zone": "Europe/Berlin",
+		"weekdays": "mon,tue,wed,thu", "start_time": "20:00", "end_time": "06:00",
+	})
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	w = send(h.ListMigrationWindows, "GET", "/api/v1/migration-windows", "", nil)
+	var list struct {
+		Windows []models.MigrationWindow `json:"windows"`
+		Total   int                      `json:"total"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if list.Total != 1 || list.Windows[0].Organization == nil || *list.Windows[0].Organization != "acme" ||
+		*list.Windows[0].Weekdays != "mon,tue,wed,thu" {
+		t.Errorf("Expected the updated window, got %+v", list)
+	}
+
+	if w := send(h.DeleteMigrationWindow, "DELETE", "/api/v1/migration-windows/"+id, id, nil); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if w := send(h.DeleteMigrationWindow, "DELETE", "/api/v1/migration-windows/"+id, id, nil); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a deleted window, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestBatchSchedulingRespectsMigrationWindows(t *testing.T) {
+	h, db := setupTestHandler(t)
+	ctx := context.Background()
+
+	org := "acme"
+	freezeStart := time.Now().Add(24 * time.Hour).UTC().Truncate(time.Second)
+	freezeEnd := freezeStart.Add(48 * time.Hour)
+	auditEnd := freezeEnd.Add(24 * time.Hour)
+	for _, window := range []*models.MigrationWindow{
+		{Name: "Release freeze", Kind: models.MigrationWindowKindBlackout, Policy: models.MigrationWindowPolicyDefer,
+			TimeZone: "UTC", StartsAt: &freezeStart, EndsAt: &freezeEnd},
+		{Name: "Acme audit", Kind: models.MigrationWindowKindBlackout, Policy: models.MigrationWindowPolicyRefuse,
+			Organization: &org, TimeZone: "UTC", StartsAt: &freezeEnd, EndsAt: &auditEnd},
+	} {
+		if err := db.CreateMigrationWindow(ctx, window); err != nil {
+			t.Fatalf("Failed to create migration window: %v", err)
+		}
+	}
+
+	// Creating a batch inside the freeze defers it to the end of the freeze
+	body, _ := json.Marshal(map[string]any{"name": "Wave 1", "scheduled_at": freezeStart.Add(time.Hour)})
+	w := httptest.NewRecorder()
+	h.CreateBatch(w, httptest.NewRequest("POST", "/api/v1/batches", bytes.NewReader(body)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusCreated, w.Code, w.Body.String())
+	}
+	var batch models.Batch
+	if err := json.NewDecoder(w.Body).Decode(&batch); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if batch.ScheduledAt == nil || !batch.ScheduledAt.Equal(freezeEnd) {
+		t.Errorf("Expected the batch deferred to %v, got %v", freezeEnd, batch.ScheduledAt)
+	}
+
+	repo := &models.Repository{FullName: "acme/api", SourceURL: "https://github.com/acme/api", Status: string(models.StatusPending), BatchID: &batch.ID}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+
+	// With an acme repository, the batch cannot be rescheduled into the acme audit
+	body, _ = json.Marshal(map[string]any{"scheduled_at": freezeEnd.Add(time.Hour)})
+	req := httptest.NewRequest("PATCH", fmt.Sprintf("/api/v1/batches/%d", batch.ID), bytes.NewReader(body))
+	req.SetPathValue("id", fmt.Sprintf("%d", batch.ID))
+	w = httptest.NewRecorder()
+	h.UpdateBatch(w, req)
+	if w.Code != http.StatusConflict || !strings.Contains(w.Body.String(), "Acme audit") {
+		t.Errorf("Expected status %d naming the audit, got %d: %s", http.StatusConflict, w.Code, w.Body.String())
+	}
+
+	// The calendar shows the windows and the batch, which is now inside the acme audit
+	from := freezeStart.Add(-time.Hour).Format(time.RFC3339)
+	to := auditEnd.Add(time.Hour).Format(time.RFC3339)
+	w = httptest.NewRecorder()
+	h.GetMigrationCalendar(w, httptest.NewRequest("GET", "/api/v1/calendar?from="+from+"&to="+to, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response struct {
+		Windows []map[string]any `json:"windows"`
+		Batches []calendarBatch  `json:"batches"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Windows) != 2 || len(response.Batches) != 1 {
+		t.Fatalf("Expected 2 windows and 1 batch, got %+v", response)
+	}
+	entry := response.Batches[0]
+	if entry.Open || !entry.Refused || len(entry.Organizations) != 1 || entry.Organizations[0] != "acme" {
+		t.Errorf("Expected the batch to conflict with the acme audit, got %+v", entry)
+	}
+
+	w = httptest.NewRecorder()
+	h.GetMigrationCalendar(w, httptest.NewRequest("GET", "/api/v1/calendar?from="+to+"&to="+from, nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an inverted range, got %d", http.StatusBadRequest, w.Code)
+	}
+}
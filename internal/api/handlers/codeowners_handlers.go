@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
+)
+
+// getCodeownersRewrite returns the CODEOWNERS rewrite recorded for a repository
+// GET /api/v1/repositories/{fullName}/codeowners
+func (h *Handler) getCodeownersRewrite(w http.ResponseWriter, r *http.Request, fullName string) {
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	rewrite, err := h.db.GetCodeownersRewrite(ctx, repo.ID)
+	if err != nil {
+		h.logger.Error("Failed to get CODEOWNERS rewrite", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("CODEOWNERS rewrite"))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"codeowners": rewrite,
+	})
+}
+
+// RewriteRepositoryCodeowners rewrites the team and user handles in the destination repository's
+// CODEOWNERS file with the team and user mappings, committing it or opening a pull request
+// POST /api/v1/repositories/{fullName}/codeowners/rewrite
+func (h *Handler) RewriteRepositoryCodeowners(w http.ResponseWriter, r *http.Request) {
+	fullName, ok := r.Context().Value(cleanFullNameKey).(string)
+	if !ok || fullName == "" {
+		fullName = r.PathValue("fullName")
+	}
+	if fullName == "" {
+		WriteError(w, ErrMissingField.WithField("fullName"))
+		return
+	}
+
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	if !repo.IsMigrationComplete() {
+		WriteError(w, ErrBadRequest.WithDetails("CODEOWNERS can only be rewritten once the repository is migrated"))
+		return
+	}
+
+	var req RewriteCodeownersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		req = RewriteCodeownersRequest{}
+	}
+	if req.Mode == "" {
+		req.Mode = string(migration.CodeownersRewritePullRequest)
+	}
+	mode, err := migration.ParseCodeownersRewriteMode(req.Mode)
+	if err != nil || mode == migration.CodeownersRewriteOff {
+		WriteError(w, ErrInvalidField.WithDetails("Invalid mode. Must be 'commit' or 'pull_request'"))
+		return
+	}
+
+	if h.codeowners == nil {
+		WriteError(w, ErrClientNotConfigured.WithDetails("A destination must be configured to rewrite CODEOWNERS"))
+		return
+	}
+
+	rewrite, err := h.codeowners.RewriteCodeowners(ctx, repo, mode)
+	if err != nil {
+		h.logger.Error("Failed to rewrite CODEOWNERS", "repo", decodedFullName, "mode", mode, "error", err)
+		WriteError(w, ErrInternal.WithDetails(fmt.Sprintf("Failed to rewrite CODEOWNERS: %v", err)))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"codeowners": rewrite,
+	})
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// fakeCodeownersRewriter records the modes it was asked to rewrite CODEOWNERS with
+type fakeCodeownersRewriter struct {
+	db    DataStore
+	err   error
+	modes []migration.CodeownersRewriteMode
+}
+
+func (f *fakeCodeownersRewriter) RewriteCodeowners(ctx context.Context, repo *models.Repository, mode migration.CodeownersRewriteMode) (*models.CodeownersRewrite, error) {
+	f.modes = append(f.modes, mode)
+	if f.err != nil {
+		return nil, f.err
+	}
+	unmapped := "@carol"
+	rewrite := &models.CodeownersRewrite{
+		RepositoryID:     repo.ID,
+		Mode:             string(mode),
+		Status:           models.CodeownersStatusCommitted,
+		Path:             ".github/CODEOWNERS",
+		HandlesRewritten: 2,
+		Unmapped:         &unmapped,
+	}
+	return rewrite, f.db.SaveCodeownersRewrite(ctx, rewrite)
+}
+
+func TestCodeownersHandlers(t *testing.T) {
+	mock := NewMockDataStore()
+	migrated := createTestRepo("org/app", models.StatusComplete)
+	migrated.ID = 1
+	pending := createTestRepo("org/pending", models.StatusPending)
+	pending.ID = 2
+	for _, repo := range []*models.Repository{migrated, pending} {
+		mock.Repos[repo.FullName] = repo
+		mock.ReposByID[repo.ID] = repo
+	}
+	h := setupTestHandlerWithMock(t, mock)
+
+	rewrite := func(fullName, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/"+fullName+"/codeowners/rewrite", strings.NewReader(body))
+		req.SetPathValue("fullName", fullName+"/codeowners/rewrite")
+		w := httptest.NewRecorder()
+		h.HandleRepositoryAction(w, req)
+		return w
+	}
+	get := func(fullName string) map[string]*models.CodeownersRewrite {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+fullName+"/codeowners", nil)
+		req.SetPathValue("fullName", fullName+"/codeowners")
+		w := httptest.NewRecorder()
+		h.GetRepositoryOrDependencies(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string]*models.CodeownersRewrite
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	t.Run("no rewrite yet", func(t *testing.T) {
+		if response := get("org%2Fapp"); response["codeowners"] != nil {
+			t.Errorf("Expected no CODEOWNERS rewrite, got %+v", response["codeowners"])
+		}
+	})
+
+	t.Run("no destination configured", func(t *testing.T) {
+		if w := rewrite("org%2Fapp", ""); w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d", w.Code)
+		}
+	})
+
+	rewriter := &fakeCodeownersRewriter{db: mock}
+	h.SetCodeownersRewriter(rewriter)
+
+	t.Run("repository not migrated", func(t *testing.T) {
+		if w := rewrite("org%2Fpending", ""); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		for _, body := range []string{`{"mode":"off"}`, `{"mode":"push"}`} {
+			if w := rewrite("org%2Fapp", body); w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+			}
+		}
+		if len(rewriter.modes) != 0 {
+			t.Errorf("Expected no rewrite, got %v", rewriter.modes)
+		}
+	})
+
+	t.Run("rewrite", func(t *testing.T) {
+		if w := rewrite("org%2Fapp", ""); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if w := rewrite("org%2Fapp", `{"mode":"commit"}`); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		want := []migration.CodeownersRewriteMode{migration.CodeownersRewritePullRequest, migration.CodeownersRewriteCommit}
+		if len(rewriter.modes) != 2 || rewriter.modes[0] != want[0] || rewriter.modes[1] != want[1] {
+			t.Errorf("Expected modes %v, got %v", want, rewriter.modes)
+		}
+
+		record := get("org%2Fapp")["codeowners"]
+		if record == nil || record.Status != models.CodeownersStatusCommitted || record.HandlesRewritten != 2 {
+			t.Errorf("Unexpected CODEOWNERS rewrite %+v", record)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		rewriter.err = errors.New("branch is protected")
+		if w := rewrite("org%2Fapp", `{"mode":"commit"}`); w.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", w.Code)
+		}
+	})
+}
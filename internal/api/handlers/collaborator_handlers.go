@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
+)
+
+// getCollaboratorGrants returns the collaborator grants logged for a repository
+// GET /api/v1/repositories/{fullName}/collaborators
+func (h *Handler) getCollaboratorGrants(w http.ResponseWriter, r *http.Request, fullName string) {
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	grants, err := h.db.GetCollaboratorGrants(ctx, repo.ID)
+	if err != nil {
+		h.logger.Error("Failed to get collaborator grants", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("collaborator grants"))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"grants": grants,
+	})
+}
+
+// GrantRepositoryCollaborators re-grants the source repository's direct collaborator permissions
+// on the destination through the user mappings and logs the outcome for every collaborator
+// POST /api/v1/repositories/{fullName}/collaborators/grant
+func (h *Handler) GrantRepositoryCollaborators(w http.ResponseWriter, r *http.Request) {
+	fullName, ok := r.Context().Value(cleanFullNameKey).(string)
+	if !ok || fullName == "" {
+		fullName = r.PathValue("fullName")
+	}
+	if fullName == "" {
+		WriteError(w, ErrMissingField.WithField("fullName"))
+		return
+	}
+
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	if !repo.IsMigrationComplete() {
+		WriteError(w, ErrBadRequest.WithDetails("Collaborators can only be granted once the repository is migrated"))
+		return
+	}
+
+	var req GrantCollaboratorsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		req = GrantCollaboratorsRequest{}
+	}
+	if req.Mode == "" {
+		req.Mode = string(migration.CollaboratorGrantsAll)
+	}
+	mode, err := migration.ParseCollaboratorGrantMode(req.Mode)
+	if err != nil || mode == migration.CollaboratorGrantsOff {
+		WriteError(w, ErrInvalidField.WithDetails("Invalid mode. Must be 'all' or 'members_only'"))
+		return
+	}
+
+	if h.collabGranter == nil {
+		WriteError(w, ErrClientNotConfigured.WithDetails("A destination must be configured to grant collaborators"))
+		return
+	}
+
+	grants, err := h.collabGranter.GrantCollaborators(ctx, repo, mode)
+	if err != nil {
+		h.logger.Error("Failed to grant collaborators", "repo", decodedFullName, "mode", mode, "error", err)
+		WriteError(w, ErrInternal.WithDetails(fmt.Sprintf("Failed to grant collaborators: %v", err)))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"grants": grants,
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// fakeCollaboratorGranter records the modes it was asked to grant collaborators with
+type fakeCollaboratorGranter struct {
+	db    DataStore
+	err   error
+	modes []migration.CollaboratorGrantMode
+}
+
+func (f *fakeCollaboratorGranter) GrantCollaborators(ctx context.Context, repo *models.Repository, mode migration.CollaboratorGrantMode) ([]*models.CollaboratorGrant, error) {
+	f.modes = append(f.modes, mode)
+	if f.err != nil {
+		return nil, f.err
+	}
+	destLogin := "alice_emu"
+	grants := []*models.CollaboratorGrant{
+		{SourceLogin: "alice", DestinationLogin: &destLogin, Permission: "write", Status: models.CollaboratorGrantStatusGranted},
+		{SourceLogin: "ghost", Permission: "read", Status: models.CollaboratorGrantStatusUnmapped},
+	}
+	return grants, f.db.SaveCollaboratorGrants(ctx, repo.ID, grants)
+}
+
+func TestCollaboratorHandlers(t *testing.T) {
+	mock := NewMockDataStore()
+	migrated := createTestRepo("org/app", models.StatusComplete)
+	migrated.ID = 1
+	pending := createTestRepo("org/pending", models.StatusPending)
+	pending.ID = 2
+	for _, repo := range []*models.Repository{migrated, pending} {
+		mock.Repos[repo.FullName] = repo
+		mock.ReposByID[repo.ID] = repo
+	}
+	h := setupTestHandlerWithMock(t, mock)
+
+	grant := func(fullName, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/"+fullName+"/collaborators/grant", strings.NewReader(body))
+		req.SetPathValue("fullName", fullName+"/collaborators/grant")
+		w := httptest.NewRecorder()
+		h.HandleRepositoryAction(w, req)
+		return w
+	}
+	get := func(fullName string) []*models.CollaboratorGrant {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+fullName+"/collaborators", nil)
+		req.SetPathValue("fullName", fullName+"/collaborators")
+		w := httptest.NewRecorder()
+		h.GetRepositoryOrDependencies(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string][]*models.CollaboratorGrant
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response["grants"]
+	}
+
+	t.Run("no grants yet", func(t *testing.T) {
+		if grants := get("org%2Fapp"); len(grants) != 0 {
+			t.Errorf("Expected no grants, got %+v", grants)
+		}
+	})
+
+	t.Run("no destination configured", func(t *testing.T) {
+		if w := grant("org%2Fapp", ""); w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d", w.Code)
+		}
+	})
+
+	granter := &fakeCollaboratorGranter{db: mock}
+	h.SetCollaboratorGranter(granter)
+
+	t.Run("repository not migrated", func(t *testing.T) {
+		if w := grant("org%2Fpending", ""); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		for _, body := range []string{`{"mode":"off"}`, `{"mode":"everyone"}`} {
+			if w := grant("org%2Fapp", body); w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status 400 for %s, got %d", body, w.Code)
+			}
+		}
+		if len(granter.modes) != 0 {
+			t.Errorf("Expected no grants, got %v", granter.modes)
+		}
+	})
+
+	t.Run("grant", func(t *testing.T) {
+		if w := grant("org%2Fapp", ""); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if w := grant("org%2Fapp", `{"mode":"members_only"}`); w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		want := []migration.CollaboratorGrantMode{migration.CollaboratorGrantsAll, migration.CollaboratorGrantsMembersOnly}
+		if len(granter.modes) != 2 || granter.modes[0] != want[0] || granter.modes[1] != want[1] {
+			t.Errorf("Expected modes %v, got %v", want, granter.modes)
+		}
+
+		grants := get("org%2Fapp")
+		if len(grants) != 2 || grants[0].Status != models.CollaboratorGrantStatusGranted || grants[1].Status != models.CollaboratorGrantStatusUnmapped {
+			t.Errorf("Unexpected grants %+v", grants)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		granter.err = errors.New("source repository not found")
+		if w := grant("org%2Fapp", ""); w.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", w.Code)
+		}
+	})
+}
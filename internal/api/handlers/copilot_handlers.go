@@ -27,6 +27,8 @@ type CopilotHandler struct {
 	authorizer    *auth.Authorizer
 	authConfig    *config.AuthConfig
 
+	requireBatchApproval bool
+
 	// Persistent service instance to maintain session state
 	service   *copilot.Service
 	serviceMu sync.RWMutex
@@ -47,6 +49,11 @@ func (h *CopilotHandler) SetAuthorizer(authorizer *auth.Authorizer, authConfig *
 	h.authConfig = authConfig
 }
 
+// SetRequireBatchApproval sets whether production batch starts through Copilot need an approved request
+func (h *CopilotHandler) SetRequireBatchApproval(required bool) {
+	h.requireBatchApproval = required
+}
+
 // sessionError represents an error during session handling
 type sessionError struct {
 	StatusCode int
@@ -157,6 +164,8 @@ func (h *CopilotHandler) getOrCreateService(settings *models.Settings) *copilot.
 		SessionTimeoutMin: settings.CopilotSessionTimeoutMin,
 		Streaming:         settings.CopilotStreaming,
 		LogLevel:          settings.CopilotLogLevel,
+
+		RequireBatchApproval: h.requireBatchApproval,
 	}
 
 	if settings.CopilotCLIPath != nil {
@@ -679,10 +679,222 @@ func (h *Handler) updateSourceRepoCount(ctx context.Context, sourceID int64) {
 	if err := h.db.UpdateSourceRepositoryCount(ctx, sourceID); err != nil {
 		h.logger.Error("Failed to update source repository count", "error", err, "source_id", sourceID)
 	} else {
-		h.logger.Info("Updated source repository count after ADO discovery", "source_id", sourceID)
+		h.logger.Info("Updated source repository count after discovery", "source_id", sourceID)
 	}
 }
 
+// StartGitLabDiscovery handles POST /api/v1/gitlab/discover.
+// It walks the requested groups and all of their subgroups using the source's credentials.
+func (h *Handler) StartGitLabDiscovery(w http.ResponseWriter, r *http.Request) {
+	var req StartGitLabDiscoveryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, ErrInvalidJSON)
+		return
+	}
+	if req.SourceID == nil {
+		WriteError(w, ErrMissingField.WithField("source_id"))
+		return
+	}
+
+	groups := make([]string, 0, len(req.Groups))
+	for _, group := range req.Groups {
+		if group = strings.Trim(strings.TrimSpace(group), "/"); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		WriteError(w, ErrMissingField.WithField("groups"))
+		return
+	}
+
+	// Check for existing in-progress discovery
+	if h.hasActiveDiscovery(w) {
+		return
+	}
+
+	collector, err := h.getGitLabCollectorForSource(r.Context(), req.SourceID)
+	if err != nil {
+		h.logger.Error("Failed to get GitLab collector for source", "error", err, "source_id", req.SourceID)
+		WriteError(w, ErrClientNotConfigured.WithDetails(err.Error()))
+		return
+	}
+	if req.Workers > 0 {
+		collector.SetWorkers(req.Workers)
+	}
+
+	progress := &models.DiscoveryProgress{
+		DiscoveryType: models.DiscoveryTypeGitLabGroup,
+		Target:        strings.Join(groups, ","),
+		TotalOrgs:     len(groups),
+	}
+	if err := h.db.CreateDiscoveryProgress(progress); err != nil {
+		h.handleProgressError(w, err)
+		return
+	}
+	tracker := discovery.NewDBProgressTracker(h.db, h.logger, progress)
+	collector.SetProgressTracker(tracker)
+
+	h.logger.Info("Starting GitLab group discovery",
+		"groups", groups,
+		"workers", req.Workers,
+		"source_id", *req.SourceID,
+		"progress_id", progress.ID)
+
+	go h.runGitLabDiscovery(groups, *req.SourceID, progress.ID, collector, tracker)
+
+	h.sendJSON(w, http.StatusAccepted, map[string]any{
+		"message":     "GitLab group discovery started",
+		"groups":      groups,
+		"source_id":   *req.SourceID,
+		"progress_id": progress.ID,
+	})
+}
+
+// runGitLabDiscovery executes GitLab group discovery in background
+func (h *Handler) runGitLabDiscovery(groups []string, sourceID, progressID int64, collector *discovery.GitLabCollector, tracker *discovery.DBProgressTracker) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Register cancel function for this discovery
+	h.discoveryMu.Lock()
+	h.discoveryCancel[progressID] = cancel
+	h.discoveryMu.Unlock()
+
+	defer func() {
+		h.discoveryMu.Lock()
+		delete(h.discoveryCancel, progressID)
+		h.discoveryMu.Unlock()
+		cancel()
+		tracker.Flush()
+	}()
+
+	err := collector.DiscoverGitLabGroups(ctx, groups)
+	switch {
+	case ctx.Err() == context.Canceled:
+		h.logger.Info("GitLab group discovery cancelled", "groups", groups)
+		if dbErr := h.db.MarkDiscoveryCancelled(progressID); dbErr != nil {
+			h.logger.Error("Failed to mark discovery as cancelled", "error", dbErr)
+		}
+		return
+	case err != nil:
+		h.logger.Error("GitLab group discovery failed", "groups", groups, "error", err)
+		if markErr := h.db.MarkDiscoveryFailed(progressID, err.Error()); markErr != nil {
+			h.logger.Error("Failed to mark discovery as failed", "error", markErr)
+		}
+	default:
+		h.logger.Info("GitLab group discovery completed", "groups", groups)
+		if markErr := h.db.MarkDiscoveryComplete(progressID); markErr != nil {
+			h.logger.Error("Failed to mark discovery as complete", "error", markErr)
+		}
+	}
+	h.updateSourceRepoCount(ctx, sourceID)
+}
+
+// StartBitbucketDiscovery handles POST /api/v1/bitbucket/discover.
+// It discovers the requested projects, or every project visible to the source's token when none are given.
+func (h *Handler) StartBitbucketDiscovery(w http.ResponseWriter, r *http.Request) {
+	var req StartBitbucketDiscoveryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteError(w, ErrInvalidJSON)
+		return
+	}
+	if req.SourceID == nil {
+		WriteError(w, ErrMissingField.WithField("source_id"))
+		return
+	}
+
+	projects := make([]string, 0, len(req.Projects))
+	for _, project := range req.Projects {
+		if project = strings.TrimSpace(project); project != "" {
+			projects = append(projects, project)
+		}
+	}
+
+	// Check for existing in-progress discovery
+	if h.hasActiveDiscovery(w) {
+		return
+	}
+
+	collector, err := h.getBitbucketCollectorForSource(r.Context(), req.SourceID)
+	if err != nil {
+		h.logger.Error("Failed to get Bitbucket collector for source", "error", err, "source_id", req.SourceID)
+		WriteError(w, ErrClientNotConfigured.WithDetails(err.Error()))
+		return
+	}
+	if req.Workers > 0 {
+		collector.SetWorkers(req.Workers)
+	}
+
+	target := "all projects"
+	if len(projects) > 0 {
+		target = strings.Join(projects, ",")
+	}
+	progress := &models.DiscoveryProgress{
+		DiscoveryType: models.DiscoveryTypeBitbucketProject,
+		Target:        target,
+		TotalOrgs:     len(projects),
+	}
+	if err := h.db.CreateDiscoveryProgress(progress); err != nil {
+		h.handleProgressError(w, err)
+		return
+	}
+	tracker := discovery.NewDBProgressTracker(h.db, h.logger, progress)
+	collector.SetProgressTracker(tracker)
+
+	h.logger.Info("Starting Bitbucket project discovery",
+		"projects", projects,
+		"workers", req.Workers,
+		"source_id", *req.SourceID,
+		"progress_id", progress.ID)
+
+	go h.runBitbucketDiscovery(projects, *req.SourceID, progress.ID, collector, tracker)
+
+	h.sendJSON(w, http.StatusAccepted, map[string]any{
+		"message":     "Bitbucket project discovery started",
+		"projects":    projects,
+		"source_id":   *req.SourceID,
+		"progress_id": progress.ID,
+	})
+}
+
+// runBitbucketDiscovery executes Bitbucket project discovery in background
+func (h *Handler) runBitbucketDiscovery(projects []string, sourceID, progressID int64, collector *discovery.BitbucketCollector, tracker *discovery.DBProgressTracker) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Register cancel function for this discovery
+	h.discoveryMu.Lock()
+	h.discoveryCancel[progressID] = cancel
+	h.discoveryMu.Unlock()
+
+	defer func() {
+		h.discoveryMu.Lock()
+		delete(h.discoveryCancel, progressID)
+		h.discoveryMu.Unlock()
+		cancel()
+		tracker.Flush()
+	}()
+
+	err := collector.DiscoverBitbucketProjects(ctx, projects)
+	switch {
+	case ctx.Err() == context.Canceled:
+		h.logger.Info("Bitbucket project discovery cancelled", "projects", projects)
+		if dbErr := h.db.MarkDiscoveryCancelled(progressID); dbErr != nil {
+			h.logger.Error("Failed to mark discovery as cancelled", "error", dbErr)
+		}
+		return
+	case err != nil:
+		h.logger.Error("Bitbucket project discovery failed", "projects", projects, "error", err)
+		if markErr := h.db.MarkDiscoveryFailed(progressID, err.Error()); markErr != nil {
+			h.logger.Error("Failed to mark discovery as failed", "error", markErr)
+		}
+	default:
+		h.logger.Info("Bitbucket project discovery completed", "projects", projects)
+		if markErr := h.db.MarkDiscoveryComplete(progressID); markErr != nil {
+			h.logger.Error("Failed to mark discovery as complete", "error", markErr)
+		}
+	}
+	h.updateSourceRepoCount(ctx, sourceID)
+}
+
 // ADODiscoveryStatusDynamic handles GET /api/v1/ado/discovery/status when no static ADO handler is configured.
 func (h *Handler) ADODiscoveryStatusDynamic(w http.ResponseWriter, r *http.Request) {
 	// If we have a pre-configured ADO handler, delegate to it
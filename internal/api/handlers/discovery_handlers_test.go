@@ -536,3 +536,117 @@ func testForceResetDiscoveryRaceCondition(t *testing.T) {
 		t.Errorf("Expected discovery.target 'race-condition-org', got %v", discovery["target"])
 	}
 }
+
+func TestStartGitLabDiscovery_Validation(t *testing.T) {
+	h, db := setupTestHandler(t)
+
+	githubSource := &models.Source{Name: "GHES", Type: models.SourceConfigTypeGitHub, BaseURL: "https://ghes.example.com", Token: "token"}
+	if err := db.CreateSource(context.Background(), githubSource); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rawBody  string
+		reqBody  map[string]any
+		wantCode int
+	}{
+		{
+			name:     "invalid json",
+			rawBody:  "invalid json",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing source_id",
+			reqBody:  map[string]any{"groups": []string{"acme"}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing groups",
+			reqBody:  map[string]any{"source_id": githubSource.ID, "groups": []string{" ", "/"}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "non-GitLab source",
+			reqBody:  map[string]any{"source_id": githubSource.ID, "groups": []string{"acme"}},
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.rawBody != "" {
+				body = []byte(tt.rawBody)
+			} else {
+				body, _ = json.Marshal(tt.reqBody)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/gitlab/discover", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			h.StartGitLabDiscovery(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestStartBitbucketDiscovery_Validation(t *testing.T) {
+	h, db := setupTestHandler(t)
+
+	gitlabSource := &models.Source{Name: "GitLab", Type: models.SourceConfigTypeGitLab, BaseURL: "https://gitlab.example.com", Token: "token"}
+	if err := db.CreateSource(context.Background(), gitlabSource); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		rawBody  string
+		reqBody  map[string]any
+		wantCode int
+	}{
+		{
+			name:     "invalid json",
+			rawBody:  "invalid json",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "missing source_id",
+			reqBody:  map[string]any{"projects": []string{"APP"}},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "unknown source",
+			reqBody:  map[string]any{"source_id": 9999},
+			wantCode: http.StatusServiceUnavailable,
+		},
+		{
+			name:     "non-Bitbucket source",
+			reqBody:  map[string]any{"source_id": gitlabSource.ID, "projects": []string{"APP"}},
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			if tt.rawBody != "" {
+				body = []byte(tt.rawBody)
+			} else {
+				body, _ = json.Marshal(tt.reqBody)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/bitbucket/discover", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			h.StartBitbucketDiscovery(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+		})
+	}
+}
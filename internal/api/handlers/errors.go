@@ -139,6 +139,18 @@ var (
 		Code:    http.StatusConflict,
 		Message: "Repository is locked for migration",
 	}
+	ErrMigrationWindowClosed = APIError{
+		Code:    http.StatusConflict,
+		Message: "Migrations are not allowed at the scheduled time",
+	}
+	ErrBatchApprovalRequired = APIError{
+		Code:    http.StatusConflict,
+		Message: "Batch requires an approved request before its migration can start",
+	}
+	ErrBatchApprovalState = APIError{
+		Code:    http.StatusConflict,
+		Message: "Batch approval cannot be changed in its current state",
+	}
 
 	// 422 Unprocessable Entity errors
 	ErrUnprocessable = APIError{
@@ -116,6 +116,37 @@ func (u *HandlerUtils) CheckRepositoryAccess(ctx context.Context, repoFullName s
 	return u.checkIdentityMappedAccess(ctx, user, repoFullName)
 }
 
+// CheckAdminAccess validates that the user has Tier 1 (Admin) access, which some destructive
+// operations require even on repositories the user can otherwise migrate.
+func (u *HandlerUtils) CheckAdminAccess(ctx context.Context) error {
+	// If auth is not enabled, allow access
+	if u.authConfig == nil || !u.authConfig.Enabled {
+		return nil
+	}
+
+	user, hasUser := auth.GetUserFromContext(ctx)
+	token, hasToken := auth.GetTokenFromContext(ctx)
+
+	if !hasUser || !hasToken {
+		return fmt.Errorf("authentication required")
+	}
+
+	destURL := u.destBaseURL
+	if destURL == "" {
+		destURL = "https://api.github.com"
+	}
+	authorizer := auth.NewAuthorizer(u.getEffectiveAuthConfig(), u.logger, destURL)
+
+	hasFullAccess, _, err := authorizer.CheckDestinationMigrationRights(ctx, user, token)
+	if err != nil {
+		return fmt.Errorf("failed to verify administrator access: %w", err)
+	}
+	if !hasFullAccess {
+		return fmt.Errorf("administrator access required")
+	}
+	return nil
+}
+
 // checkIdentityMappedAccess checks if a user can access a repository via identity mapping (Tier 2: Self-Service)
 // The user's destination GitHub account must be mapped to a source identity,
 // and that source identity must have admin access on the repository.
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 )
 
@@ -74,6 +75,11 @@ func (h *Handler) StartMigration(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Production migrations of batch repositories need the batch's approval
+	if !req.DryRun && !h.checkRepositoryApprovals(w, r, repos) {
+		return
+	}
+
 	migrationIDs := make([]int64, 0, len(repos))
 	for _, repo := range repos {
 		if !canMigrate(repo.Status) {
@@ -414,6 +420,9 @@ func (h *Handler) rollbackRepositoryBatch(ctx context.Context, repo *models.Repo
 	if repo.Status != string(models.StatusComplete) {
 		return fmt.Errorf("only completed migrations can be rolled back (current status: %s)", repo.Status)
 	}
+	if h.rollbacker == nil {
+		return fmt.Errorf("a destination must be configured to roll back migrations")
+	}
 
 	reasonMessage := reason
 	if reasonMessage == "" {
@@ -423,7 +432,12 @@ func (h *Handler) rollbackRepositoryBatch(ctx context.Context, repo *models.Repo
 		reasonMessage = fmt.Sprintf("%s (by %s)", reasonMessage, *initiatingUser)
 	}
 
-	if err := h.db.RollbackRepository(ctx, repo.FullName, reasonMessage); err != nil {
+	// Batch status updates use the default (archive) mode so the destination is never deleted in bulk by accident
+	if _, err := h.rollbacker.Rollback(ctx, repo, migration.RollbackOptions{
+		Mode:        migration.RollbackArchive,
+		Reason:      reasonMessage,
+		InitiatedBy: initiatingUser,
+	}); err != nil {
 		return fmt.Errorf("failed to rollback: %w", err)
 	}
 
@@ -613,6 +627,12 @@ func (h *Handler) HandleSelfServiceMigration(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Self-service batches start as soon as they are created, before anyone could approve them
+	if !req.DryRun && h.requireBatchApproval {
+		WriteError(w, ErrBatchApprovalRequired.WithDetails("self-service production migrations are unavailable while batch approval is required; run a dry run, then submit its batch for approval"))
+		return
+	}
+
 	h.logger.Info("Processing self-service migration request",
 		"repo_count", len(req.Repositories),
 		"dry_run", req.DryRun,
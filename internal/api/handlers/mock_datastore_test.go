@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -23,11 +24,17 @@ type MockDataStore struct {
 	MigrationHistory map[int64][]*models.MigrationHistory
 	MigrationLogs    map[int64][]*models.MigrationLog
 	Dependencies     map[int64][]*models.RepositoryDependency
+	Secrets          map[int64][]*models.RepositorySecret
+	Webhooks         map[int64][]*models.RepositoryWebhook
+	ReferencePRs     map[int64]*models.ReferencePullRequest
+	Codeowners       map[int64]*models.CodeownersRewrite
+	CollabGrants     map[int64][]*models.CollaboratorGrant
 	Users            map[string]*models.GitHubUser
 	UserMappings     map[string]*models.UserMapping
 	UserMannequins   map[string]*models.UserMannequin // key: "source_login/mannequin_org"
 	Teams            map[string]*models.GitHubTeam    // key: "org/slug"
 	TeamMappings     map[string]*models.TeamMapping
+	TeamMemberships  []*models.TeamMembershipSync
 	ADOProjects      map[string]*models.ADOProject // key: "org/project"
 
 	// Auto-increment counters
@@ -72,6 +79,11 @@ func NewMockDataStore() *MockDataStore {
 		MigrationHistory: make(map[int64][]*models.MigrationHistory),
 		MigrationLogs:    make(map[int64][]*models.MigrationLog),
 		Dependencies:     make(map[int64][]*models.RepositoryDependency),
+		Secrets:          make(map[int64][]*models.RepositorySecret),
+		Webhooks:         make(map[int64][]*models.RepositoryWebhook),
+		ReferencePRs:     make(map[int64]*models.ReferencePullRequest),
+		Codeowners:       make(map[int64]*models.CodeownersRewrite),
+		CollabGrants:     make(map[int64][]*models.CollaboratorGrant),
 		Users:            make(map[string]*models.GitHubUser),
 		UserMappings:     make(map[string]*models.UserMapping),
 		UserMannequins:   make(map[string]*models.UserMannequin),
@@ -411,6 +423,233 @@ func (m *MockDataStore) GetAllLocalDependencyPairs(_ context.Context, _ []string
 	return []storage.DependencyPair{}, nil
 }
 
+// ============================================================================
+// Secrets Checklist Operations
+// ============================================================================
+
+func (m *MockDataStore) SaveRepositorySecrets(_ context.Context, repoID int64, secrets []*models.RepositorySecret) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Secrets[repoID] = secrets
+	return nil
+}
+
+func (m *MockDataStore) GetRepositorySecrets(_ context.Context, repoID int64) ([]*models.RepositorySecret, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	secrets := m.Secrets[repoID]
+	if secrets == nil {
+		secrets = []*models.RepositorySecret{}
+	}
+	return secrets, nil
+}
+
+func (m *MockDataStore) GetSecretsChecklist(_ context.Context) ([]storage.SecretChecklistEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	entries := []storage.SecretChecklistEntry{}
+	for repoID, secrets := range m.Secrets {
+		repo := m.ReposByID[repoID]
+		if repo == nil {
+			continue
+		}
+		for _, secret := range secrets {
+			entries = append(entries, storage.SecretChecklistEntry{
+				Repository:         repo.FullName,
+				Environment:        secret.Environment,
+				Name:               secret.Name,
+				PlaceholderCreated: secret.PlaceholderCreated,
+			})
+		}
+	}
+	return entries, nil
+}
+
+// ============================================================================
+// Webhook Operations
+// ============================================================================
+
+func (m *MockDataStore) SaveRepositoryWebhooks(_ context.Context, repoID int64, webhooks []*models.RepositoryWebhook) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, webhook := range webhooks {
+		webhook.ID = repoID*1000 + int64(i) + 1
+		webhook.RepositoryID = repoID
+	}
+	m.Webhooks[repoID] = webhooks
+	return nil
+}
+
+func (m *MockDataStore) GetRepositoryWebhooks(_ context.Context, repoID int64) ([]*models.RepositoryWebhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	webhooks := m.Webhooks[repoID]
+	if webhooks == nil {
+		webhooks = []*models.RepositoryWebhook{}
+	}
+	return webhooks, nil
+}
+
+func (m *MockDataStore) GetPendingWebhooks(_ context.Context, webhookIDs, repoIDs []int64) ([]storage.PendingWebhook, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	pending := []storage.PendingWebhook{}
+	for repoID, webhooks := range m.Webhooks {
+		if len(repoIDs) > 0 && !slices.Contains(repoIDs, repoID) {
+			continue
+		}
+		repo := m.ReposByID[repoID]
+		if repo == nil {
+			continue
+		}
+		destination := ""
+		if repo.DestinationFullName != nil {
+			destination = *repo.DestinationFullName
+		}
+		for _, webhook := range webhooks {
+			if webhook.Status != models.WebhookStatusPending || webhook.DestinationHookID == nil {
+				continue
+			}
+			if len(webhookIDs) > 0 && !slices.Contains(webhookIDs, webhook.ID) {
+				continue
+			}
+			pending = append(pending, storage.PendingWebhook{
+				ID:                    webhook.ID,
+				RepositoryID:          repoID,
+				Repository:            repo.FullName,
+				DestinationRepository: destination,
+				DestinationHookID:     *webhook.DestinationHookID,
+				URL:                   webhook.URL,
+			})
+		}
+	}
+	return pending, nil
+}
+
+func (m *MockDataStore) MarkWebhookActivated(_ context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, webhooks := range m.Webhooks {
+		for _, webhook := range webhooks {
+			if webhook.ID == id {
+				now := time.Now()
+				webhook.Status = models.WebhookStatusActive
+				webhook.ActivatedAt = &now
+				webhook.Error = nil
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockDataStore) SetWebhookError(_ context.Context, id int64, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, webhooks := range m.Webhooks {
+		for _, webhook := range webhooks {
+			if webhook.ID == id {
+				webhook.Error = &message
+			}
+		}
+	}
+	return nil
+}
+
+// ============================================================================
+// Reference Pull Request Operations
+// ============================================================================
+
+func (m *MockDataStore) SaveReferencePullRequest(_ context.Context, pr *models.ReferencePullRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	pr.ID = pr.RepositoryID
+	m.ReferencePRs[pr.RepositoryID] = pr
+	return nil
+}
+
+func (m *MockDataStore) GetReferencePullRequest(_ context.Context, repoID int64) (*models.ReferencePullRequest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ReferencePRs[repoID], nil
+}
+
+// ============================================================================
+// CODEOWNERS Rewrite Operations
+// ============================================================================
+
+func (m *MockDataStore) SaveCodeownersRewrite(_ context.Context, rewrite *models.CodeownersRewrite) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rewrite.ID = rewrite.RepositoryID
+	m.Codeowners[rewrite.RepositoryID] = rewrite
+	return nil
+}
+
+func (m *MockDataStore) GetCodeownersRewrite(_ context.Context, repoID int64) (*models.CodeownersRewrite, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.Codeowners[repoID], nil
+}
+
+// ============================================================================
+// Collaborator Grant Operations
+// ============================================================================
+
+func (m *MockDataStore) SaveCollaboratorGrants(_ context.Context, repoID int64, grants []*models.CollaboratorGrant) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, grant := range grants {
+		grant.RepositoryID = repoID
+	}
+	m.CollabGrants[repoID] = grants
+	return nil
+}
+
+func (m *MockDataStore) GetCollaboratorGrants(_ context.Context, repoID int64) ([]*models.CollaboratorGrant, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if grants, ok := m.CollabGrants[repoID]; ok {
+		return grants, nil
+	}
+	return []*models.CollaboratorGrant{}, nil
+}
+
+// ============================================================================
+// Migration Window Operations
+// ============================================================================
+
+func (m *MockDataStore) ListMigrationWindows(_ context.Context) ([]*models.MigrationWindow, error) {
+	return []*models.MigrationWindow{}, nil
+}
+
+func (m *MockDataStore) GetMigrationWindow(_ context.Context, _ int64) (*models.MigrationWindow, error) {
+	return nil, nil
+}
+
+func (m *MockDataStore) CreateMigrationWindow(_ context.Context, _ *models.MigrationWindow) error {
+	return nil
+}
+
+func (m *MockDataStore) UpdateMigrationWindow(_ context.Context, _ *models.MigrationWindow) error {
+	return nil
+}
+
+func (m *MockDataStore) DeleteMigrationWindow(_ context.Context, _ int64) error {
+	return nil
+}
+
+func (m *MockDataStore) CreateBatchApproval(_ context.Context, _ *models.BatchApproval) error {
+	return nil
+}
+
+func (m *MockDataStore) ListBatchApprovals(_ context.Context, _ int64) ([]*models.BatchApproval, error) {
+	return []*models.BatchApproval{}, nil
+}
+
+func (m *MockDataStore) UseBatchApproval(_ context.Context, _ int64, _ *models.BatchApproval) (bool, error) {
+	return false, nil
+}
+
 // ============================================================================
 // Analytics Operations
 // ============================================================================
@@ -457,6 +696,10 @@ func (m *MockDataStore) GetMedianMigrationTime(_ context.Context, _, _, _ string
 	return 0, nil
 }
 
+func (m *MockDataStore) GetMigrationDurationSamples(_ context.Context) ([]*storage.MigrationDurationSample, error) {
+	return []*storage.MigrationDurationSample{}, nil
+}
+
 func (m *MockDataStore) GetOrganizationStats(_ context.Context) ([]*storage.OrganizationStats, error) {
 	return []*storage.OrganizationStats{}, nil
 }
@@ -838,6 +1081,29 @@ func (m *MockDataStore) ResetTeamMigrationStatus(_ context.Context, _ string) er
 	return nil
 }
 
+// ============================================================================
+// Team Membership Sync Operations
+// ============================================================================
+
+func (m *MockDataStore) SaveTeamMembershipSync(_ context.Context, sync *models.TeamMembershipSync) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.TeamMemberships = append(m.TeamMemberships, sync)
+	return nil
+}
+
+func (m *MockDataStore) ListTeamMembershipSyncs(_ context.Context, sourceOrg string) ([]*models.TeamMembershipSync, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var syncs []*models.TeamMembershipSync
+	for _, sync := range m.TeamMemberships {
+		if sourceOrg == "" || sync.SourceOrg == sourceOrg {
+			syncs = append(syncs, sync)
+		}
+	}
+	return syncs, nil
+}
+
 // ============================================================================
 // Team Mapping Operations
 // ============================================================================
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// getReferencePullRequest returns the reference rewrite pull request recorded for a repository
+// GET /api/v1/repositories/{fullName}/reference-pr
+func (h *Handler) getReferencePullRequest(w http.ResponseWriter, r *http.Request, fullName string) {
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	pr, err := h.db.GetReferencePullRequest(ctx, repo.ID)
+	if err != nil {
+		h.logger.Error("Failed to get reference pull request", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("reference pull request"))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"reference_pr": pr,
+	})
+}
+
+// OpenReferencePullRequest opens a pull request on the destination repository rewriting
+// submodule, workflow and manifest references to other migrated repositories
+// POST /api/v1/repositories/{fullName}/reference-pr
+func (h *Handler) OpenReferencePullRequest(w http.ResponseWriter, r *http.Request) {
+	fullName, ok := r.Context().Value(cleanFullNameKey).(string)
+	if !ok || fullName == "" {
+		fullName = r.PathValue("fullName")
+	}
+	if fullName == "" {
+		WriteError(w, ErrMissingField.WithField("fullName"))
+		return
+	}
+
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	if !repo.IsMigrationComplete() {
+		WriteError(w, ErrBadRequest.WithDetails("References can only be rewritten once the repository is migrated"))
+		return
+	}
+
+	if h.refRewriter == nil {
+		WriteError(w, ErrClientNotConfigured.WithDetails("A destination must be configured to open reference pull requests"))
+		return
+	}
+
+	pr, err := h.refRewriter.OpenReferencePR(ctx, repo)
+	if err != nil {
+		h.logger.Error("Failed to open reference pull request", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrInternal.WithDetails(fmt.Sprintf("Failed to open reference pull request: %v", err)))
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"reference_pr": pr,
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// fakeReferenceRewriter records the repositories it was asked to open pull requests for
+type fakeReferenceRewriter struct {
+	db    DataStore
+	err   error
+	calls []string
+}
+
+func (f *fakeReferenceRewriter) OpenReferencePR(ctx context.Context, repo *models.Repository) (*models.ReferencePullRequest, error) {
+	f.calls = append(f.calls, repo.FullName)
+	if f.err != nil {
+		return nil, f.err
+	}
+	number := 3
+	pr := &models.ReferencePullRequest{RepositoryID: repo.ID, Status: models.ReferencePRStatusOpen, Number: &number, FilesChanged: 1, ReferencesRewritten: 2}
+	return pr, f.db.SaveReferencePullRequest(ctx, pr)
+}
+
+func TestReferencePullRequestHandlers(t *testing.T) {
+	mock := NewMockDataStore()
+	migrated := createTestRepo("org/app", models.StatusComplete)
+	migrated.ID = 1
+	pending := createTestRepo("org/pending", models.StatusPending)
+	pending.ID = 2
+	for _, repo := range []*models.Repository{migrated, pending} {
+		mock.Repos[repo.FullName] = repo
+		mock.ReposByID[repo.ID] = repo
+	}
+	h := setupTestHandlerWithMock(t, mock)
+
+	open := func(fullName string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/"+fullName+"/reference-pr", nil)
+		req.SetPathValue("fullName", fullName+"/reference-pr")
+		w := httptest.NewRecorder()
+		h.HandleRepositoryAction(w, req)
+		return w
+	}
+	get := func(fullName string) map[string]*models.ReferencePullRequest {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/"+fullName+"/reference-pr", nil)
+		req.SetPathValue("fullName", fullName+"/reference-pr")
+		w := httptest.NewRecorder()
+		h.GetRepositoryOrDependencies(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response map[string]*models.ReferencePullRequest
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return response
+	}
+
+	t.Run("no pull request yet", func(t *testing.T) {
+		if response := get("org%2Fapp"); response["reference_pr"] != nil {
+			t.Errorf("Expected no reference pull request, got %+v", response["reference_pr"])
+		}
+	})
+
+	t.Run("no destination configured", func(t *testing.T) {
+		if w := open("org%2Fapp"); w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status 503, got %d", w.Code)
+		}
+	})
+
+	rewriter := &fakeReferenceRewriter{db: mock}
+	h.SetReferenceRewriter(rewriter)
+
+	t.Run("repository not migrated", func(t *testing.T) {
+		if w := open("org%2Fpending"); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+		if len(rewriter.calls) != 0 {
+			t.Errorf("Expected no pull request to be opened, got %v", rewriter.calls)
+		}
+	})
+
+	t.Run("open", func(t *testing.T) {
+		w := open("org%2Fapp")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if len(rewriter.calls) != 1 || rewriter.calls[0] != "org/app" {
+			t.Errorf("Unexpected calls %v", rewriter.calls)
+		}
+
+		pr := get("org%2Fapp")["reference_pr"]
+		if pr == nil || pr.Status != models.ReferencePRStatusOpen || pr.Number == nil || *pr.Number != 3 {
+			t.Errorf("Unexpected reference pull request %+v", pr)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		rewriter.err = errors.New("branch already exists")
+		if w := open("org%2Fapp"); w.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", w.Code)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 	ghapi "github.com/google/go-github/v75/github"
 	"github.com/kuhlman-labs/github-migrator/internal/discovery"
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 )
 
@@ -74,7 +75,19 @@ func (h *Handler) HandleRepositoryAction(w http.ResponseWriter, r *http.Request)
 	var action string
 	var fullName string
 
-	if strings.HasSuffix(fullPath, "/rediscover") {
+	if strings.HasSuffix(fullPath, "/webhooks/activate") {
+		action = "activate-webhooks"
+		fullName = strings.TrimSuffix(fullPath, "/webhooks/activate")
+	} else if strings.HasSuffix(fullPath, "/codeowners/rewrite") {
+		action = "rewrite-codeowners"
+		fullName = strings.TrimSuffix(fullPath, "/codeowners/rewrite")
+	} else if strings.HasSuffix(fullPath, "/collaborators/grant") {
+		action = "grant-collaborators"
+		fullName = strings.TrimSuffix(fullPath, "/collaborators/grant")
+	} else if strings.HasSuffix(fullPath, "/reference-pr") {
+		action = "reference-pr"
+		fullName = strings.TrimSuffix(fullPath, "/reference-pr")
+	} else if strings.HasSuffix(fullPath, "/rediscover") {
 		action = "rediscover"
 		fullName = strings.TrimSuffix(fullPath, "/rediscover")
 	} else if strings.HasSuffix(fullPath, "/mark-remediated") {
@@ -122,6 +135,14 @@ func (h *Handler) HandleRepositoryAction(w http.ResponseWriter, r *http.Request)
 		h.MarkRepositoryWontMigrate(w, r)
 	case "reset":
 		h.ResetRepositoryStatus(w, r)
+	case "activate-webhooks":
+		h.ActivateRepositoryWebhooks(w, r)
+	case "reference-pr":
+		h.OpenReferencePullRequest(w, r)
+	case "rewrite-codeowners":
+		h.RewriteRepositoryCodeowners(w, r)
+	case "grant-collaborators":
+		h.GrantRepositoryCollaborators(w, r)
 	default:
 		WriteError(w, ErrNotFound.WithDetails("Unknown repository action"))
 	}
@@ -195,6 +216,42 @@ func (h *Handler) GetRepositoryOrDependencies(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Check for secrets/export before the secrets checklist
+	if before, ok := strings.CutSuffix(fullPath, "/secrets/export"); ok {
+		h.exportRepositorySecrets(w, r, before)
+		return
+	}
+
+	// Check if this is a secrets checklist request
+	if before, ok := strings.CutSuffix(fullPath, "/secrets"); ok {
+		h.getRepositorySecrets(w, r, before)
+		return
+	}
+
+	// Check if this is a webhooks request
+	if before, ok := strings.CutSuffix(fullPath, "/webhooks"); ok {
+		h.getRepositoryWebhooks(w, r, before)
+		return
+	}
+
+	// Check if this is a reference pull request request
+	if before, ok := strings.CutSuffix(fullPath, "/reference-pr"); ok {
+		h.getReferencePullRequest(w, r, before)
+		return
+	}
+
+	// Check if this is a CODEOWNERS rewrite request
+	if before, ok := strings.CutSuffix(fullPath, "/codeowners"); ok {
+		h.getCodeownersRewrite(w, r, before)
+		return
+	}
+
+	// Check if this is a collaborator grants request
+	if before, ok := strings.CutSuffix(fullPath, "/collaborators"); ok {
+		h.getCollaboratorGrants(w, r, before)
+		return
+	}
+
 	// Check if this is a dependents request
 	if before, ok := strings.CutSuffix(fullPath, "/dependents"); ok {
 		fullName := before
@@ -625,6 +682,8 @@ func (h *Handler) UnlockRepository(w http.ResponseWriter, r *http.Request) {
 }
 
 // RollbackRepository handles POST /api/v1/repositories/{fullName}/rollback
+// Archives or deletes the destination repository, unlocks the source and marks the repository
+// as rolled back. With dry_run set, returns the planned steps without changing anything.
 func (h *Handler) RollbackRepository(w http.ResponseWriter, r *http.Request) {
 	fullName, ok := r.Context().Value(cleanFullNameKey).(string)
 	if !ok || fullName == "" {
@@ -657,22 +716,57 @@ func (h *Handler) RollbackRepository(w http.ResponseWriter, r *http.Request) {
 	var req RollbackRepositoryRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		req.Reason = ""
+		req = RollbackRepositoryRequest{}
 	}
 
-	if err := h.db.RollbackRepository(ctx, decodedFullName, req.Reason); err != nil {
+	mode, err := migration.ParseRollbackMode(req.Mode)
+	if err != nil {
+		WriteError(w, ErrInvalidField.WithDetails(err.Error()))
+		return
+	}
+
+	// Deleting the destination repository is limited to admins, like batch rollbacks
+	if mode == migration.RollbackDelete {
+		if err := h.CheckAdminAccess(ctx); err != nil {
+			h.logger.Warn("Repository rollback delete denied", "repo", decodedFullName, "error", err)
+			WriteError(w, ErrForbidden.WithDetails(fmt.Sprintf("Rollback mode delete: %v", err)))
+			return
+		}
+	}
+
+	if h.rollbacker == nil {
+		WriteError(w, ErrClientNotConfigured.WithDetails("A destination must be configured to roll back migrations"))
+		return
+	}
+
+	result, err := h.rollbacker.Rollback(ctx, repo, migration.RollbackOptions{
+		Mode:        mode,
+		Reason:      req.Reason,
+		DryRun:      req.DryRun,
+		InitiatedBy: getInitiatingUser(ctx),
+	})
+	if err != nil {
 		h.logger.Error("Failed to rollback repository", "error", err, "repo", decodedFullName)
-		WriteError(w, ErrDatabaseUpdate.WithDetails("repository rollback"))
+		WriteError(w, ErrInternal.WithDetails(fmt.Sprintf("Repository rollback failed: %v", err)))
+		return
+	}
+
+	if req.DryRun {
+		h.sendJSON(w, http.StatusOK, map[string]any{
+			"message":  "Rollback preview - no changes were made",
+			"rollback": result,
+		})
 		return
 	}
 
-	h.logger.Info("Repository rolled back successfully", "repo", fullName, "reason", req.Reason)
+	h.logger.Info("Repository rolled back successfully", "repo", decodedFullName, "mode", mode, "reason", req.Reason)
 
-	repo, _ = h.db.GetRepository(ctx, fullName)
+	repo, _ = h.db.GetRepository(ctx, decodedFullName)
 
 	h.sendJSON(w, http.StatusOK, map[string]any{
 		"message":    "Repository rolled back successfully",
 		"repository": repo,
+		"rollback":   result,
 	})
 }
 
@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/auth"
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 )
 
@@ -355,3 +360,134 @@ func TestListRepositoriesWithFilters(t *testing.T) {
 		}
 	})
 }
+
+func TestRollbackRepository(t *testing.T) {
+	h, db := setupTestHandler(t)
+	ctx := context.Background()
+
+	destFullName := "dest-org/test-repo"
+	repo := &models.Repository{
+		FullName:            "org/test-repo",
+		Status:              string(models.StatusComplete),
+		DestinationFullName: &destFullName,
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+
+	rollback := func(body map[string]any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/org/test-repo/rollback", bytes.NewReader(data))
+		req.SetPathValue("fullName", "org/test-repo")
+		w := httptest.NewRecorder()
+		h.RollbackRepository(w, req)
+		return w
+	}
+
+	t.Run("destination not configured", func(t *testing.T) {
+		w := rollback(map[string]any{})
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+		}
+	})
+
+	rollbacker := &fakeRollbacker{db: db}
+	h.SetRollbacker(rollbacker)
+
+	t.Run("invalid mode", func(t *testing.T) {
+		w := rollback(map[string]any{"mode": "purge"})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		w := rollback(map[string]any{"mode": "delete", "dry_run": true})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		last := rollbacker.calls[len(rollbacker.calls)-1]
+		if !last.DryRun || last.Mode != migration.RollbackDelete {
+			t.Errorf("Expected a delete dry run, got %+v", last)
+		}
+
+		unchanged, _ := db.GetRepository(ctx, repo.FullName)
+		if unchanged.Status != string(models.StatusComplete) {
+			t.Errorf("Expected status to be unchanged, got %s", unchanged.Status)
+		}
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		w := rollback(map[string]any{"reason": "Wrong org"})
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+
+		last := rollbacker.calls[len(rollbacker.calls)-1]
+		if last.DryRun || last.Mode != migration.RollbackArchive || last.Reason != "Wrong org" {
+			t.Errorf("Expected an archive rollback with the reason, got %+v", last)
+		}
+
+		var response map[string]any
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if _, ok := response["rollback"]; !ok {
+			t.Error("Expected rollback steps in the response")
+		}
+
+		updated, _ := db.GetRepository(ctx, repo.FullName)
+		if updated.Status != string(models.StatusRolledBack) {
+			t.Errorf("Expected status rolled_back, got %s", updated.Status)
+		}
+	})
+
+	t.Run("not completed", func(t *testing.T) {
+		w := rollback(map[string]any{})
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
+
+func TestRollbackRepository_DeleteRequiresAdmin(t *testing.T) {
+	db := setupTestDB(t)
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	h := NewHandler(db, logger, nil, nil, nil, nil, &config.AuthConfig{Enabled: true}, "https://api.github.com", "github")
+	rollbacker := &fakeRollbacker{db: db}
+	h.SetRollbacker(rollbacker)
+	ctx := context.Background()
+
+	destFullName := "dest-org/test-repo"
+	repo := &models.Repository{
+		FullName:            "org/test-repo",
+		Status:              string(models.StatusComplete),
+		DestinationFullName: &destFullName,
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+
+	// A signed-in user without admin access
+	rollback := func(body map[string]any) *httptest.ResponseRecorder {
+		data, _ := json.Marshal(body)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/org/test-repo/rollback", bytes.NewReader(data))
+		req = req.WithContext(createAuthContext(&auth.GitHubUser{ID: 123, Login: "testuser"}, "test-token"))
+		req.SetPathValue("fullName", "org/test-repo")
+		w := httptest.NewRecorder()
+		h.RollbackRepository(w, req)
+		return w
+	}
+
+	if w := rollback(map[string]any{"mode": "delete"}); w.Code != http.StatusForbidden {
+		t.Fatalf("Expected status %d for a non-admin delete, got %d: %s", http.StatusForbidden, w.Code, w.Body.String())
+	}
+	if len(rollbacker.calls) != 0 {
+		t.Errorf("Expected no rollback for a non-admin delete, got %+v", rollbacker.calls)
+	}
+
+	if w := rollback(map[string]any{"mode": "archive"}); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d for an archive rollback, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+}
@@ -23,6 +23,14 @@ type RetryBatchRequest struct {
 	RepositoryIDs []int64 `json:"repository_ids,omitempty"`
 }
 
+// RollbackBatchRequest is the request body for rolling back completed repositories in a batch.
+type RollbackBatchRequest struct {
+	RepositoryIDs []int64 `json:"repository_ids,omitempty"` // Defaults to every completed repository in the batch
+	Mode          string  `json:"mode,omitempty"`           // "archive" (default) or "delete"
+	Reason        string  `json:"reason,omitempty"`
+	DryRun        bool    `json:"dry_run,omitempty"`
+}
+
 // ===== Discovery Handlers =====
 
 // StartDiscoveryRequest is the request body for starting repository discovery.
@@ -125,6 +133,7 @@ type MigrateTeamsRequest struct {
 	SourceOrg      string `json:"source_org,omitempty"`
 	SourceTeamSlug string `json:"source_team_slug,omitempty"`
 	DryRun         bool   `json:"dry_run,omitempty"`
+	SyncMembers    bool   `json:"sync_members,omitempty"` // Also add members through their user mappings
 }
 
 // ===== ADO Handlers =====
@@ -137,14 +146,44 @@ type StartADODiscoveryRequest struct {
 	SourceID     *int64   `json:"source_id,omitempty"` // Optional: associate discovered repos with a source
 }
 
+// ===== GitLab Handlers =====
+
+// StartGitLabDiscoveryRequest is the request body for starting GitLab discovery.
+type StartGitLabDiscoveryRequest struct {
+	Groups   []string `json:"groups"` // Top-level group paths; subgroups are walked recursively
+	Workers  int      `json:"workers,omitempty"`
+	SourceID *int64   `json:"source_id"`
+}
+
+// ===== Bitbucket Handlers =====
+
+// StartBitbucketDiscoveryRequest is the request body for starting Bitbucket Server / Data Center discovery.
+type StartBitbucketDiscoveryRequest struct {
+	Projects []string `json:"projects,omitempty"` // Project keys; every visible project is discovered when empty
+	Workers  int      `json:"workers,omitempty"`
+	SourceID *int64   `json:"source_id"`
+}
+
 // ===== Repository Handlers =====
 
 // RollbackRepositoryRequest is the request body for rolling back a repository.
 type RollbackRepositoryRequest struct {
 	Reason string `json:"reason,omitempty"`
+	Mode   string `json:"mode,omitempty"` // "archive" (default) or "delete"
+	DryRun bool   `json:"dry_run,omitempty"`
 }
 
 // MarkWontMigrateRequest is the request body for marking a repository as won't migrate.
 type MarkWontMigrateRequest struct {
 	Unmark bool `json:"unmark,omitempty"`
 }
+
+// RewriteCodeownersRequest is the request body for rewriting a repository's CODEOWNERS file.
+type RewriteCodeownersRequest struct {
+	Mode string `json:"mode,omitempty"` // "pull_request" (default) or "commit"
+}
+
+// GrantCollaboratorsRequest is the request body for re-granting a repository's collaborator permissions.
+type GrantCollaboratorsRequest struct {
+	Mode string `json:"mode,omitempty"` // "all" (default) or "members_only"
+}
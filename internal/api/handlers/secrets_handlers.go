@@ -0,0 +1,166 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// secretChecklistRow represents a row in the "secrets to re-enter" export
+type secretChecklistRow struct {
+	Repository            string `json:"repository"`
+	DestinationRepository string `json:"destination_repository"`
+	Scope                 string `json:"scope"`
+	Environment           string `json:"environment"`
+	Name                  string `json:"name"`
+	PlaceholderCreated    bool   `json:"placeholder_created"`
+}
+
+// secretScope returns whether a secret belongs to the repository or one of its environments
+func secretScope(environment string) string {
+	if environment == "" {
+		return "repository"
+	}
+	return "environment"
+}
+
+// getRepositorySecrets returns the "secrets to re-enter" checklist for a repository
+// GET /api/v1/repositories/{fullName}/secrets
+func (h *Handler) getRepositorySecrets(w http.ResponseWriter, r *http.Request, fullName string) {
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	secrets, err := h.db.GetRepositorySecrets(ctx, repo.ID)
+	if err != nil {
+		h.logger.Error("Failed to get repository secrets", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("secrets"))
+		return
+	}
+
+	placeholders := 0
+	for _, secret := range secrets {
+		if secret.PlaceholderCreated {
+			placeholders++
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"secrets":      secrets,
+		"total":        len(secrets),
+		"placeholders": placeholders,
+	})
+}
+
+// exportRepositorySecrets exports the "secrets to re-enter" checklist for a repository
+// GET /api/v1/repositories/{fullName}/secrets/export
+func (h *Handler) exportRepositorySecrets(w http.ResponseWriter, r *http.Request, fullName string) {
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatCSV
+	}
+
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	secrets, err := h.db.GetRepositorySecrets(ctx, repo.ID)
+	if err != nil {
+		h.logger.Error("Failed to get repository secrets for export", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("secrets"))
+		return
+	}
+
+	destination := ""
+	if repo.DestinationFullName != nil {
+		destination = *repo.DestinationFullName
+	}
+	rows := make([]secretChecklistRow, 0, len(secrets))
+	for _, secret := range secrets {
+		rows = append(rows, secretChecklistRow{
+			Repository:            repo.FullName,
+			DestinationRepository: destination,
+			Scope:                 secretScope(secret.Environment),
+			Environment:           secret.Environment,
+			Name:                  secret.Name,
+			PlaceholderCreated:    secret.PlaceholderCreated,
+		})
+	}
+
+	h.writeSecretsExport(w, format, strings.ReplaceAll(decodedFullName, "/", "-")+"-secrets", rows)
+}
+
+// ExportSecretsChecklist exports the "secrets to re-enter" checklist of every migrated repository
+// GET /api/v1/secrets/export
+func (h *Handler) ExportSecretsChecklist(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatCSV
+	}
+
+	entries, err := h.db.GetSecretsChecklist(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to get secrets checklist for export", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("secrets"))
+		return
+	}
+
+	rows := make([]secretChecklistRow, 0, len(entries))
+	for _, entry := range entries {
+		rows = append(rows, secretChecklistRow{
+			Repository:            entry.Repository,
+			DestinationRepository: entry.DestinationRepository,
+			Scope:                 secretScope(entry.Environment),
+			Environment:           entry.Environment,
+			Name:                  entry.Name,
+			PlaceholderCreated:    entry.PlaceholderCreated,
+		})
+	}
+
+	h.writeSecretsExport(w, format, "secrets", rows)
+}
+
+// writeSecretsExport writes the checklist export in the specified format
+func (h *Handler) writeSecretsExport(w http.ResponseWriter, format, filename string, rows []secretChecklistRow) {
+	if format == formatJSON {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.json", filename))
+		if err := json.NewEncoder(w).Encode(rows); err != nil {
+			h.logger.Error("Failed to encode JSON", "error", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", filename))
+
+	_, _ = fmt.Fprintln(w, "repository,destination_repository,scope,environment,secret_name,placeholder_created")
+	for _, row := range rows {
+		_, _ = fmt.Fprintf(w, "%s,%s,%s,%s,%s,%t\n",
+			escapeCSV(row.Repository),
+			escapeCSV(row.DestinationRepository),
+			row.Scope,
+			escapeCSV(row.Environment),
+			escapeCSV(row.Name),
+			row.PlaceholderCreated,
+		)
+	}
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestRepositorySecretsChecklist(t *testing.T) {
+	h, db := setupTestHandler(t)
+	ctx := context.Background()
+
+	destName := "dest-org/repo1"
+	repo := &models.Repository{
+		FullName:            "org/repo1",
+		Source:              "ghes",
+		SourceURL:           "https://github.com/org/repo1",
+		Status:              string(models.StatusComplete),
+		Visibility:          "private",
+		DestinationFullName: &destName,
+		DiscoveredAt:        time.Now(),
+		UpdatedAt:           time.Now(),
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("Failed to load repository: %v", err)
+	}
+	if err := db.SaveRepositorySecrets(ctx, saved.ID, []*models.RepositorySecret{
+		{Name: "NPM_TOKEN", PlaceholderCreated: true},
+		{Environment: "production", Name: "DEPLOY_KEY, PROD"},
+	}); err != nil {
+		t.Fatalf("Failed to save secrets: %v", err)
+	}
+
+	t.Run("checklist", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/org%2Frepo1/secrets", nil)
+		req.SetPathValue("fullName", "org%2Frepo1/secrets")
+		w := httptest.NewRecorder()
+
+		h.GetRepositoryOrDependencies(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Secrets      []models.RepositorySecret `json:"secrets"`
+			Total        int                       `json:"total"`
+			Placeholders int                       `json:"placeholders"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Total != 2 || response.Placeholders != 1 {
+			t.Errorf("Expected 2 secrets with 1 placeholder, got %d and %d", response.Total, response.Placeholders)
+		}
+	})
+
+	t.Run("csv export", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/org%2Frepo1/secrets/export", nil)
+		req.SetPathValue("fullName", "org%2Frepo1/secrets/export")
+		w := httptest.NewRecorder()
+
+		h.GetRepositoryOrDependencies(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if got := w.Header().Get("Content-Disposition"); !strings.Contains(got, "org-repo1-secrets.csv") {
+			t.Errorf("Unexpected Content-Disposition %q", got)
+		}
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("Expected a header and 2 rows, got %q", w.Body.String())
+		}
+		if lines[1] != "org/repo1,dest-org/repo1,repository,,NPM_TOKEN,true" {
+			t.Errorf("Unexpected repository secret row %q", lines[1])
+		}
+		if lines[2] != `org/repo1,dest-org/repo1,environment,production,"DEPLOY_KEY, PROD",false` {
+			t.Errorf("Unexpected environment secret row %q", lines[2])
+		}
+	})
+
+	t.Run("all repositories as json", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/secrets/export?format=json", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSecretsChecklist(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var rows []secretChecklistRow
+		if err := json.Unmarshal(w.Body.Bytes(), &rows); err != nil {
+			t.Fatalf("Failed to decode export: %v", err)
+		}
+		if len(rows) != 2 || rows[0].DestinationRepository != destName || rows[1].Scope != "environment" {
+			t.Errorf("Unexpected export rows %+v", rows)
+		}
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/org%2Fmissing/secrets", nil)
+		req.SetPathValue("fullName", "org%2Fmissing/secrets")
+		w := httptest.NewRecorder()
+
+		h.GetRepositoryOrDependencies(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404, got %d", w.Code)
+		}
+	})
+}
@@ -75,6 +75,13 @@ type DataStore interface {
 	storage.BatchStore
 	storage.MigrationHistoryStore
 	storage.DependencyStore
+	storage.SecretsChecklistStore
+	storage.WebhookStore
+	storage.ReferencePullRequestStore
+	storage.CodeownersRewriteStore
+	storage.CollaboratorGrantStore
+	storage.MigrationWindowStore
+	storage.BatchApprovalStore
 	storage.AnalyticsStore
 
 	// User and team stores
@@ -83,6 +90,7 @@ type DataStore interface {
 	storage.UserMannequinStore
 	storage.TeamStore
 	storage.TeamMappingStore
+	storage.TeamMembershipSyncStore
 
 	// Source stores
 	storage.SourceStore
@@ -11,6 +11,7 @@ import (
 	"github.com/kuhlman-labs/github-migrator/internal/azuredevops"
 	"github.com/kuhlman-labs/github-migrator/internal/github"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
 
@@ -31,7 +32,7 @@ func NewSourceHandler(db *storage.Database, logger *slog.Logger) *SourceHandler
 // CreateSourceRequest represents the request body for creating a source
 type CreateSourceRequest struct {
 	Name              string `json:"name"`
-	Type              string `json:"type"` // github or azuredevops
+	Type              string `json:"type"` // github, azuredevops, gitlab, or bitbucket
 	BaseURL           string `json:"base_url"`
 	Token             string `json:"token"`
 	Organization      string `json:"organization,omitempty"`    // Required for Azure DevOps
@@ -367,6 +368,10 @@ func (h *SourceHandler) ValidateSource(w http.ResponseWriter, r *http.Request) {
 		response = h.validateGitHubConnection(ctx, baseURL, token)
 	case models.SourceConfigTypeAzureDevOps:
 		response = h.validateAzureDevOpsConnection(ctx, baseURL, token, organization)
+	case models.SourceConfigTypeGitLab:
+		response = h.validateGitLabConnection(ctx, baseURL, token)
+	case models.SourceConfigTypeBitbucket:
+		response = h.validateBitbucketConnection(ctx, baseURL, token)
 	default:
 		response = SourceValidationResponse{
 			Valid: false,
@@ -518,6 +523,56 @@ func (h *SourceHandler) validateGitHubConnection(ctx context.Context, baseURL, t
 	return response
 }
 
+// validateGitLabConnection validates a GitLab connection
+func (h *SourceHandler) validateGitLabConnection(ctx context.Context, baseURL, token string) SourceValidationResponse {
+	response := SourceValidationResponse{
+		Details: make(map[string]any),
+	}
+
+	provider, err := source.NewGitLabProvider(baseURL, token)
+	if err != nil {
+		response.Valid = false
+		response.Error = "Failed to create GitLab provider: " + err.Error()
+		return response
+	}
+
+	if err := provider.ValidateCredentials(ctx); err != nil {
+		response.Valid = false
+		response.Error = "Authentication failed: " + err.Error()
+		return response
+	}
+
+	response.Valid = true
+	response.Details["connection_status"] = "connected"
+
+	return response
+}
+
+// validateBitbucketConnection validates a Bitbucket Server / Data Center connection
+func (h *SourceHandler) validateBitbucketConnection(ctx context.Context, baseURL, token string) SourceValidationResponse {
+	response := SourceValidationResponse{
+		Details: make(map[string]any),
+	}
+
+	provider, err := source.NewBitbucketProvider(baseURL, token, "")
+	if err != nil {
+		response.Valid = false
+		response.Error = "Failed to create Bitbucket provider: " + err.Error()
+		return response
+	}
+
+	if err := provider.ValidateCredentials(ctx); err != nil {
+		response.Valid = false
+		response.Error = "Authentication failed: " + err.Error()
+		return response
+	}
+
+	response.Valid = true
+	response.Details["connection_status"] = "connected"
+
+	return response
+}
+
 // validateAzureDevOpsConnection validates an Azure DevOps connection
 func (h *SourceHandler) validateAzureDevOpsConnection(ctx context.Context, baseURL, token, organization string) SourceValidationResponse {
 	response := SourceValidationResponse{
@@ -987,7 +987,8 @@ func (h *Handler) ExecuteTeamMigration(w http.ResponseWriter, r *http.Request) {
 
 	// Start execution in background
 	go func() {
-		if err := executor.ExecuteTeamMigration(context.Background(), req.SourceOrg, req.SourceTeamSlug, req.DryRun); err != nil {
+		opts := migration.TeamMigrationOptions{DryRun: req.DryRun, SyncMembers: req.SyncMembers}
+		if err := executor.ExecuteTeamMigration(context.Background(), req.SourceOrg, req.SourceTeamSlug, opts); err != nil {
 			h.logger.Error("Team migration execution failed", "error", err)
 		}
 	}()
@@ -996,6 +997,7 @@ func (h *Handler) ExecuteTeamMigration(w http.ResponseWriter, r *http.Request) {
 	h.sendJSON(w, http.StatusAccepted, map[string]any{
 		"message":          "Team migration started",
 		"dry_run":          req.DryRun,
+		"sync_members":     req.SyncMembers,
 		"source_org":       req.SourceOrg,
 		"source_team_slug": req.SourceTeamSlug,
 	})
@@ -1038,6 +1040,40 @@ func (h *Handler) GetTeamMigrationStatus(w http.ResponseWriter, r *http.Request)
 	h.sendJSON(w, http.StatusOK, response)
 }
 
+// GetTeamMembershipReport handles GET /api/v1/team-mappings/membership-report
+// Returns the outcome of the last membership sync of each team, including members without a user mapping
+func (h *Handler) GetTeamMembershipReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	sourceOrg := r.URL.Query().Get("source_org")
+
+	syncs, err := h.db.ListTeamMembershipSyncs(ctx, sourceOrg)
+	if err != nil {
+		h.logger.Error("Failed to list team membership syncs", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("team membership syncs"))
+		return
+	}
+
+	summary := map[string]int{
+		"synced":           0,
+		"skipped":          0,
+		"failed":           0,
+		"members_added":    0,
+		"unmapped_members": 0,
+	}
+	for _, sync := range syncs {
+		summary[sync.Status]++
+		summary["members_added"] += sync.MembersAdded
+		if sync.Unmapped != nil {
+			summary["unmapped_members"] += len(strings.Split(*sync.Unmapped, ","))
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"teams":   syncs,
+		"summary": summary,
+	})
+}
+
 // CancelTeamMigration handles POST /api/v1/team-mappings/cancel
 // Cancels the currently running team migration
 func (h *Handler) CancelTeamMigration(w http.ResponseWriter, r *http.Request) {
@@ -8,6 +8,7 @@ import (
 
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/source"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
@@ -128,6 +129,8 @@ func NewMockSourceProvider(providerType source.ProviderType) *MockSourceProvider
 		name = "Mock Azure DevOps Provider"
 	case source.ProviderGitLab:
 		name = "Mock GitLab Provider"
+	case source.ProviderBitbucket:
+		name = "Mock Bitbucket Provider"
 	}
 	return &MockSourceProvider{
 		providerType: providerType,
@@ -307,3 +310,29 @@ func createTestRepo(fullName string, status models.MigrationStatus) *models.Repo
 		Source:   "github",
 	}
 }
+
+// fakeRollbacker records rollback requests and marks repositories as rolled back in the database
+type fakeRollbacker struct {
+	db    DataStore
+	calls []migration.RollbackOptions
+}
+
+func (f *fakeRollbacker) Rollback(ctx context.Context, repo *models.Repository, opts migration.RollbackOptions) (*migration.RollbackResult, error) {
+	f.calls = append(f.calls, opts)
+	result := &migration.RollbackResult{
+		Repository: repo.FullName,
+		Mode:       opts.Mode,
+		DryRun:     opts.DryRun,
+		Steps: []migration.RollbackStep{
+			{Action: migration.RollbackStepMarkRolledBack, Target: repo.FullName, Status: migration.RollbackStepPlanned},
+		},
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+	if err := f.db.RollbackRepository(ctx, repo.FullName, opts.Reason); err != nil {
+		return result, err
+	}
+	result.Steps[0].Status = migration.RollbackStepCompleted
+	return result, nil
+}
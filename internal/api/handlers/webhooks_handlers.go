@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// webhookActivationFailure describes a webhook that could not be activated
+type webhookActivationFailure struct {
+	ID         int64  `json:"id"`
+	Repository string `json:"repository"`
+	URL        string `json:"url"`
+	Error      string `json:"error"`
+}
+
+// webhookActivationResult summarizes a webhook activation request
+type webhookActivationResult struct {
+	Activated int                        `json:"activated"`
+	Failed    []webhookActivationFailure `json:"failed"`
+}
+
+// getRepositoryWebhooks returns the webhooks recreated on the destination for a repository
+// GET /api/v1/repositories/{fullName}/webhooks
+func (h *Handler) getRepositoryWebhooks(w http.ResponseWriter, r *http.Request, fullName string) {
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	webhooks, err := h.db.GetRepositoryWebhooks(ctx, repo.ID)
+	if err != nil {
+		h.logger.Error("Failed to get repository webhooks", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("webhooks"))
+		return
+	}
+
+	pending := 0
+	for _, webhook := range webhooks {
+		if webhook.Status == models.WebhookStatusPending {
+			pending++
+		}
+	}
+
+	h.sendJSON(w, http.StatusOK, map[string]any{
+		"webhooks": webhooks,
+		"total":    len(webhooks),
+		"pending":  pending,
+	})
+}
+
+// ActivateRepositoryWebhooks activates every pending webhook of a repository
+// POST /api/v1/repositories/{fullName}/webhooks/activate
+func (h *Handler) ActivateRepositoryWebhooks(w http.ResponseWriter, r *http.Request) {
+	fullName, ok := r.Context().Value(cleanFullNameKey).(string)
+	if !ok || fullName == "" {
+		fullName = r.PathValue("fullName")
+	}
+	if fullName == "" {
+		WriteError(w, ErrMissingField.WithField("fullName"))
+		return
+	}
+
+	decodedFullName, err := url.QueryUnescape(fullName)
+	if err != nil {
+		decodedFullName = fullName
+	}
+
+	ctx := r.Context()
+	repo, err := h.db.GetRepository(ctx, decodedFullName)
+	if err != nil || repo == nil {
+		WriteError(w, ErrRepositoryNotFound)
+		return
+	}
+
+	pending, err := h.db.GetPendingWebhooks(ctx, nil, []int64{repo.ID})
+	if err != nil {
+		h.logger.Error("Failed to get pending webhooks", "repo", decodedFullName, "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("webhooks"))
+		return
+	}
+
+	h.activateWebhooks(ctx, w, pending)
+}
+
+// ActivateWebhooks bulk-activates pending webhooks once their owners have re-entered the secrets.
+// Without webhook or repository IDs in the body, every pending webhook is activated.
+// POST /api/v1/webhooks/activate
+func (h *Handler) ActivateWebhooks(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		WebhookIDs    []int64 `json:"webhook_ids,omitempty"`
+		RepositoryIDs []int64 `json:"repository_ids,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		WriteError(w, ErrInvalidJSON)
+		return
+	}
+
+	ctx := r.Context()
+	pending, err := h.db.GetPendingWebhooks(ctx, req.WebhookIDs, req.RepositoryIDs)
+	if err != nil {
+		h.logger.Error("Failed to get pending webhooks", "error", err)
+		WriteError(w, ErrDatabaseFetch.WithDetails("webhooks"))
+		return
+	}
+
+	h.activateWebhooks(ctx, w, pending)
+}
+
+// activateWebhooks turns on the given webhooks on the destination and records the outcome of each
+func (h *Handler) activateWebhooks(ctx context.Context, w http.ResponseWriter, pending []storage.PendingWebhook) {
+	destClient := h.getDestinationClient()
+	if destClient == nil {
+		WriteError(w, ErrClientNotConfigured.WithDetails("Destination client"))
+		return
+	}
+
+	result := webhookActivationResult{Failed: []webhookActivationFailure{}}
+	for _, webhook := range pending {
+		owner, name, ok := strings.Cut(webhook.DestinationRepository, "/")
+		var err error
+		if !ok {
+			err = errors.New("repository has no destination")
+		} else {
+			err = destClient.ActivateHook(ctx, owner, name, webhook.DestinationHookID)
+		}
+
+		if err != nil {
+			h.logger.Warn("Failed to activate webhook", "repo", webhook.Repository, "url", webhook.URL, "error", err)
+			if recordErr := h.db.SetWebhookError(ctx, webhook.ID, err.Error()); recordErr != nil {
+				h.logger.Error("Failed to record webhook error", "id", webhook.ID, "error", recordErr)
+			}
+			result.Failed = append(result.Failed, webhookActivationFailure{
+				ID:         webhook.ID,
+				Repository: webhook.Repository,
+				URL:        webhook.URL,
+				Error:      err.Error(),
+			})
+			continue
+		}
+
+		if err := h.db.MarkWebhookActivated(ctx, webhook.ID); err != nil {
+			h.logger.Error("Failed to mark webhook activated", "id", webhook.ID, "error", err)
+		}
+		result.Activated++
+	}
+
+	h.sendJSON(w, http.StatusOK, result)
+}
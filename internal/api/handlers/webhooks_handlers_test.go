@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestRepositoryWebhooks(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	// Destination API that accepts activation of hook 101 and rejects hook 102
+	var activated []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /api/v3/repos/dest-org/repo1/hooks/101", func(w http.ResponseWriter, r *http.Request) {
+		activated = append(activated, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":101,"active":true}`))
+	})
+	mux.HandleFunc("PATCH /api/v3/repos/dest-org/repo1/hooks/102", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	retryConfig := github.DefaultRetryConfig()
+	retryConfig.MaxAttempts = 1
+	destDualClient, err := github.NewDualClient(github.DualClientConfig{
+		PATConfig: github.ClientConfig{
+			BaseURL:     server.URL,
+			Token:       "ghp_test_token",
+			RetryConfig: retryConfig,
+			Logger:      logger,
+		},
+		Logger: logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create destination client: %v", err)
+	}
+	h := NewHandler(db, logger, nil, destDualClient, nil, nil, &config.AuthConfig{Enabled: false}, "https://api.github.com", "github")
+
+	destName := "dest-org/repo1"
+	repo := &models.Repository{
+		FullName:            "org/repo1",
+		Source:              "ghes",
+		SourceURL:           "https://github.com/org/repo1",
+		Status:              string(models.StatusComplete),
+		Visibility:          "private",
+		DestinationFullName: &destName,
+		DiscoveredAt:        time.Now(),
+		UpdatedAt:           time.Now(),
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("Failed to load repository: %v", err)
+	}
+	hookID := func(id int64) *int64 { return &id }
+	if err := db.SaveRepositoryWebhooks(ctx, saved.ID, []*models.RepositoryWebhook{
+		{SourceHookID: 1, DestinationHookID: hookID(101), URL: "https://ci.example.com/hook", Events: "push", Status: models.WebhookStatusPending},
+		{SourceHookID: 2, DestinationHookID: hookID(102), URL: "https://chat.example.com/hook", Events: "issues", Status: models.WebhookStatusPending},
+		{SourceHookID: 3, DestinationHookID: hookID(103), URL: "https://old.example.com/hook", Status: models.WebhookStatusDisabled},
+	}); err != nil {
+		t.Fatalf("Failed to save webhooks: %v", err)
+	}
+
+	t.Run("list", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/repositories/org%2Frepo1/webhooks", nil)
+		req.SetPathValue("fullName", "org%2Frepo1/webhooks")
+		w := httptest.NewRecorder()
+
+		h.GetRepositoryOrDependencies(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Webhooks []models.RepositoryWebhook `json:"webhooks"`
+			Total    int                        `json:"total"`
+			Pending  int                        `json:"pending"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if response.Total != 3 || response.Pending != 2 {
+			t.Errorf("Expected 3 webhooks with 2 pending, got %d and %d", response.Total, response.Pending)
+		}
+	})
+
+	t.Run("activate repository webhooks", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/repositories/org%2Frepo1/webhooks/activate", nil)
+		req.SetPathValue("fullName", "org%2Frepo1/webhooks/activate")
+		w := httptest.NewRecorder()
+
+		h.HandleRepositoryAction(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var result webhookActivationResult
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if result.Activated != 1 || len(result.Failed) != 1 || result.Failed[0].URL != "https://chat.example.com/hook" {
+			t.Errorf("Unexpected activation result %+v", result)
+		}
+		if len(activated) != 1 {
+			t.Errorf("Expected one activation call, got %v", activated)
+		}
+
+		pending, err := db.GetPendingWebhooks(ctx, nil, nil)
+		if err != nil {
+			t.Fatalf("Failed to get pending webhooks: %v", err)
+		}
+		if len(pending) != 1 || pending[0].URL != "https://chat.example.com/hook" {
+			t.Errorf("Expected only the rejected hook to stay pending, got %+v", pending)
+		}
+	})
+
+	t.Run("bulk activation with nothing pending for the filter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/activate", strings.NewReader(`{"repository_ids":[9999]}`))
+		w := httptest.NewRecorder()
+
+		h.ActivateWebhooks(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var result webhookActivationResult
+		if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if result.Activated != 0 || len(result.Failed) != 0 {
+			t.Errorf("Expected nothing to be activated, got %+v", result)
+		}
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/activate", strings.NewReader(`{`))
+		w := httptest.NewRecorder()
+
+		h.ActivateWebhooks(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", w.Code)
+		}
+	})
+}
+
+func TestActivateWebhooks_NoDestinationClient(t *testing.T) {
+	h, _ := setupTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/webhooks/activate", nil)
+	w := httptest.NewRecorder()
+
+	h.ActivateWebhooks(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/configsvc"
 	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/leader"
 	"github.com/kuhlman-labs/github-migrator/internal/mcp"
 	"github.com/kuhlman-labs/github-migrator/internal/source"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
@@ -95,6 +96,8 @@ func NewServer(cfg *config.Config, db *storage.Database, logger *slog.Logger, so
 	// Create main handler
 	mainHandler := handlers.NewHandler(db, logger, sourceDualClient, destDualClient, sourceProvider, sourceBaseConfig, &cfg.Auth, sourceBaseURL, cfg.Source.Type)
 	mainHandler.SetDestinationBaseURL(destBaseURLForAuth)
+	mainHandler.SetInstanceID(leader.ResolveInstanceID(cfg.Migration.InstanceID))
+	mainHandler.SetRequireBatchApproval(cfg.Migration.RequireBatchApproval)
 
 	// Create ADO handler if source is Azure DevOps
 	var adoHandler *handlers.ADOHandler
@@ -132,11 +135,13 @@ func NewServer(cfg *config.Config, db *storage.Database, logger *slog.Logger, so
 		destBaseURL = defaultGitHubAPIURL
 	}
 	copilotHandler := handlers.NewCopilotHandler(db, logger, destBaseURL)
+	copilotHandler.SetRequireBatchApproval(cfg.Migration.RequireBatchApproval)
 
 	// Create MCP server for AI tool access
 	// Default port is 8081, can be configured via settings
 	mcpServer := mcp.NewServer(db, logger, mcp.Config{
-		Address: ":8081",
+		Address:              ":8081",
+		RequireBatchApproval: cfg.Migration.RequireBatchApproval,
 	})
 
 	return &Server{
@@ -204,11 +209,47 @@ func (s *Server) UpdateMCPConfig(port int) error {
 
 	// Create new MCP server with updated config
 	s.mcpServer = mcp.NewServer(s.db, s.logger, mcp.Config{
-		Address: fmt.Sprintf(":%d", port),
+		Address:              fmt.Sprintf(":%d", port),
+		RequireBatchApproval: s.config.Migration.RequireBatchApproval,
 	})
 	return nil
 }
 
+// SetRollbacker sets the executor used to roll back completed migrations
+func (s *Server) SetRollbacker(rollbacker handlers.RepositoryRollbacker) {
+	if s.handler != nil {
+		s.handler.SetRollbacker(rollbacker)
+	}
+}
+
+// SetReferenceRewriter sets the executor used to open reference rewrite pull requests
+func (s *Server) SetReferenceRewriter(rewriter handlers.ReferenceRewriter) {
+	if s.handler != nil {
+		s.handler.SetReferenceRewriter(rewriter)
+	}
+}
+
+// SetCodeownersRewriter sets the executor used to rewrite CODEOWNERS on destinations
+func (s *Server) SetCodeownersRewriter(rewriter handlers.CodeownersRewriter) {
+	if s.handler != nil {
+		s.handler.SetCodeownersRewriter(rewriter)
+	}
+}
+
+// SetCollaboratorGranter sets the executor used to re-grant collaborator permissions on destinations
+func (s *Server) SetCollaboratorGranter(granter handlers.CollaboratorGranter) {
+	if s.handler != nil {
+		s.handler.SetCollaboratorGranter(granter)
+	}
+}
+
+// SetWavePlanner sets the planner used to propose dependency-aware migration waves
+func (s *Server) SetWavePlanner(planner handlers.WavePlanner) {
+	if s.handler != nil {
+		s.handler.SetWavePlanner(planner)
+	}
+}
+
 // SetConfigService sets the dynamic configuration service and creates the settings handler
 func (s *Server) SetConfigService(configSvc *configsvc.Service) {
 	s.configSvc = configSvc
@@ -372,6 +413,12 @@ func (s *Server) Router() http.Handler {
 	protect("GET /api/v1/dependencies/graph", s.handler.GetDependencyGraph)
 	protect("GET /api/v1/dependencies/export", s.handler.ExportDependencies)
 
+	// Secrets checklist export (secrets to re-enter after migration)
+	protect("GET /api/v1/secrets/export", s.handler.ExportSecretsChecklist)
+
+	// Bulk activation of webhooks recreated inactive on the destination
+	protect("POST /api/v1/webhooks/activate", s.handler.ActivateWebhooks)
+
 	// Organization endpoints
 	protect("GET /api/v1/organizations", s.handler.ListOrganizations)
 	protect("GET /api/v1/organizations/list", s.handler.GetOrganizationList)
@@ -394,6 +441,7 @@ func (s *Server) Router() http.Handler {
 	protect("POST /api/v1/team-mappings/sync", s.handler.SyncTeamMappingsFromDiscovery)
 	protect("POST /api/v1/team-mappings/execute", s.handler.ExecuteTeamMigration)
 	protect("GET /api/v1/team-mappings/execution-status", s.handler.GetTeamMigrationStatus)
+	protect("GET /api/v1/team-mappings/membership-report", s.handler.GetTeamMembershipReport)
 	protect("POST /api/v1/team-mappings/cancel", s.handler.CancelTeamMigration)
 	protect("POST /api/v1/team-mappings/reset", s.handler.ResetTeamMigrationStatus)
 	protect("POST /api/v1/team-mappings", s.handler.CreateTeamMapping)
@@ -432,14 +480,29 @@ func (s *Server) Router() http.Handler {
 	// Batch endpoints
 	protect("GET /api/v1/batches", s.handler.ListBatches)
 	protect("POST /api/v1/batches", s.handler.CreateBatch)
+	protect("GET /api/v1/batches/wave-plan", s.handler.GetWavePlan)
+	protect("POST /api/v1/batches/chain", s.handler.ChainBatches)
+	protect("GET /api/v1/batches/pending-approvals", s.handler.ListPendingBatchApprovals)
 	protect("GET /api/v1/batches/{id}", s.handler.GetBatch)
 	protect("PATCH /api/v1/batches/{id}", s.handler.UpdateBatch)
 	protect("DELETE /api/v1/batches/{id}", s.handler.DeleteBatch)
 	protect("POST /api/v1/batches/{id}/dry-run", s.handler.DryRunBatch)
 	protect("POST /api/v1/batches/{id}/start", s.handler.StartBatch)
+	protect("GET /api/v1/batches/{id}/approvals", s.handler.ListBatchApprovals)
+	protect("POST /api/v1/batches/{id}/approval-request", s.handler.RequestBatchApproval)
+	adminOnly("POST /api/v1/batches/{id}/approve", s.handler.ApproveBatch)
+	adminOnly("POST /api/v1/batches/{id}/reject", s.handler.RejectBatch)
 	protect("POST /api/v1/batches/{id}/repositories", s.handler.AddRepositoriesToBatch)
 	protect("DELETE /api/v1/batches/{id}/repositories", s.handler.RemoveRepositoriesFromBatch)
 	protect("POST /api/v1/batches/{id}/retry", s.handler.RetryBatchFailures)
+	adminOnly("POST /api/v1/batches/{id}/rollback", s.handler.RollbackBatch)
+
+	// Migration window and calendar endpoints
+	protect("GET /api/v1/migration-windows", s.handler.ListMigrationWindows)
+	adminOnly("POST /api/v1/migration-windows", s.handler.CreateMigrationWindow)
+	adminOnly("PUT /api/v1/migration-windows/{id}", s.handler.UpdateMigrationWindow)
+	adminOnly("DELETE /api/v1/migration-windows/{id}", s.handler.DeleteMigrationWindow)
+	protect("GET /api/v1/calendar", s.handler.GetMigrationCalendar)
 
 	// Migration endpoints
 	protect("POST /api/v1/migrations/start", s.handler.StartMigration)
@@ -463,6 +526,12 @@ func (s *Server) Router() http.Handler {
 	protect("GET /api/v1/ado/projects", s.handler.ListADOProjectsDynamic)
 	protect("GET /api/v1/ado/projects/{organization}/{project}", s.handler.GetADOProjectDynamic)
 
+	// GitLab specific endpoints
+	protect("POST /api/v1/gitlab/discover", s.handler.StartGitLabDiscovery)
+
+	// Bitbucket Server / Data Center specific endpoints
+	protect("POST /api/v1/bitbucket/discover", s.handler.StartBitbucketDiscovery)
+
 	// Self-service endpoints
 	protect("POST /api/v1/self-service/migrate", s.handler.HandleSelfServiceMigration)
 
@@ -0,0 +1,243 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// ApprovalActorScheduler is the actor recorded when the scheduler starts an approved batch
+const ApprovalActorScheduler = "scheduler"
+
+// ApprovalStore is the data batch approvals are recorded in. Implemented by storage.Database.
+type ApprovalStore interface {
+	ListRepositories(ctx context.Context, filters map[string]any) ([]*models.Repository, error)
+	CreateBatchApproval(ctx context.Context, approval *models.BatchApproval) error
+	ListBatchApprovals(ctx context.Context, batchID int64) ([]*models.BatchApproval, error)
+	UseBatchApproval(ctx context.Context, approvalID int64, start *models.BatchApproval) (bool, error)
+}
+
+// DryRunSummary is the state of a batch's dry run attached to its approval request
+type DryRunSummary struct {
+	Repositories       int        `json:"repositories"`
+	Succeeded          int        `json:"succeeded"`
+	Failed             int        `json:"failed"`
+	NotRun             int        `json:"not_run"` // Added since the dry run, or never dry run
+	LastDryRunAt       *time.Time `json:"last_dry_run_at,omitempty"`
+	FailedRepositories []string   `json:"failed_repositories,omitempty"`
+	RepositoryIDs      []int64    `json:"repository_ids"` // The repositories the approval covers
+}
+
+// ApprovalError is returned when a batch's approval state does not allow a step
+type ApprovalError struct {
+	Reason string
+}
+
+func (e *ApprovalError) Error() string {
+	return e.Reason
+}
+
+// IsApprovalError reports whether err is an *ApprovalError
+func IsApprovalError(err error) bool {
+	var approvalErr *ApprovalError
+	return errors.As(err, &approvalErr)
+}
+
+// LatestApproval returns the latest step of a batch's approval, or nil if it was never submitted
+func LatestApproval(ctx context.Context, store ApprovalStore, batchID int64) (*models.BatchApproval, error) {
+	approvals, err := store.ListBatchApprovals(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if len(approvals) == 0 {
+		return nil, nil
+	}
+	return approvals[len(approvals)-1], nil
+}
+
+// RequestApproval submits a batch for approval of its production migration, attaching a summary of
+// its dry run. The batch must have finished a dry run and must not already be awaiting approval.
+func RequestApproval(ctx context.Context, store ApprovalStore, batch *models.Batch, requester string, comment *string) (*models.BatchApproval, error) {
+	if requester == "" {
+		return nil, &ApprovalError{Reason: "approval requests require an authenticated user"}
+	}
+	if batch.Status != models.BatchStatusReady && batch.Status != models.BatchStatusPending {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q is %s; only pending or ready batches can be submitted", batch.Name, batch.Status)}
+	}
+	if batch.LastDryRunAt == nil {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q has no dry run to attach; run a dry run first", batch.Name)}
+	}
+
+	latest, err := LatestApproval(ctx, store, batch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch approval: %w", err)
+	}
+	if latest != nil && (latest.Action == models.BatchApprovalRequested || latest.Action == models.BatchApprovalApproved) {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q is already %s", batch.Name, latest.Action)}
+	}
+
+	repos, err := store.ListRepositories(ctx, map[string]any{"batch_id": batch.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch repositories: %w", err)
+	}
+	if len(repos) == 0 {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q has no repositories", batch.Name)}
+	}
+	summary := DryRunSummary{Repositories: len(repos), LastDryRunAt: batch.LastDryRunAt, RepositoryIDs: make([]int64, 0, len(repos))}
+	for _, repo := range repos {
+		switch models.MigrationStatus(repo.Status) {
+		case models.StatusDryRunQueued, models.StatusDryRunInProgress:
+			return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q has a dry run in progress", batch.Name)}
+		case models.StatusDryRunComplete:
+			summary.Succeeded++
+		case models.StatusDryRunFailed:
+			summary.Failed++
+			summary.FailedRepositories = append(summary.FailedRepositories, repo.FullName)
+		default:
+			summary.NotRun++
+		}
+		summary.RepositoryIDs = append(summary.RepositoryIDs, repo.ID)
+	}
+	slices.Sort(summary.RepositoryIDs)
+
+	encoded, err := json.Marshal(summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dry run summary: %w", err)
+	}
+	request := &models.BatchApproval{
+		BatchID:       batch.ID,
+		Action:        models.BatchApprovalRequested,
+		Actor:         requester,
+		Comment:       comment,
+		DryRunSummary: encoded,
+	}
+	if err := store.CreateBatchApproval(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// ReviewApproval approves or rejects a batch awaiting approval. The reviewer must not be the
+// requester.
+func ReviewApproval(ctx context.Context, store ApprovalStore, batch *models.Batch, reviewer string, approve bool, comment *string) (*models.BatchApproval, error) {
+	if reviewer == "" {
+		return nil, &ApprovalError{Reason: "approvals require an authenticated user"}
+	}
+
+	latest, err := LatestApproval(ctx, store, batch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch approval: %w", err)
+	}
+	if latest == nil || latest.Action != models.BatchApprovalRequested {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q is not awaiting approval", batch.Name)}
+	}
+	if latest.Actor == reviewer {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q must be reviewed by someone other than %s, who requested it", batch.Name, reviewer)}
+	}
+
+	review := &models.BatchApproval{
+		BatchID: batch.ID,
+		Action:  models.BatchApprovalRejected,
+		Actor:   reviewer,
+		Comment: comment,
+	}
+	if approve {
+		review.Action = models.BatchApprovalApproved
+	}
+	if err := store.CreateBatchApproval(ctx, review); err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// CheckApproval returns an *ApprovalError unless the batch's production migration is approved and
+// its repositories are still the ones in the approved request
+func CheckApproval(ctx context.Context, store ApprovalStore, batch *models.Batch) error {
+	_, err := checkApproval(ctx, store, batch)
+	return err
+}
+
+// UseApproval checks the batch's approval like CheckApproval and uses it up for a start of its
+// production migration, recording the start in the audit log. Only one start can use an approval:
+// when several race for it, the others get an *ApprovalError.
+func UseApproval(ctx context.Context, store ApprovalStore, batch *models.Batch, actor string) error {
+	approval, err := checkApproval(ctx, store, batch)
+	if err != nil {
+		return err
+	}
+	used, err := store.UseBatchApproval(ctx, approval.ID, &models.BatchApproval{
+		BatchID: batch.ID,
+		Action:  models.BatchApprovalStarted,
+		Actor:   actor,
+	})
+	if err != nil {
+		return err
+	}
+	if !used {
+		return &ApprovalError{Reason: fmt.Sprintf("the approval of batch %q was already used to start it", batch.Name)}
+	}
+	return nil
+}
+
+// CheckRepositoryApproval returns an *ApprovalError unless a repository of the batch was in the
+// request of the batch's latest approval. The approval may already have been used by a start of
+// the batch, so its repositories can still be retried one by one.
+func CheckRepositoryApproval(ctx context.Context, store ApprovalStore, batch *models.Batch, repoID int64) error {
+	approvals, err := store.ListBatchApprovals(ctx, batch.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get batch approval: %w", err)
+	}
+	// A start follows the approval it used, which follows the request it approved
+	n := len(approvals)
+	if n > 0 && approvals[n-1].Action == models.BatchApprovalStarted {
+		n--
+	}
+	if n < 2 || approvals[n-1].Action != models.BatchApprovalApproved {
+		return &ApprovalError{Reason: fmt.Sprintf("batch %q requires an approved request before its repositories can be migrated", batch.Name)}
+	}
+	var summary DryRunSummary
+	if json.Unmarshal(approvals[n-2].DryRunSummary, &summary) != nil {
+		return &ApprovalError{Reason: fmt.Sprintf("batch %q has no approved request", batch.Name)}
+	}
+	if !slices.Contains(summary.RepositoryIDs, repoID) {
+		return &ApprovalError{Reason: fmt.Sprintf("a repository was added to batch %q after it was approved; submit it again", batch.Name)}
+	}
+	return nil
+}
+
+// checkApproval returns the batch's unused approval, or an *ApprovalError unless the batch's
+// production migration is approved and its repositories are still the ones in the approved request
+func checkApproval(ctx context.Context, store ApprovalStore, batch *models.Batch) (*models.BatchApproval, error) {
+	approvals, err := store.ListBatchApprovals(ctx, batch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch approval: %w", err)
+	}
+	if len(approvals) == 0 || approvals[len(approvals)-1].Action != models.BatchApprovalApproved || approvals[len(approvals)-1].UsedAt != nil {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q requires an approved request before its migration can start", batch.Name)}
+	}
+	approval := approvals[len(approvals)-1]
+
+	// The approval follows the request it approved
+	var summary DryRunSummary
+	if len(approvals) < 2 || json.Unmarshal(approvals[len(approvals)-2].DryRunSummary, &summary) != nil {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("batch %q has no approved request", batch.Name)}
+	}
+	repos, err := store.ListRepositories(ctx, map[string]any{"batch_id": batch.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch repositories: %w", err)
+	}
+	ids := make([]int64, 0, len(repos))
+	for _, repo := range repos {
+		ids = append(ids, repo.ID)
+	}
+	slices.Sort(ids)
+	if !slices.Equal(ids, summary.RepositoryIDs) {
+		return nil, &ApprovalError{Reason: fmt.Sprintf("the repositories in batch %q changed after it was approved; submit it again", batch.Name)}
+	}
+	return approval, nil
+}
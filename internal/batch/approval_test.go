@@ -0,0 +1,123 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestApprovalWorkflow(t *testing.T) {
+	_, db, cleanup := setupTestOrganizer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	b := createDependencyBatch(t, db, "wave_1", models.BatchStatusReady, nil, nil)
+	for name, status := range map[string]models.MigrationStatus{
+		"org/api": models.StatusDryRunComplete,
+		"org/web": models.StatusDryRunFailed,
+	} {
+		repo := createTestRepository(t, db, name, 100, map[string]bool{})
+		repo.Status = string(status)
+		repo.BatchID = &b.ID
+		if err := db.UpdateRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to update repository: %v", err)
+		}
+	}
+
+	if _, err := RequestApproval(ctx, db, b, "alice", nil); !IsApprovalError(err) || !strings.Contains(err.Error(), "no dry run") {
+		t.Errorf("Expected a batch without a dry run to be rejected, got %v", err)
+	}
+	now := time.Now()
+	b.LastDryRunAt = &now
+
+	if err := CheckApproval(ctx, db, b); !IsApprovalError(err) {
+		t.Errorf("Expected an unapproved batch not to start, got %v", err)
+	}
+
+	comment := "CHG-1042"
+	request, err := RequestApproval(ctx, db, b, "alice", &comment)
+	if err != nil {
+		t.Fatalf("RequestApproval() error = %v", err)
+	}
+	var summary DryRunSummary
+	if err := json.Unmarshal(request.DryRunSummary, &summary); err != nil {
+		t.Fatalf("Failed to decode dry run summary: %v", err)
+	}
+	if summary.Repositories != 2 || summary.Succeeded != 1 || summary.Failed != 1 ||
+		len(summary.FailedRepositories) != 1 || summary.FailedRepositories[0] != "org/web" {
+		t.Errorf("Unexpected dry run summary %+v", summary)
+	}
+	if _, err := RequestApproval(ctx, db, b, "alice", nil); !IsApprovalError(err) {
+		t.Errorf("Expected a second request to be rejected, got %v", err)
+	}
+
+	if _, err := ReviewApproval(ctx, db, b, "alice", true, nil); !IsApprovalError(err) || !strings.Contains(err.Error(), "someone other than alice") {
+		t.Errorf("Expected the requester not to approve their own request, got %v", err)
+	}
+	if _, err := ReviewApproval(ctx, db, b, "bob", true, nil); err != nil {
+		t.Fatalf("ReviewApproval() error = %v", err)
+	}
+	if err := CheckApproval(ctx, db, b); err != nil {
+		t.Errorf("Expected the approved batch to start, got %v", err)
+	}
+
+	// Adding a repository after approval needs a new request
+	added := createTestRepository(t, db, "org/docs", 100, map[string]bool{})
+	added.BatchID = &b.ID
+	if err := db.UpdateRepository(ctx, added); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+	if err := CheckApproval(ctx, db, b); !IsApprovalError(err) || !strings.Contains(err.Error(), "changed") {
+		t.Errorf("Expected a changed batch not to start, got %v", err)
+	}
+	if err := CheckRepositoryApproval(ctx, db, b, added.ID); !IsApprovalError(err) || !strings.Contains(err.Error(), "added") {
+		t.Errorf("Expected a repository added after approval not to start, got %v", err)
+	}
+	added.BatchID = nil
+	if err := db.UpdateRepository(ctx, added); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+
+	if err := UseApproval(ctx, db, b, "bob"); err != nil {
+		t.Fatalf("UseApproval() error = %v", err)
+	}
+	if err := UseApproval(ctx, db, b, "carol"); !IsApprovalError(err) {
+		t.Errorf("Expected the approval to start the batch only once, got %v", err)
+	}
+	if err := CheckApproval(ctx, db, b); !IsApprovalError(err) {
+		t.Errorf("Expected the approval to be used up by the start, got %v", err)
+	}
+	if err := CheckRepositoryApproval(ctx, db, b, summary.RepositoryIDs[0]); err != nil {
+		t.Errorf("Expected an approved repository to be retried after the start, got %v", err)
+	}
+
+	// A rejected batch can be submitted again
+	if _, err := RequestApproval(ctx, db, b, "alice", nil); err != nil {
+		t.Fatalf("RequestApproval() error = %v", err)
+	}
+	if _, err := ReviewApproval(ctx, db, b, "bob", false, &comment); err != nil {
+		t.Fatalf("ReviewApproval() error = %v", err)
+	}
+	if _, err := ReviewApproval(ctx, db, b, "bob", true, nil); !IsApprovalError(err) {
+		t.Errorf("Expected a rejected batch not to be approvable, got %v", err)
+	}
+	if _, err := RequestApproval(ctx, db, b, "alice", nil); err != nil {
+		t.Errorf("Expected a rejected batch to be resubmitted, got %v", err)
+	}
+	if err := CheckRepositoryApproval(ctx, db, b, summary.RepositoryIDs[0]); !IsApprovalError(err) {
+		t.Errorf("Expected repositories of a batch awaiting approval not to start, got %v", err)
+	}
+
+	log, _ := db.ListBatchApprovals(ctx, b.ID)
+	actions := make([]string, len(log))
+	for i, step := range log {
+		actions[i] = step.Action
+	}
+	if got := strings.Join(actions, ","); got != "requested,approved,started,requested,rejected,requested" {
+		t.Errorf("Unexpected approval log %s", got)
+	}
+}
@@ -0,0 +1,185 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// Dependency states
+const (
+	DependencyWaiting   = "waiting"   // The batch it depends on has not finished its production migration
+	DependencySatisfied = "satisfied" // The batch may start
+	DependencyBlocked   = "blocked"   // The batch it depends on was cancelled or had too many failures
+)
+
+// DependencyStore is the data batch dependencies are evaluated against. Implemented by storage.Database.
+type DependencyStore interface {
+	GetBatch(ctx context.Context, id int64) (*models.Batch, error)
+	ListBatches(ctx context.Context) ([]*models.Batch, error)
+	ListRepositories(ctx context.Context, filters map[string]any) ([]*models.Repository, error)
+}
+
+// DependencyStatus is whether a batch's dependency allows it to start
+type DependencyStatus struct {
+	BatchID     int64  `json:"batch_id"`
+	BatchName   string `json:"batch_name"`
+	BatchStatus string `json:"batch_status"`
+	State       string `json:"state"`
+	Failures    int    `json:"failures"`               // Failed production migrations in the batch depended on
+	MaxFailures *int   `json:"max_failures,omitempty"` // Failures allowed; nil for no limit
+	Reason      string `json:"reason"`
+}
+
+// CheckDependency evaluates the dependency of a batch. It returns nil if the batch has none.
+//
+// The dependency is satisfied once the batch depended on has finished its production migration
+// (completed, completed with errors, or failed) with at most MaxDependencyFailures repositories in
+// migration_failed. Until then the batch waits. It is blocked if the batch depended on was cancelled
+// or failed too many migrations; retrying those migrations unblocks it.
+func CheckDependency(ctx context.Context, store DependencyStore, batch *models.Batch) (*DependencyStatus, error) {
+	if batch.DependsOnBatchID == nil {
+		return nil, nil
+	}
+
+	dependency, err := store.GetBatch(ctx, *batch.DependsOnBatchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch dependency: %w", err)
+	}
+	status := &DependencyStatus{BatchID: *batch.DependsOnBatchID, MaxFailures: batch.MaxDependencyFailures}
+	if dependency == nil {
+		status.State = DependencyBlocked
+		status.Reason = fmt.Sprintf("batch %d it depends on no longer exists", *batch.DependsOnBatchID)
+		return status, nil
+	}
+	status.BatchName = dependency.Name
+	status.BatchStatus = dependency.Status
+
+	switch dependency.Status {
+	case models.BatchStatusCancelled:
+		status.State = DependencyBlocked
+		status.Reason = fmt.Sprintf("batch %q it depends on was cancelled", dependency.Name)
+		return status, nil
+	case models.BatchStatusCompleted, models.BatchStatusCompletedWithErrors, models.BatchStatusFailed:
+		// Dry run failures also complete a batch with errors, so it must have started migrating
+		if dependency.StartedAt != nil {
+			break
+		}
+		fallthrough
+	default:
+		status.State = DependencyWaiting
+		status.Reason = fmt.Sprintf("waiting for batch %q to complete its migration", dependency.Name)
+		return status, nil
+	}
+
+	repos, err := store.ListRepositories(ctx, map[string]any{"batch_id": dependency.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch dependency repositories: %w", err)
+	}
+	for _, repo := range repos {
+		if repo.Status == string(models.StatusMigrationFailed) {
+			status.Failures++
+		}
+	}
+
+	if batch.MaxDependencyFailures != nil && status.Failures > *batch.MaxDependencyFailures {
+		status.State = DependencyBlocked
+		status.Reason = fmt.Sprintf("batch %q it depends on has %d failed migrations, more than the %d allowed",
+			dependency.Name, status.Failures, *batch.MaxDependencyFailures)
+		return status, nil
+	}
+	status.State = DependencySatisfied
+	status.Reason = fmt.Sprintf("batch %q it depends on completed with %d failed migrations", dependency.Name, status.Failures)
+	return status, nil
+}
+
+// ValidateDependency checks a batch's dependency before it is saved: the batch depended on must
+// exist and must not depend on the batch, directly or through other batches
+func ValidateDependency(ctx context.Context, store DependencyStore, batch *models.Batch) error {
+	if batch.DependsOnBatchID == nil {
+		if batch.MaxDependencyFailures != nil {
+			return fmt.Errorf("max_dependency_failures requires depends_on_batch_id")
+		}
+		return nil
+	}
+	if batch.MaxDependencyFailures != nil && *batch.MaxDependencyFailures < 0 {
+		return fmt.Errorf("max_dependency_failures cannot be negative")
+	}
+	if batch.ID != 0 && *batch.DependsOnBatchID == batch.ID {
+		return fmt.Errorf("a batch cannot depend on itself")
+	}
+
+	batches, err := store.ListBatches(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list batches: %w", err)
+	}
+	byID := make(map[int64]*models.Batch, len(batches))
+	for _, b := range batches {
+		byID[b.ID] = b
+	}
+	if byID[*batch.DependsOnBatchID] == nil {
+		return fmt.Errorf("batch %d does not exist", *batch.DependsOnBatchID)
+	}
+
+	// Follow the chain of dependencies, which is acyclic, back from the batch depended on
+	next := batch.DependsOnBatchID
+	for range len(byID) {
+		if next == nil {
+			return nil
+		}
+		if batch.ID != 0 && *next == batch.ID {
+			return fmt.Errorf("batch %q already depends on this batch", byID[*batch.DependsOnBatchID].Name)
+		}
+		dependency := byID[*next]
+		if dependency == nil {
+			return nil
+		}
+		next = dependency.DependsOnBatchID
+	}
+	return nil
+}
+
+// ChainStore is the data batch chains are saved to. Implemented by storage.Database.
+type ChainStore interface {
+	DependencyStore
+	UpdateBatch(ctx context.Context, batch *models.Batch) error
+}
+
+// ChainBatches makes each batch depend on the one before it, allowing at most maxFailures failed
+// migrations in the previous batch (nil for no limit), so the scheduler runs them one after another.
+// The first batch's dependency is left as it is. The chained batches are returned in order.
+func ChainBatches(ctx context.Context, store ChainStore, batchIDs []int64, maxFailures *int) ([]*models.Batch, error) {
+	if len(batchIDs) < 2 {
+		return nil, fmt.Errorf("at least two batches are required")
+	}
+
+	batches := make([]*models.Batch, len(batchIDs))
+	for i, id := range batchIDs {
+		if slices.Contains(batchIDs[:i], id) {
+			return nil, fmt.Errorf("batch %d is listed more than once", id)
+		}
+		b, err := store.GetBatch(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get batch %d: %w", id, err)
+		}
+		if b == nil {
+			return nil, fmt.Errorf("batch %d does not exist", id)
+		}
+		batches[i] = b
+	}
+
+	for i := 1; i < len(batches); i++ {
+		b := batches[i]
+		b.DependsOnBatchID = &batches[i-1].ID
+		b.MaxDependencyFailures = maxFailures
+		if err := ValidateDependency(ctx, store, b); err != nil {
+			return nil, fmt.Errorf("cannot chain batch %q after %q: %w", b.Name, batches[i-1].Name, err)
+		}
+		if err := store.UpdateBatch(ctx, b); err != nil {
+			return nil, fmt.Errorf("failed to update batch %d: %w", b.ID, err)
+		}
+	}
+	return batches, nil
+}
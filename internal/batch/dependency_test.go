@@ -0,0 +1,162 @@
+package batch
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func createDependencyBatch(t *testing.T, db *storage.Database, name, status string, dependsOn *int64, maxFailures *int) *models.Batch {
+	t.Helper()
+	b := &models.Batch{
+		Name:                  name,
+		Type:                  "wave",
+		Status:                status,
+		CreatedAt:             time.Now(),
+		DependsOnBatchID:      dependsOn,
+		MaxDependencyFailures: maxFailures,
+	}
+	if err := db.CreateBatch(context.Background(), b); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	return b
+}
+
+func TestCheckDependency(t *testing.T) {
+	_, db, cleanup := setupTestOrganizer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	wave1 := createDependencyBatch(t, db, "wave_1", models.BatchStatusReady, nil, nil)
+	for i, status := range []models.MigrationStatus{models.StatusComplete, models.StatusMigrationFailed, models.StatusMigrationFailed} {
+		repo := createTestRepository(t, db, "org/repo"+string(rune('a'+i)), 100, map[string]bool{})
+		repo.Status = string(status)
+		repo.BatchID = &wave1.ID
+		if err := db.UpdateRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to update repository: %v", err)
+		}
+	}
+
+	one, two := 1, 2
+	allowOne := createDependencyBatch(t, db, "wave_2", models.BatchStatusReady, &wave1.ID, &one)
+	allowTwo := createDependencyBatch(t, db, "wave_2b", models.BatchStatusReady, &wave1.ID, &two)
+
+	if status, err := CheckDependency(ctx, db, wave1); err != nil || status != nil {
+		t.Errorf("Expected no dependency, got %+v, %v", status, err)
+	}
+	status, err := CheckDependency(ctx, db, allowOne)
+	if err != nil || status.State != DependencyWaiting {
+		t.Fatalf("Expected to wait for a batch that has not migrated, got %+v, %v", status, err)
+	}
+
+	// A dry run that completed with errors does not satisfy the dependency
+	wave1.Status = models.BatchStatusCompletedWithErrors
+	if err := db.UpdateBatch(ctx, wave1); err != nil {
+		t.Fatalf("Failed to update batch: %v", err)
+	}
+	if status, _ := CheckDependency(ctx, db, allowOne); status.State != DependencyWaiting {
+		t.Errorf("Expected to wait until the migration has started, got %+v", status)
+	}
+
+	now := time.Now()
+	wave1.StartedAt = &now
+	if err := db.UpdateBatch(ctx, wave1); err != nil {
+		t.Fatalf("Failed to update batch: %v", err)
+	}
+	status, _ = CheckDependency(ctx, db, allowOne)
+	if status.State != DependencyBlocked || status.Failures != 2 || !strings.Contains(status.Reason, "more than the 1 allowed") {
+		t.Errorf("Expected two failures to block the batch, got %+v", status)
+	}
+	if status, _ := CheckDependency(ctx, db, allowTwo); status.State != DependencySatisfied {
+		t.Errorf("Expected two failures to be allowed, got %+v", status)
+	}
+
+	wave1.Status = models.BatchStatusCancelled
+	if err := db.UpdateBatch(ctx, wave1); err != nil {
+		t.Fatalf("Failed to update batch: %v", err)
+	}
+	if status, _ := CheckDependency(ctx, db, allowTwo); status.State != DependencyBlocked {
+		t.Errorf("Expected a cancelled dependency to block the batch, got %+v", status)
+	}
+}
+
+func TestValidateDependency(t *testing.T) {
+	_, db, cleanup := setupTestOrganizer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	wave1 := createDependencyBatch(t, db, "wave_1", models.BatchStatusReady, nil, nil)
+	wave2 := createDependencyBatch(t, db, "wave_2", models.BatchStatusReady, &wave1.ID, nil)
+	wave3 := createDependencyBatch(t, db, "wave_3", models.BatchStatusReady, &wave2.ID, nil)
+
+	missing, negative, zero := int64(9999), -1, 0
+	tests := []struct {
+		name    string
+		batch   *models.Batch
+		wantErr string
+	}{
+		{"new batch after the last wave", &models.Batch{DependsOnBatchID: &wave3.ID, MaxDependencyFailures: &zero}, ""},
+		{"no dependency", &models.Batch{ID: wave1.ID}, ""},
+		{"itself", &models.Batch{ID: wave1.ID, DependsOnBatchID: &wave1.ID}, "itself"},
+		{"missing batch", &models.Batch{DependsOnBatchID: &missing}, "does not exist"},
+		{"cycle", &models.Batch{ID: wave1.ID, DependsOnBatchID: &wave3.ID}, "already depends on this batch"},
+		{"negative limit", &models.Batch{DependsOnBatchID: &wave1.ID, MaxDependencyFailures: &negative}, "negative"},
+		{"limit without dependency", &models.Batch{MaxDependencyFailures: &zero}, "requires depends_on_batch_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDependency(ctx, db, tt.batch)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateDependency() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateDependency() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChainBatches(t *testing.T) {
+	_, db, cleanup := setupTestOrganizer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	a := createDependencyBatch(t, db, "wave_a", models.BatchStatusReady, nil, nil)
+	b := createDependencyBatch(t, db, "wave_b", models.BatchStatusReady, nil, nil)
+	c := createDependencyBatch(t, db, "wave_c", models.BatchStatusReady, nil, nil)
+
+	limit := 3
+	if _, err := ChainBatches(ctx, db, []int64{a.ID, b.ID, c.ID}, &limit); err != nil {
+		t.Fatalf("ChainBatches() error = %v", err)
+	}
+	for _, link := range []struct{ batch, after int64 }{{b.ID, a.ID}, {c.ID, b.ID}} {
+		saved, _ := db.GetBatch(ctx, link.batch)
+		if saved.DependsOnBatchID == nil || *saved.DependsOnBatchID != link.after ||
+			saved.MaxDependencyFailures == nil || *saved.MaxDependencyFailures != limit {
+			t.Errorf("Batch %d: expected to depend on %d allowing %d failures, got %v, %v",
+				link.batch, link.after, limit, saved.DependsOnBatchID, saved.MaxDependencyFailures)
+		}
+	}
+
+	if _, err := ChainBatches(ctx, db, []int64{c.ID, a.ID}, nil); err == nil || !strings.Contains(err.Error(), "already depends") {
+		t.Errorf("Expected chaining back to the start to be rejected, got %v", err)
+	}
+	if _, err := ChainBatches(ctx, db, []int64{a.ID, a.ID}, nil); err == nil {
+		t.Error("Expected a repeated batch to be rejected")
+	}
+
+	// Deleting a batch releases the batches that depended on it
+	if err := db.DeleteBatch(ctx, b.ID); err != nil {
+		t.Fatalf("DeleteBatch() error = %v", err)
+	}
+	if saved, _ := db.GetBatch(ctx, c.ID); saved.DependsOnBatchID != nil || saved.MaxDependencyFailures != nil {
+		t.Errorf("Expected the dependency on the deleted batch to be cleared, got %v", saved.DependsOnBatchID)
+	}
+}
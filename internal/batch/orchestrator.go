@@ -1,21 +1,26 @@
 package batch
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/calendar"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
 
 // Orchestrator coordinates batch organization, scheduling, and execution
 type Orchestrator struct {
-	organizer *Organizer
-	scheduler *Scheduler
-	storage   *storage.Database
-	logger    *slog.Logger
+	organizer       *Organizer
+	scheduler       *Scheduler
+	storage         *storage.Database
+	logger          *slog.Logger
+	requireApproval bool
 }
 
 // OrchestratorConfig holds configuration for the orchestrator
@@ -60,6 +65,11 @@ func NewOrchestrator(cfg OrchestratorConfig) (*Orchestrator, error) {
 	}, nil
 }
 
+// SetRequireApproval sets whether production migrations of batches need an approved request
+func (o *Orchestrator) SetRequireApproval(required bool) {
+	o.requireApproval = required
+}
+
 // CreateAndExecutePilot creates a pilot batch and executes it
 func (o *Orchestrator) CreateAndExecutePilot(ctx context.Context, name string, criteria PilotCriteria, dryRun bool) (*models.Batch, error) {
 	o.logger.Info("Creating and executing pilot batch",
@@ -67,6 +77,11 @@ func (o *Orchestrator) CreateAndExecutePilot(ctx context.Context, name string, c
 		"dry_run", dryRun,
 		"criteria", criteria)
 
+	// A new batch cannot have been approved yet
+	if o.requireApproval && !dryRun {
+		return nil, fmt.Errorf("pilot batches require approval before a production migration; create the batch, request approval and start it once approved")
+	}
+
 	// Create pilot batch
 	batch, repos, err := o.organizer.CreatePilotBatch(ctx, name, criteria)
 	if err != nil {
@@ -127,8 +142,14 @@ func (o *Orchestrator) CreateAndScheduleWaves(ctx context.Context, criteria Wave
 	return waves, nil
 }
 
-// ExecuteScheduledBatches executes all batches that are scheduled to start
-func (o *Orchestrator) ExecuteScheduledBatches(ctx context.Context, dryRun bool) error {
+// ExecuteScheduledBatches executes all batches that are scheduled to start. In production runs, a
+// batch that depends on another starts once its dependency is satisfied and its scheduled time, if
+// any, has passed. Production runs also respect the migration windows: a batch that comes due
+// inside a blackout, or outside the allowed windows, is rescheduled for the next time migrations
+// are allowed, or unscheduled if the blackout refuses batches. When approval is required, a batch
+// waits until its request is approved. With a fence, batches only start while the fence's leader
+// lock is still current.
+func (o *Orchestrator) ExecuteScheduledBatches(ctx context.Context, dryRun bool, fence *storage.LeaderFence) error {
 	o.logger.Info("Checking for scheduled batches", "dry_run", dryRun)
 
 	// Get all batches
@@ -137,27 +158,46 @@ func (o *Orchestrator) ExecuteScheduledBatches(ctx context.Context, dryRun bool)
 		return fmt.Errorf("failed to list batches: %w", err)
 	}
 
+	var cal *calendar.Calendar
+	if !dryRun {
+		if cal, err = calendar.Load(ctx, o.storage); err != nil {
+			return err
+		}
+	}
+
 	now := time.Now()
 	executed := 0
 
 	for _, batch := range batches {
 		// Check if batch is scheduled and ready to execute
-		if batch.ScheduledAt != nil &&
-			batch.Status == models.BatchStatusReady &&
-			batch.ScheduledAt.Before(now) {
+		if batch.Status != models.BatchStatusReady || !o.isDue(ctx, batch, now, dryRun) {
+			continue
+		}
 
-			o.logger.Info("Executing scheduled batch",
-				"batch_id", batch.ID,
-				"batch_name", batch.Name,
-				"scheduled_at", batch.ScheduledAt)
-
-			if err := o.scheduler.ExecuteBatch(ctx, batch.ID, dryRun); err != nil {
-				o.logger.Error("Failed to execute scheduled batch",
-					"batch_id", batch.ID,
-					"error", err)
-			} else {
-				executed++
+		if cal != nil && !o.checkMigrationWindows(ctx, cal, batch, now) {
+			continue
+		}
+
+		if !dryRun && !o.isApproved(ctx, batch) {
+			continue
+		}
+
+		o.logger.Info("Executing scheduled batch",
+			"batch_id", batch.ID,
+			"batch_name", batch.Name,
+			"scheduled_at", batch.ScheduledAt,
+			"depends_on_batch_id", batch.DependsOnBatchID)
+
+		if err := o.startBatch(ctx, batch.ID, dryRun, fence); err != nil {
+			if errors.Is(err, ErrBatchStartLost) || IsApprovalError(err) {
+				o.logger.Warn("Scheduled batch not started", "batch_id", batch.ID, "reason", err.Error())
+				continue
 			}
+			o.logger.Error("Failed to execute scheduled batch",
+				"batch_id", batch.ID,
+				"error", err)
+		} else {
+			executed++
 		}
 	}
 
@@ -166,6 +206,106 @@ func (o *Orchestrator) ExecuteScheduledBatches(ctx context.Context, dryRun bool)
 	return nil
 }
 
+// isDue reports whether a ready batch should start now: once its scheduled time has passed, and in
+// production runs, once the batch it depends on has completed within its failure limit
+func (o *Orchestrator) isDue(ctx context.Context, batch *models.Batch, now time.Time, dryRun bool) bool {
+	scheduled := batch.ScheduledAt != nil && batch.ScheduledAt.Before(now)
+	if dryRun || batch.DependsOnBatchID == nil {
+		return scheduled
+	}
+	if batch.ScheduledAt != nil && !scheduled {
+		return false
+	}
+
+	dependency, err := CheckDependency(ctx, o.storage, batch)
+	if err != nil {
+		o.logger.Error("Failed to check batch dependency", "batch_id", batch.ID, "error", err)
+		return false
+	}
+	if dependency.State != DependencySatisfied {
+		o.logger.Debug("Batch dependency not satisfied",
+			"batch_id", batch.ID,
+			"batch_name", batch.Name,
+			"state", dependency.State,
+			"reason", dependency.Reason)
+		return false
+	}
+	return true
+}
+
+// isApproved reports whether a batch's production migration may start, which needs an approved
+// request when approval is required
+func (o *Orchestrator) isApproved(ctx context.Context, batch *models.Batch) bool {
+	if !o.requireApproval {
+		return true
+	}
+	if err := CheckApproval(ctx, o.storage, batch); err != nil {
+		if IsApprovalError(err) {
+			o.logger.Debug("Batch not approved", "batch_id", batch.ID, "batch_name", batch.Name, "reason", err.Error())
+		} else {
+			o.logger.Error("Failed to check batch approval", "batch_id", batch.ID, "error", err)
+		}
+		return false
+	}
+	return true
+}
+
+// startBatch starts a batch. When approval is required, a production start uses up the batch's
+// approval only once it has claimed the batch, so a start that fails first leaves it unused.
+func (o *Orchestrator) startBatch(ctx context.Context, batchID int64, dryRun bool, fence *storage.LeaderFence) error {
+	if !dryRun && o.requireApproval {
+		return o.scheduler.ExecuteApprovedBatch(ctx, batchID, fence, ApprovalActorScheduler)
+	}
+	return o.scheduler.ExecuteFencedBatch(ctx, batchID, dryRun, fence)
+}
+
+// checkMigrationWindows reports whether a due batch may run now. If not, the batch is rescheduled
+// for the next time migrations are allowed for its organizations, or unscheduled when a refusing
+// blackout is in force.
+func (o *Orchestrator) checkMigrationWindows(ctx context.Context, cal *calendar.Calendar, batch *models.Batch, now time.Time) bool {
+	orgs, err := calendar.BatchOrganizations(ctx, o.storage, batch.ID)
+	if err != nil {
+		o.logger.Error("Failed to check migration windows for batch", "batch_id", batch.ID, "error", err)
+		return false
+	}
+	decision := cal.Check(orgs, now)
+	if decision.Open {
+		return true
+	}
+
+	if decision.Refused {
+		if batch.ScheduledAt == nil {
+			// A batch started by its dependency waits for the blackout to end
+			return false
+		}
+		o.logger.Warn("Unscheduling batch refused by a blackout window",
+			"batch_id", batch.ID,
+			"batch_name", batch.Name,
+			"reason", decision.Reason)
+		batch.ScheduledAt = nil
+	} else {
+		next, ok := cal.NextOpen(orgs, now)
+		if !ok {
+			o.logger.Warn("Holding scheduled batch: migrations are not allowed within 90 days",
+				"batch_id", batch.ID,
+				"batch_name", batch.Name,
+				"reason", decision.Reason)
+			return false
+		}
+		o.logger.Info("Deferring scheduled batch outside the migration windows",
+			"batch_id", batch.ID,
+			"batch_name", batch.Name,
+			"reason", decision.Reason,
+			"scheduled_at", next)
+		batch.ScheduledAt = &next
+	}
+
+	if err := o.storage.UpdateBatch(ctx, batch); err != nil {
+		o.logger.Error("Failed to reschedule batch", "batch_id", batch.ID, "error", err)
+	}
+	return false
+}
+
 // GetAllBatchProgress returns progress for all batches
 func (o *Orchestrator) GetAllBatchProgress(ctx context.Context) ([]*BatchProgress, error) {
 	batches, err := o.storage.ListBatches(ctx)
@@ -224,7 +364,9 @@ func (o *Orchestrator) IsBatchRunning(batchID int64) bool {
 }
 
 // ExecuteSequentialWaves executes all waves in sequence
-// Useful for controlled rollout where each wave must complete before the next
+// Useful for controlled rollout where each wave must complete before the next. Production runs
+// chain the waves with batch dependencies and start the first, so the scheduler worker starts each
+// following wave once the previous one completes; dry runs are executed here one after another.
 func (o *Orchestrator) ExecuteSequentialWaves(ctx context.Context, dryRun bool) error {
 	o.logger.Info("Starting sequential wave execution", "dry_run", dryRun)
 
@@ -241,6 +383,7 @@ func (o *Orchestrator) ExecuteSequentialWaves(ctx context.Context, dryRun bool)
 			waves = append(waves, batch)
 		}
 	}
+	slices.SortFunc(waves, func(a, b *models.Batch) int { return cmp.Compare(a.ID, b.ID) })
 
 	if len(waves) == 0 {
 		o.logger.Info("No waves found to execute")
@@ -253,6 +396,20 @@ func (o *Orchestrator) ExecuteSequentialWaves(ctx context.Context, dryRun bool)
 		batchIDs[i] = wave.ID
 	}
 
+	if !dryRun {
+		if len(batchIDs) > 1 {
+			if _, err := ChainBatches(ctx, o.storage, batchIDs, nil); err != nil {
+				return fmt.Errorf("failed to chain waves: %w", err)
+			}
+		}
+		if !o.isApproved(ctx, waves[0]) {
+			o.logger.Info("Waves chained; the first wave needs an approved request before it can start", "wave_count", len(batchIDs), "batch_id", batchIDs[0])
+			return nil
+		}
+		o.logger.Info("Waves chained; starting the first", "wave_count", len(batchIDs))
+		return o.startBatch(ctx, batchIDs[0], dryRun, nil)
+	}
+
 	o.logger.Info("Executing waves sequentially", "wave_count", len(batchIDs))
 
 	return o.scheduler.ExecuteSequentialBatches(ctx, batchIDs, dryRun)
@@ -434,51 +434,27 @@ func DefaultWaveCriteria() WaveCriteria {
 	}
 }
 
-// OrganizeIntoWaves organizes pending repositories into migration waves
+// OrganizeIntoWaves organizes pending repositories into migration waves following the wave
+// plan, so repositories that depend on each other are migrated together or in order
 func (o *Organizer) OrganizeIntoWaves(ctx context.Context, criteria WaveCriteria) ([]*models.Batch, error) {
 	o.logger.Info("Organizing repositories into waves", "criteria", criteria)
 
-	// Get all pending repositories (not in any batch, exclude wont_migrate)
-	repos, err := o.storage.ListRepositories(ctx, map[string]any{
-		"status":          models.StatusPending,
-		"include_details": true,
-	})
+	plan, err := o.PlanWaves(ctx, criteria)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list repositories: %w", err)
+		return nil, err
 	}
 
-	// Filter out repos that already have a batch or are marked wont_migrate
-	var unbatched []*models.Repository
-	for _, repo := range repos {
-		if repo.BatchID == nil && repo.Status != string(models.StatusWontMigrate) {
-			unbatched = append(unbatched, repo)
-		}
-	}
-
-	o.logger.Info("Found unbatched repositories", "count", len(unbatched))
-
-	if len(unbatched) == 0 {
-		return []*models.Batch{}, nil
-	}
-
-	// Sort repositories based on criteria
-	o.sortRepositories(unbatched, criteria)
-
-	// Group into waves
 	var waves []*models.Batch
-	waveNum := 1
-
-	for i := 0; i < len(unbatched); i += criteria.WaveSize {
-		end := min(i+criteria.WaveSize, len(unbatched))
-
-		waveRepos := unbatched[i:end]
+	total := 0
+	for _, planned := range plan.Waves {
+		waveNum := planned.Number
 
 		// Create wave batch
 		batch := &models.Batch{
 			Name:            fmt.Sprintf("Wave %d", waveNum),
-			Description:     strPtr(fmt.Sprintf("Migration wave %d with %d repositories", waveNum, len(waveRepos))),
+			Description:     strPtr(fmt.Sprintf("Migration wave %d with %d repositories", waveNum, len(planned.Repositories))),
 			Type:            fmt.Sprintf("wave_%d", waveNum),
-			RepositoryCount: len(waveRepos),
+			RepositoryCount: len(planned.Repositories),
 			Status:          models.BatchStatusReady,
 			CreatedAt:       time.Now(),
 		}
@@ -488,10 +464,11 @@ func (o *Organizer) OrganizeIntoWaves(ctx context.Context, criteria WaveCriteria
 			continue
 		}
 
-		o.logger.Info("Created wave batch", "wave", waveNum, "batch_id", batch.ID, "repo_count", len(waveRepos))
+		o.logger.Info("Created wave batch", "wave", waveNum, "batch_id", batch.ID, "repo_count", len(planned.Repositories))
 
 		// Assign repositories to wave
-		for _, repo := range waveRepos {
+		for _, placement := range planned.Repositories {
+			repo := placement.repo
 			repo.BatchID = &batch.ID
 			repo.Priority = 0 // Normal priority
 			if err := o.storage.UpdateRepository(ctx, repo); err != nil {
@@ -499,14 +476,16 @@ func (o *Organizer) OrganizeIntoWaves(ctx context.Context, criteria WaveCriteria
 					"repo", repo.FullName,
 					"wave", waveNum,
 					"error", err)
+				continue
 			}
+			o.logger.Debug("Assigned repository to wave", "repo", repo.FullName, "wave", waveNum, "reason", placement.Reason)
 		}
 
 		waves = append(waves, batch)
-		waveNum++
+		total += len(planned.Repositories)
 	}
 
-	o.logger.Info("Successfully organized waves", "wave_count", len(waves), "total_repos", len(unbatched))
+	o.logger.Info("Successfully organized waves", "wave_count", len(waves), "total_repos", total, "dependency_clusters", plan.Clusters)
 
 	return waves, nil
 }
@@ -2,11 +2,13 @@ package batch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/calendar"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
@@ -52,7 +54,9 @@ func NewScheduler(cfg SchedulerConfig) (*Scheduler, error) {
 	}, nil
 }
 
-// ScheduleBatch schedules a batch to start at a specific time
+// ScheduleBatch schedules a batch to start at a specific time. A time inside a blackout window, or
+// outside the allowed migration windows, is deferred to the next time migrations are allowed, or
+// refused with a calendar.ClosedError when the blackout's policy is to refuse.
 func (s *Scheduler) ScheduleBatch(ctx context.Context, batchID int64, scheduledAt time.Time) error {
 	s.logger.Info("Scheduling batch", "batch_id", batchID, "scheduled_at", scheduledAt)
 
@@ -64,6 +68,16 @@ func (s *Scheduler) ScheduleBatch(ctx context.Context, batchID int64, scheduledA
 		return fmt.Errorf("batch not found")
 	}
 
+	adjusted, err := calendar.ScheduleBatch(ctx, s.storage, batchID, scheduledAt)
+	if err != nil {
+		return fmt.Errorf("failed to schedule batch: %w", err)
+	}
+	if !adjusted.Equal(scheduledAt) {
+		s.logger.Info("Deferring batch outside the migration windows",
+			"batch_id", batchID, "requested_at", scheduledAt, "scheduled_at", adjusted)
+		scheduledAt = adjusted
+	}
+
 	// Update batch scheduled time
 	batch.ScheduledAt = &scheduledAt
 	if err := s.storage.UpdateBatch(ctx, batch); err != nil {
@@ -75,8 +89,32 @@ func (s *Scheduler) ScheduleBatch(ctx context.Context, batchID int64, scheduledA
 	return nil
 }
 
+// ErrBatchStartLost is returned when a batch could not be moved to in_progress because its status
+// changed since it was read, or the leader starting it lost its lock
+var ErrBatchStartLost = errors.New("batch was started elsewhere or the scheduler lost leadership")
+
 // ExecuteBatch executes all migrations in a batch
 func (s *Scheduler) ExecuteBatch(ctx context.Context, batchID int64, dryRun bool) error {
+	return s.ExecuteFencedBatch(ctx, batchID, dryRun, nil)
+}
+
+// ExecuteFencedBatch executes all migrations in a batch, starting it only while the fence's leader
+// lock is current. A nil fence starts the batch regardless of leadership.
+func (s *Scheduler) ExecuteFencedBatch(ctx context.Context, batchID int64, dryRun bool, fence *storage.LeaderFence) error {
+	return s.executeBatch(ctx, batchID, dryRun, fence, "")
+}
+
+// ExecuteApprovedBatch starts the production migration of a batch like ExecuteFencedBatch, using
+// up the batch's approval on behalf of actor. The approval is used in the same transaction that
+// claims the start, so a start that fails before then leaves it for the next attempt. Returns an
+// *ApprovalError if the batch is not approved.
+func (s *Scheduler) ExecuteApprovedBatch(ctx context.Context, batchID int64, fence *storage.LeaderFence, actor string) error {
+	return s.executeBatch(ctx, batchID, false, fence, actor)
+}
+
+// executeBatch executes all migrations in a batch. With an approval actor, the start uses up the
+// batch's approval.
+func (s *Scheduler) executeBatch(ctx context.Context, batchID int64, dryRun bool, fence *storage.LeaderFence, approvalActor string) error {
 	s.logger.Info("Starting batch execution", "batch_id", batchID, "dry_run", dryRun)
 
 	// Check if batch is already running
@@ -122,7 +160,27 @@ func (s *Scheduler) ExecuteBatch(ctx context.Context, batchID int64, dryRun bool
 
 	s.logger.Info("Found migratable repositories", "count", len(migratable), "total", len(repos))
 
-	// Update batch status and timing
+	// Claim the batch so it is started once, then record the timing
+	var claimed bool
+	if approvalActor != "" {
+		approval, err := checkApproval(ctx, s.storage, batch)
+		if err != nil {
+			return err
+		}
+		claimed, err = s.storage.ClaimApprovedBatchStart(ctx, batchID, batch.Status, fence, approval.ID, &models.BatchApproval{
+			BatchID: batchID,
+			Action:  models.BatchApprovalStarted,
+			Actor:   approvalActor,
+		})
+		if err != nil {
+			return err
+		}
+	} else if claimed, err = s.storage.ClaimBatchStart(ctx, batchID, batch.Status, fence); err != nil {
+		return err
+	}
+	if !claimed {
+		return fmt.Errorf("batch %d: %w", batchID, ErrBatchStartLost)
+	}
 	batch.Status = models.BatchStatusInProgress
 	now := time.Now()
 	if dryRun {
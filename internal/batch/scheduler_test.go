@@ -2,6 +2,8 @@ package batch
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -10,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/calendar"
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
@@ -255,6 +258,35 @@ func TestScheduleBatch(t *testing.T) {
 			t.Error("Expected error when batch not found")
 		}
 	})
+
+	t.Run("deferred or refused by blackout windows", func(t *testing.T) {
+		freezeStart := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+		freezeEnd := freezeStart.Add(24 * time.Hour)
+		auditEnd := freezeEnd.Add(24 * time.Hour)
+		for _, window := range []*models.MigrationWindow{
+			{Name: "Freeze", Kind: models.MigrationWindowKindBlackout, Policy: models.MigrationWindowPolicyDefer,
+				TimeZone: "UTC", StartsAt: &freezeStart, EndsAt: &freezeEnd},
+			{Name: "Audit", Kind: models.MigrationWindowKindBlackout, Policy: models.MigrationWindowPolicyRefuse,
+				TimeZone: "UTC", StartsAt: &freezeEnd, EndsAt: &auditEnd},
+		} {
+			if err := db.CreateMigrationWindow(ctx, window); err != nil {
+				t.Fatalf("Failed to create migration window: %v", err)
+			}
+		}
+
+		if err := scheduler.ScheduleBatch(ctx, batch.ID, freezeStart.Add(time.Hour)); err != nil {
+			t.Fatalf("ScheduleBatch() error = %v", err)
+		}
+		updated, _ := db.GetBatch(ctx, batch.ID)
+		if updated.ScheduledAt == nil || !updated.ScheduledAt.Equal(auditEnd) {
+			t.Errorf("Expected the batch deferred past both blackouts to %v, got %v", auditEnd, updated.ScheduledAt)
+		}
+
+		err := scheduler.ScheduleBatch(ctx, batch.ID, freezeEnd.Add(time.Hour))
+		if !calendar.IsClosed(err) {
+			t.Errorf("Expected the refusing blackout to reject the batch, got %v", err)
+		}
+	})
 }
 
 func TestExecuteBatch(t *testing.T) {
@@ -389,6 +421,78 @@ func TestExecuteBatch(t *testing.T) {
 	})
 }
 
+func TestExecuteApprovedBatch(t *testing.T) {
+	scheduler, db, _, cleanup := setupTestScheduler(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	batch := &models.Batch{Name: "Approved Batch", Type: "wave", Status: models.BatchStatusReady, CreatedAt: time.Now()}
+	if err := db.CreateBatch(ctx, batch); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	repo := &models.Repository{
+		FullName:     "org/repo1",
+		Status:       string(models.StatusDryRunComplete),
+		Source:       "github",
+		DiscoveredAt: time.Now(),
+		UpdatedAt:    time.Now(),
+		BatchID:      &batch.ID,
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	if err := scheduler.ExecuteApprovedBatch(ctx, batch.ID, nil, ApprovalActorScheduler); !IsApprovalError(err) {
+		t.Fatalf("Expected an unapproved batch not to start, got %v", err)
+	}
+
+	summary, _ := json.Marshal(DryRunSummary{Repositories: 1, RepositoryIDs: []int64{repo.ID}})
+	for _, step := range []*models.BatchApproval{
+		{BatchID: batch.ID, Action: models.BatchApprovalRequested, Actor: "alice", DryRunSummary: summary},
+		{BatchID: batch.ID, Action: models.BatchApprovalApproved, Actor: "bob"},
+	} {
+		if err := db.CreateBatchApproval(ctx, step); err != nil {
+			t.Fatalf("CreateBatchApproval() error = %v", err)
+		}
+	}
+
+	// Starts that fail before claiming the batch leave the approval unused
+	stale := &storage.LeaderFence{Name: "batch-scheduler", OwnerID: "replica-1", Token: 1}
+	if err := scheduler.ExecuteApprovedBatch(ctx, batch.ID, stale, ApprovalActorScheduler); !errors.Is(err, ErrBatchStartLost) {
+		t.Fatalf("Expected a stale fence to lose the start, got %v", err)
+	}
+	if err := CheckApproval(ctx, db, batch); err != nil {
+		t.Errorf("Expected the approval to be unused after a lost start, got %v", err)
+	}
+
+	repo.Status = string(models.StatusComplete)
+	if err := db.UpdateRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+	if err := scheduler.ExecuteApprovedBatch(ctx, batch.ID, nil, ApprovalActorScheduler); err == nil {
+		t.Fatal("Expected a batch without migratable repositories not to start")
+	}
+	if err := CheckApproval(ctx, db, batch); err != nil {
+		t.Errorf("Expected the approval to be unused after a failed start, got %v", err)
+	}
+
+	repo.Status = string(models.StatusDryRunComplete)
+	if err := db.UpdateRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+	if err := scheduler.ExecuteApprovedBatch(ctx, batch.ID, nil, ApprovalActorScheduler); err != nil {
+		t.Fatalf("ExecuteApprovedBatch() error = %v", err)
+	}
+	waitForBatchCompletion(t, scheduler, batch.ID, 5*time.Second)
+	approvals, err := db.ListBatchApprovals(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("ListBatchApprovals() error = %v", err)
+	}
+	if len(approvals) != 3 || approvals[1].UsedAt == nil || approvals[2].Action != models.BatchApprovalStarted || approvals[2].Actor != ApprovalActorScheduler {
+		t.Errorf("Expected the start to use the approval, got %+v", approvals)
+	}
+}
+
 func TestCancelBatch(t *testing.T) {
 	scheduler, db, executor, cleanup := setupTestScheduler(t)
 	defer cleanup()
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/leader"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
@@ -15,6 +16,7 @@ type StatusUpdater struct {
 	storage  *storage.Database
 	logger   *slog.Logger
 	interval time.Duration
+	elector  *leader.Elector
 	stopCh   chan struct{}
 }
 
@@ -22,7 +24,8 @@ type StatusUpdater struct {
 type StatusUpdaterConfig struct {
 	Storage  *storage.Database
 	Logger   *slog.Logger
-	Interval time.Duration // How often to check and update statuses
+	Interval time.Duration   // How often to check and update statuses
+	Elector  *leader.Elector // Optional; when set, only the leader replica updates statuses
 }
 
 // NewStatusUpdater creates a new batch status updater
@@ -41,6 +44,7 @@ func NewStatusUpdater(cfg StatusUpdaterConfig) (*StatusUpdater, error) {
 		storage:  cfg.Storage,
 		logger:   cfg.Logger,
 		interval: cfg.Interval,
+		elector:  cfg.Elector,
 		stopCh:   make(chan struct{}),
 	}, nil
 }
@@ -49,6 +53,13 @@ func NewStatusUpdater(cfg StatusUpdaterConfig) (*StatusUpdater, error) {
 func (su *StatusUpdater) Start(ctx context.Context) {
 	su.logger.Info("Starting batch status updater", "interval", su.interval)
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if su.elector != nil {
+		go su.elector.Run(ctx)
+	}
+
 	ticker := time.NewTicker(su.interval)
 	defer ticker.Stop()
 
@@ -78,6 +89,11 @@ func (su *StatusUpdater) Stop() {
 //
 //nolint:gocyclo // Complex status transition logic with multiple edge cases
 func (su *StatusUpdater) updateBatchStatuses(ctx context.Context) {
+	if su.elector != nil && !su.elector.Fence(ctx) {
+		su.logger.Debug("Skipping batch status update - not the leader")
+		return
+	}
+
 	batches, err := su.storage.ListBatches(ctx)
 	if err != nil {
 		su.logger.Error("Failed to list batches for status update", "error", err)
@@ -0,0 +1,288 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// WavePlan is a proposed organization of unbatched repositories into migration waves
+type WavePlan struct {
+	WaveSize int            `json:"wave_size"`
+	Waves    []*PlannedWave `json:"waves"`
+	Clusters int            `json:"clusters"` // Dependency cycles kept together in one wave
+}
+
+// PlannedWave is one wave of a wave plan
+type PlannedWave struct {
+	Number       int              `json:"number"`
+	Repositories []*WavePlacement `json:"repositories"`
+}
+
+// WavePlacement explains why a repository was placed in its wave
+type WavePlacement struct {
+	RepositoryID int64    `json:"repository_id"`
+	FullName     string   `json:"full_name"`
+	Wave         int      `json:"wave"`
+	Cluster      []string `json:"cluster,omitempty"`    // Other repositories in the same dependency cycle
+	DependsOn    []string `json:"depends_on,omitempty"` // Planned repositories this one depends on, outside its cluster
+	Reason       string   `json:"reason"`
+
+	repo *models.Repository
+}
+
+// PlanWaves proposes an organization of pending, unbatched repositories into waves without creating
+// any batches. Repositories are taken in the criteria's sort order, adjusted so that:
+//   - repositories that depend on each other in a cycle (a strongly connected component of the local
+//     dependency graph) are kept together in one wave
+//   - a repository is placed in the same wave as its dependencies or a later one
+//   - no wave holds more than WaveSize repositories, except for a cycle larger than WaveSize, which
+//     gets a wave of its own
+//
+// Only dependencies between repositories being planned are considered; repositories that are
+// already batched or migrated do not constrain the plan.
+func (o *Organizer) PlanWaves(ctx context.Context, criteria WaveCriteria) (*WavePlan, error) {
+	if criteria.WaveSize <= 0 {
+		return nil, fmt.Errorf("wave size must be greater than zero")
+	}
+
+	repos, err := o.listUnbatchedRepositories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o.sortRepositories(repos, criteria)
+
+	pairs, err := o.storage.GetAllLocalDependencyPairs(ctx, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load repository dependencies: %w", err)
+	}
+
+	return planWaves(repos, pairs, criteria), nil
+}
+
+// listUnbatchedRepositories returns pending repositories that are not in a batch or marked wont_migrate
+func (o *Organizer) listUnbatchedRepositories(ctx context.Context) ([]*models.Repository, error) {
+	repos, err := o.storage.ListRepositories(ctx, map[string]any{
+		"status":          models.StatusPending,
+		"include_details": true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var unbatched []*models.Repository
+	for _, repo := range repos {
+		if repo.BatchID == nil && repo.Status != string(models.StatusWontMigrate) {
+			unbatched = append(unbatched, repo)
+		}
+	}
+	return unbatched, nil
+}
+
+// planWaves places sorted repositories into waves. The dependency graph is condensed into its
+// strongly connected components, which are packed into waves in topological order, dependencies
+// first, breaking ties by the repositories' sort order.
+func planWaves(repos []*models.Repository, pairs []storage.DependencyPair, criteria WaveCriteria) *WavePlan {
+	plan := &WavePlan{WaveSize: criteria.WaveSize, Waves: []*PlannedWave{}}
+	if len(repos) == 0 {
+		return plan
+	}
+
+	index := make(map[string]int, len(repos))
+	for i, repo := range repos {
+		index[repo.FullName] = i
+	}
+	deps := make([][]int, len(repos))
+	for _, pair := range pairs {
+		from, ok := index[pair.SourceRepo]
+		to, ok2 := index[pair.TargetRepo]
+		if !ok || !ok2 || from == to || slices.Contains(deps[from], to) {
+			continue
+		}
+		deps[from] = append(deps[from], to)
+	}
+	for _, d := range deps {
+		slices.Sort(d)
+	}
+
+	components, componentOf := stronglyConnectedComponents(deps)
+
+	// Dependencies between components, and how many unplaced components each one waits for
+	dependents := make([][]int, len(components))
+	waiting := make([]int, len(components))
+	for c, members := range components {
+		seen := make(map[int]bool)
+		for _, member := range members {
+			for _, dep := range deps[member] {
+				if d := componentOf[dep]; d != c && !seen[d] {
+					seen[d] = true
+					dependents[d] = append(dependents[d], c)
+					waiting[c]++
+				}
+			}
+		}
+	}
+
+	// Components are ranked by their first repository in sort order
+	var ready []int
+	for c := range components {
+		if waiting[c] == 0 {
+			ready = append(ready, c)
+		}
+	}
+	rank := func(c int) int { return components[c][0] }
+	slices.SortFunc(ready, func(a, b int) int { return rank(a) - rank(b) })
+
+	placements := make([]*WavePlacement, len(repos))
+	oversized := make(map[int]bool)
+	wave, waveFill := 1, 0
+	for len(ready) > 0 {
+		c := ready[0]
+		ready = ready[1:]
+		size := len(components[c])
+
+		switch {
+		case size > criteria.WaveSize:
+			if waveFill > 0 {
+				wave++
+			}
+			oversized[c] = true
+			waveFill = criteria.WaveSize // Nothing else joins this wave
+		case waveFill+size > criteria.WaveSize:
+			wave++
+			waveFill = size
+		default:
+			waveFill += size
+		}
+		for _, member := range components[c] {
+			placements[member] = &WavePlacement{
+				RepositoryID: repos[member].ID,
+				FullName:     repos[member].FullName,
+				Wave:         wave,
+				repo:         repos[member],
+			}
+		}
+		if size > 1 {
+			plan.Clusters++
+		}
+
+		for _, d := range dependents[c] {
+			waiting[d]--
+			if waiting[d] == 0 {
+				pos, _ := slices.BinarySearchFunc(ready, d, func(a, b int) int { return rank(a) - rank(b) })
+				ready = slices.Insert(ready, pos, d)
+			}
+		}
+	}
+
+	for i, placement := range placements {
+		members := components[componentOf[i]]
+		for _, member := range members {
+			if member != i {
+				placement.Cluster = append(placement.Cluster, repos[member].FullName)
+			}
+		}
+		var dependsOn []string
+		for _, dep := range deps[i] {
+			if componentOf[dep] != componentOf[i] {
+				placement.DependsOn = append(placement.DependsOn, repos[dep].FullName)
+				dependsOn = append(dependsOn, fmt.Sprintf("%s (wave %d)", repos[dep].FullName, placements[dep].Wave))
+			}
+		}
+		placement.Reason = placementReason(placement, dependsOn, oversized[componentOf[i]], criteria)
+	}
+
+	for _, placement := range placements {
+		for len(plan.Waves) < placement.Wave {
+			plan.Waves = append(plan.Waves, &PlannedWave{Number: len(plan.Waves) + 1})
+		}
+		wave := plan.Waves[placement.Wave-1]
+		wave.Repositories = append(wave.Repositories, placement)
+	}
+	return plan
+}
+
+// placementReason explains a repository's wave in plain language
+func placementReason(placement *WavePlacement, dependsOn []string, oversized bool, criteria WaveCriteria) string {
+	var reasons []string
+	if len(placement.Cluster) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Kept in wave %d with %s, which it depends on in a cycle.",
+			placement.Wave, strings.Join(placement.Cluster, ", ")))
+	}
+	if oversized {
+		reasons = append(reasons, fmt.Sprintf("The cycle has %d repositories, more than the wave size of %d, so it has a wave of its own.",
+			len(placement.Cluster)+1, criteria.WaveSize))
+	}
+	if len(dependsOn) > 0 {
+		reasons = append(reasons, fmt.Sprintf("Placed in wave %d, no earlier than its dependencies: %s.",
+			placement.Wave, strings.Join(dependsOn, ", ")))
+	}
+	if len(reasons) == 0 {
+		sortBy := criteria.SortBy
+		if sortBy == "" {
+			sortBy = "org"
+		}
+		reasons = append(reasons, fmt.Sprintf("No dependencies on other planned repositories; placed in wave %d by %s order.",
+			placement.Wave, sortBy))
+	}
+	return strings.Join(reasons, " ")
+}
+
+// stronglyConnectedComponents returns the strongly connected components of a graph given as
+// adjacency lists (Tarjan's algorithm), each with its nodes in ascending order, and the component
+// of every node
+func stronglyConnectedComponents(edges [][]int) ([][]int, []int) {
+	n := len(edges)
+	order := make([]int, n) // Discovery order, 0 when unvisited
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	componentOf := make([]int, n)
+	var stack []int
+	var components [][]int
+	counter := 0
+
+	var visit func(v int)
+	visit = func(v int) {
+		counter++
+		order[v], low[v] = counter, counter
+		stack = append(stack, v)
+		onStack[v] = true
+
+		for _, w := range edges[v] {
+			switch {
+			case order[w] == 0:
+				visit(w)
+				low[v] = min(low[v], low[w])
+			case onStack[w]:
+				low[v] = min(low[v], order[w])
+			}
+		}
+
+		if low[v] == order[v] {
+			var component []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				componentOf[w] = len(components)
+				component = append(component, w)
+				if w == v {
+					break
+				}
+			}
+			slices.Sort(component)
+			components = append(components, component)
+		}
+	}
+
+	for v := range n {
+		if order[v] == 0 {
+			visit(v)
+		}
+	}
+	return components, componentOf
+}
@@ -0,0 +1,154 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func planRepos(names ...string) []*models.Repository {
+	repos := make([]*models.Repository, len(names))
+	for i, name := range names {
+		repos[i] = &models.Repository{ID: int64(i + 1), FullName: name}
+	}
+	return repos
+}
+
+// planWaveOf maps each planned repository to its wave number
+func planWaveOf(plan *WavePlan) map[string]*WavePlacement {
+	placements := make(map[string]*WavePlacement)
+	for _, wave := range plan.Waves {
+		for _, placement := range wave.Repositories {
+			placements[placement.FullName] = placement
+		}
+	}
+	return placements
+}
+
+func TestPlanWaves_KeepsCyclesTogether(t *testing.T) {
+	repos := planRepos("org/a", "org/b", "org/c", "org/d", "org/e")
+	pairs := []storage.DependencyPair{
+		{SourceRepo: "org/a", TargetRepo: "org/e"},
+		{SourceRepo: "org/e", TargetRepo: "org/a"},
+	}
+
+	plan := planWaves(repos, pairs, WaveCriteria{WaveSize: 2, SortBy: "name"})
+
+	placements := planWaveOf(plan)
+	if placements["org/a"].Wave != placements["org/e"].Wave {
+		t.Errorf("Expected org/a and org/e in the same wave, got %d and %d", placements["org/a"].Wave, placements["org/e"].Wave)
+	}
+	if plan.Clusters != 1 || len(plan.Waves) != 3 {
+		t.Errorf("Expected 1 cluster in 3 waves, got %d in %d", plan.Clusters, len(plan.Waves))
+	}
+	for _, wave := range plan.Waves {
+		if len(wave.Repositories) > 2 {
+			t.Errorf("Wave %d exceeds the wave size: %d repositories", wave.Number, len(wave.Repositories))
+		}
+	}
+	if reason := placements["org/a"].Reason; !strings.Contains(reason, "with org/e, which it depends on in a cycle") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+}
+
+func TestPlanWaves_DependentsNeverPrecedeDependencies(t *testing.T) {
+	// org/a sorts first but depends on org/z, which depends on org/m
+	repos := planRepos("org/a", "org/b", "org/c", "org/m", "org/z")
+	pairs := []storage.DependencyPair{
+		{SourceRepo: "org/a", TargetRepo: "org/z"},
+		{SourceRepo: "org/z", TargetRepo: "org/m"},
+		{SourceRepo: "org/b", TargetRepo: "other-org/not-planned"},
+	}
+
+	plan := planWaves(repos, pairs, WaveCriteria{WaveSize: 2, SortBy: "name"})
+
+	placements := planWaveOf(plan)
+	for _, pair := range pairs[:2] {
+		if placements[pair.SourceRepo].Wave < placements[pair.TargetRepo].Wave {
+			t.Errorf("%s (wave %d) is placed before its dependency %s (wave %d)", pair.SourceRepo,
+				placements[pair.SourceRepo].Wave, pair.TargetRepo, placements[pair.TargetRepo].Wave)
+		}
+	}
+	if got := placements["org/a"].DependsOn; len(got) != 1 || got[0] != "org/z" {
+		t.Errorf("Expected org/a to depend on org/z, got %v", got)
+	}
+	if reason := placements["org/a"].Reason; !strings.Contains(reason, "no earlier than its dependencies: org/z (wave") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+	if reason := placements["org/b"].Reason; !strings.Contains(reason, "by name order") {
+		t.Errorf("Expected dependencies outside the plan to be ignored, got %q", reason)
+	}
+}
+
+func TestPlanWaves_OversizedCycleGetsItsOwnWave(t *testing.T) {
+	repos := planRepos("org/a", "org/b", "org/c", "org/d")
+	pairs := []storage.DependencyPair{
+		{SourceRepo: "org/b", TargetRepo: "org/c"},
+		{SourceRepo: "org/c", TargetRepo: "org/d"},
+		{SourceRepo: "org/d", TargetRepo: "org/b"},
+	}
+
+	plan := planWaves(repos, pairs, WaveCriteria{WaveSize: 2, SortBy: "name"})
+
+	if len(plan.Waves) != 2 || len(plan.Waves[0].Repositories) != 1 || len(plan.Waves[1].Repositories) != 3 {
+		t.Fatalf("Expected org/a alone and then the cycle in its own wave, got %+v", plan.Waves)
+	}
+	if reason := planWaveOf(plan)["org/c"].Reason; !strings.Contains(reason, "more than the wave size of 2") {
+		t.Errorf("Unexpected reason %q", reason)
+	}
+}
+
+func TestOrganizeIntoWaves_RespectsDependencies(t *testing.T) {
+	organizer, db, cleanup := setupTestOrganizer(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	repos := make(map[string]*models.Repository)
+	for i := range 6 {
+		name := fmt.Sprintf("org/repo%d", i)
+		repos[name] = createTestRepository(t, db, name, 100, map[string]bool{})
+	}
+	dependsOn := map[string]string{
+		"org/repo0": "org/repo5", // repo0 and repo5 depend on each other
+		"org/repo5": "org/repo0",
+		"org/repo1": "org/repo4",
+	}
+	for from, to := range dependsOn {
+		err := db.SaveRepositoryDependencies(ctx, repos[from].ID, []*models.RepositoryDependency{
+			{DependencyFullName: to, DependencyType: models.DependencyTypeSubmodule, DependencyURL: "https://github.com/" + to, IsLocal: true},
+		})
+		if err != nil {
+			t.Fatalf("SaveRepositoryDependencies() error = %v", err)
+		}
+	}
+
+	if _, err := organizer.PlanWaves(ctx, WaveCriteria{WaveSize: 0}); err == nil {
+		t.Error("Expected an error for a wave size of zero")
+	}
+
+	waves, err := organizer.OrganizeIntoWaves(ctx, WaveCriteria{WaveSize: 2, SortBy: "name"})
+	if err != nil {
+		t.Fatalf("OrganizeIntoWaves() error = %v", err)
+	}
+	if len(waves) != 3 {
+		t.Fatalf("Expected 3 waves, got %d", len(waves))
+	}
+
+	waveOf := make(map[string]int)
+	for i, wave := range waves {
+		assigned, _ := db.ListRepositories(ctx, map[string]any{"batch_id": wave.ID})
+		for _, repo := range assigned {
+			waveOf[repo.FullName] = i + 1
+		}
+	}
+	if waveOf["org/repo0"] != waveOf["org/repo5"] {
+		t.Errorf("Expected the repo0/repo5 cycle in one wave, got %v", waveOf)
+	}
+	if waveOf["org/repo1"] < waveOf["org/repo4"] {
+		t.Errorf("Expected repo1 no earlier than its dependency repo4, got %v", waveOf)
+	}
+}
@@ -0,0 +1,366 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when the Bitbucket API responds with 404
+var ErrNotFound = errors.New("bitbucket resource not found")
+
+// defaultPageLimit is the page size used for paged list endpoints
+const defaultPageLimit = 100
+
+// Client is a minimal Bitbucket Server / Data Center REST API (1.0) client covering
+// the endpoints needed for discovery and profiling.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientConfig contains configuration for creating a Bitbucket client
+type ClientConfig struct {
+	BaseURL    string // Bitbucket instance URL (e.g., https://bitbucket.example.com)
+	Token      string // HTTP access token or personal access token
+	Logger     *slog.Logger
+	HTTPClient *http.Client // Optional: defaults to a client with a 60s timeout
+}
+
+// Validate checks if the configuration is valid
+func (c ClientConfig) Validate() error {
+	if c.BaseURL == "" {
+		return fmt.Errorf("base URL is required")
+	}
+	if c.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+	return nil
+}
+
+// Project represents a Bitbucket project (the container for repositories)
+type Project struct {
+	ID          int64  `json:"id"`
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+}
+
+// Link is a hyperlink in a Bitbucket API response
+type Link struct {
+	Href string `json:"href"`
+	Name string `json:"name"`
+}
+
+// Links contains the self and clone links of a repository
+type Links struct {
+	Self  []Link `json:"self"`
+	Clone []Link `json:"clone"`
+}
+
+// Repository represents a Bitbucket repository
+type Repository struct {
+	ID          int64       `json:"id"`
+	Slug        string      `json:"slug"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	ScmID       string      `json:"scmId"`
+	State       string      `json:"state"`
+	Public      bool        `json:"public"`
+	Archived    bool        `json:"archived"`
+	Forkable    bool        `json:"forkable"`
+	Project     Project     `json:"project"`
+	Origin      *Repository `json:"origin,omitempty"` // Set when the repository is a fork
+	Links       Links       `json:"links"`
+}
+
+// FullName returns the repository's "PROJECT/slug" name
+func (r *Repository) FullName() string {
+	return r.Project.Key + "/" + r.Slug
+}
+
+// HTTPCloneURL returns the HTTP(S) clone URL of the repository, or "" if none is advertised
+func (r *Repository) HTTPCloneURL() string {
+	for _, link := range r.Links.Clone {
+		if link.Name == "http" || link.Name == "https" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// WebURL returns the browse URL of the repository, or "" if none is advertised
+func (r *Repository) WebURL() string {
+	if len(r.Links.Self) > 0 {
+		return r.Links.Self[0].Href
+	}
+	return ""
+}
+
+// Branch represents a repository branch
+type Branch struct {
+	ID        string `json:"id"`
+	DisplayID string `json:"displayId"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// RepositorySizes contains the storage used by a repository
+type RepositorySizes struct {
+	Repository  int64 `json:"repository"`
+	Attachments int64 `json:"attachments"`
+}
+
+// MatcherType identifies how a branch restriction matches refs
+type MatcherType struct {
+	ID   string `json:"id"` // BRANCH, PATTERN, MODEL_BRANCH, or MODEL_CATEGORY
+	Name string `json:"name"`
+}
+
+// Matcher describes the refs a branch restriction applies to
+type Matcher struct {
+	ID        string      `json:"id"`
+	DisplayID string      `json:"displayId"`
+	Type      MatcherType `json:"type"`
+}
+
+// BranchRestriction represents a branch permission
+type BranchRestriction struct {
+	ID      int64   `json:"id"`
+	Type    string  `json:"type"` // read-only, no-deletes, fast-forward-only, or pull-request-only
+	Matcher Matcher `json:"matcher"`
+}
+
+// Webhook represents a repository webhook
+type Webhook struct {
+	ID     int64    `json:"id"`
+	Name   string   `json:"name"`
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Active bool     `json:"active"`
+}
+
+// page is a paged Bitbucket API response
+type page[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+// NewClient creates a new Bitbucket client
+func NewClient(cfg ClientConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		token:      cfg.Token,
+		httpClient: httpClient,
+		logger:     logger,
+	}, nil
+}
+
+// BaseURL returns the Bitbucket instance URL
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// ListProjects returns every project visible to the token
+func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
+	var projects []Project
+	if err := listAll(ctx, c, "/rest/api/1.0/projects", nil, &projects); err != nil {
+		return nil, fmt.Errorf("failed to list projects: %w", err)
+	}
+	return projects, nil
+}
+
+// GetProject returns a project by key
+func (c *Client) GetProject(ctx context.Context, projectKey string) (*Project, error) {
+	var p Project
+	if err := c.get(ctx, "/rest/api/1.0/projects/"+url.PathEscape(projectKey), nil, &p); err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", projectKey, err)
+	}
+	return &p, nil
+}
+
+// ListRepositories returns the repositories of a project
+func (c *Client) ListRepositories(ctx context.Context, projectKey string) ([]Repository, error) {
+	var repos []Repository
+	if err := listAll(ctx, c, repoPath(projectKey, ""), nil, &repos); err != nil {
+		return nil, fmt.Errorf("failed to list repositories of project %s: %w", projectKey, err)
+	}
+	return repos, nil
+}
+
+// GetRepository returns a repository by project key and slug
+func (c *Client) GetRepository(ctx context.Context, projectKey, slug string) (*Repository, error) {
+	var r Repository
+	if err := c.get(ctx, repoPath(projectKey, slug), nil, &r); err != nil {
+		return nil, fmt.Errorf("failed to get repository %s/%s: %w", projectKey, slug, err)
+	}
+	return &r, nil
+}
+
+// GetDefaultBranch returns the default branch of a repository
+func (c *Client) GetDefaultBranch(ctx context.Context, projectKey, slug string) (*Branch, error) {
+	var b Branch
+	if err := c.get(ctx, repoPath(projectKey, slug)+"/branches/default", nil, &b); err != nil {
+		return nil, fmt.Errorf("failed to get default branch of %s/%s: %w", projectKey, slug, err)
+	}
+	return &b, nil
+}
+
+// GetRepositorySizes returns the storage used by a repository.
+// Older Bitbucket versions do not expose this endpoint, in which case ErrNotFound is returned.
+func (c *Client) GetRepositorySizes(ctx context.Context, projectKey, slug string) (*RepositorySizes, error) {
+	var sizes RepositorySizes
+	if err := c.get(ctx, repoPath(projectKey, slug)+"/sizes", nil, &sizes); err != nil {
+		return nil, fmt.Errorf("failed to get sizes of %s/%s: %w", projectKey, slug, err)
+	}
+	return &sizes, nil
+}
+
+// CountPullRequests returns the number of pull requests in the given state (ALL, OPEN, MERGED, DECLINED).
+// Bitbucket does not report totals, so every page is walked.
+func (c *Client) CountPullRequests(ctx context.Context, projectKey, slug, state string) (int, error) {
+	var prs []json.RawMessage
+	query := url.Values{"state": {state}}
+	if err := listAll(ctx, c, repoPath(projectKey, slug)+"/pull-requests", query, &prs); err != nil {
+		return 0, fmt.Errorf("failed to count pull requests of %s/%s: %w", projectKey, slug, err)
+	}
+	return len(prs), nil
+}
+
+// ListBranchRestrictions returns the branch permissions of a repository
+func (c *Client) ListBranchRestrictions(ctx context.Context, projectKey, slug string) ([]BranchRestriction, error) {
+	var restrictions []BranchRestriction
+	path := fmt.Sprintf("/rest/branch-permissions/2.0/projects/%s/repos/%s/restrictions",
+		url.PathEscape(projectKey), url.PathEscape(slug))
+	if err := listAll(ctx, c, path, nil, &restrictions); err != nil {
+		return nil, fmt.Errorf("failed to list branch restrictions of %s/%s: %w", projectKey, slug, err)
+	}
+	return restrictions, nil
+}
+
+// ListWebhooks returns the webhooks of a repository
+func (c *Client) ListWebhooks(ctx context.Context, projectKey, slug string) ([]Webhook, error) {
+	var hooks []Webhook
+	if err := listAll(ctx, c, repoPath(projectKey, slug)+"/webhooks", nil, &hooks); err != nil {
+		return nil, fmt.Errorf("failed to list webhooks of %s/%s: %w", projectKey, slug, err)
+	}
+	return hooks, nil
+}
+
+// HasFile reports whether a file exists on the default branch of a repository
+func (c *Client) HasFile(ctx context.Context, projectKey, slug, filePath string) (bool, error) {
+	resp, err := c.do(ctx, repoPath(projectKey, slug)+"/raw/"+(&url.URL{Path: filePath}).EscapedPath(), nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check file %s in %s/%s: %w", filePath, projectKey, slug, err)
+	}
+	_ = resp.Body.Close()
+	return true, nil
+}
+
+// repoPath returns the REST path of a project's repositories, or of one repository when slug is set
+func repoPath(projectKey, slug string) string {
+	path := "/rest/api/1.0/projects/" + url.PathEscape(projectKey) + "/repos"
+	if slug != "" {
+		path += "/" + url.PathEscape(slug)
+	}
+	return path
+}
+
+// listAll follows start/nextPageStart pagination and appends every page into out
+func listAll[T any](ctx context.Context, c *Client, path string, query url.Values, out *[]T) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", strconv.Itoa(defaultPageLimit))
+	start := 0
+
+	for {
+		query.Set("start", strconv.Itoa(start))
+
+		var p page[T]
+		if err := c.get(ctx, path, query, &p); err != nil {
+			return err
+		}
+		*out = append(*out, p.Values...)
+
+		if p.IsLastPage || p.NextPageStart <= start {
+			return nil
+		}
+		start = p.NextPageStart
+	}
+}
+
+// get sends a GET request and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	resp, err := c.do(ctx, path, query)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// do sends an authenticated GET request to the Bitbucket API and returns the response if it succeeded.
+// The caller is responsible for closing the response body.
+func (c *Client) do(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	reqURL := c.baseURL + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("Bitbucket API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,212 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL + "/", Token: "test-token"})
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClientConfig
+		wantErr bool
+	}{
+		{name: "valid configuration", config: ClientConfig{BaseURL: "https://bitbucket.example.com/", Token: "token"}},
+		{name: "empty base URL", config: ClientConfig{Token: "token"}, wantErr: true},
+		{name: "empty token", config: ClientConfig{BaseURL: "https://bitbucket.example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && client.BaseURL() != "https://bitbucket.example.com" {
+				t.Errorf("BaseURL() = %s, want https://bitbucket.example.com", client.BaseURL())
+			}
+		})
+	}
+}
+
+func TestRepository_Links(t *testing.T) {
+	repo := Repository{
+		Slug:    "api",
+		Project: Project{Key: "PROJ"},
+		Links: Links{
+			Self: []Link{{Href: "https://bitbucket.example.com/projects/PROJ/repos/api/browse"}},
+			Clone: []Link{
+				{Href: "ssh://git@bitbucket.example.com:7999/proj/api.git", Name: "ssh"},
+				{Href: "https://bitbucket.example.com/scm/proj/api.git", Name: "http"},
+			},
+		},
+	}
+
+	if repo.FullName() != "PROJ/api" {
+		t.Errorf("FullName() = %s, want PROJ/api", repo.FullName())
+	}
+	if repo.HTTPCloneURL() != "https://bitbucket.example.com/scm/proj/api.git" {
+		t.Errorf("HTTPCloneURL() = %s", repo.HTTPCloneURL())
+	}
+	if repo.WebURL() != "https://bitbucket.example.com/projects/PROJ/repos/api/browse" {
+		t.Errorf("WebURL() = %s", repo.WebURL())
+	}
+}
+
+func TestClient_GetProject(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("missing Authorization header")
+		}
+		if r.PathValue("key") != "PROJ" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id": 1, "key": "PROJ", "name": "Project"}`)
+	})
+	client := newTestClient(t, mux)
+
+	project, err := client.GetProject(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatalf("GetProject() error: %v", err)
+	}
+	if project.ID != 1 || project.Key != "PROJ" {
+		t.Errorf("GetProject() = %+v, want id 1 key PROJ", project)
+	}
+
+	_, err = client.GetProject(context.Background(), "MISSING")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetProject() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_ListRepositories_Paginates(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJ/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("limit") != "100" {
+			t.Errorf("expected limit=100, got %q", r.URL.Query().Get("limit"))
+		}
+		switch r.URL.Query().Get("start") {
+		case "0":
+			_, _ = fmt.Fprint(w, `{"values": [{"id": 1, "slug": "one"}], "isLastPage": false, "nextPageStart": 1}`)
+		case "1":
+			_, _ = fmt.Fprint(w, `{"values": [{"id": 2, "slug": "two", "origin": {"id": 9, "slug": "upstream"}}], "isLastPage": true}`)
+		default:
+			t.Errorf("unexpected start %q", r.URL.Query().Get("start"))
+		}
+	})
+	client := newTestClient(t, mux)
+
+	repos, err := client.ListRepositories(context.Background(), "PROJ")
+	if err != nil {
+		t.Fatalf("ListRepositories() error: %v", err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("ListRepositories() returned %d repositories, want 2", len(repos))
+	}
+	if repos[1].Origin == nil || repos[1].Origin.Slug != "upstream" {
+		t.Errorf("expected origin on second repository, got %+v", repos[1].Origin)
+	}
+}
+
+func TestClient_CountPullRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJ/repos/api/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") == "OPEN" {
+			_, _ = fmt.Fprint(w, `{"values": [{"id": 3}], "isLastPage": true}`)
+			return
+		}
+		if r.URL.Query().Get("start") == "0" {
+			_, _ = fmt.Fprint(w, `{"values": [{"id": 1}, {"id": 2}], "isLastPage": false, "nextPageStart": 2}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"values": [{"id": 3}], "isLastPage": true}`)
+	})
+	client := newTestClient(t, mux)
+
+	total, err := client.CountPullRequests(context.Background(), "PROJ", "api", "ALL")
+	if err != nil {
+		t.Fatalf("CountPullRequests() error: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("CountPullRequests(ALL) = %d, want 3", total)
+	}
+
+	open, err := client.CountPullRequests(context.Background(), "PROJ", "api", "OPEN")
+	if err != nil {
+		t.Fatalf("CountPullRequests() error: %v", err)
+	}
+	if open != 1 {
+		t.Errorf("CountPullRequests(OPEN) = %d, want 1", open)
+	}
+}
+
+func TestClient_ListBranchRestrictions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/branch-permissions/2.0/projects/PROJ/repos/api/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"values": [{"id": 1, "type": "pull-request-only", "matcher": {"id": "refs/heads/main", "displayId": "main", "type": {"id": "BRANCH"}}}], "isLastPage": true}`)
+	})
+	client := newTestClient(t, mux)
+
+	restrictions, err := client.ListBranchRestrictions(context.Background(), "PROJ", "api")
+	if err != nil {
+		t.Fatalf("ListBranchRestrictions() error: %v", err)
+	}
+	if len(restrictions) != 1 || restrictions[0].Type != "pull-request-only" || restrictions[0].Matcher.Type.ID != "BRANCH" {
+		t.Errorf("ListBranchRestrictions() = %+v", restrictions)
+	}
+}
+
+func TestClient_HasFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/1.0/projects/PROJ/repos/{slug}/raw/{file}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("slug") == "api" && r.PathValue("file") == "bitbucket-pipelines.yml" {
+			_, _ = fmt.Fprint(w, "pipelines: {}")
+			return
+		}
+		http.NotFound(w, r)
+	})
+	client := newTestClient(t, mux)
+
+	found, err := client.HasFile(context.Background(), "PROJ", "api", "bitbucket-pipelines.yml")
+	if err != nil || !found {
+		t.Errorf("HasFile() = %v, %v; want true, nil", found, err)
+	}
+
+	found, err = client.HasFile(context.Background(), "PROJ", "web", "bitbucket-pipelines.yml")
+	if err != nil || found {
+		t.Errorf("HasFile() = %v, %v; want false, nil", found, err)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors": [{"message": "Forbidden"}]}`, http.StatusForbidden)
+	}))
+
+	_, err := client.ListWebhooks(context.Background(), "PROJ", "api")
+	if err == nil {
+		t.Fatal("ListWebhooks() expected error")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("403 should not be reported as ErrNotFound")
+	}
+}
@@ -0,0 +1,417 @@
+// Package calendar decides when production migrations may run, from blackout windows (release
+// freezes, holidays) and allowed migration windows configured per organization or for all of them.
+package calendar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// horizon is how far ahead the calendar looks for the next time migrations are allowed
+const horizon = 90 * 24 * time.Hour
+
+// weekdays maps the recurring window day names to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// Store is the data the calendar reads. Implemented by storage.Database.
+type Store interface {
+	ListMigrationWindows(ctx context.Context) ([]*models.MigrationWindow, error)
+	ListRepositories(ctx context.Context, filters map[string]any) ([]*models.Repository, error)
+}
+
+// Calendar evaluates a set of migration windows
+type Calendar struct {
+	windows []*models.MigrationWindow
+}
+
+// New creates a calendar of windows
+func New(windows []*models.MigrationWindow) *Calendar {
+	return &Calendar{windows: windows}
+}
+
+// Load creates a calendar of the windows in store
+func Load(ctx context.Context, store Store) (*Calendar, error) {
+	windows, err := store.ListMigrationWindows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migration windows: %w", err)
+	}
+	return New(windows), nil
+}
+
+// Empty reports whether the calendar has no windows, so migrations are always allowed
+func (c *Calendar) Empty() bool {
+	return len(c.windows) == 0
+}
+
+// Occurrence is a single period of a migration window
+type Occurrence struct {
+	WindowID     int64     `json:"window_id"`
+	Name         string    `json:"name"`
+	Kind         string    `json:"kind"`
+	Organization *string   `json:"organization,omitempty"`
+	Policy       string    `json:"policy"`
+	StartsAt     time.Time `json:"starts_at"`
+	EndsAt       time.Time `json:"ends_at"`
+}
+
+// Decision is whether production migrations may run for a set of organizations at a point in time
+type Decision struct {
+	Open    bool                    `json:"open"`
+	Refused bool                    `json:"refused,omitempty"` // Closed by a blackout that refuses rather than defers
+	Window  *models.MigrationWindow `json:"window,omitempty"`  // The blackout in force; nil when outside the allowed windows
+	Until   *time.Time              `json:"until,omitempty"`   // When the blackout in force ends
+	Reason  string                  `json:"reason,omitempty"`
+}
+
+// ClosedError is returned when a batch cannot be scheduled at the requested time
+type ClosedError struct {
+	Decision Decision
+}
+
+func (e *ClosedError) Error() string {
+	return e.Decision.Reason
+}
+
+// Validate checks a migration window and fills in its default time zone and policy
+func Validate(window *models.MigrationWindow) error {
+	if strings.TrimSpace(window.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch window.Kind {
+	case models.MigrationWindowKindBlackout, models.MigrationWindowKindAllowed:
+	default:
+		return fmt.Errorf("kind must be %q or %q", models.MigrationWindowKindBlackout, models.MigrationWindowKindAllowed)
+	}
+	switch window.Policy {
+	case "":
+		window.Policy = models.MigrationWindowPolicyDefer
+	case models.MigrationWindowPolicyDefer, models.MigrationWindowPolicyRefuse:
+	default:
+		return fmt.Errorf("policy must be %q or %q", models.MigrationWindowPolicyDefer, models.MigrationWindowPolicyRefuse)
+	}
+	if window.Organization != nil && strings.TrimSpace(*window.Organization) == "" {
+		window.Organization = nil
+	}
+	if window.TimeZone == "" {
+		window.TimeZone = "UTC"
+	}
+	if _, err := time.LoadLocation(window.TimeZone); err != nil {
+		return fmt.Errorf("invalid time_zone %q", window.TimeZone)
+	}
+
+	oneOff := window.StartsAt != nil || window.EndsAt != nil
+	recurring := window.Weekdays != nil || window.StartTime != nil || window.EndTime != nil
+	switch {
+	case oneOff == recurring:
+		return fmt.Errorf("set either starts_at and ends_at, or weekdays, start_time and end_time")
+	case oneOff:
+		if window.StartsAt == nil || window.EndsAt == nil || !window.EndsAt.After(*window.StartsAt) {
+			return fmt.Errorf("ends_at must be after starts_at")
+		}
+	default:
+		if window.Weekdays == nil || window.StartTime == nil || window.EndTime == nil {
+			return fmt.Errorf("recurring windows need weekdays, start_time and end_time")
+		}
+		days, err := parseWeekdays(*window.Weekdays)
+		if err != nil {
+			return err
+		}
+		if len(days) == 0 {
+			return fmt.Errorf("weekdays must name at least one day")
+		}
+		for _, clock := range []string{*window.StartTime, *window.EndTime} {
+			if _, err := parseClock(clock); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Check decides whether production migrations may run for repositories in orgs at a point in time.
+// Migrations are closed inside any applicable blackout, and when any of the organizations is
+// outside its own allowed windows, if it has any: an allowed window of one organization does not
+// open another's.
+func (c *Calendar) Check(orgs []string, at time.Time) Decision {
+	var blackout *Decision
+
+	for _, window := range c.windows {
+		if window.Kind == models.MigrationWindowKindAllowed || !applies(window, orgs) {
+			continue
+		}
+		active := activeOccurrence(window, at)
+		if active == nil {
+			continue
+		}
+		refused := window.Policy == models.MigrationWindowPolicyRefuse
+		// A refusing blackout takes precedence, then the one ending last
+		if blackout == nil || (refused && !blackout.Refused) ||
+			(refused == blackout.Refused && active.EndsAt.After(*blackout.Until)) {
+			until := active.EndsAt
+			blackout = &Decision{
+				Refused: refused,
+				Window:  window,
+				Until:   &until,
+				Reason:  fmt.Sprintf("inside blackout window %q until %s", window.Name, until.Format(time.RFC3339)),
+			}
+		}
+	}
+
+	if blackout != nil {
+		return *blackout
+	}
+
+	// Without organizations only the windows covering all of them apply
+	scopes := orgs
+	if len(scopes) == 0 {
+		scopes = []string{""}
+	}
+	for _, org := range scopes {
+		if !c.insideAllowed(org, at) {
+			if org == "" {
+				return Decision{Reason: "outside the allowed migration windows"}
+			}
+			return Decision{Reason: fmt.Sprintf("outside the allowed migration windows for %s", org)}
+		}
+	}
+	return Decision{Open: true}
+}
+
+// insideAllowed reports whether an organization has no allowed windows, or at falls inside one of
+// them. The allowed windows of an organization are its own and those covering all organizations.
+func (c *Calendar) insideAllowed(org string, at time.Time) bool {
+	hasAllowed := false
+	for _, window := range c.windows {
+		if window.Kind != models.MigrationWindowKindAllowed || !applies(window, []string{org}) {
+			continue
+		}
+		if activeOccurrence(window, at) != nil {
+			return true
+		}
+		hasAllowed = true
+	}
+	return !hasAllowed
+}
+
+// NextOpen returns the first time at or after at when production migrations may run for
+// repositories in orgs, looking up to 90 days ahead
+func (c *Calendar) NextOpen(orgs []string, at time.Time) (time.Time, bool) {
+	candidates := []time.Time{at}
+	for _, window := range c.windows {
+		if !applies(window, orgs) {
+			continue
+		}
+		for _, occurrence := range occurrences(window, at, at.Add(horizon)) {
+			// Migrations can only open when a blackout ends or an allowed window starts
+			if window.Kind == models.MigrationWindowKindBlackout {
+				candidates = append(candidates, occurrence.EndsAt)
+			} else {
+				candidates = append(candidates, occurrence.StartsAt)
+			}
+		}
+	}
+	slices.SortFunc(candidates, func(a, b time.Time) int { return a.Compare(b) })
+
+	for _, candidate := range candidates {
+		if !candidate.Before(at) && c.Check(orgs, candidate).Open {
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Schedule returns when a batch of repositories in orgs requested for at should run: at itself if
+// migrations are allowed then, or the next time they are. A ClosedError is returned if a refusing
+// blackout is in force at that time, or migrations do not open again within 90 days.
+func (c *Calendar) Schedule(orgs []string, at time.Time) (time.Time, error) {
+	decision := c.Check(orgs, at)
+	if decision.Open {
+		return at, nil
+	}
+	if decision.Refused {
+		return time.Time{}, &ClosedError{Decision: decision}
+	}
+
+	next, ok := c.NextOpen(orgs, at)
+	if !ok {
+		decision.Reason += "; migrations are not allowed again within 90 days"
+		return time.Time{}, &ClosedError{Decision: decision}
+	}
+	return next, nil
+}
+
+// Occurrences lists the periods of every window overlapping [from, to), in start order
+func (c *Calendar) Occurrences(from, to time.Time) []Occurrence {
+	result := []Occurrence{}
+	for _, window := range c.windows {
+		result = append(result, occurrences(window, from, to)...)
+	}
+	slices.SortStableFunc(result, func(a, b Occurrence) int { return a.StartsAt.Compare(b.StartsAt) })
+	return result
+}
+
+// ScheduleBatch returns when a batch requested for at should run, given the organizations of its
+// repositories. See Calendar.Schedule.
+func ScheduleBatch(ctx context.Context, store Store, batchID int64, at time.Time) (time.Time, error) {
+	cal, err := Load(ctx, store)
+	if err != nil {
+		return time.Time{}, err
+	}
+	orgs, err := BatchOrganizations(ctx, store, batchID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return cal.Schedule(orgs, at)
+}
+
+// BatchOrganizations returns the distinct source organizations of a batch's repositories
+func BatchOrganizations(ctx context.Context, store Store, batchID int64) ([]string, error) {
+	if batchID == 0 {
+		return nil, nil
+	}
+	repos, err := store.ListRepositories(ctx, map[string]any{"batch_id": batchID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list batch repositories: %w", err)
+	}
+	return Organizations(repos), nil
+}
+
+// Organizations returns the distinct source organizations of repositories
+func Organizations(repos []*models.Repository) []string {
+	var orgs []string
+	for _, repo := range repos {
+		if org := repo.Organization(); org != "" && !slices.Contains(orgs, org) {
+			orgs = append(orgs, org)
+		}
+	}
+	slices.Sort(orgs)
+	return orgs
+}
+
+// IsClosed reports whether err is a ClosedError
+func IsClosed(err error) bool {
+	var closed *ClosedError
+	return errors.As(err, &closed)
+}
+
+// applies reports whether a window covers any of the organizations. Windows without an
+// organization cover all of them.
+func applies(window *models.MigrationWindow, orgs []string) bool {
+	if window.Organization == nil {
+		return true
+	}
+	return slices.ContainsFunc(orgs, func(org string) bool { return strings.EqualFold(org, *window.Organization) })
+}
+
+// activeOccurrence returns the window's occurrence containing at, or nil
+func activeOccurrence(window *models.MigrationWindow, at time.Time) *Occurrence {
+	active := occurrences(window, at, at.Add(time.Nanosecond))
+	if len(active) == 0 {
+		return nil
+	}
+	return &active[0]
+}
+
+// occurrences returns the window's periods overlapping [from, to). Windows that fail validation
+// have none.
+func occurrences(window *models.MigrationWindow, from, to time.Time) []Occurrence {
+	occurrence := func(start, end time.Time) Occurrence {
+		return Occurrence{
+			WindowID:     window.ID,
+			Name:         window.Name,
+			Kind:         window.Kind,
+			Organization: window.Organization,
+			Policy:       window.Policy,
+			StartsAt:     start,
+			EndsAt:       end,
+		}
+	}
+
+	if window.StartsAt != nil && window.EndsAt != nil {
+		if window.StartsAt.Before(to) && window.EndsAt.After(from) {
+			return []Occurrence{occurrence(*window.StartsAt, *window.EndsAt)}
+		}
+		return nil
+	}
+	if window.Weekdays == nil || window.StartTime == nil || window.EndTime == nil {
+		return nil
+	}
+
+	loc, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return nil
+	}
+	days, errDays := parseWeekdays(*window.Weekdays)
+	start, errStart := parseClock(*window.StartTime)
+	end, errEnd := parseClock(*window.EndTime)
+	if errDays != nil || errStart != nil || errEnd != nil {
+		return nil
+	}
+
+	var result []Occurrence
+	// Start a day early for windows that began the day before and run past midnight
+	day := from.In(loc).AddDate(0, 0, -1)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(days, day.Weekday()) {
+			continue
+		}
+		startsAt := atClock(day, start)
+		endsAt := atClock(day, end)
+		if end <= start {
+			endsAt = atClock(day.AddDate(0, 0, 1), end)
+		}
+		if startsAt.Before(to) && endsAt.After(from) {
+			result = append(result, occurrence(startsAt, endsAt))
+		}
+	}
+	return result
+}
+
+// atClock returns the time of day on a date, in the date's location. Unlike adding the offset to
+// midnight, this keeps wall-clock times across daylight saving changes.
+func atClock(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), int(clock/time.Hour), int(clock%time.Hour/time.Minute), 0, 0, day.Location())
+}
+
+// parseWeekdays parses comma-separated day names such as "mon,tue"
+func parseWeekdays(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		day, ok := weekdays[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q, use sun, mon, tue, wed, thu, fri or sat", name)
+		}
+		if !slices.Contains(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days, nil
+}
+
+// parseClock parses a time of day in HH:MM form as the offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q, use HH:MM", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
@@ -0,0 +1,208 @@
+package calendar
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+type fakeStore struct {
+	windows []*models.MigrationWindow
+	repos   []*models.Repository
+}
+
+func (f *fakeStore) ListMigrationWindows(context.Context) ([]*models.MigrationWindow, error) {
+	return f.windows, nil
+}
+
+func (f *fakeStore) ListRepositories(context.Context, map[string]any) ([]*models.Repository, error) {
+	return f.repos, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func blackout(name string, org *string, policy string, start, end time.Time) *models.MigrationWindow {
+	return &models.MigrationWindow{
+		Name: name, Kind: models.MigrationWindowKindBlackout, Organization: org, TimeZone: "UTC",
+		StartsAt: &start, EndsAt: &end, Policy: policy,
+	}
+}
+
+// Monday 13 January 2025, 12:00 UTC
+var monday = time.Date(2025, time.January, 13, 12, 0, 0, 0, time.UTC)
+
+func TestValidate(t *testing.T) {
+	start, end := monday, monday.Add(time.Hour)
+	tests := []struct {
+		name    string
+		window  models.MigrationWindow
+		wantErr string
+	}{
+		{"one-off", models.MigrationWindow{Name: "freeze", Kind: "blackout", StartsAt: &start, EndsAt: &end}, ""},
+		{"recurring", models.MigrationWindow{Name: "nights", Kind: "allowed", TimeZone: "America/New_York",
+			Weekdays: ptr("mon, tue"), StartTime: ptr("22:00"), EndTime: ptr("06:00")}, ""},
+		{"missing name", models.MigrationWindow{Kind: "blackout", StartsAt: &start, EndsAt: &end}, "name"},
+		{"bad kind", models.MigrationWindow{Name: "x", Kind: "maybe", StartsAt: &start, EndsAt: &end}, "kind"},
+		{"bad policy", models.MigrationWindow{Name: "x", Kind: "blackout", Policy: "ignore", StartsAt: &start, EndsAt: &end}, "policy"},
+		{"bad time zone", models.MigrationWindow{Name: "x", Kind: "blackout", TimeZone: "Mars/Olympus", StartsAt: &start, EndsAt: &end}, "time_zone"},
+		{"end before start", models.MigrationWindow{Name: "x", Kind: "blackout", StartsAt: &end, EndsAt: &start}, "ends_at"},
+		{"both shapes", models.MigrationWindow{Name: "x", Kind: "blackout", StartsAt: &start, EndsAt: &end, Weekdays: ptr("mon")}, "either"},
+		{"bad weekday", models.MigrationWindow{Name: "x", Kind: "blackout", Weekdays: ptr("funday"), StartTime: ptr("09:00"), EndTime: ptr("17:00")}, "weekday"},
+		{"bad clock", models.MigrationWindow{Name: "x", Kind: "blackout", Weekdays: ptr("mon"), StartTime: ptr("9am"), EndTime: ptr("17:00")}, "HH:MM"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(&tt.window)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				if tt.window.Policy != models.MigrationWindowPolicyDefer || tt.window.TimeZone == "" {
+					t.Errorf("Expected defaults to be filled in, got %+v", tt.window)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheck_Blackouts(t *testing.T) {
+	cal := New([]*models.MigrationWindow{
+		blackout("release freeze", nil, models.MigrationWindowPolicyDefer, monday.Add(-time.Hour), monday.Add(2*time.Hour)),
+		blackout("acme audit", ptr("acme"), models.MigrationWindowPolicyRefuse, monday, monday.Add(time.Hour)),
+	})
+
+	decision := cal.Check([]string{"other"}, monday)
+	if decision.Open || decision.Refused || decision.Window.Name != "release freeze" {
+		t.Errorf("Expected the org-wide freeze to defer, got %+v", decision)
+	}
+	decision = cal.Check([]string{"ACME"}, monday)
+	if decision.Open || !decision.Refused || decision.Window.Name != "acme audit" {
+		t.Errorf("Expected the acme blackout to refuse, got %+v", decision)
+	}
+	if decision := cal.Check([]string{"acme"}, monday.Add(2*time.Hour)); !decision.Open {
+		t.Errorf("Expected migrations to be allowed once the blackouts end, got %+v", decision)
+	}
+}
+
+func TestCheck_AllowedWindowsInTimeZone(t *testing.T) {
+	// Weeknights from 22:00 to 06:00 in New York, 03:00 to 11:00 UTC in January
+	cal := New([]*models.MigrationWindow{{
+		Name: "weeknights", Kind: models.MigrationWindowKindAllowed, TimeZone: "America/New_York",
+		Weekdays: ptr("mon,tue,wed,thu,fri"), StartTime: ptr("22:00"), EndTime: ptr("06:00"),
+	}})
+
+	if decision := cal.Check(nil, monday); decision.Open || !strings.Contains(decision.Reason, "outside the allowed") {
+		t.Errorf("Expected midday to be outside the allowed window, got %+v", decision)
+	}
+	// Tuesday 04:00 UTC is Monday 23:00 in New York
+	if decision := cal.Check(nil, monday.Add(16*time.Hour)); !decision.Open {
+		t.Errorf("Expected the overnight window to be open, got %+v", decision)
+	}
+
+	next, ok := cal.NextOpen(nil, monday)
+	if want := time.Date(2025, time.January, 14, 3, 0, 0, 0, time.UTC); !ok || !next.Equal(want) {
+		t.Errorf("NextOpen() = %v, %v, want %v", next, ok, want)
+	}
+	// Friday night's window runs into Saturday morning, then nothing until Monday night
+	saturday := time.Date(2025, time.January, 18, 12, 0, 0, 0, time.UTC)
+	next, ok = cal.NextOpen(nil, saturday)
+	if want := time.Date(2025, time.January, 21, 3, 0, 0, 0, time.UTC); !ok || !next.Equal(want) {
+		t.Errorf("NextOpen() after the weekend = %v, %v, want %v", next, ok, want)
+	}
+}
+
+func TestCheck_AllowedWindowsPerOrganization(t *testing.T) {
+	allowed := func(name, org string, start, end time.Time) *models.MigrationWindow {
+		return &models.MigrationWindow{
+			Name: name, Kind: models.MigrationWindowKindAllowed, Organization: ptr(org), TimeZone: "UTC",
+			StartsAt: &start, EndsAt: &end,
+		}
+	}
+	// acme may migrate in the morning and globex in the afternoon
+	cal := New([]*models.MigrationWindow{
+		allowed("acme mornings", "acme", monday.Add(-4*time.Hour), monday),
+		allowed("globex afternoons", "globex", monday, monday.Add(4*time.Hour)),
+	})
+
+	if decision := cal.Check([]string{"acme"}, monday.Add(time.Hour)); decision.Open || !strings.Contains(decision.Reason, "acme") {
+		t.Errorf("Expected globex's window not to open acme, got %+v", decision)
+	}
+	if decision := cal.Check([]string{"globex"}, monday.Add(time.Hour)); !decision.Open {
+		t.Errorf("Expected globex to be inside its window, got %+v", decision)
+	}
+	if decision := cal.Check([]string{"acme", "globex"}, monday.Add(time.Hour)); decision.Open {
+		t.Errorf("Expected a batch of both organizations to wait for acme, got %+v", decision)
+	}
+	if decision := cal.Check([]string{"initech"}, monday.Add(time.Hour)); !decision.Open {
+		t.Errorf("Expected an organization without allowed windows to be open, got %+v", decision)
+	}
+
+	// The windows of both organizations never overlap
+	if _, ok := cal.NextOpen([]string{"acme", "globex"}, monday.Add(-5*time.Hour)); ok {
+		t.Error("Expected no time when both organizations are inside their windows")
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	freezeEnd := monday.Add(48 * time.Hour)
+	cal := New([]*models.MigrationWindow{
+		blackout("freeze", nil, models.MigrationWindowPolicyDefer, monday.Add(-time.Hour), freezeEnd),
+		blackout("audit", ptr("acme"), models.MigrationWindowPolicyRefuse, freezeEnd.Add(24*time.Hour), freezeEnd.Add(48*time.Hour)),
+	})
+
+	at, err := cal.Schedule([]string{"other"}, monday)
+	if err != nil || !at.Equal(freezeEnd) {
+		t.Errorf("Expected the batch to be deferred to the end of the freeze, got %v, %v", at, err)
+	}
+	if at, err := cal.Schedule([]string{"other"}, freezeEnd.Add(time.Hour)); err != nil || !at.Equal(freezeEnd.Add(time.Hour)) {
+		t.Errorf("Expected an open time to be kept, got %v, %v", at, err)
+	}
+	_, err = cal.Schedule([]string{"acme"}, freezeEnd.Add(30*time.Hour))
+	if !IsClosed(err) || !strings.Contains(err.Error(), `"audit"`) {
+		t.Errorf("Expected the refusing blackout to reject the batch, got %v", err)
+	}
+
+	// Back-to-back daily blackouts never end
+	closedForever := New([]*models.MigrationWindow{{
+		Name: "always", Kind: models.MigrationWindowKindBlackout, TimeZone: "UTC", Policy: models.MigrationWindowPolicyDefer,
+		Weekdays: ptr("sun,mon,tue,wed,thu,fri,sat"), StartTime: ptr("00:00"), EndTime: ptr("00:00"),
+	}})
+	if _, err := closedForever.Schedule(nil, monday); !IsClosed(err) {
+		t.Errorf("Expected an error when migrations never open, got %v", err)
+	}
+}
+
+func TestScheduleBatchAndOccurrences(t *testing.T) {
+	store := &fakeStore{
+		windows: []*models.MigrationWindow{
+			blackout("acme freeze", ptr("acme"), models.MigrationWindowPolicyDefer, monday, monday.Add(time.Hour)),
+			{Name: "daily", Kind: models.MigrationWindowKindAllowed, TimeZone: "UTC", Weekdays: ptr("mon,tue"),
+				StartTime: ptr("09:00"), EndTime: ptr("17:00")},
+		},
+		repos: []*models.Repository{{FullName: "acme/api"}, {FullName: "acme/web"}},
+	}
+
+	at, err := ScheduleBatch(context.Background(), store, 1, monday)
+	if err != nil || !at.Equal(monday.Add(time.Hour)) {
+		t.Errorf("ScheduleBatch() = %v, %v, want %v", at, err, monday.Add(time.Hour))
+	}
+	if orgs := Organizations(store.repos); len(orgs) != 1 || orgs[0] != "acme" {
+		t.Errorf("Organizations() = %v", orgs)
+	}
+
+	cal, _ := Load(context.Background(), store)
+	occurrences := cal.Occurrences(monday.Add(-12*time.Hour), monday.Add(36*time.Hour))
+	if len(occurrences) != 3 {
+		t.Fatalf("Expected Monday's and Tuesday's allowed windows and the freeze, got %+v", occurrences)
+	}
+	if occurrences[1].Name != "acme freeze" || !occurrences[2].StartsAt.Equal(monday.Add(21*time.Hour)) {
+		t.Errorf("Unexpected occurrences %+v", occurrences)
+	}
+}
@@ -49,7 +49,7 @@ type SourceConfig struct {
 	BaseURL      string `mapstructure:"base_url"`     // API base URL
 	Token        string `mapstructure:"token"`        // Authentication token (PAT)
 	Organization string `mapstructure:"organization"` // Organization name (required for Azure DevOps)
-	Username     string `mapstructure:"username"`     // Username (optional, for Azure DevOps)
+	Username     string `mapstructure:"username"`     // Username (optional, for Azure DevOps and Bitbucket)
 
 	// GitHub App authentication (optional, for non-migration operations)
 	AppID             int64  `mapstructure:"app_id"`              // GitHub App ID
@@ -73,9 +73,34 @@ type DestinationConfig struct {
 type MigrationConfig struct {
 	Workers              int                      `mapstructure:"workers"`                 // Number of parallel workers
 	PollIntervalSeconds  int                      `mapstructure:"poll_interval_seconds"`   // Polling interval in seconds
+	InstanceID           string                   `mapstructure:"instance_id"`             // Unique ID of this server replica for migration leases (default: hostname)
+	LeaseTTLSeconds      int                      `mapstructure:"lease_ttl_seconds"`       // How long a migration lease or leader lock lasts without renewal
 	PostMigrationMode    string                   `mapstructure:"post_migration_mode"`     // never, production_only, dry_run_only, always
+	DeepValidation       bool                     `mapstructure:"deep_validation"`         // Compare every ref SHA, artifact counts and LFS objects after migration
+	ReferenceRewritePRs  bool                     `mapstructure:"reference_rewrite_prs"`   // Open a pull request rewriting submodule, workflow and manifest references after migration
+	CodeownersRewrite    string                   `mapstructure:"codeowners_rewrite"`      // off, commit, pull_request
+	CollaboratorGrants   string                   `mapstructure:"collaborator_grants"`     // off, all, members_only
+	SettingsSync         []string                 `mapstructure:"settings_sync"`           // Repository settings synced from the source after migration, or "all"
+	ProtectionReplay     bool                     `mapstructure:"protection_replay"`       // Recreate branch protections and rulesets after production migrations
+	WebhookReplay        bool                     `mapstructure:"webhook_replay"`          // Recreate source webhooks, inactive, after production migrations
+	ActionsReplay        bool                     `mapstructure:"actions_replay"`          // Recreate Actions environments, variables and placeholder secrets after production migrations
+	RequireBatchApproval bool                     `mapstructure:"require_batch_approval"`  // Batches need a request approved by a second admin before their production migration
 	DestRepoExistsAction string                   `mapstructure:"dest_repo_exists_action"` // fail, skip, delete
 	VisibilityHandling   VisibilityHandlingConfig `mapstructure:"visibility_handling"`     // Visibility transformation rules
+	ELM                  ELMConfig                `mapstructure:"elm"`                     // Enterprise Live Migrator endpoint (used by batches with migration_api=ELM)
+	GitMirror            GitMirrorConfig          `mapstructure:"git_mirror"`              // Git mirror push options (used by batches with migration_api=GIT)
+}
+
+// ELMConfig defines the Enterprise Live Migrator (ELM) service endpoint
+type ELMConfig struct {
+	BaseURL string `mapstructure:"base_url"` // ELM API base URL (ELM batches fail validation when empty)
+	Token   string `mapstructure:"token"`    // ELM API token
+}
+
+// GitMirrorConfig defines how git-only mirror-push migrations transfer repositories
+type GitMirrorConfig struct {
+	IncludeLFS bool     `mapstructure:"include_lfs"` // Transfer Git LFS objects for repositories that use LFS (default: true)
+	RefFilters []string `mapstructure:"ref_filters"` // Ref patterns to push, e.g. refs/heads/main or refs/tags/v* (default: all branches and tags)
 }
 
 // VisibilityHandlingConfig defines how to handle repository visibility during migration
@@ -223,10 +248,25 @@ func bindEnvVars() {
 		"destination.app_installation_id",
 		"migration.workers",
 		"migration.poll_interval_seconds",
+		"migration.instance_id",
+		"migration.lease_ttl_seconds",
 		"migration.post_migration_mode",
+		"migration.deep_validation",
+		"migration.reference_rewrite_prs",
+		"migration.codeowners_rewrite",
+		"migration.collaborator_grants",
+		"migration.settings_sync",
+		"migration.protection_replay",
+		"migration.webhook_replay",
+		"migration.actions_replay",
+		"migration.require_batch_approval",
 		"migration.dest_repo_exists_action",
 		"migration.visibility_handling.public_repos",
 		"migration.visibility_handling.internal_repos",
+		"migration.elm.base_url",
+		"migration.elm.token",
+		"migration.git_mirror.include_lfs",
+		"migration.git_mirror.ref_filters",
 		"logging.level",
 		"logging.format",
 		"logging.output_file",
@@ -274,10 +314,21 @@ func setDefaults() {
 	viper.SetDefault("destination.base_url", "https://api.github.com")
 	viper.SetDefault("migration.workers", 5)
 	viper.SetDefault("migration.poll_interval_seconds", 30)
+	viper.SetDefault("migration.lease_ttl_seconds", 120)
 	viper.SetDefault("migration.post_migration_mode", "production_only")
+	viper.SetDefault("migration.deep_validation", false)
+	viper.SetDefault("migration.reference_rewrite_prs", false)
+	viper.SetDefault("migration.codeowners_rewrite", "off")
+	viper.SetDefault("migration.collaborator_grants", "off")
+	viper.SetDefault("migration.settings_sync", []string{})
+	viper.SetDefault("migration.protection_replay", true)
+	viper.SetDefault("migration.webhook_replay", true)
+	viper.SetDefault("migration.actions_replay", true)
+	viper.SetDefault("migration.require_batch_approval", false)
 	viper.SetDefault("migration.dest_repo_exists_action", "fail")
 	viper.SetDefault("migration.visibility_handling.public_repos", "private")
 	viper.SetDefault("migration.visibility_handling.internal_repos", "private")
+	viper.SetDefault("migration.git_mirror.include_lfs", true)
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.format", "json")
 	viper.SetDefault("logging.output_file", "./logs/migrator.log")
@@ -353,6 +404,12 @@ func (c *Config) ParseArrayEnvVars() {
 		c.Auth.AuthorizationRules.MigrationAdminTeams,
 	)
 
+	// Parse git_mirror.ref_filters
+	c.Migration.GitMirror.RefFilters = parseStringSlice(c.Migration.GitMirror.RefFilters)
+
+	// Parse settings_sync
+	c.Migration.SettingsSync = parseStringSlice(c.Migration.SettingsSync)
+
 	// Merge privileged_teams into migration_admin_teams for backward compatibility
 	if len(c.Auth.AuthorizationRules.PrivilegedTeams) > 0 && len(c.Auth.AuthorizationRules.MigrationAdminTeams) == 0 {
 		c.Auth.AuthorizationRules.MigrationAdminTeams = c.Auth.AuthorizationRules.PrivilegedTeams
@@ -25,6 +25,7 @@ func TestSetDefaults(t *testing.T) {
 		{"logging.max_size", 100},
 		{"logging.max_backups", 3},
 		{"logging.max_age", 28},
+		{"migration.git_mirror.include_lfs", true},
 	}
 
 	for _, tt := range tests {
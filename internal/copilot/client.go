@@ -173,6 +173,8 @@ type ClientConfig struct {
 	SessionTimeoutMin int
 	Streaming         bool
 	GHToken           string // GitHub token for Copilot CLI authentication (optional)
+
+	RequireBatchApproval bool // Refuse production batch starts without an approved request
 }
 
 // NewClient creates a new Copilot SDK client wrapper.
@@ -306,6 +308,15 @@ func (c *Client) getCurrentAuth() *AuthContext {
 	return c.currentAuth
 }
 
+// approvalActor returns who is recorded in the approval log when a tool starts an approved batch:
+// the signed-in user, or "copilot" when authentication is disabled
+func (c *Client) approvalActor() string {
+	if auth := c.getCurrentAuth(); auth != nil && auth.UserLogin != "" {
+		return auth.UserLogin
+	}
+	return "copilot"
+}
+
 // setCurrentAuth sets the auth context for testing purposes.
 // In production, auth is set by acquiring messageMu in SendMessage/StreamMessage.
 func (c *Client) setCurrentAuth(auth *AuthContext) {
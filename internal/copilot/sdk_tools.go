@@ -11,6 +11,7 @@ import (
 	"time"
 
 	copilot "github.com/github/copilot-sdk/go"
+	batchpkg "github.com/kuhlman-labs/github-migrator/internal/batch"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
@@ -144,7 +145,7 @@ type ConfigureBatchParams struct {
 	BatchName      string `json:"batch_name,omitempty" jsonschema:"Name of the batch to configure"`
 	BatchID        int64  `json:"batch_id,omitempty" jsonschema:"ID of the batch to configure"`
 	DestinationOrg string `json:"destination_org,omitempty" jsonschema:"Destination organization for migration"`
-	MigrationAPI   string `json:"migration_api,omitempty" jsonschema:"Migration API to use (GEI or ELM)"`
+	MigrationAPI   string `json:"migration_api,omitempty" jsonschema:"Migration API to use (GEI, ELM or GIT)"`
 }
 
 // CheckDependenciesParams defines parameters for check_dependencies tool.
@@ -528,7 +529,7 @@ func (c *Client) createBatchTool() copilot.Tool {
 func (c *Client) createConfigureBatchTool() copilot.Tool {
 	return copilot.DefineTool(
 		"configure_batch",
-		"Configure batch settings including destination organization and migration API (GEI or ELM)",
+		"Configure batch settings including destination organization and migration API (GEI, ELM or GIT)",
 		func(params ConfigureBatchParams, inv copilot.ToolInvocation) (any, error) {
 			return c.executeConfigureBatch(context.Background(), params)
 		},
@@ -1219,8 +1220,8 @@ func (c *Client) executeConfigureBatch(ctx context.Context, params ConfigureBatc
 	}
 	if params.MigrationAPI != "" {
 		migrationAPI := strings.ToUpper(params.MigrationAPI)
-		if migrationAPI != models.MigrationAPIGEI && migrationAPI != models.MigrationAPIELM {
-			return nil, fmt.Errorf("invalid migration_api '%s'. Must be 'GEI' or 'ELM'", params.MigrationAPI)
+		if !models.IsValidMigrationAPI(migrationAPI) {
+			return nil, fmt.Errorf("invalid migration_api '%s'. Must be 'GEI', 'ELM' or 'GIT'", params.MigrationAPI)
 		}
 		batch.MigrationAPI = migrationAPI
 		changes = append(changes, fmt.Sprintf("migration API set to '%s'", migrationAPI))
@@ -1655,6 +1656,12 @@ func (c *Client) executeStartMigration(ctx context.Context, params StartMigratio
 			return nil, fmt.Errorf("batch '%s' has no repositories", batch.Name)
 		}
 
+		if !dryRun && c.config.RequireBatchApproval {
+			if err := batchpkg.UseApproval(ctx, c.db, batch, c.approvalActor()); err != nil {
+				return nil, err
+			}
+		}
+
 		batch.Status = models.BatchStatusInProgress
 		now := time.Now()
 		if dryRun {
@@ -1702,6 +1709,18 @@ func (c *Client) executeStartMigration(ctx context.Context, params StartMigratio
 			return nil, fmt.Errorf("repository '%s' cannot be queued for migration (status: %s)", params.Repository, repo.Status)
 		}
 
+		if !dryRun && c.config.RequireBatchApproval && repo.BatchID != nil {
+			repoBatch, err := c.db.GetBatch(ctx, *repo.BatchID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get batch: %w", err)
+			}
+			if repoBatch != nil {
+				if err := batchpkg.CheckRepositoryApproval(ctx, c.db, repoBatch, repo.ID); err != nil {
+					return nil, err
+				}
+			}
+		}
+
 		repo.Status = string(targetStatus)
 		if err := c.db.UpdateRepository(ctx, repo); err != nil {
 			return nil, fmt.Errorf("failed to queue repository: %w", err)
@@ -2,11 +2,13 @@ package copilot
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"os"
 	"testing"
 	"time"
 
+	batchpkg "github.com/kuhlman-labs/github-migrator/internal/batch"
 	"github.com/kuhlman-labs/github-migrator/internal/config"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
@@ -789,6 +791,75 @@ func TestExecuteStartMigration_RequiresAdmin(t *testing.T) {
 	}
 }
 
+func TestExecuteStartMigration_RequiresBatchApproval(t *testing.T) {
+	client, db := testClient(t)
+	client.config.RequireBatchApproval = true
+	ctx := context.Background()
+
+	batch := &models.Batch{Name: "approval-batch", Type: models.BatchTypePilot, Status: models.BatchStatusReady}
+	if err := db.CreateBatch(ctx, batch); err != nil {
+		t.Fatalf("failed to create test batch: %v", err)
+	}
+	repo := &models.Repository{
+		FullName:     "org/approval-repo",
+		Status:       string(models.StatusDryRunComplete),
+		Source:       "github",
+		DiscoveredAt: time.Now(),
+		UpdatedAt:    time.Now(),
+		BatchID:      &batch.ID,
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("failed to create test repository: %v", err)
+	}
+
+	client.setCurrentAuth(&AuthContext{
+		UserID:    "2",
+		UserLogin: "admin",
+		Tier:      "admin",
+		Permissions: ToolPermissions{
+			CanRead:       true,
+			CanMigrateOwn: true,
+			CanMigrateAll: true,
+		},
+	})
+	defer client.clearCurrentAuth()
+
+	production := false
+	if _, err := client.executeStartMigration(ctx, StartMigrationParams{BatchID: batch.ID, DryRun: &production}); !batchpkg.IsApprovalError(err) {
+		t.Fatalf("expected an unapproved batch not to start, got %v", err)
+	}
+	if _, err := client.executeStartMigration(ctx, StartMigrationParams{Repository: repo.FullName, DryRun: &production}); !batchpkg.IsApprovalError(err) {
+		t.Fatalf("expected a repository of an unapproved batch not to start, got %v", err)
+	}
+	got, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("failed to get repository: %v", err)
+	}
+	if got.Status != string(models.StatusDryRunComplete) {
+		t.Errorf("expected the repository not to be queued, got status %s", got.Status)
+	}
+
+	summary, _ := json.Marshal(batchpkg.DryRunSummary{Repositories: 1, RepositoryIDs: []int64{repo.ID}})
+	for _, step := range []*models.BatchApproval{
+		{BatchID: batch.ID, Action: models.BatchApprovalRequested, Actor: "alice", DryRunSummary: summary},
+		{BatchID: batch.ID, Action: models.BatchApprovalApproved, Actor: "bob"},
+	} {
+		if err := db.CreateBatchApproval(ctx, step); err != nil {
+			t.Fatalf("failed to create batch approval: %v", err)
+		}
+	}
+	if _, err := client.executeStartMigration(ctx, StartMigrationParams{BatchID: batch.ID, DryRun: &production}); err != nil {
+		t.Fatalf("expected the approved batch to start, got %v", err)
+	}
+	approvals, err := db.ListBatchApprovals(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("failed to list batch approvals: %v", err)
+	}
+	if len(approvals) != 3 || approvals[2].Action != models.BatchApprovalStarted || approvals[2].Actor != "admin" {
+		t.Errorf("expected the start to use the approval as admin, got %+v", approvals)
+	}
+}
+
 // =============================================================================
 // Tool Parameter Validation Tests
 // =============================================================================
@@ -26,6 +26,8 @@ type ServiceConfig struct {
 	Streaming         bool   // Enable streaming responses
 	LogLevel          string // SDK log level (debug, info, warn, error)
 	GHToken           string // GitHub token for Copilot CLI authentication (optional)
+
+	RequireBatchApproval bool // Refuse production batch starts without an approved request
 }
 
 // NewService creates a new Copilot service that uses the SDK.
@@ -39,6 +41,8 @@ func NewService(db *storage.Database, logger *slog.Logger, config ServiceConfig)
 		SessionTimeoutMin: config.SessionTimeoutMin,
 		Streaming:         config.Streaming,
 		GHToken:           config.GHToken,
+
+		RequireBatchApproval: config.RequireBatchApproval,
 	}
 
 	// Set defaults
@@ -0,0 +1,270 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/bitbucket"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+)
+
+// bitbucketPipelinesFile is the path of the Bitbucket Pipelines configuration in a repository
+const bitbucketPipelinesFile = "bitbucket-pipelines.yml"
+
+// bitbucketManyPRsThreshold is the pull request count above which metadata migration time becomes a complexity factor
+const bitbucketManyPRsThreshold = 50
+
+// BitbucketProfiler profiles Bitbucket Server / Data Center repositories
+type BitbucketProfiler struct {
+	client   *bitbucket.Client
+	logger   *slog.Logger
+	provider source.Provider // Optional: enables clone-based Git analysis when set
+}
+
+// NewBitbucketProfiler creates a new Bitbucket profiler.
+// provider may be nil, in which case only API-based profiling is performed.
+func NewBitbucketProfiler(client *bitbucket.Client, logger *slog.Logger, provider source.Provider) *BitbucketProfiler {
+	return &BitbucketProfiler{
+		client:   client,
+		logger:   logger,
+		provider: provider,
+	}
+}
+
+// ProfileRepository profiles a Bitbucket repository.
+// This records branch permissions, pull request counts, webhooks and Bitbucket Pipelines
+// usage into the repository features, optionally runs Git analysis on a clone, and
+// calculates the complexity score.
+func (p *BitbucketProfiler) ProfileRepository(ctx context.Context, repo *models.Repository, bbRepo *bitbucket.Repository) error {
+	if bbRepo == nil {
+		return fmt.Errorf("bitbucket repository is required")
+	}
+
+	p.logger.Debug("Profiling Bitbucket repository",
+		"repo", repo.FullName,
+		"repository_id", bbRepo.ID)
+
+	// 1. Profile Bitbucket features from the API
+	p.profileFeatures(ctx, repo, bbRepo)
+
+	// 2. Clone and analyze Git properties (LFS, submodules, large files)
+	if p.provider != nil && repo.SourceURL != "" {
+		if err := p.cloneAndAnalyzeGit(ctx, repo); err != nil {
+			p.logger.Warn("Failed to clone and analyze Git properties",
+				"repo", repo.FullName,
+				"error", err)
+			// Continue even if Git analysis fails - we have API-based data
+		}
+	}
+
+	// 3. Calculate complexity score based on all profiled features
+	complexity, breakdown := p.EstimateComplexityWithBreakdown(repo)
+	repo.SetComplexityScore(&complexity)
+
+	if err := repo.SetComplexityBreakdown(breakdown); err != nil {
+		p.logger.Warn("Failed to serialize complexity breakdown",
+			"repo", repo.FullName,
+			"error", err)
+	}
+
+	p.logger.Info("Bitbucket repository profiled",
+		"repo", repo.FullName,
+		"pull_requests", repo.GetPullRequestCount(),
+		"has_bitbucket_pipelines", repo.HasBitbucketPipelines(),
+		"branch_permissions", repo.GetBranchProtections(),
+		"webhooks", repo.GetWebhookCount(),
+		"complexity", complexity)
+
+	return nil
+}
+
+// profileFeatures records the Bitbucket-specific features of a repository.
+// Each API call is best-effort: failures are logged and profiling continues.
+func (p *BitbucketProfiler) profileFeatures(ctx context.Context, repo *models.Repository, bbRepo *bitbucket.Repository) {
+	projectKey, slug := bbRepo.Project.Key, bbRepo.Slug
+
+	// Default branch (empty repositories have none)
+	if branch, err := p.client.GetDefaultBranch(ctx, projectKey, slug); err != nil {
+		if !errors.Is(err, bitbucket.ErrNotFound) {
+			p.logger.Warn("Failed to get default branch", "repo", repo.FullName, "error", err)
+		}
+	} else if branch.DisplayID != "" {
+		defaultBranch := branch.DisplayID
+		repo.SetDefaultBranch(&defaultBranch)
+	}
+
+	// Repository size (not available on every Bitbucket version)
+	if sizes, err := p.client.GetRepositorySizes(ctx, projectKey, slug); err != nil {
+		if !errors.Is(err, bitbucket.ErrNotFound) {
+			p.logger.Warn("Failed to get repository size", "repo", repo.FullName, "error", err)
+		}
+	} else {
+		size := sizes.Repository
+		repo.SetTotalSize(&size)
+	}
+
+	// Bitbucket Pipelines configuration on the default branch
+	hasPipelines, err := p.client.HasFile(ctx, projectKey, slug, bitbucketPipelinesFile)
+	if err != nil {
+		p.logger.Warn("Failed to check for Bitbucket Pipelines configuration", "repo", repo.FullName, "error", err)
+	}
+	repo.SetHasBitbucketPipelines(hasPipelines)
+
+	// Pull requests
+	if total, err := p.client.CountPullRequests(ctx, projectKey, slug, "ALL"); err != nil {
+		p.logger.Warn("Failed to count pull requests", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetPullRequestCount(total)
+	}
+	if open, err := p.client.CountPullRequests(ctx, projectKey, slug, "OPEN"); err != nil {
+		p.logger.Warn("Failed to count open pull requests", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetOpenPRCount(open)
+	}
+
+	// Branch permissions
+	if restrictions, err := p.client.ListBranchRestrictions(ctx, projectKey, slug); err != nil {
+		p.logger.Warn("Failed to list branch permissions", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetBranchProtections(len(restrictions))
+	}
+
+	// Webhooks
+	if hooks, err := p.client.ListWebhooks(ctx, projectKey, slug); err != nil {
+		p.logger.Warn("Failed to list webhooks", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetWebhookCount(len(hooks))
+	}
+}
+
+// cloneAndAnalyzeGit clones the repository and analyzes Git properties
+func (p *BitbucketProfiler) cloneAndAnalyzeGit(ctx context.Context, repo *models.Repository) error {
+	tempDir, err := p.setupTempDir(repo.FullName)
+	if err != nil {
+		return fmt.Errorf("failed to setup temp directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			p.logger.Warn("Failed to clean up temp directory",
+				"path", tempDir,
+				"error", err)
+		}
+	}()
+
+	p.logger.Info("Cloning repository for Git analysis",
+		"repo", repo.FullName,
+		"path", tempDir)
+
+	repoInfo := source.RepositoryInfo{
+		FullName: repo.FullName,
+		CloneURL: repo.SourceURL,
+	}
+
+	cloneOpts := source.CloneOptions{
+		Shallow:           false, // Full clone required for git-sizer analysis
+		IncludeLFS:        false, // Don't fetch LFS content during discovery
+		IncludeSubmodules: false,
+	}
+
+	if err := p.provider.CloneRepository(ctx, repoInfo, tempDir, cloneOpts); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	analyzer := NewAnalyzer(p.logger)
+	if err := analyzer.AnalyzeGitProperties(ctx, repo, tempDir); err != nil {
+		return fmt.Errorf("failed to analyze Git properties: %w", err)
+	}
+
+	return nil
+}
+
+// setupTempDir creates a temporary directory for cloning
+func (p *BitbucketProfiler) setupTempDir(fullName string) (string, error) {
+	tempBase := filepath.Join(os.TempDir(), "github-migrator-bitbucket")
+
+	// #nosec G301 -- 0755 is appropriate for temporary directory
+	if err := os.MkdirAll(tempBase, 0755); err != nil {
+		return "", fmt.Errorf("failed to create temp base directory: %w", err)
+	}
+
+	safeName := strings.ReplaceAll(fullName, "/", "_")
+	safeName = strings.ReplaceAll(safeName, "..", "_")
+	safeName = strings.ReplaceAll(safeName, "\\", "_")
+	tempDir := filepath.Join(tempBase, safeName)
+
+	if err := source.ValidateRepoPath(tempDir); err != nil {
+		return "", fmt.Errorf("invalid temp directory path: %w", err)
+	}
+
+	if err := os.RemoveAll(tempDir); err != nil {
+		return "", fmt.Errorf("failed to remove existing temp directory: %w", err)
+	}
+
+	return tempDir, nil
+}
+
+// EstimateComplexityWithBreakdown estimates complexity for a Bitbucket repository and provides a breakdown.
+// Git-level factors use the same scoring as GitHub repositories; Bitbucket-specific factors
+// reflect what needs to be recreated or reworked on GitHub.
+func (p *BitbucketProfiler) EstimateComplexityWithBreakdown(repo *models.Repository) (int, *models.ComplexityBreakdown) {
+	breakdown := &models.ComplexityBreakdown{}
+
+	// Git-level factors
+	breakdown.SizePoints = calculateSizePoints(repo.GetTotalSize())
+	if repo.HasLargeFiles() {
+		breakdown.LargeFilesPoints = 4
+	}
+	if repo.HasLFS() {
+		breakdown.LFSPoints = 2
+	}
+	if repo.HasSubmodules() {
+		breakdown.SubmodulesPoints = 2
+	}
+	switch repo.Visibility {
+	case "public":
+		breakdown.PublicVisibilityPoints = 1
+	case "internal":
+		breakdown.InternalVisibilityPoints = 1
+	}
+	breakdown.ActivityPoints = calculateActivityPoints(repo)
+
+	// Bitbucket Pipelines must be rewritten as GitHub Actions workflows
+	if repo.HasBitbucketPipelines() {
+		breakdown.BitbucketPipelinesPoints = 3
+	}
+
+	// Many pull requests lengthen metadata migration
+	if repo.GetPullRequestCount() > bitbucketManyPRsThreshold {
+		breakdown.BitbucketManyPRsPoints = 2
+	}
+
+	// Branch permissions must be recreated as branch protection rules or rulesets
+	if repo.GetBranchProtections() > 0 {
+		breakdown.BitbucketBranchPermissionsPoints = 1
+	}
+
+	// Webhooks must be recreated on the destination
+	if repo.GetWebhookCount() > 0 {
+		breakdown.BitbucketWebhooksPoints = 1
+	}
+
+	total := breakdown.SizePoints +
+		breakdown.LargeFilesPoints +
+		breakdown.LFSPoints +
+		breakdown.SubmodulesPoints +
+		breakdown.PublicVisibilityPoints +
+		breakdown.InternalVisibilityPoints +
+		breakdown.ActivityPoints +
+		breakdown.BitbucketPipelinesPoints +
+		breakdown.BitbucketManyPRsPoints +
+		breakdown.BitbucketBranchPermissionsPoints +
+		breakdown.BitbucketWebhooksPoints
+
+	return total, breakdown
+}
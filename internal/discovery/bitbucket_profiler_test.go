@@ -0,0 +1,86 @@
+package discovery
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestBitbucketProfiler_EstimateComplexityWithBreakdown(t *testing.T) {
+	profiler := NewBitbucketProfiler(nil, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil)
+
+	tests := []struct {
+		name      string
+		setup     func(repo *models.Repository)
+		wantTotal int
+		check     func(t *testing.T, b *models.ComplexityBreakdown)
+	}{
+		{
+			name:      "simple private repository",
+			setup:     func(repo *models.Repository) {},
+			wantTotal: 0,
+		},
+		{
+			name: "Bitbucket Pipelines",
+			setup: func(repo *models.Repository) {
+				repo.SetHasBitbucketPipelines(true)
+			},
+			wantTotal: 3,
+			check: func(t *testing.T, b *models.ComplexityBreakdown) {
+				if b.BitbucketPipelinesPoints != 3 {
+					t.Errorf("unexpected breakdown %+v", b)
+				}
+			},
+		},
+		{
+			name: "many pull requests, branch permissions and webhooks",
+			setup: func(repo *models.Repository) {
+				repo.SetPullRequestCount(51)
+				repo.SetBranchProtections(2)
+				repo.SetWebhookCount(3)
+			},
+			wantTotal: 4,
+			check: func(t *testing.T, b *models.ComplexityBreakdown) {
+				if b.BitbucketManyPRsPoints != 2 || b.BitbucketBranchPermissionsPoints != 1 || b.BitbucketWebhooksPoints != 1 {
+					t.Errorf("unexpected breakdown %+v", b)
+				}
+				if b.BranchProtectionsPoints != 0 || b.WebhooksPoints != 0 {
+					t.Errorf("GitHub factors should not be scored for Bitbucket repositories: %+v", b)
+				}
+			},
+		},
+		{
+			name: "pull requests at threshold",
+			setup: func(repo *models.Repository) {
+				repo.SetPullRequestCount(50)
+			},
+			wantTotal: 0,
+		},
+		{
+			name: "git level factors",
+			setup: func(repo *models.Repository) {
+				repo.Visibility = "public"
+				repo.SetHasLFS(true)
+				repo.SetHasSubmodules(true)
+			},
+			wantTotal: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &models.Repository{FullName: "PROJ/repo", Visibility: "private"}
+			tt.setup(repo)
+
+			total, breakdown := profiler.EstimateComplexityWithBreakdown(repo)
+			if total != tt.wantTotal {
+				t.Errorf("EstimateComplexityWithBreakdown() = %d, want %d (%+v)", total, tt.wantTotal, breakdown)
+			}
+			if tt.check != nil {
+				tt.check(t, breakdown)
+			}
+		})
+	}
+}
@@ -397,6 +397,9 @@ func (c *Collector) teamsOnlyWorker(ctx context.Context, wg *sync.WaitGroup, wor
 		if teamInfo.Description != "" {
 			team.Description = stringPtr(teamInfo.Description)
 		}
+		if teamInfo.ParentSlug != "" {
+			team.ParentSlug = stringPtr(teamInfo.ParentSlug)
+		}
 
 		if err := c.storage.SaveTeam(ctx, team); err != nil {
 			c.logger.Warn("Failed to save team",
@@ -1644,6 +1647,9 @@ func (c *Collector) teamDiscoveryWorker(ctx context.Context, wg *sync.WaitGroup,
 		if teamInfo.Description != "" {
 			team.Description = stringPtr(teamInfo.Description)
 		}
+		if teamInfo.ParentSlug != "" {
+			team.ParentSlug = stringPtr(teamInfo.ParentSlug)
+		}
 
 		if err := c.storage.SaveTeam(ctx, team); err != nil {
 			c.logger.Warn("Failed to save team",
@@ -0,0 +1,251 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/kuhlman-labs/github-migrator/internal/bitbucket"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// BitbucketCollector discovers Bitbucket Server / Data Center projects and repositories
+type BitbucketCollector struct {
+	client          *bitbucket.Client
+	storage         *storage.Database
+	logger          *slog.Logger
+	profiler        *BitbucketProfiler
+	workers         int             // Number of parallel workers
+	sourceID        *int64          // Multi-source ID to associate with discovered entities
+	progressTracker ProgressTracker // Progress tracker for discovery updates
+}
+
+// NewBitbucketCollector creates a new Bitbucket collector.
+// provider is optional; when set, repositories are cloned for Git analysis during profiling.
+func NewBitbucketCollector(client *bitbucket.Client, db *storage.Database, logger *slog.Logger, provider source.Provider) *BitbucketCollector {
+	return &BitbucketCollector{
+		client:   client,
+		storage:  db,
+		logger:   logger,
+		profiler: NewBitbucketProfiler(client, logger, provider),
+		workers:  5, // Default to 5 parallel workers (matches GitHub collector)
+	}
+}
+
+// SetWorkers sets the number of parallel workers for processing
+func (c *BitbucketCollector) SetWorkers(workers int) {
+	if workers > 0 {
+		c.workers = workers
+	}
+}
+
+// SetSourceID sets the source ID to associate with discovered repositories
+func (c *BitbucketCollector) SetSourceID(sourceID *int64) {
+	c.sourceID = sourceID
+}
+
+// SetProgressTracker sets the progress tracker for discovery updates
+func (c *BitbucketCollector) SetProgressTracker(tracker ProgressTracker) {
+	c.progressTracker = tracker
+}
+
+// getTracker returns the progress tracker, or a no-op tracker if none is set
+func (c *BitbucketCollector) getTracker() ProgressTracker {
+	if c.progressTracker != nil {
+		return c.progressTracker
+	}
+	return NoOpProgressTracker{}
+}
+
+// DiscoverBitbucketProjects discovers every repository in the given projects.
+// When projectKeys is empty, every project visible to the token is discovered.
+// Projects are tracked like GitHub organizations for progress reporting.
+func (c *BitbucketCollector) DiscoverBitbucketProjects(ctx context.Context, projectKeys []string) error {
+	tracker := c.getTracker()
+
+	if len(projectKeys) == 0 {
+		tracker.SetPhase(models.PhaseListingRepos)
+		projects, err := c.client.ListProjects(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list projects: %w", err)
+		}
+		for _, project := range projects {
+			projectKeys = append(projectKeys, project.Key)
+		}
+		c.logger.Info("Found Bitbucket projects", "count", len(projectKeys))
+	}
+
+	tracker.SetTotalOrgs(len(projectKeys))
+
+	var lastErr error
+	for i, projectKey := range projectKeys {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		tracker.StartOrg(projectKey, i)
+		repoCount, err := c.discoverProject(ctx, projectKey, tracker)
+		if err != nil {
+			c.logger.Error("Failed to discover Bitbucket project", "project", projectKey, "error", err)
+			tracker.RecordError(err)
+			lastErr = err
+		}
+		tracker.CompleteOrg(projectKey, repoCount)
+	}
+
+	// After discovery completes, update local dependency flags
+	if c.storage != nil {
+		if err := c.storage.UpdateLocalDependencyFlags(ctx); err != nil {
+			c.logger.Warn("Failed to update local dependency flags", "error", err)
+			// Don't fail the whole discovery if this fails
+		}
+	}
+
+	return lastErr
+}
+
+// DiscoverBitbucketProject discovers every repository in a single project
+func (c *BitbucketCollector) DiscoverBitbucketProject(ctx context.Context, projectKey string) error {
+	return c.DiscoverBitbucketProjects(ctx, []string{projectKey})
+}
+
+// discoverProject lists and profiles the repositories of a project.
+// It returns the number of repositories discovered.
+func (c *BitbucketCollector) discoverProject(ctx context.Context, projectKey string, tracker ProgressTracker) (int, error) {
+	c.logger.Info("Starting Bitbucket project discovery", "project", projectKey)
+
+	tracker.SetPhase(models.PhaseListingRepos)
+	if _, err := c.client.GetProject(ctx, projectKey); err != nil {
+		return 0, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	repos, err := c.client.ListRepositories(ctx, projectKey)
+	if err != nil {
+		return 0, err
+	}
+
+	tracker.AddRepos(len(repos))
+	tracker.SetPhase(models.PhaseProfilingRepos)
+
+	c.logger.Info("Found repositories in project",
+		"project", projectKey,
+		"count", len(repos),
+		"workers", c.workers)
+
+	if err := c.processReposInParallel(ctx, projectKey, repos, tracker); err != nil {
+		return len(repos), fmt.Errorf("failed to process repositories: %w", err)
+	}
+
+	c.logger.Info("Bitbucket project discovery complete",
+		"project", projectKey,
+		"repositories", len(repos))
+
+	return len(repos), nil
+}
+
+// processReposInParallel profiles and saves repositories using a worker pool
+func (c *BitbucketCollector) processReposInParallel(ctx context.Context, projectKey string, repos []bitbucket.Repository, tracker ProgressTracker) error {
+	jobs := make(chan *bitbucket.Repository, len(repos))
+	errors := make(chan error, len(repos))
+	var wg sync.WaitGroup
+
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go c.worker(ctx, &wg, i, jobs, errors, tracker)
+	}
+
+	for i := range repos {
+		jobs <- &repos[i]
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(errors)
+
+	var errs []error
+	for err := range errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		c.logger.Warn("Repository processing completed with errors",
+			"project", projectKey,
+			"total_repos", len(repos),
+			"error_count", len(errs))
+		return fmt.Errorf("encountered %d errors during repository processing (see logs for details)", len(errs))
+	}
+
+	return nil
+}
+
+// worker processes Bitbucket repositories from the jobs channel
+func (c *BitbucketCollector) worker(ctx context.Context, wg *sync.WaitGroup, workerID int, jobs <-chan *bitbucket.Repository, errors chan<- error, tracker ProgressTracker) {
+	defer wg.Done()
+
+	for bbRepo := range jobs {
+		if ctx.Err() != nil {
+			tracker.IncrementProcessedRepos(1)
+			continue
+		}
+
+		c.logger.Debug("Worker processing repository",
+			"worker_id", workerID,
+			"repo", bbRepo.FullName())
+
+		repo := c.newRepository(bbRepo)
+
+		if err := c.profiler.ProfileRepository(ctx, repo, bbRepo); err != nil {
+			c.logger.Warn("Failed to profile repository",
+				"worker_id", workerID,
+				"repo", bbRepo.FullName(),
+				"error", err)
+			// Continue even if profiling fails - we have basic info
+		}
+
+		if c.storage != nil {
+			if err := c.storage.SaveRepository(ctx, repo); err != nil {
+				c.logger.Error("Failed to save repository",
+					"worker_id", workerID,
+					"repo", bbRepo.FullName(),
+					"error", err)
+				errors <- err
+				tracker.RecordError(err)
+				tracker.IncrementProcessedRepos(1)
+				continue
+			}
+		}
+
+		tracker.IncrementProcessedRepos(1)
+	}
+}
+
+// newRepository builds the repository model for a Bitbucket repository
+func (c *BitbucketCollector) newRepository(bbRepo *bitbucket.Repository) *models.Repository {
+	// Prefer the HTTP clone link so the repository can be cloned for analysis and migration
+	sourceURL := bbRepo.HTTPCloneURL()
+	if sourceURL == "" {
+		sourceURL = bbRepo.WebURL()
+	}
+
+	visibility := "private"
+	if bbRepo.Public {
+		visibility = "public"
+	}
+
+	repo := models.NewRepository(models.RepositoryOptions{
+		FullName:   bbRepo.FullName(),
+		Source:     models.SourceBitbucket,
+		SourceURL:  sourceURL,
+		Visibility: visibility,
+		IsArchived: bbRepo.Archived,
+		IsFork:     bbRepo.Origin != nil,
+	})
+	repo.SourceID = c.sourceID
+
+	return repo
+}
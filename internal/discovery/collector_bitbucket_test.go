@@ -0,0 +1,232 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/bitbucket"
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// newFakeBitbucketAPI serves projects "APP" and "OPS". APP has a plain public repository "website"
+// and a private fork "api" that uses Bitbucket Pipelines, branch permissions and webhooks.
+func newFakeBitbucketAPI(t *testing.T) *bitbucket.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /rest/api/1.0/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"values": [{"id": 1, "key": "APP"}, {"id": 2, "key": "OPS"}], "isLastPage": true}`)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}", func(w http.ResponseWriter, r *http.Request) {
+		switch r.PathValue("key") {
+		case "APP", "OPS":
+			_, _ = fmt.Fprintf(w, `{"key": %q}`, r.PathValue("key"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/APP/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"values": [
+			{"id": 10, "slug": "website", "public": true, "project": {"key": "APP"},
+			 "links": {"clone": [{"href": "https://bitbucket.example.com/scm/app/website.git", "name": "http"}]}},
+			{"id": 11, "slug": "api", "project": {"key": "APP"}, "origin": {"id": 5, "slug": "api"},
+			 "links": {"clone": [{"href": "https://bitbucket.example.com/scm/app/api.git", "name": "http"}]}}
+		], "isLastPage": true}`)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/OPS/repos", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"values": [{"id": 20, "slug": "infra", "archived": true, "project": {"key": "OPS"}}], "isLastPage": true}`)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}/repos/{slug}/branches/default", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "refs/heads/main", "displayId": "main", "isDefault": true}`)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}/repos/{slug}/sizes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"repository": 4096, "attachments": 0}`)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}/repos/{slug}/raw/{file}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("slug") == "api" && r.PathValue("file") == "bitbucket-pipelines.yml" {
+			_, _ = fmt.Fprint(w, "pipelines: {}")
+			return
+		}
+		http.NotFound(w, r)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}/repos/{slug}/pull-requests", func(w http.ResponseWriter, r *http.Request) {
+		count := 2
+		if r.PathValue("slug") == "api" {
+			count = 60
+		}
+		if r.URL.Query().Get("state") == "OPEN" {
+			count = 1
+		}
+		values := "["
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				values += ","
+			}
+			values += fmt.Sprintf(`{"id": %d}`, i+1)
+		}
+		values += "]"
+		_, _ = fmt.Fprintf(w, `{"values": %s, "isLastPage": true}`, values)
+	})
+	mux.HandleFunc("GET /rest/branch-permissions/2.0/projects/{key}/repos/{slug}/restrictions", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("slug") == "api" {
+			_, _ = fmt.Fprint(w, `{"values": [{"id": 1, "type": "pull-request-only"}, {"id": 2, "type": "no-deletes"}], "isLastPage": true}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"values": [], "isLastPage": true}`)
+	})
+	mux.HandleFunc("GET /rest/api/1.0/projects/{key}/repos/{slug}/webhooks", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("slug") == "api" {
+			_, _ = fmt.Fprint(w, `{"values": [{"id": 1, "name": "ci", "url": "https://ci.example.com/hook", "active": true}], "isLastPage": true}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"values": [], "isLastPage": true}`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := bitbucket.NewClient(bitbucket.ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create Bitbucket client: %v", err)
+	}
+	return client
+}
+
+// countingTracker records project and repository counts reported during discovery
+type countingTracker struct {
+	NoOpProgressTracker
+	mu        sync.Mutex
+	totalOrgs int
+	processed int
+}
+
+func (t *countingTracker) SetTotalOrgs(total int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.totalOrgs = total
+}
+
+func (t *countingTracker) IncrementProcessedRepos(count int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.processed += count
+}
+
+func TestBitbucketCollector_DiscoverBitbucketProject(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client := newFakeBitbucketAPI(t)
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	src := &models.Source{Name: "Bitbucket", Type: models.SourceConfigTypeBitbucket, BaseURL: client.BaseURL(), Token: "test-token"}
+	if err := db.CreateSource(ctx, src); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+	sourceID := src.ID
+
+	collector := NewBitbucketCollector(client, db, logger, nil)
+	collector.SetSourceID(&sourceID)
+	collector.SetWorkers(2)
+
+	if err := collector.DiscoverBitbucketProject(ctx, "APP"); err != nil {
+		t.Fatalf("DiscoverBitbucketProject() error: %v", err)
+	}
+
+	website, err := db.GetRepository(ctx, "APP/website")
+	if err != nil || website == nil {
+		t.Fatalf("expected APP/website to be saved, err = %v", err)
+	}
+	if website.Source != models.SourceBitbucket {
+		t.Errorf("Source = %s, want %s", website.Source, models.SourceBitbucket)
+	}
+	if website.SourceID == nil || *website.SourceID != sourceID {
+		t.Errorf("SourceID = %v, want %d", website.SourceID, sourceID)
+	}
+	if website.SourceURL != "https://bitbucket.example.com/scm/app/website.git" {
+		t.Errorf("SourceURL = %s, want HTTP clone URL", website.SourceURL)
+	}
+	if website.Visibility != "public" || website.IsFork {
+		t.Errorf("visibility/fork = %s/%v, want public/false", website.Visibility, website.IsFork)
+	}
+	if website.HasBitbucketPipelines() {
+		t.Error("APP/website should not use Bitbucket Pipelines")
+	}
+
+	api, err := db.GetRepository(ctx, "APP/api")
+	if err != nil || api == nil {
+		t.Fatalf("expected APP/api to be saved, err = %v", err)
+	}
+	if api.Visibility != "private" || !api.IsFork {
+		t.Errorf("visibility/fork = %s/%v, want private/true", api.Visibility, api.IsFork)
+	}
+	if !api.HasBitbucketPipelines() {
+		t.Error("expected HasBitbucketPipelines for APP/api")
+	}
+	if api.GetPullRequestCount() != 60 || api.GetOpenPRCount() != 1 {
+		t.Errorf("pull request counts = %d/%d, want 60/1", api.GetPullRequestCount(), api.GetOpenPRCount())
+	}
+	if api.GetBranchProtections() != 2 {
+		t.Errorf("BranchProtections = %d, want 2", api.GetBranchProtections())
+	}
+	if api.GetWebhookCount() != 1 {
+		t.Errorf("WebhookCount = %d, want 1", api.GetWebhookCount())
+	}
+	if api.GetDefaultBranch() == nil || *api.GetDefaultBranch() != "main" {
+		t.Errorf("DefaultBranch = %v, want main", api.GetDefaultBranch())
+	}
+	if api.GetTotalSize() == nil || *api.GetTotalSize() != 4096 {
+		t.Errorf("TotalSize = %v, want 4096", api.GetTotalSize())
+	}
+
+	breakdown, err := api.GetComplexityBreakdown()
+	if err != nil || breakdown == nil {
+		t.Fatalf("GetComplexityBreakdown() = %v, %v", breakdown, err)
+	}
+	if breakdown.BitbucketPipelinesPoints != 3 || breakdown.BitbucketManyPRsPoints != 2 ||
+		breakdown.BitbucketBranchPermissionsPoints != 1 || breakdown.BitbucketWebhooksPoints != 1 {
+		t.Errorf("unexpected Bitbucket breakdown %+v", breakdown)
+	}
+}
+
+func TestBitbucketCollector_DiscoverAllProjects(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	collector := NewBitbucketCollector(newFakeBitbucketAPI(t), nil, logger, nil)
+
+	tracker := &countingTracker{}
+	collector.SetProgressTracker(tracker)
+
+	if err := collector.DiscoverBitbucketProjects(context.Background(), nil); err != nil {
+		t.Fatalf("DiscoverBitbucketProjects() error: %v", err)
+	}
+	if tracker.totalOrgs != 2 {
+		t.Errorf("total projects = %d, want 2", tracker.totalOrgs)
+	}
+	if tracker.processed != 3 {
+		t.Errorf("processed repositories = %d, want 3", tracker.processed)
+	}
+}
+
+func TestBitbucketCollector_UnknownProject(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	collector := NewBitbucketCollector(newFakeBitbucketAPI(t), nil, logger, nil)
+
+	if err := collector.DiscoverBitbucketProject(context.Background(), "MISSING"); err == nil {
+		t.Error("DiscoverBitbucketProject() expected error for unknown project")
+	}
+}
@@ -0,0 +1,278 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// GitLabCollector discovers GitLab groups, subgroups and projects
+type GitLabCollector struct {
+	client          *gitlab.Client
+	storage         *storage.Database
+	logger          *slog.Logger
+	profiler        *GitLabProfiler
+	workers         int             // Number of parallel workers
+	sourceID        *int64          // Multi-source ID to associate with discovered entities
+	progressTracker ProgressTracker // Progress tracker for discovery updates
+}
+
+// NewGitLabCollector creates a new GitLab collector.
+// provider is optional; when set, projects are cloned for Git analysis during profiling.
+func NewGitLabCollector(client *gitlab.Client, db *storage.Database, logger *slog.Logger, provider source.Provider) *GitLabCollector {
+	return &GitLabCollector{
+		client:   client,
+		storage:  db,
+		logger:   logger,
+		profiler: NewGitLabProfiler(client, logger, provider),
+		workers:  5, // Default to 5 parallel workers (matches GitHub collector)
+	}
+}
+
+// SetWorkers sets the number of parallel workers for processing
+func (c *GitLabCollector) SetWorkers(workers int) {
+	if workers > 0 {
+		c.workers = workers
+	}
+}
+
+// SetSourceID sets the source ID to associate with discovered repositories
+func (c *GitLabCollector) SetSourceID(sourceID *int64) {
+	c.sourceID = sourceID
+}
+
+// SetProgressTracker sets the progress tracker for discovery updates
+func (c *GitLabCollector) SetProgressTracker(tracker ProgressTracker) {
+	c.progressTracker = tracker
+}
+
+// getTracker returns the progress tracker, or a no-op tracker if none is set
+func (c *GitLabCollector) getTracker() ProgressTracker {
+	if c.progressTracker != nil {
+		return c.progressTracker
+	}
+	return NoOpProgressTracker{}
+}
+
+// DiscoverGitLabGroups discovers every project in the given top-level groups and all of their subgroups.
+// Groups are tracked like GitHub organizations for progress reporting.
+func (c *GitLabCollector) DiscoverGitLabGroups(ctx context.Context, groups []string) error {
+	tracker := c.getTracker()
+	tracker.SetTotalOrgs(len(groups))
+
+	var lastErr error
+	for i, group := range groups {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		tracker.StartOrg(group, i)
+		repoCount, err := c.discoverGroupTree(ctx, group, tracker)
+		if err != nil {
+			c.logger.Error("Failed to discover GitLab group", "group", group, "error", err)
+			tracker.RecordError(err)
+			lastErr = err
+		}
+		tracker.CompleteOrg(group, repoCount)
+	}
+
+	// After discovery completes, update local dependency flags
+	if c.storage != nil {
+		if err := c.storage.UpdateLocalDependencyFlags(ctx); err != nil {
+			c.logger.Warn("Failed to update local dependency flags", "error", err)
+			// Don't fail the whole discovery if this fails
+		}
+	}
+
+	return lastErr
+}
+
+// DiscoverGitLabGroup discovers every project in a group and all of its subgroups
+func (c *GitLabCollector) DiscoverGitLabGroup(ctx context.Context, group string) error {
+	return c.DiscoverGitLabGroups(ctx, []string{group})
+}
+
+// discoverGroupTree walks a group and its subgroups, profiling every project found.
+// It returns the number of projects discovered.
+func (c *GitLabCollector) discoverGroupTree(ctx context.Context, groupPath string, tracker ProgressTracker) (int, error) {
+	c.logger.Info("Starting GitLab group discovery", "group", groupPath)
+
+	tracker.SetPhase(models.PhaseListingRepos)
+	root, err := c.client.GetGroup(ctx, groupPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	projects, err := c.collectProjects(ctx, root)
+	if err != nil {
+		return 0, err
+	}
+
+	tracker.AddRepos(len(projects))
+	tracker.SetPhase(models.PhaseProfilingRepos)
+
+	c.logger.Info("Found projects in group",
+		"group", groupPath,
+		"count", len(projects),
+		"workers", c.workers)
+
+	if err := c.processProjectsInParallel(ctx, groupPath, projects, tracker); err != nil {
+		return len(projects), fmt.Errorf("failed to process projects: %w", err)
+	}
+
+	c.logger.Info("GitLab group discovery complete",
+		"group", groupPath,
+		"projects", len(projects))
+
+	return len(projects), nil
+}
+
+// collectProjects lists the projects of a group and, recursively, of all its subgroups
+func (c *GitLabCollector) collectProjects(ctx context.Context, group *gitlab.Group) ([]gitlab.Project, error) {
+	projects, err := c.client.ListGroupProjects(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subgroups, err := c.client.ListSubgroups(ctx, group.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range subgroups {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		c.logger.Debug("Walking subgroup", "group", group.FullPath, "subgroup", subgroups[i].FullPath)
+
+		subProjects, err := c.collectProjects(ctx, &subgroups[i])
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, subProjects...)
+	}
+
+	return projects, nil
+}
+
+// processProjectsInParallel profiles and saves projects using a worker pool
+func (c *GitLabCollector) processProjectsInParallel(ctx context.Context, groupPath string, projects []gitlab.Project, tracker ProgressTracker) error {
+	jobs := make(chan *gitlab.Project, len(projects))
+	errors := make(chan error, len(projects))
+	var wg sync.WaitGroup
+
+	for i := 0; i < c.workers; i++ {
+		wg.Add(1)
+		go c.worker(ctx, &wg, i, jobs, errors, tracker)
+	}
+
+	for i := range projects {
+		jobs <- &projects[i]
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(errors)
+
+	var errs []error
+	for err := range errors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		c.logger.Warn("Project processing completed with errors",
+			"group", groupPath,
+			"total_projects", len(projects),
+			"error_count", len(errs))
+		return fmt.Errorf("encountered %d errors during project processing (see logs for details)", len(errs))
+	}
+
+	return nil
+}
+
+// worker processes GitLab projects from the jobs channel
+func (c *GitLabCollector) worker(ctx context.Context, wg *sync.WaitGroup, workerID int, jobs <-chan *gitlab.Project, errors chan<- error, tracker ProgressTracker) {
+	defer wg.Done()
+
+	for project := range jobs {
+		if ctx.Err() != nil {
+			tracker.IncrementProcessedRepos(1)
+			continue
+		}
+
+		c.logger.Debug("Worker processing project",
+			"worker_id", workerID,
+			"project", project.PathWithNamespace)
+
+		repo := c.newRepository(project)
+
+		if err := c.profiler.ProfileRepository(ctx, repo, project); err != nil {
+			c.logger.Warn("Failed to profile project",
+				"worker_id", workerID,
+				"project", project.PathWithNamespace,
+				"error", err)
+			// Continue even if profiling fails - we have basic info
+		}
+
+		if c.storage != nil {
+			if err := c.storage.SaveRepository(ctx, repo); err != nil {
+				c.logger.Error("Failed to save repository",
+					"worker_id", workerID,
+					"project", project.PathWithNamespace,
+					"error", err)
+				errors <- err
+				tracker.RecordError(err)
+				tracker.IncrementProcessedRepos(1)
+				continue
+			}
+		}
+
+		tracker.IncrementProcessedRepos(1)
+	}
+}
+
+// newRepository builds the repository model for a GitLab project
+func (c *GitLabCollector) newRepository(project *gitlab.Project) *models.Repository {
+	var defaultBranch *string
+	if project.DefaultBranch != "" {
+		branch := project.DefaultBranch
+		defaultBranch = &branch
+	}
+
+	var totalSize *int64
+	if project.Statistics != nil {
+		size := project.Statistics.RepositorySize
+		totalSize = &size
+	}
+
+	visibility := project.Visibility
+	if visibility == "" {
+		visibility = "private"
+	}
+
+	repo := models.NewRepository(models.RepositoryOptions{
+		FullName:      project.PathWithNamespace,
+		Source:        models.SourceGitLab,
+		SourceURL:     project.WebURL,
+		Visibility:    visibility,
+		DefaultBranch: defaultBranch,
+		TotalSize:     totalSize,
+		IsArchived:    project.Archived,
+		IsFork:        project.ForkedFromProject != nil,
+	})
+	repo.SourceID = c.sourceID
+
+	if project.Statistics != nil && project.Statistics.CommitCount > 0 {
+		repo.EnsureGitProperties().CommitCount = int(project.Statistics.CommitCount)
+	}
+
+	return repo
+}
@@ -0,0 +1,166 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// newFakeGitLabAPI serves a group "acme" (id 1) with one project and a subgroup
+// "acme/platform" (id 2) containing a second project that uses CI, a wiki and LFS.
+func newFakeGitLabAPI(t *testing.T) *gitlab.Client {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "acme" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id": 1, "path": "acme", "full_path": "acme"}`)
+	})
+	mux.HandleFunc("GET /api/v4/groups/1/subgroups", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[{"id": 2, "path": "platform", "full_path": "acme/platform"}]`)
+	})
+	mux.HandleFunc("GET /api/v4/groups/2/subgroups", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("GET /api/v4/groups/1/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[{"id": 10, "path_with_namespace": "acme/website", "visibility": "public",
+			"web_url": "https://gitlab.example.com/acme/website", "default_branch": "main",
+			"statistics": {"repository_size": 2048, "commit_count": 12}}]`)
+	})
+	mux.HandleFunc("GET /api/v4/groups/2/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[{"id": 20, "path_with_namespace": "acme/platform/api", "visibility": "internal",
+			"web_url": "https://gitlab.example.com/acme/platform/api", "default_branch": "main",
+			"wiki_enabled": true, "lfs_enabled": true, "merge_method": "ff", "squash_option": "always",
+			"only_allow_merge_if_pipeline_succeeds": true, "remove_source_branch_after_merge": true,
+			"statistics": {"repository_size": 4096, "lfs_objects_size": 100}}]`)
+	})
+	mux.HandleFunc("HEAD /api/v4/projects/{id}/repository/files/{file}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "20" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("GET /api/v4/projects/{id}/wikis", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[{"slug": "home", "title": "Home"}]`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/{id}/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		total := "4"
+		if r.PathValue("id") == "20" {
+			total = "75"
+		}
+		if r.URL.Query().Get("state") == "opened" {
+			total = "1"
+		}
+		w.Header().Set("X-Total", total)
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("GET /api/v4/projects/{id}/protected_branches", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") == "20" {
+			_, _ = fmt.Fprint(w, `[{"id": 1, "name": "main"}, {"id": 2, "name": "release/*"}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[]`)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient(gitlab.ClientConfig{BaseURL: server.URL, Token: "test-token"})
+	if err != nil {
+		t.Fatalf("Failed to create GitLab client: %v", err)
+	}
+	return client
+}
+
+func TestGitLabCollector_DiscoverGitLabGroup(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client := newFakeGitLabAPI(t)
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create database: %v", err)
+	}
+	defer func() { _ = db.Close() }()
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	src := &models.Source{Name: "GitLab", Type: models.SourceConfigTypeGitLab, BaseURL: client.BaseURL(), Token: "test-token"}
+	if err := db.CreateSource(ctx, src); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+	sourceID := src.ID
+
+	collector := NewGitLabCollector(client, db, logger, nil)
+	collector.SetSourceID(&sourceID)
+	collector.SetWorkers(2)
+
+	if err := collector.DiscoverGitLabGroup(ctx, "acme"); err != nil {
+		t.Fatalf("DiscoverGitLabGroup() error: %v", err)
+	}
+
+	website, err := db.GetRepository(ctx, "acme/website")
+	if err != nil || website == nil {
+		t.Fatalf("expected acme/website to be saved, err = %v", err)
+	}
+	if website.Source != models.SourceGitLab {
+		t.Errorf("Source = %s, want %s", website.Source, models.SourceGitLab)
+	}
+	if website.SourceID == nil || *website.SourceID != sourceID {
+		t.Errorf("SourceID = %v, want %d", website.SourceID, sourceID)
+	}
+	if website.HasGitLabCI() {
+		t.Error("acme/website should not have GitLab CI")
+	}
+
+	api, err := db.GetRepository(ctx, "acme/platform/api")
+	if err != nil || api == nil {
+		t.Fatalf("expected subgroup project acme/platform/api to be saved, err = %v", err)
+	}
+	if !api.HasGitLabCI() {
+		t.Error("expected HasGitLabCI for acme/platform/api")
+	}
+	if !api.HasWiki() {
+		t.Error("expected HasWiki for acme/platform/api")
+	}
+	if !api.HasLFS() {
+		t.Error("expected HasLFS for acme/platform/api")
+	}
+	if api.GetPullRequestCount() != 75 || api.GetOpenPRCount() != 1 {
+		t.Errorf("merge request counts = %d/%d, want 75/1", api.GetPullRequestCount(), api.GetOpenPRCount())
+	}
+	if api.GetBranchProtections() != 2 {
+		t.Errorf("BranchProtections = %d, want 2", api.GetBranchProtections())
+	}
+
+	settings, err := api.GetMergeSettings()
+	if err != nil || settings == nil {
+		t.Fatalf("GetMergeSettings() = %v, %v", settings, err)
+	}
+	if settings.MergeMethod != "ff" || !settings.RequirePipelineSuccess || !settings.DeleteBranchOnMerge {
+		t.Errorf("unexpected merge settings %+v", settings)
+	}
+}
+
+func TestGitLabCollector_UnknownGroup(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	collector := NewGitLabCollector(newFakeGitLabAPI(t), nil, logger, nil)
+
+	if err := collector.DiscoverGitLabGroup(context.Background(), "missing"); err == nil {
+		t.Error("DiscoverGitLabGroup() expected error for unknown group")
+	}
+}
@@ -0,0 +1,282 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+)
+
+// gitLabCIFile is the path of the GitLab CI configuration in a project
+const gitLabCIFile = ".gitlab-ci.yml"
+
+// gitLabManyMRsThreshold is the merge request count above which history loss becomes a complexity factor
+const gitLabManyMRsThreshold = 50
+
+// GitLabProfiler profiles GitLab projects
+type GitLabProfiler struct {
+	client   *gitlab.Client
+	logger   *slog.Logger
+	provider source.Provider // Optional: enables clone-based Git analysis when set
+}
+
+// NewGitLabProfiler creates a new GitLab profiler.
+// provider may be nil, in which case only API-based profiling is performed.
+func NewGitLabProfiler(client *gitlab.Client, logger *slog.Logger, provider source.Provider) *GitLabProfiler {
+	return &GitLabProfiler{
+		client:   client,
+		logger:   logger,
+		provider: provider,
+	}
+}
+
+// ProfileRepository profiles a GitLab project.
+// This records CI, wiki, LFS, merge request, protected branch and merge settings
+// into the repository features, optionally runs Git analysis on a clone, and
+// calculates the complexity score.
+func (p *GitLabProfiler) ProfileRepository(ctx context.Context, repo *models.Repository, project *gitlab.Project) error {
+	if project == nil {
+		return fmt.Errorf("gitlab project is required")
+	}
+
+	p.logger.Debug("Profiling GitLab project",
+		"repo", repo.FullName,
+		"project_id", project.ID)
+
+	// 1. Profile GitLab features from the API
+	p.profileFeatures(ctx, repo, project)
+
+	// 2. Clone and analyze Git properties (LFS, submodules, large files)
+	if p.provider != nil && !project.EmptyRepo {
+		if err := p.cloneAndAnalyzeGit(ctx, repo); err != nil {
+			p.logger.Warn("Failed to clone and analyze Git properties",
+				"repo", repo.FullName,
+				"error", err)
+			// Continue even if Git analysis fails - we have API-based data
+		}
+	}
+
+	// 3. Calculate complexity score based on all profiled features
+	complexity, breakdown := p.EstimateComplexityWithBreakdown(repo)
+	repo.SetComplexityScore(&complexity)
+
+	if err := repo.SetComplexityBreakdown(breakdown); err != nil {
+		p.logger.Warn("Failed to serialize complexity breakdown",
+			"repo", repo.FullName,
+			"error", err)
+	}
+
+	p.logger.Info("GitLab project profiled",
+		"repo", repo.FullName,
+		"merge_requests", repo.GetPullRequestCount(),
+		"has_gitlab_ci", repo.HasGitLabCI(),
+		"protected_branches", repo.GetBranchProtections(),
+		"complexity", complexity)
+
+	return nil
+}
+
+// profileFeatures records the GitLab-specific features of a project.
+// Each API call is best-effort: failures are logged and profiling continues.
+func (p *GitLabProfiler) profileFeatures(ctx context.Context, repo *models.Repository, project *gitlab.Project) {
+	// LFS: only count it as used when LFS objects are actually stored
+	if project.LFSEnabled && project.Statistics != nil && project.Statistics.LFSObjectsSize > 0 {
+		repo.SetHasLFS(true)
+	}
+
+	// CI configuration on the default branch
+	if project.DefaultBranch != "" {
+		hasCI, err := p.client.HasFile(ctx, project.ID, gitLabCIFile, project.DefaultBranch)
+		if err != nil {
+			p.logger.Warn("Failed to check for GitLab CI configuration", "repo", repo.FullName, "error", err)
+		}
+		repo.SetHasGitLabCI(hasCI)
+	}
+
+	// Wiki: the wiki is a separate repository, so only flag it when it has content
+	if project.WikiEnabled {
+		pages, err := p.client.ListWikiPages(ctx, project.ID)
+		if err != nil {
+			p.logger.Warn("Failed to list wiki pages", "repo", repo.FullName, "error", err)
+		}
+		repo.SetHasWiki(len(pages) > 0)
+	}
+
+	// Merge requests
+	if total, err := p.client.CountMergeRequests(ctx, project.ID, "all"); err != nil {
+		p.logger.Warn("Failed to count merge requests", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetPullRequestCount(total)
+	}
+	if open, err := p.client.CountMergeRequests(ctx, project.ID, "opened"); err != nil {
+		p.logger.Warn("Failed to count open merge requests", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetOpenPRCount(open)
+	}
+
+	// Protected branches
+	if branches, err := p.client.ListProtectedBranches(ctx, project.ID); err != nil {
+		p.logger.Warn("Failed to list protected branches", "repo", repo.FullName, "error", err)
+	} else {
+		repo.SetBranchProtections(len(branches))
+	}
+
+	// Merge settings
+	if err := repo.SetMergeSettings(MergeSettingsFromGitLabProject(project)); err != nil {
+		p.logger.Warn("Failed to serialize merge settings", "repo", repo.FullName, "error", err)
+	}
+}
+
+// MergeSettingsFromGitLabProject extracts the merge request settings of a GitLab project
+func MergeSettingsFromGitLabProject(project *gitlab.Project) *models.MergeSettings {
+	return &models.MergeSettings{
+		MergeMethod:                 project.MergeMethod,
+		SquashOption:                project.SquashOption,
+		DeleteBranchOnMerge:         project.RemoveSourceBranchAfterMerge,
+		RequirePipelineSuccess:      project.OnlyAllowMergeIfPipelineSucceeds,
+		RequireDiscussionResolution: project.OnlyAllowMergeIfAllDiscussionsAreResolved,
+	}
+}
+
+// cloneAndAnalyzeGit clones the project and analyzes Git properties
+func (p *GitLabProfiler) cloneAndAnalyzeGit(ctx context.Context, repo *models.Repository) error {
+	tempDir, err := p.setupTempDir(repo.FullName)
+	if err != nil {
+		return fmt.Errorf("failed to setup temp directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			p.logger.Warn("Failed to clean up temp directory",
+				"path", tempDir,
+				"error", err)
+		}
+	}()
+
+	p.logger.Info("Cloning repository for Git analysis",
+		"repo", repo.FullName,
+		"path", tempDir)
+
+	repoInfo := source.RepositoryInfo{
+		FullName: repo.FullName,
+		CloneURL: repo.SourceURL,
+	}
+
+	cloneOpts := source.CloneOptions{
+		Shallow:           false, // Full clone required for git-sizer analysis
+		IncludeLFS:        false, // LFS usage is detected from project statistics
+		IncludeSubmodules: false,
+	}
+
+	if err := p.provider.CloneRepository(ctx, repoInfo, tempDir, cloneOpts); err != nil {
+		return fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	hasLFS := repo.HasLFS()
+	analyzer := NewAnalyzer(p.logger)
+	if err := analyzer.AnalyzeGitProperties(ctx, repo, tempDir); err != nil {
+		return fmt.Errorf("failed to analyze Git properties: %w", err)
+	}
+	// Keep LFS usage detected from project statistics even if .gitattributes is missing
+	if hasLFS {
+		repo.SetHasLFS(true)
+	}
+
+	return nil
+}
+
+// setupTempDir creates a temporary directory for cloning
+func (p *GitLabProfiler) setupTempDir(fullName string) (string, error) {
+	tempBase := filepath.Join(os.TempDir(), "github-migrator-gitlab")
+
+	// #nosec G301 -- 0755 is appropriate for temporary directory
+	if err := os.MkdirAll(tempBase, 0755); err != nil {
+		return "", fmt.Errorf("failed to create temp base directory: %w", err)
+	}
+
+	// GitLab paths can be nested ("group/subgroup/project"), so flatten them
+	safeName := strings.ReplaceAll(fullName, "/", "_")
+	safeName = strings.ReplaceAll(safeName, "..", "_")
+	safeName = strings.ReplaceAll(safeName, "\\", "_")
+	tempDir := filepath.Join(tempBase, safeName)
+
+	if err := source.ValidateRepoPath(tempDir); err != nil {
+		return "", fmt.Errorf("invalid temp directory path: %w", err)
+	}
+
+	if err := os.RemoveAll(tempDir); err != nil {
+		return "", fmt.Errorf("failed to remove existing temp directory: %w", err)
+	}
+
+	return tempDir, nil
+}
+
+// EstimateComplexityWithBreakdown estimates complexity for a GitLab project and provides a breakdown.
+// Git-level factors use the same scoring as GitHub repositories; GitLab-specific factors
+// reflect what the mirror-push migration cannot carry over automatically.
+func (p *GitLabProfiler) EstimateComplexityWithBreakdown(repo *models.Repository) (int, *models.ComplexityBreakdown) {
+	breakdown := &models.ComplexityBreakdown{}
+
+	// Git-level factors
+	breakdown.SizePoints = calculateSizePoints(repo.GetTotalSize())
+	if repo.HasLargeFiles() {
+		breakdown.LargeFilesPoints = 4
+	}
+	if repo.HasLFS() {
+		breakdown.LFSPoints = 2
+	}
+	if repo.HasSubmodules() {
+		breakdown.SubmodulesPoints = 2
+	}
+	if repo.GetBranchProtections() > 0 {
+		breakdown.BranchProtectionsPoints = 1
+	}
+	switch repo.Visibility {
+	case "public":
+		breakdown.PublicVisibilityPoints = 1
+	case "internal":
+		breakdown.InternalVisibilityPoints = 1
+	}
+	breakdown.ActivityPoints = calculateActivityPoints(repo)
+
+	// GitLab CI pipelines must be rewritten as GitHub Actions workflows
+	if repo.HasGitLabCI() {
+		breakdown.GitLabCIPoints = 3
+	}
+
+	// Wiki content is not part of the git mirror
+	if repo.HasWiki() {
+		breakdown.GitLabWikiPoints = 2
+	}
+
+	// Merge request history does not migrate
+	if repo.GetPullRequestCount() > gitLabManyMRsThreshold {
+		breakdown.GitLabManyMRsPoints = 2
+	}
+
+	// Pipeline and discussion merge gates must be recreated as rulesets
+	if settings, err := repo.GetMergeSettings(); err == nil && settings != nil &&
+		(settings.RequirePipelineSuccess || settings.RequireDiscussionResolution) {
+		breakdown.GitLabMergeRulesPoints = 1
+	}
+
+	total := breakdown.SizePoints +
+		breakdown.LargeFilesPoints +
+		breakdown.LFSPoints +
+		breakdown.SubmodulesPoints +
+		breakdown.BranchProtectionsPoints +
+		breakdown.PublicVisibilityPoints +
+		breakdown.InternalVisibilityPoints +
+		breakdown.ActivityPoints +
+		breakdown.GitLabCIPoints +
+		breakdown.GitLabWikiPoints +
+		breakdown.GitLabManyMRsPoints +
+		breakdown.GitLabMergeRulesPoints
+
+	return total, breakdown
+}
@@ -0,0 +1,76 @@
+package discovery
+
+import (
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestGitLabProfiler_EstimateComplexityWithBreakdown(t *testing.T) {
+	profiler := NewGitLabProfiler(nil, slog.New(slog.NewTextHandler(os.Stderr, nil)), nil)
+
+	tests := []struct {
+		name      string
+		setup     func(repo *models.Repository)
+		wantTotal int
+		check     func(t *testing.T, b *models.ComplexityBreakdown)
+	}{
+		{
+			name:      "simple private project",
+			setup:     func(repo *models.Repository) {},
+			wantTotal: 0,
+		},
+		{
+			name: "GitLab CI and wiki",
+			setup: func(repo *models.Repository) {
+				repo.SetHasGitLabCI(true)
+				repo.SetHasWiki(true)
+			},
+			wantTotal: 5,
+			check: func(t *testing.T, b *models.ComplexityBreakdown) {
+				if b.GitLabCIPoints != 3 || b.GitLabWikiPoints != 2 {
+					t.Errorf("unexpected breakdown %+v", b)
+				}
+			},
+		},
+		{
+			name: "many merge requests and merge gates",
+			setup: func(repo *models.Repository) {
+				repo.SetPullRequestCount(51)
+				_ = repo.SetMergeSettings(&models.MergeSettings{RequireDiscussionResolution: true})
+			},
+			wantTotal: 3,
+			check: func(t *testing.T, b *models.ComplexityBreakdown) {
+				if b.GitLabManyMRsPoints != 2 || b.GitLabMergeRulesPoints != 1 {
+					t.Errorf("unexpected breakdown %+v", b)
+				}
+			},
+		},
+		{
+			name: "git level factors",
+			setup: func(repo *models.Repository) {
+				repo.Visibility = "internal"
+				repo.SetHasLFS(true)
+				repo.SetBranchProtections(1)
+			},
+			wantTotal: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &models.Repository{FullName: "group/project", Visibility: "private"}
+			tt.setup(repo)
+
+			total, breakdown := profiler.EstimateComplexityWithBreakdown(repo)
+			if total != tt.wantTotal {
+				t.Errorf("EstimateComplexityWithBreakdown() = %d, want %d (%+v)", total, tt.wantTotal, breakdown)
+			}
+			if tt.check != nil {
+				tt.check(t, breakdown)
+			}
+		})
+	}
+}
@@ -199,6 +199,9 @@ func (d *TeamDiscoverer) processTeamFull(ctx context.Context, workerID int, org
 	if teamInfo.Description != "" {
 		team.Description = stringPtr(teamInfo.Description)
 	}
+	if teamInfo.ParentSlug != "" {
+		team.ParentSlug = stringPtr(teamInfo.ParentSlug)
+	}
 
 	if err := d.storage.SaveTeam(ctx, team); err != nil {
 		d.logger.Warn("Failed to save team",
@@ -294,6 +297,9 @@ func (d *TeamDiscoverer) teamsOnlyWorker(ctx context.Context, wg *sync.WaitGroup
 		if teamInfo.Description != "" {
 			team.Description = stringPtr(teamInfo.Description)
 		}
+		if teamInfo.ParentSlug != "" {
+			team.ParentSlug = stringPtr(teamInfo.ParentSlug)
+		}
 
 		if err := d.storage.SaveTeam(ctx, team); err != nil {
 			d.logger.Warn("Failed to save team",
@@ -0,0 +1,207 @@
+// Package forecast estimates how long repository migrations will take, from the size of each
+// repository and the durations of the migrations that have already run.
+package forecast
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// Cost model for a single repository migration, used as is until enough migrations have
+// completed to calibrate it against their actual durations
+const (
+	baseSeconds          = 120.0 // Archive requests, queueing and post-migration steps
+	gitSecondsPerGB      = 90.0  // Exporting and importing the git archive
+	metadataSecondsPerGB = 300.0 // Exporting and importing issues, pull requests, releases and attachments
+	issueSeconds         = 0.05  // Importing each issue
+	pullRequestSeconds   = 0.15  // Importing each pull request with its reviews
+
+	// Metadata size estimate used when discovery did not record one, matching the discovery profiler
+	metadataOverheadBytes = 50 * 1024 * 1024
+	issueBytes            = 5 * 1024
+	pullRequestBytes      = 10 * 1024
+
+	// minCalibrationSamples is the number of completed migrations needed before the cost model is calibrated
+	minCalibrationSamples = 3
+
+	// defaultWorkers matches the default migration worker count
+	defaultWorkers = 5
+
+	bytesPerGB = 1024 * 1024 * 1024
+)
+
+// Estimate bases
+const (
+	BasisHistory = "history" // The repository's own previous migration or dry run
+	BasisModel   = "model"   // The cost model, calibrated against other repositories when possible
+)
+
+// Store is the data a Forecaster reads. Implemented by storage.Database.
+type Store interface {
+	GetMigrationDurationSamples(ctx context.Context) ([]*storage.MigrationDurationSample, error)
+	GetSettings(ctx context.Context) (*models.Settings, error)
+}
+
+// Forecaster estimates migration durations for repositories and batches
+type Forecaster struct {
+	store Store
+}
+
+// NewForecaster creates a forecaster reading migration history and settings from store
+func NewForecaster(store Store) *Forecaster {
+	return &Forecaster{store: store}
+}
+
+// RepositoryForecast is the estimated migration duration of a single repository
+type RepositoryForecast struct {
+	RepositoryID     int64  `json:"repository_id"`
+	FullName         string `json:"full_name"`
+	EstimatedSeconds int    `json:"estimated_seconds"`
+	Basis            string `json:"basis"` // history or model
+}
+
+// Forecast is the projected duration of migrating a set of repositories with the configured workers
+type Forecast struct {
+	Repositories       int                   `json:"repositories"`
+	Workers            int                   `json:"workers"`
+	TotalSeconds       int                   `json:"total_seconds"`      // Sum of the per-repository estimates
+	WallClockSeconds   int                   `json:"wall_clock_seconds"` // Projected elapsed time with the workers running in parallel
+	CalibrationSamples int                   `json:"calibration_samples"`
+	CalibrationFactor  float64               `json:"calibration_factor"` // Ratio of actual to modelled durations of past migrations
+	Longest            *RepositoryForecast   `json:"longest,omitempty"`
+	Estimates          []*RepositoryForecast `json:"estimates,omitempty"`
+}
+
+// WallClock returns the projected elapsed time as a duration
+func (f *Forecast) WallClock() time.Duration {
+	return time.Duration(f.WallClockSeconds) * time.Second
+}
+
+// Forecast estimates the duration of each repository's migration and projects the wall-clock
+// time of migrating them all. Repositories that are already migrated or marked won't migrate are
+// left out. workers overrides the configured migration worker count when greater than zero.
+func (f *Forecaster) Forecast(ctx context.Context, repos []*models.Repository, workers int) (*Forecast, error) {
+	samples, err := f.store.GetMigrationDurationSamples(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load migration history: %w", err)
+	}
+	if workers <= 0 {
+		workers = defaultWorkers
+		if settings, err := f.store.GetSettings(ctx); err == nil && settings != nil && settings.MigrationWorkers > 0 {
+			workers = settings.MigrationWorkers
+		}
+	}
+
+	// Samples are newest first, so the first one for a repository is its latest run
+	latest := make(map[int64]int)
+	for _, sample := range samples {
+		if _, ok := latest[sample.RepositoryID]; !ok {
+			latest[sample.RepositoryID] = sample.DurationSeconds
+		}
+	}
+	factor, calibrated := calibrate(samples)
+
+	forecast := &Forecast{
+		Workers:            workers,
+		CalibrationSamples: calibrated,
+		CalibrationFactor:  math.Round(factor*100) / 100,
+		Estimates:          []*RepositoryForecast{},
+	}
+	for _, repo := range repos {
+		if isMigrated(repo) {
+			continue
+		}
+		estimate := &RepositoryForecast{RepositoryID: repo.ID, FullName: repo.FullName, Basis: BasisModel}
+		if seconds, ok := latest[repo.ID]; ok {
+			estimate.EstimatedSeconds = seconds
+			estimate.Basis = BasisHistory
+		} else {
+			estimate.EstimatedSeconds = int(math.Round(modelSeconds(repo.GetTotalSize(), repo.GetEstimatedMetadataSize(),
+				repo.GetIssueCount(), repo.GetPullRequestCount()) * factor))
+		}
+
+		forecast.Estimates = append(forecast.Estimates, estimate)
+		forecast.TotalSeconds += estimate.EstimatedSeconds
+		if forecast.Longest == nil || estimate.EstimatedSeconds > forecast.Longest.EstimatedSeconds {
+			forecast.Longest = estimate
+		}
+	}
+	forecast.Repositories = len(forecast.Estimates)
+	forecast.WallClockSeconds = wallClockSeconds(forecast.Estimates, workers)
+
+	return forecast, nil
+}
+
+// modelSeconds is the uncalibrated cost model estimate for a repository
+func modelSeconds(totalSize, metadataSize *int64, issues, pullRequests int) float64 {
+	gitBytes := int64(0)
+	if totalSize != nil {
+		gitBytes = *totalSize
+	}
+	metadataBytes := int64(metadataOverheadBytes + issues*issueBytes + pullRequests*pullRequestBytes)
+	if metadataSize != nil && *metadataSize > 0 {
+		metadataBytes = *metadataSize
+	}
+
+	return baseSeconds +
+		float64(gitBytes)/bytesPerGB*gitSecondsPerGB +
+		float64(metadataBytes)/bytesPerGB*metadataSecondsPerGB +
+		float64(issues)*issueSeconds +
+		float64(pullRequests)*pullRequestSeconds
+}
+
+// calibrate returns the median ratio of the actual to the modelled duration of past migrations,
+// and the number of migrations it is based on. The factor is 1 until there are enough of them.
+func calibrate(samples []*storage.MigrationDurationSample) (float64, int) {
+	ratios := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		if sample.DurationSeconds <= 0 {
+			continue
+		}
+		modelled := modelSeconds(sample.TotalSize, sample.EstimatedMetadataSize, sample.IssueCount, sample.PullRequestCount)
+		ratios = append(ratios, float64(sample.DurationSeconds)/modelled)
+	}
+	if len(ratios) < minCalibrationSamples {
+		return 1, 0
+	}
+
+	slices.Sort(ratios)
+	mid := len(ratios) / 2
+	if len(ratios)%2 == 0 {
+		return (ratios[mid-1] + ratios[mid]) / 2, len(ratios)
+	}
+	return ratios[mid], len(ratios)
+}
+
+// wallClockSeconds projects the elapsed time of running the estimates on a number of workers.
+// Each worker takes the next repository as soon as it is free; the longest migrations are
+// assumed to start first.
+func wallClockSeconds(estimates []*RepositoryForecast, workers int) int {
+	durations := make([]int, len(estimates))
+	for i, estimate := range estimates {
+		durations[i] = estimate.EstimatedSeconds
+	}
+	slices.SortFunc(durations, func(a, b int) int { return b - a })
+
+	loads := make([]int, max(workers, 1))
+	for _, duration := range durations {
+		least := slices.Index(loads, slices.Min(loads))
+		loads[least] += duration
+	}
+	return slices.Max(loads)
+}
+
+func isMigrated(repo *models.Repository) bool {
+	switch models.MigrationStatus(repo.Status) {
+	case models.StatusComplete, models.StatusMigrationComplete, models.StatusWontMigrate:
+		return true
+	default:
+		return false
+	}
+}
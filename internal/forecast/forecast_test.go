@@ -0,0 +1,130 @@
+package forecast
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+type fakeStore struct {
+	samples []*storage.MigrationDurationSample
+	workers int
+	err     error
+}
+
+func (f *fakeStore) GetMigrationDurationSamples(context.Context) ([]*storage.MigrationDurationSample, error) {
+	return f.samples, f.err
+}
+
+func (f *fakeStore) GetSettings(context.Context) (*models.Settings, error) {
+	return &models.Settings{MigrationWorkers: f.workers}, nil
+}
+
+func sizedRepo(id int64, name string, sizeGB float64, issues, pullRequests int) *models.Repository {
+	size := int64(sizeGB * bytesPerGB)
+	return &models.Repository{
+		ID:            id,
+		FullName:      name,
+		Status:        string(models.StatusPending),
+		GitProperties: &models.RepositoryGitProperties{TotalSize: &size},
+		Features:      &models.RepositoryFeatures{IssueCount: issues, PullRequestCount: pullRequests},
+	}
+}
+
+func TestModelSeconds(t *testing.T) {
+	size := int64(2 * bytesPerGB)
+	metadata := int64(bytesPerGB)
+	got := modelSeconds(&size, &metadata, 1000, 400)
+	want := baseSeconds + 2*gitSecondsPerGB + metadataSecondsPerGB + 1000*issueSeconds + 400*pullRequestSeconds
+	if math.Abs(got-want) > 0.001 {
+		t.Errorf("modelSeconds() = %v, want %v", got, want)
+	}
+
+	// Without a recorded metadata size the discovery estimate is used
+	withoutMetadata := modelSeconds(nil, nil, 0, 0)
+	if want := baseSeconds + float64(metadataOverheadBytes)/bytesPerGB*metadataSecondsPerGB; math.Abs(withoutMetadata-want) > 0.001 {
+		t.Errorf("modelSeconds(nil) = %v, want %v", withoutMetadata, want)
+	}
+}
+
+func TestForecast_UncalibratedModel(t *testing.T) {
+	forecaster := NewForecaster(&fakeStore{workers: 2})
+	repos := []*models.Repository{
+		sizedRepo(1, "org/a", 1, 0, 0),
+		sizedRepo(2, "org/b", 1, 0, 0),
+		sizedRepo(3, "org/c", 1, 0, 0),
+		{ID: 4, FullName: "org/done", Status: string(models.StatusComplete)},
+	}
+
+	forecast, err := forecaster.Forecast(context.Background(), repos, 0)
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+	if forecast.Repositories != 3 || forecast.Workers != 2 || forecast.CalibrationFactor != 1 || forecast.CalibrationSamples != 0 {
+		t.Fatalf("Unexpected forecast %+v", forecast)
+	}
+	each := forecast.Estimates[0].EstimatedSeconds
+	if forecast.TotalSeconds != 3*each {
+		t.Errorf("Expected a total of 3 x %d, got %d", each, forecast.TotalSeconds)
+	}
+	// Three equal migrations on two workers take two rounds
+	if forecast.WallClockSeconds != 2*each {
+		t.Errorf("Expected a wall clock of 2 x %d, got %d", each, forecast.WallClockSeconds)
+	}
+}
+
+func TestForecast_CalibratedByHistory(t *testing.T) {
+	size := int64(bytesPerGB)
+	modelled := modelSeconds(&size, nil, 0, 0)
+	store := &fakeStore{samples: []*storage.MigrationDurationSample{
+		{RepositoryID: 10, Phase: "migration", DurationSeconds: int(2 * modelled), TotalSize: &size},
+		{RepositoryID: 11, Phase: "migration", DurationSeconds: int(2 * modelled), TotalSize: &size},
+		{RepositoryID: 12, Phase: "dry_run", DurationSeconds: int(4 * modelled), TotalSize: &size},
+		{RepositoryID: 1, Phase: "dry_run", DurationSeconds: int(1.5 * modelled), TotalSize: &size},
+		{RepositoryID: 1, Phase: "dry_run", DurationSeconds: int(20 * modelled), TotalSize: &size}, // Older run
+	}}
+	forecaster := NewForecaster(store)
+
+	repos := []*models.Repository{sizedRepo(1, "org/dry-run", 1, 0, 0), sizedRepo(2, "org/new", 1, 0, 0)}
+	forecast, err := forecaster.Forecast(context.Background(), repos, 1)
+	if err != nil {
+		t.Fatalf("Forecast() error = %v", err)
+	}
+
+	if forecast.CalibrationSamples != 5 || forecast.Workers != 1 {
+		t.Errorf("Unexpected calibration %+v", forecast)
+	}
+	if got := forecast.Estimates[0]; got.Basis != BasisHistory || got.EstimatedSeconds != int(1.5*modelled) {
+		t.Errorf("Expected the latest dry run duration, got %+v", got)
+	}
+	if got := forecast.Estimates[1]; got.Basis != BasisModel || math.Abs(float64(got.EstimatedSeconds)-2*modelled) > 2 {
+		t.Errorf("Expected twice the modelled duration, got %+v (modelled %.0f)", got, modelled)
+	}
+	if forecast.WallClockSeconds != forecast.TotalSeconds || forecast.Longest.FullName != "org/new" {
+		t.Errorf("Expected one worker to run the migrations back to back, got %+v", forecast)
+	}
+}
+
+func TestForecast_HistoryError(t *testing.T) {
+	forecaster := NewForecaster(&fakeStore{err: errors.New("database is locked")})
+	if _, err := forecaster.Forecast(context.Background(), nil, 0); err == nil {
+		t.Error("Expected an error when the history cannot be loaded")
+	}
+}
+
+func TestWallClockSeconds(t *testing.T) {
+	estimates := []*RepositoryForecast{{EstimatedSeconds: 60}, {EstimatedSeconds: 30}, {EstimatedSeconds: 30}, {EstimatedSeconds: 20}}
+	if got := wallClockSeconds(estimates, 2); got != 80 {
+		t.Errorf("wallClockSeconds() = %d, want 80", got)
+	}
+	if got := wallClockSeconds(estimates, 10); got != 60 {
+		t.Errorf("wallClockSeconds() with spare workers = %d, want 60", got)
+	}
+	if got := wallClockSeconds(nil, 5); got != 0 {
+		t.Errorf("wallClockSeconds() with nothing to run = %d, want 0", got)
+	}
+}
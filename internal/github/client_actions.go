@@ -0,0 +1,321 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/google/go-github/v75/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// ListEnvironments returns the deployment environments configured on a repository
+func (c *Client) ListEnvironments(ctx context.Context, owner, repo string) ([]*github.Environment, error) {
+	var environments []*github.Environment
+	opts := &github.EnvironmentListOptions{ListOptions: github.ListOptions{PerPage: 100}}
+
+	for {
+		var page *github.EnvResponse
+		var resp *github.Response
+		err := c.retryer.Do(ctx, "ListEnvironments", func(ctx context.Context) error {
+			var err error
+			page, resp, err = c.rest.Repositories.ListEnvironments(ctx, owner, repo, opts)
+			if err != nil {
+				return WrapError(err, "ListEnvironments", c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if page != nil {
+			environments = append(environments, page.Environments...)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return environments, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateUpdateEnvironment creates an environment on a repository, or updates it if it already exists
+func (c *Client) CreateUpdateEnvironment(ctx context.Context, owner, repo, name string, environment *github.CreateUpdateEnvironment) error {
+	return c.retryer.Do(ctx, "CreateUpdateEnvironment", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.CreateUpdateEnvironment(ctx, owner, repo, name, environment)
+		if err != nil {
+			return WrapError(err, "CreateUpdateEnvironment", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// ListDeploymentBranchPolicies returns the custom deployment branch and tag policies of an environment
+func (c *Client) ListDeploymentBranchPolicies(ctx context.Context, owner, repo, environment string) ([]*github.DeploymentBranchPolicy, error) {
+	var policies *github.DeploymentBranchPolicyResponse
+	err := c.retryer.Do(ctx, "ListDeploymentBranchPolicies", func(ctx context.Context) error {
+		var err error
+		policies, _, err = c.rest.Repositories.ListDeploymentBranchPolicies(ctx, owner, repo, environment)
+		if err != nil {
+			return WrapError(err, "ListDeploymentBranchPolicies", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if policies == nil {
+		return nil, nil
+	}
+
+	return policies.BranchPolicies, nil
+}
+
+// CreateDeploymentBranchPolicy adds a custom deployment branch or tag policy to an environment
+func (c *Client) CreateDeploymentBranchPolicy(ctx context.Context, owner, repo, environment string, policy *github.DeploymentBranchPolicyRequest) error {
+	return c.retryer.Do(ctx, "CreateDeploymentBranchPolicy", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repo, environment, policy)
+		if err != nil {
+			return WrapError(err, "CreateDeploymentBranchPolicy", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// GetUserID returns the numeric ID of a user, which environment reviewers are configured by
+func (c *Client) GetUserID(ctx context.Context, login string) (int64, error) {
+	var user *github.User
+	err := c.retryer.Do(ctx, "GetUserID", func(ctx context.Context) error {
+		var err error
+		user, _, err = c.rest.Users.Get(ctx, login)
+		if err != nil {
+			return WrapError(err, "GetUserID", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return user.GetID(), nil
+}
+
+// ListRepoVariables returns the Actions variables of a repository, including their values
+func (c *Client) ListRepoVariables(ctx context.Context, owner, repo string) ([]*github.ActionsVariable, error) {
+	return c.listVariables(ctx, "ListRepoVariables", func(ctx context.Context, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+		return c.rest.Actions.ListRepoVariables(ctx, owner, repo, opts)
+	})
+}
+
+// ListEnvVariables returns the Actions variables of a repository environment, including their values
+func (c *Client) ListEnvVariables(ctx context.Context, owner, repo, environment string) ([]*github.ActionsVariable, error) {
+	return c.listVariables(ctx, "ListEnvVariables", func(ctx context.Context, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+		return c.rest.Actions.ListEnvVariables(ctx, owner, repo, environment, opts)
+	})
+}
+
+// listVariables pages through an Actions variables endpoint
+func (c *Client) listVariables(ctx context.Context, operation string, list func(context.Context, *github.ListOptions) (*github.ActionsVariables, *github.Response, error)) ([]*github.ActionsVariable, error) {
+	var variables []*github.ActionsVariable
+	opts := &github.ListOptions{PerPage: 30}
+
+	for {
+		var page *github.ActionsVariables
+		var resp *github.Response
+		err := c.retryer.Do(ctx, operation, func(ctx context.Context) error {
+			var err error
+			page, resp, err = list(ctx, opts)
+			if err != nil {
+				return WrapError(err, operation, c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if page != nil {
+			variables = append(variables, page.Variables...)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return variables, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateRepoVariable creates an Actions variable on a repository
+func (c *Client) CreateRepoVariable(ctx context.Context, owner, repo string, variable *github.ActionsVariable) error {
+	return c.retryer.Do(ctx, "CreateRepoVariable", func(ctx context.Context) error {
+		_, err := c.rest.Actions.CreateRepoVariable(ctx, owner, repo, variable)
+		if err != nil {
+			return WrapError(err, "CreateRepoVariable", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// UpdateRepoVariable updates the value of an existing Actions variable on a repository
+func (c *Client) UpdateRepoVariable(ctx context.Context, owner, repo string, variable *github.ActionsVariable) error {
+	return c.retryer.Do(ctx, "UpdateRepoVariable", func(ctx context.Context) error {
+		_, err := c.rest.Actions.UpdateRepoVariable(ctx, owner, repo, variable)
+		if err != nil {
+			return WrapError(err, "UpdateRepoVariable", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// CreateEnvVariable creates an Actions variable on a repository environment
+func (c *Client) CreateEnvVariable(ctx context.Context, owner, repo, environment string, variable *github.ActionsVariable) error {
+	return c.retryer.Do(ctx, "CreateEnvVariable", func(ctx context.Context) error {
+		_, err := c.rest.Actions.CreateEnvVariable(ctx, owner, repo, environment, variable)
+		if err != nil {
+			return WrapError(err, "CreateEnvVariable", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// UpdateEnvVariable updates the value of an existing Actions variable on a repository environment
+func (c *Client) UpdateEnvVariable(ctx context.Context, owner, repo, environment string, variable *github.ActionsVariable) error {
+	return c.retryer.Do(ctx, "UpdateEnvVariable", func(ctx context.Context) error {
+		_, err := c.rest.Actions.UpdateEnvVariable(ctx, owner, repo, environment, variable)
+		if err != nil {
+			return WrapError(err, "UpdateEnvVariable", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// ListRepoSecretNames returns the names of a repository's Actions secrets.
+// Secret values can never be read back from GitHub.
+func (c *Client) ListRepoSecretNames(ctx context.Context, owner, repo string) ([]string, error) {
+	return c.listSecretNames(ctx, "ListRepoSecretNames", func(ctx context.Context, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+		return c.rest.Actions.ListRepoSecrets(ctx, owner, repo, opts)
+	})
+}
+
+// ListEnvSecretNames returns the names of a repository environment's Actions secrets
+func (c *Client) ListEnvSecretNames(ctx context.Context, repoID int64, environment string) ([]string, error) {
+	return c.listSecretNames(ctx, "ListEnvSecretNames", func(ctx context.Context, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+		return c.rest.Actions.ListEnvSecrets(ctx, int(repoID), environment, opts)
+	})
+}
+
+// listSecretNames pages through an Actions secrets endpoint
+func (c *Client) listSecretNames(ctx context.Context, operation string, list func(context.Context, *github.ListOptions) (*github.Secrets, *github.Response, error)) ([]string, error) {
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		var page *github.Secrets
+		var resp *github.Response
+		err := c.retryer.Do(ctx, operation, func(ctx context.Context) error {
+			var err error
+			page, resp, err = list(ctx, opts)
+			if err != nil {
+				return WrapError(err, operation, c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if page != nil {
+			for _, secret := range page.Secrets {
+				names = append(names, secret.Name)
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// SetRepoSecret creates or updates an Actions secret on a repository.
+// The value is encrypted with the repository's public key before it is sent.
+func (c *Client) SetRepoSecret(ctx context.Context, owner, repo, name, value string) error {
+	var key *github.PublicKey
+	err := c.retryer.Do(ctx, "GetRepoPublicKey", func(ctx context.Context) error {
+		var err error
+		key, _, err = c.rest.Actions.GetRepoPublicKey(ctx, owner, repo)
+		if err != nil {
+			return WrapError(err, "GetRepoPublicKey", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	secret, err := encryptSecret(key, name, value)
+	if err != nil {
+		return err
+	}
+
+	return c.retryer.Do(ctx, "SetRepoSecret", func(ctx context.Context) error {
+		_, err := c.rest.Actions.CreateOrUpdateRepoSecret(ctx, owner, repo, secret)
+		if err != nil {
+			return WrapError(err, "SetRepoSecret", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// SetEnvSecret creates or updates an Actions secret on a repository environment.
+// The value is encrypted with the environment's public key before it is sent.
+func (c *Client) SetEnvSecret(ctx context.Context, repoID int64, environment, name, value string) error {
+	var key *github.PublicKey
+	err := c.retryer.Do(ctx, "GetEnvPublicKey", func(ctx context.Context) error {
+		var err error
+		key, _, err = c.rest.Actions.GetEnvPublicKey(ctx, int(repoID), environment)
+		if err != nil {
+			return WrapError(err, "GetEnvPublicKey", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	secret, err := encryptSecret(key, name, value)
+	if err != nil {
+		return err
+	}
+
+	return c.retryer.Do(ctx, "SetEnvSecret", func(ctx context.Context) error {
+		_, err := c.rest.Actions.CreateOrUpdateEnvSecret(ctx, int(repoID), environment, secret)
+		if err != nil {
+			return WrapError(err, "SetEnvSecret", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// encryptSecret seals a secret value for GitHub with a libsodium sealed box
+func encryptSecret(key *github.PublicKey, name, value string) (*github.EncryptedSecret, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode public key: %w", err)
+	}
+	if len(decoded) != 32 {
+		return nil, fmt.Errorf("unexpected public key length %d", len(decoded))
+	}
+
+	var recipient [32]byte
+	copy(recipient[:], decoded)
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	return &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          key.GetKeyID(),
+		EncryptedValue: base64.StdEncoding.EncodeToString(sealed),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v75/github"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestListEnvironments(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/environments", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count":2,"environments":[
+			{"name":"staging"},
+			{"name":"production","protection_rules":[{"type":"wait_timer","wait_timer":30}]}
+		]}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	environments, err := client.ListEnvironments(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("ListEnvironments() error = %v", err)
+	}
+	if len(environments) != 2 || environments[1].GetName() != "production" {
+		t.Fatalf("Expected [staging production], got %+v", environments)
+	}
+	if len(environments[1].ProtectionRules) != 1 || environments[1].ProtectionRules[0].GetWaitTimer() != 30 {
+		t.Errorf("Expected the production wait timer rule, got %+v", environments[1].ProtectionRules)
+	}
+}
+
+func TestListRepoSecretNames(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/actions/secrets", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"total_count":2,"secrets":[
+			{"name":"DEPLOY_TOKEN","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"},
+			{"name":"NPM_TOKEN","created_at":"2024-01-01T00:00:00Z","updated_at":"2024-01-01T00:00:00Z"}
+		]}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	names, err := client.ListRepoSecretNames(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("ListRepoSecretNames() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "DEPLOY_TOKEN" || names[1] != "NPM_TOKEN" {
+		t.Errorf("Expected [DEPLOY_TOKEN NPM_TOKEN], got %v", names)
+	}
+}
+
+func TestSetRepoSecret_EncryptsWithRepositoryKey(t *testing.T) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	var received github.EncryptedSecret
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/actions/secrets/public-key", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"key_id": "key-1",
+			"key":    base64.StdEncoding.EncodeToString(publicKey[:]),
+		})
+	})
+	mux.HandleFunc("PUT /api/v3/repos/org/repo/actions/secrets/DEPLOY_TOKEN", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode secret: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	if err := client.SetRepoSecret(context.Background(), "org", "repo", "DEPLOY_TOKEN", "placeholder"); err != nil {
+		t.Fatalf("SetRepoSecret() error = %v", err)
+	}
+
+	if received.KeyID != "key-1" {
+		t.Errorf("Expected key_id key-1, got %q", received.KeyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(received.EncryptedValue)
+	if err != nil {
+		t.Fatalf("Failed to decode encrypted value: %v", err)
+	}
+	plain, ok := box.OpenAnonymous(nil, sealed, publicKey, privateKey)
+	if !ok || string(plain) != "placeholder" {
+		t.Errorf("Expected the value to decrypt to %q, got %q (ok=%v)", "placeholder", plain, ok)
+	}
+}
+
+func TestEncryptSecret_RejectsInvalidKey(t *testing.T) {
+	key := &github.PublicKey{KeyID: github.Ptr("key-1"), Key: github.Ptr(base64.StdEncoding.EncodeToString([]byte("short")))}
+	if _, err := encryptSecret(key, "NAME", "value"); err == nil {
+		t.Error("Expected an error for a public key of the wrong length")
+	}
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v75/github"
+)
+
+// RepositoryCollaborator is a user granted access to a repository directly rather than through a team
+type RepositoryCollaborator struct {
+	Login      string
+	Permission string // read, triage, write, maintain, admin or a custom repository role name
+	Outside    bool   // Not a member of the repository's organization
+}
+
+// ListDirectCollaborators lists the users with direct access to a repository, marking outside collaborators
+func (c *Client) ListDirectCollaborators(ctx context.Context, owner, repo string) ([]*RepositoryCollaborator, error) {
+	direct, err := c.listCollaborators(ctx, owner, repo, "direct")
+	if err != nil {
+		return nil, err
+	}
+	if len(direct) == 0 {
+		return nil, nil
+	}
+
+	outside, err := c.listCollaborators(ctx, owner, repo, "outside")
+	if err != nil {
+		return nil, err
+	}
+	outsideLogins := make(map[string]bool, len(outside))
+	for _, user := range outside {
+		outsideLogins[user.GetLogin()] = true
+	}
+
+	collaborators := make([]*RepositoryCollaborator, 0, len(direct))
+	for _, user := range direct {
+		collaborators = append(collaborators, &RepositoryCollaborator{
+			Login:      user.GetLogin(),
+			Permission: collaboratorPermission(user),
+			Outside:    outsideLogins[user.GetLogin()],
+		})
+	}
+	return collaborators, nil
+}
+
+func (c *Client) listCollaborators(ctx context.Context, owner, repo, affiliation string) ([]*github.User, error) {
+	var users []*github.User
+	opts := &github.ListCollaboratorsOptions{
+		Affiliation: affiliation,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		var page []*github.User
+		var resp *github.Response
+		err := c.retryer.Do(ctx, "ListCollaborators", func(ctx context.Context) error {
+			var err error
+			page, resp, err = c.rest.Repositories.ListCollaborators(ctx, owner, repo, opts)
+			if err != nil {
+				return WrapError(err, "ListCollaborators", c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, page...)
+		if resp == nil || resp.NextPage == 0 {
+			return users, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// collaboratorPermission returns the collaborator's repository role, falling back to the
+// highest of the legacy permission flags for servers that do not report role names
+func collaboratorPermission(user *github.User) string {
+	if role := user.GetRoleName(); role != "" {
+		return role
+	}
+	permissions := user.GetPermissions()
+	for _, level := range []struct{ flag, role string }{
+		{"admin", "admin"},
+		{"maintain", "maintain"},
+		{"push", "write"},
+		{"triage", "triage"},
+	} {
+		if permissions[level.flag] {
+			return level.role
+		}
+	}
+	return "read"
+}
+
+// AddCollaborator grants a user direct access to a repository with a repository role
+// (read, triage, write, maintain, admin or a custom role name). Users who are not yet
+// collaborators are sent an invitation, in which case invited is true.
+func (c *Client) AddCollaborator(ctx context.Context, owner, repo, username, permission string) (bool, error) {
+	// The API still expects the legacy names for the read and write roles
+	switch permission {
+	case "read":
+		permission = "pull"
+	case "write":
+		permission = "push"
+	}
+
+	var invitation *github.CollaboratorInvitation
+	err := c.retryer.Do(ctx, "AddCollaborator", func(ctx context.Context) error {
+		var err error
+		invitation, _, err = c.rest.Repositories.AddCollaborator(ctx, owner, repo, username, &github.RepositoryAddCollaboratorOptions{
+			Permission: permission,
+		})
+		if err != nil {
+			return WrapError(err, "AddCollaborator", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return invitation != nil && invitation.GetID() != 0, nil
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListDirectCollaborators(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("affiliation") == "outside" {
+			_, _ = w.Write([]byte(`[{"login":"vendor","role_name":"read"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"login":"alice","role_name":"maintain"},
+			{"login":"bob","permissions":{"pull":true,"triage":true,"push":true}},
+			{"login":"vendor","role_name":"read"}
+		]`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	collaborators, err := client.ListDirectCollaborators(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("ListDirectCollaborators() error = %v", err)
+	}
+	if len(collaborators) != 3 {
+		t.Fatalf("Expected 3 collaborators, got %d", len(collaborators))
+	}
+	want := []RepositoryCollaborator{
+		{Login: "alice", Permission: "maintain"},
+		{Login: "bob", Permission: "write"},
+		{Login: "vendor", Permission: "read", Outside: true},
+	}
+	for i, w := range want {
+		if *collaborators[i] != w {
+			t.Errorf("Collaborator %d = %+v, want %+v", i, *collaborators[i], w)
+		}
+	}
+}
+
+func TestAddCollaborator(t *testing.T) {
+	var permissions []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/v3/repos/org/repo/collaborators/{user}", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		permissions = append(permissions, body["permission"])
+		if r.PathValue("user") == "member" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":42,"permissions":"read"}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	invited, err := client.AddCollaborator(context.Background(), "org", "repo", "member", "write")
+	if err != nil || invited {
+		t.Errorf("AddCollaborator(member) = %v, %v; want a direct grant", invited, err)
+	}
+	invited, err = client.AddCollaborator(context.Background(), "org", "repo", "vendor", "read")
+	if err != nil || !invited {
+		t.Errorf("AddCollaborator(vendor) = %v, %v; want an invitation", invited, err)
+	}
+	if len(permissions) != 2 || permissions[0] != "push" || permissions[1] != "pull" {
+		t.Errorf("Expected legacy permission names, got %v", permissions)
+	}
+}
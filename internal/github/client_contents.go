@@ -0,0 +1,161 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v75/github"
+)
+
+// GetFileContent returns the decoded content and blob SHA of a file on a branch.
+// found is false when the file does not exist.
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref string) (content, sha string, found bool, err error) {
+	var file *github.RepositoryContent
+	err = c.retryer.Do(ctx, "GetFileContent", func(ctx context.Context) error {
+		var err error
+		file, _, _, err = c.rest.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			return WrapError(err, "GetFileContent", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			return "", "", false, nil
+		}
+		return "", "", false, err
+	}
+	if file == nil {
+		// The path is a directory
+		return "", "", false, nil
+	}
+
+	content, err = file.GetContent()
+	if err != nil {
+		return "", "", false, err
+	}
+	return content, file.GetSHA(), true, nil
+}
+
+// GetReadme returns the path, decoded content and blob SHA of a repository's README on a branch.
+// found is false when the repository has no README.
+func (c *Client) GetReadme(ctx context.Context, owner, repo, ref string) (path, content, sha string, found bool, err error) {
+	var file *github.RepositoryContent
+	err = c.retryer.Do(ctx, "GetReadme", func(ctx context.Context) error {
+		var err error
+		file, _, err = c.rest.Repositories.GetReadme(ctx, owner, repo, &github.RepositoryContentGetOptions{Ref: ref})
+		if err != nil {
+			return WrapError(err, "GetReadme", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		if IsNotFoundError(err) {
+			return "", "", "", false, nil
+		}
+		return "", "", "", false, err
+	}
+
+	content, err = file.GetContent()
+	if err != nil {
+		return "", "", "", false, err
+	}
+	return file.GetPath(), content, file.GetSHA(), true, nil
+}
+
+// GetBranchSHA returns the commit SHA a branch points at
+func (c *Client) GetBranchSHA(ctx context.Context, owner, repo, branch string) (string, error) {
+	var ref *github.Reference
+	err := c.retryer.Do(ctx, "GetBranchSHA", func(ctx context.Context) error {
+		var err error
+		ref, _, err = c.rest.Git.GetRef(ctx, owner, repo, "heads/"+branch)
+		if err != nil {
+			return WrapError(err, "GetBranchSHA", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return ref.GetObject().GetSHA(), nil
+}
+
+// CreateBranch creates a branch pointing at the given commit SHA
+func (c *Client) CreateBranch(ctx context.Context, owner, repo, branch, sha string) error {
+	return c.retryer.Do(ctx, "CreateBranch", func(ctx context.Context) error {
+		_, _, err := c.rest.Git.CreateRef(ctx, owner, repo, github.CreateRef{Ref: "refs/heads/" + branch, SHA: sha})
+		if err != nil {
+			return WrapError(err, "CreateBranch", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// UpdateFile commits new content for an existing file to a branch. sha is the blob SHA of the file being replaced.
+func (c *Client) UpdateFile(ctx context.Context, owner, repo, path, branch, message, content, sha string) error {
+	return c.retryer.Do(ctx, "UpdateFile", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.UpdateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr(message),
+			Content: []byte(content),
+			SHA:     github.Ptr(sha),
+			Branch:  github.Ptr(branch),
+		})
+		if err != nil {
+			return WrapError(err, "UpdateFile", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// CreateFile commits a new file to a branch
+func (c *Client) CreateFile(ctx context.Context, owner, repo, path, branch, message, content string) error {
+	return c.retryer.Do(ctx, "CreateFile", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.CreateFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr(message),
+			Content: []byte(content),
+			Branch:  github.Ptr(branch),
+		})
+		if err != nil {
+			return WrapError(err, "CreateFile", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// DeleteFile commits the removal of a file from a branch. sha is the blob SHA of the file being removed.
+func (c *Client) DeleteFile(ctx context.Context, owner, repo, path, branch, message, sha string) error {
+	return c.retryer.Do(ctx, "DeleteFile", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.DeleteFile(ctx, owner, repo, path, &github.RepositoryContentFileOptions{
+			Message: github.Ptr(message),
+			SHA:     github.Ptr(sha),
+			Branch:  github.Ptr(branch),
+		})
+		if err != nil {
+			return WrapError(err, "DeleteFile", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// CreatePullRequest opens a pull request from head into base
+func (c *Client) CreatePullRequest(ctx context.Context, owner, repo, title, head, base, body string) (*github.PullRequest, error) {
+	var pr *github.PullRequest
+	err := c.retryer.Do(ctx, "CreatePullRequest", func(ctx context.Context) error {
+		var err error
+		pr, _, err = c.rest.PullRequests.Create(ctx, owner, repo, &github.NewPullRequest{
+			Title: github.Ptr(title),
+			Head:  github.Ptr(head),
+			Base:  github.Ptr(base),
+			Body:  github.Ptr(body),
+		})
+		if err != nil {
+			return WrapError(err, "CreatePullRequest", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
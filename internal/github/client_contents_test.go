@@ -0,0 +1,174 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestGetFileContent(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/contents/.gitmodules", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref"); got != "main" {
+			t.Errorf("Expected ref main, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"sha":      "blob-sha",
+			"content":  base64.StdEncoding.EncodeToString([]byte("[submodule \"lib\"]\n")),
+		})
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/repo/contents/missing.txt", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+	ctx := context.Background()
+
+	content, sha, found, err := client.GetFileContent(ctx, "org", "repo", ".gitmodules", "main")
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	if !found || sha != "blob-sha" || content != "[submodule \"lib\"]\n" {
+		t.Errorf("GetFileContent() = %q, %q, %v", content, sha, found)
+	}
+
+	_, _, found, err = client.GetFileContent(ctx, "org", "repo", "missing.txt", "main")
+	if err != nil {
+		t.Fatalf("GetFileContent() for a missing file error = %v", err)
+	}
+	if found {
+		t.Error("Expected a missing file not to be found")
+	}
+}
+
+func TestBranchFileAndPullRequest(t *testing.T) {
+	var createdRef, updatedFile, createdPR map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/git/ref/heads/main", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ref":"refs/heads/main","object":{"sha":"base-sha","type":"commit"}}`))
+	})
+	mux.HandleFunc("POST /api/v3/repos/org/repo/git/refs", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&createdRef)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ref":"refs/heads/update"}`))
+	})
+	mux.HandleFunc("PUT /api/v3/repos/org/repo/contents/go.mod", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&updatedFile)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /api/v3/repos/org/repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&createdPR)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":7,"html_url":"https://github.example.com/org/repo/pull/7"}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+	ctx := context.Background()
+
+	sha, err := client.GetBranchSHA(ctx, "org", "repo", "main")
+	if err != nil || sha != "base-sha" {
+		t.Fatalf("GetBranchSHA() = %q, %v", sha, err)
+	}
+	if err := client.CreateBranch(ctx, "org", "repo", "update", sha); err != nil {
+		t.Fatalf("CreateBranch() error = %v", err)
+	}
+	if createdRef["ref"] != "refs/heads/update" || createdRef["sha"] != "base-sha" {
+		t.Errorf("Unexpected branch request %v", createdRef)
+	}
+
+	if err := client.UpdateFile(ctx, "org", "repo", "go.mod", "update", "Update go.mod", "module x\n", "blob-sha"); err != nil {
+		t.Fatalf("UpdateFile() error = %v", err)
+	}
+	if updatedFile["branch"] != "update" || updatedFile["sha"] != "blob-sha" || updatedFile["content"] != base64.StdEncoding.EncodeToString([]byte("module x\n")) {
+		t.Errorf("Unexpected file update %v", updatedFile)
+	}
+
+	pr, err := client.CreatePullRequest(ctx, "org", "repo", "Update", "update", "main", "body")
+	if err != nil {
+		t.Fatalf("CreatePullRequest() error = %v", err)
+	}
+	if pr.GetNumber() != 7 || createdPR["head"] != "update" || createdPR["base"] != "main" {
+		t.Errorf("Unexpected pull request %+v (sent %v)", pr, createdPR)
+	}
+}
+
+func TestGetReadme(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/readme", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("ref"); got != "main" {
+			t.Errorf("Expected ref main, got %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"path":     "docs/README.md",
+			"sha":      "readme-sha",
+			"content":  base64.StdEncoding.EncodeToString([]byte("# Billing\n")),
+		})
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/empty/readme", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+	ctx := context.Background()
+
+	path, content, sha, found, err := client.GetReadme(ctx, "org", "repo", "main")
+	if err != nil {
+		t.Fatalf("GetReadme() error = %v", err)
+	}
+	if !found || path != "docs/README.md" || sha != "readme-sha" || content != "# Billing\n" {
+		t.Errorf("GetReadme() = %q, %q, %q, %v", path, content, sha, found)
+	}
+
+	_, _, _, found, err = client.GetReadme(ctx, "org", "empty", "main")
+	if err != nil || found {
+		t.Errorf("GetReadme() without a README = %v, %v; want not found", found, err)
+	}
+}
+
+func TestCreateAndDeleteFile(t *testing.T) {
+	var created, deleted map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/v3/repos/org/repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&created)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"content":{"sha":"new-sha"}}`))
+	})
+	mux.HandleFunc("DELETE /api/v3/repos/org/repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&deleted)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"commit":{"sha":"commit-sha"}}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+	ctx := context.Background()
+
+	if err := client.CreateFile(ctx, "org", "repo", "README.md", "main", "Add README", "# Moved\n"); err != nil {
+		t.Fatalf("CreateFile() error = %v", err)
+	}
+	if created["branch"] != "main" || created["content"] != base64.StdEncoding.EncodeToString([]byte("# Moved\n")) {
+		t.Errorf("Unexpected create request %v", created)
+	}
+	if _, ok := created["sha"]; ok {
+		t.Errorf("Expected no sha when creating a file, got %v", created["sha"])
+	}
+
+	if err := client.DeleteFile(ctx, "org", "repo", "README.md", "main", "Remove README", "new-sha"); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if deleted["sha"] != "new-sha" || deleted["branch"] != "main" {
+		t.Errorf("Unexpected delete request %v", deleted)
+	}
+}
@@ -77,6 +77,31 @@ func (c *Client) StartMigrationWithOptions(ctx context.Context, org string, opts
 	return migration, nil
 }
 
+// LockRepository locks a repository without exporting anything.
+// It starts a migration that locks the repository and excludes all data, so the lock can be
+// held for a cutover and later released with UnlockRepository using the returned migration ID.
+func (c *Client) LockRepository(ctx context.Context, org, repo string) (int64, error) {
+	migration, err := c.StartMigrationWithOptions(ctx, org, StartMigrationOptions{
+		Repositories:         []string{repo},
+		LockRepositories:     true,
+		ExcludeMetadata:      true,
+		ExcludeGitData:       true,
+		ExcludeAttachments:   true,
+		ExcludeReleases:      true,
+		ExcludeOwnerProjects: true,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock repository %s/%s: %w", org, repo, err)
+	}
+
+	c.logger.Info("Repository locked successfully",
+		"org", org,
+		"repo", repo,
+		"migration_id", migration.GetID())
+
+	return migration.GetID(), nil
+}
+
 // UnlockRepository unlocks a repository that was locked during a migration.
 // This is used when a migration fails and the source repository remains locked.
 // See: https://docs.github.com/en/rest/migrations/orgs#unlock-an-organization-repository
@@ -174,6 +174,56 @@ func TestUnlockRepository(t *testing.T) {
 	}
 }
 
+func TestLockRepository(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/rate_limit", mockRateLimitHandlerMigrations)
+	mux.HandleFunc("/api/v3/orgs/test-org/migrations", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected POST method, got %s", r.Method)
+		}
+
+		var req map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request body: %v", err)
+		}
+
+		if req["lock_repositories"] != true {
+			t.Errorf("Expected lock_repositories to be true, got %v", req["lock_repositories"])
+		}
+		for _, field := range []string{"exclude_metadata", "exclude_git_data", "exclude_attachments", "exclude_releases", "exclude_owner_projects"} {
+			if req[field] != true {
+				t.Errorf("Expected %s to be true, got %v", field, req[field])
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 777, "state": "pending"})
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := NewClient(ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: DefaultRetryConfig(),
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	migrationID, err := client.LockRepository(context.Background(), "test-org", "test-repo")
+	if err != nil {
+		t.Fatalf("LockRepository() error = %v", err)
+	}
+	if migrationID != 777 {
+		t.Errorf("Expected migration ID 777, got %d", migrationID)
+	}
+}
+
 func TestListOrgInstallations(t *testing.T) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/api/v3/rate_limit", mockRateLimitHandlerMigrations)
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v75/github"
+)
+
+// ListProtectedBranches returns the names of a repository's protected branches
+func (c *Client) ListProtectedBranches(ctx context.Context, owner, repo string) ([]string, error) {
+	var names []string
+	opts := &github.BranchListOptions{
+		Protected:   github.Ptr(true),
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		var branches []*github.Branch
+		var resp *github.Response
+		err := c.retryer.Do(ctx, "ListProtectedBranches", func(ctx context.Context) error {
+			var err error
+			branches, resp, err = c.rest.Repositories.ListBranches(ctx, owner, repo, opts)
+			if err != nil {
+				return WrapError(err, "ListProtectedBranches", c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, branch := range branches {
+			names = append(names, branch.GetName())
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return names, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetBranchProtection returns the full protection rules of a protected branch
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repo, branch string) (*github.Protection, error) {
+	var protection *github.Protection
+	err := c.retryer.Do(ctx, "GetBranchProtection", func(ctx context.Context) error {
+		var err error
+		protection, _, err = c.rest.Repositories.GetBranchProtection(ctx, owner, repo, branch)
+		if err != nil {
+			return WrapError(err, "GetBranchProtection", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return protection, nil
+}
+
+// RequireSignaturesOnProtectedBranch requires verified commit signatures on a protected branch
+func (c *Client) RequireSignaturesOnProtectedBranch(ctx context.Context, owner, repo, branch string) error {
+	return c.retryer.Do(ctx, "RequireSignaturesOnProtectedBranch", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.RequireSignaturesOnProtectedBranch(ctx, owner, repo, branch)
+		if err != nil {
+			return WrapError(err, "RequireSignaturesOnProtectedBranch", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// ListRepositoryRulesets returns the full definitions of the rulesets configured on a repository.
+// Rulesets inherited from the organization or enterprise are not included.
+func (c *Client) ListRepositoryRulesets(ctx context.Context, owner, repo string) ([]*github.RepositoryRuleset, error) {
+	var summaries []*github.RepositoryRuleset
+	opts := &github.RepositoryListRulesetsOptions{
+		IncludesParents: github.Ptr(false),
+		ListOptions:     github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		var page []*github.RepositoryRuleset
+		var resp *github.Response
+		err := c.retryer.Do(ctx, "ListRepositoryRulesets", func(ctx context.Context) error {
+			var err error
+			page, resp, err = c.rest.Repositories.GetAllRulesets(ctx, owner, repo, opts)
+			if err != nil {
+				return WrapError(err, "ListRepositoryRulesets", c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, page...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	// The list endpoint omits conditions, rules and bypass actors
+	rulesets := make([]*github.RepositoryRuleset, 0, len(summaries))
+	for _, summary := range summaries {
+		var ruleset *github.RepositoryRuleset
+		err := c.retryer.Do(ctx, "GetRuleset", func(ctx context.Context) error {
+			var err error
+			ruleset, _, err = c.rest.Repositories.GetRuleset(ctx, owner, repo, summary.GetID(), false)
+			if err != nil {
+				return WrapError(err, "GetRuleset", c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		rulesets = append(rulesets, ruleset)
+	}
+
+	return rulesets, nil
+}
+
+// CreateRepositoryRuleset creates a ruleset on a repository
+func (c *Client) CreateRepositoryRuleset(ctx context.Context, owner, repo string, ruleset github.RepositoryRuleset) (*github.RepositoryRuleset, error) {
+	var created *github.RepositoryRuleset
+	err := c.retryer.Do(ctx, "CreateRepositoryRuleset", func(ctx context.Context) error {
+		var err error
+		created, _, err = c.rest.Repositories.CreateRuleset(ctx, owner, repo, ruleset)
+		if err != nil {
+			return WrapError(err, "CreateRepositoryRuleset", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
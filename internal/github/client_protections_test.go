@@ -0,0 +1,89 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+)
+
+func newProtectionsTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+
+	mux.HandleFunc("/api/v3/rate_limit", mockRateLimitHandlerMigrations)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: DefaultRetryConfig(),
+		Logger:      slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError})),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestListProtectedBranches(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("protected") != "true" {
+			t.Errorf("Expected protected=true, got %q", r.URL.Query().Get("protected"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"name":"main","protected":true},{"name":"release/1.x","protected":true}]`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	branches, err := client.ListProtectedBranches(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("ListProtectedBranches() error = %v", err)
+	}
+	if len(branches) != 2 || branches[0] != "main" || branches[1] != "release/1.x" {
+		t.Errorf("Expected [main release/1.x], got %v", branches)
+	}
+}
+
+func TestListRepositoryRulesets_FetchesFullDefinitions(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/rulesets", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("includes_parents") != "false" {
+			t.Errorf("Expected includes_parents=false, got %q", r.URL.Query().Get("includes_parents"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":7,"name":"protect-main","enforcement":"active","source":"org/repo"}]`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/repo/rulesets/7", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":          7,
+			"name":        "protect-main",
+			"enforcement": "active",
+			"source":      "org/repo",
+			"bypass_actors": []map[string]any{
+				{"actor_id": 42, "actor_type": "Team", "bypass_mode": "always"},
+			},
+			"rules": []map[string]any{{"type": "deletion"}},
+		})
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	rulesets, err := client.ListRepositoryRulesets(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("ListRepositoryRulesets() error = %v", err)
+	}
+	if len(rulesets) != 1 {
+		t.Fatalf("Expected 1 ruleset, got %d", len(rulesets))
+	}
+	if len(rulesets[0].BypassActors) != 1 || rulesets[0].BypassActors[0].GetActorID() != 42 {
+		t.Errorf("Expected the full ruleset with its bypass actors, got %+v", rulesets[0])
+	}
+	if rulesets[0].Rules == nil || rulesets[0].Rules.Deletion == nil {
+		t.Error("Expected the ruleset's rules to be included")
+	}
+}
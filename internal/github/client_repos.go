@@ -0,0 +1,156 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v75/github"
+)
+
+// CreateRepositoryInput contains the parameters for creating a new repository
+type CreateRepositoryInput struct {
+	Name        string
+	Description string
+	Homepage    string
+	Visibility  string // public, private, or internal (default: private)
+	HasIssues   bool
+	HasWiki     bool
+}
+
+// CreateRepository creates a new, empty repository in the organization.
+// Used by migration paths that push git data directly instead of using GEI.
+func (c *Client) CreateRepository(ctx context.Context, org string, input CreateRepositoryInput) (*github.Repository, error) {
+	c.logger.Info("Creating repository", "org", org, "name", input.Name)
+
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = "private"
+	}
+
+	newRepo := &github.Repository{
+		Name:       github.Ptr(input.Name),
+		Visibility: github.Ptr(visibility),
+		HasIssues:  github.Ptr(input.HasIssues),
+		HasWiki:    github.Ptr(input.HasWiki),
+		AutoInit:   github.Ptr(false),
+	}
+	if input.Description != "" {
+		newRepo.Description = github.Ptr(input.Description)
+	}
+	if input.Homepage != "" {
+		newRepo.Homepage = github.Ptr(input.Homepage)
+	}
+
+	var repository *github.Repository
+	err := c.retryer.Do(ctx, "CreateRepository", func(ctx context.Context) error {
+		var err error
+		repository, _, err = c.rest.Repositories.Create(ctx, org, newRepo)
+		if err != nil {
+			return WrapError(err, "CreateRepository", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("Repository created successfully",
+		"org", org,
+		"repo", repository.GetFullName())
+
+	return repository, nil
+}
+
+// UpdateRepository applies the non-nil fields of settings to an existing repository
+func (c *Client) UpdateRepository(ctx context.Context, owner, repo string, settings *github.Repository) (*github.Repository, error) {
+	var repository *github.Repository
+	err := c.retryer.Do(ctx, "UpdateRepository", func(ctx context.Context) error {
+		var err error
+		repository, _, err = c.rest.Repositories.Edit(ctx, owner, repo, settings)
+		if err != nil {
+			return WrapError(err, "UpdateRepository", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repository, nil
+}
+
+// ArchiveRepository marks a repository as archived, making it read-only
+func (c *Client) ArchiveRepository(ctx context.Context, owner, repo string) error {
+	_, err := c.UpdateRepository(ctx, owner, repo, &github.Repository{Archived: github.Ptr(true)})
+	return err
+}
+
+// UnarchiveRepository clears the archived flag of a repository, making it writable again
+func (c *Client) UnarchiveRepository(ctx context.Context, owner, repo string) error {
+	_, err := c.UpdateRepository(ctx, owner, repo, &github.Repository{Archived: github.Ptr(false)})
+	return err
+}
+
+// DeleteRepository permanently deletes a repository
+func (c *Client) DeleteRepository(ctx context.Context, owner, repo string) error {
+	return c.retryer.Do(ctx, "DeleteRepository", func(ctx context.Context) error {
+		_, err := c.rest.Repositories.Delete(ctx, owner, repo)
+		if err != nil {
+			return WrapError(err, "DeleteRepository", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// UpdateBranchProtection creates or replaces the protection rules for a branch
+func (c *Client) UpdateBranchProtection(ctx context.Context, owner, repo, branch string, protection *github.ProtectionRequest) error {
+	return c.retryer.Do(ctx, "UpdateBranchProtection", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.UpdateBranchProtection(ctx, owner, repo, branch, protection)
+		if err != nil {
+			return WrapError(err, "UpdateBranchProtection", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// ReplaceTopics replaces all topics of a repository
+func (c *Client) ReplaceTopics(ctx context.Context, owner, repo string, topics []string) error {
+	if topics == nil {
+		topics = []string{} // An empty list clears the topics; nil would be sent as null
+	}
+	return c.retryer.Do(ctx, "ReplaceTopics", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+		if err != nil {
+			return WrapError(err, "ReplaceTopics", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// GetCustomPropertyValues returns the organization custom property values set on a repository
+func (c *Client) GetCustomPropertyValues(ctx context.Context, org, repo string) ([]*github.CustomPropertyValue, error) {
+	var values []*github.CustomPropertyValue
+	err := c.retryer.Do(ctx, "GetCustomPropertyValues", func(ctx context.Context) error {
+		var err error
+		values, _, err = c.rest.Repositories.GetAllCustomPropertyValues(ctx, org, repo)
+		if err != nil {
+			return WrapError(err, "GetCustomPropertyValues", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// SetCustomPropertyValues sets organization custom property values on a repository.
+// The properties must be defined in the repository's organization.
+func (c *Client) SetCustomPropertyValues(ctx context.Context, org, repo string, values []*github.CustomPropertyValue) error {
+	return c.retryer.Do(ctx, "SetCustomPropertyValues", func(ctx context.Context) error {
+		_, err := c.rest.Repositories.CreateOrUpdateCustomProperties(ctx, org, repo, values)
+		if err != nil {
+			return WrapError(err, "SetCustomPropertyValues", c.baseURL)
+		}
+		return nil
+	})
+}
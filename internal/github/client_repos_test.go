@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v75/github"
+)
+
+func TestReplaceTopics(t *testing.T) {
+	var sent []map[string][]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/v3/repos/org/repo/topics", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sent = append(sent, body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	if err := client.ReplaceTopics(context.Background(), "org", "repo", []string{"go", "cli"}); err != nil {
+		t.Fatalf("ReplaceTopics() error = %v", err)
+	}
+	if err := client.ReplaceTopics(context.Background(), "org", "repo", nil); err != nil {
+		t.Fatalf("ReplaceTopics(nil) error = %v", err)
+	}
+
+	if len(sent) != 2 || len(sent[0]["names"]) != 2 || sent[1]["names"] == nil || len(sent[1]["names"]) != 0 {
+		t.Errorf("Unexpected topic requests %v", sent)
+	}
+}
+
+func TestCustomPropertyValues(t *testing.T) {
+	var set []*github.CustomPropertyValue
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/properties/values", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"property_name":"team","value":"payments"},{"property_name":"tier","value":null}]`))
+	})
+	mux.HandleFunc("PATCH /api/v3/repos/org/repo/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Properties []*github.CustomPropertyValue `json:"properties"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		set = body.Properties
+		w.WriteHeader(http.StatusNoContent)
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	values, err := client.GetCustomPropertyValues(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("GetCustomPropertyValues() error = %v", err)
+	}
+	if len(values) != 2 || values[0].PropertyName != "team" || values[0].Value != "payments" || values[1].Value != nil {
+		t.Errorf("Unexpected values %+v", values)
+	}
+
+	if err := client.SetCustomPropertyValues(context.Background(), "org", "repo", values[:1]); err != nil {
+		t.Fatalf("SetCustomPropertyValues() error = %v", err)
+	}
+	if len(set) != 1 || set[0].PropertyName != "team" || set[0].Value != "payments" {
+		t.Errorf("Unexpected values sent %+v", set)
+	}
+}
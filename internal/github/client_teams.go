@@ -18,6 +18,12 @@ const (
 	PermissionPull     = "pull"
 )
 
+// Team membership roles
+const (
+	TeamRoleMember     = "member"
+	TeamRoleMaintainer = "maintainer"
+)
+
 // TeamInfo represents basic team information returned from discovery
 type TeamInfo struct {
 	ID          int64
@@ -25,6 +31,7 @@ type TeamInfo struct {
 	Name        string
 	Description string
 	Privacy     string
+	ParentSlug  string // Slug of the parent team in the same organization; empty for top-level teams
 }
 
 // TeamRepository represents a repository associated with a team
@@ -74,10 +81,11 @@ func (c *Client) ListOrganizationTeams(ctx context.Context, org string) ([]*Team
 
 		for _, team := range teams {
 			info := &TeamInfo{
-				ID:      team.GetID(),
-				Slug:    team.GetSlug(),
-				Name:    team.GetName(),
-				Privacy: team.GetPrivacy(),
+				ID:         team.GetID(),
+				Slug:       team.GetSlug(),
+				Name:       team.GetName(),
+				Privacy:    team.GetPrivacy(),
+				ParentSlug: team.GetParent().GetSlug(),
 			}
 			if team.Description != nil {
 				info.Description = *team.Description
@@ -332,10 +340,11 @@ func (c *Client) GetTeamBySlug(ctx context.Context, org, slug string) (*TeamInfo
 	}
 
 	info := &TeamInfo{
-		ID:      team.GetID(),
-		Slug:    team.GetSlug(),
-		Name:    team.GetName(),
-		Privacy: team.GetPrivacy(),
+		ID:         team.GetID(),
+		Slug:       team.GetSlug(),
+		Name:       team.GetName(),
+		Privacy:    team.GetPrivacy(),
+		ParentSlug: team.GetParent().GetSlug(),
 	}
 	if team.Description != nil {
 		info.Description = *team.Description
@@ -388,10 +397,11 @@ func (c *Client) CreateTeam(ctx context.Context, org string, input CreateTeamInp
 	}
 
 	info := &TeamInfo{
-		ID:      team.GetID(),
-		Slug:    team.GetSlug(),
-		Name:    team.GetName(),
-		Privacy: team.GetPrivacy(),
+		ID:         team.GetID(),
+		Slug:       team.GetSlug(),
+		Name:       team.GetName(),
+		Privacy:    team.GetPrivacy(),
+		ParentSlug: team.GetParent().GetSlug(),
 	}
 	if team.Description != nil {
 		info.Description = *team.Description
@@ -405,6 +415,23 @@ func (c *Client) CreateTeam(ctx context.Context, org string, input CreateTeamInp
 	return info, nil
 }
 
+// SetTeamParent nests an existing team under the team with parentTeamID.
+// GitHub requires the team name on every edit, so the current name must be passed.
+func (c *Client) SetTeamParent(ctx context.Context, org, slug, name string, parentTeamID int64) error {
+	c.logger.Info("Setting parent team", "org", org, "team", slug, "parent_team_id", parentTeamID)
+
+	return c.retryer.Do(ctx, "SetTeamParent", func(ctx context.Context) error {
+		_, _, err := c.rest.Teams.EditTeamBySlug(ctx, org, slug, github.NewTeam{
+			Name:         name,
+			ParentTeamID: &parentTeamID,
+		}, false)
+		if err != nil {
+			return WrapError(err, "EditTeamBySlug", c.baseURL)
+		}
+		return nil
+	})
+}
+
 // AddTeamRepoPermission adds or updates a repository's permission for a team
 // Permission must be one of: pull, triage, push, maintain, admin
 func (c *Client) AddTeamRepoPermission(ctx context.Context, org, teamSlug, repoOwner, repoName, permission string) error {
@@ -480,3 +507,72 @@ func (c *Client) RemoveTeamMembership(ctx context.Context, org, teamSlug, userna
 
 	return nil
 }
+
+// AddTeamMembership adds a user to a team, or updates their role if they are already a member.
+// Role must be member or maintainer.
+func (c *Client) AddTeamMembership(ctx context.Context, org, teamSlug, username, role string) error {
+	if role != TeamRoleMember && role != TeamRoleMaintainer {
+		return fmt.Errorf("invalid team role %q, must be one of: %s, %s", role, TeamRoleMember, TeamRoleMaintainer)
+	}
+
+	return c.retryer.Do(ctx, "AddTeamMembership", func(ctx context.Context) error {
+		_, _, err := c.rest.Teams.AddTeamMembershipBySlug(ctx, org, teamSlug, username, &github.TeamAddTeamMembershipOptions{Role: role})
+		if err != nil {
+			return WrapError(err, "AddTeamMembershipBySlug", c.baseURL)
+		}
+		return nil
+	})
+}
+
+// TeamIdentitySync describes the identity provider groups a team's membership is synced from
+type TeamIdentitySync struct {
+	Source string   // "external_group" (Enterprise Managed Users) or "team_sync" (IdP team synchronization)
+	Groups []string // Names of the connected groups
+}
+
+// GetTeamIdentitySync returns the identity provider groups a team is connected to, or nil when
+// membership is managed on GitHub. Organizations without external groups or team
+// synchronization are treated as managed on GitHub.
+func (c *Client) GetTeamIdentitySync(ctx context.Context, org, teamSlug string) (*TeamIdentitySync, error) {
+	var external *github.ExternalGroupList
+	err := c.retryer.Do(ctx, "ListExternalGroupsForTeam", func(ctx context.Context) error {
+		var err error
+		external, _, err = c.rest.Teams.ListExternalGroupsForTeamBySlug(ctx, org, teamSlug)
+		if err != nil {
+			return WrapError(err, "ListExternalGroupsForTeamBySlug", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil && !IsNotFoundError(err) && !IsAuthError(err) {
+		return nil, err
+	}
+	if external != nil && len(external.Groups) > 0 {
+		sync := &TeamIdentitySync{Source: "external_group"}
+		for _, group := range external.Groups {
+			sync.Groups = append(sync.Groups, group.GetGroupName())
+		}
+		return sync, nil
+	}
+
+	var idp *github.IDPGroupList
+	err = c.retryer.Do(ctx, "ListIDPGroupsForTeam", func(ctx context.Context) error {
+		var err error
+		idp, _, err = c.rest.Teams.ListIDPGroupsForTeamBySlug(ctx, org, teamSlug)
+		if err != nil {
+			return WrapError(err, "ListIDPGroupsForTeamBySlug", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil && !IsNotFoundError(err) && !IsAuthError(err) {
+		return nil, err
+	}
+	if idp != nil && len(idp.Groups) > 0 {
+		sync := &TeamIdentitySync{Source: "team_sync"}
+		for _, group := range idp.Groups {
+			sync.Groups = append(sync.Groups, group.GetGroupName())
+		}
+		return sync, nil
+	}
+
+	return nil, nil
+}
@@ -42,6 +42,7 @@ func TestListOrganizationTeams(t *testing.T) {
 				"name":        "Team Two",
 				"description": "Second team",
 				"privacy":     "secret",
+				"parent":      map[string]any{"id": 123, "slug": "team-one"},
 			},
 		}
 		w.Header().Set("Content-Type", "application/json")
@@ -77,6 +78,9 @@ func TestListOrganizationTeams(t *testing.T) {
 	if teams[0].Name != "Team One" {
 		t.Errorf("Expected first team name 'Team One', got %s", teams[0].Name)
 	}
+	if teams[0].ParentSlug != "" || teams[1].ParentSlug != "team-one" {
+		t.Errorf("Expected team-two to be nested under team-one, got %q and %q", teams[0].ParentSlug, teams[1].ParentSlug)
+	}
 }
 
 func TestListTeamRepositories(t *testing.T) {
@@ -315,3 +319,85 @@ func TestTeamMember(t *testing.T) {
 		}
 	})
 }
+
+func TestAddTeamMembership(t *testing.T) {
+	var role string
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/v3/orgs/dest-org/teams/backend/memberships/alice", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		role = body["role"]
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"state":"active","role":"maintainer"}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	if err := client.AddTeamMembership(context.Background(), "dest-org", "backend", "alice", TeamRoleMaintainer); err != nil {
+		t.Fatalf("AddTeamMembership() error = %v", err)
+	}
+	if role != TeamRoleMaintainer {
+		t.Errorf("Expected role %q, got %q", TeamRoleMaintainer, role)
+	}
+
+	if err := client.AddTeamMembership(context.Background(), "dest-org", "backend", "alice", "owner"); err == nil {
+		t.Error("Expected an invalid role to be rejected")
+	}
+}
+
+func TestSetTeamParent(t *testing.T) {
+	var body map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /api/v3/orgs/dest-org/teams/frontend", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":2,"slug":"frontend","name":"Frontend","parent":{"id":1,"slug":"engineering"}}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	if err := client.SetTeamParent(context.Background(), "dest-org", "frontend", "Frontend", 1); err != nil {
+		t.Fatalf("SetTeamParent() error = %v", err)
+	}
+	if body["name"] != "Frontend" || body["parent_team_id"] != float64(1) {
+		t.Errorf("Unexpected request body %v", body)
+	}
+}
+
+func TestGetTeamIdentitySync(t *testing.T) {
+	notFound := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/orgs/org/teams/emu/external-groups", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"groups":[{"group_id":1,"group_name":"Engineering"}]}`))
+	})
+	mux.HandleFunc("GET /api/v3/orgs/org/teams/synced/external-groups", notFound)
+	mux.HandleFunc("GET /api/v3/orgs/org/teams/synced/team-sync/group-mappings", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"groups":[{"group_id":"abc","group_name":"Backend"}]}`))
+	})
+	mux.HandleFunc("GET /api/v3/orgs/org/teams/manual/external-groups", notFound)
+	mux.HandleFunc("GET /api/v3/orgs/org/teams/manual/team-sync/group-mappings", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"groups":[]}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+	ctx := context.Background()
+
+	sync, err := client.GetTeamIdentitySync(ctx, "org", "emu")
+	if err != nil || sync == nil || sync.Source != "external_group" || len(sync.Groups) != 1 || sync.Groups[0] != "Engineering" {
+		t.Errorf("GetTeamIdentitySync(emu) = %+v, %v", sync, err)
+	}
+
+	sync, err = client.GetTeamIdentitySync(ctx, "org", "synced")
+	if err != nil || sync == nil || sync.Source != "team_sync" || sync.Groups[0] != "Backend" {
+		t.Errorf("GetTeamIdentitySync(synced) = %+v, %v", sync, err)
+	}
+
+	sync, err = client.GetTeamIdentitySync(ctx, "org", "manual")
+	if err != nil || sync != nil {
+		t.Errorf("GetTeamIdentitySync(manual) = %+v, %v, want nil", sync, err)
+	}
+}
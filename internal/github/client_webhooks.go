@@ -0,0 +1,65 @@
+package github
+
+import (
+	"context"
+
+	"github.com/google/go-github/v75/github"
+)
+
+// ListHooks returns the webhooks configured on a repository.
+// Hook secrets are never returned in clear text; GitHub obfuscates them.
+func (c *Client) ListHooks(ctx context.Context, owner, repo string) ([]*github.Hook, error) {
+	var hooks []*github.Hook
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		var page []*github.Hook
+		var resp *github.Response
+		err := c.retryer.Do(ctx, "ListHooks", func(ctx context.Context) error {
+			var err error
+			page, resp, err = c.rest.Repositories.ListHooks(ctx, owner, repo, opts)
+			if err != nil {
+				return WrapError(err, "ListHooks", c.baseURL)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		hooks = append(hooks, page...)
+		if resp == nil || resp.NextPage == 0 {
+			return hooks, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// CreateHook creates a webhook on a repository and returns it with its new ID
+func (c *Client) CreateHook(ctx context.Context, owner, repo string, hook *github.Hook) (*github.Hook, error) {
+	var created *github.Hook
+	err := c.retryer.Do(ctx, "CreateHook", func(ctx context.Context) error {
+		var err error
+		created, _, err = c.rest.Repositories.CreateHook(ctx, owner, repo, hook)
+		if err != nil {
+			return WrapError(err, "CreateHook", c.baseURL)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// ActivateHook turns on delivery for a repository webhook
+func (c *Client) ActivateHook(ctx context.Context, owner, repo string, id int64) error {
+	return c.retryer.Do(ctx, "ActivateHook", func(ctx context.Context) error {
+		_, _, err := c.rest.Repositories.EditHook(ctx, owner, repo, id, &github.Hook{Active: github.Ptr(true)})
+		if err != nil {
+			return WrapError(err, "ActivateHook", c.baseURL)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,77 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v75/github"
+)
+
+func TestListHooks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/org/repo/hooks", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"id":1,"active":true,"events":["push"],"config":{"url":"https://ci.example.com/hook","content_type":"json","secret":"********"}},
+			{"id":2,"active":false,"events":["issues","pull_request"],"config":{"url":"https://chat.example.com/hook","content_type":"form"}}
+		]`))
+	})
+	client := newProtectionsTestClient(t, mux)
+
+	hooks, err := client.ListHooks(context.Background(), "org", "repo")
+	if err != nil {
+		t.Fatalf("ListHooks() error = %v", err)
+	}
+	if len(hooks) != 2 {
+		t.Fatalf("Expected 2 hooks, got %d", len(hooks))
+	}
+	if hooks[0].GetConfig().GetURL() != "https://ci.example.com/hook" || hooks[0].GetConfig().GetSecret() == "" {
+		t.Errorf("Unexpected first hook %+v", hooks[0])
+	}
+	if len(hooks[1].Events) != 2 || hooks[1].GetConfig().GetContentType() != "form" {
+		t.Errorf("Unexpected second hook %+v", hooks[1])
+	}
+}
+
+func TestCreateAndActivateHook(t *testing.T) {
+	var created, edited github.Hook
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v3/repos/org/repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+			t.Errorf("Failed to decode hook: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":42,"active":false}`))
+	})
+	mux.HandleFunc("PATCH /api/v3/repos/org/repo/hooks/42", func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&edited); err != nil {
+			t.Errorf("Failed to decode hook: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":42,"active":true}`))
+	})
+	client := newProtectionsTestClient(t, mux)
+	ctx := context.Background()
+
+	hook, err := client.CreateHook(ctx, "org", "repo", &github.Hook{
+		Config: &github.HookConfig{URL: github.Ptr("https://ci.example.com/hook")},
+		Events: []string{"push"},
+		Active: github.Ptr(false),
+	})
+	if err != nil {
+		t.Fatalf("CreateHook() error = %v", err)
+	}
+	if hook.GetID() != 42 || created.Active == nil || *created.Active {
+		t.Errorf("Expected an inactive hook with ID 42, got %+v (sent %+v)", hook, created)
+	}
+
+	if err := client.ActivateHook(ctx, "org", "repo", 42); err != nil {
+		t.Fatalf("ActivateHook() error = %v", err)
+	}
+	if edited.Active == nil || !*edited.Active {
+		t.Errorf("Expected the hook to be activated, sent %+v", edited)
+	}
+}
@@ -0,0 +1,336 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when the GitLab API responds with 404
+var ErrNotFound = errors.New("gitlab resource not found")
+
+// defaultPerPage is the page size used for paginated list endpoints (GitLab max is 100)
+const defaultPerPage = 100
+
+// Client is a minimal GitLab REST API (v4) client covering the endpoints needed
+// for discovery, profiling, and migration.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+	logger     *slog.Logger
+}
+
+// ClientConfig contains configuration for creating a GitLab client
+type ClientConfig struct {
+	BaseURL    string // GitLab instance URL (e.g., https://gitlab.com)
+	Token      string // Personal, group, or project access token
+	Logger     *slog.Logger
+	HTTPClient *http.Client // Optional: defaults to a client with a 60s timeout
+}
+
+// Validate checks if the configuration is valid
+func (c ClientConfig) Validate() error {
+	if c.BaseURL == "" {
+		return fmt.Errorf("base URL is required")
+	}
+	if c.Token == "" {
+		return fmt.Errorf("token is required")
+	}
+	return nil
+}
+
+// Group represents a GitLab group or subgroup
+type Group struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	FullPath   string `json:"full_path"`
+	Visibility string `json:"visibility"`
+	WebURL     string `json:"web_url"`
+}
+
+// ProjectStatistics contains storage statistics for a project (requires statistics=true)
+type ProjectStatistics struct {
+	CommitCount    int64 `json:"commit_count"`
+	StorageSize    int64 `json:"storage_size"`
+	RepositorySize int64 `json:"repository_size"`
+	LFSObjectsSize int64 `json:"lfs_objects_size"`
+	WikiSize       int64 `json:"wiki_size"`
+}
+
+// ForkedFromProject identifies the upstream project of a fork
+type ForkedFromProject struct {
+	ID                int64  `json:"id"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+// Project represents a GitLab project (repository)
+type Project struct {
+	ID                                        int64              `json:"id"`
+	Name                                      string             `json:"name"`
+	Path                                      string             `json:"path"`
+	PathWithNamespace                         string             `json:"path_with_namespace"`
+	Description                               string             `json:"description"`
+	DefaultBranch                             string             `json:"default_branch"`
+	Visibility                                string             `json:"visibility"`
+	WebURL                                    string             `json:"web_url"`
+	HTTPURLToRepo                             string             `json:"http_url_to_repo"`
+	Archived                                  bool               `json:"archived"`
+	EmptyRepo                                 bool               `json:"empty_repo"`
+	ForkedFromProject                         *ForkedFromProject `json:"forked_from_project,omitempty"`
+	Topics                                    []string           `json:"topics"`
+	IssuesEnabled                             bool               `json:"issues_enabled"`
+	WikiEnabled                               bool               `json:"wiki_enabled"`
+	LFSEnabled                                bool               `json:"lfs_enabled"`
+	MergeMethod                               string             `json:"merge_method"`
+	SquashOption                              string             `json:"squash_option"`
+	RemoveSourceBranchAfterMerge              bool               `json:"remove_source_branch_after_merge"`
+	OnlyAllowMergeIfPipelineSucceeds          bool               `json:"only_allow_merge_if_pipeline_succeeds"`
+	OnlyAllowMergeIfAllDiscussionsAreResolved bool               `json:"only_allow_merge_if_all_discussions_are_resolved"`
+	LastActivityAt                            *time.Time         `json:"last_activity_at,omitempty"`
+	Statistics                                *ProjectStatistics `json:"statistics,omitempty"`
+}
+
+// AccessLevel describes who may push or merge to a protected branch
+type AccessLevel struct {
+	AccessLevel            int    `json:"access_level"`
+	AccessLevelDescription string `json:"access_level_description"`
+	UserID                 *int64 `json:"user_id,omitempty"`
+	GroupID                *int64 `json:"group_id,omitempty"`
+}
+
+// Access level values used by protected branches
+const (
+	AccessLevelNoAccess   = 0
+	AccessLevelDeveloper  = 30
+	AccessLevelMaintainer = 40
+	AccessLevelAdmin      = 60
+)
+
+// ProtectedBranch represents a protected branch rule on a project
+type ProtectedBranch struct {
+	ID                        int64         `json:"id"`
+	Name                      string        `json:"name"`
+	PushAccessLevels          []AccessLevel `json:"push_access_levels"`
+	MergeAccessLevels         []AccessLevel `json:"merge_access_levels"`
+	AllowForcePush            bool          `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool          `json:"code_owner_approval_required"`
+}
+
+// WikiPage represents a project wiki page (content is not requested)
+type WikiPage struct {
+	Slug   string `json:"slug"`
+	Title  string `json:"title"`
+	Format string `json:"format"`
+}
+
+// NewClient creates a new GitLab client
+func NewClient(cfg ClientConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		token:      cfg.Token,
+		httpClient: httpClient,
+		logger:     logger,
+	}, nil
+}
+
+// BaseURL returns the GitLab instance URL
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// GetGroup returns a group by ID or full path (e.g., "parent/child")
+func (c *Client) GetGroup(ctx context.Context, group string) (*Group, error) {
+	var g Group
+	if _, err := c.get(ctx, "/groups/"+url.PathEscape(group), nil, &g); err != nil {
+		return nil, fmt.Errorf("failed to get group %s: %w", group, err)
+	}
+	return &g, nil
+}
+
+// ListSubgroups returns the direct subgroups of a group
+func (c *Client) ListSubgroups(ctx context.Context, groupID int64) ([]Group, error) {
+	var groups []Group
+	path := fmt.Sprintf("/groups/%d/subgroups", groupID)
+	if err := listAll(ctx, c, path, nil, &groups); err != nil {
+		return nil, fmt.Errorf("failed to list subgroups of group %d: %w", groupID, err)
+	}
+	return groups, nil
+}
+
+// ListGroupProjects returns the projects that belong directly to a group (subgroups excluded),
+// including storage statistics
+func (c *Client) ListGroupProjects(ctx context.Context, groupID int64) ([]Project, error) {
+	var projects []Project
+	path := fmt.Sprintf("/groups/%d/projects", groupID)
+	query := url.Values{
+		"include_subgroups": {"false"},
+		"with_shared":       {"false"},
+		"statistics":        {"true"},
+	}
+	if err := listAll(ctx, c, path, query, &projects); err != nil {
+		return nil, fmt.Errorf("failed to list projects of group %d: %w", groupID, err)
+	}
+	return projects, nil
+}
+
+// GetProject returns a project by ID or full path (e.g., "group/subgroup/project"),
+// including storage statistics
+func (c *Client) GetProject(ctx context.Context, project string) (*Project, error) {
+	var p Project
+	query := url.Values{"statistics": {"true"}}
+	if _, err := c.get(ctx, "/projects/"+url.PathEscape(project), query, &p); err != nil {
+		return nil, fmt.Errorf("failed to get project %s: %w", project, err)
+	}
+	return &p, nil
+}
+
+// ListProtectedBranches returns the protected branch rules of a project
+func (c *Client) ListProtectedBranches(ctx context.Context, projectID int64) ([]ProtectedBranch, error) {
+	var branches []ProtectedBranch
+	path := fmt.Sprintf("/projects/%d/protected_branches", projectID)
+	if err := listAll(ctx, c, path, nil, &branches); err != nil {
+		return nil, fmt.Errorf("failed to list protected branches of project %d: %w", projectID, err)
+	}
+	return branches, nil
+}
+
+// CountMergeRequests returns the number of merge requests in the given state
+// ("all", "opened", "closed", "merged") using the X-Total pagination header
+func (c *Client) CountMergeRequests(ctx context.Context, projectID int64, state string) (int, error) {
+	path := fmt.Sprintf("/projects/%d/merge_requests", projectID)
+	query := url.Values{"state": {state}, "per_page": {"1"}}
+
+	var page []json.RawMessage
+	resp, err := c.get(ctx, path, query, &page)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count merge requests of project %d: %w", projectID, err)
+	}
+
+	// X-Total is omitted by GitLab for very large collections; fall back to the page length
+	if total, convErr := strconv.Atoi(resp.Header.Get("X-Total")); convErr == nil {
+		return total, nil
+	}
+	return len(page), nil
+}
+
+// HasFile reports whether a file exists at the given ref
+func (c *Client) HasFile(ctx context.Context, projectID int64, filePath, ref string) (bool, error) {
+	path := fmt.Sprintf("/projects/%d/repository/files/%s", projectID, url.PathEscape(filePath))
+	query := url.Values{"ref": {ref}}
+
+	resp, err := c.do(ctx, http.MethodHead, path, query)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check file %s in project %d: %w", filePath, projectID, err)
+	}
+	_ = resp.Body.Close()
+	return true, nil
+}
+
+// ListWikiPages returns the wiki pages of a project (without content)
+func (c *Client) ListWikiPages(ctx context.Context, projectID int64) ([]WikiPage, error) {
+	var pages []WikiPage
+	path := fmt.Sprintf("/projects/%d/wikis", projectID)
+	if _, err := c.get(ctx, path, nil, &pages); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list wiki pages of project %d: %w", projectID, err)
+	}
+	return pages, nil
+}
+
+// listAll follows X-Next-Page pagination and appends every page into out
+func listAll[T any](ctx context.Context, c *Client, path string, query url.Values, out *[]T) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(defaultPerPage))
+	page := "1"
+
+	for page != "" {
+		query.Set("page", page)
+
+		var items []T
+		resp, err := c.get(ctx, path, query, &items)
+		if err != nil {
+			return err
+		}
+		*out = append(*out, items...)
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return nil
+}
+
+// get sends a GET request and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) (*http.Response, error) {
+	resp, err := c.do(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp, nil
+}
+
+// do sends an authenticated request to the GitLab API and returns the response if it succeeded.
+// The caller is responsible for closing the response body.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values) (*http.Response, error) {
+	reqURL := c.baseURL + "/api/v4" + path
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return nil, fmt.Errorf("GitLab API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return resp, nil
+}
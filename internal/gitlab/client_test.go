@@ -0,0 +1,170 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(ClientConfig{BaseURL: server.URL + "/", Token: "test-token"})
+	if err != nil {
+		t.Fatalf("NewClient() error: %v", err)
+	}
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  ClientConfig
+		wantErr bool
+	}{
+		{name: "valid configuration", config: ClientConfig{BaseURL: "https://gitlab.com", Token: "token"}},
+		{name: "empty base URL", config: ClientConfig{Token: "token"}, wantErr: true},
+		{name: "empty token", config: ClientConfig{BaseURL: "https://gitlab.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewClient() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && client.BaseURL() != "https://gitlab.com" {
+				t.Errorf("BaseURL() = %s, want https://gitlab.com", client.BaseURL())
+			}
+		})
+	}
+}
+
+func TestClient_GetGroup(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "test-token" {
+			t.Errorf("missing PRIVATE-TOKEN header")
+		}
+		if r.PathValue("id") != "parent/child" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id": 42, "name": "Child", "path": "child", "full_path": "parent/child"}`)
+	})
+	client := newTestClient(t, mux)
+
+	group, err := client.GetGroup(context.Background(), "parent/child")
+	if err != nil {
+		t.Fatalf("GetGroup() error: %v", err)
+	}
+	if group.ID != 42 || group.FullPath != "parent/child" {
+		t.Errorf("GetGroup() = %+v, want id 42 full_path parent/child", group)
+	}
+
+	_, err = client.GetGroup(context.Background(), "missing")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetGroup() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestClient_ListGroupProjects_Paginates(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/groups/7/projects", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("statistics") != "true" {
+			t.Errorf("expected statistics=true")
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			w.Header().Set("X-Next-Page", "2")
+			_, _ = fmt.Fprint(w, `[{"id": 1, "path_with_namespace": "grp/one"}]`)
+		case "2":
+			_, _ = fmt.Fprint(w, `[{"id": 2, "path_with_namespace": "grp/two", "statistics": {"repository_size": 1024}}]`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	})
+	client := newTestClient(t, mux)
+
+	projects, err := client.ListGroupProjects(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("ListGroupProjects() error: %v", err)
+	}
+	if len(projects) != 2 {
+		t.Fatalf("ListGroupProjects() returned %d projects, want 2", len(projects))
+	}
+	if projects[1].Statistics == nil || projects[1].Statistics.RepositorySize != 1024 {
+		t.Errorf("expected statistics on second project, got %+v", projects[1].Statistics)
+	}
+}
+
+func TestClient_CountMergeRequests(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/5/merge_requests", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") == "opened" {
+			w.Header().Set("X-Total", "3")
+		} else {
+			w.Header().Set("X-Total", "120")
+		}
+		_, _ = fmt.Fprint(w, `[{"id": 1}]`)
+	})
+	client := newTestClient(t, mux)
+
+	total, err := client.CountMergeRequests(context.Background(), 5, "all")
+	if err != nil {
+		t.Fatalf("CountMergeRequests() error: %v", err)
+	}
+	if total != 120 {
+		t.Errorf("CountMergeRequests(all) = %d, want 120", total)
+	}
+
+	open, err := client.CountMergeRequests(context.Background(), 5, "opened")
+	if err != nil {
+		t.Fatalf("CountMergeRequests() error: %v", err)
+	}
+	if open != 3 {
+		t.Errorf("CountMergeRequests(opened) = %d, want 3", open)
+	}
+}
+
+func TestClient_HasFile(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("HEAD /api/v4/projects/5/repository/files/{file}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("file") == ".gitlab-ci.yml" && r.URL.Query().Get("ref") == "main" {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	client := newTestClient(t, mux)
+
+	found, err := client.HasFile(context.Background(), 5, ".gitlab-ci.yml", "main")
+	if err != nil || !found {
+		t.Errorf("HasFile() = %v, %v; want true, nil", found, err)
+	}
+
+	found, err = client.HasFile(context.Background(), 5, ".gitlab-ci.yml", "develop")
+	if err != nil || found {
+		t.Errorf("HasFile() = %v, %v; want false, nil", found, err)
+	}
+}
+
+func TestClient_APIError(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message": "403 Forbidden"}`, http.StatusForbidden)
+	}))
+
+	_, err := client.ListProtectedBranches(context.Background(), 5)
+	if err == nil {
+		t.Fatal("ListProtectedBranches() expected error")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Errorf("403 should not be reported as ErrNotFound")
+	}
+}
@@ -0,0 +1,209 @@
+// Package leader provides database-backed leader election for background loops
+// that must run on only one server replica at a time.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// Names of the leader locks guarding singleton loops
+const (
+	LockBatchScheduler     = "batch_scheduler"
+	LockBatchStatusUpdater = "batch_status_updater"
+)
+
+// ResolveInstanceID returns the configured instance ID, or the hostname when none is configured.
+// The instance ID identifies this server replica as the owner of leader locks and migration leases.
+func ResolveInstanceID(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		return fmt.Sprintf("migrator-%d", os.Getpid())
+	}
+	return hostname
+}
+
+// Elector campaigns for a named leader lock and tracks whether this replica holds it.
+// The lock carries a fencing token that increases each time leadership changes hands;
+// Fence checks the token against the database before the leader does any work, so a
+// replica that lost the lock (e.g. after a long pause) stops acting as the leader.
+type Elector struct {
+	storage    *storage.Database
+	logger     *slog.Logger
+	name       string
+	instanceID string
+	ttl        time.Duration
+
+	mu    sync.RWMutex
+	token int64 // Fencing token while leader, 0 otherwise
+}
+
+// ElectorConfig configures a leader elector
+type ElectorConfig struct {
+	Storage    *storage.Database
+	Logger     *slog.Logger
+	Name       string        // Leader lock name
+	InstanceID string        // Unique ID of this server replica (default: hostname)
+	TTL        time.Duration // How long the lock is held without renewal (default: 2 minutes)
+}
+
+// NewElector creates a new leader elector
+func NewElector(cfg ElectorConfig) (*Elector, error) {
+	if cfg.Storage == nil {
+		return nil, fmt.Errorf("storage is required")
+	}
+	if cfg.Logger == nil {
+		return nil, fmt.Errorf("logger is required")
+	}
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("lock name is required")
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = 2 * time.Minute
+	}
+
+	return &Elector{
+		storage:    cfg.Storage,
+		logger:     cfg.Logger,
+		name:       cfg.Name,
+		instanceID: ResolveInstanceID(cfg.InstanceID),
+		ttl:        cfg.TTL,
+	}, nil
+}
+
+// Run campaigns for the lock and renews it until the context is cancelled,
+// then releases the lock so another replica can take over immediately.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	e.campaign(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.release()
+			return
+		case <-ticker.C:
+			e.campaign(ctx)
+		}
+	}
+}
+
+// Fence reports whether this replica is the leader and its fencing token is still current.
+// A replica that is not yet the leader campaigns once before giving up.
+func (e *Elector) Fence(ctx context.Context) bool {
+	return e.LeaderFence(ctx) != nil
+}
+
+// LeaderFence checks the fencing token like Fence and returns it for the leader's writes,
+// or nil if this replica is not the leader. Writes made with the fence fail once another
+// replica takes the lock over, even if this replica has not noticed yet.
+func (e *Elector) LeaderFence(ctx context.Context) *storage.LeaderFence {
+	token := e.Token()
+	if token == 0 {
+		token = e.campaign(ctx)
+		if token == 0 {
+			return nil
+		}
+	}
+
+	valid, err := e.storage.ValidateLeaderFence(ctx, e.name, e.instanceID, token)
+	if err != nil {
+		e.logger.Warn("Failed to validate leader fence",
+			"lock", e.name,
+			"error", err)
+		return nil
+	}
+	if !valid {
+		e.setToken(0)
+		e.logger.Warn("Leader fencing token is stale, stepping down",
+			"lock", e.name,
+			"instance_id", e.instanceID,
+			"fencing_token", token)
+		return nil
+	}
+	return &storage.LeaderFence{Name: e.name, OwnerID: e.instanceID, Token: token}
+}
+
+// IsLeader returns true if this replica currently believes it holds the lock
+func (e *Elector) IsLeader() bool {
+	return e.Token() != 0
+}
+
+// Token returns the current fencing token, or 0 if this replica is not the leader
+func (e *Elector) Token() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.token
+}
+
+// campaign acquires or renews the lock and returns the fencing token held afterwards
+func (e *Elector) campaign(ctx context.Context) int64 {
+	lock, err := e.storage.AcquireLeaderLock(ctx, e.name, e.instanceID, e.ttl)
+	if err != nil {
+		// Without a renewal the lock may expire, so stop acting as the leader
+		e.logger.Warn("Failed to acquire leader lock",
+			"lock", e.name,
+			"error", err)
+		e.setToken(0)
+		return 0
+	}
+
+	var token int64
+	if lock != nil {
+		token = lock.FencingToken
+	}
+	e.setToken(token)
+	return token
+}
+
+// setToken records the fencing token and logs leadership changes
+func (e *Elector) setToken(token int64) {
+	e.mu.Lock()
+	previous := e.token
+	e.token = token
+	e.mu.Unlock()
+
+	switch {
+	case previous == 0 && token != 0:
+		e.logger.Info("Acquired leader lock",
+			"lock", e.name,
+			"instance_id", e.instanceID,
+			"fencing_token", token)
+	case previous != 0 && token != previous:
+		e.logger.Warn("Lost leader lock",
+			"lock", e.name,
+			"instance_id", e.instanceID,
+			"fencing_token", previous)
+	}
+}
+
+// release gives up the lock if this replica holds it
+func (e *Elector) release() {
+	if !e.IsLeader() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := e.storage.ReleaseLeaderLock(ctx, e.name, e.instanceID); err != nil {
+		e.logger.Warn("Failed to release leader lock",
+			"lock", e.name,
+			"error", err)
+		return
+	}
+	e.mu.Lock()
+	e.token = 0
+	e.mu.Unlock()
+	e.logger.Info("Released leader lock", "lock", e.name, "instance_id", e.instanceID)
+}
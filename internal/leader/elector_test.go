@@ -0,0 +1,172 @@
+package leader
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func setupTestDB(t *testing.T) *storage.Database {
+	t.Helper()
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func newTestElector(t *testing.T, db *storage.Database, instanceID string, ttl time.Duration) *Elector {
+	t.Helper()
+
+	elector, err := NewElector(ElectorConfig{
+		Storage:    db,
+		Logger:     slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+		Name:       LockBatchScheduler,
+		InstanceID: instanceID,
+		TTL:        ttl,
+	})
+	if err != nil {
+		t.Fatalf("NewElector() error: %v", err)
+	}
+	return elector
+}
+
+func TestNewElector(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	db := &storage.Database{}
+
+	tests := []struct {
+		name    string
+		cfg     ElectorConfig
+		wantErr bool
+	}{
+		{"valid", ElectorConfig{Storage: db, Logger: logger, Name: LockBatchScheduler}, false},
+		{"missing storage", ElectorConfig{Logger: logger, Name: LockBatchScheduler}, true},
+		{"missing logger", ElectorConfig{Storage: db, Name: LockBatchScheduler}, true},
+		{"missing name", ElectorConfig{Storage: db, Logger: logger}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elector, err := NewElector(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewElector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if elector.instanceID == "" {
+				t.Error("Expected instance ID to default to the hostname")
+			}
+			if elector.ttl != 2*time.Minute {
+				t.Errorf("ttl = %v, want 2m", elector.ttl)
+			}
+		})
+	}
+}
+
+func TestResolveInstanceID(t *testing.T) {
+	if got := ResolveInstanceID("migrator-0"); got != "migrator-0" {
+		t.Errorf("ResolveInstanceID(migrator-0) = %q", got)
+	}
+	if got := ResolveInstanceID(""); got == "" {
+		t.Error("ResolveInstanceID(\"\") should fall back to the hostname")
+	}
+}
+
+func TestElector_OnlyOneLeader(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	a := newTestElector(t, db, "replica-a", time.Minute)
+	b := newTestElector(t, db, "replica-b", time.Minute)
+
+	if !a.Fence(ctx) {
+		t.Fatal("Expected replica-a to become leader")
+	}
+	if b.Fence(ctx) {
+		t.Fatal("Expected replica-b not to become leader while replica-a holds the lock")
+	}
+	if !a.IsLeader() || b.IsLeader() {
+		t.Errorf("IsLeader() = %v, %v; want true, false", a.IsLeader(), b.IsLeader())
+	}
+	if a.Token() != 1 {
+		t.Errorf("Token() = %d, want 1", a.Token())
+	}
+}
+
+func TestElector_StaleLeaderStepsDown(t *testing.T) {
+	db := setupTestDB(t)
+	ctx := context.Background()
+
+	a := newTestElector(t, db, "replica-a", 20*time.Millisecond)
+	b := newTestElector(t, db, "replica-b", time.Minute)
+
+	if !a.Fence(ctx) {
+		t.Fatal("Expected replica-a to become leader")
+	}
+
+	// replica-a stops renewing (e.g. a long pause) and replica-b takes over
+	time.Sleep(40 * time.Millisecond)
+	if !b.Fence(ctx) {
+		t.Fatal("Expected replica-b to take over the expired lock")
+	}
+	if b.Token() != 2 {
+		t.Errorf("replica-b Token() = %d, want 2", b.Token())
+	}
+
+	// replica-a still believes it is the leader until it checks its fence
+	if !a.IsLeader() {
+		t.Fatal("Expected replica-a to still hold its stale token")
+	}
+	if a.Fence(ctx) {
+		t.Error("Expected the stale leader's fence to fail")
+	}
+	if a.IsLeader() {
+		t.Error("Expected replica-a to step down")
+	}
+}
+
+func TestElector_RunReleasesOnShutdown(t *testing.T) {
+	db := setupTestDB(t)
+
+	a := newTestElector(t, db, "replica-a", time.Minute)
+	b := newTestElector(t, db, "replica-b", time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		a.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for !a.IsLeader() && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !a.IsLeader() {
+		t.Fatal("Expected replica-a to become leader")
+	}
+
+	cancel()
+	<-done
+
+	if a.IsLeader() {
+		t.Error("Expected replica-a to give up leadership on shutdown")
+	}
+	// Let the release timestamp fall behind the next claim
+	time.Sleep(5 * time.Millisecond)
+	if !b.Fence(context.Background()) {
+		t.Error("Expected replica-b to take over immediately after release")
+	}
+}
@@ -8,10 +8,16 @@ import (
 	"strings"
 	"time"
 
+	batchpkg "github.com/kuhlman-labs/github-migrator/internal/batch"
+	"github.com/kuhlman-labs/github-migrator/internal/calendar"
+	"github.com/kuhlman-labs/github-migrator/internal/forecast"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// mcpApprovalActor is recorded in the approval log when an approved batch is started through MCP
+const mcpApprovalActor = "mcp"
+
 // Migration status constants for checking batch progress
 const (
 	StatusDryRunQueued       = string(models.StatusDryRunQueued)
@@ -707,6 +713,13 @@ func (s *Server) handleScheduleBatch(ctx context.Context, req mcp.CallToolReques
 		return mcp.NewToolResultError(fmt.Sprintf("Batch not found: %s", batchName)), nil
 	}
 
+	// Times inside a blackout, or outside the allowed windows, move to the next time migrations are allowed
+	requestedAt := scheduledAt
+	scheduledAt, err = calendar.ScheduleBatch(ctx, s.db, batch.ID, scheduledAt)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Cannot schedule batch '%s': %v", batchName, err)), nil
+	}
+
 	// Update batch with scheduled time
 	batch.ScheduledAt = &scheduledAt
 	batch.Status = "scheduled"
@@ -728,6 +741,10 @@ func (s *Server) handleScheduleBatch(ctx context.Context, req mcp.CallToolReques
 		Success: true,
 		Message: fmt.Sprintf("Batch '%s' scheduled for %s", batchName, scheduledAt.Format("2006-01-02 15:04:05 MST")),
 	}
+	if !scheduledAt.Equal(requestedAt) {
+		output.Message += fmt.Sprintf(" (deferred from %s, which is outside the migration windows)",
+			requestedAt.Format("2006-01-02 15:04:05 MST"))
+	}
 
 	return s.jsonResult(output)
 }
@@ -741,13 +758,16 @@ func (s *Server) handleConfigureBatch(ctx context.Context, req mcp.CallToolReque
 	// Extract settings to configure
 	destinationOrg := req.GetString("destination_org", "")
 	migrationAPI := req.GetString("migration_api", "")
+	dependsOn := req.GetString("depends_on_batch", "")
+	_, hasMaxFailures := req.GetArguments()["max_dependency_failures"]
+	maxFailures := req.GetInt("max_dependency_failures", -1)
 
 	if batchName == "" && batchID == 0 {
 		return mcp.NewToolResultError("batch_name or batch_id is required"), nil
 	}
 
-	if destinationOrg == "" && migrationAPI == "" {
-		return mcp.NewToolResultError("At least one setting must be specified (destination_org or migration_api)"), nil
+	if destinationOrg == "" && migrationAPI == "" && dependsOn == "" && !hasMaxFailures {
+		return mcp.NewToolResultError("At least one setting must be specified (destination_org, migration_api, depends_on_batch or max_dependency_failures)"), nil
 	}
 
 	// Find batch
@@ -780,12 +800,44 @@ func (s *Server) handleConfigureBatch(ctx context.Context, req mcp.CallToolReque
 	}
 	if migrationAPI != "" {
 		migrationAPI = strings.ToUpper(migrationAPI)
-		if migrationAPI != models.MigrationAPIGEI && migrationAPI != models.MigrationAPIELM {
-			return mcp.NewToolResultError(fmt.Sprintf("Invalid migration_api '%s'. Must be 'GEI' or 'ELM'", migrationAPI)), nil
+		if !models.IsValidMigrationAPI(migrationAPI) {
+			return mcp.NewToolResultError(fmt.Sprintf("Invalid migration_api '%s'. Must be 'GEI', 'ELM' or 'GIT'", migrationAPI)), nil
 		}
 		batch.MigrationAPI = migrationAPI
 		changes = append(changes, fmt.Sprintf("migration API set to '%s'", migrationAPI))
 	}
+	if dependsOn != "" {
+		if strings.EqualFold(dependsOn, "none") {
+			batch.DependsOnBatchID = nil
+			batch.MaxDependencyFailures = nil
+			changes = append(changes, "dependency removed")
+		} else {
+			var dependency *models.Batch
+			for _, b := range batches {
+				if b.Name == dependsOn {
+					dependency = b
+					break
+				}
+			}
+			if dependency == nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Batch not found: %s", dependsOn)), nil
+			}
+			batch.DependsOnBatchID = &dependency.ID
+			changes = append(changes, fmt.Sprintf("starts after batch '%s' completes", dependency.Name))
+		}
+	}
+	if hasMaxFailures {
+		if maxFailures < 0 {
+			batch.MaxDependencyFailures = nil
+			changes = append(changes, "no limit on failures in the batch it depends on")
+		} else {
+			batch.MaxDependencyFailures = &maxFailures
+			changes = append(changes, fmt.Sprintf("at most %d failures allowed in the batch it depends on", maxFailures))
+		}
+	}
+	if err := batchpkg.ValidateDependency(ctx, s.db, batch); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid dependency: %v", err)), nil
+	}
 
 	if err := s.db.UpdateBatch(ctx, batch); err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to update batch: %v", err)), nil
@@ -887,6 +939,12 @@ func (s *Server) handleStartMigration(ctx context.Context, req mcp.CallToolReque
 			return mcp.NewToolResultError(fmt.Sprintf("Batch '%s' has no repositories", batch.Name)), nil
 		}
 
+		if !dryRun && s.requireBatchApproval {
+			if err := batchpkg.UseApproval(ctx, s.db, batch, mcpApprovalActor); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Cannot start batch: %v", err)), nil
+			}
+		}
+
 		// Update batch status
 		batch.Status = models.BatchStatusInProgress
 		now := time.Now()
@@ -1181,6 +1239,85 @@ func (s *Server) handleGetMigrationProgress(ctx context.Context, req mcp.CallToo
 	return mcp.NewToolResultError("At least one of batch_name, batch_id, or repository must be specified"), nil
 }
 
+// handleForecastMigrationDuration implements the forecast_migration_duration tool
+func (s *Server) handleForecastMigrationDuration(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	batchName := req.GetString("batch_name", "")
+	batchID := int64(req.GetInt("batch_id", 0))
+	workers := req.GetInt("workers", 0)
+	repoNames := req.GetStringSlice("repositories", nil)
+
+	output := ForecastMigrationDurationOutput{}
+	var repos []*models.Repository
+
+	switch {
+	case batchName != "" || batchID != 0:
+		batches, err := s.db.ListBatches(ctx)
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to list batches: %v", err)), nil
+		}
+
+		var batch *models.Batch
+		for _, b := range batches {
+			if (batchName != "" && b.Name == batchName) || (batchID != 0 && b.ID == batchID) {
+				batch = b
+				break
+			}
+		}
+
+		if batch == nil {
+			searchTerm := batchName
+			if batchID != 0 {
+				searchTerm = fmt.Sprintf("ID %d", batchID)
+			}
+			return mcp.NewToolResultError(fmt.Sprintf("Batch not found: %s", searchTerm)), nil
+		}
+
+		repos, err = s.db.ListRepositories(ctx, map[string]any{
+			"batch_id":        batch.ID,
+			"include_details": true,
+		})
+		if err != nil {
+			return mcp.NewToolResultError(fmt.Sprintf("Failed to get batch repositories: %v", err)), nil
+		}
+		output.BatchID = batch.ID
+		output.BatchName = batch.Name
+
+	case len(repoNames) > 0:
+		for _, name := range repoNames {
+			repo, err := s.db.GetRepository(ctx, name)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("Failed to get repository %s: %v", name, err)), nil
+			}
+			if repo == nil {
+				output.NotFound = append(output.NotFound, name)
+				continue
+			}
+			repos = append(repos, repo)
+		}
+
+	default:
+		return mcp.NewToolResultError("At least one of batch_name, batch_id, or repositories must be specified"), nil
+	}
+
+	result, err := forecast.NewForecaster(s.db).Forecast(ctx, repos, workers)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to forecast migration duration: %v", err)), nil
+	}
+	output.Forecast = result
+
+	basis := "the uncalibrated cost model"
+	if result.CalibrationSamples > 0 {
+		basis = fmt.Sprintf("%d past migrations", result.CalibrationSamples)
+	}
+	output.Message = fmt.Sprintf("%d repositories to migrate, estimated %.1f hours on %d workers (%.1f hours of migration time), based on %s",
+		result.Repositories, result.WallClock().Hours(), result.Workers, float64(result.TotalSeconds)/3600, basis)
+	if output.BatchName != "" {
+		output.Message = fmt.Sprintf("Batch '%s': %s", output.BatchName, output.Message)
+	}
+
+	return s.jsonResult(output)
+}
+
 // canQueueForMigration checks if a repository can be queued for migration
 func canQueueForMigration(status string, dryRun bool) bool {
 	switch models.MigrationStatus(status) {
@@ -21,12 +21,16 @@ type Server struct {
 	addr      string
 	mu        sync.RWMutex
 	running   bool
+
+	requireBatchApproval bool
 }
 
 // Config holds configuration for the MCP server
 type Config struct {
 	// Address to listen on (e.g., ":8081")
 	Address string
+	// RequireBatchApproval refuses production batch starts without an approved request
+	RequireBatchApproval bool
 }
 
 // NewServer creates a new MCP server with migration tools
@@ -48,7 +52,8 @@ Key capabilities:
 - Find good candidates for pilot migrations
 - Create and schedule migration batches
 - Plan migration waves that respect dependencies
-- Get team repositories and migration status`),
+- Get team repositories and migration status
+- Forecast how long a batch or set of repositories will take to migrate`),
 	)
 
 	s := &Server{
@@ -56,6 +61,8 @@ Key capabilities:
 		db:        db,
 		logger:    logger,
 		addr:      cfg.Address,
+
+		requireBatchApproval: cfg.RequireBatchApproval,
 	}
 
 	// Register all migration tools
@@ -258,7 +265,7 @@ func (s *Server) registerTools() {
 	// schedule_batch - Schedule a batch for execution
 	s.mcpServer.AddTool(
 		mcp.NewTool("schedule_batch",
-			mcp.WithDescription("Schedule a batch for migration execution at a specific date/time."),
+			mcp.WithDescription("Schedule a batch for migration execution at a specific date/time. Times inside a blackout window, or outside the allowed migration windows, are deferred to the next time migrations are allowed, or refused if the blackout refuses batches."),
 			mcp.WithString("batch_name",
 				mcp.Required(),
 				mcp.Description("Name of the batch to schedule"),
@@ -274,7 +281,7 @@ func (s *Server) registerTools() {
 	// configure_batch - Configure batch settings
 	s.mcpServer.AddTool(
 		mcp.NewTool("configure_batch",
-			mcp.WithDescription("Configure batch settings including destination organization and migration API. Use this to set where repositories in a batch will be migrated to."),
+			mcp.WithDescription("Configure batch settings including destination organization, migration API and the batch it depends on. Use this to set where repositories in a batch will be migrated to, or to start it automatically after another batch completes."),
 			mcp.WithString("batch_name",
 				mcp.Description("Name of the batch to configure"),
 			),
@@ -285,7 +292,13 @@ func (s *Server) registerTools() {
 				mcp.Description("Destination organization within the configured enterprise where repositories will be migrated"),
 			),
 			mcp.WithString("migration_api",
-				mcp.Description("Migration API to use: 'GEI' (GitHub Enterprise Importer) or 'ELM' (Enterprise Live Migrator)"),
+				mcp.Description("Migration API to use: 'GEI' (GitHub Enterprise Importer), 'ELM' (Enterprise Live Migrator) or 'GIT' (git mirror push, history only)"),
+			),
+			mcp.WithString("depends_on_batch",
+				mcp.Description("Name of the batch that must complete its migration before the scheduler starts this one, or 'none' to remove the dependency"),
+			),
+			mcp.WithNumber("max_dependency_failures",
+				mcp.Description("Failed migrations allowed in the batch it depends on before this batch is blocked (negative for no limit)"),
 			),
 		),
 		s.handleConfigureBatch,
@@ -349,5 +362,26 @@ func (s *Server) registerTools() {
 		s.handleGetMigrationProgress,
 	)
 
-	s.logger.Info("Registered MCP tools", "count", 13)
+	// forecast_migration_duration - Estimate how long migrations will take
+	s.mcpServer.AddTool(
+		mcp.NewTool("forecast_migration_duration",
+			mcp.WithDescription("Estimate how long migrating a batch or list of repositories will take, from repository size, metadata size, issue and pull request counts, and the durations of past migrations. Projects the wall-clock time with the configured migration workers."),
+			mcp.WithString("batch_name",
+				mcp.Description("Name of batch to forecast"),
+			),
+			mcp.WithNumber("batch_id",
+				mcp.Description("ID of batch to forecast"),
+			),
+			mcp.WithArray("repositories",
+				mcp.Description("List of repositories to forecast (format: org/repo)"),
+				mcp.Items(map[string]any{"type": "string"}),
+			),
+			mcp.WithNumber("workers",
+				mcp.Description("Number of parallel migration workers to project with. Defaults to the configured worker count."),
+			),
+		),
+		s.handleForecastMigrationDuration,
+	)
+
+	s.logger.Info("Registered MCP tools", "count", 14)
 }
@@ -1,7 +1,12 @@
 package mcp
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/forecast"
 )
 
 func TestRepoToSummary(t *testing.T) {
@@ -101,6 +106,34 @@ func TestToolOutputTypes(t *testing.T) {
 			t.Errorf("Expected 2 waves, got %d", output.TotalWaves)
 		}
 	})
+
+	t.Run("ForecastMigrationDurationOutput includes the forecast", func(t *testing.T) {
+		output := ForecastMigrationDurationOutput{
+			BatchName: "wave-1",
+			Forecast: &forecast.Forecast{
+				Repositories:     2,
+				Workers:          1,
+				TotalSeconds:     5400,
+				WallClockSeconds: 5400,
+				Estimates: []*forecast.RepositoryForecast{
+					{FullName: "org/repo1", EstimatedSeconds: 3600, Basis: forecast.BasisHistory},
+					{FullName: "org/repo2", EstimatedSeconds: 1800, Basis: forecast.BasisModel},
+				},
+			},
+			Message: "Batch 'wave-1': 2 repositories to migrate, estimated 1.5 hours on 1 workers",
+		}
+
+		data, err := json.Marshal(output)
+		if err != nil {
+			t.Fatalf("Failed to marshal output: %v", err)
+		}
+		if !strings.Contains(string(data), `"wall_clock_seconds":5400`) || strings.Contains(string(data), "not_found") {
+			t.Errorf("Unexpected serialized output %s", data)
+		}
+		if output.Forecast.WallClock() != 90*time.Minute {
+			t.Errorf("Expected a wall clock of 90 minutes, got %v", output.Forecast.WallClock())
+		}
+	})
 }
 
 func TestInputTypes(t *testing.T) {
@@ -4,6 +4,8 @@ package mcp
 
 import (
 	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/forecast"
 )
 
 // Status constants for migration status checks
@@ -134,7 +136,7 @@ type ConfigureBatchInput struct {
 	BatchName      string `json:"batch_name,omitempty" jsonschema_description:"Name of the batch to configure"`
 	BatchID        int64  `json:"batch_id,omitempty" jsonschema_description:"ID of the batch to configure (alternative to batch_name)"`
 	DestinationOrg string `json:"destination_org,omitempty" jsonschema_description:"Destination organization within the configured enterprise"`
-	MigrationAPI   string `json:"migration_api,omitempty" jsonschema:"enum=GEI,enum=ELM" jsonschema_description:"Migration API to use: GEI or ELM"`
+	MigrationAPI   string `json:"migration_api,omitempty" jsonschema:"enum=GEI,enum=ELM,enum=GIT" jsonschema_description:"Migration API to use: GEI, ELM or GIT"`
 }
 
 // ------- Tool Output Types -------
@@ -284,3 +286,22 @@ type GetMigrationProgressOutput struct {
 	Repositories []RepositorySummary `json:"repositories,omitempty"`
 	Message      string              `json:"message"`
 }
+
+// ------- Forecasting Types -------
+
+// ForecastMigrationDurationInput is the input for forecast_migration_duration tool
+type ForecastMigrationDurationInput struct {
+	BatchName    string   `json:"batch_name,omitempty" jsonschema_description:"Name of batch to forecast"`
+	BatchID      int64    `json:"batch_id,omitempty" jsonschema_description:"ID of batch to forecast"`
+	Repositories []string `json:"repositories,omitempty" jsonschema_description:"List of repositories to forecast"`
+	Workers      int      `json:"workers,omitempty" jsonschema_description:"Number of parallel migration workers to project with"`
+}
+
+// ForecastMigrationDurationOutput is the output for forecast_migration_duration tool
+type ForecastMigrationDurationOutput struct {
+	BatchID   int64              `json:"batch_id,omitempty"`
+	BatchName string             `json:"batch_name,omitempty"`
+	Forecast  *forecast.Forecast `json:"forecast"`
+	NotFound  []string           `json:"not_found,omitempty"`
+	Message   string             `json:"message"`
+}
@@ -0,0 +1,184 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// ELM migration states reported by the Enterprise Live Migrator API
+const (
+	ELMStateQueued          = "queued"
+	ELMStateSyncing         = "syncing"
+	ELMStateReadyForCutover = "ready_for_cutover"
+	ELMStateCuttingOver     = "cutting_over"
+	ELMStateCompleted       = "completed"
+	ELMStateFailed          = "failed"
+	ELMStateCancelled       = "cancelled"
+)
+
+// ELMClient is a minimal client for the Enterprise Live Migrator (ELM) REST API.
+//
+// ELM keeps the destination repository continuously synchronized with the source
+// until a cutover is requested, at which point the source is locked, the final
+// delta is applied, and the destination becomes the system of record:
+//
+//	POST {base}/migrations               - start a live migration
+//	GET  {base}/migrations/{id}          - get migration state
+//	POST {base}/migrations/{id}/cutover  - request cutover once ready_for_cutover
+//	POST {base}/migrations/{id}/cancel   - abandon a migration (used for dry runs)
+type ELMClient struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// ELMClientConfig configures the ELM client
+type ELMClientConfig struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client // Optional: defaults to a client with a 60s timeout
+}
+
+// ELMStartRequest is the payload used to start an ELM migration
+type ELMStartRequest struct {
+	SourceRepositoryURL string `json:"source_repository_url"`
+	SourceToken         string `json:"source_token,omitempty"`
+	TargetOrganization  string `json:"target_organization"`
+	TargetRepository    string `json:"target_repository"`
+	TargetVisibility    string `json:"target_visibility"`
+	ExcludeReleases     bool   `json:"exclude_releases"`
+	ExcludeAttachments  bool   `json:"exclude_attachments"`
+}
+
+// ELMMigration is the migration resource returned by the ELM API
+type ELMMigration struct {
+	ID                  string `json:"id"`
+	State               string `json:"state"`
+	FailureReason       string `json:"failure_reason,omitempty"`
+	TargetRepositoryURL string `json:"target_repository_url,omitempty"`
+}
+
+// NewELMClient creates a new ELM API client
+func NewELMClient(cfg ELMClientConfig) (*ELMClient, error) {
+	if cfg.BaseURL == "" {
+		return nil, fmt.Errorf("ELM base URL is required")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	return &ELMClient{
+		baseURL:    strings.TrimSuffix(cfg.BaseURL, "/"),
+		token:      cfg.Token,
+		httpClient: httpClient,
+	}, nil
+}
+
+// StartMigration starts a live migration and returns the created migration
+func (c *ELMClient) StartMigration(ctx context.Context, req ELMStartRequest) (*ELMMigration, error) {
+	var migration ELMMigration
+	if err := c.do(ctx, http.MethodPost, "/migrations", req, &migration); err != nil {
+		return nil, fmt.Errorf("failed to start ELM migration: %w", err)
+	}
+	if migration.ID == "" {
+		return nil, fmt.Errorf("ELM returned a migration without an ID")
+	}
+	return &migration, nil
+}
+
+// GetMigration returns the current state of a migration
+func (c *ELMClient) GetMigration(ctx context.Context, id string) (*ELMMigration, error) {
+	var migration ELMMigration
+	if err := c.do(ctx, http.MethodGet, "/migrations/"+id, nil, &migration); err != nil {
+		return nil, fmt.Errorf("failed to get ELM migration %s: %w", id, err)
+	}
+	return &migration, nil
+}
+
+// Cutover requests the final cutover for a migration that is ready_for_cutover
+func (c *ELMClient) Cutover(ctx context.Context, id string) (*ELMMigration, error) {
+	var migration ELMMigration
+	if err := c.do(ctx, http.MethodPost, "/migrations/"+id+"/cutover", nil, &migration); err != nil {
+		return nil, fmt.Errorf("failed to cut over ELM migration %s: %w", id, err)
+	}
+	return &migration, nil
+}
+
+// Cancel abandons a migration without cutting over
+func (c *ELMClient) Cancel(ctx context.Context, id string) error {
+	if err := c.do(ctx, http.MethodPost, "/migrations/"+id+"/cancel", nil, nil); err != nil {
+		return fmt.Errorf("failed to cancel ELM migration %s: %w", id, err)
+	}
+	return nil
+}
+
+// do sends a JSON request to the ELM API and decodes the JSON response into out (if non-nil)
+func (c *ELMClient) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("ELM API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// MapELMStateToStatus maps an ELM migration state to the repository MigrationStatus
+// it should be reported as. Unknown states map to migrating_content since ELM only
+// reports terminal states explicitly.
+func MapELMStateToStatus(state string) models.MigrationStatus {
+	switch state {
+	case ELMStateQueued:
+		return models.StatusQueuedForMigration
+	case ELMStateSyncing, ELMStateReadyForCutover, ELMStateCuttingOver:
+		return models.StatusMigratingContent
+	case ELMStateCompleted:
+		return models.StatusMigrationComplete
+	case ELMStateFailed, ELMStateCancelled:
+		return models.StatusMigrationFailed
+	default:
+		return models.StatusMigratingContent
+	}
+}
@@ -7,15 +7,17 @@ import (
 	"time"
 
 	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
 
-// PostMigrationMode defines when to run post-migration validation/tasks
+// PostMigrationMode defines when to run post-migration validation
 type PostMigrationMode string
 
 const (
-	// PostMigrationNever - Never run post-migration validation/tasks
+	// PostMigrationNever - Never run post-migration validation
 	PostMigrationNever PostMigrationMode = "never"
 
 	// PostMigrationProductionOnly - Only run on production migrations (default)
@@ -91,9 +93,22 @@ type Executor struct {
 	migSourceCache       map[string]string // Cache of owner ID -> migration source ID for GitHub (supports multiple dest orgs)
 	adoMigSourceCache    map[string]string // Cache of ADO org URL -> migration source ID (supports multiple ADO orgs)
 	logger               *slog.Logger
-	postMigrationMode    PostMigrationMode           // When to run post-migration tasks
+	postMigrationMode    PostMigrationMode           // When to run post-migration validation
 	destRepoExistsAction DestinationRepoExistsAction // What to do if destination repo exists
 	visibilityHandling   VisibilityHandling          // How to handle visibility transformations
+	elmClient            *ELMClient                  // Enterprise Live Migrator client (nil when ELM is not configured)
+	sourceProvider       source.Provider             // Git provider for sources migrated by mirror push (nil for GEI sources)
+	gitlabClient         *gitlab.Client              // GitLab API client (nil for non-GitLab sources)
+	gitMirror            GitMirrorOptions            // Options for git-only mirror-push migrations
+	deepValidation       bool                        // Compare every ref, artifact counts and LFS objects in post-migration validation
+	referenceRewritePRs  bool                        // Open a pull request rewriting cross-repo references after migration
+	codeownersRewrite    CodeownersRewriteMode       // How CODEOWNERS is rewritten with the team and user mappings after migration
+	collaboratorGrants   CollaboratorGrantMode       // Which direct collaborator permissions are re-granted after migration
+	settingsSync         []string                    // Repository settings synced from the source after migration (empty: none)
+	protectionReplay     bool                        // Recreate branch protections and rulesets after production migrations
+	webhookReplay        bool                        // Recreate source webhooks, inactive, after production migrations
+	actionsReplay        bool                        // Recreate Actions environments, variables and placeholder secrets after production migrations
+	adoEndpoints         adoEndpoints                // Azure DevOps REST API hosts for completion actions on ADO sources
 }
 
 // ExecutorConfig configures the migration executor
@@ -104,9 +119,21 @@ type ExecutorConfig struct {
 	DestClient           *github.Client
 	Storage              *storage.Database
 	Logger               *slog.Logger
-	PostMigrationMode    PostMigrationMode           // When to run post-migration tasks (default: production_only)
+	PostMigrationMode    PostMigrationMode           // When to run post-migration validation (default: production_only)
 	DestRepoExistsAction DestinationRepoExistsAction // What to do if destination repo exists (default: fail)
 	VisibilityHandling   VisibilityHandling          // How to handle visibility transformations (default: all private)
+	ELMClient            *ELMClient                  // Optional: required only for batches using the ELM migration API
+	SourceProvider       source.Provider             // Optional: required for sources migrated by mirror push (e.g., GitLab)
+	GitLabClient         *gitlab.Client              // Optional: used to recreate GitLab project settings on the destination
+	GitMirror            GitMirrorOptions            // Optional: LFS and ref filter options for batches using the GIT migration API
+	DeepValidation       bool                        // Optional: run deep post-migration validation (default: false)
+	ReferenceRewritePRs  bool                        // Optional: open reference rewrite pull requests after migration (default: false)
+	CodeownersRewrite    CodeownersRewriteMode       // Optional: rewrite CODEOWNERS after migration (default: off)
+	CollaboratorGrants   CollaboratorGrantMode       // Optional: re-grant direct collaborator permissions after migration (default: off)
+	SettingsSync         []string                    // Optional: repository settings to sync from the source after migration (default: none)
+	ProtectionReplay     bool                        // Optional: recreate branch protections and rulesets after production migrations (default: false)
+	WebhookReplay        bool                        // Optional: recreate source webhooks, inactive, after production migrations (default: false)
+	ActionsReplay        bool                        // Optional: recreate Actions environments, variables and placeholder secrets after production migrations (default: false)
 }
 
 // ArchiveURLs contains the URLs for migration archives
@@ -156,6 +183,18 @@ func NewExecutor(cfg ExecutorConfig) (*Executor, error) {
 		visibilityHandling.InternalRepos = models.VisibilityPrivate
 	}
 
+	// Default to leaving CODEOWNERS unchanged if not specified
+	codeownersRewrite := cfg.CodeownersRewrite
+	if codeownersRewrite == "" {
+		codeownersRewrite = CodeownersRewriteOff
+	}
+
+	// Default to not re-granting collaborator permissions if not specified
+	collaboratorGrants := cfg.CollaboratorGrants
+	if collaboratorGrants == "" {
+		collaboratorGrants = CollaboratorGrantsOff
+	}
+
 	return &Executor{
 		sourceClient:         cfg.SourceClient,
 		sourceToken:          cfg.SourceToken,
@@ -169,6 +208,19 @@ func NewExecutor(cfg ExecutorConfig) (*Executor, error) {
 		postMigrationMode:    postMigMode,
 		destRepoExistsAction: destRepoAction,
 		visibilityHandling:   visibilityHandling,
+		elmClient:            cfg.ELMClient,
+		sourceProvider:       cfg.SourceProvider,
+		gitlabClient:         cfg.GitLabClient,
+		gitMirror:            cfg.GitMirror,
+		deepValidation:       cfg.DeepValidation,
+		referenceRewritePRs:  cfg.ReferenceRewritePRs,
+		codeownersRewrite:    codeownersRewrite,
+		collaboratorGrants:   collaboratorGrants,
+		settingsSync:         cfg.SettingsSync,
+		protectionReplay:     cfg.ProtectionReplay,
+		webhookReplay:        cfg.WebhookReplay,
+		actionsReplay:        cfg.ActionsReplay,
+		adoEndpoints:         defaultADOEndpoints,
 	}, nil
 }
 
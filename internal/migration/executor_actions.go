@@ -0,0 +1,349 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// Migration log operations for replayed Actions settings
+const (
+	opEnvironment     = "environment"
+	opActionsVariable = "actions_variable"
+	opActionsSecret   = "actions_secret"
+)
+
+// secretPlaceholderValue is the value of placeholder secrets created on the destination.
+// Workflows that use a placeholder fail visibly instead of silently running without the secret.
+const secretPlaceholderValue = "PLACEHOLDER: re-enter this secret's value from the source repository"
+
+// replayActionsSettings recreates the source repository's Actions environments, variables and
+// secrets on the destination. GEI migrates none of them. Environments are copied with their
+// protection rules and deployment branch policies, and variables with their values. Secret values
+// cannot be read from GitHub, so each secret is created as a named placeholder and recorded in the
+// repository's "secrets to re-enter" checklist. Dry runs are skipped, as is every migration when
+// the replay is turned off. Failures are logged and never fail the migration.
+func (e *Executor) replayActionsSettings(ctx context.Context, mc *MigrationContext) {
+	repo := mc.Repo
+	if !e.actionsReplay || mc.DryRun {
+		return
+	}
+	if e.sourceClient == nil {
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opEnvironment,
+			"Skipping Actions environment, variable and secret replay (source is not a GitHub repository)", nil)
+		return
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return
+	}
+
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+	mapper := &actorMapper{
+		e:         e,
+		sourceOrg: repo.Organization(),
+		destOrg:   destOrg,
+	}
+
+	environments, err := e.sourceClient.ListEnvironments(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opEnvironment, "Failed to list source environments", err)
+	}
+
+	// Environment variables and secrets are only copied for environments that now exist on the destination
+	var replayed []string
+	for _, environment := range environments {
+		if e.replayEnvironment(ctx, mc, mapper, environment, destOrg, destName) {
+			replayed = append(replayed, environment.GetName())
+		}
+	}
+
+	e.replayVariables(ctx, mc, destOrg, destName, replayed)
+	e.replaySecretPlaceholders(ctx, mc, destOrg, destName, replayed)
+}
+
+// replayEnvironment creates or updates an environment on the destination and copies its
+// custom deployment branch policies. It reports whether the environment was created.
+func (e *Executor) replayEnvironment(ctx context.Context, mc *MigrationContext, mapper *actorMapper, environment *ghapi.Environment, destOrg, destName string) bool {
+	repo := mc.Repo
+	name := environment.GetName()
+
+	request, dropped := environmentForDestination(environment,
+		func(login string) (int64, bool) { return mapper.userID(ctx, login) },
+		func(slug string) (int64, bool) { return mapper.teamIDBySlug(ctx, slug) })
+
+	if err := e.destClient.CreateUpdateEnvironment(ctx, destOrg, destName, name, request); err != nil {
+		e.warnReplay(ctx, mc, opEnvironment, fmt.Sprintf("Failed to create environment %s", name), err)
+		return false
+	}
+
+	if policy := environment.DeploymentBranchPolicy; policy != nil && policy.GetCustomBranchPolicies() {
+		e.replayDeploymentBranchPolicies(ctx, mc, name, destOrg, destName)
+	}
+
+	e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opEnvironment,
+		fmt.Sprintf("Created environment %s", name), droppedActorsDetails(dropped))
+	return true
+}
+
+// replayDeploymentBranchPolicies copies an environment's custom branch and tag policies.
+// Policies already present on the destination are skipped, so replays can be re-run.
+func (e *Executor) replayDeploymentBranchPolicies(ctx context.Context, mc *MigrationContext, environment, destOrg, destName string) {
+	repo := mc.Repo
+
+	policies, err := e.sourceClient.ListDeploymentBranchPolicies(ctx, repo.Organization(), repo.Name(), environment)
+	if err != nil {
+		e.warnReplay(ctx, mc, opEnvironment, fmt.Sprintf("Failed to list deployment branch policies of environment %s", environment), err)
+		return
+	}
+	existing, err := e.destClient.ListDeploymentBranchPolicies(ctx, destOrg, destName, environment)
+	if err != nil {
+		e.warnReplay(ctx, mc, opEnvironment, fmt.Sprintf("Failed to list destination deployment branch policies of environment %s", environment), err)
+		return
+	}
+
+	for _, policy := range missingBranchPolicies(policies, existing) {
+		request := &ghapi.DeploymentBranchPolicyRequest{Name: policy.Name, Type: policy.Type}
+		if err := e.destClient.CreateDeploymentBranchPolicy(ctx, destOrg, destName, environment, request); err != nil {
+			e.warnReplay(ctx, mc, opEnvironment,
+				fmt.Sprintf("Failed to create deployment %s policy %s on environment %s", policy.GetType(), policy.GetName(), environment), err)
+		}
+	}
+}
+
+// replayVariables copies repository and environment variables with their values.
+// Variables that already exist on the destination are overwritten with the source value.
+func (e *Executor) replayVariables(ctx context.Context, mc *MigrationContext, destOrg, destName string, environments []string) {
+	repo := mc.Repo
+	copied := 0
+
+	variables, err := e.sourceClient.ListRepoVariables(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opActionsVariable, "Failed to list source repository variables", err)
+	} else if len(variables) > 0 {
+		existing, err := e.destClient.ListRepoVariables(ctx, destOrg, destName)
+		if err != nil {
+			e.warnReplay(ctx, mc, opActionsVariable, "Failed to list destination repository variables", err)
+		} else {
+			exists := variableNames(existing)
+			for _, variable := range variables {
+				v := &ghapi.ActionsVariable{Name: variable.Name, Value: variable.Value}
+				if exists[variable.Name] {
+					err = e.destClient.UpdateRepoVariable(ctx, destOrg, destName, v)
+				} else {
+					err = e.destClient.CreateRepoVariable(ctx, destOrg, destName, v)
+				}
+				if err != nil {
+					e.warnReplay(ctx, mc, opActionsVariable, fmt.Sprintf("Failed to copy repository variable %s", variable.Name), err)
+					continue
+				}
+				copied++
+			}
+		}
+	}
+
+	for _, environment := range environments {
+		variables, err := e.sourceClient.ListEnvVariables(ctx, repo.Organization(), repo.Name(), environment)
+		if err != nil {
+			e.warnReplay(ctx, mc, opActionsVariable, fmt.Sprintf("Failed to list source variables of environment %s", environment), err)
+			continue
+		}
+		if len(variables) == 0 {
+			continue
+		}
+		existing, err := e.destClient.ListEnvVariables(ctx, destOrg, destName, environment)
+		if err != nil {
+			e.warnReplay(ctx, mc, opActionsVariable, fmt.Sprintf("Failed to list destination variables of environment %s", environment), err)
+			continue
+		}
+
+		exists := variableNames(existing)
+		for _, variable := range variables {
+			v := &ghapi.ActionsVariable{Name: variable.Name, Value: variable.Value}
+			if exists[variable.Name] {
+				err = e.destClient.UpdateEnvVariable(ctx, destOrg, destName, environment, v)
+			} else {
+				err = e.destClient.CreateEnvVariable(ctx, destOrg, destName, environment, v)
+			}
+			if err != nil {
+				e.warnReplay(ctx, mc, opActionsVariable,
+					fmt.Sprintf("Failed to copy variable %s of environment %s", variable.Name, environment), err)
+				continue
+			}
+			copied++
+		}
+	}
+
+	if copied > 0 {
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opActionsVariable,
+			fmt.Sprintf("Copied %d Actions variables", copied), nil)
+	}
+}
+
+// replaySecretPlaceholders creates a placeholder for every source Actions secret missing on the
+// destination and saves the repository's "secrets to re-enter" checklist. Existing destination
+// secrets are never overwritten, but are still listed so their values can be checked.
+func (e *Executor) replaySecretPlaceholders(ctx context.Context, mc *MigrationContext, destOrg, destName string, environments []string) {
+	repo := mc.Repo
+	var checklist []*models.RepositorySecret
+
+	names, err := e.sourceClient.ListRepoSecretNames(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opActionsSecret, "Failed to list source repository secrets", err)
+	} else if len(names) > 0 {
+		existing, err := e.destClient.ListRepoSecretNames(ctx, destOrg, destName)
+		if err != nil {
+			e.warnReplay(ctx, mc, opActionsSecret, "Failed to list destination repository secrets", err)
+		} else {
+			exists := nameSet(existing)
+			for _, name := range names {
+				secret := &models.RepositorySecret{Name: name}
+				if !exists[name] {
+					if err := e.destClient.SetRepoSecret(ctx, destOrg, destName, name, secretPlaceholderValue); err != nil {
+						e.warnReplay(ctx, mc, opActionsSecret, fmt.Sprintf("Failed to create placeholder for repository secret %s", name), err)
+					} else {
+						secret.PlaceholderCreated = true
+					}
+				}
+				checklist = append(checklist, secret)
+			}
+		}
+	}
+
+	if len(environments) > 0 {
+		checklist = append(checklist, e.replayEnvironmentSecretPlaceholders(ctx, mc, destOrg, destName, environments)...)
+	}
+
+	if err := e.storage.SaveRepositorySecrets(ctx, repo.ID, checklist); err != nil {
+		e.logger.Warn("Failed to save secrets checklist", "repo", repo.FullName, "error", err)
+		return
+	}
+	if len(checklist) > 0 {
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opActionsSecret,
+			fmt.Sprintf("%d Actions secrets must be re-entered on the destination", len(checklist)), nil)
+	}
+}
+
+// replayEnvironmentSecretPlaceholders creates placeholders for the secrets of each replayed
+// environment and returns their checklist entries. Environment secrets are addressed by repository ID.
+func (e *Executor) replayEnvironmentSecretPlaceholders(ctx context.Context, mc *MigrationContext, destOrg, destName string, environments []string) []*models.RepositorySecret {
+	repo := mc.Repo
+
+	sourceRepo, err := e.sourceClient.GetRepository(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opActionsSecret, "Failed to look up the source repository for environment secrets", err)
+		return nil
+	}
+	destRepo, err := e.destClient.GetRepository(ctx, destOrg, destName)
+	if err != nil {
+		e.warnReplay(ctx, mc, opActionsSecret, "Failed to look up the destination repository for environment secrets", err)
+		return nil
+	}
+
+	var checklist []*models.RepositorySecret
+	for _, environment := range environments {
+		names, err := e.sourceClient.ListEnvSecretNames(ctx, sourceRepo.GetID(), environment)
+		if err != nil {
+			e.warnReplay(ctx, mc, opActionsSecret, fmt.Sprintf("Failed to list source secrets of environment %s", environment), err)
+			continue
+		}
+		if len(names) == 0 {
+			continue
+		}
+		existing, err := e.destClient.ListEnvSecretNames(ctx, destRepo.GetID(), environment)
+		if err != nil {
+			e.warnReplay(ctx, mc, opActionsSecret, fmt.Sprintf("Failed to list destination secrets of environment %s", environment), err)
+			continue
+		}
+
+		exists := nameSet(existing)
+		for _, name := range names {
+			secret := &models.RepositorySecret{Environment: environment, Name: name}
+			if !exists[name] {
+				if err := e.destClient.SetEnvSecret(ctx, destRepo.GetID(), environment, name, secretPlaceholderValue); err != nil {
+					e.warnReplay(ctx, mc, opActionsSecret,
+						fmt.Sprintf("Failed to create placeholder for secret %s of environment %s", name, environment), err)
+				} else {
+					secret.PlaceholderCreated = true
+				}
+			}
+			checklist = append(checklist, secret)
+		}
+	}
+
+	return checklist
+}
+
+// environmentForDestination converts a source environment into a request for the destination.
+// Required reviewers are remapped with mapUserID and mapTeamID; reviewers without a mapping are
+// left out and returned as dropped.
+func environmentForDestination(environment *ghapi.Environment, mapUserID, mapTeamID func(string) (int64, bool)) (*ghapi.CreateUpdateEnvironment, []string) {
+	var dropped []string
+	request := &ghapi.CreateUpdateEnvironment{
+		CanAdminsBypass:        environment.CanAdminsBypass,
+		DeploymentBranchPolicy: environment.DeploymentBranchPolicy,
+	}
+
+	for _, rule := range environment.ProtectionRules {
+		switch rule.GetType() {
+		case "wait_timer":
+			request.WaitTimer = rule.WaitTimer
+		case "required_reviewers":
+			request.PreventSelfReview = rule.PreventSelfReview
+			for _, reviewer := range rule.Reviewers {
+				switch r := reviewer.Reviewer.(type) {
+				case *ghapi.User:
+					if id, ok := mapUserID(r.GetLogin()); ok {
+						request.Reviewers = append(request.Reviewers, &ghapi.EnvReviewers{Type: ghapi.Ptr("User"), ID: ghapi.Ptr(id)})
+					} else {
+						dropped = append(dropped, "user "+r.GetLogin())
+					}
+				case *ghapi.Team:
+					if id, ok := mapTeamID(r.GetSlug()); ok {
+						request.Reviewers = append(request.Reviewers, &ghapi.EnvReviewers{Type: ghapi.Ptr("Team"), ID: ghapi.Ptr(id)})
+					} else {
+						dropped = append(dropped, "team "+r.GetSlug())
+					}
+				}
+			}
+		}
+	}
+
+	return request, dropped
+}
+
+// missingBranchPolicies returns the source deployment branch policies not yet on the destination
+func missingBranchPolicies(source, existing []*ghapi.DeploymentBranchPolicy) []*ghapi.DeploymentBranchPolicy {
+	present := make(map[string]bool, len(existing))
+	for _, policy := range existing {
+		present[policy.GetType()+":"+policy.GetName()] = true
+	}
+
+	var missing []*ghapi.DeploymentBranchPolicy
+	for _, policy := range source {
+		if !present[policy.GetType()+":"+policy.GetName()] {
+			missing = append(missing, policy)
+		}
+	}
+	return missing
+}
+
+// variableNames returns the set of variable names
+func variableNames(variables []*ghapi.ActionsVariable) map[string]bool {
+	names := make(map[string]bool, len(variables))
+	for _, variable := range variables {
+		names[variable.Name] = true
+	}
+	return names
+}
+
+// nameSet returns the set of the given names
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
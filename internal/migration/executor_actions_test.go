@@ -0,0 +1,185 @@
+package migration
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func TestEnvironmentForDestination(t *testing.T) {
+	environment := &ghapi.Environment{
+		Name:            ghapi.Ptr("production"),
+		CanAdminsBypass: ghapi.Ptr(false),
+		DeploymentBranchPolicy: &ghapi.BranchPolicy{
+			ProtectedBranches:    ghapi.Ptr(false),
+			CustomBranchPolicies: ghapi.Ptr(true),
+		},
+		ProtectionRules: []*ghapi.ProtectionRule{
+			{Type: ghapi.Ptr("wait_timer"), WaitTimer: ghapi.Ptr(30)},
+			{
+				Type:              ghapi.Ptr("required_reviewers"),
+				PreventSelfReview: ghapi.Ptr(true),
+				Reviewers: []*ghapi.RequiredReviewer{
+					{Type: ghapi.Ptr("User"), Reviewer: &ghapi.User{Login: ghapi.Ptr("alice")}},
+					{Type: ghapi.Ptr("User"), Reviewer: &ghapi.User{Login: ghapi.Ptr("bob")}},
+					{Type: ghapi.Ptr("Team"), Reviewer: &ghapi.Team{Slug: ghapi.Ptr("release-managers")}},
+				},
+			},
+			{Type: ghapi.Ptr("branch_policy")},
+		},
+	}
+
+	users := map[string]int64{"alice": 101}
+	teams := map[string]int64{"release-managers": 202}
+	request, dropped := environmentForDestination(environment,
+		func(login string) (int64, bool) { id, ok := users[login]; return id, ok },
+		func(slug string) (int64, bool) { id, ok := teams[slug]; return id, ok })
+
+	if request.GetWaitTimer() != 30 || request.GetCanAdminsBypass() || !request.GetPreventSelfReview() {
+		t.Errorf("unexpected environment settings %+v", request)
+	}
+	if !request.DeploymentBranchPolicy.GetCustomBranchPolicies() {
+		t.Error("expected the custom deployment branch policy setting to be copied")
+	}
+	if len(request.Reviewers) != 2 {
+		t.Fatalf("expected the mapped user and team reviewers, got %d", len(request.Reviewers))
+	}
+	if request.Reviewers[0].GetType() != "User" || request.Reviewers[0].GetID() != 101 {
+		t.Errorf("unexpected user reviewer %+v", request.Reviewers[0])
+	}
+	if request.Reviewers[1].GetType() != "Team" || request.Reviewers[1].GetID() != 202 {
+		t.Errorf("unexpected team reviewer %+v", request.Reviewers[1])
+	}
+	if want := []string{"user bob"}; !reflect.DeepEqual(dropped, want) {
+		t.Errorf("dropped = %v, want %v", dropped, want)
+	}
+}
+
+func TestEnvironmentForDestination_Unprotected(t *testing.T) {
+	request, dropped := environmentForDestination(&ghapi.Environment{Name: ghapi.Ptr("staging")},
+		func(string) (int64, bool) { return 0, false },
+		func(string) (int64, bool) { return 0, false })
+
+	if request.WaitTimer != nil || request.Reviewers != nil || request.DeploymentBranchPolicy != nil {
+		t.Errorf("expected an environment without protection rules, got %+v", request)
+	}
+	if len(dropped) != 0 {
+		t.Errorf("dropped = %v, want none", dropped)
+	}
+}
+
+func TestMissingBranchPolicies(t *testing.T) {
+	source := []*ghapi.DeploymentBranchPolicy{
+		{Name: ghapi.Ptr("main"), Type: ghapi.Ptr("branch")},
+		{Name: ghapi.Ptr("release/*"), Type: ghapi.Ptr("branch")},
+		{Name: ghapi.Ptr("v*"), Type: ghapi.Ptr("tag")},
+	}
+	existing := []*ghapi.DeploymentBranchPolicy{
+		{Name: ghapi.Ptr("main"), Type: ghapi.Ptr("branch")},
+		{Name: ghapi.Ptr("release/*"), Type: ghapi.Ptr("tag")},
+	}
+
+	missing := missingBranchPolicies(source, existing)
+	if len(missing) != 2 || missing[0].GetName() != "release/*" || missing[1].GetName() != "v*" {
+		t.Errorf("unexpected missing policies %+v", missing)
+	}
+}
+
+// setupReplayTest returns an executor whose source and destination clients count the repository
+// requests they send to a server that answers every request with an empty list
+func setupReplayTest(t *testing.T, cfg ExecutorConfig) (*Executor, *MigrationContext, *atomic.Int32) {
+	t.Helper()
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/repos/") {
+			requests.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := github.NewClient(github.ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: github.DefaultRetryConfig(),
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	cfg.SourceClient = client
+	cfg.DestClient = client
+	cfg.Storage = db
+	cfg.Logger = logger
+	executor, err := NewExecutor(cfg)
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+
+	destFullName := "dest-org/repo"
+	repo := createTestRepository("source-org/repo")
+	repo.DestinationFullName = &destFullName
+	if err := db.SaveRepository(context.Background(), repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+
+	return executor, &MigrationContext{Repo: repo}, &requests
+}
+
+func TestReplayActionsSettings_Skipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		dryRun  bool
+	}{
+		{name: "dry run", enabled: true, dryRun: true},
+		{name: "turned off", enabled: false, dryRun: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor, mc, requests := setupReplayTest(t, ExecutorConfig{ActionsReplay: tt.enabled})
+			mc.DryRun = tt.dryRun
+
+			executor.replayActionsSettings(context.Background(), mc)
+
+			if n := requests.Load(); n != 0 {
+				t.Errorf("Expected no repository requests, got %d", n)
+			}
+		})
+	}
+
+	t.Run("production migration", func(t *testing.T) {
+		executor, mc, requests := setupReplayTest(t, ExecutorConfig{ActionsReplay: true})
+
+		executor.replayActionsSettings(context.Background(), mc)
+
+		if requests.Load() == 0 {
+			t.Error("Expected the source environments to be listed")
+		}
+	})
+}
@@ -140,7 +140,7 @@ func (e *Executor) ExecuteADOMigration(ctx context.Context, repo *models.Reposit
 		e.logger.Info("Running post-migration validation", "repo", repo.FullName)
 		e.logOperation(ctx, repo, historyID, "INFO", "post_migration", "validate", "Running post-migration validation", nil)
 
-		if err := e.validatePostMigration(ctx, repo); err != nil {
+		if err := e.validatePostMigration(ctx, repo, batch, nil); err != nil {
 			errMsg := err.Error()
 			e.logOperation(ctx, repo, historyID, "ERROR", "post_migration", "validate", "Post-migration validation failed", &errMsg)
 			// Don't fail the entire migration, just log validation failure
@@ -0,0 +1,291 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// CodeownersRewriteMode defines how a rewritten CODEOWNERS file is applied to the destination
+type CodeownersRewriteMode string
+
+const (
+	// CodeownersRewriteOff - Leave CODEOWNERS unchanged (default)
+	CodeownersRewriteOff CodeownersRewriteMode = "off"
+
+	// CodeownersRewriteCommit - Commit the rewritten file to the default branch
+	CodeownersRewriteCommit CodeownersRewriteMode = "commit"
+
+	// CodeownersRewritePullRequest - Open a pull request with the rewritten file
+	CodeownersRewritePullRequest CodeownersRewriteMode = "pull_request"
+)
+
+// ParseCodeownersRewriteMode parses a CODEOWNERS rewrite mode, defaulting to off when empty
+func ParseCodeownersRewriteMode(mode string) (CodeownersRewriteMode, error) {
+	switch CodeownersRewriteMode(mode) {
+	case "", CodeownersRewriteOff:
+		return CodeownersRewriteOff, nil
+	case CodeownersRewriteCommit:
+		return CodeownersRewriteCommit, nil
+	case CodeownersRewritePullRequest:
+		return CodeownersRewritePullRequest, nil
+	default:
+		return "", fmt.Errorf("invalid CODEOWNERS rewrite mode %q: must be 'off', 'commit' or 'pull_request'", mode)
+	}
+}
+
+// opCodeowners is the migration log operation for the CODEOWNERS rewrite
+const opCodeowners = "codeowners"
+
+// codeownersRewriteBranch is the destination branch the rewritten file is committed to in pull request mode
+const codeownersRewriteBranch = "migration/rewrite-codeowners"
+
+// codeownersLocations are the CODEOWNERS locations in GitHub's order of precedence
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersOwner matches the owner tokens of a CODEOWNERS rule
+var codeownersOwner = regexp.MustCompile(`\S+`)
+
+// RewriteCodeowners rewrites the team and user handles in the migrated repository's CODEOWNERS
+// file with the team and user mappings, so review requests reach the destination teams. The
+// file is committed to the default branch or proposed in a pull request depending on mode.
+// Handles without a mapping are left unchanged and listed. The outcome is recorded for the
+// repository and returned, including failures.
+func (e *Executor) RewriteCodeowners(ctx context.Context, repo *models.Repository, mode CodeownersRewriteMode) (*models.CodeownersRewrite, error) {
+	record, err := e.rewriteCodeowners(ctx, repo, mode)
+	if err != nil {
+		errMsg := err.Error()
+		record.Status = models.CodeownersStatusFailed
+		record.Error = &errMsg
+	}
+
+	if saveErr := e.storage.SaveCodeownersRewrite(ctx, record); saveErr != nil {
+		return record, fmt.Errorf("failed to save CODEOWNERS rewrite: %w", saveErr)
+	}
+	return record, err
+}
+
+// rewriteCodeowners does the work of RewriteCodeowners. The returned record is never nil.
+func (e *Executor) rewriteCodeowners(ctx context.Context, repo *models.Repository, mode CodeownersRewriteMode) (*models.CodeownersRewrite, error) {
+	record := &models.CodeownersRewrite{RepositoryID: repo.ID, Mode: string(mode)}
+	if mode != CodeownersRewriteCommit && mode != CodeownersRewritePullRequest {
+		return record, fmt.Errorf("CODEOWNERS rewrite mode must be 'commit' or 'pull_request'")
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return record, fmt.Errorf("repository has no destination")
+	}
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+
+	destRepo, err := e.destClient.GetRepository(ctx, destOrg, destName)
+	if err != nil {
+		return record, fmt.Errorf("failed to get destination repository: %w", err)
+	}
+	baseBranch := destRepo.GetDefaultBranch()
+
+	var content, sha string
+	for _, location := range codeownersLocations {
+		var found bool
+		content, sha, found, err = e.destClient.GetFileContent(ctx, destOrg, destName, location, baseBranch)
+		if err != nil {
+			return record, fmt.Errorf("failed to read %s: %w", location, err)
+		}
+		if found {
+			record.Path = location
+			break
+		}
+	}
+	if record.Path == "" {
+		record.Status = models.CodeownersStatusNotFound
+		return record, nil
+	}
+
+	// Teams are looked up by the organization named in the handle
+	mappers := make(map[string]*actorMapper)
+	mapperFor := func(org string) *actorMapper {
+		key := strings.ToLower(org)
+		if mappers[key] == nil {
+			mappers[key] = &actorMapper{e: e, sourceOrg: org, destOrg: destOrg}
+		}
+		return mappers[key]
+	}
+	rewritten, count, unmapped := rewriteCodeownersContent(content, func(handle string) (string, bool) {
+		if org, slug, isTeam := strings.Cut(strings.TrimPrefix(handle, "@"), "/"); isTeam {
+			destSlug, ok := mapperFor(org).team(ctx, slug)
+			return "@" + destOrg + "/" + destSlug, ok
+		}
+		destLogin, ok := mapperFor(repo.Organization()).user(ctx, strings.TrimPrefix(handle, "@"))
+		return "@" + destLogin, ok
+	})
+	if len(unmapped) > 0 {
+		joined := strings.Join(unmapped, ",")
+		record.Unmapped = &joined
+	}
+	if count == 0 {
+		record.Status = models.CodeownersStatusNoChanges
+		return record, nil
+	}
+
+	change := destinationFileChange{
+		Path:    record.Path,
+		Content: rewritten,
+		SHA:     sha,
+		Message: "Rewrite CODEOWNERS handles for the destination",
+	}
+	if mode == CodeownersRewriteCommit {
+		if err := e.destClient.UpdateFile(ctx, destOrg, destName, change.Path, baseBranch, change.Message, change.Content, change.SHA); err != nil {
+			return record, fmt.Errorf("failed to commit %s: %w", change.Path, err)
+		}
+		record.Status = models.CodeownersStatusCommitted
+	} else {
+		pr, err := e.openFileChangesPR(ctx, destOrg, destName, baseBranch, codeownersRewriteBranch,
+			"Rewrite CODEOWNERS for the destination", codeownersPRBody(record.Path, count, unmapped),
+			[]destinationFileChange{change})
+		if err != nil {
+			return record, err
+		}
+		record.Status = models.CodeownersStatusPullRequest
+		record.PullRequestNumber = pr.Number
+		record.PullRequestURL = pr.HTMLURL
+	}
+
+	record.HandlesRewritten = count
+	return record, nil
+}
+
+// rewriteCodeownersContent replaces the @user and @org/team handles of every CODEOWNERS rule
+// using mapHandle, keeping patterns, comments, email owners and spacing as they are. It returns
+// the new content, the number of handles rewritten and the distinct handles without a mapping.
+func rewriteCodeownersContent(content string, mapHandle func(handle string) (string, bool)) (string, int, []string) {
+	type mapping struct {
+		handle string
+		ok     bool
+	}
+	cache := make(map[string]mapping)
+	unmappedSet := make(map[string]bool)
+	count := 0
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		ownersStart := codeownersPatternEnd(line)
+		owners := line[ownersStart:]
+		var b strings.Builder
+		last := 0
+		for _, loc := range codeownersOwner.FindAllStringIndex(owners, -1) {
+			token := owners[loc[0]:loc[1]]
+			if strings.HasPrefix(token, "#") {
+				break
+			}
+			if !strings.HasPrefix(token, "@") {
+				continue
+			}
+
+			key := strings.ToLower(token)
+			mapped, cached := cache[key]
+			if !cached {
+				mapped.handle, mapped.ok = mapHandle(token)
+				cache[key] = mapped
+			}
+			if !mapped.ok {
+				unmappedSet[token] = true
+				continue
+			}
+			if mapped.handle == token {
+				continue
+			}
+
+			b.WriteString(owners[last:loc[0]])
+			b.WriteString(mapped.handle)
+			last = loc[1]
+			count++
+		}
+		if last > 0 {
+			b.WriteString(owners[last:])
+			lines[i] = line[:ownersStart] + b.String()
+		}
+	}
+
+	unmapped := make([]string, 0, len(unmappedSet))
+	for handle := range unmappedSet {
+		unmapped = append(unmapped, handle)
+	}
+	sort.Strings(unmapped)
+
+	return strings.Join(lines, "\n"), count, unmapped
+}
+
+// codeownersPatternEnd returns the index just past the file pattern of a CODEOWNERS rule.
+// Patterns may contain backslash-escaped spaces.
+func codeownersPatternEnd(line string) int {
+	i := 0
+	for i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	for i < len(line) {
+		switch line[i] {
+		case '\\':
+			i += 2
+			continue
+		case ' ', '\t':
+			return i
+		}
+		i++
+	}
+	return len(line)
+}
+
+// codeownersPRBody describes the rewrite and the handles left unmapped
+func codeownersPRBody(path string, rewritten int, unmapped []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "This pull request was opened after migration. It rewrites %d team and user handles in `%s` with the team and user mappings, so review requests reach the destination owners.\n", rewritten, path)
+
+	if len(unmapped) > 0 {
+		b.WriteString("\n### Handles without a mapping\n\n")
+		b.WriteString("These handles were left unchanged. Map them and rewrite again, or edit them in this pull request:\n\n")
+		for _, handle := range unmapped {
+			fmt.Fprintf(&b, "- `%s`\n", handle)
+		}
+	}
+
+	return b.String()
+}
+
+// rewriteCodeownersAfterMigration rewrites CODEOWNERS as part of post-migration when enabled.
+// Failures are logged and never fail the migration.
+func (e *Executor) rewriteCodeownersAfterMigration(ctx context.Context, mc *MigrationContext) {
+	if e.codeownersRewrite == CodeownersRewriteOff || mc.DryRun {
+		return
+	}
+
+	record, err := e.RewriteCodeowners(ctx, mc.Repo, e.codeownersRewrite)
+	if err != nil {
+		e.warnReplay(ctx, mc, opCodeowners, "Failed to rewrite CODEOWNERS", err)
+		return
+	}
+
+	var message string
+	switch record.Status {
+	case models.CodeownersStatusCommitted:
+		message = fmt.Sprintf("Rewrote %d handles in %s", record.HandlesRewritten, record.Path)
+	case models.CodeownersStatusPullRequest:
+		message = fmt.Sprintf("Opened pull request #%d rewriting %d handles in %s", *record.PullRequestNumber, record.HandlesRewritten, record.Path)
+	case models.CodeownersStatusNotFound:
+		message = "No CODEOWNERS file to rewrite"
+	default:
+		message = "No CODEOWNERS handles needed rewriting"
+	}
+	var details *string
+	if record.Unmapped != nil {
+		unmapped := "Handles without a mapping: " + strings.ReplaceAll(*record.Unmapped, ",", ", ")
+		details = &unmapped
+	}
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "post_migration", opCodeowners, message, details)
+}
@@ -0,0 +1,82 @@
+package migration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRewriteCodeownersContent(t *testing.T) {
+	mappings := map[string]string{
+		"@platform/backend": "@acme/backend-team",
+		"@platform/docs":    "@acme/docs",
+		"@alice":            "@alice-acme",
+		"@bob":              "@bob",
+	}
+	var lookups []string
+	mapHandle := func(handle string) (string, bool) {
+		lookups = append(lookups, handle)
+		mapped, ok := mappings[handle]
+		return mapped, ok
+	}
+
+	content := strings.Join([]string{
+		"# Owners for the platform",
+		"*                     @platform/backend @alice",
+		"/docs/                @platform/docs  docs@example.com",
+		"/my\\ dir/@alice/     @carol @alice   # @platform/backend is not an owner here",
+		"",
+		"   # indented comment @alice",
+		"*.go\t@bob @platform/frontend",
+		"/vendor/",
+	}, "\n")
+	want := strings.Join([]string{
+		"# Owners for the platform",
+		"*                     @acme/backend-team @alice-acme",
+		"/docs/                @acme/docs  docs@example.com",
+		"/my\\ dir/@alice/     @carol @alice-acme   # @platform/backend is not an owner here",
+		"",
+		"   # indented comment @alice",
+		"*.go\t@bob @platform/frontend",
+		"/vendor/",
+	}, "\n")
+
+	got, count, unmapped := rewriteCodeownersContent(content, mapHandle)
+	if got != want {
+		t.Errorf("rewriteCodeownersContent() content =\n%s\nwant\n%s", got, want)
+	}
+	if count != 4 {
+		t.Errorf("rewriteCodeownersContent() count = %d, want 4", count)
+	}
+	if wantUnmapped := []string{"@carol", "@platform/frontend"}; !reflect.DeepEqual(unmapped, wantUnmapped) {
+		t.Errorf("rewriteCodeownersContent() unmapped = %v, want %v", unmapped, wantUnmapped)
+	}
+	if len(lookups) != 6 {
+		t.Errorf("Expected each distinct handle to be looked up once, got %v", lookups)
+	}
+}
+
+func TestParseCodeownersRewriteMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CodeownersRewriteMode
+		wantErr bool
+	}{
+		{"", CodeownersRewriteOff, false},
+		{"off", CodeownersRewriteOff, false},
+		{"commit", CodeownersRewriteCommit, false},
+		{"pull_request", CodeownersRewritePullRequest, false},
+		{"pr", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCodeownersRewriteMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseCodeownersRewriteMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCodeownersRewriteMode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// CollaboratorGrantMode defines which direct collaborator permissions are re-granted on the destination
+type CollaboratorGrantMode string
+
+const (
+	// CollaboratorGrantsOff - Do not re-grant collaborator permissions (default)
+	CollaboratorGrantsOff CollaboratorGrantMode = "off"
+
+	// CollaboratorGrantsAll - Re-grant organization members and outside collaborators
+	CollaboratorGrantsAll CollaboratorGrantMode = "all"
+
+	// CollaboratorGrantsMembersOnly - Re-grant organization members and skip outside collaborators,
+	// e.g. for EMU destinations whose enterprise does not allow them
+	CollaboratorGrantsMembersOnly CollaboratorGrantMode = "members_only"
+)
+
+// ParseCollaboratorGrantMode parses a collaborator grant mode, defaulting to off when empty
+func ParseCollaboratorGrantMode(mode string) (CollaboratorGrantMode, error) {
+	switch CollaboratorGrantMode(mode) {
+	case "", CollaboratorGrantsOff:
+		return CollaboratorGrantsOff, nil
+	case CollaboratorGrantsAll:
+		return CollaboratorGrantsAll, nil
+	case CollaboratorGrantsMembersOnly:
+		return CollaboratorGrantsMembersOnly, nil
+	default:
+		return "", fmt.Errorf("invalid collaborator grant mode %q: must be 'off', 'all' or 'members_only'", mode)
+	}
+}
+
+// opCollaborators is the migration log operation for re-granted collaborator permissions
+const opCollaborators = "collaborators"
+
+// GrantCollaborators re-grants the source repository's direct collaborator permissions on the
+// destination through the user mappings. Organization members are granted the permission
+// directly; other users, including outside collaborators, are invited. Outside collaborators are
+// skipped in members_only mode, and once the destination rejects one (EMU enterprises and
+// organizations can forbid them) the rest are skipped with the reason. Every collaborator's
+// outcome is logged for the repository, replacing any earlier grants, and returned.
+func (e *Executor) GrantCollaborators(ctx context.Context, repo *models.Repository, mode CollaboratorGrantMode) ([]*models.CollaboratorGrant, error) {
+	if mode != CollaboratorGrantsAll && mode != CollaboratorGrantsMembersOnly {
+		return nil, fmt.Errorf("collaborator grant mode must be 'all' or 'members_only'")
+	}
+	if e.sourceClient == nil {
+		return nil, fmt.Errorf("collaborators can only be granted for GitHub sources")
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return nil, fmt.Errorf("repository has no destination")
+	}
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+
+	collaborators, err := e.sourceClient.ListDirectCollaborators(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source collaborators: %w", err)
+	}
+
+	mapper := &actorMapper{e: e, sourceOrg: repo.Organization(), destOrg: destOrg}
+	var outsideRejected string
+	grants := make([]*models.CollaboratorGrant, 0, len(collaborators))
+	for _, collaborator := range collaborators {
+		grant := &models.CollaboratorGrant{
+			SourceLogin: collaborator.Login,
+			Permission:  collaborator.Permission,
+			Outside:     collaborator.Outside,
+		}
+		grants = append(grants, grant)
+
+		destLogin, ok := mapper.user(ctx, collaborator.Login)
+		if !ok {
+			grant.Status = models.CollaboratorGrantStatusUnmapped
+			continue
+		}
+		grant.DestinationLogin = &destLogin
+
+		switch {
+		case collaborator.Outside && mode == CollaboratorGrantsMembersOnly:
+			setCollaboratorGrantSkipped(grant, "Outside collaborators are not granted in members_only mode")
+			continue
+		case collaborator.Outside && outsideRejected != "":
+			setCollaboratorGrantSkipped(grant, outsideRejected)
+			continue
+		}
+
+		invited, err := e.destClient.AddCollaborator(ctx, destOrg, destName, destLogin, collaborator.Permission)
+		switch {
+		case err == nil && invited:
+			grant.Status = models.CollaboratorGrantStatusInvited
+		case err == nil:
+			grant.Status = models.CollaboratorGrantStatusGranted
+		case collaborator.Outside && isCollaboratorPolicyError(err):
+			outsideRejected = fmt.Sprintf("The destination does not allow outside collaborators (EMU or organization policy): %v", err)
+			setCollaboratorGrantSkipped(grant, outsideRejected)
+		default:
+			errMsg := err.Error()
+			grant.Status = models.CollaboratorGrantStatusFailed
+			grant.Reason = &errMsg
+		}
+	}
+
+	if err := e.storage.SaveCollaboratorGrants(ctx, repo.ID, grants); err != nil {
+		return grants, fmt.Errorf("failed to save collaborator grants: %w", err)
+	}
+	return grants, nil
+}
+
+// grantCollaboratorsAfterMigration re-grants collaborator permissions when configured. Every
+// grant is written to the migration log; failures never fail the migration.
+func (e *Executor) grantCollaboratorsAfterMigration(ctx context.Context, mc *MigrationContext) {
+	if e.collaboratorGrants == CollaboratorGrantsOff || mc.DryRun {
+		return
+	}
+	if e.sourceClient == nil {
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "post_migration", opCollaborators,
+			"Skipping collaborator grants (source is not a GitHub repository)", nil)
+		return
+	}
+
+	grants, err := e.GrantCollaborators(ctx, mc.Repo, e.collaboratorGrants)
+	if err != nil {
+		e.warnReplay(ctx, mc, opCollaborators, "Failed to grant collaborator permissions", err)
+		if grants == nil {
+			return
+		}
+	}
+
+	counts := make(map[string]int)
+	for _, grant := range grants {
+		counts[grant.Status]++
+		level := "INFO"
+		message := fmt.Sprintf("%s: %s (%s)", grant.SourceLogin, grant.Status, grant.Permission)
+		if grant.DestinationLogin != nil {
+			message = fmt.Sprintf("%s -> %s: %s (%s)", grant.SourceLogin, *grant.DestinationLogin, grant.Status, grant.Permission)
+		}
+		if grant.Status == models.CollaboratorGrantStatusFailed || grant.Status == models.CollaboratorGrantStatusUnmapped ||
+			grant.Status == models.CollaboratorGrantStatusSkipped {
+			level = "WARN"
+		}
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, level, "post_migration", opCollaborators, message, grant.Reason)
+	}
+
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "post_migration", opCollaborators,
+		fmt.Sprintf("Collaborator permissions: %d granted, %d invited, %d unmapped, %d skipped, %d failed",
+			counts[models.CollaboratorGrantStatusGranted], counts[models.CollaboratorGrantStatusInvited],
+			counts[models.CollaboratorGrantStatusUnmapped], counts[models.CollaboratorGrantStatusSkipped],
+			counts[models.CollaboratorGrantStatusFailed]), nil)
+}
+
+// isCollaboratorPolicyError reports whether the destination refused a collaborator because of
+// an enterprise or organization policy rather than a transient or per-user problem
+func isCollaboratorPolicyError(err error) bool {
+	var apiErr *github.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusForbidden || apiErr.StatusCode == http.StatusUnprocessableEntity
+}
+
+func setCollaboratorGrantSkipped(grant *models.CollaboratorGrant, reason string) {
+	grant.Status = models.CollaboratorGrantStatusSkipped
+	grant.Reason = &reason
+}
@@ -0,0 +1,233 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func TestParseCollaboratorGrantMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    CollaboratorGrantMode
+		wantErr bool
+	}{
+		{"", CollaboratorGrantsOff, false},
+		{"off", CollaboratorGrantsOff, false},
+		{"all", CollaboratorGrantsAll, false},
+		{"members_only", CollaboratorGrantsMembersOnly, false},
+		{"everyone", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCollaboratorGrantMode(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseCollaboratorGrantMode(%q) = %q, %v; want %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// fakeCollaboratorServer serves the source collaborators and records the destination grants
+type fakeCollaboratorServer struct {
+	mu            sync.Mutex
+	granted       map[string]string // destination login -> permission
+	rejectOutside bool              // Respond 422 to outside collaborators, as EMU enterprises do
+}
+
+func (f *fakeCollaboratorServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/source-org/repo/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("affiliation") == "outside" {
+			_, _ = w.Write([]byte(`[{"login":"vendor","role_name":"read"},{"login":"contractor","role_name":"triage"}]`))
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"login":"alice","role_name":"admin"},
+			{"login":"bob","role_name":"write"},
+			{"login":"ghost","role_name":"read"},
+			{"login":"vendor","role_name":"read"},
+			{"login":"contractor","role_name":"triage"}
+		]`))
+	})
+	mux.HandleFunc("PUT /api/v3/repos/dest-org/repo/collaborators/{user}", func(w http.ResponseWriter, r *http.Request) {
+		user := r.PathValue("user")
+		if f.rejectOutside && (user == "vendor-acme" || user == "contractor-acme") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Outside collaborators are not allowed"}`))
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.mu.Lock()
+		f.granted[user] = body["permission"]
+		f.mu.Unlock()
+		if user == "bob-acme" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"id":9}`))
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func setupCollaboratorTest(t *testing.T, fake *fakeCollaboratorServer) (*Executor, *storage.Database, *models.Repository) {
+	t.Helper()
+
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := github.NewClient(github.ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: github.DefaultRetryConfig(),
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, source := range []string{"alice", "bob", "vendor", "contractor"} {
+		destLogin := source + "-acme"
+		if err := db.SaveUserMapping(ctx, &models.UserMapping{SourceLogin: source, DestinationLogin: &destLogin, MappingStatus: "mapped"}); err != nil {
+			t.Fatalf("SaveUserMapping() error = %v", err)
+		}
+	}
+
+	executor, err := NewExecutor(ExecutorConfig{
+		SourceClient: client,
+		DestClient:   client,
+		Storage:      db,
+		Logger:       logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+
+	destFullName := "dest-org/repo"
+	repo := createTestRepository("source-org/repo")
+	repo.Status = string(models.StatusComplete)
+	repo.DestinationFullName = &destFullName
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to load repository: %v", err)
+	}
+
+	return executor, db, saved
+}
+
+func grantStatuses(grants []*models.CollaboratorGrant) map[string]string {
+	statuses := make(map[string]string, len(grants))
+	for _, grant := range grants {
+		statuses[grant.SourceLogin] = grant.Status
+	}
+	return statuses
+}
+
+func TestGrantCollaborators(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("all", func(t *testing.T) {
+		fake := &fakeCollaboratorServer{granted: make(map[string]string)}
+		executor, db, repo := setupCollaboratorTest(t, fake)
+
+		grants, err := executor.GrantCollaborators(ctx, repo, CollaboratorGrantsAll)
+		if err != nil {
+			t.Fatalf("GrantCollaborators() error = %v", err)
+		}
+
+		want := map[string]string{
+			"alice":      models.CollaboratorGrantStatusGranted,
+			"bob":        models.CollaboratorGrantStatusInvited,
+			"ghost":      models.CollaboratorGrantStatusUnmapped,
+			"vendor":     models.CollaboratorGrantStatusGranted,
+			"contractor": models.CollaboratorGrantStatusGranted,
+		}
+		for login, status := range grantStatuses(grants) {
+			if want[login] != status {
+				t.Errorf("%s: status = %s, want %s", login, status, want[login])
+			}
+		}
+		if fake.granted["alice-acme"] != "admin" || fake.granted["bob-acme"] != "push" || fake.granted["vendor-acme"] != "pull" {
+			t.Errorf("Unexpected destination permissions %v", fake.granted)
+		}
+
+		saved, err := db.GetCollaboratorGrants(ctx, repo.ID)
+		if err != nil || len(saved) != 5 {
+			t.Fatalf("Expected 5 logged grants, got %d (%v)", len(saved), err)
+		}
+	})
+
+	t.Run("members only", func(t *testing.T) {
+		fake := &fakeCollaboratorServer{granted: make(map[string]string)}
+		executor, _, repo := setupCollaboratorTest(t, fake)
+
+		grants, err := executor.GrantCollaborators(ctx, repo, CollaboratorGrantsMembersOnly)
+		if err != nil {
+			t.Fatalf("GrantCollaborators() error = %v", err)
+		}
+
+		statuses := grantStatuses(grants)
+		if statuses["vendor"] != models.CollaboratorGrantStatusSkipped || statuses["contractor"] != models.CollaboratorGrantStatusSkipped {
+			t.Errorf("Expected outside collaborators to be skipped, got %v", statuses)
+		}
+		if _, ok := fake.granted["vendor-acme"]; ok {
+			t.Error("Expected no grant for an outside collaborator")
+		}
+	})
+
+	t.Run("destination rejects outside collaborators", func(t *testing.T) {
+		fake := &fakeCollaboratorServer{granted: make(map[string]string), rejectOutside: true}
+		executor, _, repo := setupCollaboratorTest(t, fake)
+
+		grants, err := executor.GrantCollaborators(ctx, repo, CollaboratorGrantsAll)
+		if err != nil {
+			t.Fatalf("GrantCollaborators() error = %v", err)
+		}
+
+		for _, grant := range grants {
+			if !grant.Outside {
+				continue
+			}
+			if grant.Status != models.CollaboratorGrantStatusSkipped || grant.Reason == nil {
+				t.Errorf("%s: expected to be skipped with a reason, got %s", grant.SourceLogin, grant.Status)
+			}
+		}
+		if grantStatuses(grants)["alice"] != models.CollaboratorGrantStatusGranted {
+			t.Error("Expected organization members to still be granted")
+		}
+	})
+
+	t.Run("off is rejected", func(t *testing.T) {
+		executor, _, repo := setupCollaboratorTest(t, &fakeCollaboratorServer{granted: make(map[string]string)})
+		if _, err := executor.GrantCollaborators(ctx, repo, CollaboratorGrantsOff); err == nil {
+			t.Error("Expected an error for mode off")
+		}
+	})
+}
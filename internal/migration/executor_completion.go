@@ -0,0 +1,288 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// Completion actions a batch can run on the source repository after a successful production
+// migration, so developers stop pushing to it
+const (
+	CompletionActionReadmeNotice = "readme_notice" // Prepend a "moved to <destination>" banner to the README
+	CompletionActionDescription  = "description"   // Replace the description with a "moved to <destination>" notice
+	CompletionActionArchive      = "archive"       // Archive the repository, or make it read-only on Azure DevOps
+)
+
+// CompletionActions lists every completion action in the order they run. Archiving runs last
+// since an archived repository's README and description can no longer be changed.
+var CompletionActions = []string{
+	CompletionActionReadmeNotice,
+	CompletionActionDescription,
+	CompletionActionArchive,
+}
+
+const phaseCompletionActions = "completion"
+
+// movedNoticeMarker identifies a README that already has the moved banner
+const movedNoticeMarker = "This repository has moved to"
+
+// ParseCompletionActions validates a list of completion actions and returns it in run order without duplicates
+func ParseCompletionActions(actions []string) ([]string, error) {
+	selected := make(map[string]bool, len(actions))
+	for _, action := range actions {
+		action = strings.TrimSpace(action)
+		switch {
+		case action == "":
+			continue
+		case !slices.Contains(CompletionActions, action):
+			return nil, fmt.Errorf("invalid completion action %q: must be one of %s", action, strings.Join(CompletionActions, ", "))
+		}
+		selected[action] = true
+	}
+
+	var parsed []string
+	for _, action := range CompletionActions {
+		if selected[action] {
+			parsed = append(parsed, action)
+		}
+	}
+	return parsed, nil
+}
+
+// completionState is the source state a completion action changed, recorded so rollback can restore it
+type completionState struct {
+	// README notice
+	Branch  string `json:"branch,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Content string `json:"content,omitempty"`
+	Created bool   `json:"created,omitempty"` // The README did not exist and was created for the notice
+
+	// Description
+	Description *string `json:"description,omitempty"`
+
+	// Azure DevOps read-only lock: the Project Valid Users entry on the repository before it was denied write access
+	Token      string `json:"token,omitempty"`
+	Descriptor string `json:"descriptor,omitempty"`
+	Allow      int    `json:"allow,omitempty"`
+	Deny       int    `json:"deny,omitempty"`
+}
+
+// runCompletionActions runs the batch's completion actions on the source of a successfully migrated
+// repository and records them so rollback can revert them. Each action is logged; failures never
+// fail the migration.
+func (e *Executor) runCompletionActions(ctx context.Context, repo *models.Repository, batch *models.Batch, historyID *int64) {
+	actions, err := ParseCompletionActions(batch.CompletionActionList())
+	if err != nil {
+		errMsg := err.Error()
+		e.logOperation(ctx, repo, historyID, "WARN", phaseCompletionActions, "validate", "Skipping completion actions", &errMsg)
+		return
+	}
+	if len(actions) == 0 {
+		return
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		e.logOperation(ctx, repo, historyID, "WARN", phaseCompletionActions, "validate",
+			"Skipping completion actions (no destination repository recorded)", nil)
+		return
+	}
+
+	var results []*models.CompletionAction
+	switch {
+	case e.sourceClient != nil:
+		results = e.applyGitHubCompletionActions(ctx, repo, actions)
+	case isADORepository(repo):
+		results = e.applyADOCompletionActions(ctx, repo, actions)
+	default:
+		for _, action := range actions {
+			results = append(results, completionSkipped(action, "Not supported for this source"))
+		}
+	}
+
+	for _, result := range results {
+		level := "INFO"
+		message := fmt.Sprintf("%s: %s", result.Action, result.Status)
+		if result.Status == models.CompletionActionStatusFailed || result.Status == models.CompletionActionStatusSkipped {
+			level = "WARN"
+		}
+		e.logOperation(ctx, repo, historyID, level, phaseCompletionActions, result.Action, message, result.Reason)
+	}
+
+	if err := e.storage.SaveCompletionActions(ctx, repo.ID, results); err != nil {
+		errMsg := err.Error()
+		e.logger.Error("Failed to save completion actions", "repo", repo.FullName, "error", err)
+		e.logOperation(ctx, repo, historyID, "ERROR", phaseCompletionActions, "save",
+			"Failed to record completion actions - rollback will not revert them", &errMsg)
+	}
+}
+
+// applyGitHubCompletionActions runs completion actions on a GitHub source repository
+func (e *Executor) applyGitHubCompletionActions(ctx context.Context, repo *models.Repository, actions []string) []*models.CompletionAction {
+	owner, name := repo.Organization(), repo.Name()
+	results := make([]*models.CompletionAction, 0, len(actions))
+
+	source, err := e.sourceClient.GetRepository(ctx, owner, name)
+	if err != nil {
+		for _, action := range actions {
+			results = append(results, completionFailed(action, fmt.Errorf("failed to read source repository: %w", err)))
+		}
+		return results
+	}
+
+	for _, action := range actions {
+		if source.GetArchived() {
+			results = append(results, completionSkipped(action, "Source repository is already archived"))
+			continue
+		}
+
+		var result *models.CompletionAction
+		switch action {
+		case CompletionActionReadmeNotice:
+			result = e.applyReadmeNotice(ctx, repo, source.GetDefaultBranch())
+		case CompletionActionDescription:
+			original := source.GetDescription()
+			_, err := e.sourceClient.UpdateRepository(ctx, owner, name, &ghapi.Repository{
+				Description: ghapi.Ptr(fmt.Sprintf("%s %s", movedNoticeMarker, destinationURL(repo))),
+			})
+			result = completionResult(action, completionState{Description: &original}, err)
+		case CompletionActionArchive:
+			result = completionResult(action, completionState{}, e.sourceClient.ArchiveRepository(ctx, owner, name))
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// applyReadmeNotice prepends the moved banner to the source README, creating a README.md when there is none.
+// READMEs in formats other than Markdown are left alone.
+func (e *Executor) applyReadmeNotice(ctx context.Context, repo *models.Repository, branch string) *models.CompletionAction {
+	owner, name := repo.Organization(), repo.Name()
+	readmePath, content, sha, found, err := e.sourceClient.GetReadme(ctx, owner, name, branch)
+	if err != nil {
+		return completionFailed(CompletionActionReadmeNotice, fmt.Errorf("failed to read source README: %w", err))
+	}
+
+	notice := movedNotice(repo)
+	message := fmt.Sprintf("Add notice that the repository has moved to %s", *repo.DestinationFullName)
+	switch {
+	case !found:
+		state := completionState{Branch: branch, Path: "README.md", Created: true}
+		return completionResult(CompletionActionReadmeNotice, state,
+			e.sourceClient.CreateFile(ctx, owner, name, state.Path, branch, message, notice))
+	case !isMarkdownFile(readmePath):
+		return completionSkipped(CompletionActionReadmeNotice, fmt.Sprintf("README %s is not Markdown", readmePath))
+	case strings.Contains(content, movedNoticeMarker):
+		return completionSkipped(CompletionActionReadmeNotice, "README already has a moved notice")
+	}
+
+	state := completionState{Branch: branch, Path: readmePath, Content: content}
+	return completionResult(CompletionActionReadmeNotice, state,
+		e.sourceClient.UpdateFile(ctx, owner, name, readmePath, branch, message, notice+"\n"+content, sha))
+}
+
+// revertCompletionAction restores the source state changed by an applied completion action
+func (e *Executor) revertCompletionAction(ctx context.Context, repo *models.Repository, action *models.CompletionAction) error {
+	var state completionState
+	if action.OriginalState != nil {
+		if err := json.Unmarshal([]byte(*action.OriginalState), &state); err != nil {
+			return fmt.Errorf("failed to parse recorded source state: %w", err)
+		}
+	}
+	if state.Token != "" {
+		return e.unlockADORepository(ctx, repo, state)
+	}
+	if e.sourceClient == nil {
+		return fmt.Errorf("source does not support reverting %s", action.Action)
+	}
+
+	owner, name := repo.Organization(), repo.Name()
+	switch action.Action {
+	case CompletionActionReadmeNotice:
+		message := "Remove notice that the repository has moved"
+		_, sha, found, err := e.sourceClient.GetFileContent(ctx, owner, name, state.Path, state.Branch)
+		switch {
+		case err != nil:
+			return fmt.Errorf("failed to read source README: %w", err)
+		case !found && state.Created:
+			return nil
+		case !found:
+			return fmt.Errorf("source README %s no longer exists", state.Path)
+		case state.Created:
+			return e.sourceClient.DeleteFile(ctx, owner, name, state.Path, state.Branch, message, sha)
+		default:
+			return e.sourceClient.UpdateFile(ctx, owner, name, state.Path, state.Branch, message, state.Content, sha)
+		}
+	case CompletionActionDescription:
+		description := ""
+		if state.Description != nil {
+			description = *state.Description
+		}
+		_, err := e.sourceClient.UpdateRepository(ctx, owner, name, &ghapi.Repository{Description: ghapi.Ptr(description)})
+		return err
+	case CompletionActionArchive:
+		return e.sourceClient.UnarchiveRepository(ctx, owner, name)
+	default:
+		return fmt.Errorf("unknown completion action: %s", action.Action)
+	}
+}
+
+// movedNotice returns the Markdown banner added to the source README
+func movedNotice(repo *models.Repository) string {
+	return fmt.Sprintf("> [!IMPORTANT]\n> %s %s. This copy is kept for reference only; push changes and open issues and pull requests there.\n",
+		movedNoticeMarker, destinationLink(repo))
+}
+
+// destinationLink returns the destination repository as a Markdown link when its URL is known
+func destinationLink(repo *models.Repository) string {
+	if repo.DestinationURL != nil && *repo.DestinationURL != "" {
+		return fmt.Sprintf("[%s](%s)", *repo.DestinationFullName, *repo.DestinationURL)
+	}
+	return *repo.DestinationFullName
+}
+
+// destinationURL returns the destination repository's URL, or its full name when the URL is not known
+func destinationURL(repo *models.Repository) string {
+	if repo.DestinationURL != nil && *repo.DestinationURL != "" {
+		return *repo.DestinationURL
+	}
+	return *repo.DestinationFullName
+}
+
+func isMarkdownFile(file string) bool {
+	switch strings.ToLower(path.Ext(file)) {
+	case ".md", ".markdown", "":
+		return true
+	default:
+		return false
+	}
+}
+
+func isADORepository(repo *models.Repository) bool {
+	project := repo.GetADOProject()
+	return project != nil && *project != ""
+}
+
+// completionResult returns an applied action recording the original state, or a failed action when err is set
+func completionResult(action string, state completionState, err error) *models.CompletionAction {
+	if err != nil {
+		return completionFailed(action, err)
+	}
+	encoded, _ := json.Marshal(state)
+	original := string(encoded)
+	return &models.CompletionAction{Action: action, Status: models.CompletionActionStatusApplied, OriginalState: &original}
+}
+
+func completionFailed(action string, err error) *models.CompletionAction {
+	reason := err.Error()
+	return &models.CompletionAction{Action: action, Status: models.CompletionActionStatusFailed, Reason: &reason}
+}
+
+func completionSkipped(action, reason string) *models.CompletionAction {
+	return &models.CompletionAction{Action: action, Status: models.CompletionActionStatusSkipped, Reason: &reason}
+}
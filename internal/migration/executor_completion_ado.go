@@ -0,0 +1,219 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/ado"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// adoEndpoints are the Azure DevOps REST API hosts used by completion actions on ADO sources
+type adoEndpoints struct {
+	API      string // Organization APIs, e.g. https://dev.azure.com
+	Identity string // Identity APIs, e.g. https://vssps.dev.azure.com
+}
+
+var defaultADOEndpoints = adoEndpoints{
+	API:      "https://dev.azure.com",
+	Identity: "https://vssps.dev.azure.com",
+}
+
+const (
+	// adoGitSecurityNamespace is the security namespace of Git repository permissions
+	adoGitSecurityNamespace = "2e9eb7ed-3c0a-47d4-87c1-0ffdd275fd87"
+
+	// adoDenyWrite denies every write permission on a repository: contributing, force pushing,
+	// creating branches and tags, managing notes, bypassing policies, creating and renaming
+	// repositories, editing policies, removing others' locks and contributing to pull requests.
+	// Read, administer and manage permissions are left alone.
+	adoDenyWrite = 56828
+
+	adoAPIVersion = "7.0"
+)
+
+// applyADOCompletionActions runs completion actions on an Azure DevOps source repository.
+// ADO repositories have no description and are not archived; the archive action makes the
+// repository read-only by denying the project's valid users write access instead.
+func (e *Executor) applyADOCompletionActions(ctx context.Context, repo *models.Repository, actions []string) []*models.CompletionAction {
+	results := make([]*models.CompletionAction, 0, len(actions))
+	for _, action := range actions {
+		if action != CompletionActionArchive {
+			results = append(results, completionSkipped(action, "Not supported for Azure DevOps sources"))
+			continue
+		}
+		state, err := e.lockADORepository(ctx, repo)
+		results = append(results, completionResult(action, state, err))
+	}
+	return results
+}
+
+// lockADORepository denies the project's valid users write access to the repository and returns
+// their previous access control entry so it can be restored
+func (e *Executor) lockADORepository(ctx context.Context, repo *models.Repository) (completionState, error) {
+	parsed, err := ado.ParseFromSourceURL(repo.SourceURL)
+	if err != nil {
+		return completionState{}, fmt.Errorf("failed to parse ADO source URL: %w", err)
+	}
+
+	var gitRepo struct {
+		ID      string `json:"id"`
+		Project struct {
+			ID string `json:"id"`
+		} `json:"project"`
+	}
+	repoURL := fmt.Sprintf("%s/%s/%s/_apis/git/repositories/%s?api-version=%s", e.adoEndpoints.API,
+		url.PathEscape(parsed.Organization), url.PathEscape(parsed.Project), url.PathEscape(parsed.Repository), adoAPIVersion)
+	if err := e.adoRequest(ctx, http.MethodGet, repoURL, nil, &gitRepo); err != nil {
+		return completionState{}, fmt.Errorf("failed to get ADO repository: %w", err)
+	}
+
+	descriptor, err := e.adoProjectValidUsers(ctx, parsed, gitRepo.Project.ID)
+	if err != nil {
+		return completionState{}, err
+	}
+	state := completionState{
+		Token:      fmt.Sprintf("repoV2/%s/%s", gitRepo.Project.ID, gitRepo.ID),
+		Descriptor: descriptor,
+	}
+
+	var acls struct {
+		Value []struct {
+			AcesDictionary map[string]struct {
+				Allow int `json:"allow"`
+				Deny  int `json:"deny"`
+			} `json:"acesDictionary"`
+		} `json:"value"`
+	}
+	aclURL := fmt.Sprintf("%s/%s/_apis/accesscontrollists/%s?token=%s&descriptors=%s&api-version=%s", e.adoEndpoints.API,
+		url.PathEscape(parsed.Organization), adoGitSecurityNamespace, url.QueryEscape(state.Token), url.QueryEscape(descriptor), adoAPIVersion)
+	if err := e.adoRequest(ctx, http.MethodGet, aclURL, nil, &acls); err != nil {
+		return completionState{}, fmt.Errorf("failed to read ADO repository permissions: %w", err)
+	}
+	for _, acl := range acls.Value {
+		if ace, ok := acl.AcesDictionary[descriptor]; ok {
+			state.Allow, state.Deny = ace.Allow, ace.Deny
+		}
+	}
+
+	if err := e.setADOAccessControlEntry(ctx, parsed.Organization, state.Token, descriptor, 0, adoDenyWrite, true); err != nil {
+		return completionState{}, fmt.Errorf("failed to deny write access to the ADO repository: %w", err)
+	}
+	return state, nil
+}
+
+// unlockADORepository restores the project's valid users access control entry recorded by lockADORepository
+func (e *Executor) unlockADORepository(ctx context.Context, repo *models.Repository, state completionState) error {
+	parsed, err := ado.ParseFromSourceURL(repo.SourceURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse ADO source URL: %w", err)
+	}
+
+	if state.Allow == 0 && state.Deny == 0 {
+		// There was no explicit entry, so remove the one added for the lock
+		removeURL := fmt.Sprintf("%s/%s/_apis/accesscontrolentries/%s?token=%s&descriptors=%s&api-version=%s", e.adoEndpoints.API,
+			url.PathEscape(parsed.Organization), adoGitSecurityNamespace, url.QueryEscape(state.Token), url.QueryEscape(state.Descriptor), adoAPIVersion)
+		if err := e.adoRequest(ctx, http.MethodDelete, removeURL, nil, nil); err != nil {
+			return fmt.Errorf("failed to restore ADO repository permissions: %w", err)
+		}
+		return nil
+	}
+
+	if err := e.setADOAccessControlEntry(ctx, parsed.Organization, state.Token, state.Descriptor, state.Allow, state.Deny, false); err != nil {
+		return fmt.Errorf("failed to restore ADO repository permissions: %w", err)
+	}
+	return nil
+}
+
+// adoProjectValidUsers returns the identity descriptor of the project's Project Valid Users group
+func (e *Executor) adoProjectValidUsers(ctx context.Context, parsed *ado.ParsedURL, projectID string) (string, error) {
+	var identities struct {
+		Value []struct {
+			Descriptor string `json:"descriptor"`
+			Properties map[string]struct {
+				Value string `json:"$value"`
+			} `json:"properties"`
+		} `json:"value"`
+	}
+	group := fmt.Sprintf(`[%s]\Project Valid Users`, parsed.Project)
+	identitiesURL := fmt.Sprintf("%s/%s/_apis/identities?searchFilter=General&filterValue=%s&queryMembership=None&api-version=%s",
+		e.adoEndpoints.Identity, url.PathEscape(parsed.Organization), url.QueryEscape(group), adoAPIVersion)
+	if err := e.adoRequest(ctx, http.MethodGet, identitiesURL, nil, &identities); err != nil {
+		return "", fmt.Errorf("failed to look up ADO Project Valid Users group: %w", err)
+	}
+
+	for _, identity := range identities.Value {
+		if identity.Properties["LocalScopeId"].Value == projectID {
+			return identity.Descriptor, nil
+		}
+	}
+	return "", fmt.Errorf("ADO Project Valid Users group not found for project %s", parsed.Project)
+}
+
+// setADOAccessControlEntry sets the Git repository permissions of an identity. With merge the
+// bits are added to the identity's existing entry; without it the entry is replaced.
+func (e *Executor) setADOAccessControlEntry(ctx context.Context, org, token, descriptor string, allow, deny int, merge bool) error {
+	body := map[string]any{
+		"token": token,
+		"merge": merge,
+		"accessControlEntries": []map[string]any{
+			{"descriptor": descriptor, "allow": allow, "deny": deny},
+		},
+	}
+	aceURL := fmt.Sprintf("%s/%s/_apis/accesscontrolentries/%s?api-version=%s", e.adoEndpoints.API,
+		url.PathEscape(org), adoGitSecurityNamespace, adoAPIVersion)
+	return e.adoRequest(ctx, http.MethodPost, aceURL, body, nil)
+}
+
+// adoRequest calls an Azure DevOps REST API with the source PAT, decoding the JSON response into out when set
+func (e *Executor) adoRequest(ctx context.Context, method, apiURL string, body, out any) error {
+	if e.sourceToken == "" {
+		return fmt.Errorf("ADO PAT is not configured")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+
+	// ADO uses Basic Auth with empty username and PAT as password
+	auth := base64.StdEncoding.EncodeToString([]byte(":" + e.sourceToken))
+	req.Header.Set("Authorization", "Basic "+auth)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call ADO API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("ADO API returned %s: %s", resp.Status, string(respBody))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode ADO API response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package migration
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestParseCompletionActions(t *testing.T) {
+	actions, err := ParseCompletionActions([]string{"archive", " readme_notice ", "", "archive"})
+	if err != nil {
+		t.Fatalf("ParseCompletionActions() error = %v", err)
+	}
+	if strings.Join(actions, ",") != "readme_notice,archive" {
+		t.Errorf("Expected run order without duplicates, got %v", actions)
+	}
+
+	if actions, err := ParseCompletionActions(nil); err != nil || actions != nil {
+		t.Errorf("ParseCompletionActions(nil) = %v, %v", actions, err)
+	}
+	if _, err := ParseCompletionActions([]string{"delete"}); err == nil {
+		t.Error("Expected an error for an unknown completion action")
+	}
+}
+
+// fakeCompletionSource serves the source repository changed by completion actions
+type fakeCompletionSource struct {
+	mu          sync.Mutex
+	description string
+	archived    bool
+	readme      string
+	edits       []map[string]any // Repository PATCH bodies
+	readmePuts  []string         // Decoded README contents committed
+}
+
+func (f *fakeCompletionSource) register(mux *http.ServeMux) {
+	mux.HandleFunc("/api/v3/repos/source-org/repo", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if r.Method == http.MethodPatch {
+			var edit map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&edit)
+			f.edits = append(f.edits, edit)
+			if description, ok := edit["description"].(string); ok {
+				f.description = description
+			}
+			if archived, ok := edit["archived"].(bool); ok {
+				f.archived = archived
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"full_name":      "source-org/repo",
+			"default_branch": "main",
+			"description":    f.description,
+			"archived":       f.archived,
+		})
+	})
+	readme := func(w http.ResponseWriter, _ *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"encoding": "base64",
+			"path":     "README.md",
+			"sha":      "readme-sha",
+			"content":  base64.StdEncoding.EncodeToString([]byte(f.readme)),
+		})
+	}
+	mux.HandleFunc("GET /api/v3/repos/source-org/repo/readme", readme)
+	mux.HandleFunc("GET /api/v3/repos/source-org/repo/contents/README.md", readme)
+	mux.HandleFunc("PUT /api/v3/repos/source-org/repo/contents/README.md", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body struct {
+			Content string `json:"content"`
+			SHA     string `json:"sha"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		decoded, _ := base64.StdEncoding.DecodeString(body.Content)
+		f.readme = string(decoded)
+		f.readmePuts = append(f.readmePuts, f.readme)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"content":{"sha":"new-sha"}}`))
+	})
+}
+
+func TestCompletionActions_AppliedAndRevertedByRollback(t *testing.T) {
+	source := &fakeCompletionSource{description: "Billing service", readme: "# Billing\n"}
+	executor, db, repo := setupRollbackTest(t, &fakeRollbackServer{routes: source.register})
+	ctx := context.Background()
+
+	actions := "archive,description,readme_notice"
+	batch := &models.Batch{Name: "wave-1", CompletionActions: &actions}
+	executor.runCompletionActions(ctx, repo, batch, nil)
+
+	if len(source.readmePuts) != 1 || !strings.HasPrefix(source.readme, "> [!IMPORTANT]\n> This repository has moved to [dest-org/repo](https://github.com/dest-org/repo)") ||
+		!strings.HasSuffix(source.readme, "\n# Billing\n") {
+		t.Errorf("Expected the moved notice above the README, got %q", source.readme)
+	}
+	if source.description != "This repository has moved to https://github.com/dest-org/repo" {
+		t.Errorf("Unexpected description %q", source.description)
+	}
+	if !source.archived {
+		t.Error("Expected the source to be archived")
+	}
+	if len(source.edits) != 2 || source.edits[1]["archived"] != true {
+		t.Errorf("Expected the archive to run last, got %v", source.edits)
+	}
+
+	recorded, err := db.GetCompletionActions(ctx, repo.ID)
+	if err != nil || len(recorded) != 3 {
+		t.Fatalf("GetCompletionActions() = %v, %v", recorded, err)
+	}
+	for _, action := range recorded {
+		if action.Status != models.CompletionActionStatusApplied {
+			t.Errorf("Expected %s to be applied, got %s (%v)", action.Action, action.Status, action.Reason)
+		}
+	}
+
+	result, err := executor.Rollback(ctx, repo, RollbackOptions{Reason: "test"})
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	var reverts []string
+	for _, step := range result.Steps {
+		if strings.HasPrefix(step.Action, "revert_") {
+			reverts = append(reverts, step.Action)
+			if step.Status != RollbackStepCompleted {
+				t.Errorf("Expected %s to complete, got %s: %s", step.Action, step.Status, step.Message)
+			}
+		}
+	}
+	if strings.Join(reverts, ",") != "revert_archive,revert_description,revert_readme_notice" {
+		t.Errorf("Expected reverts in reverse order, got %v", reverts)
+	}
+	if source.archived || source.description != "Billing service" || source.readme != "# Billing\n" {
+		t.Errorf("Expected the source to be restored, got archived=%v description=%q readme=%q",
+			source.archived, source.description, source.readme)
+	}
+
+	recorded, _ = db.GetCompletionActions(ctx, repo.ID)
+	for _, action := range recorded {
+		if action.Status != models.CompletionActionStatusReverted {
+			t.Errorf("Expected %s to be marked reverted, got %s", action.Action, action.Status)
+		}
+	}
+}
+
+func TestCompletionActions_ArchivedSourceIsSkipped(t *testing.T) {
+	source := &fakeCompletionSource{archived: true}
+	executor, db, repo := setupRollbackTest(t, &fakeRollbackServer{routes: source.register})
+	ctx := context.Background()
+
+	actions := "description,archive"
+	executor.runCompletionActions(ctx, repo, &models.Batch{CompletionActions: &actions}, nil)
+
+	if len(source.edits) != 0 {
+		t.Errorf("Expected an archived source to be left alone, got %v", source.edits)
+	}
+	recorded, _ := db.GetCompletionActions(ctx, repo.ID)
+	if len(recorded) != 2 || recorded[0].Status != models.CompletionActionStatusSkipped {
+		t.Fatalf("Expected skipped actions, got %+v", recorded)
+	}
+
+	plan, err := executor.Rollback(ctx, repo, RollbackOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	for _, step := range plan.Steps {
+		if strings.HasPrefix(step.Action, "revert_") {
+			t.Errorf("Expected no revert of skipped actions, got %s", step.Action)
+		}
+	}
+}
+
+func TestCompletionActions_ADOReadOnly(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	var locked map[string]any
+	ado := http.NewServeMux()
+	record := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}
+	ado.HandleFunc("GET /org/proj/_apis/git/repositories/repo", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		_, _ = w.Write([]byte(`{"id":"repo-id","project":{"id":"project-id"}}`))
+	})
+	ado.HandleFunc("GET /org/_apis/identities", func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if got := r.URL.Query().Get("filterValue"); got != `[proj]\Project Valid Users` {
+			t.Errorf("Unexpected identity filter %q", got)
+		}
+		_, _ = w.Write([]byte(`{"value":[
+			{"descriptor":"other","properties":{"LocalScopeId":{"$value":"other-project"}}},
+			{"descriptor":"valid-users","properties":{"LocalScopeId":{"$value":"project-id"}}}
+		]}`))
+	})
+	ado.HandleFunc("GET /org/_apis/accesscontrollists/"+adoGitSecurityNamespace, func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if got := r.URL.Query().Get("token"); got != "repoV2/project-id/repo-id" {
+			t.Errorf("Unexpected token %q", got)
+		}
+		_, _ = w.Write([]byte(`{"value":[]}`))
+	})
+	ado.HandleFunc("/org/_apis/accesscontrolentries/"+adoGitSecurityNamespace, func(w http.ResponseWriter, r *http.Request) {
+		record(r)
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&locked)
+		}
+		_, _ = w.Write([]byte(`{"value":[]}`))
+	})
+	server := httptest.NewServer(ado)
+	t.Cleanup(server.Close)
+
+	executor, db, repo := setupRollbackTest(t, &fakeRollbackServer{})
+	executor.sourceClient = nil
+	executor.sourceToken = "ado-pat"
+	executor.adoEndpoints = adoEndpoints{API: server.URL, Identity: server.URL}
+	project := "proj"
+	repo.SetADOProject(&project)
+	repo.SourceURL = "https://dev.azure.com/org/proj/_git/repo"
+	repo.IsSourceLocked = false
+	ctx := context.Background()
+
+	actions := "readme_notice,archive"
+	executor.runCompletionActions(ctx, repo, &models.Batch{CompletionActions: &actions}, nil)
+
+	recorded, _ := db.GetCompletionActions(ctx, repo.ID)
+	if len(recorded) != 2 || recorded[0].Status != models.CompletionActionStatusSkipped || recorded[1].Status != models.CompletionActionStatusApplied {
+		t.Fatalf("Expected the README notice skipped and the repository locked, got %+v", recorded)
+	}
+	entries, _ := locked["accessControlEntries"].([]any)
+	if len(entries) != 1 || locked["merge"] != true {
+		t.Fatalf("Unexpected lock request %v", locked)
+	}
+	if entry := entries[0].(map[string]any); entry["descriptor"] != "valid-users" || entry["deny"] != float64(adoDenyWrite) {
+		t.Errorf("Unexpected access control entry %v", entry)
+	}
+
+	plan, err := executor.Rollback(ctx, repo, RollbackOptions{Reason: "test"})
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if statuses := stepStatuses(plan); statuses[RollbackStepRevertArchive] != RollbackStepCompleted {
+		t.Errorf("Expected write access to be restored, got %v", plan.Steps)
+	}
+	if last := calls[len(calls)-1]; last != "DELETE /org/_apis/accesscontrolentries/"+adoGitSecurityNamespace {
+		t.Errorf("Expected the lock entry to be removed, got %v", calls)
+	}
+}
@@ -0,0 +1,260 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+const phaseCutover = "cutover"
+
+// supportsDeltaSync returns true if the executor can lock the source and re-sync git refs at cutover.
+// Delta sync needs the GitHub migrations API to lock the source and a git provider to clone it.
+func (e *Executor) supportsDeltaSync() bool {
+	return e.sourceClient != nil && e.sourceProvider != nil
+}
+
+// recordPreSeed records a completed delta sync dry run as the repository's pre-seed.
+// The commit the pre-seed was taken from is kept so the cutover can report what changed,
+// and the destination is archived so nobody starts working in it before the cutover.
+func (e *Executor) recordPreSeed(ctx context.Context, mc *MigrationContext) {
+	repo := mc.Repo
+	if !e.supportsDeltaSync() {
+		msg := "Source does not support delta sync - the scheduled migration will run a full migration"
+		e.logger.Warn(msg, "repo", repo.FullName)
+		e.logOperation(ctx, repo, mc.HistoryID, "WARN", "migration", "pre_seed", msg, nil)
+		return
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		msg := "No destination repository recorded - the scheduled migration will run a full migration"
+		e.logger.Warn(msg, "repo", repo.FullName)
+		e.logOperation(ctx, repo, mc.HistoryID, "WARN", "migration", "pre_seed", msg, nil)
+		return
+	}
+
+	now := time.Now()
+	repo.PreSeededAt = &now
+	repo.PreSeedCommitSHA = repo.GetLastCommitSHA()
+
+	details := fmt.Sprintf("destination=%s, commit=%s", *repo.DestinationFullName, stringOrNone(repo.PreSeedCommitSHA))
+	e.logOperation(ctx, repo, mc.HistoryID, "INFO", "migration", "pre_seed",
+		"Repository pre-seeded - changed refs will be re-synced at the scheduled cutover", &details)
+
+	owner, name, _ := strings.Cut(*repo.DestinationFullName, "/")
+	if err := e.destClient.ArchiveRepository(ctx, owner, name); err != nil {
+		// The pre-seed is still usable, the destination just stays writable until cutover
+		errMsg := err.Error()
+		e.logger.Warn("Failed to archive pre-seeded destination repository", "repo", repo.FullName, "error", err)
+		e.logOperation(ctx, repo, mc.HistoryID, "WARN", "migration", "pre_seed",
+			"Failed to archive pre-seeded destination repository", &errMsg)
+	}
+}
+
+// executeCutover completes a pre-seeded delta sync migration.
+// It locks the source, re-syncs the branches and tags that changed since the pre-seed,
+// unarchives the destination, runs the post-migration phase and unlocks the source. If a
+// step fails the source is unlocked and the pre-seed is kept so the cutover can be retried.
+func (e *Executor) executeCutover(ctx context.Context, repo *models.Repository, batch *models.Batch) error {
+	historyID, err := e.createMigrationHistory(ctx, repo, false)
+	if err != nil {
+		return fmt.Errorf("failed to create migration history: %w", err)
+	}
+	mc := e.NewMigrationContext(repo, batch, false)
+	mc.HistoryID = historyID
+
+	destFullName := *repo.DestinationFullName
+	e.logger.Info("Starting delta sync cutover",
+		"repo", repo.FullName,
+		"batch", batch.Name,
+		"destination", destFullName,
+		"pre_seeded_at", repo.PreSeededAt)
+	e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "start",
+		fmt.Sprintf("Starting delta sync cutover to %s (pre-seeded %s)", destFullName, repo.PreSeededAt.Format(time.RFC3339)), nil)
+
+	if err := e.runCutover(ctx, repo, historyID, destFullName); err != nil {
+		errMsg := err.Error()
+		e.logOperation(ctx, repo, historyID, "ERROR", phaseCutover, "fail", "Delta sync cutover failed", &errMsg)
+		e.updateHistoryStatus(ctx, historyID, statusFailed, &errMsg)
+
+		if repo.IsSourceLocked {
+			repo.IsSourceLocked = false
+			e.unlockSourceRepository(ctx, repo)
+		}
+		repo.Status = string(models.StatusMigrationFailed)
+		if updateErr := e.storage.UpdateRepository(ctx, repo); updateErr != nil {
+			e.logger.Error("Failed to update repository status", "error", updateErr)
+		}
+		return err
+	}
+
+	return e.completeCutover(ctx, mc)
+}
+
+// completeCutover finishes a cutover whose refs were re-synced. The pre-seed was a dry run, so
+// the settings, protections, Actions settings and webhooks are replayed now, like after any
+// production migration. Then the source is released and the pre-seed cleared.
+func (e *Executor) completeCutover(ctx context.Context, mc *MigrationContext) error {
+	repo := mc.Repo
+	if err := e.phasePostMigration(ctx, mc); err != nil {
+		e.logger.Warn("Post-migration phase returned error", "error", err, "repo", repo.FullName)
+	}
+
+	e.unlockSourceRepository(ctx, repo)
+	repo.IsSourceLocked = false
+	e.runCompletionActions(ctx, repo, mc.Batch, mc.HistoryID)
+	repo.PreSeededAt = nil
+	repo.PreSeedCommitSHA = nil
+	repo.Status = string(models.StatusComplete)
+	now := time.Now()
+	repo.MigratedAt = &now
+
+	e.logger.Info("Delta sync cutover complete", "repo", repo.FullName, "destination", stringOrNone(repo.DestinationFullName))
+	e.logOperation(ctx, repo, mc.HistoryID, "INFO", "migration", "complete", msgMigrationComplete, nil)
+	e.updateHistoryStatus(ctx, mc.HistoryID, "completed", nil)
+
+	return e.storage.UpdateRepository(ctx, repo)
+}
+
+// runCutover performs the cutover steps, recording the source lock on the repository as soon as it is taken
+func (e *Executor) runCutover(ctx context.Context, repo *models.Repository, historyID *int64, destFullName string) error {
+	if !e.supportsDeltaSync() {
+		return fmt.Errorf("source does not support delta sync cutover")
+	}
+
+	// 1. Lock the source so no further changes land during the re-sync
+	migrationID, err := e.sourceClient.LockRepository(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		return fmt.Errorf("failed to lock source repository: %w", err)
+	}
+	repo.SourceMigrationID = &migrationID
+	repo.IsSourceLocked = true
+	repo.Status = string(models.StatusMigratingContent)
+	if err := e.storage.UpdateRepository(ctx, repo); err != nil {
+		e.logger.Error("Failed to update repository status", "error", err)
+	}
+	e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "lock",
+		fmt.Sprintf("Locked source repository (migration %d)", migrationID), nil)
+
+	// 2. Refresh the source's last commit so the change since the pre-seed can be reported
+	if err := e.runPreMigrationDiscovery(ctx, repo); err != nil {
+		e.logger.Warn("Cutover discovery failed, continuing with ref comparison", "repo", repo.FullName, "error", err)
+	}
+	if current := repo.GetLastCommitSHA(); current != nil && repo.PreSeedCommitSHA != nil && *current == *repo.PreSeedCommitSHA {
+		e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "compare",
+			fmt.Sprintf("Default branch unchanged since pre-seed (%s)", *current), nil)
+	} else {
+		details := fmt.Sprintf("pre-seed=%s, current=%s", stringOrNone(repo.PreSeedCommitSHA), stringOrNone(current))
+		e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "compare",
+			"Default branch changed since pre-seed", &details)
+	}
+
+	// 3. Compare the source and destination refs
+	tempDir, err := e.cloneMirror(ctx, repo, repo.HasLFS())
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+	repoPath := filepath.Join(tempDir, "repo.git")
+
+	destURL, err := e.destinationPushURL(destFullName)
+	if err != nil {
+		return err
+	}
+	token := e.destClient.Token()
+
+	sourceRefs, err := listRefs(ctx, repoPath, "", "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags")
+	if err != nil {
+		return fmt.Errorf("failed to list source refs: %w", err)
+	}
+	destRefs, err := listRefs(ctx, repoPath, token, "ls-remote", "--heads", "--tags", destURL)
+	if err != nil {
+		return fmt.Errorf("failed to list destination refs: %w", err)
+	}
+
+	changed, deleted := diffRefs(sourceRefs, destRefs)
+	details := fmt.Sprintf("changed=%d, deleted=%d", len(changed), len(deleted))
+	e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "compare",
+		fmt.Sprintf("%d refs changed and %d refs deleted since pre-seed", len(changed), len(deleted)), &details)
+
+	// 4. Swap: make the destination writable and push only the changed refs
+	owner, name, _ := strings.Cut(destFullName, "/")
+	if err := e.destClient.UnarchiveRepository(ctx, owner, name); err != nil {
+		return fmt.Errorf("failed to unarchive destination repository: %w", err)
+	}
+
+	if len(changed) == 0 && len(deleted) == 0 {
+		e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "push", "Destination already up to date", nil)
+		return nil
+	}
+
+	refSpecs := make([]string, 0, len(changed)+len(deleted))
+	for _, ref := range changed {
+		refSpecs = append(refSpecs, "+"+ref+":"+ref)
+	}
+	for _, ref := range deleted {
+		refSpecs = append(refSpecs, ":"+ref)
+	}
+	if err := pushMirror(ctx, repoPath, destURL, mirrorPushOptions{
+		RefSpecs:   refSpecs,
+		IncludeLFS: repo.HasLFS(),
+		Token:      token,
+	}); err != nil {
+		return err
+	}
+
+	e.logOperation(ctx, repo, historyID, "INFO", phaseCutover, "push",
+		fmt.Sprintf("Re-synced %d refs to %s", len(refSpecs), destFullName), nil)
+	return nil
+}
+
+// listRefs runs a git command that prints "<sha> <ref>" lines and returns the refs by name.
+// Peeled tag entries ("refs/tags/v1^{}") printed by ls-remote are ignored.
+func listRefs(ctx context.Context, dir, token string, args ...string) (map[string]string, error) {
+	output, err := gitOutput(ctx, dir, token, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || strings.HasSuffix(fields[1], "^{}") {
+			continue
+		}
+		refs[fields[1]] = fields[0]
+	}
+	return refs, nil
+}
+
+// diffRefs compares source and destination refs.
+// It returns the refs that are new or point at a different object on the source,
+// and the refs that only exist on the destination, both sorted by name.
+func diffRefs(source, dest map[string]string) (changed, deleted []string) {
+	for ref, sha := range source {
+		if dest[ref] != sha {
+			changed = append(changed, ref)
+		}
+	}
+	for ref := range dest {
+		if _, ok := source[ref]; !ok {
+			deleted = append(deleted, ref)
+		}
+	}
+	sort.Strings(changed)
+	sort.Strings(deleted)
+	return changed, deleted
+}
+
+// stringOrNone returns the string value or "none" when nil
+func stringOrNone(s *string) string {
+	if s == nil || *s == "" {
+		return "none"
+	}
+	return *s
+}
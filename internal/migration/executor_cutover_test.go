@@ -0,0 +1,167 @@
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestDiffRefs(t *testing.T) {
+	source := map[string]string{
+		"refs/heads/main":    "bbb",
+		"refs/heads/feature": "ccc",
+		"refs/heads/release": "ddd",
+		"refs/tags/v1.0.0":   "eee",
+	}
+	dest := map[string]string{
+		"refs/heads/main":    "aaa", // moved since pre-seed
+		"refs/heads/feature": "ccc", // unchanged
+		"refs/heads/old":     "fff", // deleted on source
+		"refs/tags/v1.0.0":   "eee", // unchanged
+	}
+
+	changed, deleted := diffRefs(source, dest)
+
+	if want := []string{"refs/heads/main", "refs/heads/release"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if want := []string{"refs/heads/old"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+
+	changed, deleted = diffRefs(source, source)
+	if len(changed) != 0 || len(deleted) != 0 {
+		t.Errorf("expected no differences for identical refs, got changed=%v deleted=%v", changed, deleted)
+	}
+}
+
+func TestListRefs_ComparesMirrorWithDestination(t *testing.T) {
+	ctx := context.Background()
+	mirrorPath, destPath := setupMirrorRepos(t)
+
+	if err := pushMirror(ctx, mirrorPath, destPath, mirrorPushOptions{}); err != nil {
+		t.Fatalf("pushMirror() error: %v", err)
+	}
+
+	// Changes on the source after the pre-seed
+	gitTest(t, mirrorPath, "branch", "release", "main")
+	gitTest(t, mirrorPath, "tag", "-a", "v2.0.0", "-m", "Release 2.0.0", "main")
+	gitTest(t, mirrorPath, "update-ref", "-d", "refs/tags/v1.0.0")
+
+	sourceRefs, err := listRefs(ctx, mirrorPath, "", "for-each-ref", "--format=%(objectname) %(refname)", "refs/heads", "refs/tags")
+	if err != nil {
+		t.Fatalf("listRefs() source error: %v", err)
+	}
+	destRefs, err := listRefs(ctx, mirrorPath, "", "ls-remote", "--heads", "--tags", destPath)
+	if err != nil {
+		t.Fatalf("listRefs() destination error: %v", err)
+	}
+	if _, ok := sourceRefs["refs/merge-requests/1/head"]; ok {
+		t.Error("source refs should only include branches and tags")
+	}
+
+	changed, deleted := diffRefs(sourceRefs, destRefs)
+	if want := []string{"refs/heads/release", "refs/tags/v2.0.0"}; !reflect.DeepEqual(changed, want) {
+		t.Errorf("changed = %v, want %v", changed, want)
+	}
+	if want := []string{"refs/tags/v1.0.0"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("deleted = %v, want %v", deleted, want)
+	}
+}
+
+func TestRecordPreSeed(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, _, repo := setupRollbackTest(t, fake)
+	executor.sourceProvider = &fakeProvider{}
+	sha := "abc123"
+	repo.SetLastCommitSHA(&sha)
+
+	executor.recordPreSeed(context.Background(), &MigrationContext{Repo: repo, DryRun: true, PreSeed: true})
+
+	if repo.PreSeededAt == nil {
+		t.Fatal("expected PreSeededAt to be set")
+	}
+	if repo.PreSeedCommitSHA == nil || *repo.PreSeedCommitSHA != sha {
+		t.Errorf("PreSeedCommitSHA = %v, want %s", repo.PreSeedCommitSHA, sha)
+	}
+	if len(fake.calls) != 1 || fake.calls[0] != "PATCH /api/v3/repos/dest-org/repo" {
+		t.Errorf("expected the destination to be archived, got calls %v", fake.calls)
+	}
+}
+
+func TestRecordPreSeed_UnsupportedSource(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, _, repo := setupRollbackTest(t, fake)
+
+	executor.recordPreSeed(context.Background(), &MigrationContext{Repo: repo, DryRun: true, PreSeed: true})
+
+	if repo.PreSeededAt != nil {
+		t.Error("expected no pre-seed without a git provider for the source")
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", fake.calls)
+	}
+}
+
+func TestExecuteWithStrategy_CutoverFailureKeepsPreSeed(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, db, repo := setupRollbackTest(t, fake)
+	executor.sourceProvider = &fakeProvider{}
+	ctx := context.Background()
+
+	preSeededAt := time.Now().Add(-48 * time.Hour)
+	sha := "abc123"
+	repo.Status = string(models.StatusQueuedForMigration)
+	repo.IsSourceLocked = false
+	repo.SourceMigrationID = nil
+	repo.PreSeededAt = &preSeededAt
+	repo.PreSeedCommitSHA = &sha
+	if err := db.UpdateRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to update repository: %v", err)
+	}
+
+	// The fake server has no migrations endpoint, so locking the source fails
+	batch := &models.Batch{ID: 1, Name: "cutover", DeltaSync: true}
+	if err := executor.ExecuteWithStrategy(ctx, repo, batch, false); err == nil {
+		t.Fatal("expected the cutover to fail when the source cannot be locked")
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusMigrationFailed) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusMigrationFailed)
+	}
+	if !updated.IsPreSeeded() {
+		t.Error("expected the pre-seed to be kept so the cutover can be retried")
+	}
+	if updated.IsSourceLocked {
+		t.Error("expected the source not to be locked")
+	}
+}
+
+func TestCompleteCutover_ReplaysSettings(t *testing.T) {
+	executor, mc, requests := setupReplayTest(t, ExecutorConfig{
+		PostMigrationMode: PostMigrationNever,
+		ProtectionReplay:  true,
+	})
+	ctx := context.Background()
+
+	preSeededAt := time.Now().Add(-48 * time.Hour)
+	mc.Repo.PreSeededAt = &preSeededAt
+	mc.Batch = &models.Batch{ID: 1, Name: "cutover", DeltaSync: true}
+
+	if err := executor.completeCutover(ctx, mc); err != nil {
+		t.Fatalf("completeCutover() error = %v", err)
+	}
+	if requests.Load() == 0 {
+		t.Error("expected branch protections to be replayed at cutover")
+	}
+	if mc.Repo.Status != string(models.StatusComplete) || mc.Repo.IsPreSeeded() {
+		t.Errorf("expected a completed repository without pre-seed, got status %s, pre-seeded %v", mc.Repo.Status, mc.Repo.IsPreSeeded())
+	}
+}
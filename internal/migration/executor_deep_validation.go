@@ -0,0 +1,457 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+const (
+	// lfsBatchSize is the number of objects sent in each LFS batch API request
+	lfsBatchSize = 100
+	// maxMissingLFSOIDs caps the missing LFS object IDs kept in a deep validation report
+	maxMissingLFSOIDs = 100
+	// lfsRequestTimeout is the timeout for each LFS batch API request
+	lfsRequestTimeout = 60 * time.Second
+)
+
+// lfsObject is an LFS object pointer as sent to the LFS batch API
+type lfsObject struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// deepValidate compares every branch and tag, the issue, pull request, release and
+// release asset counts, and (for repositories using LFS) the presence of every LFS object
+// between the source and the destination. Checks that cannot run are recorded in the
+// report's errors rather than failing the migration.
+func (e *Executor) deepValidate(ctx context.Context, repo *models.Repository, batch *models.Batch, destFullName string) *models.DeepValidationReport {
+	report := &models.DeepValidationReport{
+		CheckedAt: time.Now(),
+		RefDiffs:  []models.RefDiff{},
+		Counts:    []models.ArtifactCountComparison{},
+	}
+
+	// 1. Refs: compare the SHA of every branch and tag
+	if err := e.validateRefs(ctx, repo, destFullName, report); err != nil {
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	// 2. Artifact counts
+	e.validateArtifactCounts(ctx, repo, batch, destFullName, report)
+
+	// 3. LFS objects
+	if repo.HasLFS() {
+		lfs, err := e.validateLFSObjects(ctx, destFullName)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to validate LFS objects: %v", err))
+		}
+		report.LFS = lfs
+	}
+
+	report.Passed = len(report.RefDiffs) == 0 && len(report.Errors) == 0 &&
+		(report.LFS == nil || report.LFS.Missing == 0)
+	for _, count := range report.Counts {
+		if !count.Match && count.Skipped == "" {
+			report.Passed = false
+		}
+	}
+
+	return report
+}
+
+// validateRefs lists the branches and tags on both sides with git ls-remote and records the differences
+func (e *Executor) validateRefs(ctx context.Context, repo *models.Repository, destFullName string, report *models.DeepValidationReport) error {
+	sourceURL, sourceToken, err := e.sourceGitURL(repo)
+	if err != nil {
+		return fmt.Errorf("failed to list source refs: %w", err)
+	}
+	destURL, err := e.destinationPushURL(destFullName)
+	if err != nil {
+		return fmt.Errorf("failed to list destination refs: %w", err)
+	}
+
+	sourceRefs, err := listRefs(ctx, "", sourceToken, "ls-remote", "--heads", "--tags", sourceURL)
+	if err != nil {
+		return fmt.Errorf("failed to list source refs: %w", err)
+	}
+	destRefs, err := listRefs(ctx, "", e.destClient.Token(), "ls-remote", "--heads", "--tags", destURL)
+	if err != nil {
+		return fmt.Errorf("failed to list destination refs: %w", err)
+	}
+
+	report.SourceRefs = len(sourceRefs)
+	report.DestRefs = len(destRefs)
+	report.RefDiffs, report.RefsMatched = compareRefs(sourceRefs, destRefs)
+	return nil
+}
+
+// sourceGitURL returns the git URL of the source repository with credentials embedded,
+// and the secret to redact from git errors
+func (e *Executor) sourceGitURL(repo *models.Repository) (string, string, error) {
+	if e.sourceProvider != nil {
+		authURL, err := e.sourceProvider.GetAuthenticatedCloneURL(repo.SourceURL)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to build authenticated source URL: %w", err)
+		}
+		secret := ""
+		if parsedURL, err := url.Parse(authURL); err == nil && parsedURL.User != nil {
+			secret, _ = parsedURL.User.Password()
+		}
+		return authURL, secret, nil
+	}
+
+	var rawURL, token string
+	switch {
+	case e.sourceClient != nil && e.sourceClient.Token() != "":
+		rawURL = e.sourceClient.RepositoryURL(repo.FullName) + ".git"
+		token = e.sourceClient.Token()
+	case e.sourceToken != "" && repo.SourceURL != "":
+		rawURL = repo.SourceURL
+		token = e.sourceToken
+	default:
+		return "", "", fmt.Errorf("no source credentials available for git access")
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid source URL: %w", err)
+	}
+	parsedURL.User = url.UserPassword("x-access-token", token)
+	return parsedURL.String(), token, nil
+}
+
+// compareRefs compares source and destination refs by SHA.
+// It returns the refs that differ, sorted by name, and the number of refs that match.
+func compareRefs(source, dest map[string]string) ([]models.RefDiff, int) {
+	diffs := []models.RefDiff{}
+	matched := 0
+
+	for ref, sourceSHA := range source {
+		destSHA, ok := dest[ref]
+		switch {
+		case !ok:
+			diffs = append(diffs, models.RefDiff{Ref: ref, Status: models.RefDiffMissing, SourceSHA: sourceSHA})
+		case destSHA != sourceSHA:
+			diffs = append(diffs, models.RefDiff{Ref: ref, Status: models.RefDiffMismatch, SourceSHA: sourceSHA, DestSHA: destSHA})
+		default:
+			matched++
+		}
+	}
+	for ref, destSHA := range dest {
+		if _, ok := source[ref]; !ok {
+			diffs = append(diffs, models.RefDiff{Ref: ref, Status: models.RefDiffExtra, DestSHA: destSHA})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Ref < diffs[j].Ref })
+	return diffs, matched
+}
+
+// validateArtifactCounts compares issue, pull request, release and release asset counts.
+// Source counts are only available for GitHub sources.
+func (e *Executor) validateArtifactCounts(ctx context.Context, repo *models.Repository, batch *models.Batch, destFullName string, report *models.DeepValidationReport) {
+	if e.sourceClient == nil {
+		for _, field := range []string{"issues", "pull_requests", "releases", "release_assets"} {
+			report.Counts = append(report.Counts, models.ArtifactCountComparison{
+				Field:   field,
+				Skipped: "source is not a GitHub repository",
+			})
+		}
+		return
+	}
+
+	destOrg, destName, _ := strings.Cut(destFullName, "/")
+
+	sourceIssues, sourcePulls, err := countIssuesAndPullRequests(ctx, e.sourceClient, repo.Organization(), repo.Name())
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to count source issues: %v", err))
+	}
+	destIssues, destPulls, destErr := countIssuesAndPullRequests(ctx, e.destClient, destOrg, destName)
+	if destErr != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to count destination issues: %v", destErr))
+	}
+	if err == nil && destErr == nil {
+		report.Counts = append(report.Counts,
+			newCountComparison("issues", sourceIssues, destIssues),
+			newCountComparison("pull_requests", sourcePulls, destPulls))
+	}
+
+	if e.shouldExcludeReleases(repo, batch) {
+		for _, field := range []string{"releases", "release_assets"} {
+			report.Counts = append(report.Counts, models.ArtifactCountComparison{
+				Field:   field,
+				Skipped: "releases were excluded from the migration",
+			})
+		}
+		return
+	}
+
+	sourceReleases, sourceAssets, err := countReleasesAndAssets(ctx, e.sourceClient, repo.Organization(), repo.Name())
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to count source releases: %v", err))
+	}
+	destReleases, destAssets, destErr := countReleasesAndAssets(ctx, e.destClient, destOrg, destName)
+	if destErr != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to count destination releases: %v", destErr))
+	}
+	if err == nil && destErr == nil {
+		report.Counts = append(report.Counts,
+			newCountComparison("releases", sourceReleases, destReleases),
+			newCountComparison("release_assets", sourceAssets, destAssets))
+	}
+}
+
+// newCountComparison builds a count comparison for a field
+func newCountComparison(field string, source, dest int) models.ArtifactCountComparison {
+	return models.ArtifactCountComparison{
+		Field:       field,
+		Source:      source,
+		Destination: dest,
+		Match:       source == dest,
+	}
+}
+
+// countIssuesAndPullRequests counts every issue and pull request in a repository, open or closed
+func countIssuesAndPullRequests(ctx context.Context, client *github.Client, org, name string) (issues, pulls int, err error) {
+	opts := &ghapi.IssueListByRepoOptions{
+		State:       "all",
+		ListOptions: ghapi.ListOptions{PerPage: 100},
+	}
+	for {
+		var page []*ghapi.Issue
+		resp, err := client.DoWithRetry(ctx, "ListIssues", func(ctx context.Context) (*ghapi.Response, error) {
+			var resp *ghapi.Response
+			var err error
+			page, resp, err = client.REST().Issues.ListByRepo(ctx, org, name, opts)
+			return resp, err
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, issue := range page {
+			if issue.PullRequestLinks == nil {
+				issues++
+			} else {
+				pulls++
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return issues, pulls, nil
+		}
+		opts.ListOptions.Page = resp.NextPage
+	}
+}
+
+// countReleasesAndAssets counts every release in a repository and the assets attached to them
+func countReleasesAndAssets(ctx context.Context, client *github.Client, org, name string) (releases, assets int, err error) {
+	opts := &ghapi.ListOptions{PerPage: 100}
+	for {
+		var page []*ghapi.RepositoryRelease
+		resp, err := client.DoWithRetry(ctx, "ListReleases", func(ctx context.Context) (*ghapi.Response, error) {
+			var resp *ghapi.Response
+			var err error
+			page, resp, err = client.REST().Repositories.ListReleases(ctx, org, name, opts)
+			return resp, err
+		})
+		if err != nil {
+			return 0, 0, err
+		}
+		for _, release := range page {
+			releases++
+			assets += len(release.Assets)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return releases, assets, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// validateLFSObjects checks that every LFS object referenced anywhere in the destination's
+// history can be downloaded from the destination's LFS storage.
+// The destination is mirror cloned (without LFS content) to list the referenced objects.
+func (e *Executor) validateLFSObjects(ctx context.Context, destFullName string) (*models.LFSValidation, error) {
+	destURL, err := e.destinationPushURL(destFullName)
+	if err != nil {
+		return nil, err
+	}
+	token := e.destClient.Token()
+
+	tempDir, err := os.MkdirTemp("", "github-migrator-validate-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(tempDir) }()
+
+	if err := runGit(ctx, tempDir, token, "clone", "--mirror", destURL, "repo.git"); err != nil {
+		return nil, fmt.Errorf("failed to clone destination repository: %w", err)
+	}
+
+	objects, err := listLFSObjects(ctx, filepath.Join(tempDir, "repo.git"))
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := e.destClient.RepositoryURL(destFullName) + ".git/info/lfs/objects/batch"
+	missing, err := checkLFSObjects(ctx, &http.Client{Timeout: lfsRequestTimeout}, endpoint, token, objects)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.LFSValidation{
+		Objects: len(objects),
+		Missing: len(missing),
+	}
+	if len(missing) > maxMissingLFSOIDs {
+		missing = missing[:maxMissingLFSOIDs]
+	}
+	result.MissingOIDs = missing
+	return result, nil
+}
+
+// listLFSObjects lists the unique LFS objects referenced anywhere in a repository's history
+func listLFSObjects(ctx context.Context, repoPath string) ([]lfsObject, error) {
+	output, err := gitOutput(ctx, repoPath, "", "lfs", "ls-files", "--all", "--json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LFS objects: %w", err)
+	}
+
+	var listing struct {
+		Files []lfsObject `json:"files"`
+	}
+	if strings.TrimSpace(output) != "" {
+		if err := json.Unmarshal([]byte(output), &listing); err != nil {
+			return nil, fmt.Errorf("failed to parse LFS object list: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool, len(listing.Files))
+	objects := make([]lfsObject, 0, len(listing.Files))
+	for _, obj := range listing.Files {
+		if seen[obj.OID] {
+			continue
+		}
+		seen[obj.OID] = true
+		objects = append(objects, obj)
+	}
+	return objects, nil
+}
+
+// checkLFSObjects asks the LFS batch API at endpoint to download each object and returns
+// the IDs of objects the server reports as missing or cannot serve
+func checkLFSObjects(ctx context.Context, client *http.Client, endpoint, token string, objects []lfsObject) ([]string, error) {
+	type batchObject struct {
+		OID     string         `json:"oid"`
+		Actions map[string]any `json:"actions"`
+		Error   *struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	missing := []string{}
+	for start := 0; start < len(objects); start += lfsBatchSize {
+		end := min(start+lfsBatchSize, len(objects))
+		chunk := objects[start:end]
+
+		body, err := json.Marshal(map[string]any{
+			"operation": "download",
+			"transfers": []string{"basic"},
+			"objects":   chunk,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode LFS batch request: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create LFS batch request: %w", err)
+		}
+		req.Header.Set("Accept", "application/vnd.git-lfs+json")
+		req.Header.Set("Content-Type", "application/vnd.git-lfs+json")
+		req.SetBasicAuth("x-access-token", token)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("LFS batch request failed: %w", err)
+		}
+
+		var result struct {
+			Objects []batchObject `json:"objects"`
+		}
+		decodeErr := json.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("LFS batch request returned status %d", resp.StatusCode)
+		}
+		if decodeErr != nil {
+			return nil, fmt.Errorf("failed to decode LFS batch response: %w", decodeErr)
+		}
+
+		returned := make(map[string]bool, len(result.Objects))
+		for _, obj := range result.Objects {
+			returned[obj.OID] = true
+			if obj.Error != nil || obj.Actions["download"] == nil {
+				missing = append(missing, obj.OID)
+			}
+		}
+		// Objects left out of the response cannot be downloaded either
+		for _, obj := range chunk {
+			if !returned[obj.OID] {
+				missing = append(missing, obj.OID)
+			}
+		}
+	}
+
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// deepValidationMismatches summarizes a deep validation report as validation mismatches.
+// Ref differences and missing LFS objects are critical; count differences are not.
+func deepValidationMismatches(report *models.DeepValidationReport) []ValidationMismatch {
+	var mismatches []ValidationMismatch
+
+	if len(report.RefDiffs) > 0 {
+		mismatches = append(mismatches, ValidationMismatch{
+			Field:       "ref_diffs",
+			SourceValue: 0,
+			DestValue:   len(report.RefDiffs),
+			Critical:    true,
+		})
+	}
+	for _, count := range report.Counts {
+		if count.Match || count.Skipped != "" {
+			continue
+		}
+		mismatches = append(mismatches, ValidationMismatch{
+			Field:       count.Field + "_count",
+			SourceValue: count.Source,
+			DestValue:   count.Destination,
+			Critical:    false,
+		})
+	}
+	if report.LFS != nil && report.LFS.Missing > 0 {
+		mismatches = append(mismatches, ValidationMismatch{
+			Field:       "lfs_missing_objects",
+			SourceValue: 0,
+			DestValue:   report.LFS.Missing,
+			Critical:    true,
+		})
+	}
+
+	return mismatches
+}
@@ -0,0 +1,191 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestCompareRefs(t *testing.T) {
+	source := map[string]string{
+		"refs/heads/main":    "aaa",
+		"refs/heads/feature": "bbb",
+		"refs/heads/release": "ccc",
+		"refs/tags/v1.0.0":   "ddd",
+	}
+	dest := map[string]string{
+		"refs/heads/main":    "aaa",
+		"refs/heads/feature": "fff",
+		"refs/heads/stale":   "eee",
+		"refs/tags/v1.0.0":   "ddd",
+	}
+
+	diffs, matched := compareRefs(source, dest)
+
+	if matched != 2 {
+		t.Errorf("matched = %d, want 2", matched)
+	}
+	want := []models.RefDiff{
+		{Ref: "refs/heads/feature", Status: models.RefDiffMismatch, SourceSHA: "bbb", DestSHA: "fff"},
+		{Ref: "refs/heads/release", Status: models.RefDiffMissing, SourceSHA: "ccc"},
+		{Ref: "refs/heads/stale", Status: models.RefDiffExtra, DestSHA: "eee"},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("diffs = %+v, want %+v", diffs, want)
+	}
+
+	diffs, matched = compareRefs(source, source)
+	if len(diffs) != 0 || matched != len(source) {
+		t.Errorf("expected identical refs to match, got diffs=%v matched=%d", diffs, matched)
+	}
+}
+
+func TestCheckLFSObjects(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if user, pass, ok := r.BasicAuth(); !ok || user != "x-access-token" || pass != "test-token" {
+			t.Errorf("unexpected credentials %q/%q", user, pass)
+		}
+		if got := r.Header.Get("Content-Type"); got != "application/vnd.git-lfs+json" {
+			t.Errorf("Content-Type = %q", got)
+		}
+
+		var req struct {
+			Operation string      `json:"operation"`
+			Objects   []lfsObject `json:"objects"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if req.Operation != "download" {
+			t.Errorf("operation = %q, want download", req.Operation)
+		}
+
+		objects := []map[string]any{}
+		for _, obj := range req.Objects {
+			switch obj.OID {
+			case "missing":
+				objects = append(objects, map[string]any{
+					"oid":   obj.OID,
+					"error": map[string]any{"code": 404, "message": "Object does not exist"},
+				})
+			case "omitted":
+				// Left out of the response
+			default:
+				objects = append(objects, map[string]any{
+					"oid":     obj.OID,
+					"actions": map[string]any{"download": map[string]any{"href": "https://example.com/" + obj.OID}},
+				})
+			}
+		}
+		w.Header().Set("Content-Type", "application/vnd.git-lfs+json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"objects": objects})
+	}))
+	defer server.Close()
+
+	objects := []lfsObject{{OID: "missing", Size: 1}, {OID: "omitted", Size: 1}}
+	for i := range lfsBatchSize {
+		objects = append(objects, lfsObject{OID: fmt.Sprintf("oid-%03d", i), Size: 10})
+	}
+
+	missing, err := checkLFSObjects(context.Background(), server.Client(), server.URL, "test-token", objects)
+	if err != nil {
+		t.Fatalf("checkLFSObjects() error: %v", err)
+	}
+	if want := []string{"missing", "omitted"}; !reflect.DeepEqual(missing, want) {
+		t.Errorf("missing = %v, want %v", missing, want)
+	}
+	if requests != 2 {
+		t.Errorf("expected objects to be sent in 2 batches, got %d requests", requests)
+	}
+}
+
+func TestCountIssuesAndReleases(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/rate_limit", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"resources":{"core":{"limit":5000,"remaining":4999,"reset":1234567890}}}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != "all" {
+			t.Errorf("state = %q, want all", r.URL.Query().Get("state"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`[{"number":3,"pull_request":{"url":"x"}}]`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/api/v3/repos/org/repo/issues?state=all&page=2>; rel="next"`, "http://"+r.Host))
+		_, _ = w.Write([]byte(`[{"number":1},{"number":2,"pull_request":{"url":"x"}}]`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/org/repo/releases", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"id":1,"assets":[{"id":1},{"id":2}]},{"id":2,"assets":[]}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := github.NewClient(github.ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: github.DefaultRetryConfig(),
+		Logger:      slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	ctx := context.Background()
+
+	issues, pulls, err := countIssuesAndPullRequests(ctx, client, "org", "repo")
+	if err != nil {
+		t.Fatalf("countIssuesAndPullRequests() error: %v", err)
+	}
+	if issues != 1 || pulls != 2 {
+		t.Errorf("issues = %d, pulls = %d, want 1 and 2", issues, pulls)
+	}
+
+	releases, assets, err := countReleasesAndAssets(ctx, client, "org", "repo")
+	if err != nil {
+		t.Fatalf("countReleasesAndAssets() error: %v", err)
+	}
+	if releases != 2 || assets != 2 {
+		t.Errorf("releases = %d, assets = %d, want 2 and 2", releases, assets)
+	}
+}
+
+func TestDeepValidationMismatches(t *testing.T) {
+	report := &models.DeepValidationReport{
+		RefDiffs: []models.RefDiff{{Ref: "refs/heads/main", Status: models.RefDiffMismatch}},
+		Counts: []models.ArtifactCountComparison{
+			{Field: "issues", Source: 10, Destination: 10, Match: true},
+			{Field: "releases", Source: 3, Destination: 2},
+			{Field: "release_assets", Skipped: "releases were excluded from the migration"},
+		},
+		LFS: &models.LFSValidation{Objects: 5, Missing: 1},
+	}
+
+	mismatches := deepValidationMismatches(report)
+
+	want := []ValidationMismatch{
+		{Field: "ref_diffs", SourceValue: 0, DestValue: 1, Critical: true},
+		{Field: "releases_count", SourceValue: 3, DestValue: 2, Critical: false},
+		{Field: "lfs_missing_objects", SourceValue: 0, DestValue: 1, Critical: true},
+	}
+	if !reflect.DeepEqual(mismatches, want) {
+		t.Errorf("mismatches = %+v, want %+v", mismatches, want)
+	}
+
+	if got := deepValidationMismatches(&models.DeepValidationReport{}); len(got) != 0 {
+		t.Errorf("expected no mismatches for a clean report, got %+v", got)
+	}
+}
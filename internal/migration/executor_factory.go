@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
+	gitsource "github.com/kuhlman-labs/github-migrator/internal/source"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
 
@@ -33,6 +35,16 @@ type ExecutorFactory struct {
 	logger            *slog.Logger
 	postMigrationMode PostMigrationMode
 	configProvider    MigrationConfigProvider // Dynamic config provider (optional)
+	elmClient         *ELMClient              // Enterprise Live Migrator client (optional)
+	gitMirror         GitMirrorOptions        // Git mirror push options
+	deepValidation    bool                    // Run deep post-migration validation
+	referencePRs      bool                    // Open reference rewrite pull requests after migration
+	codeownersRewrite CodeownersRewriteMode   // How CODEOWNERS is rewritten after migration
+	collabGrants      CollaboratorGrantMode   // Which direct collaborator permissions are re-granted after migration
+	settingsSync      []string                // Repository settings synced from the source after migration
+	protectionReplay  bool                    // Recreate branch protections and rulesets after production migrations
+	webhookReplay     bool                    // Recreate source webhooks, inactive, after production migrations
+	actionsReplay     bool                    // Recreate Actions environments, variables and placeholder secrets after production migrations
 
 	// Static fallback values used when no configProvider is set
 	staticDestRepoExistsAction DestinationRepoExistsAction
@@ -52,6 +64,16 @@ type ExecutorFactoryConfig struct {
 	DestRepoExistsAction DestinationRepoExistsAction
 	VisibilityHandling   VisibilityHandling
 	ConfigProvider       MigrationConfigProvider // Optional: provides dynamic settings
+	ELMClient            *ELMClient              // Optional: enables batches with migration_api=ELM
+	GitMirror            GitMirrorOptions        // Optional: LFS and ref filter options for batches with migration_api=GIT
+	DeepValidation       bool                    // Optional: compare every ref, artifact counts and LFS objects after migration
+	ReferenceRewritePRs  bool                    // Optional: open a pull request rewriting cross-repo references after migration
+	CodeownersRewrite    CodeownersRewriteMode   // Optional: rewrite CODEOWNERS with the team and user mappings after migration
+	CollaboratorGrants   CollaboratorGrantMode   // Optional: re-grant direct collaborator permissions through the user mappings after migration
+	SettingsSync         []string                // Optional: repository settings to sync from the source after migration (see SyncableSettings)
+	ProtectionReplay     bool                    // Optional: recreate branch protections and rulesets after production migrations
+	WebhookReplay        bool                    // Optional: recreate source webhooks, inactive, after production migrations
+	ActionsReplay        bool                    // Optional: recreate Actions environments, variables and placeholder secrets after production migrations
 }
 
 // NewExecutorFactory creates a new executor factory
@@ -91,6 +113,16 @@ func NewExecutorFactory(cfg ExecutorFactoryConfig) (*ExecutorFactory, error) {
 		logger:                     cfg.Logger,
 		postMigrationMode:          postMigMode,
 		configProvider:             cfg.ConfigProvider,
+		elmClient:                  cfg.ELMClient,
+		gitMirror:                  cfg.GitMirror,
+		deepValidation:             cfg.DeepValidation,
+		referencePRs:               cfg.ReferenceRewritePRs,
+		codeownersRewrite:          cfg.CodeownersRewrite,
+		collabGrants:               cfg.CollaboratorGrants,
+		settingsSync:               cfg.SettingsSync,
+		protectionReplay:           cfg.ProtectionReplay,
+		webhookReplay:              cfg.WebhookReplay,
+		actionsReplay:              cfg.ActionsReplay,
 		staticDestRepoExistsAction: destRepoAction,
 		staticVisibilityHandling:   visibilityHandling,
 		executorCache:              make(map[int64]*Executor),
@@ -177,6 +209,16 @@ func (f *ExecutorFactory) createExecutorForSource(source *models.Source) (*Execu
 		PostMigrationMode:    f.postMigrationMode,
 		DestRepoExistsAction: f.getDestRepoExistsAction(),
 		VisibilityHandling:   f.getVisibilityHandling(),
+		ELMClient:            f.elmClient,
+		GitMirror:            f.gitMirror,
+		DeepValidation:       f.deepValidation,
+		ReferenceRewritePRs:  f.referencePRs,
+		CodeownersRewrite:    f.codeownersRewrite,
+		CollaboratorGrants:   f.collabGrants,
+		SettingsSync:         f.settingsSync,
+		ProtectionReplay:     f.protectionReplay,
+		WebhookReplay:        f.webhookReplay,
+		ActionsReplay:        f.actionsReplay,
 	}
 
 	if source.IsGitHub() {
@@ -215,6 +257,9 @@ func (f *ExecutorFactory) createExecutorForSource(source *models.Source) (*Execu
 		cfg.SourceClient = client
 		cfg.SourceURL = source.BaseURL
 		cfg.SourceToken = source.Token
+		cfg.SourceProvider = f.newGitMirrorProvider(source, func() (gitsource.Provider, error) {
+			return gitsource.NewGitHubProvider(source.BaseURL, source.Token)
+		})
 
 	} else if source.IsAzureDevOps() {
 		// ADO sources don't use a GitHub source client
@@ -222,6 +267,46 @@ func (f *ExecutorFactory) createExecutorForSource(source *models.Source) (*Execu
 		cfg.SourceClient = nil
 		cfg.SourceURL = source.BaseURL
 		cfg.SourceToken = source.Token
+		cfg.SourceProvider = f.newGitMirrorProvider(source, func() (gitsource.Provider, error) {
+			organization := ""
+			if source.Organization != nil {
+				organization = *source.Organization
+			}
+			return gitsource.NewAzureDevOpsProvider(organization, source.Token, "")
+		})
+	} else if source.IsGitLab() {
+		// GitLab sources are not supported by GEI - the git data is mirror-pushed
+		// from a clone and the project settings are recreated through the GitLab API
+		provider, err := gitsource.NewGitLabProvider(source.BaseURL, source.Token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab provider: %w", err)
+		}
+
+		client, err := gitlab.NewClient(gitlab.ClientConfig{
+			BaseURL: source.BaseURL,
+			Token:   source.Token,
+			Logger:  f.logger,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab client: %w", err)
+		}
+
+		cfg.SourceClient = nil
+		cfg.SourceURL = source.BaseURL
+		cfg.SourceToken = source.Token
+		cfg.SourceProvider = provider
+		cfg.GitLabClient = client
+	} else if source.IsBitbucket() {
+		// Bitbucket Server is not supported by GEI - repositories are always mirror-pushed
+		provider, err := gitsource.NewBitbucketProvider(source.BaseURL, source.Token, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Bitbucket provider: %w", err)
+		}
+
+		cfg.SourceClient = nil
+		cfg.SourceURL = source.BaseURL
+		cfg.SourceToken = source.Token
+		cfg.SourceProvider = provider
 	} else {
 		return nil, fmt.Errorf("unsupported source type: %s", source.Type)
 	}
@@ -229,6 +314,21 @@ func (f *ExecutorFactory) createExecutorForSource(source *models.Source) (*Execu
 	return NewExecutor(cfg)
 }
 
+// newGitMirrorProvider creates the git provider used when a GEI source is migrated in a
+// batch using the GIT migration API. GEI migrations don't need it, so a failure is
+// logged and the git-only strategy reports the missing provider during validation.
+func (f *ExecutorFactory) newGitMirrorProvider(source *models.Source, create func() (gitsource.Provider, error)) gitsource.Provider {
+	provider, err := create()
+	if err != nil {
+		f.logger.Debug("Git mirror push unavailable for source",
+			"source_id", source.ID,
+			"source_name", source.Name,
+			"error", err)
+		return nil
+	}
+	return provider
+}
+
 // InvalidateCache removes a cached executor for the given source ID.
 // Call this when source credentials are updated.
 func (f *ExecutorFactory) InvalidateCache(sourceID int64) {
@@ -263,6 +363,61 @@ func (f *ExecutorFactory) ExecuteWithStrategy(ctx context.Context, repo *models.
 	return executor.ExecuteWithStrategy(ctx, repo, batch, dryRun)
 }
 
+// ResumeWithStrategy resumes an in-flight migration for the repository after a restart.
+// It returns ErrNoCheckpoint when there is nothing to resume.
+func (f *ExecutorFactory) ResumeWithStrategy(ctx context.Context, repo *models.Repository, batch *models.Batch) error {
+	executor, err := f.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to get executor: %w", err)
+	}
+
+	return executor.ResumeWithStrategy(ctx, repo, batch)
+}
+
+// Rollback rolls back a completed migration for the repository using the appropriate source.
+// See Executor.Rollback for the steps performed.
+func (f *ExecutorFactory) Rollback(ctx context.Context, repo *models.Repository, opts RollbackOptions) (*RollbackResult, error) {
+	executor, err := f.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executor: %w", err)
+	}
+
+	return executor.Rollback(ctx, repo, opts)
+}
+
+// OpenReferencePR opens the reference rewrite pull request for a migrated repository
+// using the executor for the repository's source
+func (f *ExecutorFactory) OpenReferencePR(ctx context.Context, repo *models.Repository) (*models.ReferencePullRequest, error) {
+	executor, err := f.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executor: %w", err)
+	}
+
+	return executor.OpenReferencePR(ctx, repo)
+}
+
+// RewriteCodeowners rewrites the repository's CODEOWNERS file on the destination using the
+// executor for the repository's source.
+func (f *ExecutorFactory) RewriteCodeowners(ctx context.Context, repo *models.Repository, mode CodeownersRewriteMode) (*models.CodeownersRewrite, error) {
+	executor, err := f.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executor: %w", err)
+	}
+
+	return executor.RewriteCodeowners(ctx, repo, mode)
+}
+
+// GrantCollaborators re-grants the repository's direct collaborator permissions on the
+// destination using the executor for the repository's source.
+func (f *ExecutorFactory) GrantCollaborators(ctx context.Context, repo *models.Repository, mode CollaboratorGrantMode) ([]*models.CollaboratorGrant, error) {
+	executor, err := f.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executor: %w", err)
+	}
+
+	return executor.GrantCollaborators(ctx, repo, mode)
+}
+
 // ExecuteMigration implements the MigrationExecutor interface for compatibility with batch scheduler.
 // It routes to ExecuteWithStrategy internally.
 func (f *ExecutorFactory) ExecuteMigration(ctx context.Context, repo *models.Repository, batch *models.Batch, dryRun bool) error {
@@ -231,7 +231,7 @@ func TestExecutorFactory_GetExecutorForRepository_UnsupportedSourceType(t *testi
 
 	// Use raw SQL to insert unsupported type since model validation would reject it
 	err := db.DB().Exec(`INSERT INTO sources (name, type, base_url, token, is_active) VALUES (?, ?, ?, ?, ?)`,
-		"Unsupported Source", "gitlab", "https://gitlab.example.com", "test-token", true).Error
+		"Unsupported Source", "perforce", "https://perforce.example.com", "test-token", true).Error
 	if err != nil {
 		t.Fatalf("Failed to create source: %v", err)
 	}
@@ -259,6 +259,46 @@ func TestExecutorFactory_GetExecutorForRepository_UnsupportedSourceType(t *testi
 	}
 }
 
+func TestExecutorFactory_GetExecutorForRepository_GitLabSource(t *testing.T) {
+	factory, db := setupTestFactory(t)
+
+	ctx := context.Background()
+
+	source := &models.Source{
+		Name:    "GitLab",
+		Type:    models.SourceConfigTypeGitLab,
+		BaseURL: "https://gitlab.example.com",
+		Token:   "glpat-test",
+	}
+	if err := db.CreateSource(ctx, source); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+
+	repo := &models.Repository{
+		ID:       1,
+		FullName: "group/subgroup/project",
+		Source:   models.SourceGitLab,
+		SourceID: &source.ID,
+	}
+
+	executor, err := factory.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		t.Fatalf("GetExecutorForRepository() error: %v", err)
+	}
+	if executor.sourceClient != nil {
+		t.Error("Expected nil source client for GitLab source")
+	}
+	if executor.sourceProvider == nil {
+		t.Error("Expected source provider for GitLab source")
+	}
+	if executor.gitlabClient == nil {
+		t.Error("Expected GitLab client for GitLab source")
+	}
+	if executor.sourceURL != "https://gitlab.example.com" {
+		t.Errorf("sourceURL = %s, want https://gitlab.example.com", executor.sourceURL)
+	}
+}
+
 func TestExecutorFactory_CacheInvalidation(t *testing.T) {
 	factory, _ := setupTestFactory(t)
 
@@ -398,3 +438,40 @@ func TestExecutorFactory_ConcurrentAccess(t *testing.T) {
 		}
 	}
 }
+
+func TestExecutorFactory_GetExecutorForRepository_BitbucketSource(t *testing.T) {
+	factory, db := setupTestFactory(t)
+
+	ctx := context.Background()
+
+	source := &models.Source{
+		Name:    "Bitbucket",
+		Type:    models.SourceConfigTypeBitbucket,
+		BaseURL: "https://bitbucket.example.com",
+		Token:   "bb-token",
+	}
+	if err := db.CreateSource(ctx, source); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+
+	repo := &models.Repository{
+		ID:       1,
+		FullName: "PROJ/api",
+		Source:   models.SourceBitbucket,
+		SourceID: &source.ID,
+	}
+
+	executor, err := factory.GetExecutorForRepository(ctx, repo)
+	if err != nil {
+		t.Fatalf("GetExecutorForRepository() error: %v", err)
+	}
+	if executor.sourceClient != nil {
+		t.Error("Expected nil source client for Bitbucket source")
+	}
+	if executor.sourceProvider == nil {
+		t.Error("Expected source provider for Bitbucket source")
+	}
+	if executor.sourceURL != "https://bitbucket.example.com" {
+		t.Errorf("sourceURL = %s, want https://bitbucket.example.com", executor.sourceURL)
+	}
+}
@@ -0,0 +1,40 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	ghapi "github.com/google/go-github/v75/github"
+)
+
+// destinationFileChange is new content for an existing file on the destination
+type destinationFileChange struct {
+	Path    string
+	Content string
+	SHA     string // Blob SHA of the file being replaced
+	Message string // Commit message
+}
+
+// openFileChangesPR commits file changes to a new branch off base on the destination and
+// opens a pull request for them. Fails when the branch already exists, so an earlier pull
+// request is never overwritten.
+func (e *Executor) openFileChangesPR(ctx context.Context, destOrg, destName, base, branch, title, body string, changes []destinationFileChange) (*ghapi.PullRequest, error) {
+	baseSHA, err := e.destClient.GetBranchSHA(ctx, destOrg, destName, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", base, err)
+	}
+	if err := e.destClient.CreateBranch(ctx, destOrg, destName, branch, baseSHA); err != nil {
+		return nil, fmt.Errorf("failed to create branch %s (merge or delete an earlier one before reopening): %w", branch, err)
+	}
+	for _, change := range changes {
+		if err := e.destClient.UpdateFile(ctx, destOrg, destName, change.Path, branch, change.Message, change.Content, change.SHA); err != nil {
+			return nil, fmt.Errorf("failed to commit %s: %w", change.Path, err)
+		}
+	}
+
+	pr, err := e.destClient.CreatePullRequest(ctx, destOrg, destName, title, branch, base, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pull request: %w", err)
+	}
+	return pr, nil
+}
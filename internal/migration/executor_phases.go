@@ -2,6 +2,7 @@ package migration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -21,6 +22,7 @@ type MigrationContext struct {
 	ExcludeReleases    bool
 	ExcludeAttachments bool
 	LockRepositories   bool
+	PreSeed            bool // Dry run of a delta sync batch, kept as the seed for its cutover
 
 	// Migration state
 	ArchiveIDs  *ArchiveIDs
@@ -37,6 +39,7 @@ func (e *Executor) NewMigrationContext(repo *models.Repository, batch *models.Ba
 		ExcludeReleases:    e.shouldExcludeReleases(repo, batch),
 		ExcludeAttachments: e.shouldExcludeAttachments(repo, batch),
 		LockRepositories:   !dryRun,
+		PreSeed:            dryRun && batch.UsesDeltaSync(),
 	}
 }
 
@@ -46,8 +49,8 @@ func (e *Executor) phasePreMigration(ctx context.Context, mc *MigrationContext)
 	e.logger.Info("Running pre-migration validation", "repo", mc.Repo.FullName)
 	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "pre_migration", "validate", "Running pre-migration validation", nil)
 
-	// Run discovery on source repository for production migrations to get latest stats
-	if !mc.DryRun {
+	// Run discovery on source repository for production migrations and pre-seeds to get latest stats
+	if !mc.DryRun || mc.PreSeed {
 		e.logger.Info("Running pre-migration discovery to refresh repository data", "repo", mc.Repo.FullName)
 		e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "pre_migration", "discovery", "Refreshing repository characteristics", nil)
 
@@ -105,6 +108,9 @@ func (e *Executor) phaseArchiveGeneration(ctx context.Context, mc *MigrationCont
 	}
 
 	mc.ArchiveIDs = archiveIDs
+	if err := e.saveCheckpoint(ctx, mc, models.CheckpointArchivesGenerating); err != nil {
+		return err
+	}
 	details := fmt.Sprintf("Git Archive ID: %d, Metadata Archive ID: %d", archiveIDs.GitArchiveID, archiveIDs.MetadataArchiveID)
 	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "archive_generation", "initiate", "Archive generation initiated successfully", &details)
 
@@ -136,6 +142,9 @@ func (e *Executor) phaseArchivePolling(ctx context.Context, mc *MigrationContext
 	}
 
 	mc.ArchiveURLs = archiveURLs
+	if err := e.saveCheckpoint(ctx, mc, models.CheckpointArchivesReady); err != nil {
+		return err
+	}
 	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "archive_generation", "complete", "Archives generated successfully", nil)
 
 	return nil
@@ -183,9 +192,22 @@ func (e *Executor) phaseMigrationPolling(ctx context.Context, mc *MigrationConte
 	return nil
 }
 
-// phasePostMigration runs post-migration validation.
+// phasePostMigration syncs repository settings, replays branch protections, rulesets, Actions settings and
+// webhooks, optionally opens the reference rewrite pull request, and runs post-migration validation.
 // Phase 6: Validates the migration was successful.
 func (e *Executor) phasePostMigration(ctx context.Context, mc *MigrationContext) error {
+	// The replays only change production destinations and each has its own setting, so they run
+	// whatever the post-migration mode, which controls validation alone. CODEOWNERS is rewritten
+	// before protections are replayed so a direct commit to the default branch is not blocked, and
+	// everything is replayed before validation so it compares settings as they end up
+	e.rewriteCodeownersAfterMigration(ctx, mc)
+	settingsReport := e.syncSettings(ctx, mc)
+	e.replayProtections(ctx, mc)
+	e.replayActionsSettings(ctx, mc)
+	e.replayWebhooks(ctx, mc)
+	e.grantCollaboratorsAfterMigration(ctx, mc)
+	e.openReferencePRAfterMigration(ctx, mc)
+
 	if !e.shouldRunPostMigration(mc.DryRun) {
 		reason := fmt.Sprintf("Skipping post-migration validation (mode: %s, dry_run: %v)", e.postMigrationMode, mc.DryRun)
 		e.logger.Info(reason, "repo", mc.Repo.FullName)
@@ -196,7 +218,7 @@ func (e *Executor) phasePostMigration(ctx context.Context, mc *MigrationContext)
 	e.logger.Info("Running post-migration validation", "repo", mc.Repo.FullName, "mode", e.postMigrationMode)
 	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "post_migration", "validate", "Running post-migration validation", nil)
 
-	if err := e.validatePostMigration(ctx, mc.Repo); err != nil {
+	if err := e.validatePostMigration(ctx, mc.Repo, mc.Batch, settingsReport); err != nil {
 		errMsg := err.Error()
 		e.logOperation(ctx, mc.Repo, mc.HistoryID, "WARN", "post_migration", "validate", "Post-migration validation failed", &errMsg)
 		// Don't fail the migration on validation warnings
@@ -220,6 +242,11 @@ func (e *Executor) phaseCompletion(ctx context.Context, mc *MigrationContext) er
 		e.unlockSourceRepository(ctx, mc.Repo)
 	}
 
+	// Run the batch's completion actions on the source once it is unlocked
+	if !mc.DryRun {
+		e.runCompletionActions(ctx, mc.Repo, mc.Batch, mc.HistoryID)
+	}
+
 	if mc.DryRun {
 		completionStatus = models.StatusDryRunComplete
 		completionMsg = msgDryRunComplete
@@ -245,6 +272,11 @@ func (e *Executor) phaseCompletion(ctx context.Context, mc *MigrationContext) er
 // handlePhaseError handles error recovery for a phase failure.
 // This centralizes the error recovery logic used across all phases.
 func (e *Executor) handlePhaseError(ctx context.Context, mc *MigrationContext, err error) {
+	// The replica that took the migration over records its outcome
+	if errors.Is(err, ErrLeaseLost) {
+		return
+	}
+
 	errMsg := err.Error()
 	e.updateHistoryStatus(ctx, mc.HistoryID, statusFailed, &errMsg)
 
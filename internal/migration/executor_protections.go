@@ -0,0 +1,434 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// Migration log operations for replayed protection rules
+const (
+	opBranchProtection = "branch_protection"
+	opRuleset          = "ruleset"
+)
+
+// replayProtections recreates the source repository's branch protections and rulesets on
+// the destination. GEI does not migrate rulesets or every protection setting, so the full
+// definitions are read from the source and applied again. Users and teams are remapped
+// through the user and team mappings; actors without a mapping are dropped. Every rule
+// applied, skipped or failed is recorded in the migration logs. Dry runs are skipped, as is every
+// migration when the replay is turned off. Failures never fail the migration.
+func (e *Executor) replayProtections(ctx context.Context, mc *MigrationContext) {
+	repo := mc.Repo
+	if !e.protectionReplay || mc.DryRun {
+		return
+	}
+	if e.sourceClient == nil {
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opBranchProtection,
+			"Skipping branch protection and ruleset replay (source is not a GitHub repository)", nil)
+		return
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return
+	}
+
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+	mapper := &actorMapper{
+		e:         e,
+		sourceOrg: repo.Organization(),
+		destOrg:   destOrg,
+	}
+
+	e.replayBranchProtections(ctx, mc, mapper, destOrg, destName)
+	e.replayRulesets(ctx, mc, mapper, destOrg, destName)
+}
+
+// replayBranchProtections copies the protection rules of every protected source branch to the destination
+func (e *Executor) replayBranchProtections(ctx context.Context, mc *MigrationContext, mapper *actorMapper, destOrg, destName string) {
+	repo := mc.Repo
+
+	branches, err := e.sourceClient.ListProtectedBranches(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opBranchProtection, "Failed to list protected source branches", err)
+		return
+	}
+
+	applied := 0
+	for _, branch := range branches {
+		protection, err := e.sourceClient.GetBranchProtection(ctx, repo.Organization(), repo.Name(), branch)
+		if err != nil {
+			e.warnReplay(ctx, mc, opBranchProtection, fmt.Sprintf("Skipped branch protection for %s: failed to read source protection", branch), err)
+			continue
+		}
+
+		request, dropped := protectionRequestFromSource(protection,
+			func(login string) (string, bool) { return mapper.user(ctx, login) },
+			func(slug string) (string, bool) { return mapper.team(ctx, slug) })
+
+		if err := e.destClient.UpdateBranchProtection(ctx, destOrg, destName, branch, request); err != nil {
+			e.warnReplay(ctx, mc, opBranchProtection, fmt.Sprintf("Failed to apply branch protection to %s", branch), err)
+			continue
+		}
+		if protection.RequiredSignatures != nil && protection.RequiredSignatures.GetEnabled() {
+			if err := e.destClient.RequireSignaturesOnProtectedBranch(ctx, destOrg, destName, branch); err != nil {
+				e.warnReplay(ctx, mc, opBranchProtection, fmt.Sprintf("Failed to require signed commits on %s", branch), err)
+			}
+		}
+
+		applied++
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opBranchProtection,
+			fmt.Sprintf("Applied branch protection to %s", branch), droppedActorsDetails(dropped))
+	}
+
+	if len(branches) > 0 {
+		e.logger.Info("Replayed branch protections", "repo", repo.FullName, "applied", applied, "total", len(branches))
+	}
+}
+
+// replayRulesets creates the source repository's rulesets on the destination.
+// Rulesets that already exist on the destination (by name) are skipped.
+func (e *Executor) replayRulesets(ctx context.Context, mc *MigrationContext, mapper *actorMapper, destOrg, destName string) {
+	repo := mc.Repo
+
+	rulesets, err := e.sourceClient.ListRepositoryRulesets(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opRuleset, "Failed to list source rulesets", err)
+		return
+	}
+	if len(rulesets) == 0 {
+		return
+	}
+
+	existing := make(map[string]bool)
+	destRulesets, err := e.destClient.ListRepositoryRulesets(ctx, destOrg, destName)
+	if err != nil {
+		e.warnReplay(ctx, mc, opRuleset, "Failed to list destination rulesets", err)
+		return
+	}
+	for _, ruleset := range destRulesets {
+		existing[ruleset.Name] = true
+	}
+
+	// App IDs are only meaningful on the instance that issued them
+	sameInstance := e.sourceClient.BaseURL() == e.destClient.BaseURL()
+
+	applied := 0
+	for _, ruleset := range rulesets {
+		if existing[ruleset.Name] {
+			e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opRuleset,
+				fmt.Sprintf("Skipped ruleset %q: already exists on the destination", ruleset.Name), nil)
+			continue
+		}
+
+		request, dropped := rulesetForDestination(ruleset, sameInstance,
+			func(teamID int64) (int64, string, bool) { return mapper.teamID(ctx, teamID) })
+
+		if _, err := e.destClient.CreateRepositoryRuleset(ctx, destOrg, destName, request); err != nil {
+			e.warnReplay(ctx, mc, opRuleset, fmt.Sprintf("Failed to create ruleset %q", ruleset.Name), err)
+			continue
+		}
+
+		applied++
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opRuleset,
+			fmt.Sprintf("Created ruleset %q", ruleset.Name), droppedActorsDetails(dropped))
+	}
+
+	e.logger.Info("Replayed rulesets", "repo", repo.FullName, "applied", applied, "total", len(rulesets))
+}
+
+// warnReplay logs a repository setting that could not be replayed on the destination
+func (e *Executor) warnReplay(ctx context.Context, mc *MigrationContext, operation, message string, err error) {
+	errMsg := err.Error()
+	e.logger.Warn(message, "repo", mc.Repo.FullName, "error", err)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "WARN", "post_migration", operation, message, &errMsg)
+}
+
+// droppedActorsDetails describes the actors left out of a replayed rule, or nil when none were
+func droppedActorsDetails(dropped []string) *string {
+	if len(dropped) == 0 {
+		return nil
+	}
+	details := "Dropped actors without a destination mapping: " + strings.Join(dropped, ", ")
+	return &details
+}
+
+// protectionRequestFromSource converts a source branch protection into a request for the destination.
+// Users and teams are remapped with mapUser and mapTeam; those without a mapping are left out and
+// returned as dropped. Status checks are no longer tied to the source's GitHub Apps.
+func protectionRequestFromSource(p *ghapi.Protection, mapUser, mapTeam func(string) (string, bool)) (*ghapi.ProtectionRequest, []string) {
+	var dropped []string
+	users := func(source []*ghapi.User) []string {
+		mapped := []string{}
+		for _, user := range source {
+			if login, ok := mapUser(user.GetLogin()); ok {
+				mapped = append(mapped, login)
+			} else {
+				dropped = append(dropped, "user "+user.GetLogin())
+			}
+		}
+		return mapped
+	}
+	teams := func(source []*ghapi.Team) []string {
+		mapped := []string{}
+		for _, team := range source {
+			if slug, ok := mapTeam(team.GetSlug()); ok {
+				mapped = append(mapped, slug)
+			} else {
+				dropped = append(dropped, "team "+team.GetSlug())
+			}
+		}
+		return mapped
+	}
+	apps := func(source []*ghapi.App) []string {
+		slugs := []string{}
+		for _, app := range source {
+			slugs = append(slugs, app.GetSlug())
+		}
+		return slugs
+	}
+
+	request := &ghapi.ProtectionRequest{
+		EnforceAdmins: p.EnforceAdmins != nil && p.EnforceAdmins.Enabled,
+	}
+
+	if checks := p.RequiredStatusChecks; checks != nil {
+		request.RequiredStatusChecks = &ghapi.RequiredStatusChecks{Strict: checks.Strict}
+		if checks.Checks != nil {
+			converted := make([]*ghapi.RequiredStatusCheck, 0, len(*checks.Checks))
+			for _, check := range *checks.Checks {
+				converted = append(converted, &ghapi.RequiredStatusCheck{Context: check.Context})
+			}
+			request.RequiredStatusChecks.Checks = &converted
+		} else {
+			contexts := []string{}
+			if checks.Contexts != nil {
+				contexts = *checks.Contexts
+			}
+			request.RequiredStatusChecks.Contexts = &contexts
+		}
+	}
+
+	if reviews := p.RequiredPullRequestReviews; reviews != nil {
+		request.RequiredPullRequestReviews = &ghapi.PullRequestReviewsEnforcementRequest{
+			DismissStaleReviews:          reviews.DismissStaleReviews,
+			RequireCodeOwnerReviews:      reviews.RequireCodeOwnerReviews,
+			RequiredApprovingReviewCount: reviews.RequiredApprovingReviewCount,
+			RequireLastPushApproval:      ghapi.Ptr(reviews.RequireLastPushApproval),
+		}
+		if restrictions := reviews.DismissalRestrictions; restrictions != nil {
+			dismissUsers := users(restrictions.Users)
+			dismissTeams := teams(restrictions.Teams)
+			dismissApps := apps(restrictions.Apps)
+			request.RequiredPullRequestReviews.DismissalRestrictionsRequest = &ghapi.DismissalRestrictionsRequest{
+				Users: &dismissUsers,
+				Teams: &dismissTeams,
+				Apps:  &dismissApps,
+			}
+		}
+		if allowances := reviews.BypassPullRequestAllowances; allowances != nil {
+			request.RequiredPullRequestReviews.BypassPullRequestAllowancesRequest = &ghapi.BypassPullRequestAllowancesRequest{
+				Users: users(allowances.Users),
+				Teams: teams(allowances.Teams),
+				Apps:  apps(allowances.Apps),
+			}
+		}
+	}
+
+	if restrictions := p.Restrictions; restrictions != nil {
+		request.Restrictions = &ghapi.BranchRestrictionsRequest{
+			Users: users(restrictions.Users),
+			Teams: teams(restrictions.Teams),
+			Apps:  apps(restrictions.Apps),
+		}
+	}
+
+	if p.RequireLinearHistory != nil {
+		request.RequireLinearHistory = ghapi.Ptr(p.RequireLinearHistory.Enabled)
+	}
+	if p.AllowForcePushes != nil {
+		request.AllowForcePushes = ghapi.Ptr(p.AllowForcePushes.Enabled)
+	}
+	if p.AllowDeletions != nil {
+		request.AllowDeletions = ghapi.Ptr(p.AllowDeletions.Enabled)
+	}
+	if p.RequiredConversationResolution != nil {
+		request.RequiredConversationResolution = ghapi.Ptr(p.RequiredConversationResolution.Enabled)
+	}
+	if p.BlockCreations != nil {
+		request.BlockCreations = p.BlockCreations.Enabled
+	}
+	if p.LockBranch != nil {
+		request.LockBranch = p.LockBranch.Enabled
+	}
+	if p.AllowForkSyncing != nil {
+		request.AllowForkSyncing = p.AllowForkSyncing.Enabled
+	}
+
+	return request, dropped
+}
+
+// rulesetForDestination converts a source ruleset into a ruleset to create on the destination.
+// Team bypass actors are remapped with mapTeamID, which returns the destination team ID and the
+// source team slug; teams without a mapping are dropped. GitHub App bypass actors are only kept
+// when the source and destination are the same GitHub instance.
+func rulesetForDestination(source *ghapi.RepositoryRuleset, sameInstance bool, mapTeamID func(int64) (int64, string, bool)) (ghapi.RepositoryRuleset, []string) {
+	var dropped []string
+
+	ruleset := ghapi.RepositoryRuleset{
+		Name:        source.Name,
+		Target:      source.Target,
+		Enforcement: source.Enforcement,
+		Conditions:  source.Conditions,
+		Rules:       source.Rules,
+	}
+
+	for _, actor := range source.BypassActors {
+		actorType := ghapi.BypassActorType("")
+		if actor.ActorType != nil {
+			actorType = *actor.ActorType
+		}
+
+		switch actorType {
+		case ghapi.BypassActorTypeTeam:
+			destID, slug, ok := mapTeamID(actor.GetActorID())
+			if !ok {
+				if slug == "" {
+					slug = fmt.Sprintf("%d", actor.GetActorID())
+				}
+				dropped = append(dropped, "team "+slug)
+				continue
+			}
+			ruleset.BypassActors = append(ruleset.BypassActors, &ghapi.BypassActor{
+				ActorID:    ghapi.Ptr(destID),
+				ActorType:  actor.ActorType,
+				BypassMode: actor.BypassMode,
+			})
+		case ghapi.BypassActorTypeIntegration:
+			if !sameInstance {
+				dropped = append(dropped, fmt.Sprintf("app %d", actor.GetActorID()))
+				continue
+			}
+			ruleset.BypassActors = append(ruleset.BypassActors, actor)
+		default:
+			// Organization admins, repository roles and deploy keys are not tied to an identity
+			ruleset.BypassActors = append(ruleset.BypassActors, actor)
+		}
+	}
+
+	return ruleset, dropped
+}
+
+// actorMapper resolves source users and teams to their destination counterparts
+// through the user and team mappings. Lookups are cached for the duration of a replay.
+type actorMapper struct {
+	e         *Executor
+	sourceOrg string
+	destOrg   string
+
+	sourceTeamSlugs map[int64]string // Source team ID -> slug, loaded on first use
+	destTeamIDs     map[string]int64 // Destination team slug -> ID (0 when the team does not exist)
+	destUserIDs     map[string]int64 // Destination login -> ID (0 when the user does not exist)
+}
+
+// user returns the destination login for a source user
+func (m *actorMapper) user(ctx context.Context, login string) (string, bool) {
+	mapping, err := m.e.storage.GetUserMappingBySourceLogin(ctx, login)
+	if err != nil {
+		m.e.logger.Warn("Failed to look up user mapping", "login", login, "error", err)
+		return "", false
+	}
+	if mapping == nil || mapping.MappingStatus == string(models.UserMappingStatusSkipped) ||
+		mapping.DestinationLogin == nil || *mapping.DestinationLogin == "" {
+		return "", false
+	}
+	return *mapping.DestinationLogin, true
+}
+
+// team returns the destination slug for a source team.
+// Teams mapped to a different organization cannot be granted access to the repository.
+func (m *actorMapper) team(ctx context.Context, slug string) (string, bool) {
+	mapping, err := m.e.storage.GetTeamMapping(ctx, m.sourceOrg, slug)
+	if err != nil {
+		m.e.logger.Warn("Failed to look up team mapping", "org", m.sourceOrg, "team", slug, "error", err)
+		return "", false
+	}
+	if mapping == nil || mapping.MappingStatus == "skipped" ||
+		mapping.DestinationOrg == nil || *mapping.DestinationOrg != m.destOrg ||
+		mapping.DestinationTeamSlug == nil || *mapping.DestinationTeamSlug == "" {
+		return "", false
+	}
+	return *mapping.DestinationTeamSlug, true
+}
+
+// teamID returns the destination team ID for a source team ID, along with the source team's slug
+func (m *actorMapper) teamID(ctx context.Context, sourceTeamID int64) (int64, string, bool) {
+	if m.sourceTeamSlugs == nil {
+		m.sourceTeamSlugs = make(map[int64]string)
+		teams, err := m.e.sourceClient.ListOrganizationTeams(ctx, m.sourceOrg)
+		if err != nil {
+			m.e.logger.Warn("Failed to list source teams", "org", m.sourceOrg, "error", err)
+		}
+		for _, team := range teams {
+			m.sourceTeamSlugs[team.ID] = team.Slug
+		}
+	}
+
+	slug, ok := m.sourceTeamSlugs[sourceTeamID]
+	if !ok {
+		return 0, "", false
+	}
+	id, ok := m.teamIDBySlug(ctx, slug)
+	if !ok {
+		return 0, slug, false
+	}
+	return id, slug, true
+}
+
+// teamIDBySlug returns the destination team ID for a source team slug
+func (m *actorMapper) teamIDBySlug(ctx context.Context, slug string) (int64, bool) {
+	destSlug, ok := m.team(ctx, slug)
+	if !ok {
+		return 0, false
+	}
+
+	if m.destTeamIDs == nil {
+		m.destTeamIDs = make(map[string]int64)
+	}
+	id, cached := m.destTeamIDs[destSlug]
+	if !cached {
+		team, err := m.e.destClient.GetTeamBySlug(ctx, m.destOrg, destSlug)
+		if err != nil {
+			m.e.logger.Warn("Failed to look up destination team", "org", m.destOrg, "team", destSlug, "error", err)
+		}
+		if team != nil {
+			id = team.ID
+		}
+		m.destTeamIDs[destSlug] = id
+	}
+	return id, id != 0
+}
+
+// userID returns the destination user ID for a source user login
+func (m *actorMapper) userID(ctx context.Context, login string) (int64, bool) {
+	destLogin, ok := m.user(ctx, login)
+	if !ok {
+		return 0, false
+	}
+
+	if m.destUserIDs == nil {
+		m.destUserIDs = make(map[string]int64)
+	}
+	id, cached := m.destUserIDs[destLogin]
+	if !cached {
+		var err error
+		id, err = m.e.destClient.GetUserID(ctx, destLogin)
+		if err != nil {
+			m.e.logger.Warn("Failed to look up destination user", "login", destLogin, "error", err)
+		}
+		m.destUserIDs[destLogin] = id
+	}
+	return id, id != 0
+}
@@ -0,0 +1,205 @@
+package migration
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestProtectionRequestFromSource(t *testing.T) {
+	checks := []*ghapi.RequiredStatusCheck{{Context: "ci/build", AppID: ghapi.Ptr(int64(15368))}}
+	protection := &ghapi.Protection{
+		RequiredStatusChecks: &ghapi.RequiredStatusChecks{Strict: true, Checks: &checks},
+		RequiredPullRequestReviews: &ghapi.PullRequestReviewsEnforcement{
+			DismissStaleReviews:          true,
+			RequireCodeOwnerReviews:      true,
+			RequiredApprovingReviewCount: 2,
+			DismissalRestrictions: &ghapi.DismissalRestrictions{
+				Users: []*ghapi.User{{Login: ghapi.Ptr("alice")}},
+				Teams: []*ghapi.Team{{Slug: ghapi.Ptr("unmapped-team")}},
+			},
+			BypassPullRequestAllowances: &ghapi.BypassPullRequestAllowances{
+				Users: []*ghapi.User{{Login: ghapi.Ptr("bob")}},
+				Teams: []*ghapi.Team{{Slug: ghapi.Ptr("release-managers")}},
+				Apps:  []*ghapi.App{{Slug: ghapi.Ptr("dependabot")}},
+			},
+		},
+		EnforceAdmins: &ghapi.AdminEnforcement{Enabled: true},
+		Restrictions: &ghapi.BranchRestrictions{
+			Teams: []*ghapi.Team{{Slug: ghapi.Ptr("release-managers")}},
+		},
+		RequireLinearHistory: &ghapi.RequireLinearHistory{Enabled: true},
+		AllowForcePushes:     &ghapi.AllowForcePushes{Enabled: false},
+		LockBranch:           &ghapi.LockBranch{Enabled: ghapi.Ptr(true)},
+	}
+
+	users := map[string]string{"alice": "alice_corp"}
+	teams := map[string]string{"release-managers": "releasers"}
+	request, dropped := protectionRequestFromSource(protection,
+		func(login string) (string, bool) { v, ok := users[login]; return v, ok },
+		func(slug string) (string, bool) { v, ok := teams[slug]; return v, ok })
+
+	if !request.EnforceAdmins || !request.RequiredStatusChecks.Strict {
+		t.Errorf("expected enforce_admins and strict status checks, got %+v", request)
+	}
+	gotChecks := *request.RequiredStatusChecks.Checks
+	if len(gotChecks) != 1 || gotChecks[0].Context != "ci/build" || gotChecks[0].AppID != nil {
+		t.Errorf("expected status checks without the source app ID, got %+v", gotChecks)
+	}
+
+	reviews := request.RequiredPullRequestReviews
+	if reviews.RequiredApprovingReviewCount != 2 || !reviews.DismissStaleReviews || !reviews.RequireCodeOwnerReviews {
+		t.Errorf("unexpected review settings %+v", reviews)
+	}
+	if got := *reviews.DismissalRestrictionsRequest.Users; !reflect.DeepEqual(got, []string{"alice_corp"}) {
+		t.Errorf("dismissal users = %v, want [alice_corp]", got)
+	}
+	if got := *reviews.DismissalRestrictionsRequest.Teams; len(got) != 0 {
+		t.Errorf("dismissal teams = %v, want none", got)
+	}
+	bypass := reviews.BypassPullRequestAllowancesRequest
+	if len(bypass.Users) != 0 || !reflect.DeepEqual(bypass.Teams, []string{"releasers"}) || !reflect.DeepEqual(bypass.Apps, []string{"dependabot"}) {
+		t.Errorf("unexpected bypass allowances %+v", bypass)
+	}
+	if !reflect.DeepEqual(request.Restrictions.Teams, []string{"releasers"}) || request.Restrictions.Users == nil {
+		t.Errorf("unexpected push restrictions %+v", request.Restrictions)
+	}
+
+	if !*request.RequireLinearHistory || *request.AllowForcePushes || !*request.LockBranch {
+		t.Errorf("unexpected branch settings %+v", request)
+	}
+	if request.AllowDeletions != nil {
+		t.Error("expected settings missing on the source to be left unset")
+	}
+
+	if want := []string{"team unmapped-team", "user bob"}; !reflect.DeepEqual(dropped, want) {
+		t.Errorf("dropped = %v, want %v", dropped, want)
+	}
+}
+
+func TestRulesetForDestination(t *testing.T) {
+	teamType := ghapi.BypassActorTypeTeam
+	appType := ghapi.BypassActorTypeIntegration
+	roleType := ghapi.BypassActorTypeRepositoryRole
+	always := ghapi.BypassModeAlways
+	source := &ghapi.RepositoryRuleset{
+		ID:          ghapi.Ptr(int64(7)),
+		Name:        "protect-main",
+		Source:      "source-org/repo",
+		Enforcement: ghapi.RulesetEnforcementActive,
+		BypassActors: []*ghapi.BypassActor{
+			{ActorID: ghapi.Ptr(int64(11)), ActorType: &teamType, BypassMode: &always},
+			{ActorID: ghapi.Ptr(int64(12)), ActorType: &teamType, BypassMode: &always},
+			{ActorID: ghapi.Ptr(int64(99)), ActorType: &appType, BypassMode: &always},
+			{ActorID: ghapi.Ptr(int64(5)), ActorType: &roleType, BypassMode: &always},
+		},
+		Rules: &ghapi.RepositoryRulesetRules{Deletion: &ghapi.EmptyRuleParameters{}},
+	}
+	mapTeamID := func(id int64) (int64, string, bool) {
+		if id == 11 {
+			return 111, "platform", true
+		}
+		return 0, "unmapped", false
+	}
+
+	ruleset, dropped := rulesetForDestination(source, false, mapTeamID)
+
+	if ruleset.ID != nil || ruleset.Source != "" {
+		t.Errorf("expected source identifiers to be cleared, got ID=%v source=%q", ruleset.ID, ruleset.Source)
+	}
+	if ruleset.Name != "protect-main" || ruleset.Rules == nil || ruleset.Rules.Deletion == nil {
+		t.Errorf("expected the name and rules to be copied, got %+v", ruleset)
+	}
+	if len(ruleset.BypassActors) != 2 {
+		t.Fatalf("expected the mapped team and repository role, got %+v", ruleset.BypassActors)
+	}
+	if ruleset.BypassActors[0].GetActorID() != 111 || ruleset.BypassActors[1].GetActorID() != 5 {
+		t.Errorf("unexpected bypass actors %v, %v", ruleset.BypassActors[0].GetActorID(), ruleset.BypassActors[1].GetActorID())
+	}
+	if want := []string{"team unmapped", "app 99"}; !reflect.DeepEqual(dropped, want) {
+		t.Errorf("dropped = %v, want %v", dropped, want)
+	}
+
+	ruleset, _ = rulesetForDestination(source, true, mapTeamID)
+	if len(ruleset.BypassActors) != 3 {
+		t.Errorf("expected app bypass actors to be kept on the same instance, got %d actors", len(ruleset.BypassActors))
+	}
+}
+
+func TestActorMapper(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, db, _ := setupRollbackTest(t, fake)
+	ctx := context.Background()
+
+	destLogin := "alice_corp"
+	if err := db.SaveUserMapping(ctx, &models.UserMapping{SourceLogin: "alice", DestinationLogin: &destLogin, MappingStatus: "mapped"}); err != nil {
+		t.Fatalf("SaveUserMapping() error: %v", err)
+	}
+	if err := db.SaveUserMapping(ctx, &models.UserMapping{SourceLogin: "bob", DestinationLogin: &destLogin, MappingStatus: "skipped"}); err != nil {
+		t.Fatalf("SaveUserMapping() error: %v", err)
+	}
+	destOrg, otherOrg, destSlug := "dest-org", "other-org", "platform-team"
+	if err := db.SaveTeamMapping(ctx, &models.TeamMapping{SourceOrg: "source-org", SourceTeamSlug: "platform", DestinationOrg: &destOrg, DestinationTeamSlug: &destSlug, MappingStatus: "mapped"}); err != nil {
+		t.Fatalf("SaveTeamMapping() error: %v", err)
+	}
+	if err := db.SaveTeamMapping(ctx, &models.TeamMapping{SourceOrg: "source-org", SourceTeamSlug: "elsewhere", DestinationOrg: &otherOrg, DestinationTeamSlug: &destSlug, MappingStatus: "mapped"}); err != nil {
+		t.Fatalf("SaveTeamMapping() error: %v", err)
+	}
+
+	mapper := &actorMapper{e: executor, sourceOrg: "source-org", destOrg: "dest-org"}
+
+	if login, ok := mapper.user(ctx, "alice"); !ok || login != "alice_corp" {
+		t.Errorf("user(alice) = %q, %v, want alice_corp", login, ok)
+	}
+	if _, ok := mapper.user(ctx, "bob"); ok {
+		t.Error("expected skipped user mappings to be dropped")
+	}
+	if _, ok := mapper.user(ctx, "carol"); ok {
+		t.Error("expected unmapped users to be dropped")
+	}
+	if slug, ok := mapper.team(ctx, "platform"); !ok || slug != "platform-team" {
+		t.Errorf("team(platform) = %q, %v, want platform-team", slug, ok)
+	}
+	if _, ok := mapper.team(ctx, "elsewhere"); ok {
+		t.Error("expected teams mapped to another organization to be dropped")
+	}
+}
+
+func TestPhasePostMigration_ReplaysIndependentOfValidationMode(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("production migration with validation turned off", func(t *testing.T) {
+		executor, mc, requests := setupReplayTest(t, ExecutorConfig{
+			PostMigrationMode: PostMigrationNever,
+			ProtectionReplay:  true,
+		})
+
+		if err := executor.phasePostMigration(ctx, mc); err != nil {
+			t.Fatalf("phasePostMigration() error = %v", err)
+		}
+		if requests.Load() == 0 {
+			t.Error("Expected branch protections to be replayed")
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		executor, mc, requests := setupReplayTest(t, ExecutorConfig{
+			PostMigrationMode: PostMigrationNever,
+			ProtectionReplay:  true,
+			ActionsReplay:     true,
+			WebhookReplay:     true,
+			SettingsSync:      SyncableSettings,
+		})
+		mc.DryRun = true
+
+		if err := executor.phasePostMigration(ctx, mc); err != nil {
+			t.Fatalf("phasePostMigration() error = %v", err)
+		}
+		if n := requests.Load(); n != 0 {
+			t.Errorf("Expected nothing to be replayed on a dry run, got %d repository requests", n)
+		}
+	})
+}
@@ -0,0 +1,356 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// opReferences is the migration log operation for the reference rewrite pull request
+const opReferences = "reference_rewrite"
+
+// referenceRewriteBranch is the destination branch the rewritten files are committed to
+const referenceRewriteBranch = "migration/rewrite-references"
+
+// referenceRewrite maps a migrated dependency's source name to its destination name
+type referenceRewrite struct {
+	Source      string // owner/repo on the source
+	Destination string // owner/repo on the destination
+}
+
+// referenceFileChange is a file whose references were rewritten
+type referenceFileChange struct {
+	destinationFileChange
+	Rewritten int
+}
+
+// OpenReferencePR opens a pull request on the migrated repository that rewrites its
+// .gitmodules, workflow files and package manifests so references to other migrated
+// repositories point at their destination names and host. Dependencies that are tracked
+// but not migrated yet are listed as unresolved so the pull request can be reopened later.
+// The outcome is recorded for the repository and returned, including failures.
+func (e *Executor) OpenReferencePR(ctx context.Context, repo *models.Repository) (*models.ReferencePullRequest, error) {
+	record, err := e.openReferencePR(ctx, repo)
+	if err != nil {
+		errMsg := err.Error()
+		record.Status = models.ReferencePRStatusFailed
+		record.Error = &errMsg
+	}
+
+	if saveErr := e.storage.SaveReferencePullRequest(ctx, record); saveErr != nil {
+		return record, fmt.Errorf("failed to save reference pull request: %w", saveErr)
+	}
+	return record, err
+}
+
+// openReferencePR does the work of OpenReferencePR. The returned record is never nil.
+func (e *Executor) openReferencePR(ctx context.Context, repo *models.Repository) (*models.ReferencePullRequest, error) {
+	record := &models.ReferencePullRequest{RepositoryID: repo.ID, Branch: referenceRewriteBranch}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return record, fmt.Errorf("repository has no destination")
+	}
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+
+	deps, err := e.storage.GetRepositoryDependencies(ctx, repo.ID)
+	if err != nil {
+		return record, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	rewrites, unresolved, err := e.resolveReferenceRewrites(ctx, repo, deps)
+	if err != nil {
+		return record, err
+	}
+	if len(unresolved) > 0 {
+		joined := strings.Join(unresolved, ",")
+		record.Unresolved = &joined
+	}
+
+	files := referenceFiles(deps, rewrites)
+	if len(files) == 0 {
+		record.Status = models.ReferencePRStatusNoChanges
+		return record, nil
+	}
+
+	sourceHost := hostOf(repo.SourceURL)
+	destHost := hostOf(e.destClient.RepositoryURL(*repo.DestinationFullName))
+
+	destRepo, err := e.destClient.GetRepository(ctx, destOrg, destName)
+	if err != nil {
+		return record, fmt.Errorf("failed to get destination repository: %w", err)
+	}
+	baseBranch := destRepo.GetDefaultBranch()
+
+	var changes []referenceFileChange
+	for _, file := range files {
+		content, sha, found, err := e.destClient.GetFileContent(ctx, destOrg, destName, file, baseBranch)
+		if err != nil {
+			return record, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		if !found {
+			continue
+		}
+		rewritten, count := rewriteReferences(content, sourceHost, destHost, rewrites)
+		if count == 0 {
+			continue
+		}
+		changes = append(changes, referenceFileChange{
+			destinationFileChange: destinationFileChange{
+				Path:    file,
+				Content: rewritten,
+				SHA:     sha,
+				Message: fmt.Sprintf("Rewrite references in %s to migrated repositories", file),
+			},
+			Rewritten: count,
+		})
+	}
+	if len(changes) == 0 {
+		record.Status = models.ReferencePRStatusNoChanges
+		return record, nil
+	}
+
+	fileChanges := make([]destinationFileChange, 0, len(changes))
+	for _, change := range changes {
+		fileChanges = append(fileChanges, change.destinationFileChange)
+	}
+	pr, err := e.openFileChangesPR(ctx, destOrg, destName, baseBranch, referenceRewriteBranch,
+		"Rewrite references to migrated repositories", referencePRBody(changes, rewrites, unresolved), fileChanges)
+	if err != nil {
+		return record, err
+	}
+
+	for _, change := range changes {
+		record.FilesChanged++
+		record.ReferencesRewritten += change.Rewritten
+	}
+	record.Status = models.ReferencePRStatusOpen
+	record.Number = pr.Number
+	record.URL = pr.HTMLURL
+	return record, nil
+}
+
+// resolveReferenceRewrites looks up the repository's dependencies in the migration records.
+// Migrated dependencies become rewrites to their destination names; tracked dependencies that
+// are not migrated yet are returned as unresolved. Dependencies outside the migration are ignored.
+func (e *Executor) resolveReferenceRewrites(ctx context.Context, repo *models.Repository, deps []*models.RepositoryDependency) ([]referenceRewrite, []string, error) {
+	names := make([]string, 0, len(deps))
+	seen := make(map[string]bool, len(deps))
+	for _, dep := range deps {
+		if dep.DependencyFullName == repo.FullName || seen[dep.DependencyFullName] {
+			continue
+		}
+		seen[dep.DependencyFullName] = true
+		names = append(names, dep.DependencyFullName)
+	}
+
+	tracked, err := e.storage.GetRepositoriesByNames(ctx, names)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve dependencies: %w", err)
+	}
+
+	var rewrites []referenceRewrite
+	var unresolved []string
+	for _, dep := range tracked {
+		if !dep.IsMigrationComplete() || dep.DestinationFullName == nil || *dep.DestinationFullName == "" {
+			unresolved = append(unresolved, dep.FullName)
+			continue
+		}
+		rewrites = append(rewrites, referenceRewrite{Source: dep.FullName, Destination: *dep.DestinationFullName})
+	}
+	sort.Slice(rewrites, func(i, j int) bool { return rewrites[i].Source < rewrites[j].Source })
+	sort.Strings(unresolved)
+
+	return rewrites, unresolved, nil
+}
+
+// referenceFiles lists the files that reference at least one migrated dependency:
+// .gitmodules for submodules, the workflow file for reusable workflows and actions,
+// and the manifest for package references
+func referenceFiles(deps []*models.RepositoryDependency, rewrites []referenceRewrite) []string {
+	migrated := make(map[string]bool, len(rewrites))
+	for _, rewrite := range rewrites {
+		migrated[rewrite.Source] = true
+	}
+
+	seen := make(map[string]bool)
+	var files []string
+	for _, dep := range deps {
+		if !migrated[dep.DependencyFullName] {
+			continue
+		}
+
+		var metadata struct {
+			WorkflowFile string `json:"workflow_file"`
+			Manifest     string `json:"manifest"`
+		}
+		if dep.Metadata != nil {
+			_ = json.Unmarshal([]byte(*dep.Metadata), &metadata)
+		}
+
+		var file string
+		switch dep.DependencyType {
+		case models.DependencyTypeSubmodule:
+			file = ".gitmodules"
+		case models.DependencyTypeWorkflow:
+			if metadata.WorkflowFile != "" {
+				file = path.Join(".github/workflows", metadata.WorkflowFile)
+			}
+		default:
+			file = filepath.ToSlash(metadata.Manifest)
+		}
+		if file == "" || seen[file] {
+			continue
+		}
+		seen[file] = true
+		files = append(files, file)
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// rewriteReferences rewrites references to migrated repositories in a file and returns the
+// new content and the number of references rewritten. Two forms are recognized:
+// URLs and module paths on the source host (https://host/owner/repo, git@host:owner/repo.git,
+// host/owner/repo/v2), which move to the destination host; and host-less workflow
+// references (uses: owner/repo/...@ref, repository: owner/repo).
+func rewriteReferences(content, sourceHost, destHost string, rewrites []referenceRewrite) (string, int) {
+	total := 0
+	for _, rewrite := range rewrites {
+		name := regexp.QuoteMeta(rewrite.Source)
+
+		if sourceHost != "" {
+			hostPattern := regexp.MustCompile(`(?i)` + regexp.QuoteMeta(sourceHost) + `([/:])` + name + `(\.git)?`)
+			var count int
+			content, count = replaceReference(content, hostPattern, func(groups []string) string {
+				return destHost + groups[1] + rewrite.Destination + groups[2]
+			})
+			total += count
+		}
+
+		workflowPattern := regexp.MustCompile(`(?i)((?:uses|repository):\s*["']?)` + name)
+		var count int
+		content, count = replaceReference(content, workflowPattern, func(groups []string) string {
+			return groups[1] + rewrite.Destination
+		})
+		total += count
+	}
+
+	return content, total
+}
+
+// replaceReference replaces the matches of pattern that stand alone as a reference, so a
+// rewrite of org/app leaves org/app-legacy and myorg/app untouched. Matches that would not
+// change are not counted.
+func replaceReference(content string, pattern *regexp.Regexp, replace func(groups []string) string) (string, int) {
+	var b strings.Builder
+	last, count := 0, 0
+	for _, loc := range pattern.FindAllStringSubmatchIndex(content, -1) {
+		start, end := loc[0], loc[1]
+		if start > 0 && isReferenceChar(content[start-1], false) {
+			continue
+		}
+		if end < len(content) && isReferenceChar(content[end], true) {
+			continue
+		}
+
+		groups := make([]string, len(loc)/2)
+		for i := range groups {
+			if loc[2*i] >= 0 {
+				groups[i] = content[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		replacement := replace(groups)
+		if replacement == groups[0] {
+			continue
+		}
+		b.WriteString(content[last:start])
+		b.WriteString(replacement)
+		last = end
+		count++
+	}
+	if count == 0 {
+		return content, 0
+	}
+	b.WriteString(content[last:])
+	return b.String(), count
+}
+
+// isReferenceChar reports whether c continues a host or repository name. Underscores may
+// appear in repository names but not in host names.
+func isReferenceChar(c byte, inName bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '-':
+		return true
+	case c == '_':
+		return inName
+	}
+	return false
+}
+
+// referencePRBody describes the rewritten files and the dependencies still to migrate
+func referencePRBody(changes []referenceFileChange, rewrites []referenceRewrite, unresolved []string) string {
+	var b strings.Builder
+	b.WriteString("This pull request was opened after migration. It points references to other migrated repositories at their new location.\n\n")
+
+	b.WriteString("### Repositories\n\n")
+	for _, rewrite := range rewrites {
+		fmt.Fprintf(&b, "- `%s` → `%s`\n", rewrite.Source, rewrite.Destination)
+	}
+
+	b.WriteString("\n### Files\n\n")
+	for _, change := range changes {
+		fmt.Fprintf(&b, "- `%s` (%d references)\n", change.Path, change.Rewritten)
+	}
+
+	if len(unresolved) > 0 {
+		b.WriteString("\n### Not migrated yet\n\n")
+		b.WriteString("These dependencies have not been migrated, so references to them were left unchanged:\n\n")
+		for _, name := range unresolved {
+			fmt.Fprintf(&b, "- `%s`\n", name)
+		}
+	}
+
+	b.WriteString("\nReview the changes, and check that source code imports and scripts do not also need updating.\n")
+	return b.String()
+}
+
+// hostOf returns the host of a URL, or "" when it cannot be parsed
+func hostOf(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
+// openReferencePRAfterMigration opens the reference rewrite pull request as part of
+// post-migration when enabled. Failures are logged and never fail the migration.
+func (e *Executor) openReferencePRAfterMigration(ctx context.Context, mc *MigrationContext) {
+	if !e.referenceRewritePRs || mc.DryRun {
+		return
+	}
+
+	record, err := e.OpenReferencePR(ctx, mc.Repo)
+	if err != nil {
+		e.warnReplay(ctx, mc, opReferences, "Failed to open reference rewrite pull request", err)
+		return
+	}
+
+	message := "No references to migrated repositories needed rewriting"
+	if record.Status == models.ReferencePRStatusOpen {
+		message = fmt.Sprintf("Opened pull request #%d rewriting %d references in %d files", *record.Number, record.ReferencesRewritten, record.FilesChanged)
+	}
+	var details *string
+	if record.Unresolved != nil {
+		unresolved := "Dependencies not migrated yet: " + strings.ReplaceAll(*record.Unresolved, ",", ", ")
+		details = &unresolved
+	}
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "post_migration", opReferences, message, details)
+}
@@ -0,0 +1,115 @@
+package migration
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestRewriteReferences(t *testing.T) {
+	rewrites := []referenceRewrite{
+		{Source: "platform/lib", Destination: "acme-platform/lib"},
+		{Source: "platform/shared-workflows", Destination: "acme-platform/workflows"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		count   int
+	}{
+		{
+			name:    "submodule https url",
+			content: "[submodule \"lib\"]\n\tpath = lib\n\turl = https://ghes.example.com/platform/lib.git\n",
+			want:    "[submodule \"lib\"]\n\tpath = lib\n\turl = https://github.com/acme-platform/lib.git\n",
+			count:   1,
+		},
+		{
+			name:    "submodule ssh url",
+			content: "url = git@ghes.example.com:platform/lib.git",
+			want:    "url = git@github.com:acme-platform/lib.git",
+			count:   1,
+		},
+		{
+			name:    "go module path",
+			content: "require (\n\tghes.example.com/platform/lib v1.2.0\n\tghes.example.com/platform/lib/v2 v2.0.1\n)\n",
+			want:    "require (\n\tgithub.com/acme-platform/lib v1.2.0\n\tgithub.com/acme-platform/lib/v2 v2.0.1\n)\n",
+			count:   2,
+		},
+		{
+			name:    "workflow uses and checkout",
+			content: "    uses: platform/shared-workflows/.github/workflows/build.yml@v1\n    - uses: actions/checkout@v4\n      with:\n        repository: 'platform/lib'\n",
+			want:    "    uses: acme-platform/workflows/.github/workflows/build.yml@v1\n    - uses: actions/checkout@v4\n      with:\n        repository: 'acme-platform/lib'\n",
+			count:   2,
+		},
+		{
+			name:    "similar names are left alone",
+			content: "https://ghes.example.com/platform/lib-legacy https://ghes.example.com/myplatform/lib https://api.ghes.example.com/platform/lib uses: platform/library@v1",
+			want:    "https://ghes.example.com/platform/lib-legacy https://ghes.example.com/myplatform/lib https://api.ghes.example.com/platform/lib uses: platform/library@v1",
+			count:   0,
+		},
+		{
+			name:    "other hosts are left alone",
+			content: "url = https://gitlab.example.com/platform/lib.git",
+			want:    "url = https://gitlab.example.com/platform/lib.git",
+			count:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, count := rewriteReferences(tt.content, "ghes.example.com", "github.com", rewrites)
+			if got != tt.want {
+				t.Errorf("rewriteReferences() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if count != tt.count {
+				t.Errorf("rewriteReferences() count = %d, want %d", count, tt.count)
+			}
+		})
+	}
+}
+
+func TestRewriteReferences_UnchangedNameIsNotCounted(t *testing.T) {
+	content := "uses: platform/lib/.github/workflows/ci.yml@main"
+	got, count := rewriteReferences(content, "github.com", "github.com", []referenceRewrite{{Source: "platform/lib", Destination: "platform/lib"}})
+	if got != content || count != 0 {
+		t.Errorf("rewriteReferences() = %q, %d; want the content unchanged", got, count)
+	}
+}
+
+func TestReferenceFiles(t *testing.T) {
+	metadata := func(s string) *string { return &s }
+	deps := []*models.RepositoryDependency{
+		{DependencyFullName: "platform/lib", DependencyType: models.DependencyTypeSubmodule, Metadata: metadata(`{"path":"lib","url":"https://ghes.example.com/platform/lib.git"}`)},
+		{DependencyFullName: "platform/lib", DependencyType: models.DependencyTypePackage, Metadata: metadata(`{"package_manager":"GO","manifest":"services/api/go.mod","version":"v1.2.0"}`)},
+		{DependencyFullName: "platform/shared-workflows", DependencyType: models.DependencyTypeWorkflow, Metadata: metadata(`{"workflow_file":"ci.yml","uses":"platform/shared-workflows/.github/workflows/build.yml@v1"}`)},
+		{DependencyFullName: "platform/not-migrated", DependencyType: models.DependencyTypePackage, Metadata: metadata(`{"manifest":"package.json"}`)},
+		{DependencyFullName: "platform/lib", DependencyType: models.DependencyTypeSubmodule},
+	}
+	rewrites := []referenceRewrite{
+		{Source: "platform/lib", Destination: "acme-platform/lib"},
+		{Source: "platform/shared-workflows", Destination: "acme-platform/workflows"},
+	}
+
+	got := referenceFiles(deps, rewrites)
+	want := []string{".github/workflows/ci.yml", ".gitmodules", "services/api/go.mod"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("referenceFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestReferencePRBody(t *testing.T) {
+	body := referencePRBody(
+		[]referenceFileChange{{destinationFileChange: destinationFileChange{Path: ".gitmodules"}, Rewritten: 2}},
+		[]referenceRewrite{{Source: "platform/lib", Destination: "acme-platform/lib"}},
+		[]string{"platform/not-migrated"},
+	)
+
+	for _, want := range []string{"`platform/lib` → `acme-platform/lib`", "`.gitmodules` (2 references)", "### Not migrated yet", "`platform/not-migrated`"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the body to contain %q:\n%s", want, body)
+		}
+	}
+}
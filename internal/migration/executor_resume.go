@@ -0,0 +1,138 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// ErrNoCheckpoint is returned by ResumeWithStrategy when a repository has no in-flight
+// migration with remote state to resume. Nothing was started remotely, so the
+// migration should simply be queued again.
+var ErrNoCheckpoint = errors.New("no resumable migration checkpoint")
+
+// ErrLeaseLost is returned when another replica took over a migration's lease. The migration
+// must stop without recording anything, since the new owner now drives it.
+var ErrLeaseLost = errors.New("migration lease lost to another replica")
+
+// OwnershipCheck returns ErrLeaseLost if the caller no longer owns the migration
+type OwnershipCheck func(ctx context.Context) error
+
+type ownershipCheckKey struct{}
+
+// WithOwnershipCheck returns a context whose migration runs check before recording each
+// phase checkpoint, so a replica that lost the migration stops instead of overwriting the
+// new owner's progress.
+func WithOwnershipCheck(ctx context.Context, check OwnershipCheck) context.Context {
+	return context.WithValue(ctx, ownershipCheckKey{}, check)
+}
+
+// msgInterruptedBeforeStart is recorded on history records abandoned by a restart
+// before any remote work had started.
+const msgInterruptedBeforeStart = "Interrupted by a server restart before any remote work started - migration re-queued"
+
+// saveCheckpoint records that a phase has completed, along with the state needed to resume after it.
+// It returns ErrLeaseLost if the context's ownership check finds another replica owns the migration.
+// Failures to save are logged but never fail the migration - the only cost is a less precise resume.
+func (e *Executor) saveCheckpoint(ctx context.Context, mc *MigrationContext, checkpoint string) error {
+	if check, ok := ctx.Value(ownershipCheckKey{}).(OwnershipCheck); ok {
+		if err := check(ctx); err != nil {
+			e.logger.Warn("Stopping migration that is now owned by another replica",
+				"repo", mc.Repo.FullName,
+				"checkpoint", checkpoint)
+			return err
+		}
+	}
+	if mc.HistoryID == nil {
+		return nil
+	}
+
+	cp := &models.MigrationHistory{Checkpoint: &checkpoint}
+	if mc.ArchiveIDs != nil {
+		cp.GitArchiveID = &mc.ArchiveIDs.GitArchiveID
+		cp.MetadataArchiveID = &mc.ArchiveIDs.MetadataArchiveID
+	}
+	if mc.ArchiveURLs != nil {
+		cp.GitArchiveURL = &mc.ArchiveURLs.GitSource
+		cp.MetadataArchiveURL = &mc.ArchiveURLs.Metadata
+	}
+	if mc.MigrationID != "" {
+		migrationID := mc.MigrationID
+		cp.MigrationID = &migrationID
+	}
+
+	if err := e.storage.SaveMigrationCheckpoint(ctx, *mc.HistoryID, cp); err != nil {
+		e.logger.Warn("Failed to save migration checkpoint",
+			"repo", mc.Repo.FullName,
+			"checkpoint", checkpoint,
+			"error", err)
+	}
+	return nil
+}
+
+// restoreCheckpoint loads the remote state recorded on a history record into the migration context.
+func restoreCheckpoint(mc *MigrationContext, history *models.MigrationHistory) {
+	if history.GitArchiveID != nil && history.MetadataArchiveID != nil {
+		mc.ArchiveIDs = &ArchiveIDs{
+			GitArchiveID:      *history.GitArchiveID,
+			MetadataArchiveID: *history.MetadataArchiveID,
+		}
+	}
+	if history.GitArchiveURL != nil && history.MetadataArchiveURL != nil {
+		mc.ArchiveURLs = &ArchiveURLs{
+			GitSource: *history.GitArchiveURL,
+			Metadata:  *history.MetadataArchiveURL,
+		}
+	}
+	if history.MigrationID != nil {
+		mc.MigrationID = *history.MigrationID
+	}
+}
+
+// ResumeWithStrategy resumes a migration that was in flight when the server stopped.
+// It continues from the last checkpoint recorded on the repository's in-progress
+// migration history, reusing the archives or destination migration already started
+// rather than starting over.
+//
+// ErrNoCheckpoint is returned when there is nothing to resume. Any in-progress history
+// record is closed as failed in that case so the migration can be queued again.
+func (e *Executor) ResumeWithStrategy(ctx context.Context, repo *models.Repository, batch *models.Batch) error {
+	history, err := e.storage.GetInProgressMigrationHistory(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to load migration checkpoint: %w", err)
+	}
+	if !history.IsResumable() {
+		if history != nil {
+			msg := msgInterruptedBeforeStart
+			e.updateHistoryStatus(ctx, &history.ID, statusFailed, &msg)
+			e.logOperation(ctx, repo, &history.ID, "WARN", "migration", "resume", msg, nil)
+		}
+		return ErrNoCheckpoint
+	}
+
+	strategy := e.newStrategyRegistry(batch).GetStrategy(repo)
+	if strategy == nil {
+		return fmt.Errorf("no migration strategy found for repository %s", repo.FullName)
+	}
+
+	dryRun := history.Phase == "dry_run"
+	checkpoint := *history.Checkpoint
+
+	mc := e.NewMigrationContext(repo, batch, dryRun)
+	mc.HistoryID = &history.ID
+	restoreCheckpoint(mc, history)
+
+	e.logger.Info("Resuming migration from checkpoint",
+		"repo", repo.FullName,
+		"strategy", strategy.Name(),
+		"checkpoint", checkpoint,
+		"migration_id", mc.MigrationID,
+		"dry_run", dryRun)
+	e.logOperation(ctx, repo, mc.HistoryID, "INFO", "migration", "resume",
+		fmt.Sprintf("Resuming %s after restart from checkpoint %s using %s strategy",
+			map[bool]string{true: "dry run", false: "migration"}[dryRun], checkpoint, strategy.Name()), nil)
+
+	return e.executeFromCheckpoint(ctx, mc, strategy, checkpoint)
+}
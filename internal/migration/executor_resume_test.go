@@ -0,0 +1,196 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// createInProgressHistory creates an in-progress history record with the given checkpoint
+func createInProgressHistory(t *testing.T, db *storage.Database, repo *models.Repository, phase, checkpoint string) int64 {
+	t.Helper()
+
+	ctx := context.Background()
+	id, err := db.CreateMigrationHistory(ctx, &models.MigrationHistory{
+		RepositoryID: repo.ID,
+		Status:       "in_progress",
+		Phase:        phase,
+		StartedAt:    time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create migration history: %v", err)
+	}
+	if checkpoint != "" {
+		migrationID := "elm-123"
+		if err := db.SaveMigrationCheckpoint(ctx, id, &models.MigrationHistory{Checkpoint: &checkpoint, MigrationID: &migrationID}); err != nil {
+			t.Fatalf("Failed to save checkpoint: %v", err)
+		}
+	}
+	return id
+}
+
+func TestSaveAndRestoreCheckpoint(t *testing.T) {
+	executor, db, repo := setupELMTest(t, &fakeELMServer{states: []string{ELMStateQueued}})
+	ctx := context.Background()
+
+	historyID := createInProgressHistory(t, db, repo, "migration", "")
+	mc := executor.NewMigrationContext(repo, nil, false)
+	mc.HistoryID = &historyID
+	mc.ArchiveIDs = &ArchiveIDs{GitArchiveID: 1, MetadataArchiveID: 2}
+	mc.ArchiveURLs = &ArchiveURLs{GitSource: "https://ghes.example.com/git", Metadata: "https://ghes.example.com/metadata"}
+	mc.MigrationID = "RM_123"
+
+	if err := executor.saveCheckpoint(ctx, mc, models.CheckpointMigrationStarted); err != nil {
+		t.Fatalf("saveCheckpoint() error = %v", err)
+	}
+
+	history, err := db.GetInProgressMigrationHistory(ctx, repo.ID)
+	if err != nil || history == nil {
+		t.Fatalf("GetInProgressMigrationHistory() = %v, %v", history, err)
+	}
+	if history.Checkpoint == nil || *history.Checkpoint != models.CheckpointMigrationStarted {
+		t.Fatalf("Checkpoint = %v, want %s", history.Checkpoint, models.CheckpointMigrationStarted)
+	}
+
+	restored := executor.NewMigrationContext(repo, nil, false)
+	restoreCheckpoint(restored, history)
+
+	if restored.ArchiveIDs == nil || *restored.ArchiveIDs != *mc.ArchiveIDs {
+		t.Errorf("ArchiveIDs = %+v, want %+v", restored.ArchiveIDs, mc.ArchiveIDs)
+	}
+	if restored.ArchiveURLs == nil || *restored.ArchiveURLs != *mc.ArchiveURLs {
+		t.Errorf("ArchiveURLs = %+v, want %+v", restored.ArchiveURLs, mc.ArchiveURLs)
+	}
+	if restored.MigrationID != "RM_123" {
+		t.Errorf("MigrationID = %q, want RM_123", restored.MigrationID)
+	}
+}
+
+func TestSaveCheckpoint_LeaseLost(t *testing.T) {
+	executor, db, repo := setupELMTest(t, &fakeELMServer{states: []string{ELMStateQueued}})
+
+	historyID := createInProgressHistory(t, db, repo, "migration", "")
+	mc := executor.NewMigrationContext(repo, nil, false)
+	mc.HistoryID = &historyID
+
+	ctx := WithOwnershipCheck(context.Background(), func(context.Context) error { return ErrLeaseLost })
+	if err := executor.saveCheckpoint(ctx, mc, models.CheckpointMigrationStarted); !errors.Is(err, ErrLeaseLost) {
+		t.Fatalf("saveCheckpoint() error = %v, want ErrLeaseLost", err)
+	}
+
+	history, _ := db.GetInProgressMigrationHistory(context.Background(), repo.ID)
+	if history == nil || history.Checkpoint != nil {
+		t.Errorf("Expected no checkpoint to be recorded after the lease was lost, got %+v", history)
+	}
+
+	// The new owner records the outcome, so the failure is not written
+	executor.handleStrategyPhaseError(ctx, mc, NewELMMigrationStrategy(executor, nil), ErrLeaseLost)
+	if saved, _ := db.GetRepositoryByID(context.Background(), repo.ID); saved.Status == string(models.StatusMigrationFailed) {
+		t.Error("Expected a migration that lost its lease not to be marked failed")
+	}
+}
+
+func TestResumeWithStrategy_NoCheckpoint(t *testing.T) {
+	executor, db, repo := setupELMTest(t, &fakeELMServer{states: []string{ELMStateQueued}})
+	ctx := context.Background()
+
+	// No history at all
+	if err := executor.ResumeWithStrategy(ctx, repo, nil); !errors.Is(err, ErrNoCheckpoint) {
+		t.Fatalf("ResumeWithStrategy() error = %v, want ErrNoCheckpoint", err)
+	}
+
+	// Interrupted after validation, before anything started remotely
+	historyID := createInProgressHistory(t, db, repo, "migration", models.CheckpointPreMigration)
+	if err := executor.ResumeWithStrategy(ctx, repo, nil); !errors.Is(err, ErrNoCheckpoint) {
+		t.Fatalf("ResumeWithStrategy() error = %v, want ErrNoCheckpoint", err)
+	}
+
+	history, err := db.GetMigrationHistory(ctx, repo.ID)
+	if err != nil {
+		t.Fatalf("GetMigrationHistory() error: %v", err)
+	}
+	if len(history) != 1 || history[0].ID != historyID || history[0].Status != statusFailed {
+		t.Errorf("expected the interrupted history to be closed as failed, got %+v", history)
+	}
+}
+
+func TestExecuteFromCheckpoint_ResumesPollingWithoutRestarting(t *testing.T) {
+	fake := &fakeELMServer{states: []string{ELMStateSyncing, ELMStateReadyForCutover}}
+	executor, db, repo := setupELMTest(t, fake)
+	executor.postMigrationMode = PostMigrationNever
+	strategy := newTestELMStrategy(executor)
+	ctx := context.Background()
+
+	historyID := createInProgressHistory(t, db, repo, "migration", models.CheckpointMigrationStarted)
+	history, err := db.GetInProgressMigrationHistory(ctx, repo.ID)
+	if err != nil || history == nil {
+		t.Fatalf("GetInProgressMigrationHistory() = %v, %v", history, err)
+	}
+
+	mc := executor.NewMigrationContext(repo, strategy.batch, false)
+	mc.HistoryID = &historyID
+	restoreCheckpoint(mc, history)
+
+	if err := executor.executeFromCheckpoint(ctx, mc, strategy, *history.Checkpoint); err != nil {
+		t.Fatalf("executeFromCheckpoint() error: %v", err)
+	}
+
+	if fake.lastStart.TargetRepository != "" {
+		t.Error("expected the existing ELM migration to be polled, but a new one was started")
+	}
+	if fake.cutoverCalls != 1 {
+		t.Errorf("cutover calls = %d, want 1", fake.cutoverCalls)
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusComplete) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusComplete)
+	}
+
+	records, err := db.GetMigrationHistory(ctx, repo.ID)
+	if err != nil {
+		t.Fatalf("GetMigrationHistory() error: %v", err)
+	}
+	if len(records) != 1 || records[0].Status != "completed" {
+		t.Fatalf("expected the resumed history to be completed, got %+v", records)
+	}
+	if records[0].Checkpoint == nil || *records[0].Checkpoint != models.CheckpointMigrationFinished {
+		t.Errorf("Checkpoint = %v, want %s", records[0].Checkpoint, models.CheckpointMigrationFinished)
+	}
+}
+
+func TestResumeWithStrategy_DryRunFinished(t *testing.T) {
+	fake := &fakeELMServer{states: []string{ELMStateQueued}}
+	executor, db, repo := setupELMTest(t, fake)
+	executor.postMigrationMode = PostMigrationNever
+	ctx := context.Background()
+
+	createInProgressHistory(t, db, repo, "dry_run", models.CheckpointMigrationFinished)
+	batch := &models.Batch{MigrationAPI: models.MigrationAPIELM}
+
+	if err := executor.ResumeWithStrategy(ctx, repo, batch); err != nil {
+		t.Fatalf("ResumeWithStrategy() error: %v", err)
+	}
+
+	if fake.pollCount != 0 {
+		t.Errorf("poll count = %d, want 0 for a finished migration", fake.pollCount)
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusDryRunComplete) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusDryRunComplete)
+	}
+	if updated.LastDryRunAt == nil {
+		t.Error("expected LastDryRunAt to be set")
+	}
+}
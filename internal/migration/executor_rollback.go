@@ -0,0 +1,338 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// RollbackMode determines what happens to the destination repository during a rollback
+type RollbackMode string
+
+const (
+	// RollbackArchive archives the destination repository, keeping it read-only for reference
+	RollbackArchive RollbackMode = "archive"
+	// RollbackDelete permanently deletes the destination repository
+	RollbackDelete RollbackMode = "delete"
+)
+
+// Rollback step actions
+const (
+	RollbackStepArchiveDestination = "archive_destination"
+	RollbackStepDeleteDestination  = "delete_destination"
+	RollbackStepUnlockSource       = "unlock_source"
+	RollbackStepRevertReadmeNotice = "revert_readme_notice"
+	RollbackStepRevertDescription  = "revert_description"
+	RollbackStepRevertArchive      = "revert_archive"
+	RollbackStepMarkRolledBack     = "mark_rolled_back"
+)
+
+// revertSteps maps each completion action to the rollback step that reverts it
+var revertSteps = map[string]string{
+	CompletionActionReadmeNotice: RollbackStepRevertReadmeNotice,
+	CompletionActionDescription:  RollbackStepRevertDescription,
+	CompletionActionArchive:      RollbackStepRevertArchive,
+}
+
+// Rollback step statuses
+const (
+	RollbackStepPlanned   = "planned"
+	RollbackStepCompleted = "completed"
+	RollbackStepSkipped   = "skipped"
+	RollbackStepFailed    = "failed"
+)
+
+const phaseRollback = "rollback"
+
+// ParseRollbackMode parses a rollback mode, defaulting to archive when empty
+func ParseRollbackMode(mode string) (RollbackMode, error) {
+	switch RollbackMode(mode) {
+	case "", RollbackArchive:
+		return RollbackArchive, nil
+	case RollbackDelete:
+		return RollbackDelete, nil
+	default:
+		return "", fmt.Errorf("invalid rollback mode %q: must be 'archive' or 'delete'", mode)
+	}
+}
+
+// RollbackOptions configures a repository rollback
+type RollbackOptions struct {
+	Mode        RollbackMode // What to do with the destination repository (default: archive)
+	Reason      string       // Recorded in the rollback history entry
+	DryRun      bool         // Preview the steps without changing anything
+	InitiatedBy *string      // User who requested the rollback (recorded in migration logs)
+}
+
+// RollbackStep describes a single step of a rollback and its outcome
+type RollbackStep struct {
+	Action  string `json:"action"`
+	Target  string `json:"target,omitempty"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+
+	completionAction *models.CompletionAction // The completion action a revert step restores
+}
+
+// RollbackResult describes the steps taken (or planned, for a dry run) to roll back a repository
+type RollbackResult struct {
+	Repository string         `json:"repository"`
+	Mode       RollbackMode   `json:"mode"`
+	DryRun     bool           `json:"dry_run"`
+	Steps      []RollbackStep `json:"steps"`
+}
+
+// Rollback undoes a completed migration.
+// It archives or deletes the destination repository, unlocks the source repository if it
+// is still locked by a GEI migration, reverts the batch's completion actions on the source,
+// clears the destination fields and marks the repository as rolled back. Each step is recorded in the migration logs.
+// A dry run returns the planned steps without changing anything.
+// If a step fails the rollback stops and the error is returned along with the steps taken so far;
+// the rollback can then be retried, as a destination that no longer exists is skipped.
+func (e *Executor) Rollback(ctx context.Context, repo *models.Repository, opts RollbackOptions) (*RollbackResult, error) {
+	if repo.Status != string(models.StatusComplete) {
+		return nil, fmt.Errorf("only completed migrations can be rolled back (current status: %s)", repo.Status)
+	}
+
+	mode, err := ParseRollbackMode(string(opts.Mode))
+	if err != nil {
+		return nil, err
+	}
+
+	completionActions, err := e.storage.GetCompletionActions(ctx, repo.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completion actions: %w", err)
+	}
+
+	result := &RollbackResult{
+		Repository: repo.FullName,
+		Mode:       mode,
+		DryRun:     opts.DryRun,
+		Steps:      e.planRollback(repo, mode, completionActions),
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	e.logger.Info("Rolling back repository", "repo", repo.FullName, "mode", mode)
+
+	for i := range result.Steps {
+		step := &result.Steps[i]
+		if step.Status == RollbackStepSkipped {
+			e.logRollbackStep(ctx, repo, step, opts.InitiatedBy)
+			continue
+		}
+
+		if err := e.runRollbackStep(ctx, repo, step, opts.Reason); err != nil {
+			step.Status = RollbackStepFailed
+			step.Message = err.Error()
+			e.logRollbackStep(ctx, repo, step, opts.InitiatedBy)
+			return result, fmt.Errorf("rollback step %s failed: %w", step.Action, err)
+		}
+		e.logRollbackStep(ctx, repo, step, opts.InitiatedBy)
+	}
+
+	e.logger.Info("Repository rolled back successfully", "repo", repo.FullName, "mode", mode)
+	return result, nil
+}
+
+// planRollback determines the rollback steps for a repository.
+// Steps that do not apply are marked as skipped with the reason.
+func (e *Executor) planRollback(repo *models.Repository, mode RollbackMode, completionActions []*models.CompletionAction) []RollbackStep {
+	steps := make([]RollbackStep, 0, 3+len(completionActions))
+
+	destAction := RollbackStepArchiveDestination
+	if mode == RollbackDelete {
+		destAction = RollbackStepDeleteDestination
+	}
+	switch {
+	case repo.DestinationFullName == nil || *repo.DestinationFullName == "":
+		steps = append(steps, RollbackStep{
+			Action:  destAction,
+			Status:  RollbackStepSkipped,
+			Message: "No destination repository recorded",
+		})
+	case mode == RollbackDelete:
+		steps = append(steps, RollbackStep{
+			Action:  destAction,
+			Target:  *repo.DestinationFullName,
+			Status:  RollbackStepPlanned,
+			Message: fmt.Sprintf("Delete destination repository %s", *repo.DestinationFullName),
+		})
+	default:
+		steps = append(steps, RollbackStep{
+			Action:  destAction,
+			Target:  *repo.DestinationFullName,
+			Status:  RollbackStepPlanned,
+			Message: fmt.Sprintf("Archive destination repository %s", *repo.DestinationFullName),
+		})
+	}
+
+	switch {
+	case !repo.IsSourceLocked:
+		steps = append(steps, RollbackStep{
+			Action:  RollbackStepUnlockSource,
+			Target:  repo.FullName,
+			Status:  RollbackStepSkipped,
+			Message: "Source repository is not locked",
+		})
+	case repo.SourceMigrationID == nil:
+		steps = append(steps, RollbackStep{
+			Action:  RollbackStepUnlockSource,
+			Target:  repo.FullName,
+			Status:  RollbackStepSkipped,
+			Message: "No source migration ID recorded - unlock the source repository manually",
+		})
+	case e.sourceClient == nil:
+		steps = append(steps, RollbackStep{
+			Action:  RollbackStepUnlockSource,
+			Target:  repo.FullName,
+			Status:  RollbackStepSkipped,
+			Message: "Source does not support unlocking via the GitHub API - unlock the source repository manually",
+		})
+	default:
+		steps = append(steps, RollbackStep{
+			Action:  RollbackStepUnlockSource,
+			Target:  repo.FullName,
+			Status:  RollbackStepPlanned,
+			Message: fmt.Sprintf("Unlock source repository %s (migration %d)", repo.FullName, *repo.SourceMigrationID),
+		})
+	}
+
+	// Revert applied completion actions in reverse, so the source is unarchived before its README and description are restored
+	for i := len(completionActions) - 1; i >= 0; i-- {
+		action := completionActions[i]
+		if action.Status != models.CompletionActionStatusApplied {
+			continue
+		}
+		steps = append(steps, RollbackStep{
+			Action:           revertSteps[action.Action],
+			Target:           repo.FullName,
+			Status:           RollbackStepPlanned,
+			Message:          revertMessage(repo, action.Action, false),
+			completionAction: action,
+		})
+	}
+
+	steps = append(steps, RollbackStep{
+		Action:  RollbackStepMarkRolledBack,
+		Target:  repo.FullName,
+		Status:  RollbackStepPlanned,
+		Message: "Clear the destination, remove the repository from its batch and mark it as rolled back",
+	})
+
+	return steps
+}
+
+// runRollbackStep executes a planned rollback step and updates its status and message
+func (e *Executor) runRollbackStep(ctx context.Context, repo *models.Repository, step *RollbackStep, reason string) error {
+	switch step.Action {
+	case RollbackStepArchiveDestination, RollbackStepDeleteDestination:
+		owner, name, ok := strings.Cut(step.Target, "/")
+		if !ok {
+			return fmt.Errorf("invalid destination repository name: %s", step.Target)
+		}
+
+		var err error
+		if step.Action == RollbackStepDeleteDestination {
+			err = e.destClient.DeleteRepository(ctx, owner, name)
+		} else {
+			err = e.destClient.ArchiveRepository(ctx, owner, name)
+		}
+		if github.IsNotFoundError(err) {
+			step.Status = RollbackStepSkipped
+			step.Message = fmt.Sprintf("Destination repository %s no longer exists", step.Target)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		step.Status = RollbackStepCompleted
+		if step.Action == RollbackStepDeleteDestination {
+			step.Message = fmt.Sprintf("Deleted destination repository %s", step.Target)
+		} else {
+			step.Message = fmt.Sprintf("Archived destination repository %s", step.Target)
+		}
+		return nil
+
+	case RollbackStepUnlockSource:
+		if err := e.sourceClient.UnlockRepository(ctx, repo.Organization(), repo.Name(), *repo.SourceMigrationID); err != nil {
+			return err
+		}
+		repo.IsSourceLocked = false
+		if err := e.storage.UpdateRepository(ctx, repo); err != nil {
+			return fmt.Errorf("failed to update source lock status: %w", err)
+		}
+		step.Status = RollbackStepCompleted
+		step.Message = fmt.Sprintf("Unlocked source repository %s", repo.FullName)
+		return nil
+
+	case RollbackStepRevertReadmeNotice, RollbackStepRevertDescription, RollbackStepRevertArchive:
+		if err := e.revertCompletionAction(ctx, repo, step.completionAction); err != nil {
+			return err
+		}
+		if err := e.storage.MarkCompletionActionReverted(ctx, step.completionAction.ID); err != nil {
+			return err
+		}
+		step.Status = RollbackStepCompleted
+		step.Message = revertMessage(repo, step.completionAction.Action, true)
+		return nil
+
+	case RollbackStepMarkRolledBack:
+		if err := e.storage.RollbackRepository(ctx, repo.FullName, reason); err != nil {
+			return err
+		}
+		step.Status = RollbackStepCompleted
+		step.Message = "Marked repository as rolled back"
+		return nil
+
+	default:
+		return fmt.Errorf("unknown rollback step: %s", step.Action)
+	}
+}
+
+// revertMessage describes the rollback step reverting a completion action, before or after it runs
+func revertMessage(repo *models.Repository, action string, done bool) string {
+	var planned, completed string
+	switch {
+	case action == CompletionActionReadmeNotice:
+		planned, completed = "Remove the moved notice from the source README", "Removed the moved notice from the source README"
+	case action == CompletionActionDescription:
+		planned, completed = "Restore the source repository description", "Restored the source repository description"
+	case isADORepository(repo):
+		planned, completed = "Restore write access to the source repository", "Restored write access to the source repository"
+	default:
+		planned, completed = "Unarchive the source repository", "Unarchived the source repository"
+	}
+	if done {
+		return completed
+	}
+	return planned
+}
+
+// logRollbackStep records the outcome of a rollback step in the migration logs
+func (e *Executor) logRollbackStep(ctx context.Context, repo *models.Repository, step *RollbackStep, initiatedBy *string) {
+	level := "INFO"
+	if step.Status == RollbackStepFailed {
+		level = "ERROR"
+	}
+
+	log := &models.MigrationLog{
+		RepositoryID: repo.ID,
+		Level:        level,
+		Phase:        phaseRollback,
+		Operation:    step.Action,
+		Message:      step.Message,
+		InitiatedBy:  initiatedBy,
+		Timestamp:    time.Now(),
+	}
+	if err := e.storage.CreateMigrationLog(ctx, log); err != nil {
+		e.logger.Error("Failed to create migration log", "error", err)
+	}
+}
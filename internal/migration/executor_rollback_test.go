@@ -0,0 +1,290 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// fakeRollbackServer records the GitHub API calls made during a rollback
+type fakeRollbackServer struct {
+	mu          sync.Mutex
+	calls       []string
+	destMissing bool // Respond 404 to destination repository calls
+
+	routes func(mux *http.ServeMux) // Registers additional routes, e.g. for the source repository
+}
+
+func (f *fakeRollbackServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/rate_limit", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"resources": map[string]any{
+				"core": map[string]any{"limit": 5000, "remaining": 4999, "reset": 1234567890},
+			},
+		})
+	})
+	mux.HandleFunc("/api/v3/repos/dest-org/repo", func(w http.ResponseWriter, r *http.Request) {
+		f.record(r)
+		if f.destMissing {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"full_name":"dest-org/repo","archived":true}`))
+	})
+	mux.HandleFunc("DELETE /api/v3/orgs/source-org/migrations/42/repos/repo/lock", func(w http.ResponseWriter, r *http.Request) {
+		f.record(r)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if f.routes != nil {
+		f.routes(mux)
+	}
+	return mux
+}
+
+func (f *fakeRollbackServer) record(r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, r.Method+" "+r.URL.Path)
+}
+
+func setupRollbackTest(t *testing.T, fake *fakeRollbackServer) (*Executor, *storage.Database, *models.Repository) {
+	t.Helper()
+
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := github.NewClient(github.ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: github.DefaultRetryConfig(),
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	executor, err := NewExecutor(ExecutorConfig{
+		SourceClient: client,
+		DestClient:   client,
+		Storage:      db,
+		Logger:       logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+
+	destFullName := "dest-org/repo"
+	destURL := "https://github.com/dest-org/repo"
+	migrationID := int64(42)
+	repo := createTestRepository("source-org/repo")
+	repo.Status = string(models.StatusComplete)
+	repo.DestinationFullName = &destFullName
+	repo.DestinationURL = &destURL
+	repo.SourceMigrationID = &migrationID
+	repo.IsSourceLocked = true
+	if err := db.SaveRepository(context.Background(), repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	saved, err := db.GetRepository(context.Background(), repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to load repository: %v", err)
+	}
+
+	return executor, db, saved
+}
+
+func stepStatuses(result *RollbackResult) map[string]string {
+	statuses := make(map[string]string, len(result.Steps))
+	for _, step := range result.Steps {
+		statuses[step.Action] = step.Status
+	}
+	return statuses
+}
+
+func TestParseRollbackMode(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RollbackMode
+		wantErr bool
+	}{
+		{"", RollbackArchive, false},
+		{"archive", RollbackArchive, false},
+		{"delete", RollbackDelete, false},
+		{"purge", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRollbackMode(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRollbackMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseRollbackMode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRollback_DryRunChangesNothing(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, db, repo := setupRollbackTest(t, fake)
+	ctx := context.Background()
+
+	result, err := executor.Rollback(ctx, repo, RollbackOptions{Mode: RollbackDelete, DryRun: true})
+	if err != nil {
+		t.Fatalf("Rollback() error: %v", err)
+	}
+
+	want := map[string]string{
+		RollbackStepDeleteDestination: RollbackStepPlanned,
+		RollbackStepUnlockSource:      RollbackStepPlanned,
+		RollbackStepMarkRolledBack:    RollbackStepPlanned,
+	}
+	if got := stepStatuses(result); len(got) != len(want) {
+		t.Fatalf("steps = %+v, want %+v", result.Steps, want)
+	} else {
+		for action, status := range want {
+			if got[action] != status {
+				t.Errorf("step %s status = %q, want %q", action, got[action], status)
+			}
+		}
+	}
+
+	if len(fake.calls) != 0 {
+		t.Errorf("expected no API calls during a dry run, got %v", fake.calls)
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusComplete) || updated.DestinationFullName == nil {
+		t.Errorf("expected repository to be unchanged, got status %s, destination %v", updated.Status, updated.DestinationFullName)
+	}
+}
+
+func TestRollback_ArchivesDestinationAndUnlocksSource(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, db, repo := setupRollbackTest(t, fake)
+	ctx := context.Background()
+
+	result, err := executor.Rollback(ctx, repo, RollbackOptions{Reason: "Wrong destination org"})
+	if err != nil {
+		t.Fatalf("Rollback() error: %v", err)
+	}
+
+	if result.Mode != RollbackArchive {
+		t.Errorf("mode = %q, want %q", result.Mode, RollbackArchive)
+	}
+	for action, status := range stepStatuses(result) {
+		if status != RollbackStepCompleted {
+			t.Errorf("step %s status = %q, want %q", action, status, RollbackStepCompleted)
+		}
+	}
+
+	wantCalls := []string{
+		"PATCH /api/v3/repos/dest-org/repo",
+		"DELETE /api/v3/orgs/source-org/migrations/42/repos/repo/lock",
+	}
+	if len(fake.calls) != len(wantCalls) {
+		t.Fatalf("calls = %v, want %v", fake.calls, wantCalls)
+	}
+	for i := range wantCalls {
+		if fake.calls[i] != wantCalls[i] {
+			t.Errorf("call[%d] = %q, want %q", i, fake.calls[i], wantCalls[i])
+		}
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusRolledBack) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusRolledBack)
+	}
+	if updated.IsSourceLocked {
+		t.Error("expected source to be unlocked")
+	}
+	if updated.DestinationFullName != nil || updated.DestinationURL != nil {
+		t.Errorf("expected destination to be cleared, got %v / %v", updated.DestinationFullName, updated.DestinationURL)
+	}
+
+	logs, err := db.GetMigrationLogs(ctx, repo.ID, "", phaseRollback, 100, 0)
+	if err != nil {
+		t.Fatalf("GetMigrationLogs() error: %v", err)
+	}
+	if len(logs) != len(result.Steps) {
+		t.Errorf("expected one migration log per step, got %d logs for %d steps", len(logs), len(result.Steps))
+	}
+}
+
+func TestRollback_DeleteSkipsMissingDestination(t *testing.T) {
+	fake := &fakeRollbackServer{destMissing: true}
+	executor, db, repo := setupRollbackTest(t, fake)
+	repo.IsSourceLocked = false
+	ctx := context.Background()
+
+	result, err := executor.Rollback(ctx, repo, RollbackOptions{Mode: RollbackDelete})
+	if err != nil {
+		t.Fatalf("Rollback() error: %v", err)
+	}
+
+	statuses := stepStatuses(result)
+	if statuses[RollbackStepDeleteDestination] != RollbackStepSkipped {
+		t.Errorf("delete step status = %q, want %q", statuses[RollbackStepDeleteDestination], RollbackStepSkipped)
+	}
+	if statuses[RollbackStepUnlockSource] != RollbackStepSkipped {
+		t.Errorf("unlock step status = %q, want %q", statuses[RollbackStepUnlockSource], RollbackStepSkipped)
+	}
+	if statuses[RollbackStepMarkRolledBack] != RollbackStepCompleted {
+		t.Errorf("mark step status = %q, want %q", statuses[RollbackStepMarkRolledBack], RollbackStepCompleted)
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusRolledBack) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusRolledBack)
+	}
+}
+
+func TestRollback_RejectsIncompleteMigration(t *testing.T) {
+	fake := &fakeRollbackServer{}
+	executor, _, repo := setupRollbackTest(t, fake)
+	repo.Status = string(models.StatusMigrationFailed)
+
+	if _, err := executor.Rollback(context.Background(), repo, RollbackOptions{}); err == nil {
+		t.Fatal("expected an error rolling back a failed migration")
+	}
+	if len(fake.calls) != 0 {
+		t.Errorf("expected no API calls, got %v", fake.calls)
+	}
+}
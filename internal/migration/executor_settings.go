@@ -0,0 +1,346 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// Repository settings the post-migration settings sync can copy from the source. GEI migrates none of them.
+const (
+	SettingDescription         = "description"
+	SettingHomepage            = "homepage"
+	SettingTopics              = "topics"
+	SettingMergeMethods        = "merge_methods"          // Allowed merge methods: merge commit, squash and rebase
+	SettingDeleteBranchOnMerge = "delete_branch_on_merge" // Automatically delete head branches
+	SettingSecurityAndAnalysis = "security_and_analysis"  // Advanced Security, secret scanning, push protection and Dependabot security updates
+	SettingCustomProperties    = "custom_properties"      // Organization custom property values
+)
+
+// SyncableSettings lists every setting the settings sync supports, in the order they are synced
+var SyncableSettings = []string{
+	SettingDescription,
+	SettingHomepage,
+	SettingTopics,
+	SettingMergeMethods,
+	SettingDeleteBranchOnMerge,
+	SettingSecurityAndAnalysis,
+	SettingCustomProperties,
+}
+
+// settingsSyncAll selects every syncable setting in the allow list
+const settingsSyncAll = "all"
+
+// opSettingsSync is the migration log operation for the post-migration settings sync
+const opSettingsSync = "settings_sync"
+
+// ParseSettingsSync validates a settings sync allow list and returns it in sync order without
+// duplicates. "all" selects every syncable setting; an empty list disables the sync.
+func ParseSettingsSync(settings []string) ([]string, error) {
+	selected := make(map[string]bool, len(settings))
+	for _, setting := range settings {
+		setting = strings.TrimSpace(setting)
+		switch {
+		case setting == "":
+			continue
+		case setting == settingsSyncAll:
+			return slices.Clone(SyncableSettings), nil
+		case !slices.Contains(SyncableSettings, setting):
+			return nil, fmt.Errorf("invalid setting %q: must be 'all' or one of %s", setting, strings.Join(SyncableSettings, ", "))
+		}
+		selected[setting] = true
+	}
+
+	var allowed []string
+	for _, setting := range SyncableSettings {
+		if selected[setting] {
+			allowed = append(allowed, setting)
+		}
+	}
+	return allowed, nil
+}
+
+// syncSettings copies the allowed repository settings from the source to the destination and returns
+// a per-setting diff for the validation report, or nil when the sync is disabled, the migration is a
+// dry run, or the sync cannot run. Settings that already match are left alone. Failures are logged
+// and never fail the migration.
+func (e *Executor) syncSettings(ctx context.Context, mc *MigrationContext) *models.SettingsSyncReport {
+	repo := mc.Repo
+	if len(e.settingsSync) == 0 || mc.DryRun {
+		return nil
+	}
+	if e.sourceClient == nil {
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opSettingsSync,
+			"Skipping settings sync (source is not a GitHub repository)", nil)
+		return nil
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return nil
+	}
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+	report := &models.SettingsSyncReport{SyncedAt: time.Now().UTC()}
+
+	source, err := e.sourceClient.GetRepository(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opSettingsSync, "Failed to read source repository settings", err)
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to read source repository settings: %v", err))
+		return report
+	}
+	dest, err := e.destClient.GetRepository(ctx, destOrg, destName)
+	if err != nil {
+		e.warnReplay(ctx, mc, opSettingsSync, "Failed to read destination repository settings", err)
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to read destination repository settings: %v", err))
+		return report
+	}
+
+	counts := make(map[string]int)
+	for _, setting := range e.settingsSync {
+		var field models.SettingsFieldSync
+		switch setting {
+		case SettingTopics:
+			field = e.syncTopics(ctx, source, dest, destOrg, destName)
+		case SettingCustomProperties:
+			field = e.syncCustomProperties(ctx, repo, destOrg, destName)
+		default:
+			field = e.syncRepositorySetting(ctx, setting, source, dest, destOrg, destName)
+		}
+		report.Settings = append(report.Settings, field)
+		counts[field.Status]++
+
+		switch field.Status {
+		case models.SettingsSyncApplied:
+			e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opSettingsSync,
+				fmt.Sprintf("Synced %s from the source", setting), nil)
+		case models.SettingsSyncFailed, models.SettingsSyncSkipped:
+			message := fmt.Sprintf("Failed to sync %s", setting)
+			if field.Status == models.SettingsSyncSkipped {
+				message = fmt.Sprintf("Skipped %s", setting)
+			}
+			errMsg := field.Error
+			e.logOperation(ctx, repo, mc.HistoryID, "WARN", "post_migration", opSettingsSync, message, &errMsg)
+		}
+	}
+
+	e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opSettingsSync,
+		fmt.Sprintf("Settings sync: %d applied, %d unchanged, %d skipped, %d failed",
+			counts[models.SettingsSyncApplied], counts[models.SettingsSyncUnchanged],
+			counts[models.SettingsSyncSkipped], counts[models.SettingsSyncFailed]), nil)
+	return report
+}
+
+// syncRepositorySetting syncs a setting changed through the repository update API
+func (e *Executor) syncRepositorySetting(ctx context.Context, setting string, source, dest *ghapi.Repository, destOrg, destName string) models.SettingsFieldSync {
+	field := models.SettingsFieldSync{Field: setting}
+	sourceValue, ok := repositorySettingValue(setting, source)
+	if !ok {
+		field.Status = models.SettingsSyncSkipped
+		field.Error = "not visible to the source token (admin access is required)"
+		return field
+	}
+	destValue, _ := repositorySettingValue(setting, dest)
+	field.Source, field.Destination = sourceValue, destValue
+
+	if reflect.DeepEqual(sourceValue, destValue) {
+		field.Status = models.SettingsSyncUnchanged
+		return field
+	}
+	if _, err := e.destClient.UpdateRepository(ctx, destOrg, destName, repositorySettingEdit(setting, source, dest)); err != nil {
+		field.Status = models.SettingsSyncFailed
+		field.Error = err.Error()
+		return field
+	}
+	field.Status = models.SettingsSyncApplied
+	return field
+}
+
+// syncTopics replaces the destination topics with the source topics
+func (e *Executor) syncTopics(ctx context.Context, source, dest *ghapi.Repository, destOrg, destName string) models.SettingsFieldSync {
+	sourceTopics := sortedTopics(source.Topics)
+	field := models.SettingsFieldSync{Field: SettingTopics, Source: sourceTopics, Destination: sortedTopics(dest.Topics)}
+
+	if slices.Equal(sourceTopics, field.Destination.([]string)) {
+		field.Status = models.SettingsSyncUnchanged
+		return field
+	}
+	if err := e.destClient.ReplaceTopics(ctx, destOrg, destName, sourceTopics); err != nil {
+		field.Status = models.SettingsSyncFailed
+		field.Error = err.Error()
+		return field
+	}
+	field.Status = models.SettingsSyncApplied
+	return field
+}
+
+// syncCustomProperties sets the source's custom property values that differ on the destination.
+// The properties must be defined in the destination organization.
+func (e *Executor) syncCustomProperties(ctx context.Context, repo *models.Repository, destOrg, destName string) models.SettingsFieldSync {
+	field := models.SettingsFieldSync{Field: SettingCustomProperties}
+
+	sourceValues, err := e.sourceClient.GetCustomPropertyValues(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		field.Status = models.SettingsSyncSkipped
+		field.Error = fmt.Sprintf("failed to read source custom properties: %v", err)
+		return field
+	}
+	destValues, err := e.destClient.GetCustomPropertyValues(ctx, destOrg, destName)
+	if err != nil {
+		field.Status = models.SettingsSyncFailed
+		field.Error = fmt.Sprintf("failed to read destination custom properties: %v", err)
+		return field
+	}
+
+	source, destination := customPropertyMap(sourceValues), customPropertyMap(destValues)
+	field.Source, field.Destination = source, destination
+
+	var changed []*ghapi.CustomPropertyValue
+	for _, value := range sourceValues {
+		if !reflect.DeepEqual(value.Value, destination[value.PropertyName]) {
+			changed = append(changed, value)
+		}
+	}
+	if len(changed) == 0 {
+		field.Status = models.SettingsSyncUnchanged
+		return field
+	}
+	if err := e.destClient.SetCustomPropertyValues(ctx, destOrg, destName, changed); err != nil {
+		field.Status = models.SettingsSyncFailed
+		field.Error = err.Error()
+		return field
+	}
+	field.Status = models.SettingsSyncApplied
+	return field
+}
+
+// repositorySettingValue returns a comparable value of a repository setting, or false when the
+// repository response does not include it (e.g. merge settings and security features are only
+// returned to tokens with admin access)
+func repositorySettingValue(setting string, repo *ghapi.Repository) (any, bool) {
+	switch setting {
+	case SettingDescription:
+		return repo.GetDescription(), true
+	case SettingHomepage:
+		return repo.GetHomepage(), true
+	case SettingMergeMethods:
+		if repo.AllowMergeCommit == nil && repo.AllowSquashMerge == nil && repo.AllowRebaseMerge == nil {
+			return nil, false
+		}
+		return map[string]bool{
+			"merge_commit": repo.GetAllowMergeCommit(),
+			"squash":       repo.GetAllowSquashMerge(),
+			"rebase":       repo.GetAllowRebaseMerge(),
+		}, true
+	case SettingDeleteBranchOnMerge:
+		if repo.DeleteBranchOnMerge == nil {
+			return nil, false
+		}
+		return repo.GetDeleteBranchOnMerge(), true
+	case SettingSecurityAndAnalysis:
+		features := securityFeatures(repo.GetSecurityAndAnalysis())
+		if len(features) == 0 {
+			return nil, false
+		}
+		return features, true
+	}
+	return nil, false
+}
+
+// repositorySettingEdit returns the repository update that applies the source's value of a setting.
+// Only security features that differ are changed, so features unavailable on the destination
+// (e.g. without an Advanced Security license) do not block the others.
+func repositorySettingEdit(setting string, source, dest *ghapi.Repository) *ghapi.Repository {
+	switch setting {
+	case SettingDescription:
+		return &ghapi.Repository{Description: ghapi.Ptr(source.GetDescription())}
+	case SettingHomepage:
+		return &ghapi.Repository{Homepage: ghapi.Ptr(source.GetHomepage())}
+	case SettingMergeMethods:
+		return &ghapi.Repository{
+			AllowMergeCommit: source.AllowMergeCommit,
+			AllowSquashMerge: source.AllowSquashMerge,
+			AllowRebaseMerge: source.AllowRebaseMerge,
+		}
+	case SettingDeleteBranchOnMerge:
+		return &ghapi.Repository{DeleteBranchOnMerge: source.DeleteBranchOnMerge}
+	case SettingSecurityAndAnalysis:
+		sourceFeatures := securityFeatures(source.GetSecurityAndAnalysis())
+		destFeatures := securityFeatures(dest.GetSecurityAndAnalysis())
+		edit := &ghapi.SecurityAndAnalysis{}
+		for feature, status := range sourceFeatures {
+			if destFeatures[feature] == status {
+				continue
+			}
+			switch feature {
+			case "advanced_security":
+				edit.AdvancedSecurity = &ghapi.AdvancedSecurity{Status: ghapi.Ptr(status)}
+			case "secret_scanning":
+				edit.SecretScanning = &ghapi.SecretScanning{Status: ghapi.Ptr(status)}
+			case "secret_scanning_push_protection":
+				edit.SecretScanningPushProtection = &ghapi.SecretScanningPushProtection{Status: ghapi.Ptr(status)}
+			case "dependabot_security_updates":
+				edit.DependabotSecurityUpdates = &ghapi.DependabotSecurityUpdates{Status: ghapi.Ptr(status)}
+			}
+		}
+		return &ghapi.Repository{SecurityAndAnalysis: edit}
+	}
+	return &ghapi.Repository{}
+}
+
+// securityFeatures returns the status ("enabled" or "disabled") of each security feature reported for a repository
+func securityFeatures(saa *ghapi.SecurityAndAnalysis) map[string]string {
+	features := make(map[string]string)
+	if saa == nil {
+		return features
+	}
+	if status := saa.GetAdvancedSecurity().GetStatus(); status != "" {
+		features["advanced_security"] = status
+	}
+	if status := saa.GetSecretScanning().GetStatus(); status != "" {
+		features["secret_scanning"] = status
+	}
+	if status := saa.GetSecretScanningPushProtection().GetStatus(); status != "" {
+		features["secret_scanning_push_protection"] = status
+	}
+	if status := saa.GetDependabotSecurityUpdates().GetStatus(); status != "" {
+		features["dependabot_security_updates"] = status
+	}
+	return features
+}
+
+// sortedTopics returns a sorted copy of the topics, never nil so an empty list is reported as []
+func sortedTopics(topics []string) []string {
+	sorted := append([]string{}, topics...)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// customPropertyMap indexes custom property values by property name
+func customPropertyMap(values []*ghapi.CustomPropertyValue) map[string]any {
+	m := make(map[string]any, len(values))
+	for _, value := range values {
+		m[value.PropertyName] = value.Value
+	}
+	return m
+}
+
+// settingsSyncMismatches converts the settings the sync could not apply into validation mismatches
+func settingsSyncMismatches(report *models.SettingsSyncReport) []ValidationMismatch {
+	var mismatches []ValidationMismatch
+	for _, setting := range report.Settings {
+		if setting.Status != models.SettingsSyncFailed {
+			continue
+		}
+		mismatches = append(mismatches, ValidationMismatch{
+			Field:       "setting_" + setting.Field,
+			SourceValue: setting.Source,
+			DestValue:   setting.Destination,
+			Critical:    false,
+		})
+	}
+	return mismatches
+}
@@ -0,0 +1,282 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func TestParseSettingsSync(t *testing.T) {
+	tests := []struct {
+		input   []string
+		want    string
+		wantErr bool
+	}{
+		{nil, "", false},
+		{[]string{""}, "", false},
+		{[]string{"topics", "description", "topics"}, "description,topics", false},
+		{[]string{"merge_methods", "all"}, strings.Join(SyncableSettings, ","), false},
+		{[]string{"topics", "visibility"}, "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSettingsSync(tt.input)
+		if (err != nil) != tt.wantErr || strings.Join(got, ",") != tt.want {
+			t.Errorf("ParseSettingsSync(%v) = %v, %v; want %q, error %v", tt.input, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRepositorySettingValue(t *testing.T) {
+	admin := &ghapi.Repository{
+		AllowMergeCommit:    ghapi.Ptr(false),
+		AllowSquashMerge:    ghapi.Ptr(true),
+		AllowRebaseMerge:    ghapi.Ptr(true),
+		DeleteBranchOnMerge: ghapi.Ptr(true),
+		SecurityAndAnalysis: &ghapi.SecurityAndAnalysis{
+			SecretScanning: &ghapi.SecretScanning{Status: ghapi.Ptr("enabled")},
+		},
+	}
+	reader := &ghapi.Repository{Description: ghapi.Ptr("Payments API")}
+
+	if value, ok := repositorySettingValue(SettingMergeMethods, admin); !ok || value.(map[string]bool)["merge_commit"] || !value.(map[string]bool)["squash"] {
+		t.Errorf("Unexpected merge methods %v", value)
+	}
+	if value, ok := repositorySettingValue(SettingSecurityAndAnalysis, admin); !ok || value.(map[string]string)["secret_scanning"] != "enabled" {
+		t.Errorf("Unexpected security features %v", value)
+	}
+	for _, setting := range []string{SettingMergeMethods, SettingDeleteBranchOnMerge, SettingSecurityAndAnalysis} {
+		if _, ok := repositorySettingValue(setting, reader); ok {
+			t.Errorf("Expected %s to be unavailable without admin access", setting)
+		}
+	}
+	if value, ok := repositorySettingValue(SettingDescription, reader); !ok || value != "Payments API" {
+		t.Errorf("Unexpected description %v", value)
+	}
+}
+
+func TestRepositorySettingEdit_SecurityAndAnalysis(t *testing.T) {
+	source := &ghapi.Repository{SecurityAndAnalysis: &ghapi.SecurityAndAnalysis{
+		SecretScanning:               &ghapi.SecretScanning{Status: ghapi.Ptr("enabled")},
+		SecretScanningPushProtection: &ghapi.SecretScanningPushProtection{Status: ghapi.Ptr("enabled")},
+	}}
+	dest := &ghapi.Repository{SecurityAndAnalysis: &ghapi.SecurityAndAnalysis{
+		SecretScanning: &ghapi.SecretScanning{Status: ghapi.Ptr("enabled")},
+	}}
+
+	edit := repositorySettingEdit(SettingSecurityAndAnalysis, source, dest).GetSecurityAndAnalysis()
+
+	if edit.SecretScanning != nil {
+		t.Error("Expected features that already match to be left out")
+	}
+	if edit.GetSecretScanningPushProtection().GetStatus() != "enabled" {
+		t.Errorf("Expected push protection to be enabled, got %+v", edit)
+	}
+}
+
+// fakeSettingsServer serves source and destination repositories and records the destination updates
+type fakeSettingsServer struct {
+	mu                  sync.Mutex
+	edits               []map[string]any
+	topics              []string
+	properties          []*ghapi.CustomPropertyValue
+	rejectSecurityEdits bool // Respond 422 to security and analysis updates, as without a license
+}
+
+func (f *fakeSettingsServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/source-org/repo", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"repo","description":"Payments API","homepage":"https://pay.example.com",
+			"topics":["payments","go"],"allow_merge_commit":false,"allow_squash_merge":true,"allow_rebase_merge":true,
+			"delete_branch_on_merge":true,"security_and_analysis":{"secret_scanning":{"status":"enabled"}}}`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/dest-org/repo", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"repo","description":"Payments API","homepage":"",
+			"topics":[],"allow_merge_commit":true,"allow_squash_merge":true,"allow_rebase_merge":true,
+			"delete_branch_on_merge":false,"security_and_analysis":{"secret_scanning":{"status":"disabled"}}}`))
+	})
+	mux.HandleFunc("PATCH /api/v3/repos/dest-org/repo", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["security_and_analysis"]; ok && f.rejectSecurityEdits {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"Secret scanning is not available for this repository"}`))
+			return
+		}
+		f.mu.Lock()
+		f.edits = append(f.edits, body)
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"name":"repo"}`))
+	})
+	mux.HandleFunc("PUT /api/v3/repos/dest-org/repo/topics", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Names []string `json:"names"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.topics = body.Names
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(body)
+	})
+	mux.HandleFunc("GET /api/v3/repos/source-org/repo/properties/values", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"property_name":"team","value":"payments"},{"property_name":"tier","value":"1"}]`))
+	})
+	mux.HandleFunc("GET /api/v3/repos/dest-org/repo/properties/values", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"property_name":"team","value":"payments"},{"property_name":"tier","value":null}]`))
+	})
+	mux.HandleFunc("PATCH /api/v3/repos/dest-org/repo/properties/values", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Properties []*ghapi.CustomPropertyValue `json:"properties"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		f.properties = body.Properties
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func setupSettingsTest(t *testing.T, fake *fakeSettingsServer, settings []string) (*Executor, *MigrationContext) {
+	t.Helper()
+
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := github.NewClient(github.ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: github.DefaultRetryConfig(),
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	executor, err := NewExecutor(ExecutorConfig{
+		SourceClient: client,
+		DestClient:   client,
+		Storage:      db,
+		Logger:       logger,
+		SettingsSync: settings,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+
+	destFullName := "dest-org/repo"
+	repo := createTestRepository("source-org/repo")
+	repo.DestinationFullName = &destFullName
+	if err := db.SaveRepository(context.Background(), repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+
+	return executor, &MigrationContext{Repo: repo}
+}
+
+func settingStatuses(report *models.SettingsSyncReport) map[string]string {
+	statuses := make(map[string]string, len(report.Settings))
+	for _, setting := range report.Settings {
+		statuses[setting.Field] = setting.Status
+	}
+	return statuses
+}
+
+func TestSyncSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		executor, mc := setupSettingsTest(t, &fakeSettingsServer{}, nil)
+		if report := executor.syncSettings(ctx, mc); report != nil {
+			t.Errorf("Expected no report, got %+v", report)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		fake := &fakeSettingsServer{}
+		executor, mc := setupSettingsTest(t, fake, SyncableSettings)
+		mc.DryRun = true
+
+		if report := executor.syncSettings(ctx, mc); report != nil {
+			t.Errorf("Expected no report, got %+v", report)
+		}
+		if len(fake.edits) != 0 || fake.topics != nil || fake.properties != nil {
+			t.Errorf("Expected the dry run destination to be left alone, got edits %v", fake.edits)
+		}
+	})
+
+	t.Run("all settings", func(t *testing.T) {
+		fake := &fakeSettingsServer{}
+		executor, mc := setupSettingsTest(t, fake, SyncableSettings)
+
+		report := executor.syncSettings(ctx, mc)
+
+		want := map[string]string{
+			SettingDescription:         models.SettingsSyncUnchanged,
+			SettingHomepage:            models.SettingsSyncApplied,
+			SettingTopics:              models.SettingsSyncApplied,
+			SettingMergeMethods:        models.SettingsSyncApplied,
+			SettingDeleteBranchOnMerge: models.SettingsSyncApplied,
+			SettingSecurityAndAnalysis: models.SettingsSyncApplied,
+			SettingCustomProperties:    models.SettingsSyncApplied,
+		}
+		got := settingStatuses(report)
+		for setting, status := range want {
+			if got[setting] != status {
+				t.Errorf("%s: status = %s, want %s", setting, got[setting], status)
+			}
+		}
+		if strings.Join(fake.topics, ",") != "go,payments" {
+			t.Errorf("Unexpected topics %v", fake.topics)
+		}
+		if len(fake.properties) != 1 || fake.properties[0].PropertyName != "tier" {
+			t.Errorf("Expected only the differing property to be set, got %+v", fake.properties)
+		}
+		if len(fake.edits) != 4 {
+			t.Errorf("Expected 4 repository updates, got %v", fake.edits)
+		}
+		if mismatches := settingsSyncMismatches(report); len(mismatches) != 0 {
+			t.Errorf("Expected no mismatches, got %+v", mismatches)
+		}
+	})
+
+	t.Run("rejected setting is a validation mismatch", func(t *testing.T) {
+		fake := &fakeSettingsServer{rejectSecurityEdits: true}
+		executor, mc := setupSettingsTest(t, fake, []string{SettingSecurityAndAnalysis, SettingDeleteBranchOnMerge})
+
+		report := executor.syncSettings(ctx, mc)
+
+		got := settingStatuses(report)
+		if got[SettingSecurityAndAnalysis] != models.SettingsSyncFailed || got[SettingDeleteBranchOnMerge] != models.SettingsSyncApplied {
+			t.Errorf("Unexpected statuses %v", got)
+		}
+		mismatches := settingsSyncMismatches(report)
+		if len(mismatches) != 1 || mismatches[0].Field != "setting_security_and_analysis" || mismatches[0].Critical {
+			t.Errorf("Unexpected mismatches %+v", mismatches)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package migration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,24 +10,30 @@ import (
 )
 
 // ExecuteWithStrategy executes a migration using the appropriate strategy based on the repository source.
-// This is the unified entry point that automatically selects between GitHub and ADO migration strategies.
+// This is the unified entry point that automatically selects between the ELM, GitLab, GitHub and ADO migration strategies.
 //
 // The migration proceeds through these common phases:
 //  1. Strategy selection and source validation
 //  2. Pre-migration validation and discovery
-//  3. Archive preparation (source-specific: GitHub generates archives, ADO skips)
-//  4. Migration start (source-specific: different GraphQL mutations)
-//  5. Migration status polling
+//  3. Archive preparation (source-specific: GitHub generates archives, ADO, GitLab and git mirror skip)
+//  4. Migration start (source-specific: different GraphQL mutations, or a mirror push for GitLab and git mirror)
+//  5. Migration status polling (ELM also drives its cutover here)
 //  6. Post-migration validation
 //  7. Completion and cleanup
+//
+// In a delta sync batch the dry run is the pre-seed, and the migration of a pre-seeded
+// repository is a cutover that only re-syncs the refs changed since (see executeCutover).
 func (e *Executor) ExecuteWithStrategy(ctx context.Context, repo *models.Repository, batch *models.Batch, dryRun bool) error {
-	// Create strategy registry and get appropriate strategy
-	registry := NewStrategyRegistry(
-		NewGitHubMigrationStrategy(e),
-		NewADOMigrationStrategy(e),
-	)
+	// Delta sync batches only re-sync changed refs for repositories pre-seeded by their dry run
+	if !dryRun && batch.UsesDeltaSync() {
+		if repo.IsPreSeeded() {
+			return e.executeCutover(ctx, repo, batch)
+		}
+		e.logger.Warn("Repository was not pre-seeded, running a full migration", "repo", repo.FullName, "batch", batch.Name)
+	}
 
-	strategy := registry.GetStrategy(repo)
+	// Create strategy registry and get appropriate strategy
+	strategy := e.newStrategyRegistry(batch).GetStrategy(repo)
 	if strategy == nil {
 		return fmt.Errorf("no migration strategy found for repository %s", repo.FullName)
 	}
@@ -83,30 +90,89 @@ func (e *Executor) ExecuteWithStrategy(ctx context.Context, repo *models.Reposit
 		e.handleStrategyPhaseError(ctx, mc, strategy, err)
 		return err
 	}
-
-	// Phase 3: Archive preparation (strategy-specific)
-	if err := e.executeArchivePreparation(ctx, mc, strategy); err != nil {
+	if err := e.saveCheckpoint(ctx, mc, models.CheckpointPreMigration); err != nil {
 		e.handleStrategyPhaseError(ctx, mc, strategy, err)
 		return err
 	}
 
-	// Phase 4: Migration start (strategy-specific)
-	migrationID, err := strategy.StartMigration(ctx, mc)
-	if err != nil {
+	// Phase 3: Archive preparation (strategy-specific)
+	if err := e.executeArchivePreparation(ctx, mc, strategy); err != nil {
 		e.handleStrategyPhaseError(ctx, mc, strategy, err)
 		return err
 	}
-	mc.MigrationID = migrationID
 
-	// Phase 5: Migration polling (common) - reuse existing phase method
-	if err := e.phaseMigrationPolling(ctx, mc); err != nil {
+	// Phases 4-7 are shared with migrations resumed after a restart
+	return e.executeFromCheckpoint(ctx, mc, strategy, models.CheckpointArchivesReady)
+}
+
+// newStrategyRegistry creates the strategy registry used to select a repository's strategy.
+// ELM and git mirror are registered first since they are selected by the batch's
+// migration API rather than by the repository source. GitLab must precede GitHub,
+// which matches every repository without an ADO project.
+func (e *Executor) newStrategyRegistry(batch *models.Batch) *StrategyRegistry {
+	return NewStrategyRegistry(
+		NewELMMigrationStrategy(e, batch),
+		NewGitMirrorMigrationStrategy(e, batch),
+		NewGitLabMigrationStrategy(e),
+		NewGitHubMigrationStrategy(e),
+		NewADOMigrationStrategy(e),
+	)
+}
+
+// executeFromCheckpoint runs the phases that follow the given checkpoint through to completion,
+// recording a new checkpoint as each phase finishes.
+func (e *Executor) executeFromCheckpoint(ctx context.Context, mc *MigrationContext, strategy MigrationStrategy, checkpoint string) error {
+	switch checkpoint {
+	case models.CheckpointArchivesGenerating:
+		// Archive generation was started before a restart - wait for the same archives
+		if mc.ArchiveIDs == nil {
+			err := fmt.Errorf("checkpoint %s has no archive IDs", checkpoint)
+			e.handleStrategyPhaseError(ctx, mc, strategy, err)
+			return err
+		}
+		if err := e.phaseArchivePolling(ctx, mc); err != nil {
+			e.handleStrategyPhaseError(ctx, mc, strategy, err)
+			return err
+		}
+		fallthrough
+
+	case models.CheckpointArchivesReady:
+		// Phase 4: Migration start (strategy-specific)
+		migrationID, err := strategy.StartMigration(ctx, mc)
+		if err != nil {
+			e.handleStrategyPhaseError(ctx, mc, strategy, err)
+			return err
+		}
+		mc.MigrationID = migrationID
+		if err := e.saveCheckpoint(ctx, mc, models.CheckpointMigrationStarted); err != nil {
+			e.handleStrategyPhaseError(ctx, mc, strategy, err)
+			return err
+		}
+		fallthrough
+
+	case models.CheckpointMigrationStarted:
+		// Phase 5: Migration polling (strategy-specific if the strategy provides its own poller)
+		if err := e.executeMigrationPolling(ctx, mc, strategy); err != nil {
+			e.handleStrategyPhaseError(ctx, mc, strategy, err)
+			return err
+		}
+		if err := e.saveCheckpoint(ctx, mc, models.CheckpointMigrationFinished); err != nil {
+			e.handleStrategyPhaseError(ctx, mc, strategy, err)
+			return err
+		}
+
+	case models.CheckpointMigrationFinished:
+		// Only post-migration and completion remain
+
+	default:
+		err := fmt.Errorf("unknown migration checkpoint %q", checkpoint)
 		e.handleStrategyPhaseError(ctx, mc, strategy, err)
 		return err
 	}
 
 	// Phase 6: Post-migration validation (common, errors logged but don't fail) - reuse existing phase method
 	if err := e.phasePostMigration(ctx, mc); err != nil {
-		e.logger.Warn("Post-migration phase returned error", "error", err, "repo", repo.FullName)
+		e.logger.Warn("Post-migration phase returned error", "error", err, "repo", mc.Repo.FullName)
 	}
 
 	// Phase 7: Completion (strategy-aware)
@@ -136,6 +202,15 @@ func (e *Executor) executeArchivePreparation(ctx context.Context, mc *MigrationC
 	return strategy.PrepareArchives(ctx, mc)
 }
 
+// executeMigrationPolling waits for migration completion, using the strategy's own
+// poller when it implements MigrationPoller and GEI status polling otherwise.
+func (e *Executor) executeMigrationPolling(ctx context.Context, mc *MigrationContext, strategy MigrationStrategy) error {
+	if poller, ok := strategy.(MigrationPoller); ok {
+		return poller.PollMigration(ctx, mc)
+	}
+	return e.phaseMigrationPolling(ctx, mc)
+}
+
 // executeCompletion marks the migration as complete with strategy-aware cleanup.
 func (e *Executor) executeCompletion(ctx context.Context, mc *MigrationContext, strategy MigrationStrategy) error {
 	completionStatus := models.StatusComplete
@@ -147,6 +222,11 @@ func (e *Executor) executeCompletion(ctx context.Context, mc *MigrationContext,
 		e.unlockSourceRepository(ctx, mc.Repo)
 	}
 
+	// Run the batch's completion actions on the source once it is unlocked
+	if !mc.DryRun {
+		e.runCompletionActions(ctx, mc.Repo, mc.Batch, mc.HistoryID)
+	}
+
 	if mc.DryRun {
 		completionStatus = models.StatusDryRunComplete
 		completionMsg = msgDryRunComplete
@@ -164,6 +244,9 @@ func (e *Executor) executeCompletion(ctx context.Context, mc *MigrationContext,
 
 	if mc.DryRun {
 		mc.Repo.LastDryRunAt = &now
+		if mc.PreSeed {
+			e.recordPreSeed(ctx, mc)
+		}
 	} else {
 		mc.Repo.MigratedAt = &now
 	}
@@ -173,6 +256,11 @@ func (e *Executor) executeCompletion(ctx context.Context, mc *MigrationContext,
 
 // handleStrategyPhaseError handles error recovery for a phase failure with strategy context.
 func (e *Executor) handleStrategyPhaseError(ctx context.Context, mc *MigrationContext, strategy MigrationStrategy, err error) {
+	// The replica that took the migration over records its outcome
+	if errors.Is(err, ErrLeaseLost) {
+		return
+	}
+
 	errMsg := err.Error()
 	e.updateHistoryStatus(ctx, mc.HistoryID, statusFailed, &errMsg)
 
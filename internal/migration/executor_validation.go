@@ -22,11 +22,21 @@ type ValidationMismatch struct {
 // validatePreMigration performs pre-migration validation
 // nolint:gocyclo // Complex validation logic - refactoring would reduce readability
 func (e *Executor) validatePreMigration(ctx context.Context, repo *models.Repository, batch *models.Batch) error {
-	// Check for GitHub Enterprise Importer blocking issues
-	if repo.HasOversizedRepository() {
+	// Check for GitHub Enterprise Importer blocking issues.
+	// Git mirror migrations are not subject to GEI limits, so they proceed with a warning.
+	if repo.HasOversizedRepository() && usesGitMirror(repo, batch) {
+		e.logger.Warn("Repository exceeds GitHub's 40 GiB GEI limit, continuing with git mirror migration",
+			"repo", repo.FullName)
+	} else if repo.HasOversizedRepository() {
 		return fmt.Errorf("repository exceeds GitHub's 40 GiB size limit and requires remediation before migration (reduce repository size using Git LFS or history rewriting)")
 	}
 
+	// GitHub rejects pushes containing files over 100 MB, so a git mirror migration would only
+	// fail at the push, after the full mirror clone
+	if usesGitMirror(repo, batch) && mirrorPushBlocked(repo, e.gitMirror) {
+		return fmt.Errorf("repository has files over GitHub's 100 MB push limit and requires remediation before a git mirror migration (move them to Git LFS and enable LFS transfer, or rewrite history)")
+	}
+
 	// Check for blockers
 	var issues []string
 
@@ -146,8 +156,11 @@ func (e *Executor) validatePreMigration(ctx context.Context, repo *models.Reposi
 	return nil
 }
 
-// validatePostMigration performs comprehensive post-migration validation
-func (e *Executor) validatePostMigration(ctx context.Context, repo *models.Repository) error {
+// validatePostMigration performs comprehensive post-migration validation.
+// When deep validation is enabled, every ref, the artifact counts and LFS objects are also compared.
+// settings is the report of the settings sync (nil when it did not run); settings it could not apply
+// are reported as mismatches and the report is saved with the validation.
+func (e *Executor) validatePostMigration(ctx context.Context, repo *models.Repository, batch *models.Batch, settings *models.SettingsSyncReport) error {
 	if repo.DestinationFullName == nil {
 		return fmt.Errorf("destination repository not set")
 	}
@@ -165,6 +178,44 @@ func (e *Executor) validatePostMigration(ctx context.Context, repo *models.Repos
 	// Compare source and destination characteristics
 	mismatches, hasCriticalMismatches := e.compareRepositoryCharacteristics(repo, destRepo)
 
+	var deepReport *string
+	if e.deepValidation {
+		report := e.deepValidate(ctx, repo, batch, *repo.DestinationFullName)
+		for _, mismatch := range deepValidationMismatches(report) {
+			mismatches = append(mismatches, mismatch)
+			hasCriticalMismatches = hasCriticalMismatches || mismatch.Critical
+		}
+		if len(report.Errors) > 0 {
+			e.logger.Warn("Deep validation checks could not run",
+				"repo", repo.FullName,
+				"errors", report.Errors)
+		}
+
+		data, err := json.Marshal(report)
+		if err != nil {
+			e.logger.Error("Failed to marshal deep validation report", "error", err)
+		} else {
+			reportJSON := string(data)
+			deepReport = &reportJSON
+		}
+	}
+
+	var settingsReport *string
+	if settings != nil {
+		for _, mismatch := range settingsSyncMismatches(settings) {
+			mismatches = append(mismatches, mismatch)
+			hasCriticalMismatches = hasCriticalMismatches || mismatch.Critical
+		}
+
+		data, err := json.Marshal(settings)
+		if err != nil {
+			e.logger.Error("Failed to marshal settings sync report", "error", err)
+		} else {
+			reportJSON := string(data)
+			settingsReport = &reportJSON
+		}
+	}
+
 	// Generate validation report
 	validationStatus := "passed"
 	var validationDetails *string
@@ -201,7 +252,7 @@ func (e *Executor) validatePostMigration(ctx context.Context, repo *models.Repos
 	}
 
 	// Update validation fields in database
-	if err := e.storage.UpdateRepositoryValidation(ctx, repo.FullName, validationStatus, validationDetails, destinationData); err != nil {
+	if err := e.storage.UpdateRepositoryValidation(ctx, repo.FullName, validationStatus, validationDetails, destinationData, deepReport, settingsReport); err != nil {
 		e.logger.Error("Failed to update validation status", "error", err)
 		// Don't fail the migration due to database update error
 	}
@@ -210,6 +261,8 @@ func (e *Executor) validatePostMigration(ctx context.Context, repo *models.Repos
 	repo.SetValidationStatus(&validationStatus)
 	repo.SetValidationDetails(validationDetails)
 	repo.SetDestinationData(destinationData)
+	repo.SetDeepValidationReport(deepReport)
+	repo.SetSettingsSyncReport(settingsReport)
 
 	// Don't fail migration on validation warnings - just log them
 	return nil
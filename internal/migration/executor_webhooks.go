@@ -0,0 +1,125 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// opWebhook is the migration log operation for recreated webhooks
+const opWebhook = "webhook"
+
+// replayWebhooks recreates the source repository's webhooks on the destination. Hook secrets
+// cannot be read back from GitHub, so every hook is created inactive and tracked as pending
+// until its owner has re-entered the secret and activated it. Hooks already present on the
+// destination with the same URL are tracked as they are instead of being duplicated.
+// Dry runs are skipped so their hooks are never tracked, as is every migration when the
+// replay is turned off. Failures are logged and never fail the migration.
+func (e *Executor) replayWebhooks(ctx context.Context, mc *MigrationContext) {
+	repo := mc.Repo
+	if !e.webhookReplay || mc.DryRun {
+		return
+	}
+	if e.sourceClient == nil {
+		e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opWebhook,
+			"Skipping webhook replay (source is not a GitHub repository)", nil)
+		return
+	}
+	if repo.DestinationFullName == nil || *repo.DestinationFullName == "" {
+		return
+	}
+	destOrg, destName, _ := strings.Cut(*repo.DestinationFullName, "/")
+
+	hooks, err := e.sourceClient.ListHooks(ctx, repo.Organization(), repo.Name())
+	if err != nil {
+		e.warnReplay(ctx, mc, opWebhook, "Failed to list source webhooks", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+	existing, err := e.destClient.ListHooks(ctx, destOrg, destName)
+	if err != nil {
+		e.warnReplay(ctx, mc, opWebhook, "Failed to list destination webhooks", err)
+		return
+	}
+	byURL := hooksByURL(existing)
+
+	tracked := make([]*models.RepositoryWebhook, 0, len(hooks))
+	pending := 0
+	for _, hook := range hooks {
+		request, webhook := webhookForDestination(hook)
+
+		if present, ok := byURL[webhook.URL]; ok {
+			webhook.DestinationHookID = ghapi.Ptr(present.GetID())
+			if present.GetActive() {
+				webhook.Status = models.WebhookStatusActive
+			}
+		} else {
+			created, err := e.destClient.CreateHook(ctx, destOrg, destName, request)
+			if err != nil {
+				e.warnReplay(ctx, mc, opWebhook, fmt.Sprintf("Failed to create webhook %s", webhook.URL), err)
+				errMsg := err.Error()
+				webhook.Status = models.WebhookStatusFailed
+				webhook.Error = &errMsg
+			} else {
+				webhook.DestinationHookID = ghapi.Ptr(created.GetID())
+			}
+		}
+
+		if webhook.Status == models.WebhookStatusPending {
+			pending++
+		}
+		tracked = append(tracked, webhook)
+	}
+
+	if err := e.storage.SaveRepositoryWebhooks(ctx, repo.ID, tracked); err != nil {
+		e.logger.Warn("Failed to save webhooks", "repo", repo.FullName, "error", err)
+		return
+	}
+	e.logOperation(ctx, repo, mc.HistoryID, "INFO", "post_migration", opWebhook,
+		fmt.Sprintf("Recreated %d webhooks inactive; %d must be activated once their secrets are re-entered", len(tracked), pending), nil)
+}
+
+// webhookForDestination converts a source webhook into an inactive hook for the destination
+// and the record that tracks it. Hooks inactive on the source are tracked as disabled rather
+// than pending, since nobody is waiting for them to deliver.
+func webhookForDestination(hook *ghapi.Hook) (*ghapi.Hook, *models.RepositoryWebhook) {
+	config := hook.GetConfig()
+	request := &ghapi.Hook{
+		Config: &ghapi.HookConfig{
+			URL:         config.URL,
+			ContentType: config.ContentType,
+			InsecureSSL: config.InsecureSSL,
+		},
+		Events: hook.Events,
+		Active: ghapi.Ptr(false),
+	}
+
+	webhook := &models.RepositoryWebhook{
+		SourceHookID: hook.GetID(),
+		URL:          config.GetURL(),
+		Events:       strings.Join(hook.Events, ","),
+		ContentType:  config.GetContentType(),
+		InsecureSSL:  config.GetInsecureSSL() == "1",
+		HasSecret:    config.GetSecret() != "",
+		Status:       models.WebhookStatusPending,
+	}
+	if !hook.GetActive() {
+		webhook.Status = models.WebhookStatusDisabled
+	}
+
+	return request, webhook
+}
+
+// hooksByURL indexes webhooks by their delivery URL
+func hooksByURL(hooks []*ghapi.Hook) map[string]*ghapi.Hook {
+	byURL := make(map[string]*ghapi.Hook, len(hooks))
+	for _, hook := range hooks {
+		byURL[hook.GetConfig().GetURL()] = hook
+	}
+	return byURL
+}
@@ -0,0 +1,98 @@
+package migration
+
+import (
+	"context"
+	"testing"
+
+	ghapi "github.com/google/go-github/v75/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestWebhookForDestination(t *testing.T) {
+	hook := &ghapi.Hook{
+		ID:     ghapi.Ptr(int64(7)),
+		Active: ghapi.Ptr(true),
+		Events: []string{"push", "pull_request"},
+		Config: &ghapi.HookConfig{
+			URL:         ghapi.Ptr("https://ci.example.com/hook"),
+			ContentType: ghapi.Ptr("json"),
+			InsecureSSL: ghapi.Ptr("1"),
+			Secret:      ghapi.Ptr("********"),
+		},
+	}
+
+	request, webhook := webhookForDestination(hook)
+
+	if request.GetActive() {
+		t.Error("expected the destination hook to be created inactive")
+	}
+	if request.GetConfig().Secret != nil {
+		t.Error("expected the obfuscated secret not to be copied")
+	}
+	if request.GetConfig().GetURL() != "https://ci.example.com/hook" || request.GetConfig().GetContentType() != "json" || len(request.Events) != 2 {
+		t.Errorf("unexpected destination hook %+v", request)
+	}
+	if webhook.SourceHookID != 7 || webhook.Events != "push,pull_request" || !webhook.InsecureSSL || !webhook.HasSecret {
+		t.Errorf("unexpected webhook record %+v", webhook)
+	}
+	if webhook.Status != models.WebhookStatusPending {
+		t.Errorf("status = %s, want %s", webhook.Status, models.WebhookStatusPending)
+	}
+}
+
+func TestWebhookForDestination_InactiveOnSource(t *testing.T) {
+	_, webhook := webhookForDestination(&ghapi.Hook{
+		Active: ghapi.Ptr(false),
+		Config: &ghapi.HookConfig{URL: ghapi.Ptr("https://old.example.com/hook")},
+	})
+
+	if webhook.Status != models.WebhookStatusDisabled {
+		t.Errorf("status = %s, want %s", webhook.Status, models.WebhookStatusDisabled)
+	}
+	if webhook.HasSecret || webhook.InsecureSSL {
+		t.Errorf("unexpected webhook record %+v", webhook)
+	}
+}
+
+func TestHooksByURL(t *testing.T) {
+	byURL := hooksByURL([]*ghapi.Hook{
+		{ID: ghapi.Ptr(int64(1)), Config: &ghapi.HookConfig{URL: ghapi.Ptr("https://a.example.com")}},
+		{ID: ghapi.Ptr(int64(2)), Config: &ghapi.HookConfig{URL: ghapi.Ptr("https://b.example.com")}},
+	})
+
+	if len(byURL) != 2 || byURL["https://b.example.com"].GetID() != 2 {
+		t.Errorf("unexpected index %+v", byURL)
+	}
+}
+
+func TestReplayWebhooks_Skipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		dryRun  bool
+	}{
+		{name: "dry run", enabled: true, dryRun: true},
+		{name: "turned off", enabled: false, dryRun: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			executor, mc, requests := setupReplayTest(t, ExecutorConfig{WebhookReplay: tt.enabled})
+			mc.DryRun = tt.dryRun
+
+			executor.replayWebhooks(ctx, mc)
+
+			if n := requests.Load(); n != 0 {
+				t.Errorf("Expected no repository requests, got %d", n)
+			}
+			hooks, err := executor.storage.GetRepositoryWebhooks(ctx, mc.Repo.ID)
+			if err != nil {
+				t.Fatalf("Failed to get webhooks: %v", err)
+			}
+			if len(hooks) != 0 {
+				t.Errorf("Expected no tracked webhooks, got %d", len(hooks))
+			}
+		})
+	}
+}
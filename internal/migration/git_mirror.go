@@ -0,0 +1,162 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+)
+
+// defaultMirrorRefSpecs pushes every branch and tag.
+// Provider-specific refs (GitLab's refs/merge-requests/*, GitHub's refs/pull/*, ...)
+// are left out since the destination either rejects them or has no use for them.
+var defaultMirrorRefSpecs = []string{
+	"+refs/heads/*:refs/heads/*",
+	"+refs/tags/*:refs/tags/*",
+}
+
+// GitMirrorOptions configures git-only mirror-push migrations
+type GitMirrorOptions struct {
+	IncludeLFS bool     // Transfer Git LFS objects for repositories that use LFS
+	RefFilters []string // Ref patterns to push (e.g. "refs/heads/main", "refs/tags/v*"); all branches and tags when empty
+}
+
+// refSpecsFromFilters converts ref patterns into force-push refspecs that map each
+// ref to the same name on the destination. Patterns must be full ref names starting
+// with "refs/" and may contain a single "*" wildcard, as git refspecs allow.
+func refSpecsFromFilters(filters []string) ([]string, error) {
+	if len(filters) == 0 {
+		return defaultMirrorRefSpecs, nil
+	}
+
+	refSpecs := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		pattern := strings.TrimPrefix(strings.TrimSpace(filter), "+")
+		if !strings.HasPrefix(pattern, "refs/") {
+			return nil, fmt.Errorf("invalid ref filter %q: must start with refs/", filter)
+		}
+		if strings.Count(pattern, "*") > 1 {
+			return nil, fmt.Errorf("invalid ref filter %q: only one * wildcard is allowed", filter)
+		}
+		if strings.ContainsAny(pattern, ": \t?[\\^~") || strings.Contains(pattern, "..") {
+			return nil, fmt.Errorf("invalid ref filter %q: contains characters not allowed in ref names", filter)
+		}
+		refSpecs = append(refSpecs, "+"+pattern+":"+pattern)
+	}
+	return refSpecs, nil
+}
+
+// mirrorPushOptions configures how a mirror clone is pushed to the destination
+type mirrorPushOptions struct {
+	RefSpecs   []string // Refs to push (default: all branches and tags)
+	IncludeLFS bool     // Also push Git LFS objects (requires git-lfs)
+	Token      string   // Token embedded in the destination URL, redacted from errors
+}
+
+// cloneMirror creates a mirror clone of the repository's source into a new temporary directory.
+// The caller must remove the returned directory when done.
+func (e *Executor) cloneMirror(ctx context.Context, repo *models.Repository, includeLFS bool) (string, error) {
+	tempDir, err := os.MkdirTemp("", "github-migrator-mirror-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp directory: %w", err)
+	}
+
+	repoPath := filepath.Join(tempDir, "repo.git")
+	info := source.RepositoryInfo{
+		FullName: repo.FullName,
+		CloneURL: repo.SourceURL,
+	}
+	opts := source.CloneOptions{
+		Mirror:            true,
+		IncludeLFS:        includeLFS,
+		IncludeSubmodules: false,
+	}
+
+	if err := e.sourceProvider.CloneRepository(ctx, info, repoPath, opts); err != nil {
+		_ = os.RemoveAll(tempDir)
+		return "", fmt.Errorf("failed to clone source repository: %w", err)
+	}
+
+	return tempDir, nil
+}
+
+// destinationPushURL returns the git URL of a destination repository with the
+// destination token embedded for HTTPS authentication.
+func (e *Executor) destinationPushURL(destFullName string) (string, error) {
+	token := e.destClient.Token()
+	if token == "" {
+		return "", fmt.Errorf("destination client has no token available for git push")
+	}
+
+	parsedURL, err := url.Parse(e.destClient.RepositoryURL(destFullName) + ".git")
+	if err != nil {
+		return "", fmt.Errorf("invalid destination URL: %w", err)
+	}
+	parsedURL.User = url.UserPassword("x-access-token", token)
+
+	return parsedURL.String(), nil
+}
+
+// pushMirror pushes the refs of a mirror clone at repoPath to destURL.
+// Refs deleted on the source are pruned from the destination so repeated pushes converge.
+func pushMirror(ctx context.Context, repoPath, destURL string, opts mirrorPushOptions) error {
+	if err := source.ValidateDestPath(repoPath); err != nil {
+		return fmt.Errorf("invalid repository path: %w", err)
+	}
+
+	refSpecs := opts.RefSpecs
+	if len(refSpecs) == 0 {
+		refSpecs = defaultMirrorRefSpecs
+	}
+
+	args := append([]string{"push", "--prune", destURL}, refSpecs...)
+	if err := runGit(ctx, repoPath, opts.Token, args...); err != nil {
+		return fmt.Errorf("git push failed: %w", err)
+	}
+
+	if opts.IncludeLFS {
+		if err := runGit(ctx, repoPath, opts.Token, "lfs", "push", "--all", destURL); err != nil {
+			return fmt.Errorf("git lfs push failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// runGit runs a git command in dir, redacting token from any error output
+func runGit(ctx context.Context, dir, token string, args ...string) error {
+	_, err := gitOutput(ctx, dir, token, args...)
+	return err
+}
+
+// gitOutput runs a git command in dir and returns its standard output,
+// redacting token from any error output
+func gitOutput(ctx context.Context, dir, token string, args ...string) (string, error) {
+	// #nosec G204 -- arguments are built from validated paths, URLs and fixed refspecs
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	// Set GIT_TERMINAL_PROMPT=0 to prevent interactive prompts
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if err := cmd.Run(); err != nil {
+		errMsg := strings.TrimSpace(stderr.String())
+		if token != "" {
+			errMsg = strings.ReplaceAll(errMsg, token, "[REDACTED]")
+		}
+		return "", fmt.Errorf("%w: %s", err, errMsg)
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,183 @@
+package migration
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gitTest runs a git command in dir, skipping the test when git is unavailable
+func gitTest(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Skipf("git %s failed, skipping test: %v (%s)", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupMirrorRepos creates a mirror clone with two branches, a tag and a
+// GitLab-style merge request ref, plus an empty bare destination repository.
+func setupMirrorRepos(t *testing.T) (mirrorPath, destPath string) {
+	t.Helper()
+
+	base := t.TempDir()
+	workPath := filepath.Join(base, "work")
+	mirrorPath = filepath.Join(base, "mirror.git")
+	destPath = filepath.Join(base, "dest.git")
+
+	if err := os.MkdirAll(workPath, 0750); err != nil {
+		t.Fatalf("Failed to create work directory: %v", err)
+	}
+	gitTest(t, workPath, "init", "-b", "main")
+	if err := os.WriteFile(filepath.Join(workPath, "README.md"), []byte("hello"), 0600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	gitTest(t, workPath, "add", ".")
+	gitTest(t, workPath, "commit", "-m", "Initial commit")
+	gitTest(t, workPath, "branch", "feature")
+	gitTest(t, workPath, "tag", "v1.0.0")
+	gitTest(t, workPath, "update-ref", "refs/merge-requests/1/head", "HEAD")
+
+	gitTest(t, base, "clone", "--mirror", workPath, mirrorPath)
+	gitTest(t, base, "init", "--bare", destPath)
+
+	return mirrorPath, destPath
+}
+
+func TestPushMirror(t *testing.T) {
+	ctx := context.Background()
+	mirrorPath, destPath := setupMirrorRepos(t)
+
+	if err := pushMirror(ctx, mirrorPath, destPath, mirrorPushOptions{}); err != nil {
+		t.Fatalf("pushMirror() error: %v", err)
+	}
+
+	refs := gitTest(t, destPath, "for-each-ref", "--format=%(refname)")
+	for _, want := range []string{"refs/heads/main", "refs/heads/feature", "refs/tags/v1.0.0"} {
+		if !strings.Contains(refs, want) {
+			t.Errorf("destination is missing %s, refs:\n%s", want, refs)
+		}
+	}
+	if strings.Contains(refs, "refs/merge-requests/") {
+		t.Errorf("merge request refs should not be pushed, refs:\n%s", refs)
+	}
+
+	// Branches deleted on the source are pruned from the destination on the next push
+	gitTest(t, mirrorPath, "update-ref", "-d", "refs/heads/feature")
+	if err := pushMirror(ctx, mirrorPath, destPath, mirrorPushOptions{}); err != nil {
+		t.Fatalf("pushMirror() second push error: %v", err)
+	}
+	refs = gitTest(t, destPath, "for-each-ref", "--format=%(refname)")
+	if strings.Contains(refs, "refs/heads/feature") {
+		t.Errorf("deleted branch should be pruned from destination, refs:\n%s", refs)
+	}
+}
+
+func TestPushMirror_CustomRefSpecs(t *testing.T) {
+	mirrorPath, destPath := setupMirrorRepos(t)
+
+	opts := mirrorPushOptions{RefSpecs: []string{"+refs/heads/main:refs/heads/main"}}
+	if err := pushMirror(context.Background(), mirrorPath, destPath, opts); err != nil {
+		t.Fatalf("pushMirror() error: %v", err)
+	}
+
+	refs := gitTest(t, destPath, "for-each-ref", "--format=%(refname)")
+	if refs != "refs/heads/main" {
+		t.Errorf("destination refs = %q, want only refs/heads/main", refs)
+	}
+}
+
+func TestPushMirror_InvalidPath(t *testing.T) {
+	err := pushMirror(context.Background(), "/tmp/repo;rm -rf", "/tmp/dest.git", mirrorPushOptions{})
+	if err == nil || !strings.Contains(err.Error(), "invalid repository path") {
+		t.Errorf("pushMirror() error = %v, want invalid repository path", err)
+	}
+}
+
+func TestRunGit_RedactsToken(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available, skipping test")
+	}
+
+	err := runGit(context.Background(), t.TempDir(), "secret-token", "ls-remote", "/nonexistent/secret-token/repo.git")
+	if err == nil {
+		t.Fatal("runGit() expected error for missing remote")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("runGit() error leaks token: %v", err)
+	}
+	if !strings.Contains(err.Error(), "[REDACTED]") {
+		t.Errorf("runGit() error = %v, want redacted token", err)
+	}
+}
+
+func TestRefSpecsFromFilters(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "no filters uses default refspecs",
+			want: defaultMirrorRefSpecs,
+		},
+		{
+			name:    "branches and tags",
+			filters: []string{"refs/heads/release/*", " refs/tags/* "},
+			want:    []string{"+refs/heads/release/*:refs/heads/release/*", "+refs/tags/*:refs/tags/*"},
+		},
+		{
+			name:    "leading plus is accepted",
+			filters: []string{"+refs/heads/main"},
+			want:    []string{"+refs/heads/main:refs/heads/main"},
+		},
+		{name: "short branch name", filters: []string{"main"}, wantErr: true},
+		{name: "multiple wildcards", filters: []string{"refs/*/release/*"}, wantErr: true},
+		{name: "explicit destination", filters: []string{"refs/heads/main:refs/heads/trunk"}, wantErr: true},
+		{name: "parent traversal", filters: []string{"refs/heads/../tags/*"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := refSpecsFromFilters(tt.filters)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("refSpecsFromFilters() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("refSpecsFromFilters() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushMirror_FilteredRefSpecs(t *testing.T) {
+	mirrorPath, destPath := setupMirrorRepos(t)
+
+	refSpecs, err := refSpecsFromFilters([]string{"refs/heads/*", "refs/tags/v1.*"})
+	if err != nil {
+		t.Fatalf("refSpecsFromFilters() error: %v", err)
+	}
+	if err := pushMirror(context.Background(), mirrorPath, destPath, mirrorPushOptions{RefSpecs: refSpecs}); err != nil {
+		t.Fatalf("pushMirror() error: %v", err)
+	}
+
+	refs := gitTest(t, destPath, "for-each-ref", "--format=%(refname)")
+	want := "refs/heads/feature\nrefs/heads/main\nrefs/tags/v1.0.0"
+	if refs != want {
+		t.Errorf("destination refs = %q, want %q", refs, want)
+	}
+}
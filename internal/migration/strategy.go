@@ -35,6 +35,15 @@ type MigrationStrategy interface {
 	ShouldUnlockSource() bool
 }
 
+// MigrationPoller is an optional interface for strategies that track migration
+// progress through their own API instead of GEI's GraphQL migration status.
+// When a strategy implements it, PollMigration replaces the common polling phase.
+type MigrationPoller interface {
+	// PollMigration waits for the migration started by StartMigration to finish,
+	// driving any strategy-specific phases (such as cutover) along the way.
+	PollMigration(ctx context.Context, mc *MigrationContext) error
+}
+
 // StrategyRegistry manages the available migration strategies.
 type StrategyRegistry struct {
 	strategies []MigrationStrategy
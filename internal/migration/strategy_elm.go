@@ -0,0 +1,263 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// ELMMigrationStrategy implements MigrationStrategy for the Enterprise Live Migrator (ELM).
+// It is selected for every repository in a batch whose MigrationAPI is ELM.
+// ELM differs from GEI-based migrations in several ways:
+// - No archive generation (ELM syncs directly from the source)
+// - The destination is kept in sync until an explicit cutover is requested
+// - Source locking is handled by ELM during cutover, so no unlock is needed afterwards
+type ELMMigrationStrategy struct {
+	executor *Executor
+	batch    *models.Batch
+
+	// Polling intervals (overridable in tests)
+	pollInitialInterval time.Duration
+	pollMaxInterval     time.Duration
+}
+
+// NewELMMigrationStrategy creates a new ELM migration strategy for the given batch.
+// The batch may be nil, in which case the strategy never matches.
+func NewELMMigrationStrategy(executor *Executor, batch *models.Batch) *ELMMigrationStrategy {
+	return &ELMMigrationStrategy{
+		executor:            executor,
+		batch:               batch,
+		pollInitialInterval: migrationInitialInterval,
+		pollMaxInterval:     migrationMaxInterval,
+	}
+}
+
+// Name returns the strategy name.
+func (s *ELMMigrationStrategy) Name() string {
+	return "ELM"
+}
+
+// SupportsRepository returns true if the repository is being migrated in an ELM batch.
+func (s *ELMMigrationStrategy) SupportsRepository(repo *models.Repository) bool {
+	return s.batch != nil && s.batch.MigrationAPI == models.MigrationAPIELM
+}
+
+// ValidateSource validates that ELM is configured and can migrate the repository.
+func (s *ELMMigrationStrategy) ValidateSource(ctx context.Context, repo *models.Repository) error {
+	if s.executor.elmClient == nil {
+		return fmt.Errorf("ELM client is not configured (set migration.elm.base_url)")
+	}
+	if adoProject := repo.GetADOProject(); adoProject != nil && *adoProject != "" {
+		return fmt.Errorf("ELM does not support Azure DevOps source repositories")
+	}
+	if repo.SourceURL == "" {
+		return fmt.Errorf("repository %s has no source URL", repo.FullName)
+	}
+	return nil
+}
+
+// PrepareArchives is a no-op for ELM migrations since ELM syncs directly from the source.
+func (s *ELMMigrationStrategy) PrepareArchives(ctx context.Context, mc *MigrationContext) error {
+	e := s.executor
+
+	e.logger.Info("Skipping archive generation for ELM migration (ELM syncs directly from source)",
+		"repo", mc.Repo.FullName)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "archive_generation", "skip",
+		"ELM migrations do not require archive generation - ELM syncs directly from source", nil)
+
+	return nil
+}
+
+// StartMigration starts a live migration on ELM.
+func (s *ELMMigrationStrategy) StartMigration(ctx context.Context, mc *MigrationContext) (string, error) {
+	e := s.executor
+
+	destOrg := e.getDestinationOrg(mc.Repo, mc.Batch)
+	destRepoName := e.getDestinationRepoName(mc.Repo)
+
+	e.logger.Info("Starting ELM migration",
+		"repo", mc.Repo.FullName,
+		"destination", fmt.Sprintf("%s/%s", destOrg, destRepoName))
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "initiate",
+		"Starting live migration with Enterprise Live Migrator", nil)
+
+	migration, err := e.elmClient.StartMigration(ctx, ELMStartRequest{
+		SourceRepositoryURL: mc.Repo.SourceURL,
+		SourceToken:         e.sourceToken,
+		TargetOrganization:  destOrg,
+		TargetRepository:    destRepoName,
+		TargetVisibility:    e.determineTargetVisibility(mc.Repo.Visibility),
+		ExcludeReleases:     mc.ExcludeReleases,
+		ExcludeAttachments:  mc.ExcludeAttachments,
+	})
+	if err != nil {
+		errMsg := err.Error()
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "ERROR", "migration", "initiate", "Failed to start migration", &errMsg)
+		return "", fmt.Errorf("failed to start migration: %w", err)
+	}
+
+	e.logger.Info("ELM migration started",
+		"repo", mc.Repo.FullName,
+		"migration_id", migration.ID,
+		"state", migration.State)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "initiated",
+		fmt.Sprintf("ELM migration started with ID: %s", migration.ID), nil)
+
+	mc.Repo.Status = string(MapELMStateToStatus(migration.State))
+	if err := e.storage.UpdateRepository(ctx, mc.Repo); err != nil {
+		e.logger.Error("Failed to update repository status", "error", err)
+	}
+
+	return migration.ID, nil
+}
+
+// PollMigration polls ELM until the migration completes.
+// Production migrations request a cutover once ELM reports ready_for_cutover.
+// Dry runs stop at ready_for_cutover and cancel the ELM migration so the source
+// is never locked.
+func (s *ELMMigrationStrategy) PollMigration(ctx context.Context, mc *MigrationContext) error {
+	e := s.executor
+
+	e.logger.Info("Polling ELM migration status", "repo", mc.Repo.FullName, "migration_id", mc.MigrationID)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration_progress", "poll", "Polling for ELM migration progress", nil)
+
+	startTime := time.Now()
+	timeoutDeadline := startTime.Add(migrationTimeout)
+	lastState := ""
+	cutoverRequested := false
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if time.Now().After(timeoutDeadline) {
+			return s.failPolling(ctx, mc, fmt.Errorf("migration timeout exceeded (48 hours)"))
+		}
+
+		migration, err := e.elmClient.GetMigration(ctx, mc.MigrationID)
+		if err != nil {
+			return s.failPolling(ctx, mc, err)
+		}
+
+		if migration.State != lastState {
+			e.logger.Info("ELM migration state changed",
+				"repo", mc.Repo.FullName,
+				"migration_id", mc.MigrationID,
+				"state", migration.State)
+			e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration_progress", "poll",
+				fmt.Sprintf("ELM migration state: %s", migration.State), nil)
+			lastState = migration.State
+
+			mc.Repo.Status = string(MapELMStateToStatus(migration.State))
+			if err := e.storage.UpdateRepository(ctx, mc.Repo); err != nil {
+				e.logger.Error("Failed to update repository status", "error", err)
+			}
+		}
+
+		switch migration.State {
+		case ELMStateCompleted:
+			s.setDestination(mc, migration)
+			if err := e.storage.UpdateRepository(ctx, mc.Repo); err != nil {
+				e.logger.Error("Failed to update repository status", "error", err)
+			}
+			e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration_progress", "complete", "Migration completed successfully", nil)
+			return nil
+
+		case ELMStateFailed, ELMStateCancelled:
+			reason := migration.FailureReason
+			if reason == "" {
+				reason = migration.State
+			}
+			return s.failPolling(ctx, mc, fmt.Errorf("ELM migration %s: %s", migration.State, reason))
+
+		case ELMStateReadyForCutover:
+			if mc.DryRun {
+				return s.finishDryRun(ctx, mc, migration)
+			}
+			if !cutoverRequested {
+				if err := s.cutover(ctx, mc); err != nil {
+					return s.failPolling(ctx, mc, err)
+				}
+				cutoverRequested = true
+			}
+		}
+
+		elapsed := time.Since(startTime)
+		timer.Reset(calculateAdaptivePollInterval(elapsed, s.pollInitialInterval, s.pollMaxInterval, migrationFastPhaseDuration))
+	}
+}
+
+// cutover requests the final ELM cutover, which locks the source and applies the last delta.
+func (s *ELMMigrationStrategy) cutover(ctx context.Context, mc *MigrationContext) error {
+	e := s.executor
+
+	e.logger.Info("Requesting ELM cutover", "repo", mc.Repo.FullName, "migration_id", mc.MigrationID)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "cutover", "initiate",
+		"Destination is in sync - requesting cutover", nil)
+
+	if _, err := e.elmClient.Cutover(ctx, mc.MigrationID); err != nil {
+		errMsg := err.Error()
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "ERROR", "cutover", "initiate", "Failed to request cutover", &errMsg)
+		return err
+	}
+
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "cutover", "initiated", "Cutover requested", nil)
+	return nil
+}
+
+// finishDryRun completes a dry run once ELM has synced the destination, cancelling the
+// live migration so that no cutover (and no source lock) ever happens.
+func (s *ELMMigrationStrategy) finishDryRun(ctx context.Context, mc *MigrationContext, migration *ELMMigration) error {
+	e := s.executor
+
+	s.setDestination(mc, migration)
+
+	if err := e.elmClient.Cancel(ctx, mc.MigrationID); err != nil {
+		// The sync itself succeeded, so only warn - the ELM migration can be cancelled manually
+		errMsg := err.Error()
+		e.logger.Warn("Failed to cancel ELM dry run migration", "repo", mc.Repo.FullName, "error", err)
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "WARN", "cutover", "skip", "Failed to cancel ELM dry run migration", &errMsg)
+	} else {
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "cutover", "skip",
+			"Dry run synced successfully - cutover skipped and ELM migration cancelled", nil)
+	}
+
+	if err := e.storage.UpdateRepository(ctx, mc.Repo); err != nil {
+		e.logger.Error("Failed to update repository status", "error", err)
+	}
+	return nil
+}
+
+// setDestination records the destination repository details on the repository.
+func (s *ELMMigrationStrategy) setDestination(mc *MigrationContext, migration *ELMMigration) {
+	e := s.executor
+
+	destFullName := fmt.Sprintf("%s/%s", e.getDestinationOrg(mc.Repo, mc.Batch), e.getDestinationRepoName(mc.Repo))
+	mc.Repo.DestinationFullName = &destFullName
+
+	destURL := migration.TargetRepositoryURL
+	if destURL == "" {
+		destURL = e.destClient.RepositoryURL(destFullName)
+	}
+	mc.Repo.DestinationURL = &destURL
+}
+
+// failPolling logs a polling failure and wraps it like the common polling phase does.
+func (s *ELMMigrationStrategy) failPolling(ctx context.Context, mc *MigrationContext, err error) error {
+	errMsg := err.Error()
+	s.executor.logOperation(ctx, mc.Repo, mc.HistoryID, "ERROR", "migration_progress", "poll", "Migration failed", &errMsg)
+	return fmt.Errorf("migration failed: %w", err)
+}
+
+// ShouldUnlockSource returns false since ELM manages the source lock during cutover.
+func (s *ELMMigrationStrategy) ShouldUnlockSource() bool {
+	return false
+}
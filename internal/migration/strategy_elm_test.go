@@ -0,0 +1,327 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+// fakeELMServer is an in-memory stand-in for the ELM REST API.
+// Each GET advances the migration through the given state sequence; a cutover
+// request moves it to cutting_over and then completed.
+type fakeELMServer struct {
+	mu            sync.Mutex
+	states        []string
+	pollCount     int
+	cutoverCalls  int
+	cancelCalls   int
+	failureReason string
+	lastStart     ELMStartRequest
+	authHeader    string
+}
+
+func (f *fakeELMServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /migrations", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.authHeader = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&f.lastStart); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ELMMigration{ID: "elm-123", State: ELMStateQueued})
+	})
+	mux.HandleFunc("GET /migrations/{id}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		state := f.states[min(f.pollCount, len(f.states)-1)]
+		f.pollCount++
+		_ = json.NewEncoder(w).Encode(ELMMigration{
+			ID:                  r.PathValue("id"),
+			State:               state,
+			FailureReason:       f.failureReason,
+			TargetRepositoryURL: "https://github.com/dest-org/repo",
+		})
+	})
+	mux.HandleFunc("POST /migrations/{id}/cutover", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.cutoverCalls++
+		f.states = append(f.states[:f.pollCount], ELMStateCuttingOver, ELMStateCompleted)
+		_ = json.NewEncoder(w).Encode(ELMMigration{ID: r.PathValue("id"), State: ELMStateCuttingOver})
+	})
+	mux.HandleFunc("POST /migrations/{id}/cancel", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.cancelCalls++
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func setupELMTest(t *testing.T, fake *fakeELMServer) (*Executor, *storage.Database, *models.Repository) {
+	t.Helper()
+
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{
+		Type: "sqlite",
+		DSN:  ":memory:",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	elmClient, err := NewELMClient(ELMClientConfig{BaseURL: server.URL + "/", Token: "elm-token"})
+	if err != nil {
+		t.Fatalf("Failed to create ELM client: %v", err)
+	}
+
+	executor, err := NewExecutor(ExecutorConfig{
+		SourceToken: "source-token",
+		DestClient:  &github.Client{},
+		Storage:     db,
+		Logger:      slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+		ELMClient:   elmClient,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create executor: %v", err)
+	}
+
+	repo := createTestRepository("source-org/repo")
+	if err := db.SaveRepository(context.Background(), repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	saved, err := db.GetRepository(context.Background(), repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to load repository: %v", err)
+	}
+
+	return executor, db, saved
+}
+
+func newTestELMStrategy(executor *Executor) *ELMMigrationStrategy {
+	destOrg := "dest-org"
+	s := NewELMMigrationStrategy(executor, &models.Batch{
+		MigrationAPI:   models.MigrationAPIELM,
+		DestinationOrg: &destOrg,
+	})
+	s.pollInitialInterval = time.Millisecond
+	s.pollMaxInterval = time.Millisecond
+	return s
+}
+
+func TestStrategyRegistry_SelectsELMForELMBatches(t *testing.T) {
+	adoRepo := &models.Repository{FullName: "project/repo"}
+	adoRepo.SetADOProject(strPtr("MyProject"))
+
+	tests := []struct {
+		name         string
+		batch        *models.Batch
+		repo         *models.Repository
+		wantStrategy string
+	}{
+		{
+			name:         "ELM batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIELM},
+			repo:         &models.Repository{FullName: "org/repo"},
+			wantStrategy: "ELM",
+		},
+		{
+			name:         "GEI batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIGEI},
+			repo:         &models.Repository{FullName: "org/repo"},
+			wantStrategy: "GitHub",
+		},
+		{
+			name:         "no batch",
+			batch:        nil,
+			repo:         &models.Repository{FullName: "org/repo"},
+			wantStrategy: "GitHub",
+		},
+		{
+			name:         "ADO repository in ELM batch is still routed to ELM",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIELM},
+			repo:         adoRepo,
+			wantStrategy: "ELM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewStrategyRegistry(
+				NewELMMigrationStrategy(nil, tt.batch),
+				NewGitHubMigrationStrategy(nil),
+				NewADOMigrationStrategy(nil),
+			)
+
+			strategy := registry.GetStrategy(tt.repo)
+			if strategy == nil {
+				t.Fatal("GetStrategy() returned nil, want strategy")
+			}
+			if strategy.Name() != tt.wantStrategy {
+				t.Errorf("GetStrategy() = %s, want %s", strategy.Name(), tt.wantStrategy)
+			}
+		})
+	}
+}
+
+func TestMapELMStateToStatus(t *testing.T) {
+	tests := []struct {
+		state string
+		want  models.MigrationStatus
+	}{
+		{ELMStateQueued, models.StatusQueuedForMigration},
+		{ELMStateSyncing, models.StatusMigratingContent},
+		{ELMStateReadyForCutover, models.StatusMigratingContent},
+		{ELMStateCuttingOver, models.StatusMigratingContent},
+		{ELMStateCompleted, models.StatusMigrationComplete},
+		{ELMStateFailed, models.StatusMigrationFailed},
+		{ELMStateCancelled, models.StatusMigrationFailed},
+		{"something_new", models.StatusMigratingContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.state, func(t *testing.T) {
+			if got := MapELMStateToStatus(tt.state); got != tt.want {
+				t.Errorf("MapELMStateToStatus(%q) = %s, want %s", tt.state, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestELMMigrationStrategy_ValidateSource(t *testing.T) {
+	executor, _, repo := setupELMTest(t, &fakeELMServer{states: []string{ELMStateQueued}})
+	strategy := newTestELMStrategy(executor)
+
+	if err := strategy.ValidateSource(context.Background(), repo); err != nil {
+		t.Errorf("ValidateSource() unexpected error: %v", err)
+	}
+
+	adoRepo := createTestRepository("project/repo")
+	adoRepo.SetADOProject(strPtr("MyProject"))
+	if err := strategy.ValidateSource(context.Background(), adoRepo); err == nil {
+		t.Error("ValidateSource() expected error for ADO repository")
+	}
+
+	executor.elmClient = nil
+	err := strategy.ValidateSource(context.Background(), repo)
+	if err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Errorf("ValidateSource() error = %v, want 'not configured' error", err)
+	}
+}
+
+func TestELMMigrationStrategy_Lifecycle(t *testing.T) {
+	fake := &fakeELMServer{states: []string{ELMStateSyncing, ELMStateReadyForCutover}}
+	executor, db, repo := setupELMTest(t, fake)
+	strategy := newTestELMStrategy(executor)
+	ctx := context.Background()
+
+	mc := executor.NewMigrationContext(repo, strategy.batch, false)
+
+	migrationID, err := strategy.StartMigration(ctx, mc)
+	if err != nil {
+		t.Fatalf("StartMigration() error: %v", err)
+	}
+	if migrationID != "elm-123" {
+		t.Errorf("StartMigration() = %q, want elm-123", migrationID)
+	}
+	if fake.lastStart.TargetOrganization != "dest-org" || fake.lastStart.TargetRepository != "repo" {
+		t.Errorf("unexpected start target %s/%s", fake.lastStart.TargetOrganization, fake.lastStart.TargetRepository)
+	}
+	if fake.lastStart.SourceToken != "source-token" {
+		t.Errorf("expected source token to be forwarded, got %q", fake.lastStart.SourceToken)
+	}
+	if fake.authHeader != "Bearer elm-token" {
+		t.Errorf("expected bearer auth header, got %q", fake.authHeader)
+	}
+	if mc.Repo.Status != string(models.StatusQueuedForMigration) {
+		t.Errorf("status after start = %s, want %s", mc.Repo.Status, models.StatusQueuedForMigration)
+	}
+
+	mc.MigrationID = migrationID
+	if err := strategy.PollMigration(ctx, mc); err != nil {
+		t.Fatalf("PollMigration() error: %v", err)
+	}
+
+	if fake.cutoverCalls != 1 {
+		t.Errorf("cutover calls = %d, want 1", fake.cutoverCalls)
+	}
+	if fake.cancelCalls != 0 {
+		t.Errorf("cancel calls = %d, want 0", fake.cancelCalls)
+	}
+
+	updated, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("Failed to reload repository: %v", err)
+	}
+	if updated.Status != string(models.StatusMigrationComplete) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusMigrationComplete)
+	}
+	if updated.DestinationFullName == nil || *updated.DestinationFullName != "dest-org/repo" {
+		t.Errorf("DestinationFullName = %v, want dest-org/repo", updated.DestinationFullName)
+	}
+	if updated.DestinationURL == nil || *updated.DestinationURL != "https://github.com/dest-org/repo" {
+		t.Errorf("DestinationURL = %v, want https://github.com/dest-org/repo", updated.DestinationURL)
+	}
+}
+
+func TestELMMigrationStrategy_DryRunSkipsCutover(t *testing.T) {
+	fake := &fakeELMServer{states: []string{ELMStateSyncing, ELMStateReadyForCutover}}
+	executor, _, repo := setupELMTest(t, fake)
+	strategy := newTestELMStrategy(executor)
+
+	mc := executor.NewMigrationContext(repo, strategy.batch, true)
+	mc.MigrationID = "elm-123"
+
+	if err := strategy.PollMigration(context.Background(), mc); err != nil {
+		t.Fatalf("PollMigration() error: %v", err)
+	}
+	if fake.cutoverCalls != 0 {
+		t.Errorf("cutover calls = %d, want 0 for dry run", fake.cutoverCalls)
+	}
+	if fake.cancelCalls != 1 {
+		t.Errorf("cancel calls = %d, want 1 for dry run", fake.cancelCalls)
+	}
+}
+
+func TestELMMigrationStrategy_FailedMigration(t *testing.T) {
+	fake := &fakeELMServer{
+		states:        []string{ELMStateSyncing, ELMStateFailed},
+		failureReason: "source repository unreachable",
+	}
+	executor, _, repo := setupELMTest(t, fake)
+	strategy := newTestELMStrategy(executor)
+
+	mc := executor.NewMigrationContext(repo, strategy.batch, false)
+	mc.MigrationID = "elm-123"
+
+	err := strategy.PollMigration(context.Background(), mc)
+	if err == nil {
+		t.Fatal("PollMigration() expected error for failed migration")
+	}
+	if !strings.Contains(err.Error(), "source repository unreachable") {
+		t.Errorf("error = %v, want failure reason", err)
+	}
+	if mc.Repo.Status != string(models.StatusMigrationFailed) {
+		t.Errorf("status = %s, want %s", mc.Repo.Status, models.StatusMigrationFailed)
+	}
+}
@@ -0,0 +1,208 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// GitMirrorMigrationStrategy implements MigrationStrategy for git-only migrations.
+// It is selected for every repository in a batch whose MigrationAPI is GIT, and for
+// Bitbucket Server repositories, which GEI cannot import through this tool.
+// The repository is cloned with --mirror from the source provider and pushed to a new
+// destination repository:
+//   - Only git history moves (branches and tags, optionally filtered, plus LFS objects)
+//   - GEI size, commit and file limits do not apply, so repositories flagged for
+//     remediation can still be migrated
+//   - Pull requests, issues, releases and settings are not migrated
+type GitMirrorMigrationStrategy struct {
+	executor *Executor
+	batch    *models.Batch
+}
+
+// NewGitMirrorMigrationStrategy creates a new git mirror migration strategy for the given batch.
+// The batch may be nil, in which case only Bitbucket repositories match.
+func NewGitMirrorMigrationStrategy(executor *Executor, batch *models.Batch) *GitMirrorMigrationStrategy {
+	return &GitMirrorMigrationStrategy{
+		executor: executor,
+		batch:    batch,
+	}
+}
+
+// Name returns the strategy name.
+func (s *GitMirrorMigrationStrategy) Name() string {
+	return "GitMirror"
+}
+
+// SupportsRepository returns true if the repository is being migrated in a GIT batch
+// or comes from Bitbucket Server.
+func (s *GitMirrorMigrationStrategy) SupportsRepository(repo *models.Repository) bool {
+	return usesGitMirror(repo, s.batch)
+}
+
+// ValidateSource validates that the source can be cloned and the ref filters are usable.
+func (s *GitMirrorMigrationStrategy) ValidateSource(ctx context.Context, repo *models.Repository) error {
+	e := s.executor
+
+	if e.sourceProvider == nil {
+		return fmt.Errorf("git source provider is not configured for this source")
+	}
+	if repo.SourceURL == "" {
+		return fmt.Errorf("repository %s has no source URL", repo.FullName)
+	}
+	if _, err := refSpecsFromFilters(e.gitMirror.RefFilters); err != nil {
+		return err
+	}
+	if err := e.sourceProvider.ValidateCredentials(ctx); err != nil {
+		return fmt.Errorf("source credentials are invalid: %w", err)
+	}
+	return nil
+}
+
+// PrepareArchives is a no-op for git mirror migrations since git data is pushed directly.
+func (s *GitMirrorMigrationStrategy) PrepareArchives(ctx context.Context, mc *MigrationContext) error {
+	e := s.executor
+
+	e.logger.Info("Skipping archive generation for git mirror migration (git data is mirror-pushed)",
+		"repo", mc.Repo.FullName)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "archive_generation", "skip",
+		"Git mirror migrations do not require archive generation - git data is mirror-pushed to the destination", nil)
+
+	return nil
+}
+
+// StartMigration clones the source repository and pushes it to a new destination repository.
+// The push is synchronous, so the returned migration ID is the destination repository's full name.
+func (s *GitMirrorMigrationStrategy) StartMigration(ctx context.Context, mc *MigrationContext) (string, error) {
+	e := s.executor
+
+	destOrg := e.getDestinationOrg(mc.Repo, mc.Batch)
+	destRepoName := e.getDestinationRepoName(mc.Repo)
+	destFullName := fmt.Sprintf("%s/%s", destOrg, destRepoName)
+
+	refSpecs, err := refSpecsFromFilters(e.gitMirror.RefFilters)
+	if err != nil {
+		return "", s.failStart(ctx, mc, "initiate", "Invalid ref filters", err)
+	}
+	includeLFS := e.gitMirror.IncludeLFS && mc.Repo.HasLFS()
+
+	e.logger.Info("Starting git mirror migration",
+		"repo", mc.Repo.FullName,
+		"destination", destFullName,
+		"include_lfs", includeLFS,
+		"ref_specs", refSpecs)
+	details := fmt.Sprintf("include_lfs=%v, refs=%s", includeLFS, strings.Join(refSpecs, " "))
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "initiate",
+		fmt.Sprintf("Mirror-pushing git history to %s", destFullName), &details)
+
+	if blockers := geiLimitsExceeded(mc.Repo); len(blockers) > 0 {
+		reasons := strings.Join(blockers, ", ")
+		e.logger.Warn("Repository exceeds GEI limits, migrating git history only",
+			"repo", mc.Repo.FullName,
+			"reasons", reasons)
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "WARN", "migration", "initiate",
+			"Repository exceeds GEI limits - migrating git history only", &reasons)
+	}
+
+	mc.Repo.Status = string(models.StatusMigratingContent)
+	if err := e.storage.UpdateRepository(ctx, mc.Repo); err != nil {
+		e.logger.Error("Failed to update repository status", "error", err)
+	}
+
+	tempDir, err := e.cloneMirror(ctx, mc.Repo, includeLFS)
+	if err != nil {
+		return "", s.failStart(ctx, mc, "clone", "Failed to clone source repository", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			e.logger.Warn("Failed to clean up temp directory", "path", tempDir, "error", err)
+		}
+	}()
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "clone", "Source repository cloned", nil)
+
+	input := github.CreateRepositoryInput{
+		Name:       destRepoName,
+		Visibility: e.determineTargetVisibility(mc.Repo.Visibility),
+		HasIssues:  true,
+	}
+	if _, err := e.destClient.CreateRepository(ctx, destOrg, input); err != nil {
+		return "", s.failStart(ctx, mc, "create", "Failed to create destination repository", err)
+	}
+
+	pushURL, err := e.destinationPushURL(destFullName)
+	if err != nil {
+		return "", s.failStart(ctx, mc, "push", "Failed to build destination URL", err)
+	}
+	pushOpts := mirrorPushOptions{
+		RefSpecs:   refSpecs,
+		IncludeLFS: includeLFS,
+		Token:      e.destClient.Token(),
+	}
+	if err := pushMirror(ctx, filepath.Join(tempDir, "repo.git"), pushURL, pushOpts); err != nil {
+		return "", s.failStart(ctx, mc, "push", "Failed to push git data to destination", err)
+	}
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "push",
+		"Git history pushed to destination", nil)
+
+	mc.Repo.DestinationFullName = &destFullName
+	destURL := e.destClient.RepositoryURL(destFullName)
+	mc.Repo.DestinationURL = &destURL
+
+	e.logger.Info("Git mirror migration finished", "repo", mc.Repo.FullName, "destination", destFullName)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "initiated",
+		fmt.Sprintf("Git history migrated to %s", destFullName), nil)
+
+	return destFullName, nil
+}
+
+// PollMigration is a no-op since the mirror push completes within StartMigration.
+func (s *GitMirrorMigrationStrategy) PollMigration(ctx context.Context, mc *MigrationContext) error {
+	return nil
+}
+
+// ShouldUnlockSource returns false since the source is never locked for a mirror push.
+func (s *GitMirrorMigrationStrategy) ShouldUnlockSource() bool {
+	return false
+}
+
+// failStart logs a StartMigration failure and wraps the error.
+func (s *GitMirrorMigrationStrategy) failStart(ctx context.Context, mc *MigrationContext, action, message string, err error) error {
+	errMsg := err.Error()
+	s.executor.logOperation(ctx, mc.Repo, mc.HistoryID, "ERROR", "migration", action, message, &errMsg)
+	return fmt.Errorf("failed to start migration: %s: %w", strings.ToLower(message), err)
+}
+
+// usesGitMirror reports whether the repository is migrated by mirror-pushing git data
+// rather than through GEI.
+func usesGitMirror(repo *models.Repository, batch *models.Batch) bool {
+	return batch.UsesGitMirror() || repo.Source == models.SourceBitbucket
+}
+
+// mirrorPushBlocked reports whether GitHub would reject the mirror push for files over its 100 MB
+// limit. Blocking files are allowed when LFS objects are transferred with the push.
+func mirrorPushBlocked(repo *models.Repository, opts GitMirrorOptions) bool {
+	return repo.HasBlockingFiles() && !(opts.IncludeLFS && repo.HasLFS())
+}
+
+// geiLimitsExceeded returns the GEI limits the repository was flagged for during discovery.
+func geiLimitsExceeded(repo *models.Repository) []string {
+	var reasons []string
+	if repo.HasOversizedRepository() {
+		reasons = append(reasons, "repository larger than 40 GiB")
+	}
+	if repo.HasOversizedCommits() {
+		reasons = append(reasons, "oversized commits")
+	}
+	if repo.HasLongRefs() {
+		reasons = append(reasons, "long ref names")
+	}
+	if repo.HasBlockingFiles() {
+		reasons = append(reasons, "blocking files")
+	}
+	return reasons
+}
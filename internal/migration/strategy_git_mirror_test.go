@@ -0,0 +1,215 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+)
+
+func TestStrategyRegistry_SelectsGitMirror(t *testing.T) {
+	tests := []struct {
+		name         string
+		batch        *models.Batch
+		repo         *models.Repository
+		wantStrategy string
+	}{
+		{
+			name:         "GitHub repository in GIT batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIGit},
+			repo:         &models.Repository{FullName: "org/repo", Source: models.SourceGHES},
+			wantStrategy: "GitMirror",
+		},
+		{
+			name:         "GitLab repository in GIT batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIGit},
+			repo:         &models.Repository{FullName: "group/project", Source: models.SourceGitLab},
+			wantStrategy: "GitMirror",
+		},
+		{
+			name:         "Bitbucket repository without batch",
+			repo:         &models.Repository{FullName: "PROJ/api", Source: models.SourceBitbucket},
+			wantStrategy: "GitMirror",
+		},
+		{
+			name:         "Bitbucket repository in GEI batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIGEI},
+			repo:         &models.Repository{FullName: "PROJ/api", Source: models.SourceBitbucket},
+			wantStrategy: "GitMirror",
+		},
+		{
+			name:         "GitHub repository in GEI batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIGEI},
+			repo:         &models.Repository{FullName: "org/repo", Source: models.SourceGHES},
+			wantStrategy: "GitHub",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewStrategyRegistry(
+				NewELMMigrationStrategy(nil, tt.batch),
+				NewGitMirrorMigrationStrategy(nil, tt.batch),
+				NewGitLabMigrationStrategy(nil),
+				NewGitHubMigrationStrategy(nil),
+				NewADOMigrationStrategy(nil),
+			)
+
+			strategy := registry.GetStrategy(tt.repo)
+			if strategy == nil {
+				t.Fatal("GetStrategy() returned nil, want strategy")
+			}
+			if strategy.Name() != tt.wantStrategy {
+				t.Errorf("GetStrategy() = %s, want %s", strategy.Name(), tt.wantStrategy)
+			}
+		})
+	}
+}
+
+func TestGitMirrorMigrationStrategy_ValidateSource(t *testing.T) {
+	repo := &models.Repository{
+		FullName:  "PROJ/api",
+		Source:    models.SourceBitbucket,
+		SourceURL: "https://bitbucket.example.com/scm/proj/api.git",
+	}
+
+	tests := []struct {
+		name       string
+		provider   source.Provider
+		refFilters []string
+		repo       *models.Repository
+		wantErr    string
+	}{
+		{
+			name:     "valid",
+			provider: &fakeProvider{},
+			repo:     repo,
+		},
+		{
+			name:       "valid with ref filters",
+			provider:   &fakeProvider{},
+			refFilters: []string{"refs/heads/main", "refs/tags/*"},
+			repo:       repo,
+		},
+		{
+			name:    "no provider",
+			repo:    repo,
+			wantErr: "source provider is not configured",
+		},
+		{
+			name:     "no source URL",
+			provider: &fakeProvider{},
+			repo:     &models.Repository{FullName: "PROJ/api", Source: models.SourceBitbucket},
+			wantErr:  "has no source URL",
+		},
+		{
+			name:       "invalid ref filter",
+			provider:   &fakeProvider{},
+			refFilters: []string{"main"},
+			repo:       repo,
+			wantErr:    "must start with refs/",
+		},
+		{
+			name:     "invalid credentials",
+			provider: &fakeProvider{credentialsErr: errors.New("401")},
+			repo:     repo,
+			wantErr:  "credentials are invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &Executor{
+				sourceProvider: tt.provider,
+				gitMirror:      GitMirrorOptions{RefFilters: tt.refFilters},
+				logger:         slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+			}
+			err := NewGitMirrorMigrationStrategy(executor, nil).ValidateSource(context.Background(), tt.repo)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSource() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSource() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGitMirrorMigrationStrategy_ShouldUnlockSource(t *testing.T) {
+	if NewGitMirrorMigrationStrategy(nil, nil).ShouldUnlockSource() {
+		t.Error("Git mirror strategy should not unlock the source")
+	}
+}
+
+func TestGEILimitsExceeded(t *testing.T) {
+	repo := &models.Repository{FullName: "org/repo"}
+	if reasons := geiLimitsExceeded(repo); len(reasons) != 0 {
+		t.Errorf("geiLimitsExceeded() = %v, want none", reasons)
+	}
+
+	repo.SetHasOversizedRepository(true)
+	repo.SetHasBlockingFiles(true)
+	reasons := geiLimitsExceeded(repo)
+	if strings.Join(reasons, ", ") != "repository larger than 40 GiB, blocking files" {
+		t.Errorf("geiLimitsExceeded() = %v", reasons)
+	}
+}
+
+func TestMirrorPushBlocked(t *testing.T) {
+	repo := &models.Repository{FullName: "org/repo"}
+	if mirrorPushBlocked(repo, GitMirrorOptions{}) {
+		t.Error("Repository without blocking files should not be blocked")
+	}
+
+	repo.SetHasBlockingFiles(true)
+	if !mirrorPushBlocked(repo, GitMirrorOptions{IncludeLFS: true}) {
+		t.Error("Repository with blocking files and no LFS should be blocked")
+	}
+	repo.SetHasLFS(true)
+	if !mirrorPushBlocked(repo, GitMirrorOptions{IncludeLFS: false}) {
+		t.Error("Repository with blocking files should be blocked when LFS transfer is disabled")
+	}
+	if mirrorPushBlocked(repo, GitMirrorOptions{IncludeLFS: true}) {
+		t.Error("Repository with blocking files should not be blocked when LFS objects are transferred")
+	}
+}
+
+func TestValidatePreMigration_GitMirrorBlockingFiles(t *testing.T) {
+	executor := &Executor{
+		logger: slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+	}
+	repo := &models.Repository{FullName: "org/repo", Source: models.SourceGHES}
+	repo.SetHasBlockingFiles(true)
+
+	err := executor.validatePreMigration(context.Background(), repo, &models.Batch{MigrationAPI: models.MigrationAPIGit})
+	if err == nil || !strings.Contains(err.Error(), "100 MB push limit") {
+		t.Errorf("validatePreMigration() error = %v, want a failure for blocking files", err)
+	}
+}
+
+func TestUsesGitMirror(t *testing.T) {
+	ghRepo := &models.Repository{FullName: "org/repo", Source: models.SourceGHES}
+	bbRepo := &models.Repository{FullName: "PROJ/api", Source: models.SourceBitbucket}
+
+	if usesGitMirror(ghRepo, nil) {
+		t.Error("GitHub repository without batch should not use git mirror")
+	}
+	if usesGitMirror(ghRepo, &models.Batch{MigrationAPI: models.MigrationAPIGEI}) {
+		t.Error("GitHub repository in GEI batch should not use git mirror")
+	}
+	if !usesGitMirror(ghRepo, &models.Batch{MigrationAPI: models.MigrationAPIGit}) {
+		t.Error("GitHub repository in GIT batch should use git mirror")
+	}
+	if !usesGitMirror(bbRepo, nil) {
+		t.Error("Bitbucket repository should use git mirror")
+	}
+}
@@ -0,0 +1,321 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	ghapi "github.com/google/go-github/v75/github"
+
+	"github.com/kuhlman-labs/github-migrator/internal/discovery"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// GitLabMigrationStrategy implements MigrationStrategy for GitLab source repositories.
+// GEI cannot import from GitLab, so this strategy moves the repository itself:
+// - The project is cloned with --mirror from GitLab
+// - A new destination repository is created and every branch and tag is pushed to it
+// - Core settings (default branch, merge settings, protected branches) are recreated
+// Merge requests, issues, wikis and CI pipelines are not migrated.
+type GitLabMigrationStrategy struct {
+	executor *Executor
+}
+
+// NewGitLabMigrationStrategy creates a new GitLab migration strategy.
+func NewGitLabMigrationStrategy(executor *Executor) *GitLabMigrationStrategy {
+	return &GitLabMigrationStrategy{executor: executor}
+}
+
+// Name returns the strategy name.
+func (s *GitLabMigrationStrategy) Name() string {
+	return "GitLab"
+}
+
+// SupportsRepository returns true if this is a GitLab source repository.
+func (s *GitLabMigrationStrategy) SupportsRepository(repo *models.Repository) bool {
+	return repo.Source == models.SourceGitLab
+}
+
+// ValidateSource validates that the GitLab source can be cloned.
+func (s *GitLabMigrationStrategy) ValidateSource(ctx context.Context, repo *models.Repository) error {
+	e := s.executor
+
+	if e.sourceProvider == nil {
+		return fmt.Errorf("GitLab source provider is not configured")
+	}
+	if repo.SourceURL == "" {
+		return fmt.Errorf("repository %s has no source URL", repo.FullName)
+	}
+	if err := e.sourceProvider.ValidateCredentials(ctx); err != nil {
+		return fmt.Errorf("GitLab credentials are invalid: %w", err)
+	}
+	return nil
+}
+
+// PrepareArchives is a no-op for GitLab migrations since git data is pushed directly.
+func (s *GitLabMigrationStrategy) PrepareArchives(ctx context.Context, mc *MigrationContext) error {
+	e := s.executor
+
+	e.logger.Info("Skipping archive generation for GitLab migration (git data is mirror-pushed)",
+		"repo", mc.Repo.FullName)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "archive_generation", "skip",
+		"GitLab migrations do not require archive generation - git data is mirror-pushed to the destination", nil)
+
+	return nil
+}
+
+// StartMigration clones the GitLab project, pushes it to a new destination repository
+// and recreates the core project settings. The push is synchronous, so the returned
+// migration ID is the destination repository's full name.
+func (s *GitLabMigrationStrategy) StartMigration(ctx context.Context, mc *MigrationContext) (string, error) {
+	e := s.executor
+
+	destOrg := e.getDestinationOrg(mc.Repo, mc.Batch)
+	destRepoName := e.getDestinationRepoName(mc.Repo)
+	destFullName := fmt.Sprintf("%s/%s", destOrg, destRepoName)
+
+	e.logger.Info("Starting GitLab mirror migration",
+		"repo", mc.Repo.FullName,
+		"destination", destFullName)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "initiate",
+		fmt.Sprintf("Mirror-pushing GitLab project to %s", destFullName), nil)
+
+	mc.Repo.Status = string(models.StatusMigratingContent)
+	if err := e.storage.UpdateRepository(ctx, mc.Repo); err != nil {
+		e.logger.Error("Failed to update repository status", "error", err)
+	}
+
+	// Fetch the current project so settings reflect the source at migration time
+	project := s.fetchProject(ctx, mc)
+
+	tempDir, err := e.cloneMirror(ctx, mc.Repo, mc.Repo.HasLFS())
+	if err != nil {
+		return "", s.failStart(ctx, mc, "clone", "Failed to clone GitLab project", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			e.logger.Warn("Failed to clean up temp directory", "path", tempDir, "error", err)
+		}
+	}()
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "clone", "GitLab project cloned", nil)
+
+	input := github.CreateRepositoryInput{
+		Name:       destRepoName,
+		Visibility: e.determineTargetVisibility(mc.Repo.Visibility),
+		HasIssues:  true,
+		HasWiki:    mc.Repo.HasWiki(),
+	}
+	if project != nil {
+		input.Description = project.Description
+		input.Homepage = project.WebURL
+	}
+	if _, err := e.destClient.CreateRepository(ctx, destOrg, input); err != nil {
+		return "", s.failStart(ctx, mc, "create", "Failed to create destination repository", err)
+	}
+
+	pushURL, err := e.destinationPushURL(destFullName)
+	if err != nil {
+		return "", s.failStart(ctx, mc, "push", "Failed to build destination URL", err)
+	}
+	pushOpts := mirrorPushOptions{
+		IncludeLFS: mc.Repo.HasLFS(),
+		Token:      e.destClient.Token(),
+	}
+	if err := pushMirror(ctx, filepath.Join(tempDir, "repo.git"), pushURL, pushOpts); err != nil {
+		return "", s.failStart(ctx, mc, "push", "Failed to push git data to destination", err)
+	}
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "push",
+		"Branches and tags pushed to destination", nil)
+
+	// Settings are best-effort: the git data is already migrated
+	s.applySettings(ctx, mc, destOrg, destRepoName, project)
+
+	mc.Repo.DestinationFullName = &destFullName
+	destURL := e.destClient.RepositoryURL(destFullName)
+	mc.Repo.DestinationURL = &destURL
+
+	e.logger.Info("GitLab mirror migration finished", "repo", mc.Repo.FullName, "destination", destFullName)
+	e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "initiated",
+		fmt.Sprintf("GitLab project migrated to %s", destFullName), nil)
+
+	return destFullName, nil
+}
+
+// PollMigration is a no-op since the mirror push completes within StartMigration.
+func (s *GitLabMigrationStrategy) PollMigration(ctx context.Context, mc *MigrationContext) error {
+	return nil
+}
+
+// ShouldUnlockSource returns false since GitLab projects are never locked.
+func (s *GitLabMigrationStrategy) ShouldUnlockSource() bool {
+	return false
+}
+
+// fetchProject fetches the GitLab project, returning nil when no GitLab client is
+// configured or the lookup fails (stored discovery data is used instead).
+func (s *GitLabMigrationStrategy) fetchProject(ctx context.Context, mc *MigrationContext) *gitlab.Project {
+	e := s.executor
+	if e.gitlabClient == nil {
+		return nil
+	}
+
+	project, err := e.gitlabClient.GetProject(ctx, mc.Repo.FullName)
+	if err != nil {
+		e.logger.Warn("Failed to fetch GitLab project, using discovered settings",
+			"repo", mc.Repo.FullName,
+			"error", err)
+		return nil
+	}
+	return project
+}
+
+// applySettings recreates the default branch, merge settings and protected branches
+// on the destination repository. Failures are logged as warnings.
+func (s *GitLabMigrationStrategy) applySettings(ctx context.Context, mc *MigrationContext, owner, repoName string, project *gitlab.Project) {
+	e := s.executor
+
+	settings, err := mc.Repo.GetMergeSettings()
+	if err != nil {
+		e.logger.Warn("Failed to read stored merge settings", "repo", mc.Repo.FullName, "error", err)
+	}
+	defaultBranch := ""
+	if branch := mc.Repo.GetDefaultBranch(); branch != nil {
+		defaultBranch = *branch
+	}
+	if project != nil {
+		settings = discovery.MergeSettingsFromGitLabProject(project)
+		defaultBranch = project.DefaultBranch
+	}
+
+	if update := repositorySettingsFromGitLab(defaultBranch, settings); update != nil {
+		if _, err := e.destClient.UpdateRepository(ctx, owner, repoName, update); err != nil {
+			s.warnSettings(ctx, mc, "Failed to apply repository settings", err)
+		} else {
+			e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "settings",
+				"Default branch and merge settings applied", nil)
+		}
+	}
+
+	if project == nil {
+		return
+	}
+
+	branches, err := e.gitlabClient.ListProtectedBranches(ctx, project.ID)
+	if err != nil {
+		s.warnSettings(ctx, mc, "Failed to list protected branches", err)
+		return
+	}
+
+	applied := 0
+	for _, branch := range branches {
+		// GitHub branch protection applies to a single existing branch;
+		// wildcard rules need to be recreated as rulesets
+		if strings.ContainsAny(branch.Name, "*?[") {
+			e.logger.Warn("Skipping wildcard protected branch (recreate as a ruleset)",
+				"repo", mc.Repo.FullName,
+				"branch", branch.Name)
+			continue
+		}
+
+		protection := branchProtectionFromGitLab(branch, settings)
+		if err := e.destClient.UpdateBranchProtection(ctx, owner, repoName, branch.Name, protection); err != nil {
+			s.warnSettings(ctx, mc, fmt.Sprintf("Failed to protect branch %s", branch.Name), err)
+			continue
+		}
+		applied++
+	}
+
+	if applied > 0 {
+		e.logOperation(ctx, mc.Repo, mc.HistoryID, "INFO", "migration", "settings",
+			fmt.Sprintf("Recreated protection on %d of %d protected branches", applied, len(branches)), nil)
+	}
+}
+
+// warnSettings logs a non-fatal settings failure.
+func (s *GitLabMigrationStrategy) warnSettings(ctx context.Context, mc *MigrationContext, message string, err error) {
+	errMsg := err.Error()
+	s.executor.logger.Warn(message, "repo", mc.Repo.FullName, "error", err)
+	s.executor.logOperation(ctx, mc.Repo, mc.HistoryID, "WARN", "migration", "settings", message, &errMsg)
+}
+
+// failStart logs a StartMigration failure and wraps the error.
+func (s *GitLabMigrationStrategy) failStart(ctx context.Context, mc *MigrationContext, action, message string, err error) error {
+	errMsg := err.Error()
+	s.executor.logOperation(ctx, mc.Repo, mc.HistoryID, "ERROR", "migration", action, message, &errMsg)
+	return fmt.Errorf("failed to start migration: %s: %w", strings.ToLower(message), err)
+}
+
+// repositorySettingsFromGitLab maps the GitLab default branch and merge settings to a
+// GitHub repository update. Returns nil when there is nothing to apply.
+//
+// GitLab merge methods map as follows:
+//   - merge: merge commits only
+//   - rebase_merge: merge commits only (GitHub has no semi-linear history mode)
+//   - ff: rebase merging only
+//
+// A squash option of "always" replaces the merge method with squash merging,
+// "never" disables squash merging, and anything else additionally allows it.
+func repositorySettingsFromGitLab(defaultBranch string, settings *models.MergeSettings) *ghapi.Repository {
+	if defaultBranch == "" && settings == nil {
+		return nil
+	}
+
+	update := &ghapi.Repository{}
+	if defaultBranch != "" {
+		update.DefaultBranch = ghapi.Ptr(defaultBranch)
+	}
+	if settings == nil {
+		return update
+	}
+
+	allowMerge := settings.MergeMethod != "ff"
+	allowRebase := settings.MergeMethod == "ff"
+	allowSquash := settings.SquashOption != "never"
+	if settings.SquashOption == "always" {
+		allowMerge = false
+		allowRebase = false
+	}
+
+	update.AllowMergeCommit = ghapi.Ptr(allowMerge)
+	update.AllowRebaseMerge = ghapi.Ptr(allowRebase)
+	update.AllowSquashMerge = ghapi.Ptr(allowSquash)
+	update.DeleteBranchOnMerge = ghapi.Ptr(settings.DeleteBranchOnMerge)
+
+	return update
+}
+
+// branchProtectionFromGitLab maps a GitLab protected branch to GitHub branch protection.
+// Branches nobody may push to directly require a pull request; code owner approval,
+// force pushes and discussion resolution carry over. Pipeline requirements cannot be
+// mapped since GitHub status checks must be named explicitly.
+func branchProtectionFromGitLab(branch gitlab.ProtectedBranch, settings *models.MergeSettings) *ghapi.ProtectionRequest {
+	protection := &ghapi.ProtectionRequest{
+		EnforceAdmins:    false,
+		AllowForcePushes: ghapi.Ptr(branch.AllowForcePush),
+	}
+
+	noDirectPush := len(branch.PushAccessLevels) > 0
+	for _, level := range branch.PushAccessLevels {
+		if level.AccessLevel != gitlab.AccessLevelNoAccess {
+			noDirectPush = false
+			break
+		}
+	}
+
+	if noDirectPush || branch.CodeOwnerApprovalRequired {
+		protection.RequiredPullRequestReviews = &ghapi.PullRequestReviewsEnforcementRequest{
+			RequireCodeOwnerReviews:      branch.CodeOwnerApprovalRequired,
+			RequiredApprovingReviewCount: 0,
+		}
+	}
+
+	if settings != nil && settings.RequireDiscussionResolution {
+		protection.RequiredConversationResolution = ghapi.Ptr(true)
+	}
+
+	return protection
+}
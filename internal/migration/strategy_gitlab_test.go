@@ -0,0 +1,247 @@
+package migration
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/gitlab"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/source"
+)
+
+// fakeProvider is a source.Provider stub for strategy validation tests
+type fakeProvider struct {
+	credentialsErr error
+}
+
+func (p *fakeProvider) Type() source.ProviderType { return source.ProviderGitLab }
+func (p *fakeProvider) Name() string              { return "fake" }
+func (p *fakeProvider) CloneRepository(ctx context.Context, info source.RepositoryInfo, destPath string, opts source.CloneOptions) error {
+	return nil
+}
+func (p *fakeProvider) GetAuthenticatedCloneURL(cloneURL string) (string, error) {
+	return cloneURL, nil
+}
+func (p *fakeProvider) ValidateCredentials(ctx context.Context) error { return p.credentialsErr }
+func (p *fakeProvider) SupportsFeature(feature source.Feature) bool   { return false }
+
+func TestStrategyRegistry_SelectsGitLabForGitLabRepositories(t *testing.T) {
+	tests := []struct {
+		name         string
+		batch        *models.Batch
+		repo         *models.Repository
+		wantStrategy string
+	}{
+		{
+			name:         "GitLab repository",
+			repo:         &models.Repository{FullName: "group/subgroup/project", Source: models.SourceGitLab},
+			wantStrategy: "GitLab",
+		},
+		{
+			name:         "GitHub repository",
+			repo:         &models.Repository{FullName: "org/repo", Source: models.SourceGHES},
+			wantStrategy: "GitHub",
+		},
+		{
+			name:         "GitLab repository in ELM batch",
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIELM},
+			repo:         &models.Repository{FullName: "group/project", Source: models.SourceGitLab},
+			wantStrategy: "ELM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewStrategyRegistry(
+				NewELMMigrationStrategy(nil, tt.batch),
+				NewGitMirrorMigrationStrategy(nil, tt.batch),
+				NewGitLabMigrationStrategy(nil),
+				NewGitHubMigrationStrategy(nil),
+				NewADOMigrationStrategy(nil),
+			)
+
+			strategy := registry.GetStrategy(tt.repo)
+			if strategy == nil {
+				t.Fatal("GetStrategy() returned nil, want strategy")
+			}
+			if strategy.Name() != tt.wantStrategy {
+				t.Errorf("GetStrategy() = %s, want %s", strategy.Name(), tt.wantStrategy)
+			}
+		})
+	}
+}
+
+func TestGitLabMigrationStrategy_ValidateSource(t *testing.T) {
+	repo := &models.Repository{
+		FullName:  "group/project",
+		Source:    models.SourceGitLab,
+		SourceURL: "https://gitlab.example.com/group/project",
+	}
+
+	tests := []struct {
+		name     string
+		provider source.Provider
+		repo     *models.Repository
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			provider: &fakeProvider{},
+			repo:     repo,
+		},
+		{
+			name:    "no provider",
+			repo:    repo,
+			wantErr: "source provider is not configured",
+		},
+		{
+			name:     "no source URL",
+			provider: &fakeProvider{},
+			repo:     &models.Repository{FullName: "group/project", Source: models.SourceGitLab},
+			wantErr:  "has no source URL",
+		},
+		{
+			name:     "invalid credentials",
+			provider: &fakeProvider{credentialsErr: errors.New("401")},
+			repo:     repo,
+			wantErr:  "credentials are invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			executor := &Executor{
+				sourceProvider: tt.provider,
+				logger:         slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})),
+			}
+			err := NewGitLabMigrationStrategy(executor).ValidateSource(context.Background(), tt.repo)
+
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSource() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateSource() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGitLabMigrationStrategy_ShouldUnlockSource(t *testing.T) {
+	if NewGitLabMigrationStrategy(nil).ShouldUnlockSource() {
+		t.Error("GitLab strategy should not unlock the source")
+	}
+}
+
+func TestRepositorySettingsFromGitLab(t *testing.T) {
+	tests := []struct {
+		name        string
+		branch      string
+		settings    *models.MergeSettings
+		wantNil     bool
+		wantMerge   bool
+		wantRebase  bool
+		wantSquash  bool
+		wantDeleted bool
+	}{
+		{
+			name:    "nothing to apply",
+			wantNil: true,
+		},
+		{
+			name:       "merge commits with optional squash",
+			branch:     "main",
+			settings:   &models.MergeSettings{MergeMethod: "merge", SquashOption: "default_off"},
+			wantMerge:  true,
+			wantSquash: true,
+		},
+		{
+			name:        "fast-forward without squash",
+			settings:    &models.MergeSettings{MergeMethod: "ff", SquashOption: "never", DeleteBranchOnMerge: true},
+			wantRebase:  true,
+			wantDeleted: true,
+		},
+		{
+			name:       "squash always",
+			settings:   &models.MergeSettings{MergeMethod: "rebase_merge", SquashOption: "always"},
+			wantSquash: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			update := repositorySettingsFromGitLab(tt.branch, tt.settings)
+			if tt.wantNil {
+				if update != nil {
+					t.Errorf("repositorySettingsFromGitLab() = %+v, want nil", update)
+				}
+				return
+			}
+			if update == nil {
+				t.Fatal("repositorySettingsFromGitLab() = nil, want update")
+			}
+			if update.GetDefaultBranch() != tt.branch {
+				t.Errorf("DefaultBranch = %q, want %q", update.GetDefaultBranch(), tt.branch)
+			}
+			if update.GetAllowMergeCommit() != tt.wantMerge ||
+				update.GetAllowRebaseMerge() != tt.wantRebase ||
+				update.GetAllowSquashMerge() != tt.wantSquash {
+				t.Errorf("merge/rebase/squash = %v/%v/%v, want %v/%v/%v",
+					update.GetAllowMergeCommit(), update.GetAllowRebaseMerge(), update.GetAllowSquashMerge(),
+					tt.wantMerge, tt.wantRebase, tt.wantSquash)
+			}
+			if update.GetDeleteBranchOnMerge() != tt.wantDeleted {
+				t.Errorf("DeleteBranchOnMerge = %v, want %v", update.GetDeleteBranchOnMerge(), tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestBranchProtectionFromGitLab(t *testing.T) {
+	noOne := []gitlab.AccessLevel{{AccessLevel: gitlab.AccessLevelNoAccess}}
+	maintainers := []gitlab.AccessLevel{{AccessLevel: gitlab.AccessLevelMaintainer}}
+
+	t.Run("no direct pushes requires pull requests", func(t *testing.T) {
+		protection := branchProtectionFromGitLab(gitlab.ProtectedBranch{Name: "main", PushAccessLevels: noOne}, nil)
+		if protection.RequiredPullRequestReviews == nil {
+			t.Fatal("expected pull request reviews to be required")
+		}
+		if protection.RequiredConversationResolution != nil {
+			t.Error("conversation resolution should not be set without merge settings")
+		}
+	})
+
+	t.Run("maintainer pushes allowed", func(t *testing.T) {
+		protection := branchProtectionFromGitLab(gitlab.ProtectedBranch{
+			Name:             "main",
+			PushAccessLevels: maintainers,
+			AllowForcePush:   true,
+		}, nil)
+		if protection.RequiredPullRequestReviews != nil {
+			t.Error("pull request reviews should not be required when maintainers can push")
+		}
+		if protection.AllowForcePushes == nil || !*protection.AllowForcePushes {
+			t.Error("expected force pushes to be allowed")
+		}
+	})
+
+	t.Run("code owners and discussion resolution", func(t *testing.T) {
+		protection := branchProtectionFromGitLab(gitlab.ProtectedBranch{
+			Name:                      "main",
+			PushAccessLevels:          maintainers,
+			CodeOwnerApprovalRequired: true,
+		}, &models.MergeSettings{RequireDiscussionResolution: true})
+		if protection.RequiredPullRequestReviews == nil || !protection.RequiredPullRequestReviews.RequireCodeOwnerReviews {
+			t.Error("expected code owner reviews to be required")
+		}
+		if protection.RequiredConversationResolution == nil || !*protection.RequiredConversationResolution {
+			t.Error("expected conversation resolution to be required")
+		}
+	})
+}
@@ -34,17 +34,20 @@ type TeamExecutor struct {
 
 // TeamMigrationProgress tracks the progress of a team migration execution
 type TeamMigrationProgress struct {
-	TotalTeams       int        `json:"total_teams"`
-	ProcessedTeams   int        `json:"processed_teams"`
-	CreatedTeams     int        `json:"created_teams"`
-	SkippedTeams     int        `json:"skipped_teams"`
-	FailedTeams      int        `json:"failed_teams"`
-	TotalReposSynced int        `json:"total_repos_synced"`
-	StartedAt        time.Time  `json:"started_at"`
-	CompletedAt      *time.Time `json:"completed_at,omitempty"`
-	CurrentTeam      string     `json:"current_team,omitempty"`
-	Status           string     `json:"status"` // pending, in_progress, completed, cancelled, failed
-	Errors           []string   `json:"errors,omitempty"`
+	TotalTeams        int        `json:"total_teams"`
+	ProcessedTeams    int        `json:"processed_teams"`
+	CreatedTeams      int        `json:"created_teams"`
+	SkippedTeams      int        `json:"skipped_teams"`
+	FailedTeams       int        `json:"failed_teams"`
+	TotalReposSynced  int        `json:"total_repos_synced"`
+	MembersAdded      int        `json:"members_added"`                // Members added to destination teams when syncing members
+	UnmappedMembers   int        `json:"unmapped_members"`             // Members without a user mapping when syncing members
+	HierarchyWarnings []string   `json:"hierarchy_warnings,omitempty"` // Teams created without their source parent, and why
+	StartedAt         time.Time  `json:"started_at"`
+	CompletedAt       *time.Time `json:"completed_at,omitempty"`
+	CurrentTeam       string     `json:"current_team,omitempty"`
+	Status            string     `json:"status"` // pending, in_progress, completed, cancelled, failed
+	Errors            []string   `json:"errors,omitempty"`
 }
 
 // NewTeamExecutor creates a new TeamExecutor
@@ -114,9 +117,10 @@ func (e *TeamExecutor) Cancel() error {
 
 // ExecuteTeamMigration executes team migration for all mapped teams, or a single team if both
 // sourceOrgFilter AND sourceTeamSlugFilter are provided (both required to uniquely identify a team).
-// Teams are created WITHOUT members (empty) to support EMU/IdP-managed environments.
-// Only repository permissions are applied.
-func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter string, sourceTeamSlugFilter string, dryRun bool) error {
+// Teams are created WITHOUT members (empty) to support EMU/IdP-managed environments, parents
+// before children so nested teams keep their hierarchy, and repository permissions are applied. With opts.SyncMembers, discovered members are also added
+// through their user mappings, except to teams managed by an identity provider.
+func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter string, sourceTeamSlugFilter string, opts TeamMigrationOptions) error {
 	e.mu.Lock()
 	if e.running {
 		e.mu.Unlock()
@@ -140,7 +144,8 @@ func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter
 	e.logger.Info("Starting team migration execution",
 		"source_org_filter", sourceOrgFilter,
 		"source_team_slug_filter", sourceTeamSlugFilter,
-		"dry_run", dryRun)
+		"dry_run", opts.DryRun,
+		"sync_members", opts.SyncMembers)
 
 	// Get all mapped teams ready for migration
 	mappings, err := e.storage.GetMappedTeamsForMigration(ctx, sourceOrgFilter)
@@ -149,6 +154,15 @@ func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter
 		return fmt.Errorf("failed to get mapped teams: %w", err)
 	}
 
+	// Order teams so parents are created before their children. The plan covers every mapped
+	// team so a single team can still be nested under a parent migrated earlier.
+	sourceParents, err := e.sourceTeamParents(ctx, mappings)
+	if err != nil {
+		e.logger.Warn("Failed to load source team hierarchy, teams will be created at the top level", "error", err)
+	}
+	hierarchy := planTeamHierarchy(mappings, sourceParents)
+	mappings = hierarchy.ordered
+
 	// Filter to single team if specified
 	if sourceTeamSlugFilter != "" && sourceOrgFilter != "" {
 		var filteredMappings []*models.TeamMapping
@@ -196,8 +210,13 @@ func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter
 		default:
 		}
 
+		if warning, ok := hierarchy.warnings[mapping.SourceFullSlug()]; ok {
+			e.logger.Warn("Team will not be nested under its source parent", "team", mapping.SourceFullSlug(), "reason", warning)
+			e.addHierarchyWarning(mapping, warning)
+		}
+
 		// Process this team
-		err := e.processTeamMapping(ctx, mapping, dryRun)
+		err := e.processTeamMapping(ctx, mapping, opts, hierarchy.parents[mapping.SourceFullSlug()])
 		if err != nil {
 			e.logger.Error("Failed to process team mapping",
 				"team", mapping.SourceFullSlug(),
@@ -225,6 +244,7 @@ func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter
 	skippedTeams := e.progress.SkippedTeams
 	failedTeams := e.progress.FailedTeams
 	totalReposSynced := e.progress.TotalReposSynced
+	membersAdded := e.progress.MembersAdded
 	e.mu.Unlock()
 
 	e.logger.Info("Team migration execution completed",
@@ -232,16 +252,19 @@ func (e *TeamExecutor) ExecuteTeamMigration(ctx context.Context, sourceOrgFilter
 		"created", createdTeams,
 		"skipped", skippedTeams,
 		"failed", failedTeams,
-		"repos_synced", totalReposSynced)
+		"repos_synced", totalReposSynced,
+		"members_added", membersAdded)
 
 	return nil
 }
 
 // processTeamMapping processes a single team mapping
-// Handles both initial team creation and re-sync for newly migrated repos
+// Handles both initial team creation and re-sync for newly migrated repos.
+// parentSlug is the destination team to nest it under; empty for top-level teams.
 //
 //nolint:gocyclo // Complex orchestration logic with multiple API calls and error paths
-func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.TeamMapping, dryRun bool) error {
+func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.TeamMapping, opts TeamMigrationOptions, parentSlug string) error {
+	dryRun := opts.DryRun
 	if mapping.DestinationOrg == nil || mapping.DestinationTeamSlug == nil {
 		return fmt.Errorf("destination org or team slug is not set")
 	}
@@ -280,7 +303,8 @@ func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.T
 		if dryRun {
 			e.logger.Info("DRY RUN: Would create team",
 				"org", destOrg,
-				"slug", destTeamSlug)
+				"slug", destTeamSlug,
+				"parent", parentSlug)
 			// Note: When using PAT auth, would also remove PAT owner from team after creation
 			if e.destClient.IsPATAuthenticated() {
 				e.logger.Info("DRY RUN: Would remove PAT owner from team after creation (PAT auth detected)")
@@ -294,11 +318,22 @@ func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.T
 				teamName = *mapping.SourceTeamName
 			}
 
-			// Create the team (empty, without members)
-			createdTeam, err := e.destClient.CreateTeam(ctx, destOrg, github.CreateTeamInput{
+			input := github.CreateTeamInput{
 				Name:    teamName,
-				Privacy: "closed", // Default to closed
-			})
+				Privacy: "closed", // Default to closed; secret teams cannot be nested
+			}
+			if parentSlug != "" {
+				parentID, warning := e.resolveDestinationParent(ctx, destOrg, parentSlug)
+				if warning != "" {
+					e.logger.Warn("Creating team at the top level", "team", destOrg+"/"+destTeamSlug, "reason", warning)
+					e.addHierarchyWarning(mapping, warning)
+				} else {
+					input.ParentTeam = &parentID
+				}
+			}
+
+			// Create the team (empty, without members)
+			createdTeam, err := e.destClient.CreateTeam(ctx, destOrg, input)
 			if err != nil {
 				errMsg := err.Error()
 				_ = e.storage.UpdateTeamMigrationStatus(ctx, mapping.SourceOrg, mapping.SourceTeamSlug, storage.TeamMigrationStatusFailed, &errMsg)
@@ -309,7 +344,8 @@ func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.T
 			e.logger.Info("Created team in destination",
 				"org", destOrg,
 				"slug", destTeamSlug,
-				"name", teamName)
+				"name", teamName,
+				"parent", parentSlug)
 
 			// Mark team as created in destination
 			teamCreated := true
@@ -350,6 +386,11 @@ func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.T
 			})
 		}
 
+		// Nest existing top-level teams, e.g. created before their parent existed
+		if parentSlug != "" && existingTeam.ParentSlug == "" {
+			e.nestExistingTeam(ctx, mapping, destOrg, existingTeam, parentSlug, dryRun)
+		}
+
 		if isResync {
 			e.logger.Info("Re-syncing team permissions (team already exists)",
 				"org", destOrg,
@@ -491,7 +532,16 @@ func (e *TeamExecutor) processTeamMapping(ctx context.Context, mapping *models.T
 		}
 	}
 
-	// Step 6: Mark as completed
+	// Step 6: Add members through their user mappings if requested
+	if opts.SyncMembers {
+		membership := e.syncTeamMembers(ctx, mapping, destOrg, destTeamSlug, dryRun)
+		if membership.Status == models.TeamMembershipStatusFailed {
+			e.addError(fmt.Sprintf("%s members: %s", mapping.SourceFullSlug(), *membership.Reason))
+		}
+		e.addMembersSynced(membership)
+	}
+
+	// Step 7: Mark as completed
 	if !dryRun {
 		if err := e.storage.UpdateTeamMigrationStatus(ctx, mapping.SourceOrg, mapping.SourceTeamSlug, storage.TeamMigrationStatusCompleted, nil); err != nil {
 			e.logger.Warn("Failed to update team migration status to completed", "error", err)
@@ -542,6 +592,21 @@ func (e *TeamExecutor) addReposSynced(count int) {
 	e.progress.TotalReposSynced += count
 }
 
+func (e *TeamExecutor) addMembersSynced(membership *models.TeamMembershipSync) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.progress.MembersAdded += membership.MembersAdded
+	if membership.Unmapped != nil {
+		e.progress.UnmappedMembers += len(strings.Split(*membership.Unmapped, ","))
+	}
+}
+
+func (e *TeamExecutor) addHierarchyWarning(mapping *models.TeamMapping, warning string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.progress.HierarchyWarnings = append(e.progress.HierarchyWarnings, mapping.SourceFullSlug()+": "+warning)
+}
+
 func (e *TeamExecutor) addError(msg string) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
@@ -0,0 +1,166 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// teamHierarchyPlan orders team mappings so that parent teams are created before their children
+type teamHierarchyPlan struct {
+	ordered  []*models.TeamMapping
+	parents  map[string]string // source full slug -> destination parent team slug
+	warnings map[string]string // source full slug -> why the team is not nested under its source parent
+}
+
+// planTeamHierarchy works out where each mapped team belongs in the destination hierarchy.
+// sourceParents maps a source team ("org/slug") to its parent team ("org/slug").
+//
+// A team is nested under the destination team of its nearest mapped ancestor in the same
+// destination organization, so unmapped teams in the middle of a tree are skipped over. Teams
+// whose ancestors are not mapped there are created at the top level. If nesting a team would
+// create a cycle in the destination (e.g. when mappings merge or reorder teams), it is also
+// created at the top level. Every such case is recorded as a warning.
+func planTeamHierarchy(mappings []*models.TeamMapping, sourceParents map[string]string) *teamHierarchyPlan {
+	plan := &teamHierarchyPlan{
+		parents:  make(map[string]string),
+		warnings: make(map[string]string),
+	}
+
+	bySource := make(map[string]*models.TeamMapping, len(mappings))
+	byDest := make(map[string][]*models.TeamMapping, len(mappings))
+	for _, m := range mappings {
+		bySource[m.SourceFullSlug()] = m
+		if dest := m.DestinationFullSlug(); dest != "" {
+			byDest[dest] = append(byDest[dest], m)
+		}
+	}
+
+	for _, m := range mappings {
+		if m.DestinationOrg == nil || m.DestinationTeamSlug == nil {
+			continue
+		}
+		parent := sourceParents[m.SourceFullSlug()]
+		if parent == "" {
+			continue
+		}
+
+		seen := map[string]bool{m.SourceFullSlug(): true}
+		for ancestor := parent; ancestor != "" && !seen[ancestor]; ancestor = sourceParents[ancestor] {
+			seen[ancestor] = true
+			am := bySource[ancestor]
+			if am == nil || am.DestinationOrg == nil || am.DestinationTeamSlug == nil ||
+				*am.DestinationOrg != *m.DestinationOrg || *am.DestinationTeamSlug == *m.DestinationTeamSlug {
+				continue
+			}
+			plan.parents[m.SourceFullSlug()] = *am.DestinationTeamSlug
+			if ancestor != parent {
+				plan.warnings[m.SourceFullSlug()] = fmt.Sprintf("parent team %s is not mapped to %s; nested under %s, the destination of its nearest mapped ancestor %s",
+					parent, *m.DestinationOrg, am.DestinationFullSlug(), ancestor)
+			}
+			break
+		}
+		if _, ok := plan.parents[m.SourceFullSlug()]; !ok {
+			plan.warnings[m.SourceFullSlug()] = fmt.Sprintf("parent team %s has no mapped ancestor in %s; created at the top level",
+				parent, *m.DestinationOrg)
+		}
+	}
+
+	// Depth-first topological sort over the destination parents
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[*models.TeamMapping]int, len(mappings))
+	var visit func(m *models.TeamMapping)
+	visit = func(m *models.TeamMapping) {
+		if state[m] != 0 {
+			return
+		}
+		state[m] = visiting
+		if parentSlug, ok := plan.parents[m.SourceFullSlug()]; ok {
+			for _, pm := range byDest[*m.DestinationOrg+"/"+parentSlug] {
+				if state[pm] == visiting {
+					delete(plan.parents, m.SourceFullSlug())
+					plan.warnings[m.SourceFullSlug()] = fmt.Sprintf("nesting under %s/%s would create a cycle; created at the top level",
+						*m.DestinationOrg, parentSlug)
+					break
+				}
+				visit(pm)
+			}
+		}
+		state[m] = visited
+		plan.ordered = append(plan.ordered, m)
+	}
+	for _, m := range mappings {
+		visit(m)
+	}
+
+	return plan
+}
+
+// sourceTeamParents returns the discovered parent of every team in the organizations of the mappings
+func (e *TeamExecutor) sourceTeamParents(ctx context.Context, mappings []*models.TeamMapping) (map[string]string, error) {
+	orgs := make(map[string]bool)
+	var orgList []string
+	for _, m := range mappings {
+		if !orgs[m.SourceOrg] {
+			orgs[m.SourceOrg] = true
+			orgList = append(orgList, m.SourceOrg)
+		}
+	}
+	if len(orgList) == 0 {
+		return map[string]string{}, nil
+	}
+
+	teams, err := e.storage.ListTeams(ctx, strings.Join(orgList, ","))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list source teams: %w", err)
+	}
+
+	parents := make(map[string]string)
+	for _, team := range teams {
+		if team.ParentSlug != nil && *team.ParentSlug != "" {
+			parents[team.FullSlug()] = team.Organization + "/" + *team.ParentSlug
+		}
+	}
+	return parents, nil
+}
+
+// resolveDestinationParent returns the ID of the destination parent team, or 0 with a warning
+// when it does not exist yet (e.g. its own migration failed or was not part of this run)
+func (e *TeamExecutor) resolveDestinationParent(ctx context.Context, destOrg, parentSlug string) (int64, string) {
+	parent, err := e.destClient.GetTeamBySlug(ctx, destOrg, parentSlug)
+	if err != nil {
+		return 0, fmt.Sprintf("failed to look up parent team %s/%s: %v", destOrg, parentSlug, err)
+	}
+	if parent == nil {
+		return 0, fmt.Sprintf("parent team %s/%s does not exist in the destination; migrate it and run again to nest this team", destOrg, parentSlug)
+	}
+	return parent.ID, ""
+}
+
+// nestExistingTeam sets the parent of a destination team that exists at the top level.
+// Teams that already have a parent are left alone so manual changes are not undone.
+func (e *TeamExecutor) nestExistingTeam(ctx context.Context, mapping *models.TeamMapping, destOrg string, team *github.TeamInfo, parentSlug string, dryRun bool) {
+	if dryRun {
+		e.logger.Info("DRY RUN: Would nest existing team", "team", destOrg+"/"+team.Slug, "parent", parentSlug)
+		return
+	}
+
+	parentID, warning := e.resolveDestinationParent(ctx, destOrg, parentSlug)
+	if warning == "" {
+		if err := e.destClient.SetTeamParent(ctx, destOrg, team.Slug, team.Name, parentID); err != nil {
+			warning = fmt.Sprintf("failed to nest under %s/%s: %v", destOrg, parentSlug, err)
+		}
+	}
+	if warning != "" {
+		e.logger.Warn("Existing team left at the top level", "team", destOrg+"/"+team.Slug, "reason", warning)
+		e.addHierarchyWarning(mapping, warning)
+		return
+	}
+	e.logger.Info("Nested existing team under its parent", "team", destOrg+"/"+team.Slug, "parent", parentSlug)
+}
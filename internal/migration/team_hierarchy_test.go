@@ -0,0 +1,98 @@
+package migration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func hierarchyMapping(source, destOrg, destSlug string) *models.TeamMapping {
+	org, slug, _ := strings.Cut(source, "/")
+	return &models.TeamMapping{SourceOrg: org, SourceTeamSlug: slug, DestinationOrg: &destOrg, DestinationTeamSlug: &destSlug}
+}
+
+func orderedSources(plan *teamHierarchyPlan) []string {
+	var sources []string
+	for _, m := range plan.ordered {
+		sources = append(sources, m.SourceFullSlug())
+	}
+	return sources
+}
+
+func TestPlanTeamHierarchy(t *testing.T) {
+	t.Run("parents are created before their children", func(t *testing.T) {
+		mappings := []*models.TeamMapping{
+			hierarchyMapping("src/web", "dest", "web"),
+			hierarchyMapping("src/frontend", "dest", "frontend"),
+			hierarchyMapping("src/engineering", "dest", "engineering"),
+		}
+		parents := map[string]string{"src/web": "src/frontend", "src/frontend": "src/engineering"}
+
+		plan := planTeamHierarchy(mappings, parents)
+
+		if got := strings.Join(orderedSources(plan), ","); got != "src/engineering,src/frontend,src/web" {
+			t.Errorf("Unexpected order %s", got)
+		}
+		if plan.parents["src/web"] != "frontend" || plan.parents["src/frontend"] != "engineering" {
+			t.Errorf("Unexpected parents %v", plan.parents)
+		}
+		if _, ok := plan.parents["src/engineering"]; ok {
+			t.Error("Expected engineering to stay at the top level")
+		}
+		if len(plan.warnings) != 0 {
+			t.Errorf("Expected no warnings, got %v", plan.warnings)
+		}
+	})
+
+	t.Run("unmapped parents are skipped over", func(t *testing.T) {
+		mappings := []*models.TeamMapping{
+			hierarchyMapping("src/web", "dest", "web"),
+			hierarchyMapping("src/engineering", "dest", "engineering"),
+			hierarchyMapping("src/ops", "dest", "ops"),
+		}
+		parents := map[string]string{"src/web": "src/frontend", "src/frontend": "src/engineering", "src/ops": "src/platform"}
+
+		plan := planTeamHierarchy(mappings, parents)
+
+		if plan.parents["src/web"] != "engineering" || !strings.Contains(plan.warnings["src/web"], "nearest mapped ancestor src/engineering") {
+			t.Errorf("Expected web under engineering, got %q (%q)", plan.parents["src/web"], plan.warnings["src/web"])
+		}
+		if _, ok := plan.parents["src/ops"]; ok || !strings.Contains(plan.warnings["src/ops"], "created at the top level") {
+			t.Errorf("Expected ops at the top level, got %v", plan.warnings)
+		}
+	})
+
+	t.Run("parents mapped to another organization are ignored", func(t *testing.T) {
+		mappings := []*models.TeamMapping{
+			hierarchyMapping("src/web", "dest", "web"),
+			hierarchyMapping("src/frontend", "other", "frontend"),
+		}
+
+		plan := planTeamHierarchy(mappings, map[string]string{"src/web": "src/frontend"})
+
+		if _, ok := plan.parents["src/web"]; ok || plan.warnings["src/web"] == "" {
+			t.Errorf("Expected web at the top level with a warning, got %v %v", plan.parents, plan.warnings)
+		}
+	})
+
+	t.Run("cycles created by the mappings are broken", func(t *testing.T) {
+		// a is under b and c is under d in the source, but a and d share a destination, as do b and c
+		mappings := []*models.TeamMapping{
+			hierarchyMapping("src/a", "dest", "x"),
+			hierarchyMapping("src/b", "dest", "y"),
+			hierarchyMapping("src/c", "dest", "y"),
+			hierarchyMapping("src/d", "dest", "x"),
+		}
+		parents := map[string]string{"src/a": "src/b", "src/c": "src/d"}
+
+		plan := planTeamHierarchy(mappings, parents)
+
+		if len(plan.ordered) != 4 {
+			t.Fatalf("Expected every team to be planned, got %v", orderedSources(plan))
+		}
+		if len(plan.parents) != 1 || !strings.Contains(plan.warnings["src/c"], "would create a cycle") {
+			t.Errorf("Expected the cycle through c to be broken, got %v %v", plan.parents, plan.warnings)
+		}
+	})
+}
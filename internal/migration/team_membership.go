@@ -0,0 +1,133 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// TeamMigrationOptions configures a team migration execution
+type TeamMigrationOptions struct {
+	DryRun      bool // Log what would be done without changing the destination
+	SyncMembers bool // Add discovered team members to the destination teams through their user mappings
+}
+
+// syncTeamMembers adds the discovered members of a source team to its destination team through
+// their user mappings, keeping maintainer roles. Teams whose destination membership is managed
+// by an identity provider (EMU external groups or IdP team synchronization) are skipped, since
+// GitHub rejects manual changes to them. Members without a user mapping are listed in the
+// returned record, which is saved unless this is a dry run.
+func (e *TeamExecutor) syncTeamMembers(ctx context.Context, mapping *models.TeamMapping, destOrg, destTeamSlug string, dryRun bool) *models.TeamMembershipSync {
+	record := &models.TeamMembershipSync{
+		SourceID:            mapping.SourceID,
+		SourceOrg:           mapping.SourceOrg,
+		SourceTeamSlug:      mapping.SourceTeamSlug,
+		DestinationOrg:      destOrg,
+		DestinationTeamSlug: destTeamSlug,
+	}
+	e.applyTeamMembers(ctx, record, dryRun)
+
+	if dryRun {
+		e.logger.Info("DRY RUN: Team membership sync",
+			"team", mapping.SourceFullSlug(),
+			"status", record.Status,
+			"members", record.MembersAdded,
+			"maintainers", record.MaintainersAdded,
+			"unmapped", record.Unmapped)
+		return record
+	}
+
+	if err := e.storage.SaveTeamMembershipSync(ctx, record); err != nil {
+		e.logger.Warn("Failed to save team membership sync", "team", mapping.SourceFullSlug(), "error", err)
+	}
+	return record
+}
+
+// applyTeamMembers fills in the outcome of a membership sync
+func (e *TeamExecutor) applyTeamMembers(ctx context.Context, record *models.TeamMembershipSync, dryRun bool) {
+	destTeam := record.DestinationOrg + "/" + record.DestinationTeamSlug
+
+	identitySync, err := e.destClient.GetTeamIdentitySync(ctx, record.DestinationOrg, record.DestinationTeamSlug)
+	if err != nil {
+		setTeamMembershipFailed(record, fmt.Sprintf("Failed to check whether %s is synced from an identity provider: %v", destTeam, err))
+		return
+	}
+	if identitySync != nil {
+		reason := fmt.Sprintf("Membership of %s is managed by the identity provider through team synchronization group %s", destTeam, strings.Join(identitySync.Groups, ", "))
+		if identitySync.Source == "external_group" {
+			reason = fmt.Sprintf("Membership of %s is managed by the identity provider through EMU external group %s", destTeam, strings.Join(identitySync.Groups, ", "))
+		}
+		record.Status = models.TeamMembershipStatusSkipped
+		record.Reason = &reason
+		return
+	}
+
+	members, err := e.storage.GetTeamMembersByOrgAndSlug(ctx, record.SourceOrg, record.SourceTeamSlug)
+	if err != nil {
+		setTeamMembershipFailed(record, fmt.Sprintf("Failed to get discovered team members: %v", err))
+		return
+	}
+
+	var unmapped, failed []string
+	for _, member := range members {
+		destLogin, ok := e.destinationLogin(ctx, member.Login)
+		if !ok {
+			unmapped = append(unmapped, member.Login)
+			continue
+		}
+
+		role := github.TeamRoleMember
+		if member.Role == github.TeamRoleMaintainer {
+			role = github.TeamRoleMaintainer
+		}
+
+		if dryRun {
+			e.logger.Info("DRY RUN: Would add team member", "team", destTeam, "user", destLogin, "role", role)
+		} else if err := e.destClient.AddTeamMembership(ctx, record.DestinationOrg, record.DestinationTeamSlug, destLogin, role); err != nil {
+			e.logger.Warn("Failed to add team member", "team", destTeam, "user", destLogin, "role", role, "error", err)
+			failed = append(failed, destLogin)
+			continue
+		}
+
+		record.MembersAdded++
+		if role == github.TeamRoleMaintainer {
+			record.MaintainersAdded++
+		}
+	}
+
+	record.Status = models.TeamMembershipStatusSynced
+	if len(unmapped) > 0 {
+		sort.Strings(unmapped)
+		joined := strings.Join(unmapped, ",")
+		record.Unmapped = &joined
+	}
+	if len(failed) > 0 {
+		sort.Strings(failed)
+		joined := strings.Join(failed, ",")
+		record.Failed = &joined
+	}
+}
+
+// destinationLogin returns the destination login of a source user.
+// Skipped mappings and mappings without a destination login count as unmapped.
+func (e *TeamExecutor) destinationLogin(ctx context.Context, login string) (string, bool) {
+	mapping, err := e.storage.GetUserMappingBySourceLogin(ctx, login)
+	if err != nil {
+		e.logger.Warn("Failed to look up user mapping", "login", login, "error", err)
+		return "", false
+	}
+	if mapping == nil || mapping.MappingStatus == string(models.UserMappingStatusSkipped) ||
+		mapping.DestinationLogin == nil || *mapping.DestinationLogin == "" {
+		return "", false
+	}
+	return *mapping.DestinationLogin, true
+}
+
+func setTeamMembershipFailed(record *models.TeamMembershipSync, reason string) {
+	record.Status = models.TeamMembershipStatusFailed
+	record.Reason = &reason
+}
@@ -0,0 +1,141 @@
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/github"
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
+)
+
+func TestSyncTeamMembers(t *testing.T) {
+	var mu sync.Mutex
+	added := make(map[string]string) // destination login -> role
+	mux := http.NewServeMux()
+	notFound := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	}
+	mux.HandleFunc("GET /api/v3/orgs/dest-org/teams/backend/external-groups", notFound)
+	mux.HandleFunc("GET /api/v3/orgs/dest-org/teams/backend/team-sync/group-mappings", notFound)
+	mux.HandleFunc("PUT /api/v3/orgs/dest-org/teams/backend/memberships/{login}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.PathValue("login") == "erin-acme" {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message":"User is not a member of the organization"}`))
+			return
+		}
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		added[r.PathValue("login")] = body["role"]
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"state":"active"}`))
+	})
+	mux.HandleFunc("GET /api/v3/orgs/dest-org/teams/emu/external-groups", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"groups":[{"group_id":7,"group_name":"Engineering"}]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	client, err := github.NewClient(github.ClientConfig{
+		BaseURL:     server.URL,
+		Token:       "test-token",
+		RetryConfig: github.DefaultRetryConfig(),
+		Logger:      logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	db, err := storage.NewDatabase(config.DatabaseConfig{Type: "sqlite", DSN: ":memory:"})
+	if err != nil {
+		t.Fatalf("Failed to create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	ctx := context.Background()
+	team := &models.GitHubTeam{Organization: "source-org", Slug: "backend", Name: "Backend"}
+	if err := db.SaveTeam(ctx, team); err != nil {
+		t.Fatalf("SaveTeam() error = %v", err)
+	}
+	saved, err := db.GetTeamByOrgAndSlug(ctx, "source-org", "backend")
+	if err != nil || saved == nil {
+		t.Fatalf("GetTeamByOrgAndSlug() = %v, %v", saved, err)
+	}
+	for login, role := range map[string]string{"alice": "maintainer", "bob": "member", "carol": "member", "dave": "member", "erin": "member"} {
+		if err := db.SaveTeamMember(ctx, &models.GitHubTeamMember{TeamID: saved.ID, Login: login, Role: role}); err != nil {
+			t.Fatalf("SaveTeamMember() error = %v", err)
+		}
+	}
+	for source, dest := range map[string]string{"alice": "alice-acme", "bob": "bob-acme", "dave": "dave-acme", "erin": "erin-acme"} {
+		destLogin := dest
+		status := "mapped"
+		if source == "dave" {
+			status = "skipped"
+		}
+		if err := db.SaveUserMapping(ctx, &models.UserMapping{SourceLogin: source, DestinationLogin: &destLogin, MappingStatus: status}); err != nil {
+			t.Fatalf("SaveUserMapping() error = %v", err)
+		}
+	}
+
+	executor := NewTeamExecutor(db, nil, client, logger)
+	mapping := &models.TeamMapping{SourceOrg: "source-org", SourceTeamSlug: "backend"}
+
+	t.Run("dry run adds nobody", func(t *testing.T) {
+		record := executor.syncTeamMembers(ctx, mapping, "dest-org", "backend", true)
+		if record.Status != models.TeamMembershipStatusSynced || record.MembersAdded != 3 {
+			t.Errorf("Unexpected dry run record %+v", record)
+		}
+		if len(added) != 0 {
+			t.Errorf("Expected no members to be added during a dry run, got %v", added)
+		}
+		if syncs, _ := db.ListTeamMembershipSyncs(ctx, ""); len(syncs) != 0 {
+			t.Errorf("Expected no record to be saved during a dry run, got %+v", syncs)
+		}
+	})
+
+	t.Run("adds mapped members with their roles", func(t *testing.T) {
+		record := executor.syncTeamMembers(ctx, mapping, "dest-org", "backend", false)
+		if record.Status != models.TeamMembershipStatusSynced || record.MembersAdded != 2 || record.MaintainersAdded != 1 {
+			t.Errorf("Unexpected record %+v", record)
+		}
+		if added["alice-acme"] != github.TeamRoleMaintainer || added["bob-acme"] != github.TeamRoleMember || len(added) != 2 {
+			t.Errorf("Unexpected memberships %v", added)
+		}
+		if record.Unmapped == nil || *record.Unmapped != "carol,dave" {
+			t.Errorf("Expected carol and dave to be unmapped, got %v", record.Unmapped)
+		}
+		if record.Failed == nil || *record.Failed != "erin-acme" {
+			t.Errorf("Expected erin-acme to fail, got %v", record.Failed)
+		}
+
+		syncs, err := db.ListTeamMembershipSyncs(ctx, "source-org")
+		if err != nil || len(syncs) != 1 || syncs[0].DestinationTeamSlug != "backend" {
+			t.Errorf("ListTeamMembershipSyncs() = %+v, %v", syncs, err)
+		}
+	})
+
+	t.Run("skips teams managed by the identity provider", func(t *testing.T) {
+		emu := &models.TeamMapping{SourceOrg: "source-org", SourceTeamSlug: "platform"}
+		record := executor.syncTeamMembers(ctx, emu, "dest-org", "emu", false)
+		if record.Status != models.TeamMembershipStatusSkipped || record.Reason == nil || !strings.Contains(*record.Reason, "EMU external group Engineering") {
+			t.Errorf("Unexpected record %+v", record)
+		}
+	})
+}
@@ -93,6 +93,7 @@ const (
 	SourceTypeGHEC        = "ghec" // GitHub Enterprise Cloud
 	SourceTypeGitLab      = "gitlab"
 	SourceTypeAzureDevOps = "azuredevops"
+	SourceTypeBitbucket   = "bitbucket" // Bitbucket Server / Data Center
 )
 
 // MigrationStatus values are defined elsewhere in this package (models.go).
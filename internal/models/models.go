@@ -11,8 +11,14 @@ import (
 const (
 	MigrationAPIGEI = "GEI" // GitHub Enterprise Importer
 	MigrationAPIELM = "ELM" // Enterprise Live Migrator
+	MigrationAPIGit = "GIT" // Git mirror push (git history only, not subject to GEI limits)
 )
 
+// IsValidMigrationAPI returns true if api is a supported batch migration API.
+func IsValidMigrationAPI(api string) bool {
+	return api == MigrationAPIGEI || api == MigrationAPIELM || api == MigrationAPIGit
+}
+
 // Repository represents a Git repository to be migrated.
 //
 // # Field Organization
@@ -53,6 +59,10 @@ type Repository struct {
 	SourceMigrationID   *int64  `json:"source_migration_id,omitempty"`
 	IsSourceLocked      bool    `json:"is_source_locked" gorm:"default:false"`
 
+	// Delta sync pre-seed (set by a delta sync batch dry run, cleared at cutover)
+	PreSeededAt      *time.Time `json:"pre_seeded_at,omitempty"`
+	PreSeedCommitSHA *string    `json:"pre_seed_commit_sha,omitempty"`
+
 	// Migration exclusions (batch-level overrides)
 	ExcludeReleases      bool `json:"exclude_releases" gorm:"default:false"`
 	ExcludeAttachments   bool `json:"exclude_attachments" gorm:"default:false"`
@@ -102,6 +112,11 @@ func (r *Repository) HasLFS() bool {
 	return r.GitProperties != nil && r.GitProperties.HasLFS
 }
 
+// IsPreSeeded returns true if the repository has an unlocked pre-seed awaiting cutover
+func (r *Repository) IsPreSeeded() bool {
+	return r.PreSeededAt != nil && r.DestinationFullName != nil && *r.DestinationFullName != ""
+}
+
 // HasSubmodules returns true if the repository has submodules
 func (r *Repository) HasSubmodules() bool {
 	return r.GitProperties != nil && r.GitProperties.HasSubmodules
@@ -407,6 +422,7 @@ const (
 	SourceGHEC        = "ghec"
 	SourceGitLab      = "gitlab"
 	SourceAzureDevOps = "azuredevops"
+	SourceBitbucket   = "bitbucket"
 )
 
 // RepositoryOptions provides options for creating a new repository
@@ -705,6 +721,23 @@ func (r *Repository) flattenValidation(result map[string]any) {
 			result["complexity_breakdown"] = breakdown
 		}
 	}
+	if v.ValidationStatus != nil {
+		result["validation_status"] = *v.ValidationStatus
+	}
+	// Parse deep validation report JSON string into object
+	if v.DeepValidationReport != nil && *v.DeepValidationReport != "" {
+		var report DeepValidationReport
+		if err := json.Unmarshal([]byte(*v.DeepValidationReport), &report); err == nil {
+			result["deep_validation_report"] = report
+		}
+	}
+	// Parse settings sync report JSON string into object
+	if v.SettingsSyncReport != nil && *v.SettingsSyncReport != "" {
+		var report SettingsSyncReport
+		if err := json.Unmarshal([]byte(*v.SettingsSyncReport), &report); err == nil {
+			result["settings_sync_report"] = report
+		}
+	}
 }
 
 // MarshalJSON implements custom JSON marshaling to flatten related table data for API compatibility
@@ -860,8 +893,91 @@ type ComplexityBreakdown struct {
 	ADOServiceHookPoints       int `json:"ado_service_hook_points"`       // 1 point - recreate webhooks
 	ADOManyPRsPoints           int `json:"ado_many_prs_points"`           // 2 points - metadata migration time
 	ADOBranchPolicyPoints      int `json:"ado_branch_policy_points"`      // 1 point - need validation/recreation
+
+	// GitLab specific complexity factors
+	GitLabCIPoints         int `json:"gitlab_ci_points"`          // 3 points - pipelines must be rewritten as GitHub Actions
+	GitLabWikiPoints       int `json:"gitlab_wiki_points"`        // 2 points - wiki is not mirrored with git data
+	GitLabManyMRsPoints    int `json:"gitlab_many_mrs_points"`    // 2 points - merge request history does not migrate
+	GitLabMergeRulesPoints int `json:"gitlab_merge_rules_points"` // 1 point - pipeline/discussion merge gates need rulesets
+
+	// Bitbucket specific complexity factors
+	BitbucketPipelinesPoints         int `json:"bitbucket_pipelines_points"`          // 3 points - pipelines must be rewritten as GitHub Actions
+	BitbucketManyPRsPoints           int `json:"bitbucket_many_prs_points"`           // 2 points - metadata migration time
+	BitbucketBranchPermissionsPoints int `json:"bitbucket_branch_permissions_points"` // 1 point - permissions must be recreated as rulesets
+	BitbucketWebhooksPoints          int `json:"bitbucket_webhooks_points"`           // 1 point - webhooks must be recreated
 }
 
+// Ref diff statuses reported by deep post-migration validation
+const (
+	RefDiffMissing  = "missing_in_destination" // Ref exists on the source but not the destination
+	RefDiffExtra    = "extra_in_destination"   // Ref exists on the destination but not the source
+	RefDiffMismatch = "sha_mismatch"           // Ref points at a different object on each side
+)
+
+// DeepValidationReport is the result of deep post-migration validation.
+// Every branch and tag is compared by SHA; only refs that differ are listed.
+type DeepValidationReport struct {
+	Passed      bool                      `json:"passed"`
+	CheckedAt   time.Time                 `json:"checked_at"`
+	SourceRefs  int                       `json:"source_refs"`      // Branches and tags on the source
+	DestRefs    int                       `json:"dest_refs"`        // Branches and tags on the destination
+	RefsMatched int                       `json:"refs_matched"`     // Refs with the same SHA on both sides
+	RefDiffs    []RefDiff                 `json:"ref_diffs"`        // Refs that are missing, extra or point elsewhere
+	Counts      []ArtifactCountComparison `json:"counts"`           // Issue, pull request, release and release asset counts
+	LFS         *LFSValidation            `json:"lfs,omitempty"`    // LFS object presence (repositories using LFS only)
+	Errors      []string                  `json:"errors,omitempty"` // Checks that could not run
+}
+
+// RefDiff describes a branch or tag that differs between source and destination
+type RefDiff struct {
+	Ref       string `json:"ref"`
+	Status    string `json:"status"`
+	SourceSHA string `json:"source_sha,omitempty"`
+	DestSHA   string `json:"dest_sha,omitempty"`
+}
+
+// ArtifactCountComparison compares the number of an artifact type on source and destination
+type ArtifactCountComparison struct {
+	Field       string `json:"field"`
+	Source      int    `json:"source"`
+	Destination int    `json:"destination"`
+	Match       bool   `json:"match"`
+	Skipped     string `json:"skipped,omitempty"` // Why the count was not compared (e.g. releases excluded)
+}
+
+// LFSValidation reports whether the LFS objects referenced by the destination's history exist in its LFS storage
+type LFSValidation struct {
+	Objects     int      `json:"objects"`
+	Missing     int      `json:"missing"`
+	MissingOIDs []string `json:"missing_oids,omitempty"` // First missing object IDs, capped to keep the report small
+}
+
+// SettingsSyncReport is the result of syncing repository settings GEI does not migrate
+// (topics, merge settings, custom properties, ...) from the source to the destination.
+// Only the settings in the configured allow list are synced and listed.
+type SettingsSyncReport struct {
+	SyncedAt time.Time           `json:"synced_at"`
+	Settings []SettingsFieldSync `json:"settings"`
+	Errors   []string            `json:"errors,omitempty"` // Why the settings could not be read
+}
+
+// SettingsFieldSync compares one setting on the source and destination and records how it was synced
+type SettingsFieldSync struct {
+	Field       string `json:"field"`
+	Source      any    `json:"source"`
+	Destination any    `json:"destination"` // Destination value before the sync
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Settings sync status constants
+const (
+	SettingsSyncApplied   = "applied"   // The destination was updated to the source value
+	SettingsSyncUnchanged = "unchanged" // The destination already had the source value
+	SettingsSyncSkipped   = "skipped"   // The setting could not be read from the source
+	SettingsSyncFailed    = "failed"    // The destination rejected the update
+)
+
 // MigrationStatus represents the status of a repository migration
 type MigrationStatus string
 
@@ -895,6 +1011,39 @@ type MigrationHistory struct {
 	StartedAt       time.Time  `json:"started_at" gorm:"column:started_at;not null"`
 	CompletedAt     *time.Time `json:"completed_at,omitempty" gorm:"column:completed_at"`
 	DurationSeconds *int       `json:"duration_seconds,omitempty" gorm:"column:duration_seconds"`
+
+	// Phase checkpoint - the last completed phase and the remote state needed to resume from it
+	Checkpoint         *string    `json:"checkpoint,omitempty" gorm:"column:checkpoint"`
+	CheckpointAt       *time.Time `json:"checkpoint_at,omitempty" gorm:"column:checkpoint_at"`
+	GitArchiveID       *int64     `json:"git_archive_id,omitempty" gorm:"column:git_archive_id"`
+	MetadataArchiveID  *int64     `json:"metadata_archive_id,omitempty" gorm:"column:metadata_archive_id"`
+	GitArchiveURL      *string    `json:"-" gorm:"column:git_archive_url;type:text"`      // Pre-signed, never exposed via the API
+	MetadataArchiveURL *string    `json:"-" gorm:"column:metadata_archive_url;type:text"` // Pre-signed, never exposed via the API
+	MigrationID        *string    `json:"migration_id,omitempty" gorm:"column:migration_id"`
+}
+
+// Migration checkpoints, in phase order. Each one records that the phase has completed,
+// so a resumed migration continues with the phase that follows it.
+const (
+	CheckpointPreMigration       = "pre_migration"       // Validation passed, nothing started remotely
+	CheckpointArchivesGenerating = "archives_generating" // Archive generation started on the source (archive IDs known)
+	CheckpointArchivesReady      = "archives_ready"      // Archives generated (archive URLs known)
+	CheckpointMigrationStarted   = "migration_started"   // Migration started on the destination (migration ID known)
+	CheckpointMigrationFinished  = "migration_finished"  // Migration finished on the destination
+)
+
+// IsResumable returns true if remote work was started that a restarted worker can pick up
+// rather than starting the migration over.
+func (h *MigrationHistory) IsResumable() bool {
+	if h == nil || h.Checkpoint == nil {
+		return false
+	}
+	switch *h.Checkpoint {
+	case CheckpointArchivesGenerating, CheckpointArchivesReady, CheckpointMigrationStarted, CheckpointMigrationFinished:
+		return true
+	default:
+		return false
+	}
 }
 
 // TableName specifies the table name for MigrationHistory model
@@ -921,6 +1070,38 @@ func (MigrationLog) TableName() string {
 	return "migration_logs"
 }
 
+// MigrationLease records which server replica owns a repository's migration.
+// The owner extends the lease with heartbeats while the migration runs; another
+// replica may claim the repository once the lease has expired.
+type MigrationLease struct {
+	RepositoryID int64     `json:"repository_id" gorm:"primaryKey;column:repository_id"`
+	OwnerID      string    `json:"owner_id" gorm:"column:owner_id;not null;index"`
+	AcquiredAt   time.Time `json:"acquired_at" gorm:"column:acquired_at;not null"`
+	HeartbeatAt  time.Time `json:"heartbeat_at" gorm:"column:heartbeat_at;not null"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"column:expires_at;not null;index"`
+}
+
+// TableName specifies the table name for MigrationLease model
+func (MigrationLease) TableName() string {
+	return "migration_leases"
+}
+
+// LeaderLock records which server replica runs a singleton background loop.
+// FencingToken increases every time the lock changes owner.
+type LeaderLock struct {
+	Name         string    `json:"name" gorm:"primaryKey;column:name"`
+	OwnerID      string    `json:"owner_id" gorm:"column:owner_id;not null"`
+	FencingToken int64     `json:"fencing_token" gorm:"column:fencing_token;not null;default:1"`
+	AcquiredAt   time.Time `json:"acquired_at" gorm:"column:acquired_at;not null"`
+	RenewedAt    time.Time `json:"renewed_at" gorm:"column:renewed_at;not null"`
+	ExpiresAt    time.Time `json:"expires_at" gorm:"column:expires_at;not null"`
+}
+
+// TableName specifies the table name for LeaderLock model
+func (LeaderLock) TableName() string {
+	return "leader_locks"
+}
+
 // Batch represents a group of repositories to be migrated together
 type Batch struct {
 	ID                     int64      `json:"id" gorm:"primaryKey;autoIncrement"`
@@ -943,9 +1124,23 @@ type Batch struct {
 
 	// Migration Settings (batch-level defaults, repository settings take precedence)
 	DestinationOrg     *string `json:"destination_org,omitempty" gorm:"column:destination_org"`             // Default destination org for repositories in this batch
-	MigrationAPI       string  `json:"migration_api" gorm:"column:migration_api;not null"`                  // Migration API to use: "GEI" or "ELM" (default: "GEI")
+	MigrationAPI       string  `json:"migration_api" gorm:"column:migration_api;not null"`                  // Migration API to use: "GEI", "ELM" or "GIT" (default: "GEI")
 	ExcludeReleases    bool    `json:"exclude_releases" gorm:"column:exclude_releases;default:false"`       // Skip releases during migration (applies if repo doesn't override)
 	ExcludeAttachments bool    `json:"exclude_attachments" gorm:"column:exclude_attachments;default:false"` // Skip attachments during migration (applies if repo doesn't override)
+
+	// Delta sync: the dry run pre-seeds each repository unlocked, and the scheduled
+	// migration locks the source and re-syncs only the git refs that changed since
+	DeltaSync bool `json:"delta_sync" gorm:"column:delta_sync;default:false"`
+
+	// Completion actions run on the source after a successful production migration, so developers
+	// stop pushing to it: readme_notice, description and archive (comma-separated, empty for none)
+	CompletionActions *string `json:"completion_actions,omitempty" gorm:"column:completion_actions;type:text"`
+
+	// Dependency: the scheduler starts the batch only after the batch it depends on completes its
+	// production migration with at most MaxDependencyFailures failed repositories (nil for no limit),
+	// and no earlier than ScheduledAt when that is set
+	DependsOnBatchID      *int64 `json:"depends_on_batch_id,omitempty" gorm:"column:depends_on_batch_id;index"`
+	MaxDependencyFailures *int   `json:"max_dependency_failures,omitempty" gorm:"column:max_dependency_failures"`
 }
 
 // TableName specifies the table name for Batch model
@@ -953,6 +1148,31 @@ func (Batch) TableName() string {
 	return "batches"
 }
 
+// UsesGitMirror returns true if the batch migrates git history only by mirror push.
+// Repositories that need remediation for GEI limits can still be migrated this way.
+func (b *Batch) UsesGitMirror() bool {
+	return b != nil && b.MigrationAPI == MigrationAPIGit
+}
+
+// UsesDeltaSync returns true if the batch pre-seeds repositories and re-syncs changed refs at cutover
+func (b *Batch) UsesDeltaSync() bool {
+	return b != nil && b.DeltaSync
+}
+
+// CompletionActionList returns the batch's completion actions, or nil when none are configured
+func (b *Batch) CompletionActionList() []string {
+	if b == nil || b.CompletionActions == nil {
+		return nil
+	}
+	var actions []string
+	for _, action := range strings.Split(*b.CompletionActions, ",") {
+		if action = strings.TrimSpace(action); action != "" {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
 // Duration calculates the batch execution duration if both StartedAt and CompletedAt are set
 func (b *Batch) Duration() *time.Duration {
 	if b.StartedAt == nil || b.CompletedAt == nil {
@@ -1006,6 +1226,234 @@ const (
 	DependencyTypePackage         = "package"
 )
 
+// RepositorySecret is an entry in a repository's "secrets to re-enter" checklist.
+// Secret values cannot be read from the source, so every Actions secret recreated on the
+// destination is a named placeholder whose real value has to be entered by hand.
+type RepositorySecret struct {
+	ID                 int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	RepositoryID       int64     `json:"repository_id" gorm:"column:repository_id;not null;index"`
+	Environment        string    `json:"environment" gorm:"column:environment;not null;default:''"` // Empty for repository-level secrets
+	Name               string    `json:"name" gorm:"column:name;not null"`
+	PlaceholderCreated bool      `json:"placeholder_created" gorm:"column:placeholder_created;default:false"` // False when the secret already existed on the destination or creation failed
+	RecordedAt         time.Time `json:"recorded_at" gorm:"column:recorded_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for RepositorySecret model
+func (RepositorySecret) TableName() string {
+	return "repository_secrets"
+}
+
+// RepositoryWebhook tracks a source webhook recreated on the destination repository.
+// Hook secrets cannot be read back from the source, so hooks are created inactive and
+// only turned on once their owners have re-entered the secret on the destination.
+type RepositoryWebhook struct {
+	ID                int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	RepositoryID      int64      `json:"repository_id" gorm:"column:repository_id;not null;index"`
+	SourceHookID      int64      `json:"source_hook_id" gorm:"column:source_hook_id;not null"`
+	DestinationHookID *int64     `json:"destination_hook_id,omitempty" gorm:"column:destination_hook_id"` // Nil when creation failed
+	URL               string     `json:"url" gorm:"column:url;not null"`
+	Events            string     `json:"events" gorm:"column:events;type:text"` // Comma-separated event names
+	ContentType       string     `json:"content_type" gorm:"column:content_type"`
+	InsecureSSL       bool       `json:"insecure_ssl" gorm:"column:insecure_ssl;default:false"`
+	HasSecret         bool       `json:"has_secret" gorm:"column:has_secret;default:false"`
+	Status            string     `json:"status" gorm:"column:status;not null;index"` // pending, active, disabled, failed
+	Error             *string    `json:"error,omitempty" gorm:"column:error;type:text"`
+	RecordedAt        time.Time  `json:"recorded_at" gorm:"column:recorded_at;not null;autoCreateTime"`
+	ActivatedAt       *time.Time `json:"activated_at,omitempty" gorm:"column:activated_at"`
+}
+
+// TableName specifies the table name for RepositoryWebhook model
+func (RepositoryWebhook) TableName() string {
+	return "repository_webhooks"
+}
+
+// Webhook status constants
+const (
+	WebhookStatusPending  = "pending"  // Created inactive, waiting for its secret and activation
+	WebhookStatusActive   = "active"   // Delivering on the destination
+	WebhookStatusDisabled = "disabled" // Inactive on the source too, so it was left inactive
+	WebhookStatusFailed   = "failed"   // Could not be created on the destination
+)
+
+// ReferencePullRequest records the pull request opened on a migrated repository to rewrite
+// its cross-repo references (submodules, workflow uses: and manifest git URLs) to the
+// destination. There is at most one per repository; reopening replaces it.
+type ReferencePullRequest struct {
+	ID                  int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	RepositoryID        int64     `json:"repository_id" gorm:"column:repository_id;not null;uniqueIndex"`
+	Status              string    `json:"status" gorm:"column:status;not null"` // open, no_changes, failed
+	Number              *int      `json:"number,omitempty" gorm:"column:number"`
+	URL                 *string   `json:"url,omitempty" gorm:"column:url"`
+	Branch              string    `json:"branch" gorm:"column:branch"`
+	FilesChanged        int       `json:"files_changed" gorm:"column:files_changed;default:0"`
+	ReferencesRewritten int       `json:"references_rewritten" gorm:"column:references_rewritten;default:0"`
+	Unresolved          *string   `json:"unresolved,omitempty" gorm:"column:unresolved;type:text"` // Comma-separated dependencies not migrated yet
+	Error               *string   `json:"error,omitempty" gorm:"column:error;type:text"`
+	CreatedAt           time.Time `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for ReferencePullRequest model
+func (ReferencePullRequest) TableName() string {
+	return "repository_reference_prs"
+}
+
+// Reference pull request status constants
+const (
+	ReferencePRStatusOpen      = "open"       // Pull request opened on the destination
+	ReferencePRStatusNoChanges = "no_changes" // Nothing referenced a migrated repository
+	ReferencePRStatusFailed    = "failed"     // Files could not be rewritten or the pull request could not be opened
+)
+
+// CodeownersRewrite records the rewrite of a migrated repository's CODEOWNERS file, whose
+// source team and user handles are replaced with their mapped destination handles.
+// There is at most one per repository; rewriting again replaces it.
+type CodeownersRewrite struct {
+	ID                int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	RepositoryID      int64     `json:"repository_id" gorm:"column:repository_id;not null;uniqueIndex"`
+	Mode              string    `json:"mode" gorm:"column:mode;not null"`     // commit, pull_request
+	Status            string    `json:"status" gorm:"column:status;not null"` // committed, pull_request, no_changes, not_found, failed
+	Path              string    `json:"path" gorm:"column:path"`              // Location of the CODEOWNERS file
+	HandlesRewritten  int       `json:"handles_rewritten" gorm:"column:handles_rewritten;default:0"`
+	Unmapped          *string   `json:"unmapped,omitempty" gorm:"column:unmapped;type:text"` // Comma-separated handles without a mapping
+	PullRequestNumber *int      `json:"pull_request_number,omitempty" gorm:"column:pull_request_number"`
+	PullRequestURL    *string   `json:"pull_request_url,omitempty" gorm:"column:pull_request_url"`
+	Error             *string   `json:"error,omitempty" gorm:"column:error;type:text"`
+	CreatedAt         time.Time `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for CodeownersRewrite model
+func (CodeownersRewrite) TableName() string {
+	return "repository_codeowners_rewrites"
+}
+
+// CODEOWNERS rewrite status constants
+const (
+	CodeownersStatusCommitted   = "committed"    // Rewritten file committed to the default branch
+	CodeownersStatusPullRequest = "pull_request" // Pull request opened with the rewritten file
+	CodeownersStatusNoChanges   = "no_changes"   // No handle had a different destination mapping
+	CodeownersStatusNotFound    = "not_found"    // The repository has no CODEOWNERS file
+	CodeownersStatusFailed      = "failed"       // The file could not be committed or the pull request opened
+)
+
+// CollaboratorGrant records the re-grant of a source collaborator's direct repository permission
+// on the destination through their user mapping. Together the grants of a repository form an
+// audit log of who was given access after migration; granting again replaces them.
+type CollaboratorGrant struct {
+	ID               int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	RepositoryID     int64     `json:"repository_id" gorm:"column:repository_id;not null;index"`
+	SourceLogin      string    `json:"source_login" gorm:"column:source_login;not null"`
+	DestinationLogin *string   `json:"destination_login,omitempty" gorm:"column:destination_login"` // Nil when the user has no mapping
+	Permission       string    `json:"permission" gorm:"column:permission;not null"`                // read, triage, write, maintain, admin or a custom role
+	Outside          bool      `json:"outside" gorm:"column:outside;default:false"`                 // Outside collaborator on the source
+	Status           string    `json:"status" gorm:"column:status;not null;index"`                  // granted, invited, unmapped, skipped, failed
+	Reason           *string   `json:"reason,omitempty" gorm:"column:reason;type:text"`
+	CreatedAt        time.Time `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for CollaboratorGrant model
+func (CollaboratorGrant) TableName() string {
+	return "repository_collaborator_grants"
+}
+
+// Collaborator grant status constants
+const (
+	CollaboratorGrantStatusGranted  = "granted"  // Permission granted to an existing organization member
+	CollaboratorGrantStatusInvited  = "invited"  // Invitation sent; access starts once it is accepted
+	CollaboratorGrantStatusUnmapped = "unmapped" // The source user has no destination user mapping
+	CollaboratorGrantStatusSkipped  = "skipped"  // Outside collaborators are not allowed or not being granted
+	CollaboratorGrantStatusFailed   = "failed"   // The destination rejected the grant
+)
+
+// CompletionAction records an action run on the source repository after a successful production
+// migration, along with the state needed to revert it on rollback
+type CompletionAction struct {
+	ID            int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	RepositoryID  int64      `json:"repository_id" gorm:"column:repository_id;not null;index"`
+	Action        string     `json:"action" gorm:"column:action;not null"`            // readme_notice, description or archive
+	Status        string     `json:"status" gorm:"column:status;not null;index"`      // applied, skipped, failed, reverted
+	OriginalState *string    `json:"-" gorm:"column:original_state;type:text"`        // JSON-encoded source state restored on rollback
+	Reason        *string    `json:"reason,omitempty" gorm:"column:reason;type:text"` // Why the action was skipped or failed
+	RevertedAt    *time.Time `json:"reverted_at,omitempty" gorm:"column:reverted_at"` // When rollback restored the source
+	CreatedAt     time.Time  `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for CompletionAction model
+func (CompletionAction) TableName() string {
+	return "repository_completion_actions"
+}
+
+// Completion action status constants
+const (
+	CompletionActionStatusApplied  = "applied"  // The source was changed; rollback reverts it
+	CompletionActionStatusSkipped  = "skipped"  // The action does not apply to the source
+	CompletionActionStatusFailed   = "failed"   // The source rejected the change
+	CompletionActionStatusReverted = "reverted" // Rollback restored the source
+)
+
+// MigrationWindow is a period in which production migrations are either forbidden (a blackout,
+// such as a release freeze or holiday) or allowed. A window is either a one-off period between
+// StartsAt and EndsAt, or recurs weekly on Weekdays between StartTime and EndTime in TimeZone.
+type MigrationWindow struct {
+	ID           int64      `json:"id" gorm:"primaryKey;autoIncrement"`
+	Name         string     `json:"name" gorm:"column:name;not null"`
+	Kind         string     `json:"kind" gorm:"column:kind;not null;index"`                   // blackout or allowed
+	Organization *string    `json:"organization,omitempty" gorm:"column:organization;index"`  // Source organization; nil applies to all
+	TimeZone     string     `json:"time_zone" gorm:"column:time_zone;not null;default:'UTC'"` // IANA time zone of the recurring times
+	StartsAt     *time.Time `json:"starts_at,omitempty" gorm:"column:starts_at"`              // One-off window start
+	EndsAt       *time.Time `json:"ends_at,omitempty" gorm:"column:ends_at"`                  // One-off window end (exclusive)
+	Weekdays     *string    `json:"weekdays,omitempty" gorm:"column:weekdays"`                // Recurring days, comma-separated: mon,tue,...
+	StartTime    *string    `json:"start_time,omitempty" gorm:"column:start_time"`            // Recurring start, HH:MM
+	EndTime      *string    `json:"end_time,omitempty" gorm:"column:end_time"`                // Recurring end, HH:MM; at or before StartTime ends the next day
+	Policy       string     `json:"policy" gorm:"column:policy;not null;default:'defer'"`     // Blackouts: defer or refuse scheduled batches
+	Description  *string    `json:"description,omitempty" gorm:"column:description;type:text"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+	UpdatedAt    time.Time  `json:"updated_at" gorm:"column:updated_at;not null;autoUpdateTime"`
+}
+
+// TableName specifies the table name for MigrationWindow model
+func (MigrationWindow) TableName() string {
+	return "migration_windows"
+}
+
+// Migration window kinds
+const (
+	MigrationWindowKindBlackout = "blackout" // Production migrations must not run
+	MigrationWindowKindAllowed  = "allowed"  // Production migrations may only run inside allowed windows, when any apply
+)
+
+// Migration window policies for scheduled batches that would start inside a blackout
+const (
+	MigrationWindowPolicyDefer  = "defer"  // Move the batch to the next time migrations are allowed
+	MigrationWindowPolicyRefuse = "refuse" // Reject the schedule
+)
+
+// BatchApproval records a step of the two-person approval a batch's production migration needs
+// when migration.require_batch_approval is enabled. Together the steps of a batch form its approval
+// audit log; the latest step is its approval state.
+type BatchApproval struct {
+	ID            int64           `json:"id" gorm:"primaryKey;autoIncrement"`
+	BatchID       int64           `json:"batch_id" gorm:"column:batch_id;not null;index"`
+	Action        string          `json:"action" gorm:"column:action;not null"`                               // requested, approved, rejected, started
+	Actor         string          `json:"actor" gorm:"column:actor;not null"`                                 // Login of the user, or "scheduler"
+	Comment       *string         `json:"comment,omitempty" gorm:"column:comment;type:text"`                  // Requester's note or reviewer's reason
+	DryRunSummary json.RawMessage `json:"dry_run_summary,omitempty" gorm:"column:dry_run_summary;type:jsonb"` // Attached to requests
+	UsedAt        *time.Time      `json:"used_at,omitempty" gorm:"column:used_at"`                            // When a start used up an approval
+	CreatedAt     time.Time       `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for BatchApproval model
+func (BatchApproval) TableName() string {
+	return "batch_approvals"
+}
+
+// Batch approval actions
+const (
+	BatchApprovalRequested = "requested" // Submitted for approval with a dry run summary
+	BatchApprovalApproved  = "approved"  // Approved by someone other than the requester; the batch may start
+	BatchApprovalRejected  = "rejected"  // Rejected; the batch needs a new request
+	BatchApprovalStarted   = "started"   // The production migration started, using up the approval
+)
+
 // GitHubTeam represents a GitHub team for filtering repositories by team membership
 // Teams are org-scoped, so the same team name can exist in different organizations
 type GitHubTeam struct {
@@ -1016,6 +1464,7 @@ type GitHubTeam struct {
 	Name         string    `json:"name" gorm:"column:name;not null"`
 	Description  *string   `json:"description,omitempty" gorm:"column:description;type:text"`
 	Privacy      string    `json:"privacy" gorm:"column:privacy;not null;default:closed"`
+	ParentSlug   *string   `json:"parent_slug,omitempty" gorm:"column:parent_slug"` // Parent team in the same organization; nil for top-level teams
 	DiscoveredAt time.Time `json:"discovered_at" gorm:"column:discovered_at;not null;autoCreateTime"`
 	UpdatedAt    time.Time `json:"updated_at" gorm:"column:updated_at;not null;autoUpdateTime"`
 }
@@ -1232,6 +1681,37 @@ func (t *TeamMapping) DestinationFullSlug() string {
 	return *t.DestinationOrg + "/" + *t.DestinationTeamSlug
 }
 
+// TeamMembershipSync records the outcome of adding a source team's members to its destination team.
+// Members are added through their user mappings; members without a mapping are listed so they can
+// be mapped and synced again.
+type TeamMembershipSync struct {
+	ID                  int64     `json:"id" gorm:"primaryKey;autoIncrement"`
+	SourceID            *int64    `json:"source_id,omitempty" gorm:"column:source_id;index"`
+	SourceOrg           string    `json:"source_org" gorm:"column:source_org;not null;uniqueIndex:idx_team_membership_sync_source"`
+	SourceTeamSlug      string    `json:"source_team_slug" gorm:"column:source_team_slug;not null;uniqueIndex:idx_team_membership_sync_source"`
+	DestinationOrg      string    `json:"destination_org" gorm:"column:destination_org;not null"`
+	DestinationTeamSlug string    `json:"destination_team_slug" gorm:"column:destination_team_slug;not null"`
+	Status              string    `json:"status" gorm:"column:status;not null;index"`      // synced, skipped, failed
+	Reason              *string   `json:"reason,omitempty" gorm:"column:reason;type:text"` // Why the team was skipped or failed
+	MembersAdded        int       `json:"members_added" gorm:"column:members_added;default:0"`
+	MaintainersAdded    int       `json:"maintainers_added" gorm:"column:maintainers_added;default:0"`
+	Unmapped            *string   `json:"unmapped,omitempty" gorm:"column:unmapped;type:text"` // Comma-separated source logins without a user mapping
+	Failed              *string   `json:"failed,omitempty" gorm:"column:failed;type:text"`     // Comma-separated destination logins that could not be added
+	CreatedAt           time.Time `json:"created_at" gorm:"column:created_at;not null;autoCreateTime"`
+}
+
+// TableName specifies the table name for TeamMembershipSync model
+func (TeamMembershipSync) TableName() string {
+	return "team_membership_syncs"
+}
+
+// Team membership sync status constants
+const (
+	TeamMembershipStatusSynced  = "synced"  // Mapped members were added to the destination team
+	TeamMembershipStatusSkipped = "skipped" // Membership is managed by an identity provider on the destination
+	TeamMembershipStatusFailed  = "failed"  // The destination team could not be inspected
+)
+
 // Discovery progress phase constants
 const (
 	PhaseListingRepos        = "listing_repos"
@@ -1259,6 +1739,10 @@ const (
 	// ADO discovery types
 	DiscoveryTypeADOOrganization = "ado_organization"
 	DiscoveryTypeADOProject      = "ado_project"
+	// GitLab discovery types
+	DiscoveryTypeGitLabGroup = "gitlab_group"
+	// Bitbucket discovery types
+	DiscoveryTypeBitbucketProject = "bitbucket_project"
 )
 
 // DiscoveryProgress tracks the progress of a discovery operation
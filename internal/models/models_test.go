@@ -345,6 +345,37 @@ func TestRepository_MarshalJSON(t *testing.T) {
 			t.Errorf("Expected size_points=5, got %v", breakdown["size_points"])
 		}
 	})
+
+	t.Run("marshal with deep validation report", func(t *testing.T) {
+		status := "failed"
+		reportStr := `{"passed":false,"source_refs":2,"dest_refs":2,"refs_matched":1,"ref_diffs":[{"ref":"refs/heads/main","status":"sha_mismatch","source_sha":"aaa","dest_sha":"bbb"}],"counts":[]}`
+		repo := &Repository{
+			FullName:   "org/repo",
+			Source:     "ghes",
+			Status:     "complete",
+			Validation: &RepositoryValidation{ValidationStatus: &status, DeepValidationReport: &reportStr},
+		}
+		data, err := json.Marshal(repo)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var result struct {
+			ValidationStatus     string               `json:"validation_status"`
+			DeepValidationReport DeepValidationReport `json:"deep_validation_report"`
+		}
+		if err := json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Failed to unmarshal result: %v", err)
+		}
+
+		if result.ValidationStatus != "failed" {
+			t.Errorf("Expected validation_status=failed, got %q", result.ValidationStatus)
+		}
+		report := result.DeepValidationReport
+		if report.RefsMatched != 1 || len(report.RefDiffs) != 1 || report.RefDiffs[0].Status != RefDiffMismatch {
+			t.Errorf("Expected deep_validation_report to be flattened as an object, got %+v", report)
+		}
+	})
 }
 
 // TestMigrationStatus_Constants tests that status constants are correct
@@ -590,3 +621,30 @@ func TestMigrationLog_Structure(t *testing.T) {
 		t.Error("Expected InitiatedBy to be 'user@example.com'")
 	}
 }
+
+func TestMigrationHistory_IsResumable(t *testing.T) {
+	checkpoint := func(c string) *string { return &c }
+
+	tests := []struct {
+		name    string
+		history *MigrationHistory
+		want    bool
+	}{
+		{"nil history", nil, false},
+		{"no checkpoint", &MigrationHistory{}, false},
+		{"pre-migration", &MigrationHistory{Checkpoint: checkpoint(CheckpointPreMigration)}, false},
+		{"archives generating", &MigrationHistory{Checkpoint: checkpoint(CheckpointArchivesGenerating)}, true},
+		{"archives ready", &MigrationHistory{Checkpoint: checkpoint(CheckpointArchivesReady)}, true},
+		{"migration started", &MigrationHistory{Checkpoint: checkpoint(CheckpointMigrationStarted)}, true},
+		{"migration finished", &MigrationHistory{Checkpoint: checkpoint(CheckpointMigrationFinished)}, true},
+		{"unknown", &MigrationHistory{Checkpoint: checkpoint("bogus")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.history.IsResumable(); got != tt.want {
+				t.Errorf("IsResumable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
 
 // This file provides backward compatibility methods for code that needs to
 // directly set properties on Repository that are now in related tables.
@@ -287,6 +291,33 @@ func (r *Repository) SetOpenPRCount(count int) {
 	r.EnsureFeatures().OpenPRCount = count
 }
 
+// SetHasGitLabCI sets the has_gitlab_ci flag in features
+func (r *Repository) SetHasGitLabCI(value bool) {
+	r.EnsureFeatures().HasGitLabCI = value
+}
+
+// SetHasBitbucketPipelines sets the has_bitbucket_pipelines flag in features
+func (r *Repository) SetHasBitbucketPipelines(value bool) {
+	r.EnsureFeatures().HasBitbucketPipelines = value
+}
+
+// SetMergeSettings serializes the merge settings to JSON and stores them in features
+func (r *Repository) SetMergeSettings(settings *MergeSettings) error {
+	if settings == nil {
+		r.EnsureFeatures().MergeSettings = nil
+		return nil
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return fmt.Errorf("failed to marshal merge settings: %w", err)
+	}
+
+	jsonStr := string(data)
+	r.EnsureFeatures().MergeSettings = &jsonStr
+	return nil
+}
+
 // Setter methods for ADO properties
 
 // SetADOProject sets the project in ADO properties
@@ -466,6 +497,16 @@ func (r *Repository) SetDestinationData(data *string) {
 	r.EnsureValidation().DestinationData = data
 }
 
+// SetDeepValidationReport sets the deep validation report in validation
+func (r *Repository) SetDeepValidationReport(report *string) {
+	r.EnsureValidation().DeepValidationReport = report
+}
+
+// SetSettingsSyncReport sets the settings sync report in validation
+func (r *Repository) SetSettingsSyncReport(report *string) {
+	r.EnsureValidation().SettingsSyncReport = report
+}
+
 // SetHasOversizedCommits sets the has_oversized_commits flag in validation
 func (r *Repository) SetHasOversizedCommits(value bool) {
 	r.EnsureValidation().HasOversizedCommits = value
@@ -762,6 +803,30 @@ func (r *Repository) GetOpenPRCount() int {
 	return 0
 }
 
+// HasGitLabCI returns true if the repository has a GitLab CI configuration
+func (r *Repository) HasGitLabCI() bool {
+	return r.Features != nil && r.Features.HasGitLabCI
+}
+
+// HasBitbucketPipelines returns true if the repository has a Bitbucket Pipelines configuration
+func (r *Repository) HasBitbucketPipelines() bool {
+	return r.Features != nil && r.Features.HasBitbucketPipelines
+}
+
+// GetMergeSettings deserializes the JSON merge settings from features
+func (r *Repository) GetMergeSettings() (*MergeSettings, error) {
+	if r.Features == nil || r.Features.MergeSettings == nil || *r.Features.MergeSettings == "" {
+		return nil, nil
+	}
+
+	var settings MergeSettings
+	if err := json.Unmarshal([]byte(*r.Features.MergeSettings), &settings); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal merge settings: %w", err)
+	}
+
+	return &settings, nil
+}
+
 // ADO property getters
 
 // GetADOPullRequestCount returns the pull request count from ADO properties
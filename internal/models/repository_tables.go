@@ -65,11 +65,28 @@ type RepositoryFeatures struct {
 	TagCount             int     `json:"tag_count" gorm:"default:0"`
 	OpenIssueCount       int     `json:"open_issue_count" gorm:"default:0"`
 	OpenPRCount          int     `json:"open_pr_count" gorm:"default:0"`
+
+	// GitLab-specific features (only populated for GitLab repos)
+	HasGitLabCI   bool    `json:"has_gitlab_ci" gorm:"column:has_gitlab_ci;default:false"`
+	MergeSettings *string `json:"merge_settings,omitempty" gorm:"type:text"` // JSON-encoded MergeSettings
+
+	// Bitbucket-specific features (only populated for Bitbucket repos)
+	HasBitbucketPipelines bool `json:"has_bitbucket_pipelines" gorm:"default:false"`
 }
 
 // TableName specifies the table name for RepositoryFeatures
 func (RepositoryFeatures) TableName() string { return "repository_features" }
 
+// MergeSettings captures source merge request settings so they can be recreated
+// on the destination repository. Stored as JSON in RepositoryFeatures.MergeSettings.
+type MergeSettings struct {
+	MergeMethod                 string `json:"merge_method,omitempty"`  // merge, rebase_merge, or ff
+	SquashOption                string `json:"squash_option,omitempty"` // never, always, default_on, or default_off
+	DeleteBranchOnMerge         bool   `json:"delete_branch_on_merge"`
+	RequirePipelineSuccess      bool   `json:"require_pipeline_success"`
+	RequireDiscussionResolution bool   `json:"require_discussion_resolution"`
+}
+
 // RepositoryADOProperties stores Azure DevOps properties (1:1, only for ADO repos)
 type RepositoryADOProperties struct {
 	RepositoryID            int64   `json:"repository_id" gorm:"primaryKey"`
@@ -116,6 +133,8 @@ type RepositoryValidation struct {
 	ValidationStatus           *string `json:"validation_status,omitempty"`
 	ValidationDetails          *string `json:"validation_details,omitempty" gorm:"type:text"`
 	DestinationData            *string `json:"destination_data,omitempty" gorm:"type:text"`
+	DeepValidationReport       *string `json:"deep_validation_report,omitempty" gorm:"type:text"` // JSON per-ref diff report from deep post-migration validation
+	SettingsSyncReport         *string `json:"settings_sync_report,omitempty" gorm:"type:text"`   // JSON per-setting diff from the post-migration settings sync
 	HasOversizedCommits        bool    `json:"has_oversized_commits" gorm:"default:false"`
 	OversizedCommitDetails     *string `json:"oversized_commit_details,omitempty" gorm:"type:text"`
 	HasLongRefs                bool    `json:"has_long_refs" gorm:"default:false"`
@@ -135,12 +154,15 @@ type RepositoryValidation struct {
 // TableName specifies the table name for RepositoryValidation
 func (RepositoryValidation) TableName() string { return "repository_validation" }
 
-// MarshalJSON implements custom JSON marshaling to parse complexity_breakdown as object
+// MarshalJSON implements custom JSON marshaling to parse complexity_breakdown, deep_validation_report and
+// settings_sync_report as objects
 func (v RepositoryValidation) MarshalJSON() ([]byte, error) {
 	type Alias RepositoryValidation
 	result := struct {
 		Alias
-		ComplexityBreakdown any `json:"complexity_breakdown,omitempty"`
+		ComplexityBreakdown  any `json:"complexity_breakdown,omitempty"`
+		DeepValidationReport any `json:"deep_validation_report,omitempty"`
+		SettingsSyncReport   any `json:"settings_sync_report,omitempty"`
 	}{
 		Alias: Alias(v),
 	}
@@ -153,5 +175,23 @@ func (v RepositoryValidation) MarshalJSON() ([]byte, error) {
 			result.ComplexityBreakdown = *v.ComplexityBreakdown
 		}
 	}
+	// Parse deep validation report JSON string into object
+	if v.DeepValidationReport != nil && *v.DeepValidationReport != "" {
+		var report map[string]any
+		if err := json.Unmarshal([]byte(*v.DeepValidationReport), &report); err == nil {
+			result.DeepValidationReport = report
+		} else {
+			result.DeepValidationReport = *v.DeepValidationReport
+		}
+	}
+	// Parse settings sync report JSON string into object
+	if v.SettingsSyncReport != nil && *v.SettingsSyncReport != "" {
+		var report map[string]any
+		if err := json.Unmarshal([]byte(*v.SettingsSyncReport), &report); err == nil {
+			result.SettingsSyncReport = report
+		} else {
+			result.SettingsSyncReport = *v.SettingsSyncReport
+		}
+	}
 	return json.Marshal(result)
 }
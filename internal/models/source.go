@@ -10,6 +10,8 @@ import (
 const (
 	SourceConfigTypeGitHub      = "github"
 	SourceConfigTypeAzureDevOps = "azuredevops"
+	SourceConfigTypeGitLab      = "gitlab"
+	SourceConfigTypeBitbucket   = "bitbucket"
 )
 
 // Source represents a configured migration source (e.g., GitHub Enterprise Server, Azure DevOps).
@@ -20,7 +22,7 @@ type Source struct {
 	Name string `json:"name" db:"name" gorm:"column:name;uniqueIndex;not null"` // User-friendly name (e.g., "GHES Production", "ADO Main")
 
 	// Connection configuration
-	Type           string  `json:"type" db:"type" gorm:"column:type;not null;index"`                             // "github" or "azuredevops"
+	Type           string  `json:"type" db:"type" gorm:"column:type;not null;index"`                             // "github", "azuredevops", "gitlab", or "bitbucket"
 	BaseURL        string  `json:"base_url" db:"base_url" gorm:"column:base_url;not null"`                       // API base URL
 	Token          string  `json:"-" db:"token" gorm:"column:token;not null"`                                    // PAT token (excluded from JSON serialization)
 	Organization   *string `json:"organization,omitempty" db:"organization" gorm:"column:organization"`          // Required for Azure DevOps (top-level container)
@@ -55,7 +57,7 @@ var (
 	ErrSourceNameRequired    = errors.New("source name is required")
 	ErrSourceNameTooLong     = errors.New("source name must be 100 characters or less")
 	ErrSourceTypeRequired    = errors.New("source type is required")
-	ErrSourceTypeInvalid     = errors.New("source type must be 'github' or 'azuredevops'")
+	ErrSourceTypeInvalid     = errors.New("source type must be 'github', 'azuredevops', 'gitlab', or 'bitbucket'")
 	ErrSourceBaseURLRequired = errors.New("source base URL is required")
 	ErrSourceTokenRequired   = errors.New("source token is required")
 	ErrSourceOrgRequired     = errors.New("organization is required for Azure DevOps sources")
@@ -77,7 +79,8 @@ func (s *Source) Validate() error {
 	if s.Type == "" {
 		return ErrSourceTypeRequired
 	}
-	if s.Type != SourceConfigTypeGitHub && s.Type != SourceConfigTypeAzureDevOps {
+	if s.Type != SourceConfigTypeGitHub && s.Type != SourceConfigTypeAzureDevOps &&
+		s.Type != SourceConfigTypeGitLab && s.Type != SourceConfigTypeBitbucket {
 		return ErrSourceTypeInvalid
 	}
 
@@ -136,6 +139,16 @@ func (s *Source) IsAzureDevOps() bool {
 	return s.Type == SourceConfigTypeAzureDevOps
 }
 
+// IsGitLab returns true if this is a GitLab source
+func (s *Source) IsGitLab() bool {
+	return s.Type == SourceConfigTypeGitLab
+}
+
+// IsBitbucket returns true if this is a Bitbucket Server / Data Center source
+func (s *Source) IsBitbucket() bool {
+	return s.Type == SourceConfigTypeBitbucket
+}
+
 // HasAppAuth returns true if GitHub App authentication is configured
 func (s *Source) HasAppAuth() bool {
 	return s.AppID != nil && *s.AppID > 0 && s.AppPrivateKey != nil && *s.AppPrivateKey != ""
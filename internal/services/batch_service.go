@@ -134,7 +134,7 @@ func (s *BatchService) AddRepositoriesToBatch(ctx context.Context, batchID int64
 		}
 
 		// Check eligibility
-		eligible, reason := s.checkRepoEligibility(repo)
+		eligible, reason := s.checkRepoEligibility(repo, batch)
 		if !eligible {
 			result.Reason = reason
 			results = append(results, result)
@@ -276,10 +276,16 @@ func (s *BatchService) StartBatch(ctx context.Context, batchID int64) (*models.B
 	return batch, nil
 }
 
-// checkRepoEligibility checks if a repository is eligible for batch assignment.
-func (s *BatchService) checkRepoEligibility(repo *models.Repository) (bool, string) {
-	// Check for oversized repository
-	if repo.HasOversizedRepository() {
+// checkRepoEligibility checks if a repository is eligible for assignment to the batch.
+// Git mirror batches are not subject to GEI limits and also accept repositories
+// that require remediation.
+func (s *BatchService) checkRepoEligibility(repo *models.Repository, batch *models.Batch) (bool, string) {
+	if batch.UsesGitMirror() {
+		if repo.Status == string(models.StatusRemediationRequired) {
+			return true, ""
+		}
+	} else if repo.HasOversizedRepository() {
+		// Check for oversized repository
 		return false, "repository exceeds GitHub's 40 GiB size limit"
 	}
 
@@ -772,9 +772,17 @@ func TestStartBatch(t *testing.T) {
 }
 
 func TestCheckRepoEligibility(t *testing.T) {
+	oversized := func(status models.MigrationStatus) *models.Repository {
+		r := &models.Repository{Status: string(status)}
+		r.SetHasOversizedRepository(true)
+		return r
+	}
+	gitBatch := &models.Batch{MigrationAPI: models.MigrationAPIGit}
+
 	tests := []struct {
 		name         string
 		repo         *models.Repository
+		batch        *models.Batch
 		wantEligible bool
 		wantReason   string
 	}{
@@ -794,15 +802,37 @@ func TestCheckRepoEligibility(t *testing.T) {
 			wantEligible: true,
 		},
 		{
-			name: "not eligible - oversized",
-			repo: func() *models.Repository {
-				r := &models.Repository{Status: string(models.StatusPending)}
-				r.SetHasOversizedRepository(true)
-				return r
-			}(),
+			name:         "not eligible - oversized",
+			repo:         oversized(models.StatusPending),
+			wantEligible: false,
+			wantReason:   "repository exceeds GitHub's 40 GiB size limit",
+		},
+		{
+			name:         "not eligible - remediation required",
+			repo:         oversized(models.StatusRemediationRequired),
+			batch:        &models.Batch{MigrationAPI: models.MigrationAPIGEI},
 			wantEligible: false,
 			wantReason:   "repository exceeds GitHub's 40 GiB size limit",
 		},
+		{
+			name:         "eligible oversized repo in git mirror batch",
+			repo:         oversized(models.StatusPending),
+			batch:        gitBatch,
+			wantEligible: true,
+		},
+		{
+			name:         "eligible remediation required repo in git mirror batch",
+			repo:         oversized(models.StatusRemediationRequired),
+			batch:        gitBatch,
+			wantEligible: true,
+		},
+		{
+			name:         "not eligible - wrong status in git mirror batch",
+			repo:         &models.Repository{Status: string(models.StatusComplete)},
+			batch:        gitBatch,
+			wantEligible: false,
+			wantReason:   fmt.Sprintf("status '%s' is not eligible", models.StatusComplete),
+		},
 		{
 			name:         "not eligible - wrong status",
 			repo:         &models.Repository{Status: string(models.StatusComplete)},
@@ -814,7 +844,7 @@ func TestCheckRepoEligibility(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc := NewBatchService(NewMockBatchStore(), NewMockRepoStore(), newTestLogger())
-			eligible, reason := svc.checkRepoEligibility(tt.repo)
+			eligible, reason := svc.checkRepoEligibility(tt.repo, tt.batch)
 
 			if eligible != tt.wantEligible {
 				t.Errorf("eligible = %v, want %v", eligible, tt.wantEligible)
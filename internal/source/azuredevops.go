@@ -76,7 +76,9 @@ func (p *AzureDevOpsProvider) CloneRepository(ctx context.Context, info Reposito
 		args = append(args, "--depth=1")
 	}
 
-	if opts.Bare {
+	if opts.Mirror {
+		args = append(args, "--mirror")
+	} else if opts.Bare {
 		args = append(args, "--bare")
 	}
 
@@ -18,6 +18,9 @@ func NewProviderFromConfig(cfg config.SourceConfig) (Provider, error) {
 	case "gitlab":
 		return NewGitLabProvider(cfg.BaseURL, cfg.Token)
 
+	case "bitbucket":
+		return NewBitbucketProvider(cfg.BaseURL, cfg.Token, cfg.Username)
+
 	case "azuredevops", "ado":
 		if cfg.Organization == "" {
 			return nil, fmt.Errorf("organization is required for Azure DevOps provider")
@@ -25,7 +28,7 @@ func NewProviderFromConfig(cfg config.SourceConfig) (Provider, error) {
 		return NewAzureDevOpsProvider(cfg.Organization, cfg.Token, cfg.Username)
 
 	default:
-		return nil, fmt.Errorf("unsupported provider type: %s (supported: github, gitlab, azuredevops)", cfg.Type)
+		return nil, fmt.Errorf("unsupported provider type: %s (supported: github, gitlab, azuredevops, bitbucket)", cfg.Type)
 	}
 }
 
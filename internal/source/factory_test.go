@@ -43,6 +43,16 @@ func TestNewProviderFromConfig(t *testing.T) {
 			expectError:  false,
 			expectedType: ProviderGitLab,
 		},
+		{
+			name: "Bitbucket provider",
+			cfg: config.SourceConfig{
+				Type:    "bitbucket",
+				BaseURL: "https://bitbucket.example.com",
+				Token:   "test_token",
+			},
+			expectError:  false,
+			expectedType: ProviderBitbucket,
+		},
 		{
 			name: "Azure DevOps provider",
 			cfg: config.SourceConfig{
@@ -74,7 +84,7 @@ func TestNewProviderFromConfig(t *testing.T) {
 		{
 			name: "Unsupported provider type",
 			cfg: config.SourceConfig{
-				Type:  "bitbucket",
+				Type:  "perforce",
 				Token: "test_token",
 			},
 			expectError: true,
@@ -95,7 +95,9 @@ func (p *GitHubProvider) CloneRepository(ctx context.Context, info RepositoryInf
 		args = append(args, "--depth=1")
 	}
 
-	if opts.Bare {
+	if opts.Mirror {
+		args = append(args, "--mirror")
+	} else if opts.Bare {
 		args = append(args, "--bare")
 	}
 
@@ -2,6 +2,9 @@ package source
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -211,36 +214,64 @@ func TestGitLabProvider_SupportsFeature(t *testing.T) {
 	}
 }
 
-func TestGitLabProvider_CloneRepository_NotImplemented(t *testing.T) {
+func TestGitLabProvider_CloneRepository_InvalidInputs(t *testing.T) {
 	provider, err := NewGitLabProvider("https://gitlab.com", "token")
 	if err != nil {
 		t.Fatalf("Failed to create provider: %v", err)
 	}
 
-	// CloneRepository should return "not yet implemented" error
-	err = provider.CloneRepository(context.TODO(), RepositoryInfo{}, "", CloneOptions{})
-	if err == nil {
-		t.Error("Expected error, got nil")
+	// Empty destination path is rejected before git is invoked
+	err = provider.CloneRepository(context.TODO(), RepositoryInfo{CloneURL: "https://gitlab.com/org/repo.git"}, "", CloneOptions{})
+	if err == nil || !contains(err.Error(), "invalid destination path") {
+		t.Errorf("Expected invalid destination path error, got %v", err)
 	}
 
-	if !contains(err.Error(), "not yet implemented") {
-		t.Errorf("Expected error to contain 'not yet implemented', got %q", err.Error())
+	// Invalid clone URL is rejected before git is invoked
+	err = provider.CloneRepository(context.TODO(), RepositoryInfo{CloneURL: "file:///etc/passwd"}, t.TempDir()+"/repo", CloneOptions{})
+	if err == nil || !contains(err.Error(), "failed to get authenticated URL") {
+		t.Errorf("Expected authenticated URL error, got %v", err)
 	}
 }
 
-func TestGitLabProvider_ValidateCredentials_NotImplemented(t *testing.T) {
-	provider, err := NewGitLabProvider("https://gitlab.com", "token")
-	if err != nil {
-		t.Fatalf("Failed to create provider: %v", err)
+func TestGitLabProvider_ValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+		wantAuth   bool
+	}{
+		{name: "valid token", statusCode: http.StatusOK},
+		{name: "invalid token", statusCode: http.StatusUnauthorized, wantErr: true, wantAuth: true},
+		{name: "forbidden", statusCode: http.StatusForbidden, wantErr: true, wantAuth: true},
+		{name: "server error", statusCode: http.StatusInternalServerError, wantErr: true},
 	}
 
-	// ValidateCredentials should return "not yet implemented" error
-	err = provider.ValidateCredentials(context.TODO())
-	if err == nil {
-		t.Error("Expected error, got nil")
-	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v4/user" {
+					t.Errorf("Unexpected path %s", r.URL.Path)
+				}
+				if r.Header.Get("PRIVATE-TOKEN") != "token" {
+					t.Errorf("Expected PRIVATE-TOKEN header, got %q", r.Header.Get("PRIVATE-TOKEN"))
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"id": 1, "username": "migrator"}`))
+			}))
+			defer server.Close()
 
-	if !contains(err.Error(), "not yet implemented") {
-		t.Errorf("Expected error to contain 'not yet implemented', got %q", err.Error())
+			provider, err := NewGitLabProvider(server.URL+"/", "token")
+			if err != nil {
+				t.Fatalf("Failed to create provider: %v", err)
+			}
+
+			err = provider.ValidateCredentials(context.TODO())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateCredentials() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantAuth && !errors.Is(err, ErrAuthenticationFailed) {
+				t.Errorf("Expected ErrAuthenticationFailed, got %v", err)
+			}
+		})
 	}
 }
@@ -28,6 +28,8 @@ const (
 	ProviderGitLab ProviderType = "gitlab"
 	// ProviderAzureDevOps represents Azure DevOps (formerly VSTS)
 	ProviderAzureDevOps ProviderType = "azuredevops"
+	// ProviderBitbucket represents Bitbucket Server or Bitbucket Data Center
+	ProviderBitbucket ProviderType = "bitbucket"
 )
 
 // CloneOptions contains options for cloning a repository
@@ -40,6 +42,9 @@ type CloneOptions struct {
 	IncludeLFS bool
 	// IncludeSubmodules indicates whether to clone submodules
 	IncludeSubmodules bool
+	// Mirror indicates whether to perform a mirror clone (--mirror) of all refs.
+	// A mirror clone is always bare; used when pushing full history to a new remote.
+	Mirror bool
 }
 
 // DefaultCloneOptions returns default clone options for discovery
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// CreateBatchApproval appends a step to a batch's approval audit log
+func (d *Database) CreateBatchApproval(ctx context.Context, approval *models.BatchApproval) error {
+	if err := d.db.WithContext(ctx).Create(approval).Error; err != nil {
+		return fmt.Errorf("failed to create batch approval: %w", err)
+	}
+	return nil
+}
+
+// ListBatchApprovals retrieves the approval audit log of a batch, oldest first
+func (d *Database) ListBatchApprovals(ctx context.Context, batchID int64) ([]*models.BatchApproval, error) {
+	// Initialize as empty slice instead of nil so JSON serialization returns [] not null
+	approvals := make([]*models.BatchApproval, 0)
+
+	err := d.db.WithContext(ctx).
+		Where("batch_id = ?", batchID).
+		Order("id").
+		Find(&approvals).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query batch approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// UseBatchApproval marks an approval as used and appends the start that used it to the batch's
+// approval audit log. The approval is claimed with a single conditional update, so when several
+// starts race for the same approval only one of them gets it. Returns false, recording nothing,
+// if the approval was already used.
+func (d *Database) UseBatchApproval(ctx context.Context, approvalID int64, start *models.BatchApproval) (bool, error) {
+	used := false
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		used, err = useBatchApproval(tx, approvalID, start)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to use batch approval: %w", err)
+	}
+	return used, nil
+}
+
+// errApprovalUsed rolls back a transaction whose approval was already used
+var errApprovalUsed = errors.New("batch approval already used")
+
+// useBatchApproval marks an approval as used and records the start within tx
+func useBatchApproval(tx *gorm.DB, approvalID int64, start *models.BatchApproval) (bool, error) {
+	result := tx.Model(&models.BatchApproval{}).
+		Where("id = ? AND action = ? AND used_at IS NULL", approvalID, models.BatchApprovalApproved).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	if err := tx.Create(start).Error; err != nil {
+		return false, err
+	}
+	return true, nil
+}
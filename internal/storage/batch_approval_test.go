@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestBatchApprovals(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	comment := "CHG-1042"
+	steps := []*models.BatchApproval{
+		{BatchID: 1, Action: models.BatchApprovalRequested, Actor: "alice", Comment: &comment,
+			DryRunSummary: json.RawMessage(`{"repositories":2}`)},
+		{BatchID: 2, Action: models.BatchApprovalRequested, Actor: "carol"},
+		{BatchID: 1, Action: models.BatchApprovalApproved, Actor: "bob"},
+	}
+	for _, step := range steps {
+		if err := db.CreateBatchApproval(ctx, step); err != nil {
+			t.Fatalf("CreateBatchApproval() error = %v", err)
+		}
+	}
+
+	approvals, err := db.ListBatchApprovals(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListBatchApprovals() error = %v", err)
+	}
+	if len(approvals) != 2 || approvals[0].Actor != "alice" || approvals[1].Action != models.BatchApprovalApproved {
+		t.Fatalf("Expected the request and approval of batch 1 in order, got %+v", approvals)
+	}
+	if approvals[0].Comment == nil || *approvals[0].Comment != comment {
+		t.Errorf("Expected the request comment to be saved, got %v", approvals[0].Comment)
+	}
+	var summary map[string]int
+	if err := json.Unmarshal(approvals[0].DryRunSummary, &summary); err != nil || summary["repositories"] != 2 {
+		t.Errorf("Expected the dry run summary to be saved, got %s (%v)", approvals[0].DryRunSummary, err)
+	}
+
+	none, err := db.ListBatchApprovals(ctx, 3)
+	if err != nil || none == nil || len(none) != 0 {
+		t.Errorf("Expected an empty log for a batch without approvals, got %v, %v", none, err)
+	}
+}
+
+func TestUseBatchApproval(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	request := &models.BatchApproval{BatchID: 1, Action: models.BatchApprovalRequested, Actor: "alice"}
+	approval := &models.BatchApproval{BatchID: 1, Action: models.BatchApprovalApproved, Actor: "bob"}
+	for _, step := range []*models.BatchApproval{request, approval} {
+		if err := db.CreateBatchApproval(ctx, step); err != nil {
+			t.Fatalf("CreateBatchApproval() error = %v", err)
+		}
+	}
+
+	if used, err := db.UseBatchApproval(ctx, request.ID, &models.BatchApproval{BatchID: 1, Action: models.BatchApprovalStarted, Actor: "alice"}); err != nil || used {
+		t.Errorf("Expected a request not to be usable as an approval, got %v, %v", used, err)
+	}
+	if used, err := db.UseBatchApproval(ctx, approval.ID, &models.BatchApproval{BatchID: 1, Action: models.BatchApprovalStarted, Actor: "alice"}); err != nil || !used {
+		t.Fatalf("Expected the approval to be used, got %v, %v", used, err)
+	}
+	if used, err := db.UseBatchApproval(ctx, approval.ID, &models.BatchApproval{BatchID: 1, Action: models.BatchApprovalStarted, Actor: "carol"}); err != nil || used {
+		t.Errorf("Expected a second start not to use the same approval, got %v, %v", used, err)
+	}
+
+	approvals, err := db.ListBatchApprovals(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListBatchApprovals() error = %v", err)
+	}
+	if len(approvals) != 3 || approvals[1].UsedAt == nil || approvals[2].Action != models.BatchApprovalStarted || approvals[2].Actor != "alice" {
+		t.Errorf("Expected the approval to be marked used and one start recorded, got %+v", approvals)
+	}
+}
+
+func TestClaimApprovedBatchStart(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	batch := &models.Batch{Name: "wave_1", Type: "wave", Status: models.BatchStatusReady}
+	if err := db.CreateBatch(ctx, batch); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+	approval := &models.BatchApproval{BatchID: batch.ID, Action: models.BatchApprovalApproved, Actor: "bob"}
+	if err := db.CreateBatchApproval(ctx, approval); err != nil {
+		t.Fatalf("CreateBatchApproval() error = %v", err)
+	}
+	if used, err := db.UseBatchApproval(ctx, approval.ID, &models.BatchApproval{BatchID: batch.ID, Action: models.BatchApprovalStarted, Actor: "alice"}); err != nil || !used {
+		t.Fatalf("Expected the approval to be used, got %v, %v", used, err)
+	}
+
+	// A start whose approval was already used does not claim the batch
+	start := &models.BatchApproval{BatchID: batch.ID, Action: models.BatchApprovalStarted, Actor: "scheduler"}
+	if claimed, err := db.ClaimApprovedBatchStart(ctx, batch.ID, models.BatchStatusReady, nil, approval.ID, start); err != nil || claimed {
+		t.Fatalf("Expected a used approval not to claim the batch, got %v, %v", claimed, err)
+	}
+	got, err := db.GetBatch(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("GetBatch() error = %v", err)
+	}
+	if got.Status != models.BatchStatusReady {
+		t.Errorf("Expected the claim to be rolled back, got status %s", got.Status)
+	}
+
+	// A lost claim does not use the approval
+	second := &models.BatchApproval{BatchID: batch.ID, Action: models.BatchApprovalApproved, Actor: "bob"}
+	if err := db.CreateBatchApproval(ctx, second); err != nil {
+		t.Fatalf("CreateBatchApproval() error = %v", err)
+	}
+	if claimed, err := db.ClaimApprovedBatchStart(ctx, batch.ID, models.BatchStatusPending, nil, second.ID, start); err != nil || claimed {
+		t.Fatalf("Expected a stale status not to claim the batch, got %v, %v", claimed, err)
+	}
+	if claimed, err := db.ClaimApprovedBatchStart(ctx, batch.ID, models.BatchStatusReady, nil, second.ID, start); err != nil || !claimed {
+		t.Fatalf("Expected the approved start to claim the batch, got %v, %v", claimed, err)
+	}
+
+	approvals, err := db.ListBatchApprovals(ctx, batch.ID)
+	if err != nil {
+		t.Fatalf("ListBatchApprovals() error = %v", err)
+	}
+	if len(approvals) != 4 || approvals[2].UsedAt == nil || approvals[3].Actor != "scheduler" {
+		t.Errorf("Expected one start recorded per used approval, got %+v", approvals)
+	}
+}
@@ -51,7 +51,45 @@ type DialectDialer interface {
 	// PercentileMedian returns SQL for calculating median, or empty if not supported.
 	// The caller should fall back to ordering/limiting for databases without support.
 	PercentileMedian(column string) string
-}
+
+	// ClaimLeaseSQL returns a single atomic statement that inserts a migration lease, or takes
+	// over an existing one that is expired or already held by the same owner.
+	// Parameters: repository_id, owner_id, acquired_at, heartbeat_at, expires_at.
+	// The statement affects no rows when the lease is held by another owner.
+	ClaimLeaseSQL() string
+
+	// ClaimLeaderLockSQL returns a single atomic statement that inserts a leader lock, or takes
+	// over an existing one that is expired or already held by the same owner. The fencing token
+	// is incremented when the lock changes owner and kept when the owner renews it.
+	// Parameters: name, owner_id, acquired_at, renewed_at, expires_at.
+	// The statement affects no rows when the lock is held by another owner.
+	ClaimLeaderLockSQL() string
+}
+
+// upsertClaimLeaseSQL claims a migration lease using INSERT ... ON CONFLICT,
+// which SQLite and PostgreSQL both support.
+const upsertClaimLeaseSQL = `INSERT INTO migration_leases (repository_id, owner_id, acquired_at, heartbeat_at, expires_at)
+VALUES (?, ?, ?, ?, ?)
+ON CONFLICT (repository_id) DO UPDATE SET
+	owner_id = excluded.owner_id,
+	acquired_at = excluded.acquired_at,
+	heartbeat_at = excluded.heartbeat_at,
+	expires_at = excluded.expires_at
+WHERE migration_leases.owner_id = excluded.owner_id OR migration_leases.expires_at < excluded.acquired_at`
+
+// upsertClaimLeaderLockSQL claims a leader lock using INSERT ... ON CONFLICT,
+// which SQLite and PostgreSQL both support.
+const upsertClaimLeaderLockSQL = `INSERT INTO leader_locks (name, owner_id, fencing_token, acquired_at, renewed_at, expires_at)
+VALUES (?, ?, 1, ?, ?, ?)
+ON CONFLICT (name) DO UPDATE SET
+	fencing_token = CASE WHEN leader_locks.owner_id = excluded.owner_id
+		THEN leader_locks.fencing_token ELSE leader_locks.fencing_token + 1 END,
+	acquired_at = CASE WHEN leader_locks.owner_id = excluded.owner_id
+		THEN leader_locks.acquired_at ELSE excluded.acquired_at END,
+	owner_id = excluded.owner_id,
+	renewed_at = excluded.renewed_at,
+	expires_at = excluded.expires_at
+WHERE leader_locks.owner_id = excluded.owner_id OR leader_locks.expires_at < excluded.acquired_at`
 
 // NewDialectDialer creates a dialect dialer based on the database configuration
 func NewDialectDialer(cfg config.DatabaseConfig) (DialectDialer, error) {
@@ -187,6 +225,16 @@ func (d *SQLiteDialect) PercentileMedian(column string) string {
 	return ""
 }
 
+// ClaimLeaseSQL returns the SQLite upsert that claims a migration lease.
+func (d *SQLiteDialect) ClaimLeaseSQL() string {
+	return upsertClaimLeaseSQL
+}
+
+// ClaimLeaderLockSQL returns the SQLite upsert that claims a leader lock.
+func (d *SQLiteDialect) ClaimLeaderLockSQL() string {
+	return upsertClaimLeaderLockSQL
+}
+
 // PostgresDialect handles PostgreSQL-specific configuration
 type PostgresDialect struct {
 	cfg config.DatabaseConfig
@@ -267,6 +315,16 @@ func (d *PostgresDialect) PercentileMedian(column string) string {
 	return fmt.Sprintf("PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY %s)", column)
 }
 
+// ClaimLeaseSQL returns the PostgreSQL upsert that claims a migration lease.
+func (d *PostgresDialect) ClaimLeaseSQL() string {
+	return upsertClaimLeaseSQL
+}
+
+// ClaimLeaderLockSQL returns the PostgreSQL upsert that claims a leader lock.
+func (d *PostgresDialect) ClaimLeaderLockSQL() string {
+	return upsertClaimLeaderLockSQL
+}
+
 // SQLServerDialect handles SQL Server-specific configuration
 type SQLServerDialect struct {
 	cfg config.DatabaseConfig
@@ -345,3 +403,40 @@ func (d *SQLServerDialect) SupportsPercentileCont() bool {
 func (d *SQLServerDialect) PercentileMedian(column string) string {
 	return fmt.Sprintf("PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY %s) OVER ()", column)
 }
+
+// ClaimLeaseSQL returns the SQL Server MERGE that claims a migration lease.
+// HOLDLOCK keeps the match and the insert atomic under concurrent claims.
+func (d *SQLServerDialect) ClaimLeaseSQL() string {
+	return `MERGE migration_leases WITH (HOLDLOCK) AS target
+USING (SELECT ? AS repository_id, ? AS owner_id, ? AS acquired_at, ? AS heartbeat_at, ? AS expires_at) AS source
+ON target.repository_id = source.repository_id
+WHEN MATCHED AND (target.owner_id = source.owner_id OR target.expires_at < source.acquired_at) THEN
+	UPDATE SET
+		owner_id = source.owner_id,
+		acquired_at = source.acquired_at,
+		heartbeat_at = source.heartbeat_at,
+		expires_at = source.expires_at
+WHEN NOT MATCHED THEN
+	INSERT (repository_id, owner_id, acquired_at, heartbeat_at, expires_at)
+	VALUES (source.repository_id, source.owner_id, source.acquired_at, source.heartbeat_at, source.expires_at);`
+}
+
+// ClaimLeaderLockSQL returns the SQL Server MERGE that claims a leader lock.
+// HOLDLOCK keeps the match and the insert atomic under concurrent claims.
+func (d *SQLServerDialect) ClaimLeaderLockSQL() string {
+	return `MERGE leader_locks WITH (HOLDLOCK) AS target
+USING (SELECT ? AS name, ? AS owner_id, ? AS acquired_at, ? AS renewed_at, ? AS expires_at) AS source
+ON target.name = source.name
+WHEN MATCHED AND (target.owner_id = source.owner_id OR target.expires_at < source.acquired_at) THEN
+	UPDATE SET
+		fencing_token = CASE WHEN target.owner_id = source.owner_id
+			THEN target.fencing_token ELSE target.fencing_token + 1 END,
+		acquired_at = CASE WHEN target.owner_id = source.owner_id
+			THEN target.acquired_at ELSE source.acquired_at END,
+		owner_id = source.owner_id,
+		renewed_at = source.renewed_at,
+		expires_at = source.expires_at
+WHEN NOT MATCHED THEN
+	INSERT (name, owner_id, fencing_token, acquired_at, renewed_at, expires_at)
+	VALUES (source.name, source.owner_id, 1, source.acquired_at, source.renewed_at, source.expires_at);`
+}
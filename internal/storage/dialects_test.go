@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/kuhlman-labs/github-migrator/internal/config"
@@ -319,3 +320,54 @@ func TestSQLServerDialect_Dialect(t *testing.T) {
 		t.Error("Dialect() returned nil")
 	}
 }
+
+func TestDialect_ClaimLeaseSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect DialectDialer
+		want    string
+	}{
+		{"sqlite", &SQLiteDialect{}, "ON CONFLICT (repository_id) DO UPDATE"},
+		{"postgres", &PostgresDialect{}, "ON CONFLICT (repository_id) DO UPDATE"},
+		{"sqlserver", &SQLServerDialect{}, "MERGE migration_leases WITH (HOLDLOCK)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := tt.dialect.ClaimLeaseSQL()
+			if !strings.Contains(sql, tt.want) {
+				t.Errorf("ClaimLeaseSQL() = %q, want containing %q", sql, tt.want)
+			}
+			if n := strings.Count(sql, "?"); n != 5 {
+				t.Errorf("ClaimLeaseSQL() has %d placeholders, want 5", n)
+			}
+		})
+	}
+}
+
+func TestDialect_ClaimLeaderLockSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect DialectDialer
+		want    string
+	}{
+		{"sqlite", &SQLiteDialect{}, "ON CONFLICT (name) DO UPDATE"},
+		{"postgres", &PostgresDialect{}, "ON CONFLICT (name) DO UPDATE"},
+		{"sqlserver", &SQLServerDialect{}, "MERGE leader_locks WITH (HOLDLOCK)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql := tt.dialect.ClaimLeaderLockSQL()
+			if !strings.Contains(sql, tt.want) {
+				t.Errorf("ClaimLeaderLockSQL() = %q, want containing %q", sql, tt.want)
+			}
+			if !strings.Contains(sql, "fencing_token + 1") {
+				t.Error("ClaimLeaderLockSQL() should increment the fencing token on takeover")
+			}
+			if n := strings.Count(sql, "?"); n != 5 {
+				t.Errorf("ClaimLeaderLockSQL() has %d placeholders, want 5", n)
+			}
+		})
+	}
+}
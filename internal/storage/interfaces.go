@@ -109,6 +109,78 @@ type DependencyStore interface {
 	GetAllLocalDependencyPairs(ctx context.Context, dependencyTypes []string, sourceID *int64) ([]DependencyPair, error)
 }
 
+// SecretsChecklistStore defines operations for the "secrets to re-enter" checklist.
+type SecretsChecklistStore interface {
+	// SaveRepositorySecrets replaces the checklist of a repository.
+	SaveRepositorySecrets(ctx context.Context, repoID int64, secrets []*models.RepositorySecret) error
+	// GetRepositorySecrets retrieves the checklist of a repository.
+	GetRepositorySecrets(ctx context.Context, repoID int64) ([]*models.RepositorySecret, error)
+	// GetSecretsChecklist retrieves the checklist across all repositories.
+	GetSecretsChecklist(ctx context.Context) ([]SecretChecklistEntry, error)
+}
+
+// WebhookStore defines operations for webhooks recreated on the destination.
+type WebhookStore interface {
+	// SaveRepositoryWebhooks replaces the webhooks tracked for a repository.
+	SaveRepositoryWebhooks(ctx context.Context, repoID int64, webhooks []*models.RepositoryWebhook) error
+	// GetRepositoryWebhooks retrieves the webhooks tracked for a repository.
+	GetRepositoryWebhooks(ctx context.Context, repoID int64) ([]*models.RepositoryWebhook, error)
+	// GetPendingWebhooks retrieves the webhooks waiting for activation, optionally filtered.
+	GetPendingWebhooks(ctx context.Context, webhookIDs, repoIDs []int64) ([]PendingWebhook, error)
+	// MarkWebhookActivated records that a webhook was turned on.
+	MarkWebhookActivated(ctx context.Context, id int64) error
+	// SetWebhookError records why a webhook could not be activated.
+	SetWebhookError(ctx context.Context, id int64, message string) error
+}
+
+// ReferencePullRequestStore defines operations for the pull requests that rewrite cross-repo references.
+type ReferencePullRequestStore interface {
+	// SaveReferencePullRequest replaces the reference pull request recorded for a repository.
+	SaveReferencePullRequest(ctx context.Context, pr *models.ReferencePullRequest) error
+	// GetReferencePullRequest retrieves the reference pull request of a repository, or nil.
+	GetReferencePullRequest(ctx context.Context, repoID int64) (*models.ReferencePullRequest, error)
+}
+
+// CodeownersRewriteStore defines operations for CODEOWNERS files rewritten with the team and user mappings.
+type CodeownersRewriteStore interface {
+	// SaveCodeownersRewrite replaces the CODEOWNERS rewrite recorded for a repository.
+	SaveCodeownersRewrite(ctx context.Context, rewrite *models.CodeownersRewrite) error
+	// GetCodeownersRewrite retrieves the CODEOWNERS rewrite of a repository, or nil.
+	GetCodeownersRewrite(ctx context.Context, repoID int64) (*models.CodeownersRewrite, error)
+}
+
+// CollaboratorGrantStore defines operations for direct collaborator permissions re-granted on destinations.
+type CollaboratorGrantStore interface {
+	// SaveCollaboratorGrants replaces the collaborator grants logged for a repository.
+	SaveCollaboratorGrants(ctx context.Context, repoID int64, grants []*models.CollaboratorGrant) error
+	// GetCollaboratorGrants retrieves the collaborator grants logged for a repository.
+	GetCollaboratorGrants(ctx context.Context, repoID int64) ([]*models.CollaboratorGrant, error)
+}
+
+// MigrationWindowStore defines operations for blackout and allowed migration windows.
+type MigrationWindowStore interface {
+	// ListMigrationWindows retrieves all migration windows.
+	ListMigrationWindows(ctx context.Context) ([]*models.MigrationWindow, error)
+	// GetMigrationWindow retrieves a migration window by ID, or nil if it does not exist.
+	GetMigrationWindow(ctx context.Context, id int64) (*models.MigrationWindow, error)
+	// CreateMigrationWindow inserts a new migration window.
+	CreateMigrationWindow(ctx context.Context, window *models.MigrationWindow) error
+	// UpdateMigrationWindow saves every field of an existing migration window.
+	UpdateMigrationWindow(ctx context.Context, window *models.MigrationWindow) error
+	// DeleteMigrationWindow deletes a migration window.
+	DeleteMigrationWindow(ctx context.Context, id int64) error
+}
+
+// BatchApprovalStore defines operations for the approval audit log of batches.
+type BatchApprovalStore interface {
+	// CreateBatchApproval appends a step to a batch's approval audit log.
+	CreateBatchApproval(ctx context.Context, approval *models.BatchApproval) error
+	// ListBatchApprovals retrieves the approval audit log of a batch, oldest first.
+	ListBatchApprovals(ctx context.Context, batchID int64) ([]*models.BatchApproval, error)
+	// UseBatchApproval marks an approval used and records the start that used it, unless it was already used.
+	UseBatchApproval(ctx context.Context, approvalID int64, start *models.BatchApproval) (bool, error)
+}
+
 // AnalyticsStore defines operations for analytics and statistics.
 type AnalyticsStore interface {
 	// GetRepositoryStatsByStatus returns repository counts grouped by status.
@@ -129,6 +201,8 @@ type AnalyticsStore interface {
 	GetAverageMigrationTime(ctx context.Context, org, project, batchFilter string, sourceID *int64) (float64, error)
 	// GetMedianMigrationTime returns median migration duration.
 	GetMedianMigrationTime(ctx context.Context, org, project, batchFilter string, sourceID *int64) (float64, error)
+	// GetMigrationDurationSamples returns completed migrations and dry runs with their repository sizes.
+	GetMigrationDurationSamples(ctx context.Context) ([]*MigrationDurationSample, error)
 	// GetOrganizationStats returns statistics grouped by organization.
 	GetOrganizationStats(ctx context.Context) ([]*OrganizationStats, error)
 	// GetOrganizationStatsFiltered returns filtered organization statistics.
@@ -243,6 +317,14 @@ type TeamMappingStore interface {
 	ResetTeamMigrationStatus(ctx context.Context, sourceOrg string) error
 }
 
+// TeamMembershipSyncStore defines operations for team membership sync results.
+type TeamMembershipSyncStore interface {
+	// SaveTeamMembershipSync replaces the membership sync recorded for a source team.
+	SaveTeamMembershipSync(ctx context.Context, sync *models.TeamMembershipSync) error
+	// ListTeamMembershipSyncs lists membership syncs, optionally for one source organization.
+	ListTeamMembershipSyncs(ctx context.Context, sourceOrg string) ([]*models.TeamMembershipSync, error)
+}
+
 // ADOStore defines operations for Azure DevOps data.
 type ADOStore interface {
 	// GetADOProjects retrieves ADO projects for an organization.
@@ -322,24 +404,31 @@ type DatabaseAccess interface {
 // Compile-time interface checks.
 // These ensure Database implements all defined interfaces.
 var (
-	_ RepositoryReader      = (*Database)(nil)
-	_ RepositoryWriter      = (*Database)(nil)
-	_ RepositoryStore       = (*Database)(nil)
-	_ BatchReader           = (*Database)(nil)
-	_ BatchWriter           = (*Database)(nil)
-	_ BatchStore            = (*Database)(nil)
-	_ MigrationHistoryStore = (*Database)(nil)
-	_ DependencyStore       = (*Database)(nil)
-	_ AnalyticsStore        = (*Database)(nil)
-	_ UserStore             = (*Database)(nil)
-	_ UserMappingStore      = (*Database)(nil)
-	_ UserMannequinStore    = (*Database)(nil)
-	_ TeamStore             = (*Database)(nil)
-	_ TeamMappingStore      = (*Database)(nil)
-	_ SourceStore           = (*Database)(nil)
-	_ ADOStore              = (*Database)(nil)
-	_ DiscoveryStore        = (*Database)(nil)
-	_ SettingsStore         = (*Database)(nil)
-	_ SetupStore            = (*Database)(nil)
-	_ DatabaseAccess        = (*Database)(nil)
+	_ RepositoryReader          = (*Database)(nil)
+	_ RepositoryWriter          = (*Database)(nil)
+	_ RepositoryStore           = (*Database)(nil)
+	_ BatchReader               = (*Database)(nil)
+	_ BatchWriter               = (*Database)(nil)
+	_ BatchStore                = (*Database)(nil)
+	_ MigrationHistoryStore     = (*Database)(nil)
+	_ DependencyStore           = (*Database)(nil)
+	_ SecretsChecklistStore     = (*Database)(nil)
+	_ WebhookStore              = (*Database)(nil)
+	_ ReferencePullRequestStore = (*Database)(nil)
+	_ CodeownersRewriteStore    = (*Database)(nil)
+	_ CollaboratorGrantStore    = (*Database)(nil)
+	_ MigrationWindowStore      = (*Database)(nil)
+	_ TeamMembershipSyncStore   = (*Database)(nil)
+	_ AnalyticsStore            = (*Database)(nil)
+	_ UserStore                 = (*Database)(nil)
+	_ UserMappingStore          = (*Database)(nil)
+	_ UserMannequinStore        = (*Database)(nil)
+	_ TeamStore                 = (*Database)(nil)
+	_ TeamMappingStore          = (*Database)(nil)
+	_ SourceStore               = (*Database)(nil)
+	_ ADOStore                  = (*Database)(nil)
+	_ DiscoveryStore            = (*Database)(nil)
+	_ SettingsStore             = (*Database)(nil)
+	_ SetupStore                = (*Database)(nil)
+	_ DatabaseAccess            = (*Database)(nil)
 )
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// AcquireLeaderLock atomically claims or renews the named leader lock for the owner.
+// It succeeds when no lock exists, the existing lock has expired, or the owner already holds it.
+// Returns the lock with its current fencing token, or nil when another owner holds an unexpired lock.
+func (d *Database) AcquireLeaderLock(ctx context.Context, name, ownerID string, ttl time.Duration) (*models.LeaderLock, error) {
+	now := time.Now().UTC()
+	result := d.db.WithContext(ctx).Exec(d.dialect.ClaimLeaderLockSQL(), name, ownerID, now, now, now.Add(ttl))
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to acquire leader lock: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	lock, err := d.GetLeaderLock(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if lock == nil || lock.OwnerID != ownerID {
+		return nil, nil
+	}
+	return lock, nil
+}
+
+// ReleaseLeaderLock expires the named leader lock if it is still held by the owner.
+// The row is kept so the fencing token keeps increasing for the next leader.
+func (d *Database) ReleaseLeaderLock(ctx context.Context, name, ownerID string) error {
+	err := d.db.WithContext(ctx).Model(&models.LeaderLock{}).
+		Where("name = ? AND owner_id = ?", name, ownerID).
+		Update("expires_at", time.Now().UTC()).Error
+	if err != nil {
+		return fmt.Errorf("failed to release leader lock: %w", err)
+	}
+	return nil
+}
+
+// LeaderFence identifies the leader lock and fencing token a leader's writes are made under.
+// Fenced writes only apply while the owner still holds the lock with the same token.
+type LeaderFence struct {
+	Name    string
+	OwnerID string
+	Token   int64
+}
+
+// fenceCondition returns a subquery matching the fence's lock while it is still current
+func (d *Database) fenceCondition(fence *LeaderFence) *gorm.DB {
+	return d.db.Model(&models.LeaderLock{}).Select("1").
+		Where("name = ? AND owner_id = ? AND fencing_token = ? AND expires_at > ?",
+			fence.Name, fence.OwnerID, fence.Token, time.Now().UTC())
+}
+
+// ValidateLeaderFence reports whether the owner still holds the named leader lock
+// with the given fencing token and the lock has not expired.
+func (d *Database) ValidateLeaderFence(ctx context.Context, name, ownerID string, token int64) (bool, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&models.LeaderLock{}).
+		Where("name = ? AND owner_id = ? AND fencing_token = ? AND expires_at > ?", name, ownerID, token, time.Now().UTC()).
+		Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("failed to validate leader fence: %w", err)
+	}
+	return count > 0, nil
+}
+
+// GetLeaderLock retrieves the named leader lock.
+// Returns nil if the lock has never been acquired.
+func (d *Database) GetLeaderLock(ctx context.Context, name string) (*models.LeaderLock, error) {
+	var lock models.LeaderLock
+	err := d.db.WithContext(ctx).Where("name = ?", name).First(&lock).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leader lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// ListActiveLeaderLocks retrieves all leader locks that have not expired, ordered by name.
+func (d *Database) ListActiveLeaderLocks(ctx context.Context) ([]*models.LeaderLock, error) {
+	var locks []*models.LeaderLock
+	err := d.db.WithContext(ctx).
+		Where("expires_at > ?", time.Now().UTC()).
+		Order("name ASC").
+		Find(&locks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list leader locks: %w", err)
+	}
+	return locks, nil
+}
@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestLeaderLock(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	// First claim creates the lock with token 1
+	lock, err := db.AcquireLeaderLock(ctx, "scheduler", "replica-a", time.Minute)
+	if err != nil || lock == nil {
+		t.Fatalf("AcquireLeaderLock(replica-a) = %v, %v", lock, err)
+	}
+	if lock.FencingToken != 1 {
+		t.Errorf("FencingToken = %d, want 1", lock.FencingToken)
+	}
+
+	// Another replica cannot take an unexpired lock
+	other, err := db.AcquireLeaderLock(ctx, "scheduler", "replica-b", time.Minute)
+	if err != nil || other != nil {
+		t.Fatalf("AcquireLeaderLock(replica-b) = %v, %v; want nil", other, err)
+	}
+
+	// Renewal by the owner keeps the token
+	renewed, err := db.AcquireLeaderLock(ctx, "scheduler", "replica-a", time.Minute)
+	if err != nil || renewed == nil {
+		t.Fatalf("AcquireLeaderLock(replica-a) renew = %v, %v", renewed, err)
+	}
+	if renewed.FencingToken != 1 {
+		t.Errorf("FencingToken after renewal = %d, want 1", renewed.FencingToken)
+	}
+	if !renewed.AcquiredAt.Equal(lock.AcquiredAt) {
+		t.Errorf("AcquiredAt changed on renewal: %v -> %v", lock.AcquiredAt, renewed.AcquiredAt)
+	}
+
+	valid, err := db.ValidateLeaderFence(ctx, "scheduler", "replica-a", 1)
+	if err != nil || !valid {
+		t.Fatalf("ValidateLeaderFence(replica-a, 1) = %v, %v; want true", valid, err)
+	}
+
+	// Locks are independent by name
+	if lock, _ := db.AcquireLeaderLock(ctx, "status_updater", "replica-b", time.Minute); lock == nil {
+		t.Fatal("Expected replica-b to acquire an unrelated lock")
+	}
+
+	active, err := db.ListActiveLeaderLocks(ctx)
+	if err != nil || len(active) != 2 {
+		t.Fatalf("ListActiveLeaderLocks() = %d locks, %v; want 2", len(active), err)
+	}
+	if active[0].Name != "scheduler" || active[1].Name != "status_updater" {
+		t.Errorf("ListActiveLeaderLocks() order = %s, %s", active[0].Name, active[1].Name)
+	}
+
+	// After release another replica takes over with a new token
+	if err := db.ReleaseLeaderLock(ctx, "scheduler", "replica-a"); err != nil {
+		t.Fatalf("ReleaseLeaderLock() error = %v", err)
+	}
+	// Let the release timestamp fall behind the next claim
+	time.Sleep(5 * time.Millisecond)
+	takeover, err := db.AcquireLeaderLock(ctx, "scheduler", "replica-b", time.Minute)
+	if err != nil || takeover == nil {
+		t.Fatalf("AcquireLeaderLock(replica-b) after release = %v, %v", takeover, err)
+	}
+	if takeover.FencingToken != 2 {
+		t.Errorf("FencingToken after takeover = %d, want 2", takeover.FencingToken)
+	}
+
+	// The previous leader's fence is no longer valid
+	valid, err = db.ValidateLeaderFence(ctx, "scheduler", "replica-a", 1)
+	if err != nil || valid {
+		t.Errorf("ValidateLeaderFence(replica-a, 1) = %v, %v; want false", valid, err)
+	}
+	valid, err = db.ValidateLeaderFence(ctx, "scheduler", "replica-b", 2)
+	if err != nil || !valid {
+		t.Errorf("ValidateLeaderFence(replica-b, 2) = %v, %v; want true", valid, err)
+	}
+}
+
+func TestClaimBatchStart_StaleFenceLosesWrite(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	newBatch := func(name string) *models.Batch {
+		b := &models.Batch{Name: name, Type: "wave", Status: batchStatusReady, CreatedAt: time.Now()}
+		if err := db.CreateBatch(ctx, b); err != nil {
+			t.Fatalf("Failed to create batch: %v", err)
+		}
+		return b
+	}
+	first, second := newBatch("wave_1"), newBatch("wave_2")
+
+	lock, _ := db.AcquireLeaderLock(ctx, "scheduler", "replica-a", time.Minute)
+	stale := &LeaderFence{Name: "scheduler", OwnerID: "replica-a", Token: lock.FencingToken}
+	if claimed, err := db.ClaimBatchStart(ctx, first.ID, batchStatusReady, stale); err != nil || !claimed {
+		t.Fatalf("ClaimBatchStart() with a current fence = %v, %v; want true", claimed, err)
+	}
+	if claimed, _ := db.ClaimBatchStart(ctx, first.ID, batchStatusReady, stale); claimed {
+		t.Error("Expected a batch that already started not to be claimed again")
+	}
+
+	// replica-a stalls, its lock expires and replica-b becomes the leader
+	if err := db.ReleaseLeaderLock(ctx, "scheduler", "replica-a"); err != nil {
+		t.Fatalf("ReleaseLeaderLock() error = %v", err)
+	}
+	next, _ := db.AcquireLeaderLock(ctx, "scheduler", "replica-b", time.Minute)
+	if next == nil {
+		t.Fatal("Expected replica-b to take over the lock")
+	}
+
+	if claimed, err := db.ClaimBatchStart(ctx, second.ID, batchStatusReady, stale); err != nil || claimed {
+		t.Fatalf("ClaimBatchStart() with a stale fence = %v, %v; want false", claimed, err)
+	}
+	if saved, _ := db.GetBatch(ctx, second.ID); saved.Status != batchStatusReady {
+		t.Errorf("Status = %s, want %s after a stale fence", saved.Status, batchStatusReady)
+	}
+
+	current := &LeaderFence{Name: "scheduler", OwnerID: "replica-b", Token: next.FencingToken}
+	if claimed, err := db.ClaimBatchStart(ctx, second.ID, batchStatusReady, current); err != nil || !claimed {
+		t.Fatalf("ClaimBatchStart() for the new leader = %v, %v; want true", claimed, err)
+	}
+}
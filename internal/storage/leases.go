@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// AcquireMigrationLease atomically claims the migration lease for a repository.
+// It succeeds when no lease exists, the existing lease has expired, or the owner already holds it.
+// Returns false (with no error) when another owner holds an unexpired lease.
+func (d *Database) AcquireMigrationLease(ctx context.Context, repoID int64, ownerID string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+	result := d.db.WithContext(ctx).Exec(d.dialect.ClaimLeaseSQL(), repoID, ownerID, now, now, now.Add(ttl))
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to acquire migration lease: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// RenewMigrationLeases extends every lease held by the owner and records a heartbeat.
+// Returns the number of leases renewed.
+func (d *Database) RenewMigrationLeases(ctx context.Context, ownerID string, ttl time.Duration) (int64, error) {
+	now := time.Now().UTC()
+	result := d.db.WithContext(ctx).Model(&models.MigrationLease{}).
+		Where("owner_id = ?", ownerID).
+		Updates(map[string]any{
+			"heartbeat_at": now,
+			"expires_at":   now.Add(ttl),
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to renew migration leases: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ListLeasedRepositoryIDs returns the IDs of the repositories whose lease is held by the owner.
+func (d *Database) ListLeasedRepositoryIDs(ctx context.Context, ownerID string) ([]int64, error) {
+	var ids []int64
+	err := d.db.WithContext(ctx).Model(&models.MigrationLease{}).
+		Where("owner_id = ?", ownerID).
+		Pluck("repository_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migration leases: %w", err)
+	}
+	return ids, nil
+}
+
+// ReleaseMigrationLease releases a repository's lease if it is still held by the owner.
+func (d *Database) ReleaseMigrationLease(ctx context.Context, repoID int64, ownerID string) error {
+	err := d.db.WithContext(ctx).
+		Where("repository_id = ? AND owner_id = ?", repoID, ownerID).
+		Delete(&models.MigrationLease{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to release migration lease: %w", err)
+	}
+	return nil
+}
+
+// GetMigrationLease retrieves the lease for a repository.
+// Returns nil if the repository has no lease.
+func (d *Database) GetMigrationLease(ctx context.Context, repoID int64) (*models.MigrationLease, error) {
+	var lease models.MigrationLease
+	err := d.db.WithContext(ctx).Where("repository_id = ?", repoID).First(&lease).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration lease: %w", err)
+	}
+	return &lease, nil
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMigrationLeases(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test/repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	savedRepo, _ := db.GetRepository(ctx, repo.FullName)
+
+	// First claim succeeds
+	acquired, err := db.AcquireMigrationLease(ctx, savedRepo.ID, "replica-a", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireMigrationLease(replica-a) = %v, %v; want true", acquired, err)
+	}
+
+	// Another replica cannot claim an unexpired lease
+	acquired, err = db.AcquireMigrationLease(ctx, savedRepo.ID, "replica-b", time.Minute)
+	if err != nil || acquired {
+		t.Fatalf("AcquireMigrationLease(replica-b) = %v, %v; want false", acquired, err)
+	}
+
+	// The owner can claim its own lease again
+	acquired, err = db.AcquireMigrationLease(ctx, savedRepo.ID, "replica-a", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireMigrationLease(replica-a) again = %v, %v; want true", acquired, err)
+	}
+
+	lease, err := db.GetMigrationLease(ctx, savedRepo.ID)
+	if err != nil || lease == nil {
+		t.Fatalf("GetMigrationLease() = %v, %v", lease, err)
+	}
+	if lease.OwnerID != "replica-a" {
+		t.Errorf("OwnerID = %s, want replica-a", lease.OwnerID)
+	}
+
+	// Heartbeats extend the owner's leases only
+	renewed, err := db.RenewMigrationLeases(ctx, "replica-a", time.Hour)
+	if err != nil || renewed != 1 {
+		t.Fatalf("RenewMigrationLeases(replica-a) = %d, %v; want 1", renewed, err)
+	}
+	renewed, err = db.RenewMigrationLeases(ctx, "replica-b", time.Hour)
+	if err != nil || renewed != 0 {
+		t.Fatalf("RenewMigrationLeases(replica-b) = %d, %v; want 0", renewed, err)
+	}
+	renewedLease, _ := db.GetMigrationLease(ctx, savedRepo.ID)
+	if !renewedLease.ExpiresAt.After(lease.ExpiresAt) {
+		t.Errorf("ExpiresAt = %v, want after %v", renewedLease.ExpiresAt, lease.ExpiresAt)
+	}
+
+	if ids, err := db.ListLeasedRepositoryIDs(ctx, "replica-a"); err != nil || len(ids) != 1 || ids[0] != savedRepo.ID {
+		t.Errorf("ListLeasedRepositoryIDs(replica-a) = %v, %v; want [%d]", ids, err, savedRepo.ID)
+	}
+	if ids, err := db.ListLeasedRepositoryIDs(ctx, "replica-b"); err != nil || len(ids) != 0 {
+		t.Errorf("ListLeasedRepositoryIDs(replica-b) = %v, %v; want none", ids, err)
+	}
+
+	// Releasing someone else's lease is a no-op
+	if err := db.ReleaseMigrationLease(ctx, savedRepo.ID, "replica-b"); err != nil {
+		t.Fatalf("ReleaseMigrationLease(replica-b) error = %v", err)
+	}
+	if lease, _ := db.GetMigrationLease(ctx, savedRepo.ID); lease == nil {
+		t.Fatal("Expected lease to survive release by another replica")
+	}
+
+	if err := db.ReleaseMigrationLease(ctx, savedRepo.ID, "replica-a"); err != nil {
+		t.Fatalf("ReleaseMigrationLease(replica-a) error = %v", err)
+	}
+	if lease, _ := db.GetMigrationLease(ctx, savedRepo.ID); lease != nil {
+		t.Fatalf("Expected lease to be released, got %+v", lease)
+	}
+}
+
+func TestAcquireMigrationLease_TakesOverExpiredLease(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test/repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	savedRepo, _ := db.GetRepository(ctx, repo.FullName)
+
+	// A lease whose holder stopped heartbeating
+	if _, err := db.AcquireMigrationLease(ctx, savedRepo.ID, "replica-a", -time.Second); err != nil {
+		t.Fatalf("AcquireMigrationLease(replica-a) error = %v", err)
+	}
+
+	acquired, err := db.AcquireMigrationLease(ctx, savedRepo.ID, "replica-b", time.Minute)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireMigrationLease(replica-b) = %v, %v; want true", acquired, err)
+	}
+
+	lease, _ := db.GetMigrationLease(ctx, savedRepo.ID)
+	if lease == nil || lease.OwnerID != "replica-b" {
+		t.Fatalf("Expected replica-b to own the lease, got %+v", lease)
+	}
+
+	// Deleting the repository removes its lease
+	if err := db.DeleteRepository(ctx, savedRepo.FullName); err != nil {
+		t.Fatalf("DeleteRepository() error = %v", err)
+	}
+	if lease, _ := db.GetMigrationLease(ctx, savedRepo.ID); lease != nil {
+		t.Errorf("Expected lease to be deleted with the repository, got %+v", lease)
+	}
+}
@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+// ListMigrationWindows retrieves all blackout and allowed migration windows
+func (d *Database) ListMigrationWindows(ctx context.Context) ([]*models.MigrationWindow, error) {
+	// Initialize as empty slice instead of nil so JSON serialization returns [] not null
+	windows := make([]*models.MigrationWindow, 0)
+
+	err := d.db.WithContext(ctx).
+		Order("kind, name, id").
+		Find(&windows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query migration windows: %w", err)
+	}
+
+	return windows, nil
+}
+
+// GetMigrationWindow retrieves a migration window by ID, or nil if it does not exist
+func (d *Database) GetMigrationWindow(ctx context.Context, id int64) (*models.MigrationWindow, error) {
+	var windows []*models.MigrationWindow
+	err := d.db.WithContext(ctx).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&windows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get migration window: %w", err)
+	}
+	if len(windows) == 0 {
+		return nil, nil
+	}
+
+	return windows[0], nil
+}
+
+// CreateMigrationWindow inserts a new migration window
+func (d *Database) CreateMigrationWindow(ctx context.Context, window *models.MigrationWindow) error {
+	if err := d.db.WithContext(ctx).Create(window).Error; err != nil {
+		return fmt.Errorf("failed to create migration window: %w", err)
+	}
+	return nil
+}
+
+// UpdateMigrationWindow saves every field of an existing migration window
+func (d *Database) UpdateMigrationWindow(ctx context.Context, window *models.MigrationWindow) error {
+	if err := d.db.WithContext(ctx).Save(window).Error; err != nil {
+		return fmt.Errorf("failed to update migration window: %w", err)
+	}
+	return nil
+}
+
+// DeleteMigrationWindow deletes a migration window
+func (d *Database) DeleteMigrationWindow(ctx context.Context, id int64) error {
+	result := d.db.WithContext(ctx).Delete(&models.MigrationWindow{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete migration window: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("migration window %d not found", id)
+	}
+	return nil
+}
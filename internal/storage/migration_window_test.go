@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestMigrationWindows(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	starts := time.Date(2026, 12, 20, 0, 0, 0, 0, time.UTC)
+	ends := starts.Add(14 * 24 * time.Hour)
+	org := "payments"
+	freeze := &models.MigrationWindow{
+		Name:         "Year-end freeze",
+		Kind:         models.MigrationWindowKindBlackout,
+		Organization: &org,
+		TimeZone:     "UTC",
+		StartsAt:     &starts,
+		EndsAt:       &ends,
+		Policy:       models.MigrationWindowPolicyRefuse,
+	}
+	weekdays, startTime, endTime := "mon,tue,wed,thu,fri", "18:00", "06:00"
+	nights := &models.MigrationWindow{
+		Name:      "Weeknights",
+		Kind:      models.MigrationWindowKindAllowed,
+		TimeZone:  "America/New_York",
+		Weekdays:  &weekdays,
+		StartTime: &startTime,
+		EndTime:   &endTime,
+		Policy:    models.MigrationWindowPolicyDefer,
+	}
+	for _, window := range []*models.MigrationWindow{freeze, nights} {
+		if err := db.CreateMigrationWindow(ctx, window); err != nil {
+			t.Fatalf("CreateMigrationWindow() error = %v", err)
+		}
+	}
+
+	windows, err := db.ListMigrationWindows(ctx)
+	if err != nil {
+		t.Fatalf("ListMigrationWindows() error = %v", err)
+	}
+	if len(windows) != 2 || windows[0].Name != "Weeknights" || windows[1].Name != "Year-end freeze" {
+		t.Fatalf("Expected allowed windows before blackouts, got %+v", windows)
+	}
+	if !windows[1].StartsAt.Equal(starts) || *windows[1].Organization != org {
+		t.Errorf("Unexpected stored blackout %+v", windows[1])
+	}
+
+	freeze.Policy = models.MigrationWindowPolicyDefer
+	if err := db.UpdateMigrationWindow(ctx, freeze); err != nil {
+		t.Fatalf("UpdateMigrationWindow() error = %v", err)
+	}
+	got, err := db.GetMigrationWindow(ctx, freeze.ID)
+	if err != nil || got == nil || got.Policy != models.MigrationWindowPolicyDefer {
+		t.Fatalf("GetMigrationWindow() = %+v, %v", got, err)
+	}
+
+	if err := db.DeleteMigrationWindow(ctx, freeze.ID); err != nil {
+		t.Fatalf("DeleteMigrationWindow() error = %v", err)
+	}
+	if got, _ := db.GetMigrationWindow(ctx, freeze.ID); got != nil {
+		t.Errorf("Expected the window to be deleted, got %+v", got)
+	}
+	if err := db.DeleteMigrationWindow(ctx, freeze.ID); err == nil {
+		t.Error("Expected an error deleting a missing window")
+	}
+}
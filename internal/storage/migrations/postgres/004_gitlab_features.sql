@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add GitLab-specific feature columns populated by the GitLab profiler
+ALTER TABLE repository_features ADD COLUMN IF NOT EXISTS has_gitlab_ci BOOLEAN DEFAULT FALSE;
+ALTER TABLE repository_features ADD COLUMN IF NOT EXISTS merge_settings TEXT;
+
+-- +goose Down
+ALTER TABLE repository_features DROP COLUMN IF EXISTS merge_settings;
+ALTER TABLE repository_features DROP COLUMN IF EXISTS has_gitlab_ci;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Add Bitbucket-specific feature columns populated by the Bitbucket profiler
+ALTER TABLE repository_features ADD COLUMN IF NOT EXISTS has_bitbucket_pipelines BOOLEAN DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE repository_features DROP COLUMN IF EXISTS has_bitbucket_pipelines;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Add phase checkpoint columns so in-flight migrations can be resumed after a restart
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS checkpoint TEXT;
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS checkpoint_at TIMESTAMP;
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS git_archive_id BIGINT;
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS metadata_archive_id BIGINT;
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS git_archive_url TEXT;
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS metadata_archive_url TEXT;
+ALTER TABLE migration_history ADD COLUMN IF NOT EXISTS migration_id TEXT;
+
+-- +goose Down
+ALTER TABLE migration_history DROP COLUMN IF EXISTS migration_id;
+ALTER TABLE migration_history DROP COLUMN IF EXISTS metadata_archive_url;
+ALTER TABLE migration_history DROP COLUMN IF EXISTS git_archive_url;
+ALTER TABLE migration_history DROP COLUMN IF EXISTS metadata_archive_id;
+ALTER TABLE migration_history DROP COLUMN IF EXISTS git_archive_id;
+ALTER TABLE migration_history DROP COLUMN IF EXISTS checkpoint_at;
+ALTER TABLE migration_history DROP COLUMN IF EXISTS checkpoint;
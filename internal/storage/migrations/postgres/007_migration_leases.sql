@@ -0,0 +1,17 @@
+-- +goose Up
+-- Create table for migration worker leases so several server replicas can share the queue.
+-- A replica owns a repository's migration while its lease is unexpired; leases are extended
+-- by heartbeats and can be claimed by another replica once they expire.
+CREATE TABLE IF NOT EXISTS migration_leases (
+    repository_id BIGINT PRIMARY KEY REFERENCES repositories(id) ON DELETE CASCADE,
+    owner_id TEXT NOT NULL,
+    acquired_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    heartbeat_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_migration_leases_owner ON migration_leases(owner_id);
+CREATE INDEX IF NOT EXISTS idx_migration_leases_expires_at ON migration_leases(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS migration_leases;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Add delta sync columns: batches can pre-seed repositories with an unlocked migration
+-- and re-sync the changed git refs at the scheduled cutover
+ALTER TABLE batches ADD COLUMN IF NOT EXISTS delta_sync BOOLEAN DEFAULT FALSE;
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS pre_seeded_at TIMESTAMP;
+ALTER TABLE repositories ADD COLUMN IF NOT EXISTS pre_seed_commit_sha TEXT;
+
+-- +goose Down
+ALTER TABLE repositories DROP COLUMN IF EXISTS pre_seed_commit_sha;
+ALTER TABLE repositories DROP COLUMN IF EXISTS pre_seeded_at;
+ALTER TABLE batches DROP COLUMN IF EXISTS delta_sync;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Add the per-ref deep post-migration validation report
+ALTER TABLE repository_validation ADD COLUMN IF NOT EXISTS deep_validation_report TEXT;
+
+-- +goose Down
+ALTER TABLE repository_validation DROP COLUMN IF EXISTS deep_validation_report;
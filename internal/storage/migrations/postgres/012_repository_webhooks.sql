@@ -0,0 +1,24 @@
+-- +goose Up
+-- Create table tracking source webhooks recreated on the destination. Hook secrets cannot be
+-- read back, so hooks are created inactive and activated once their owners re-enter the secret.
+CREATE TABLE IF NOT EXISTS repository_webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    source_hook_id BIGINT NOT NULL,
+    destination_hook_id BIGINT,
+    url TEXT NOT NULL,
+    events TEXT,
+    content_type TEXT,
+    insecure_ssl BOOLEAN DEFAULT FALSE,
+    has_secret BOOLEAN DEFAULT FALSE,
+    status TEXT NOT NULL,
+    error TEXT,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    activated_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_repo_webhooks_repo ON repository_webhooks(repository_id);
+CREATE INDEX IF NOT EXISTS idx_repo_webhooks_status ON repository_webhooks(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_webhooks;
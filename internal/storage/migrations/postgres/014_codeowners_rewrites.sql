@@ -0,0 +1,21 @@
+-- +goose Up
+-- Create table recording the rewrite of each migrated repository's CODEOWNERS file with the
+-- team and user mappings, including the handles that could not be mapped.
+CREATE TABLE IF NOT EXISTS repository_codeowners_rewrites (
+    id BIGSERIAL PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    mode TEXT NOT NULL,
+    status TEXT NOT NULL,
+    path TEXT,
+    handles_rewritten INTEGER DEFAULT 0,
+    unmapped TEXT,
+    pull_request_number INTEGER,
+    pull_request_url TEXT,
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_repo_codeowners_rewrites_repo ON repository_codeowners_rewrites(repository_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_codeowners_rewrites;
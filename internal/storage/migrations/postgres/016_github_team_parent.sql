@@ -0,0 +1,6 @@
+-- +goose Up
+-- Add the parent team slug so nested teams can be recreated with their hierarchy
+ALTER TABLE github_teams ADD COLUMN IF NOT EXISTS parent_slug VARCHAR(255);
+
+-- +goose Down
+ALTER TABLE github_teams DROP COLUMN IF EXISTS parent_slug;
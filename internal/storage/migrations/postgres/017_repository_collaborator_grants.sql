@@ -0,0 +1,20 @@
+-- +goose Up
+-- Create table logging the direct collaborator permissions re-granted on migrated repositories
+-- through the user mappings.
+CREATE TABLE IF NOT EXISTS repository_collaborator_grants (
+    id BIGSERIAL PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    source_login VARCHAR(255) NOT NULL,
+    destination_login VARCHAR(255),
+    permission VARCHAR(100) NOT NULL,
+    outside BOOLEAN DEFAULT FALSE,
+    status VARCHAR(50) NOT NULL,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_repo_collaborator_grants_repo ON repository_collaborator_grants(repository_id);
+CREATE INDEX IF NOT EXISTS idx_repo_collaborator_grants_status ON repository_collaborator_grants(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_collaborator_grants;
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Add the per-setting report of the post-migration settings sync
+ALTER TABLE repository_validation ADD COLUMN IF NOT EXISTS settings_sync_report TEXT;
+
+-- +goose Down
+ALTER TABLE repository_validation DROP COLUMN IF EXISTS settings_sync_report;
@@ -0,0 +1,22 @@
+-- +goose Up
+-- Add batch completion actions run on the source after a successful production migration,
+-- and a table recording each action with the source state restored on rollback
+ALTER TABLE batches ADD COLUMN IF NOT EXISTS completion_actions TEXT;
+
+CREATE TABLE IF NOT EXISTS repository_completion_actions (
+    id BIGSERIAL PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    action VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    original_state TEXT,
+    reason TEXT,
+    reverted_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_repo_completion_actions_repo ON repository_completion_actions(repository_id);
+CREATE INDEX IF NOT EXISTS idx_repo_completion_actions_status ON repository_completion_actions(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_completion_actions;
+ALTER TABLE batches DROP COLUMN IF EXISTS completion_actions;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Add blackout and allowed migration windows checked by the scheduler and migration worker
+CREATE TABLE IF NOT EXISTS migration_windows (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    organization VARCHAR(255),
+    time_zone VARCHAR(100) NOT NULL DEFAULT 'UTC',
+    starts_at TIMESTAMP,
+    ends_at TIMESTAMP,
+    weekdays VARCHAR(50),
+    start_time VARCHAR(5),
+    end_time VARCHAR(5),
+    policy VARCHAR(50) NOT NULL DEFAULT 'defer',
+    description TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_migration_windows_kind ON migration_windows(kind);
+CREATE INDEX IF NOT EXISTS idx_migration_windows_organization ON migration_windows(organization);
+
+-- +goose Down
+DROP TABLE IF EXISTS migration_windows;
@@ -0,0 +1,12 @@
+-- +goose Up
+-- Add batch dependencies: a batch with a dependency starts only after the batch it depends on
+-- completes its production migration with at most max_dependency_failures failed repositories
+ALTER TABLE batches ADD COLUMN IF NOT EXISTS depends_on_batch_id BIGINT;
+ALTER TABLE batches ADD COLUMN IF NOT EXISTS max_dependency_failures INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_batches_depends_on ON batches(depends_on_batch_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_batches_depends_on;
+ALTER TABLE batches DROP COLUMN IF EXISTS max_dependency_failures;
+ALTER TABLE batches DROP COLUMN IF EXISTS depends_on_batch_id;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Add the audit log of two-person approvals required before a batch's production migration
+CREATE TABLE IF NOT EXISTS batch_approvals (
+    id BIGSERIAL PRIMARY KEY,
+    batch_id BIGINT NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    comment TEXT,
+    dry_run_summary JSONB,
+    used_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_batch_approvals_batch_id ON batch_approvals(batch_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS batch_approvals;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add GitLab-specific feature columns populated by the GitLab profiler
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE repository_features ADD COLUMN has_gitlab_ci INTEGER DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE repository_features ADD COLUMN merge_settings TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+ALTER TABLE repository_features DROP COLUMN merge_settings;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE repository_features DROP COLUMN has_gitlab_ci;
+-- +goose StatementEnd
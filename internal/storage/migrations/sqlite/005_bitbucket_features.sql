@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add Bitbucket-specific feature columns populated by the Bitbucket profiler
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE repository_features ADD COLUMN has_bitbucket_pipelines INTEGER DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+ALTER TABLE repository_features DROP COLUMN has_bitbucket_pipelines;
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add phase checkpoint columns so in-flight migrations can be resumed after a restart
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN checkpoint TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN checkpoint_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN git_archive_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN metadata_archive_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN git_archive_url TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN metadata_archive_url TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history ADD COLUMN migration_id TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN migration_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN metadata_archive_url;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN git_archive_url;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN metadata_archive_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN git_archive_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN checkpoint_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE migration_history DROP COLUMN checkpoint;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Create table for leader locks so singleton loops (batch scheduler, batch status updater)
+-- run on only one server replica. The fencing token increases every time the lock changes
+-- owner, so a replica that lost the lock can detect that it is no longer the leader.
+CREATE TABLE IF NOT EXISTS leader_locks (
+    name TEXT PRIMARY KEY,
+    owner_id TEXT NOT NULL,
+    fencing_token INTEGER NOT NULL DEFAULT 1,
+    acquired_at DATETIME NOT NULL,
+    renewed_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS leader_locks;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add delta sync columns: batches can pre-seed repositories with an unlocked migration
+-- and re-sync the changed git refs at the scheduled cutover
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE batches ADD COLUMN delta_sync INTEGER DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE repositories ADD COLUMN pre_seeded_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE repositories ADD COLUMN pre_seed_commit_sha TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+ALTER TABLE repositories DROP COLUMN pre_seed_commit_sha;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE repositories DROP COLUMN pre_seeded_at;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE batches DROP COLUMN delta_sync;
+-- +goose StatementEnd
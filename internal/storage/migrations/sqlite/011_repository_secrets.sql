@@ -0,0 +1,16 @@
+-- +goose Up
+-- Create table for the per-repository "secrets to re-enter" checklist. Actions secret values
+-- cannot be read from the source, so migrated secrets are recreated as named placeholders.
+CREATE TABLE IF NOT EXISTS repository_secrets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    environment TEXT NOT NULL DEFAULT '',
+    name TEXT NOT NULL,
+    placeholder_created INTEGER DEFAULT 0,
+    recorded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_repo_secrets_repo ON repository_secrets(repository_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_secrets;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Create table tracking source webhooks recreated on the destination. Hook secrets cannot be
+-- read back, so hooks are created inactive and activated once their owners re-enter the secret.
+CREATE TABLE IF NOT EXISTS repository_webhooks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    source_hook_id INTEGER NOT NULL,
+    destination_hook_id INTEGER,
+    url TEXT NOT NULL,
+    events TEXT,
+    content_type TEXT,
+    insecure_ssl INTEGER DEFAULT 0,
+    has_secret INTEGER DEFAULT 0,
+    status TEXT NOT NULL,
+    error TEXT,
+    recorded_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    activated_at DATETIME
+);
+
+CREATE INDEX IF NOT EXISTS idx_repo_webhooks_repo ON repository_webhooks(repository_id);
+CREATE INDEX IF NOT EXISTS idx_repo_webhooks_status ON repository_webhooks(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_webhooks;
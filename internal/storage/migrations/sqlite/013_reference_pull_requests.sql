@@ -0,0 +1,21 @@
+-- +goose Up
+-- Create table recording the pull request opened on each migrated repository to rewrite its
+-- submodule, workflow and manifest references to the destination.
+CREATE TABLE IF NOT EXISTS repository_reference_prs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    number INTEGER,
+    url TEXT,
+    branch TEXT,
+    files_changed INTEGER DEFAULT 0,
+    references_rewritten INTEGER DEFAULT 0,
+    unresolved TEXT,
+    error TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_repo_reference_prs_repo ON repository_reference_prs(repository_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_reference_prs;
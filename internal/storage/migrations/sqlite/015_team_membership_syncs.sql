@@ -0,0 +1,25 @@
+-- +goose Up
+-- Create table recording the outcome of syncing each team's members to its destination team,
+-- including the members without a user mapping.
+CREATE TABLE IF NOT EXISTS team_membership_syncs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_id INTEGER,
+    source_org TEXT NOT NULL,
+    source_team_slug TEXT NOT NULL,
+    destination_org TEXT NOT NULL,
+    destination_team_slug TEXT NOT NULL,
+    status TEXT NOT NULL,
+    reason TEXT,
+    members_added INTEGER DEFAULT 0,
+    maintainers_added INTEGER DEFAULT 0,
+    unmapped TEXT,
+    failed TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_membership_sync_source ON team_membership_syncs(source_org, source_team_slug);
+CREATE INDEX IF NOT EXISTS idx_team_membership_syncs_source_id ON team_membership_syncs(source_id);
+CREATE INDEX IF NOT EXISTS idx_team_membership_syncs_status ON team_membership_syncs(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS team_membership_syncs;
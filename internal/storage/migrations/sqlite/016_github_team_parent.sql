@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add the parent team slug so nested teams can be recreated with their hierarchy
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE github_teams ADD COLUMN parent_slug TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+ALTER TABLE github_teams DROP COLUMN parent_slug;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add the per-setting report of the post-migration settings sync
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE repository_validation ADD COLUMN settings_sync_report TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+ALTER TABLE repository_validation DROP COLUMN settings_sync_report;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add batch completion actions run on the source after a successful production migration,
+-- and a table recording each action with the source state restored on rollback
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE batches ADD COLUMN completion_actions TEXT;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS repository_completion_actions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    repository_id INTEGER NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    action TEXT NOT NULL,
+    status TEXT NOT NULL,
+    original_state TEXT,
+    reason TEXT,
+    reverted_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_repo_completion_actions_repo ON repository_completion_actions(repository_id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_repo_completion_actions_status ON repository_completion_actions(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+DROP TABLE IF EXISTS repository_completion_actions;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE batches DROP COLUMN completion_actions;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- Add blackout and allowed migration windows checked by the scheduler and migration worker
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS migration_windows (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    organization TEXT,
+    time_zone TEXT NOT NULL DEFAULT 'UTC',
+    starts_at DATETIME,
+    ends_at DATETIME,
+    weekdays TEXT,
+    start_time TEXT,
+    end_time TEXT,
+    policy TEXT NOT NULL DEFAULT 'defer',
+    description TEXT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_migration_windows_kind ON migration_windows(kind);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_migration_windows_organization ON migration_windows(organization);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS migration_windows;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose NO TRANSACTION
+-- Add batch dependencies: a batch with a dependency starts only after the batch it depends on
+-- completes its production migration with at most max_dependency_failures failed repositories
+-- Note: SQLite doesn't support IF NOT EXISTS for ADD COLUMN.
+-- Using NO TRANSACTION mode allows the statement to fail gracefully
+-- if the column already exists (making migration idempotent for re-runs).
+
+-- +goose StatementBegin
+ALTER TABLE batches ADD COLUMN depends_on_batch_id INTEGER;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE batches ADD COLUMN max_dependency_failures INTEGER;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_batches_depends_on ON batches(depends_on_batch_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose NO TRANSACTION
+-- Note: DROP COLUMN requires SQLite 3.35.0+ (March 2021)
+
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_batches_depends_on;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE batches DROP COLUMN max_dependency_failures;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE batches DROP COLUMN depends_on_batch_id;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Add the audit log of two-person approvals required before a batch's production migration
+
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS batch_approvals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    batch_id INTEGER NOT NULL,
+    action TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    comment TEXT,
+    dry_run_summary TEXT,
+    used_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS idx_batch_approvals_batch_id ON batch_approvals(batch_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS batch_approvals;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Add GitLab-specific feature columns populated by the GitLab profiler
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_features') AND name = 'has_gitlab_ci')
+    ALTER TABLE repository_features ADD has_gitlab_ci BIT DEFAULT 0;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_features') AND name = 'merge_settings')
+    ALTER TABLE repository_features ADD merge_settings NVARCHAR(MAX);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_features') AND name = 'merge_settings')
+    ALTER TABLE repository_features DROP COLUMN merge_settings;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_features') AND name = 'has_gitlab_ci')
+    ALTER TABLE repository_features DROP COLUMN has_gitlab_ci;
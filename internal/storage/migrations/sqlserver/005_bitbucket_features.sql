@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add Bitbucket-specific feature columns populated by the Bitbucket profiler
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_features') AND name = 'has_bitbucket_pipelines')
+    ALTER TABLE repository_features ADD has_bitbucket_pipelines BIT DEFAULT 0;
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_features') AND name = 'has_bitbucket_pipelines')
+    ALTER TABLE repository_features DROP COLUMN has_bitbucket_pipelines;
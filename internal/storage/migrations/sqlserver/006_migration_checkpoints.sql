@@ -0,0 +1,38 @@
+-- +goose Up
+-- Add phase checkpoint columns so in-flight migrations can be resumed after a restart
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'checkpoint')
+    ALTER TABLE migration_history ADD checkpoint NVARCHAR(255);
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'checkpoint_at')
+    ALTER TABLE migration_history ADD checkpoint_at DATETIME2;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'git_archive_id')
+    ALTER TABLE migration_history ADD git_archive_id BIGINT;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'metadata_archive_id')
+    ALTER TABLE migration_history ADD metadata_archive_id BIGINT;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'git_archive_url')
+    ALTER TABLE migration_history ADD git_archive_url NVARCHAR(MAX);
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'metadata_archive_url')
+    ALTER TABLE migration_history ADD metadata_archive_url NVARCHAR(MAX);
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'migration_id')
+    ALTER TABLE migration_history ADD migration_id NVARCHAR(255);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'migration_id')
+    ALTER TABLE migration_history DROP COLUMN migration_id;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'metadata_archive_url')
+    ALTER TABLE migration_history DROP COLUMN metadata_archive_url;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'git_archive_url')
+    ALTER TABLE migration_history DROP COLUMN git_archive_url;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'metadata_archive_id')
+    ALTER TABLE migration_history DROP COLUMN metadata_archive_id;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'git_archive_id')
+    ALTER TABLE migration_history DROP COLUMN git_archive_id;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'checkpoint_at')
+    ALTER TABLE migration_history DROP COLUMN checkpoint_at;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'migration_history') AND name = 'checkpoint')
+    ALTER TABLE migration_history DROP COLUMN checkpoint;
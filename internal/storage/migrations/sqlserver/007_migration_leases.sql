@@ -0,0 +1,21 @@
+-- +goose Up
+-- Create table for migration worker leases so several server replicas can share the queue.
+-- A replica owns a repository's migration while its lease is unexpired; leases are extended
+-- by heartbeats and can be claimed by another replica once they expire.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'migration_leases')
+CREATE TABLE migration_leases (
+    repository_id BIGINT PRIMARY KEY REFERENCES repositories(id) ON DELETE CASCADE,
+    owner_id NVARCHAR(255) NOT NULL,
+    acquired_at DATETIME2 NOT NULL,
+    heartbeat_at DATETIME2 NOT NULL,
+    expires_at DATETIME2 NOT NULL
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_migration_leases_owner')
+    CREATE INDEX idx_migration_leases_owner ON migration_leases(owner_id);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_migration_leases_expires_at')
+    CREATE INDEX idx_migration_leases_expires_at ON migration_leases(expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS migration_leases;
@@ -0,0 +1,16 @@
+-- +goose Up
+-- Create table for leader locks so singleton loops (batch scheduler, batch status updater)
+-- run on only one server replica. The fencing token increases every time the lock changes
+-- owner, so a replica that lost the lock can detect that it is no longer the leader.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'leader_locks')
+CREATE TABLE leader_locks (
+    name NVARCHAR(255) PRIMARY KEY,
+    owner_id NVARCHAR(255) NOT NULL,
+    fencing_token BIGINT NOT NULL DEFAULT 1,
+    acquired_at DATETIME2 NOT NULL,
+    renewed_at DATETIME2 NOT NULL,
+    expires_at DATETIME2 NOT NULL
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS leader_locks;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Add delta sync columns: batches can pre-seed repositories with an unlocked migration
+-- and re-sync the changed git refs at the scheduled cutover
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'delta_sync')
+    ALTER TABLE batches ADD delta_sync BIT DEFAULT 0;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repositories') AND name = 'pre_seeded_at')
+    ALTER TABLE repositories ADD pre_seeded_at DATETIME2;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repositories') AND name = 'pre_seed_commit_sha')
+    ALTER TABLE repositories ADD pre_seed_commit_sha NVARCHAR(255);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repositories') AND name = 'pre_seed_commit_sha')
+    ALTER TABLE repositories DROP COLUMN pre_seed_commit_sha;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repositories') AND name = 'pre_seeded_at')
+    ALTER TABLE repositories DROP COLUMN pre_seeded_at;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'delta_sync')
+    ALTER TABLE batches DROP COLUMN delta_sync;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add the per-ref deep post-migration validation report
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_validation') AND name = 'deep_validation_report')
+    ALTER TABLE repository_validation ADD deep_validation_report NVARCHAR(MAX);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_validation') AND name = 'deep_validation_report')
+    ALTER TABLE repository_validation DROP COLUMN deep_validation_report;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- Create table for the per-repository "secrets to re-enter" checklist. Actions secret values
+-- cannot be read from the source, so migrated secrets are recreated as named placeholders.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'repository_secrets')
+CREATE TABLE repository_secrets (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    environment NVARCHAR(255) NOT NULL DEFAULT '',
+    name NVARCHAR(255) NOT NULL,
+    placeholder_created BIT DEFAULT 0,
+    recorded_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_secrets_repo')
+CREATE INDEX idx_repo_secrets_repo ON repository_secrets(repository_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_secrets;
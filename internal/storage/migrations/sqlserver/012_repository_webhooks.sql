@@ -0,0 +1,28 @@
+-- +goose Up
+-- Create table tracking source webhooks recreated on the destination. Hook secrets cannot be
+-- read back, so hooks are created inactive and activated once their owners re-enter the secret.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'repository_webhooks')
+CREATE TABLE repository_webhooks (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    source_hook_id BIGINT NOT NULL,
+    destination_hook_id BIGINT,
+    url NVARCHAR(2048) NOT NULL,
+    events NVARCHAR(MAX),
+    content_type NVARCHAR(50),
+    insecure_ssl BIT DEFAULT 0,
+    has_secret BIT DEFAULT 0,
+    status NVARCHAR(50) NOT NULL,
+    error NVARCHAR(MAX),
+    recorded_at DATETIME2 NOT NULL DEFAULT GETUTCDATE(),
+    activated_at DATETIME2
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_webhooks_repo')
+CREATE INDEX idx_repo_webhooks_repo ON repository_webhooks(repository_id);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_webhooks_status')
+CREATE INDEX idx_repo_webhooks_status ON repository_webhooks(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_webhooks;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Create table recording the pull request opened on each migrated repository to rewrite its
+-- submodule, workflow and manifest references to the destination.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'repository_reference_prs')
+CREATE TABLE repository_reference_prs (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    status NVARCHAR(50) NOT NULL,
+    number INT,
+    url NVARCHAR(2048),
+    branch NVARCHAR(255),
+    files_changed INT DEFAULT 0,
+    references_rewritten INT DEFAULT 0,
+    unresolved NVARCHAR(MAX),
+    error NVARCHAR(MAX),
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_reference_prs_repo')
+CREATE UNIQUE INDEX idx_repo_reference_prs_repo ON repository_reference_prs(repository_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_reference_prs;
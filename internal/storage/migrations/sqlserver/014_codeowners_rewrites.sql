@@ -0,0 +1,23 @@
+-- +goose Up
+-- Create table recording the rewrite of each migrated repository's CODEOWNERS file with the
+-- team and user mappings, including the handles that could not be mapped.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'repository_codeowners_rewrites')
+CREATE TABLE repository_codeowners_rewrites (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    mode NVARCHAR(50) NOT NULL,
+    status NVARCHAR(50) NOT NULL,
+    path NVARCHAR(255),
+    handles_rewritten INT DEFAULT 0,
+    unmapped NVARCHAR(MAX),
+    pull_request_number INT,
+    pull_request_url NVARCHAR(2048),
+    error NVARCHAR(MAX),
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_codeowners_rewrites_repo')
+CREATE UNIQUE INDEX idx_repo_codeowners_rewrites_repo ON repository_codeowners_rewrites(repository_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_codeowners_rewrites;
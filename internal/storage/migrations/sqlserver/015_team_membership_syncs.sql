@@ -0,0 +1,31 @@
+-- +goose Up
+-- Create table recording the outcome of syncing each team's members to its destination team,
+-- including the members without a user mapping.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'team_membership_syncs')
+CREATE TABLE team_membership_syncs (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    source_id BIGINT,
+    source_org NVARCHAR(255) NOT NULL,
+    source_team_slug NVARCHAR(255) NOT NULL,
+    destination_org NVARCHAR(255) NOT NULL,
+    destination_team_slug NVARCHAR(255) NOT NULL,
+    status NVARCHAR(50) NOT NULL,
+    reason NVARCHAR(MAX),
+    members_added INT DEFAULT 0,
+    maintainers_added INT DEFAULT 0,
+    unmapped NVARCHAR(MAX),
+    failed NVARCHAR(MAX),
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_team_membership_sync_source')
+CREATE UNIQUE INDEX idx_team_membership_sync_source ON team_membership_syncs(source_org, source_team_slug);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_team_membership_syncs_source_id')
+CREATE INDEX idx_team_membership_syncs_source_id ON team_membership_syncs(source_id);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_team_membership_syncs_status')
+CREATE INDEX idx_team_membership_syncs_status ON team_membership_syncs(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS team_membership_syncs;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add the parent team slug so nested teams can be recreated with their hierarchy
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'github_teams') AND name = 'parent_slug')
+    ALTER TABLE github_teams ADD parent_slug NVARCHAR(255);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'github_teams') AND name = 'parent_slug')
+    ALTER TABLE github_teams DROP COLUMN parent_slug;
@@ -0,0 +1,24 @@
+-- +goose Up
+-- Create table logging the direct collaborator permissions re-granted on migrated repositories
+-- through the user mappings.
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'repository_collaborator_grants')
+CREATE TABLE repository_collaborator_grants (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    source_login NVARCHAR(255) NOT NULL,
+    destination_login NVARCHAR(255),
+    permission NVARCHAR(100) NOT NULL,
+    outside BIT DEFAULT 0,
+    status NVARCHAR(50) NOT NULL,
+    reason NVARCHAR(MAX),
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_collaborator_grants_repo')
+CREATE INDEX idx_repo_collaborator_grants_repo ON repository_collaborator_grants(repository_id);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_collaborator_grants_status')
+CREATE INDEX idx_repo_collaborator_grants_status ON repository_collaborator_grants(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_collaborator_grants;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Add the per-setting report of the post-migration settings sync
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_validation') AND name = 'settings_sync_report')
+    ALTER TABLE repository_validation ADD settings_sync_report NVARCHAR(MAX);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'repository_validation') AND name = 'settings_sync_report')
+    ALTER TABLE repository_validation DROP COLUMN settings_sync_report;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Add batch completion actions run on the source after a successful production migration,
+-- and a table recording each action with the source state restored on rollback
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'completion_actions')
+    ALTER TABLE batches ADD completion_actions NVARCHAR(MAX);
+
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'repository_completion_actions')
+CREATE TABLE repository_completion_actions (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    repository_id BIGINT NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    action NVARCHAR(50) NOT NULL,
+    status NVARCHAR(50) NOT NULL,
+    original_state NVARCHAR(MAX),
+    reason NVARCHAR(MAX),
+    reverted_at DATETIME2,
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_completion_actions_repo')
+CREATE INDEX idx_repo_completion_actions_repo ON repository_completion_actions(repository_id);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_repo_completion_actions_status')
+CREATE INDEX idx_repo_completion_actions_status ON repository_completion_actions(status);
+
+-- +goose Down
+DROP TABLE IF EXISTS repository_completion_actions;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'completion_actions')
+    ALTER TABLE batches DROP COLUMN completion_actions;
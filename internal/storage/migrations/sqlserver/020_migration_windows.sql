@@ -0,0 +1,28 @@
+-- +goose Up
+-- Add blackout and allowed migration windows checked by the scheduler and migration worker
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'migration_windows')
+CREATE TABLE migration_windows (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    name NVARCHAR(255) NOT NULL,
+    kind NVARCHAR(50) NOT NULL,
+    organization NVARCHAR(255),
+    time_zone NVARCHAR(100) NOT NULL DEFAULT 'UTC',
+    starts_at DATETIME2,
+    ends_at DATETIME2,
+    weekdays NVARCHAR(50),
+    start_time NVARCHAR(5),
+    end_time NVARCHAR(5),
+    policy NVARCHAR(50) NOT NULL DEFAULT 'defer',
+    description NVARCHAR(MAX),
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE(),
+    updated_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_migration_windows_kind')
+CREATE INDEX idx_migration_windows_kind ON migration_windows(kind);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_migration_windows_organization')
+CREATE INDEX idx_migration_windows_organization ON migration_windows(organization);
+
+-- +goose Down
+DROP TABLE IF EXISTS migration_windows;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Add batch dependencies: a batch with a dependency starts only after the batch it depends on
+-- completes its production migration with at most max_dependency_failures failed repositories
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'depends_on_batch_id')
+    ALTER TABLE batches ADD depends_on_batch_id BIGINT;
+
+IF NOT EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'max_dependency_failures')
+    ALTER TABLE batches ADD max_dependency_failures INT;
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_batches_depends_on')
+CREATE INDEX idx_batches_depends_on ON batches(depends_on_batch_id);
+
+-- +goose Down
+IF EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_batches_depends_on')
+    DROP INDEX idx_batches_depends_on ON batches;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'max_dependency_failures')
+    ALTER TABLE batches DROP COLUMN max_dependency_failures;
+IF EXISTS (SELECT * FROM sys.columns WHERE object_id = OBJECT_ID(N'batches') AND name = 'depends_on_batch_id')
+    ALTER TABLE batches DROP COLUMN depends_on_batch_id;
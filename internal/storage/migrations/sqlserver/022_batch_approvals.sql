@@ -0,0 +1,19 @@
+-- +goose Up
+-- Add the audit log of two-person approvals required before a batch's production migration
+IF NOT EXISTS (SELECT * FROM sys.tables WHERE name = 'batch_approvals')
+CREATE TABLE batch_approvals (
+    id BIGINT IDENTITY(1,1) PRIMARY KEY,
+    batch_id BIGINT NOT NULL,
+    action NVARCHAR(50) NOT NULL,
+    actor NVARCHAR(255) NOT NULL,
+    comment NVARCHAR(MAX),
+    dry_run_summary NVARCHAR(MAX),
+    used_at DATETIME2,
+    created_at DATETIME2 NOT NULL DEFAULT GETUTCDATE()
+);
+
+IF NOT EXISTS (SELECT * FROM sys.indexes WHERE name = 'idx_batch_approvals_batch_id')
+CREATE INDEX idx_batch_approvals_batch_id ON batch_approvals(batch_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS batch_approvals;
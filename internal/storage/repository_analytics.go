@@ -1022,10 +1022,11 @@ func (d *Database) GetProjectStatsFiltered(ctx context.Context, orgFilter, proje
 
 // DashboardActionItems contains all action items requiring admin attention
 type DashboardActionItems struct {
-	FailedMigrations    []*FailedRepository  `json:"failed_migrations"`
-	FailedDryRuns       []*FailedRepository  `json:"failed_dry_runs"`
-	ReadyBatches        []*models.Batch      `json:"ready_batches"`
-	BlockedRepositories []*models.Repository `json:"blocked_repositories"`
+	FailedMigrations    []*FailedRepository         `json:"failed_migrations"`
+	FailedDryRuns       []*FailedRepository         `json:"failed_dry_runs"`
+	ReadyBatches        []*models.Batch             `json:"ready_batches"`
+	BlockedRepositories []*models.Repository        `json:"blocked_repositories"`
+	PendingWebhooks     []*PendingWebhookRepository `json:"pending_webhooks"`
 }
 
 // FailedRepository represents a repository that needs attention
@@ -1047,6 +1048,7 @@ func (d *Database) GetDashboardActionItems(ctx context.Context) (*DashboardActio
 		FailedDryRuns:       make([]*FailedRepository, 0),
 		ReadyBatches:        make([]*models.Batch, 0),
 		BlockedRepositories: make([]*models.Repository, 0),
+		PendingWebhooks:     make([]*PendingWebhookRepository, 0),
 	}
 
 	// Get failed migrations
@@ -1151,5 +1153,11 @@ func (d *Database) GetDashboardActionItems(ctx context.Context) (*DashboardActio
 		return nil, fmt.Errorf("failed to get blocked repositories: %w", err)
 	}
 
+	// Get migrated repositories whose recreated webhooks still wait for activation
+	actionItems.PendingWebhooks, err = d.GetPendingWebhookRepositories(ctx, 50)
+	if err != nil {
+		return nil, err
+	}
+
 	return actionItems, nil
 }
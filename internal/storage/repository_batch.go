@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
@@ -47,6 +48,63 @@ func (d *Database) UpdateBatch(ctx context.Context, batch *models.Batch) error {
 	return result.Error
 }
 
+// ClaimBatchStart atomically moves a batch from the given status to in_progress. With a fence,
+// the update only applies while the fence's leader lock is still current, so a leader that lost
+// its lock cannot start a batch the new leader also starts.
+// Returns false (with no error) if the batch's status changed or the fence is stale.
+func (d *Database) ClaimBatchStart(ctx context.Context, batchID int64, fromStatus string, fence *LeaderFence) (bool, error) {
+	claimed, err := d.claimBatchStart(d.db.WithContext(ctx), batchID, fromStatus, fence)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim batch start: %w", err)
+	}
+	return claimed, nil
+}
+
+// ClaimApprovedBatchStart claims a batch start like ClaimBatchStart and, in the same transaction,
+// uses the approval the start needs like UseBatchApproval. Either both happen or neither does, so
+// a start that loses its claim does not use up the approval.
+// Returns false (recording nothing) if the claim is lost or the approval was already used.
+func (d *Database) ClaimApprovedBatchStart(ctx context.Context, batchID int64, fromStatus string, fence *LeaderFence, approvalID int64, start *models.BatchApproval) (bool, error) {
+	claimed := false
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		ok, err := d.claimBatchStart(tx, batchID, fromStatus, fence)
+		if err != nil || !ok {
+			return err
+		}
+		ok, err = useBatchApproval(tx, approvalID, start)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			// Undo the claim
+			return errApprovalUsed
+		}
+		claimed = true
+		return nil
+	})
+	if errors.Is(err, errApprovalUsed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim approved batch start: %w", err)
+	}
+	return claimed, nil
+}
+
+// claimBatchStart moves a batch from the given status to in_progress within tx
+func (d *Database) claimBatchStart(tx *gorm.DB, batchID int64, fromStatus string, fence *LeaderFence) (bool, error) {
+	query := tx.Model(&models.Batch{}).
+		Where("id = ? AND status = ?", batchID, fromStatus)
+	if fence != nil {
+		query = query.Where("EXISTS (?)", d.fenceCondition(fence))
+	}
+	result := query.Update("status", batchStatusInProgress)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
 // ListBatches retrieves all batches using GORM
 func (d *Database) ListBatches(ctx context.Context) ([]*models.Batch, error) {
 	var batches []*models.Batch
@@ -100,6 +158,17 @@ func (d *Database) DeleteBatch(ctx context.Context, batchID int64) error {
 			return fmt.Errorf("failed to clear batch from repositories: %w", result.Error)
 		}
 
+		// Batches that depended on this one no longer wait for it
+		result = tx.Model(&models.Batch{}).
+			Where("depends_on_batch_id = ?", batchID).
+			Updates(map[string]any{
+				"depends_on_batch_id":     nil,
+				"max_dependency_failures": nil,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("failed to clear batch dependencies: %w", result.Error)
+		}
+
 		// Delete the batch
 		result = tx.Delete(&models.Batch{}, batchID)
 		if result.Error != nil {
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveCodeownersRewrite replaces the CODEOWNERS rewrite recorded for a repository
+func (d *Database) SaveCodeownersRewrite(ctx context.Context, rewrite *models.CodeownersRewrite) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", rewrite.RepositoryID).Delete(&models.CodeownersRewrite{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing CODEOWNERS rewrite: %w", err)
+		}
+
+		rewrite.ID = 0
+		if err := tx.Create(rewrite).Error; err != nil {
+			return fmt.Errorf("failed to insert CODEOWNERS rewrite: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetCodeownersRewrite retrieves the CODEOWNERS rewrite recorded for a repository.
+// Returns nil when the file has not been rewritten.
+func (d *Database) GetCodeownersRewrite(ctx context.Context, repoID int64) (*models.CodeownersRewrite, error) {
+	var rewrite models.CodeownersRewrite
+	err := d.db.WithContext(ctx).Where("repository_id = ?", repoID).First(&rewrite).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query CODEOWNERS rewrite: %w", err)
+	}
+
+	return &rewrite, nil
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestCodeownersRewrite(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	got, err := db.GetCodeownersRewrite(ctx, saved.ID)
+	if err != nil || got != nil {
+		t.Fatalf("GetCodeownersRewrite() before save = %v, %v", got, err)
+	}
+
+	if err := db.SaveCodeownersRewrite(ctx, &models.CodeownersRewrite{
+		RepositoryID: saved.ID,
+		Mode:         "pull_request",
+		Status:       models.CodeownersStatusNotFound,
+	}); err != nil {
+		t.Fatalf("SaveCodeownersRewrite() error = %v", err)
+	}
+
+	unmapped := "@test-org/old-team,@departed-user"
+	if err := db.SaveCodeownersRewrite(ctx, &models.CodeownersRewrite{
+		RepositoryID:     saved.ID,
+		Mode:             "commit",
+		Status:           models.CodeownersStatusCommitted,
+		Path:             ".github/CODEOWNERS",
+		HandlesRewritten: 4,
+		Unmapped:         &unmapped,
+	}); err != nil {
+		t.Fatalf("SaveCodeownersRewrite() error = %v", err)
+	}
+
+	got, err = db.GetCodeownersRewrite(ctx, saved.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetCodeownersRewrite() = %v, %v", got, err)
+	}
+	if got.Status != models.CodeownersStatusCommitted || got.Path != ".github/CODEOWNERS" || got.HandlesRewritten != 4 {
+		t.Errorf("expected the commit to replace the earlier rewrite, got %+v", got)
+	}
+	if got.Unmapped == nil || *got.Unmapped != unmapped {
+		t.Errorf("unexpected unmapped handles %v", got.Unmapped)
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveCollaboratorGrants replaces the collaborator grants logged for a repository
+func (d *Database) SaveCollaboratorGrants(ctx context.Context, repoID int64, grants []*models.CollaboratorGrant) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", repoID).Delete(&models.CollaboratorGrant{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing collaborator grants: %w", err)
+		}
+
+		if len(grants) > 0 {
+			for _, grant := range grants {
+				grant.ID = 0
+				grant.RepositoryID = repoID
+			}
+			if err := tx.Create(grants).Error; err != nil {
+				return fmt.Errorf("failed to insert collaborator grants: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetCollaboratorGrants retrieves the collaborator grants logged for a repository
+func (d *Database) GetCollaboratorGrants(ctx context.Context, repoID int64) ([]*models.CollaboratorGrant, error) {
+	// Initialize as empty slice instead of nil so JSON serialization returns [] not null
+	grants := make([]*models.CollaboratorGrant, 0)
+
+	err := d.db.WithContext(ctx).
+		Where("repository_id = ?", repoID).
+		Order("source_login").
+		Find(&grants).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query collaborator grants: %w", err)
+	}
+
+	return grants, nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestCollaboratorGrants(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	grants, err := db.GetCollaboratorGrants(ctx, saved.ID)
+	if err != nil || len(grants) != 0 {
+		t.Fatalf("GetCollaboratorGrants() before save = %v, %v", grants, err)
+	}
+
+	if err := db.SaveCollaboratorGrants(ctx, saved.ID, []*models.CollaboratorGrant{
+		{SourceLogin: "old", Permission: "read", Status: models.CollaboratorGrantStatusUnmapped},
+	}); err != nil {
+		t.Fatalf("SaveCollaboratorGrants() error = %v", err)
+	}
+
+	alice := "alice-acme"
+	if err := db.SaveCollaboratorGrants(ctx, saved.ID, []*models.CollaboratorGrant{
+		{SourceLogin: "vendor", Permission: "read", Outside: true, Status: models.CollaboratorGrantStatusSkipped},
+		{SourceLogin: "alice", DestinationLogin: &alice, Permission: "maintain", Status: models.CollaboratorGrantStatusGranted},
+	}); err != nil {
+		t.Fatalf("SaveCollaboratorGrants() error = %v", err)
+	}
+
+	grants, err = db.GetCollaboratorGrants(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetCollaboratorGrants() error = %v", err)
+	}
+	if len(grants) != 2 {
+		t.Fatalf("Expected saving again to replace the grants, got %d", len(grants))
+	}
+	if grants[0].SourceLogin != "alice" || *grants[0].DestinationLogin != alice || grants[0].RepositoryID != saved.ID {
+		t.Errorf("Unexpected first grant %+v", grants[0])
+	}
+	if grants[1].SourceLogin != "vendor" || !grants[1].Outside || grants[1].Status != models.CollaboratorGrantStatusSkipped {
+		t.Errorf("Unexpected second grant %+v", grants[1])
+	}
+}
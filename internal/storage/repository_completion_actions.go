@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveCompletionActions replaces the completion actions recorded for a repository
+func (d *Database) SaveCompletionActions(ctx context.Context, repoID int64, actions []*models.CompletionAction) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", repoID).Delete(&models.CompletionAction{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing completion actions: %w", err)
+		}
+
+		if len(actions) > 0 {
+			for _, action := range actions {
+				action.ID = 0
+				action.RepositoryID = repoID
+			}
+			if err := tx.Create(actions).Error; err != nil {
+				return fmt.Errorf("failed to insert completion actions: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetCompletionActions retrieves the completion actions recorded for a repository in the order they ran
+func (d *Database) GetCompletionActions(ctx context.Context, repoID int64) ([]*models.CompletionAction, error) {
+	// Initialize as empty slice instead of nil so JSON serialization returns [] not null
+	actions := make([]*models.CompletionAction, 0)
+
+	err := d.db.WithContext(ctx).
+		Where("repository_id = ?", repoID).
+		Order("id").
+		Find(&actions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query completion actions: %w", err)
+	}
+
+	return actions, nil
+}
+
+// MarkCompletionActionReverted records that rollback restored the source state changed by a completion action
+func (d *Database) MarkCompletionActionReverted(ctx context.Context, id int64) error {
+	result := d.db.WithContext(ctx).
+		Model(&models.CompletionAction{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":      models.CompletionActionStatusReverted,
+			"reverted_at": time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to mark completion action reverted: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("completion action %d not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestCompletionActions(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	if err := db.SaveCompletionActions(ctx, saved.ID, []*models.CompletionAction{
+		{Action: "archive", Status: models.CompletionActionStatusFailed},
+	}); err != nil {
+		t.Fatalf("SaveCompletionActions() error = %v", err)
+	}
+
+	state := `{"description":"Billing service"}`
+	if err := db.SaveCompletionActions(ctx, saved.ID, []*models.CompletionAction{
+		{Action: "description", Status: models.CompletionActionStatusApplied, OriginalState: &state},
+		{Action: "archive", Status: models.CompletionActionStatusApplied},
+	}); err != nil {
+		t.Fatalf("SaveCompletionActions() error = %v", err)
+	}
+
+	actions, err := db.GetCompletionActions(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetCompletionActions() error = %v", err)
+	}
+	if len(actions) != 2 {
+		t.Fatalf("Expected saving again to replace the actions, got %d", len(actions))
+	}
+	if actions[0].Action != "description" || actions[0].OriginalState == nil || *actions[0].OriginalState != state {
+		t.Errorf("Unexpected first action %+v", actions[0])
+	}
+
+	if err := db.MarkCompletionActionReverted(ctx, actions[1].ID); err != nil {
+		t.Fatalf("MarkCompletionActionReverted() error = %v", err)
+	}
+	actions, _ = db.GetCompletionActions(ctx, saved.ID)
+	if actions[1].Status != models.CompletionActionStatusReverted || actions[1].RevertedAt == nil {
+		t.Errorf("Expected the archive action to be reverted, got %+v", actions[1])
+	}
+	if actions[0].Status != models.CompletionActionStatusApplied {
+		t.Errorf("Expected the description action to stay applied, got %s", actions[0].Status)
+	}
+
+	if err := db.MarkCompletionActionReverted(ctx, 9999); err == nil {
+		t.Error("Expected an error for an unknown completion action")
+	}
+}
@@ -156,6 +156,8 @@ func (d *Database) updateExistingRepository(tx *gorm.DB, existing, repo *models.
 		repo.Priority = existing.Priority
 		repo.DestinationURL = existing.DestinationURL
 		repo.DestinationFullName = existing.DestinationFullName
+		repo.PreSeededAt = existing.PreSeededAt
+		repo.PreSeedCommitSHA = existing.PreSeedCommitSHA
 	}
 
 	repo.UpdatedAt = time.Now()
@@ -627,8 +629,10 @@ func (d *Database) GetRepositoryByID(ctx context.Context, id int64) (*models.Rep
 	return &repo, nil
 }
 
-// UpdateRepositoryValidation updates the validation fields for a repository using GORM
-func (d *Database) UpdateRepositoryValidation(ctx context.Context, fullName string, validationStatus string, validationDetails, destinationData *string) error {
+// UpdateRepositoryValidation updates the post-migration validation fields for a repository using GORM.
+// deepValidationReport and settingsSyncReport are nil when deep validation or the settings sync did not run,
+// clearing any earlier report.
+func (d *Database) UpdateRepositoryValidation(ctx context.Context, fullName string, validationStatus string, validationDetails, destinationData, deepValidationReport, settingsSyncReport *string) error {
 	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Get the repository ID
 		var repo models.Repository
@@ -638,15 +642,17 @@ func (d *Database) UpdateRepositoryValidation(ctx context.Context, fullName stri
 
 		// Update the validation table
 		validation := &models.RepositoryValidation{
-			RepositoryID:      repo.ID,
-			ValidationStatus:  &validationStatus,
-			ValidationDetails: validationDetails,
-			DestinationData:   destinationData,
+			RepositoryID:         repo.ID,
+			ValidationStatus:     &validationStatus,
+			ValidationDetails:    validationDetails,
+			DestinationData:      destinationData,
+			DeepValidationReport: deepValidationReport,
+			SettingsSyncReport:   settingsSyncReport,
 		}
 
 		if err := tx.Clauses(clause.OnConflict{
 			Columns:   []clause.Column{{Name: "repository_id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"validation_status", "validation_details", "destination_data"}),
+			DoUpdates: clause.AssignmentColumns([]string{"validation_status", "validation_details", "destination_data", "deep_validation_report", "settings_sync_report"}),
 		}).Create(validation).Error; err != nil {
 			return fmt.Errorf("failed to update repository validation: %w", err)
 		}
@@ -662,7 +668,8 @@ func (d *Database) UpdateRepositoryValidation(ctx context.Context, fullName stri
 	})
 }
 
-// RollbackRepository marks a repository as rolled back and creates a migration history entry using GORM
+// RollbackRepository marks a repository as rolled back, clears its destination and batch assignment,
+// and creates a migration history entry using GORM
 func (d *Database) RollbackRepository(ctx context.Context, fullName string, reason string) error {
 	// Get the repository
 	repo, err := d.GetRepository(ctx, fullName)
@@ -675,14 +682,18 @@ func (d *Database) RollbackRepository(ctx context.Context, fullName string, reas
 
 	oldBatchID := repo.BatchID
 
-	// Update repository status to rolled_back and clear batch assignment using GORM
+	// Update repository status to rolled_back and clear batch assignment and destination using GORM
 	now := time.Now().UTC()
 	result := d.db.WithContext(ctx).Model(&models.Repository{}).
 		Where("full_name = ?", fullName).
 		Updates(map[string]any{
-			"status":     string(models.StatusRolledBack),
-			"batch_id":   nil,
-			"updated_at": now,
+			"status":                string(models.StatusRolledBack),
+			"batch_id":              nil,
+			"destination_full_name": nil,
+			"destination_url":       nil,
+			"pre_seeded_at":         nil,
+			"pre_seed_commit_sha":   nil,
+			"updated_at":            now,
 		})
 
 	if result.Error != nil {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
 )
 
 // GetMigrationHistory retrieves migration history for a repository using GORM
@@ -82,6 +83,45 @@ func (d *Database) UpdateMigrationHistory(ctx context.Context, id int64, status
 	return nil
 }
 
+// SaveMigrationCheckpoint records the last completed phase of an in-flight migration
+// along with the remote state (archive IDs and URLs, migration ID) needed to resume it
+func (d *Database) SaveMigrationCheckpoint(ctx context.Context, id int64, checkpoint *models.MigrationHistory) error {
+	result := d.db.WithContext(ctx).Model(&models.MigrationHistory{}).Where("id = ?", id).Updates(map[string]any{
+		"checkpoint":           checkpoint.Checkpoint,
+		"checkpoint_at":        time.Now(),
+		"git_archive_id":       checkpoint.GitArchiveID,
+		"metadata_archive_id":  checkpoint.MetadataArchiveID,
+		"git_archive_url":      checkpoint.GitArchiveURL,
+		"metadata_archive_url": checkpoint.MetadataArchiveURL,
+		"migration_id":         checkpoint.MigrationID,
+	})
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to save migration checkpoint: %w", result.Error)
+	}
+
+	return nil
+}
+
+// GetInProgressMigrationHistory returns the most recent in-progress migration history
+// record for a repository, or nil if the repository has none
+func (d *Database) GetInProgressMigrationHistory(ctx context.Context, repoID int64) (*models.MigrationHistory, error) {
+	var history models.MigrationHistory
+	err := d.db.WithContext(ctx).
+		Where("repository_id = ? AND status = ?", repoID, "in_progress").
+		Order("started_at DESC, id DESC").
+		First(&history).Error
+
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-progress migration history: %w", err)
+	}
+
+	return &history, nil
+}
+
 // CreateMigrationLog creates a new migration log entry using GORM
 func (d *Database) CreateMigrationLog(ctx context.Context, log *models.MigrationLog) error {
 	result := d.db.WithContext(ctx).Create(log)
@@ -343,3 +383,43 @@ func (d *Database) GetMigrationCompletionStatsByProjectFiltered(ctx context.Cont
 
 	return stats, nil
 }
+
+// MigrationDurationSample is a completed migration or dry run with the size of the repository it migrated
+type MigrationDurationSample struct {
+	RepositoryID          int64
+	Phase                 string // migration or dry_run
+	DurationSeconds       int
+	TotalSize             *int64
+	EstimatedMetadataSize *int64
+	IssueCount            int
+	PullRequestCount      int
+}
+
+// GetMigrationDurationSamples returns every completed migration and dry run with a recorded
+// duration, newest first, for forecasting the duration of future migrations
+func (d *Database) GetMigrationDurationSamples(ctx context.Context) ([]*MigrationDurationSample, error) {
+	query := `
+		SELECT
+			mh.repository_id,
+			mh.phase,
+			mh.duration_seconds,
+			gp.total_size,
+			v.estimated_metadata_size,
+			COALESCE(f.issue_count, 0) as issue_count,
+			COALESCE(f.pull_request_count, 0) as pull_request_count
+		FROM migration_history mh
+		LEFT JOIN repository_git_properties gp ON gp.repository_id = mh.repository_id
+		LEFT JOIN repository_features f ON f.repository_id = mh.repository_id
+		LEFT JOIN repository_validation v ON v.repository_id = mh.repository_id
+		WHERE mh.status = 'completed'
+			AND mh.phase IN ('migration', 'dry_run')
+			AND mh.duration_seconds IS NOT NULL
+		ORDER BY mh.id DESC
+	`
+
+	var samples []*MigrationDurationSample
+	if err := d.db.WithContext(ctx).Raw(query).Scan(&samples).Error; err != nil {
+		return nil, fmt.Errorf("failed to get migration duration samples: %w", err)
+	}
+	return samples, nil
+}
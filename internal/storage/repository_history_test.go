@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestGetMigrationDurationSamples(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	repo.Features.IssueCount = 12
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	record := func(phase, status string) {
+		id, err := db.CreateMigrationHistory(ctx, &models.MigrationHistory{
+			RepositoryID: saved.ID, Phase: phase, Status: "in_progress", StartedAt: time.Now().Add(-time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("CreateMigrationHistory() error = %v", err)
+		}
+		if status != "in_progress" {
+			if err := db.UpdateMigrationHistory(ctx, id, status, nil); err != nil {
+				t.Fatalf("UpdateMigrationHistory() error = %v", err)
+			}
+		}
+	}
+	record("dry_run", "completed")
+	record("migration", "failed")
+	record("migration", "in_progress")
+	record("rollback", "completed")
+	record("migration", "completed")
+
+	samples, err := db.GetMigrationDurationSamples(ctx)
+	if err != nil {
+		t.Fatalf("GetMigrationDurationSamples() error = %v", err)
+	}
+	if len(samples) != 2 || samples[0].Phase != "migration" || samples[1].Phase != "dry_run" {
+		t.Fatalf("Expected the completed migration and dry run, newest first, got %+v", samples)
+	}
+	sample := samples[0]
+	if sample.RepositoryID != saved.ID || sample.DurationSeconds < 59 || sample.IssueCount != 12 ||
+		sample.TotalSize == nil || *sample.TotalSize != *repo.GitProperties.TotalSize {
+		t.Errorf("Unexpected sample %+v", sample)
+	}
+}
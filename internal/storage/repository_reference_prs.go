@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveReferencePullRequest replaces the reference rewrite pull request recorded for a repository
+func (d *Database) SaveReferencePullRequest(ctx context.Context, pr *models.ReferencePullRequest) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", pr.RepositoryID).Delete(&models.ReferencePullRequest{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing reference pull request: %w", err)
+		}
+
+		pr.ID = 0
+		if err := tx.Create(pr).Error; err != nil {
+			return fmt.Errorf("failed to insert reference pull request: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetReferencePullRequest retrieves the reference rewrite pull request recorded for a repository.
+// Returns nil when none has been opened.
+func (d *Database) GetReferencePullRequest(ctx context.Context, repoID int64) (*models.ReferencePullRequest, error) {
+	var pr models.ReferencePullRequest
+	err := d.db.WithContext(ctx).Where("repository_id = ?", repoID).First(&pr).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to query reference pull request: %w", err)
+	}
+
+	return &pr, nil
+}
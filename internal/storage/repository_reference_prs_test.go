@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestReferencePullRequest(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	got, err := db.GetReferencePullRequest(ctx, saved.ID)
+	if err != nil || got != nil {
+		t.Fatalf("GetReferencePullRequest() before save = %v, %v", got, err)
+	}
+
+	errMsg := "branch already exists"
+	if err := db.SaveReferencePullRequest(ctx, &models.ReferencePullRequest{
+		RepositoryID: saved.ID,
+		Status:       models.ReferencePRStatusFailed,
+		Error:        &errMsg,
+	}); err != nil {
+		t.Fatalf("SaveReferencePullRequest() error = %v", err)
+	}
+
+	number := 12
+	url := "https://github.com/dest-org/test-repo/pull/12"
+	unresolved := "test-org/not-migrated"
+	if err := db.SaveReferencePullRequest(ctx, &models.ReferencePullRequest{
+		RepositoryID:        saved.ID,
+		Status:              models.ReferencePRStatusOpen,
+		Number:              &number,
+		URL:                 &url,
+		Branch:              "migration/rewrite-references",
+		FilesChanged:        2,
+		ReferencesRewritten: 3,
+		Unresolved:          &unresolved,
+	}); err != nil {
+		t.Fatalf("SaveReferencePullRequest() error = %v", err)
+	}
+
+	got, err = db.GetReferencePullRequest(ctx, saved.ID)
+	if err != nil || got == nil {
+		t.Fatalf("GetReferencePullRequest() = %v, %v", got, err)
+	}
+	if got.Status != models.ReferencePRStatusOpen || got.Number == nil || *got.Number != 12 || got.Error != nil {
+		t.Errorf("expected the open pull request to replace the failed attempt, got %+v", got)
+	}
+	if got.FilesChanged != 2 || got.ReferencesRewritten != 3 || got.Unresolved == nil || *got.Unresolved != unresolved {
+		t.Errorf("unexpected pull request details %+v", got)
+	}
+}
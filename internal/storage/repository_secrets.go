@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveRepositorySecrets replaces the "secrets to re-enter" checklist of a repository
+func (d *Database) SaveRepositorySecrets(ctx context.Context, repoID int64, secrets []*models.RepositorySecret) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", repoID).Delete(&models.RepositorySecret{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing secrets: %w", err)
+		}
+
+		if len(secrets) > 0 {
+			for _, secret := range secrets {
+				secret.ID = 0
+				secret.RepositoryID = repoID
+			}
+			if err := tx.Create(secrets).Error; err != nil {
+				return fmt.Errorf("failed to insert secrets: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetRepositorySecrets retrieves the "secrets to re-enter" checklist of a repository.
+// Repository-level secrets are listed first, followed by environment secrets.
+func (d *Database) GetRepositorySecrets(ctx context.Context, repoID int64) ([]*models.RepositorySecret, error) {
+	// Initialize as empty slice instead of nil so JSON serialization returns [] not null
+	secrets := make([]*models.RepositorySecret, 0)
+
+	err := d.db.WithContext(ctx).
+		Where("repository_id = ?", repoID).
+		Order("environment, name").
+		Find(&secrets).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query secrets: %w", err)
+	}
+
+	return secrets, nil
+}
+
+// SecretChecklistEntry is a secret to re-enter along with the repository it belongs to
+type SecretChecklistEntry struct {
+	Repository            string
+	DestinationRepository string
+	Environment           string
+	Name                  string
+	PlaceholderCreated    bool
+}
+
+// GetSecretsChecklist returns the secrets to re-enter across all repositories
+func (d *Database) GetSecretsChecklist(ctx context.Context) ([]SecretChecklistEntry, error) {
+	var results []SecretChecklistEntry
+	err := d.db.WithContext(ctx).
+		Table("repository_secrets rs").
+		Select("r.full_name as repository, COALESCE(r.destination_full_name, '') as destination_repository, rs.environment, rs.name, rs.placeholder_created").
+		Joins("JOIN repositories r ON rs.repository_id = r.id").
+		Order("r.full_name, rs.environment, rs.name").
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secrets checklist: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestRepositorySecrets(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	destName := "dest-org/test-repo"
+	repo.DestinationFullName = &destName
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	secrets := []*models.RepositorySecret{
+		{Environment: "production", Name: "DEPLOY_KEY", PlaceholderCreated: true},
+		{Name: "NPM_TOKEN", PlaceholderCreated: true},
+		{Name: "SLACK_WEBHOOK"},
+	}
+	if err := db.SaveRepositorySecrets(ctx, saved.ID, secrets); err != nil {
+		t.Fatalf("SaveRepositorySecrets() error = %v", err)
+	}
+
+	got, err := db.GetRepositorySecrets(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetRepositorySecrets() error = %v", err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("GetRepositorySecrets() returned %d secrets, want 3", len(got))
+	}
+	if got[0].Name != "NPM_TOKEN" || got[2].Environment != "production" {
+		t.Errorf("expected repository secrets before environment secrets, got %s/%s first and %s/%s last",
+			got[0].Environment, got[0].Name, got[2].Environment, got[2].Name)
+	}
+
+	checklist, err := db.GetSecretsChecklist(ctx)
+	if err != nil {
+		t.Fatalf("GetSecretsChecklist() error = %v", err)
+	}
+	if len(checklist) != 3 || checklist[0].Repository != "test-org/test-repo" || checklist[0].DestinationRepository != destName {
+		t.Errorf("unexpected checklist %+v", checklist)
+	}
+	if checklist[1].Name != "SLACK_WEBHOOK" || checklist[1].PlaceholderCreated {
+		t.Errorf("expected SLACK_WEBHOOK without a placeholder, got %+v", checklist[1])
+	}
+
+	// Saving again replaces the checklist
+	if err := db.SaveRepositorySecrets(ctx, saved.ID, []*models.RepositorySecret{{Name: "NPM_TOKEN"}}); err != nil {
+		t.Fatalf("SaveRepositorySecrets() replace error = %v", err)
+	}
+	got, err = db.GetRepositorySecrets(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetRepositorySecrets() error = %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("expected the checklist to be replaced, got %d secrets", len(got))
+	}
+}
@@ -45,6 +45,7 @@ func (d *Database) SaveTeam(ctx context.Context, team *models.GitHubTeam) error
 		"name":        team.Name,
 		"description": team.Description,
 		"privacy":     team.Privacy,
+		"parent_slug": team.ParentSlug,
 		"updated_at":  team.UpdatedAt,
 	})
 
@@ -383,6 +384,7 @@ type TeamDetail struct {
 	Name         string                 `json:"name"`
 	Description  *string                `json:"description,omitempty"`
 	Privacy      string                 `json:"privacy"`
+	ParentSlug   *string                `json:"parent_slug,omitempty"`
 	DiscoveredAt time.Time              `json:"discovered_at"`
 	Members      []TeamDetailMember     `json:"members"`
 	Repositories []TeamDetailRepository `json:"repositories"`
@@ -431,6 +433,7 @@ func (d *Database) GetTeamDetail(ctx context.Context, org, slug string) (*TeamDe
 		Name:         team.Name,
 		Description:  team.Description,
 		Privacy:      team.Privacy,
+		ParentSlug:   team.ParentSlug,
 		DiscoveredAt: team.DiscoveredAt,
 		Members:      []TeamDetailMember{},
 		Repositories: []TeamDetailRepository{},
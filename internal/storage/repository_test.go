@@ -748,6 +748,38 @@ func TestRollbackRepositoryClearsBatchID(t *testing.T) {
 	}
 }
 
+func TestRollbackRepositoryClearsDestination(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	destFullName := "dest-org/test-repo"
+	destURL := "https://github.com/dest-org/test-repo"
+	repo := createTestRepository("org/test-repo")
+	repo.Status = string(models.StatusComplete)
+	repo.DestinationFullName = &destFullName
+	repo.DestinationURL = &destURL
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+
+	if err := db.RollbackRepository(ctx, repo.FullName, "Testing rollback"); err != nil {
+		t.Fatalf("RollbackRepository() error = %v", err)
+	}
+
+	rolledBackRepo, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("GetRepository() after rollback error = %v", err)
+	}
+	if rolledBackRepo.DestinationFullName != nil {
+		t.Errorf("Expected destination_full_name to be NULL after rollback, got %s", *rolledBackRepo.DestinationFullName)
+	}
+	if rolledBackRepo.DestinationURL != nil {
+		t.Errorf("Expected destination_url to be NULL after rollback, got %s", *rolledBackRepo.DestinationURL)
+	}
+}
+
 func TestGetMigrationHistory(t *testing.T) {
 	db := setupTestDB(t)
 	defer func() { _ = db.Close() }()
@@ -863,6 +895,94 @@ func TestUpdateMigrationHistory(t *testing.T) {
 	}
 }
 
+func TestMigrationCheckpoint(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test/repo")
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	savedRepo, _ := db.GetRepository(ctx, repo.FullName)
+
+	// No history yet
+	active, err := db.GetInProgressMigrationHistory(ctx, savedRepo.ID)
+	if err != nil {
+		t.Fatalf("GetInProgressMigrationHistory() error = %v", err)
+	}
+	if active != nil {
+		t.Fatalf("Expected no in-progress history, got %+v", active)
+	}
+
+	// A finished run followed by an in-flight one
+	finishedID, err := db.CreateMigrationHistory(ctx, &models.MigrationHistory{
+		RepositoryID: savedRepo.ID, Status: "in_progress", Phase: "dry_run", StartedAt: time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateMigrationHistory() error = %v", err)
+	}
+	if err := db.UpdateMigrationHistory(ctx, finishedID, "completed", nil); err != nil {
+		t.Fatalf("UpdateMigrationHistory() error = %v", err)
+	}
+	historyID, err := db.CreateMigrationHistory(ctx, &models.MigrationHistory{
+		RepositoryID: savedRepo.ID, Status: "in_progress", Phase: "migration", StartedAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("CreateMigrationHistory() error = %v", err)
+	}
+
+	checkpoint := models.CheckpointArchivesReady
+	gitArchiveID, metadataArchiveID := int64(11), int64(12)
+	gitURL, metadataURL := "https://ghes.example.com/git.tar.gz", "https://ghes.example.com/metadata.tar.gz"
+	err = db.SaveMigrationCheckpoint(ctx, historyID, &models.MigrationHistory{
+		Checkpoint:         &checkpoint,
+		GitArchiveID:       &gitArchiveID,
+		MetadataArchiveID:  &metadataArchiveID,
+		GitArchiveURL:      &gitURL,
+		MetadataArchiveURL: &metadataURL,
+	})
+	if err != nil {
+		t.Fatalf("SaveMigrationCheckpoint() error = %v", err)
+	}
+
+	active, err = db.GetInProgressMigrationHistory(ctx, savedRepo.ID)
+	if err != nil {
+		t.Fatalf("GetInProgressMigrationHistory() error = %v", err)
+	}
+	if active == nil || active.ID != historyID {
+		t.Fatalf("GetInProgressMigrationHistory() = %+v, want history %d", active, historyID)
+	}
+	if active.Checkpoint == nil || *active.Checkpoint != models.CheckpointArchivesReady {
+		t.Errorf("Checkpoint = %v, want %s", active.Checkpoint, models.CheckpointArchivesReady)
+	}
+	if active.CheckpointAt == nil {
+		t.Error("Expected CheckpointAt to be set")
+	}
+	if active.GitArchiveID == nil || *active.GitArchiveID != 11 || active.MetadataArchiveID == nil || *active.MetadataArchiveID != 12 {
+		t.Errorf("archive IDs = %v/%v, want 11/12", active.GitArchiveID, active.MetadataArchiveID)
+	}
+	if active.GitArchiveURL == nil || *active.GitArchiveURL != gitURL || active.MetadataArchiveURL == nil || *active.MetadataArchiveURL != metadataURL {
+		t.Errorf("archive URLs = %v/%v", active.GitArchiveURL, active.MetadataArchiveURL)
+	}
+	if !active.IsResumable() {
+		t.Error("Expected history with archive checkpoint to be resumable")
+	}
+
+	// Completing the run clears it from the in-progress lookup
+	if err := db.UpdateMigrationHistory(ctx, historyID, "completed", nil); err != nil {
+		t.Fatalf("UpdateMigrationHistory() error = %v", err)
+	}
+	active, err = db.GetInProgressMigrationHistory(ctx, savedRepo.ID)
+	if err != nil {
+		t.Fatalf("GetInProgressMigrationHistory() error = %v", err)
+	}
+	if active != nil {
+		t.Errorf("Expected no in-progress history after completion, got %d", active.ID)
+	}
+}
+
 func TestGetMigrationLogs(t *testing.T) {
 	db := setupTestDB(t)
 	defer func() { _ = db.Close() }()
@@ -1185,6 +1305,44 @@ func TestSaveRepository_PreservesBatchIDDuringRediscovery(t *testing.T) {
 	t.Logf("✅ batch_id correctly preserved during re-discovery (batch_id=%d)", *afterRediscovery.BatchID)
 }
 
+// TestSaveRepository_PreservesPreSeedDuringRediscovery verifies that re-discovery
+// keeps a delta sync pre-seed so the scheduled cutover still re-syncs only changed refs
+func TestSaveRepository_PreservesPreSeedDuringRediscovery(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	preSeededAt := time.Now().Add(-24 * time.Hour)
+	sha := "abc123"
+	destFullName := "dest-org/test-repo"
+	repo := createTestRepository("org/test-repo")
+	repo.Status = string(models.StatusDryRunComplete)
+	repo.DestinationFullName = &destFullName
+	repo.PreSeededAt = &preSeededAt
+	repo.PreSeedCommitSHA = &sha
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+
+	rediscoveredRepo := createTestRepository("org/test-repo")
+	rediscoveredRepo.Status = string(models.StatusPending)
+	if err := db.SaveRepository(ctx, rediscoveredRepo); err != nil {
+		t.Fatalf("SaveRepository() during re-discovery error = %v", err)
+	}
+
+	afterRediscovery, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil {
+		t.Fatalf("GetRepository() after re-discovery error = %v", err)
+	}
+	if afterRediscovery.PreSeededAt == nil {
+		t.Error("Expected pre_seeded_at to be preserved during re-discovery")
+	}
+	if afterRediscovery.PreSeedCommitSHA == nil || *afterRediscovery.PreSeedCommitSHA != sha {
+		t.Errorf("Expected pre_seed_commit_sha %s, got %v", sha, afterRediscovery.PreSeedCommitSHA)
+	}
+}
+
 func TestListRepositoriesWithSearch(t *testing.T) {
 	db := setupTestDB(t)
 	defer func() { _ = db.Close() }()
@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveRepositoryWebhooks replaces the recreated webhooks tracked for a repository
+func (d *Database) SaveRepositoryWebhooks(ctx context.Context, repoID int64, webhooks []*models.RepositoryWebhook) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("repository_id = ?", repoID).Delete(&models.RepositoryWebhook{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing webhooks: %w", err)
+		}
+
+		if len(webhooks) > 0 {
+			for _, webhook := range webhooks {
+				webhook.ID = 0
+				webhook.RepositoryID = repoID
+			}
+			if err := tx.Create(webhooks).Error; err != nil {
+				return fmt.Errorf("failed to insert webhooks: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetRepositoryWebhooks retrieves the recreated webhooks tracked for a repository
+func (d *Database) GetRepositoryWebhooks(ctx context.Context, repoID int64) ([]*models.RepositoryWebhook, error) {
+	// Initialize as empty slice instead of nil so JSON serialization returns [] not null
+	webhooks := make([]*models.RepositoryWebhook, 0)
+
+	err := d.db.WithContext(ctx).
+		Where("repository_id = ?", repoID).
+		Order("url, source_hook_id").
+		Find(&webhooks).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// PendingWebhook is a recreated webhook waiting for activation, along with the
+// destination repository it lives on
+type PendingWebhook struct {
+	ID                    int64
+	RepositoryID          int64
+	Repository            string
+	DestinationRepository string
+	DestinationHookID     int64
+	URL                   string
+}
+
+// GetPendingWebhooks returns the webhooks waiting for activation. When webhookIDs or
+// repoIDs are given, only the matching webhooks are returned.
+func (d *Database) GetPendingWebhooks(ctx context.Context, webhookIDs, repoIDs []int64) ([]PendingWebhook, error) {
+	query := d.db.WithContext(ctx).
+		Table("repository_webhooks rw").
+		Select("rw.id, rw.repository_id, r.full_name as repository, COALESCE(r.destination_full_name, '') as destination_repository, rw.destination_hook_id, rw.url").
+		Joins("JOIN repositories r ON rw.repository_id = r.id").
+		Where("rw.status = ? AND rw.destination_hook_id IS NOT NULL", models.WebhookStatusPending)
+
+	if len(webhookIDs) > 0 {
+		query = query.Where("rw.id IN ?", webhookIDs)
+	}
+	if len(repoIDs) > 0 {
+		query = query.Where("rw.repository_id IN ?", repoIDs)
+	}
+
+	var results []PendingWebhook
+	if err := query.Order("r.full_name, rw.url").Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to get pending webhooks: %w", err)
+	}
+
+	return results, nil
+}
+
+// MarkWebhookActivated records that a recreated webhook was turned on
+func (d *Database) MarkWebhookActivated(ctx context.Context, id int64) error {
+	now := time.Now()
+	err := d.db.WithContext(ctx).
+		Model(&models.RepositoryWebhook{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":       models.WebhookStatusActive,
+			"activated_at": now,
+			"error":        nil,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark webhook activated: %w", err)
+	}
+	return nil
+}
+
+// SetWebhookError records why a webhook could not be activated. The webhook stays pending.
+func (d *Database) SetWebhookError(ctx context.Context, id int64, message string) error {
+	err := d.db.WithContext(ctx).
+		Model(&models.RepositoryWebhook{}).
+		Where("id = ?", id).
+		Update("error", message).Error
+	if err != nil {
+		return fmt.Errorf("failed to record webhook error: %w", err)
+	}
+	return nil
+}
+
+// PendingWebhookRepository is a migrated repository with webhooks still waiting for activation
+type PendingWebhookRepository struct {
+	RepositoryID          int64  `json:"repository_id"`
+	FullName              string `json:"full_name"`
+	DestinationRepository string `json:"destination_repository"`
+	PendingWebhooks       int    `json:"pending_webhooks"`
+}
+
+// GetPendingWebhookRepositories returns the repositories with webhooks waiting for activation,
+// which is the outstanding integration work after migration
+func (d *Database) GetPendingWebhookRepositories(ctx context.Context, limit int) ([]*PendingWebhookRepository, error) {
+	results := make([]*PendingWebhookRepository, 0)
+	err := d.db.WithContext(ctx).
+		Table("repository_webhooks rw").
+		Select("r.id as repository_id, r.full_name, COALESCE(r.destination_full_name, '') as destination_repository, COUNT(*) as pending_webhooks").
+		Joins("JOIN repositories r ON rw.repository_id = r.id").
+		Where("rw.status = ?", models.WebhookStatusPending).
+		Group("r.id, r.full_name, r.destination_full_name").
+		Order("r.full_name").
+		Limit(limit).
+		Scan(&results).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repositories with pending webhooks: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestRepositoryWebhooks(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	repo := createTestRepository("test-org/test-repo")
+	destName := "dest-org/test-repo"
+	repo.DestinationFullName = &destName
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("SaveRepository() error = %v", err)
+	}
+	saved, err := db.GetRepository(ctx, repo.FullName)
+	if err != nil || saved == nil {
+		t.Fatalf("GetRepository() = %v, %v", saved, err)
+	}
+
+	hookID := func(id int64) *int64 { return &id }
+	webhooks := []*models.RepositoryWebhook{
+		{SourceHookID: 1, DestinationHookID: hookID(101), URL: "https://ci.example.com/hook", Events: "push", HasSecret: true, Status: models.WebhookStatusPending},
+		{SourceHookID: 2, DestinationHookID: hookID(102), URL: "https://chat.example.com/hook", Events: "issues,pull_request", Status: models.WebhookStatusPending},
+		{SourceHookID: 3, DestinationHookID: hookID(103), URL: "https://old.example.com/hook", Status: models.WebhookStatusDisabled},
+		{SourceHookID: 4, URL: "https://broken.example.com/hook", Status: models.WebhookStatusFailed},
+	}
+	if err := db.SaveRepositoryWebhooks(ctx, saved.ID, webhooks); err != nil {
+		t.Fatalf("SaveRepositoryWebhooks() error = %v", err)
+	}
+
+	got, err := db.GetRepositoryWebhooks(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetRepositoryWebhooks() error = %v", err)
+	}
+	if len(got) != 4 {
+		t.Fatalf("GetRepositoryWebhooks() returned %d webhooks, want 4", len(got))
+	}
+
+	pending, err := db.GetPendingWebhooks(ctx, nil, nil)
+	if err != nil {
+		t.Fatalf("GetPendingWebhooks() error = %v", err)
+	}
+	if len(pending) != 2 || pending[0].DestinationRepository != destName || pending[0].URL != "https://chat.example.com/hook" {
+		t.Fatalf("unexpected pending webhooks %+v", pending)
+	}
+	if pending[0].DestinationHookID != 102 {
+		t.Errorf("expected destination hook 102, got %d", pending[0].DestinationHookID)
+	}
+
+	repos, err := db.GetPendingWebhookRepositories(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetPendingWebhookRepositories() error = %v", err)
+	}
+	if len(repos) != 1 || repos[0].PendingWebhooks != 2 || repos[0].DestinationRepository != destName {
+		t.Errorf("unexpected repositories with pending webhooks %+v", repos)
+	}
+
+	if err := db.SetWebhookError(ctx, pending[1].ID, "secret missing"); err != nil {
+		t.Fatalf("SetWebhookError() error = %v", err)
+	}
+	if err := db.MarkWebhookActivated(ctx, pending[0].ID); err != nil {
+		t.Fatalf("MarkWebhookActivated() error = %v", err)
+	}
+
+	// Filters narrow the pending webhooks to the requested ones
+	pending, err = db.GetPendingWebhooks(ctx, []int64{pending[1].ID}, []int64{saved.ID})
+	if err != nil {
+		t.Fatalf("GetPendingWebhooks() error = %v", err)
+	}
+	if len(pending) != 1 || pending[0].URL != "https://ci.example.com/hook" {
+		t.Fatalf("expected only the CI hook to remain pending, got %+v", pending)
+	}
+
+	got, err = db.GetRepositoryWebhooks(ctx, saved.ID)
+	if err != nil {
+		t.Fatalf("GetRepositoryWebhooks() error = %v", err)
+	}
+	for _, webhook := range got {
+		switch webhook.URL {
+		case "https://chat.example.com/hook":
+			if webhook.Status != models.WebhookStatusActive || webhook.ActivatedAt == nil {
+				t.Errorf("expected the chat hook to be active, got %+v", webhook)
+			}
+		case "https://ci.example.com/hook":
+			if webhook.Error == nil || *webhook.Error != "secret missing" {
+				t.Errorf("expected the CI hook error to be recorded, got %+v", webhook)
+			}
+		}
+	}
+}
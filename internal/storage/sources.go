@@ -383,6 +383,36 @@ func (d *Database) DeleteSourceCascade(ctx context.Context, sourceID int64) erro
 				return fmt.Errorf("failed to delete dependencies: %w", err)
 			}
 
+			// Delete secrets checklists
+			if err := tx.Where("repository_id IN ?", repoIDs).
+				Delete(&models.RepositorySecret{}).Error; err != nil {
+				return fmt.Errorf("failed to delete secrets checklists: %w", err)
+			}
+
+			// Delete recreated webhooks
+			if err := tx.Where("repository_id IN ?", repoIDs).
+				Delete(&models.RepositoryWebhook{}).Error; err != nil {
+				return fmt.Errorf("failed to delete webhooks: %w", err)
+			}
+
+			// Delete reference rewrite pull requests
+			if err := tx.Where("repository_id IN ?", repoIDs).
+				Delete(&models.ReferencePullRequest{}).Error; err != nil {
+				return fmt.Errorf("failed to delete reference pull requests: %w", err)
+			}
+
+			// Delete CODEOWNERS rewrites
+			if err := tx.Where("repository_id IN ?", repoIDs).
+				Delete(&models.CodeownersRewrite{}).Error; err != nil {
+				return fmt.Errorf("failed to delete CODEOWNERS rewrites: %w", err)
+			}
+
+			// Delete collaborator grants
+			if err := tx.Where("repository_id IN ?", repoIDs).
+				Delete(&models.CollaboratorGrant{}).Error; err != nil {
+				return fmt.Errorf("failed to delete collaborator grants: %w", err)
+			}
+
 			// Delete team-repository associations
 			if err := tx.Where("repository_id IN ?", repoIDs).
 				Delete(&models.GitHubTeamRepository{}).Error; err != nil {
@@ -436,6 +466,12 @@ func (d *Database) DeleteSourceCascade(ctx context.Context, sourceID int64) erro
 			return fmt.Errorf("failed to delete team mappings: %w", err)
 		}
 
+		// Delete team membership syncs
+		if err := tx.Where("source_id = ?", sourceID).
+			Delete(&models.TeamMembershipSync{}).Error; err != nil {
+			return fmt.Errorf("failed to delete team membership syncs: %w", err)
+		}
+
 		// Finally, delete the source itself
 		result := tx.Delete(&models.Source{}, sourceID)
 		if result.Error != nil {
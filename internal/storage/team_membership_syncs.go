@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SaveTeamMembershipSync replaces the membership sync recorded for a source team
+func (d *Database) SaveTeamMembershipSync(ctx context.Context, sync *models.TeamMembershipSync) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source_org = ? AND source_team_slug = ?", sync.SourceOrg, sync.SourceTeamSlug).
+			Delete(&models.TeamMembershipSync{}).Error; err != nil {
+			return fmt.Errorf("failed to clear existing team membership sync: %w", err)
+		}
+
+		sync.ID = 0
+		if err := tx.Create(sync).Error; err != nil {
+			return fmt.Errorf("failed to insert team membership sync: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ListTeamMembershipSyncs lists the recorded team membership syncs, optionally for a single source organization
+func (d *Database) ListTeamMembershipSyncs(ctx context.Context, sourceOrg string) ([]*models.TeamMembershipSync, error) {
+	query := d.db.WithContext(ctx).Order("source_org ASC, source_team_slug ASC")
+	if sourceOrg != "" {
+		query = query.Where("source_org = ?", sourceOrg)
+	}
+
+	var syncs []*models.TeamMembershipSync
+	if err := query.Find(&syncs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list team membership syncs: %w", err)
+	}
+
+	return syncs, nil
+}
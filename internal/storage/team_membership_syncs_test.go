@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kuhlman-labs/github-migrator/internal/models"
+)
+
+func TestTeamMembershipSyncs(t *testing.T) {
+	db := setupTestDB(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	syncs, err := db.ListTeamMembershipSyncs(ctx, "")
+	if err != nil || len(syncs) != 0 {
+		t.Fatalf("ListTeamMembershipSyncs() before save = %v, %v", syncs, err)
+	}
+
+	reason := "Membership is synced from external group Engineering"
+	for _, sync := range []*models.TeamMembershipSync{
+		{SourceOrg: "org-b", SourceTeamSlug: "ops", DestinationOrg: "dest", DestinationTeamSlug: "ops", Status: models.TeamMembershipStatusSkipped, Reason: &reason},
+		{SourceOrg: "org-a", SourceTeamSlug: "backend", DestinationOrg: "dest", DestinationTeamSlug: "backend", Status: models.TeamMembershipStatusFailed},
+	} {
+		if err := db.SaveTeamMembershipSync(ctx, sync); err != nil {
+			t.Fatalf("SaveTeamMembershipSync() error = %v", err)
+		}
+	}
+
+	// Syncing a team again replaces its earlier record
+	unmapped := "carol,dave"
+	if err := db.SaveTeamMembershipSync(ctx, &models.TeamMembershipSync{
+		SourceOrg:           "org-a",
+		SourceTeamSlug:      "backend",
+		DestinationOrg:      "dest",
+		DestinationTeamSlug: "backend",
+		Status:              models.TeamMembershipStatusSynced,
+		MembersAdded:        3,
+		MaintainersAdded:    1,
+		Unmapped:            &unmapped,
+	}); err != nil {
+		t.Fatalf("SaveTeamMembershipSync() error = %v", err)
+	}
+
+	syncs, err = db.ListTeamMembershipSyncs(ctx, "")
+	if err != nil {
+		t.Fatalf("ListTeamMembershipSyncs() error = %v", err)
+	}
+	if len(syncs) != 2 || syncs[0].SourceOrg != "org-a" || syncs[1].SourceOrg != "org-b" {
+		t.Fatalf("Expected the two teams ordered by organization, got %+v", syncs)
+	}
+	if syncs[0].Status != models.TeamMembershipStatusSynced || syncs[0].MembersAdded != 3 || syncs[0].Unmapped == nil || *syncs[0].Unmapped != unmapped {
+		t.Errorf("Unexpected replaced sync %+v", syncs[0])
+	}
+
+	syncs, err = db.ListTeamMembershipSyncs(ctx, "org-b")
+	if err != nil || len(syncs) != 1 || syncs[0].Reason == nil || *syncs[0].Reason != reason {
+		t.Errorf("ListTeamMembershipSyncs(org-b) = %+v, %v", syncs, err)
+	}
+}
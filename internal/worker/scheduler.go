@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/kuhlman-labs/github-migrator/internal/batch"
+	"github.com/kuhlman-labs/github-migrator/internal/leader"
+	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
 
 // SchedulerWorker periodically checks for and executes scheduled batches
@@ -13,6 +15,7 @@ type SchedulerWorker struct {
 	orchestrator *batch.Orchestrator
 	logger       *slog.Logger
 	interval     time.Duration
+	elector      *leader.Elector // Optional; when set, only the leader replica executes scheduled batches
 }
 
 // NewSchedulerWorker creates a new scheduler worker
@@ -24,10 +27,22 @@ func NewSchedulerWorker(orchestrator *batch.Orchestrator, logger *slog.Logger) *
 	}
 }
 
+// SetElector makes the scheduler run only on the replica holding the elector's leader lock
+func (sw *SchedulerWorker) SetElector(elector *leader.Elector) {
+	sw.elector = elector
+}
+
 // Start begins the scheduler worker loop
 func (sw *SchedulerWorker) Start(ctx context.Context) {
 	sw.logger.Info("Starting scheduler worker", "interval", sw.interval)
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if sw.elector != nil {
+		go sw.elector.Run(ctx)
+	}
+
 	ticker := time.NewTicker(sw.interval)
 	defer ticker.Stop()
 
@@ -46,10 +61,20 @@ func (sw *SchedulerWorker) Start(ctx context.Context) {
 }
 
 func (sw *SchedulerWorker) checkScheduledBatches(ctx context.Context) {
+	// Batches are started under the fencing token, so a leader that stalls after this check
+	// cannot start them once another replica has taken over
+	var fence *storage.LeaderFence
+	if sw.elector != nil {
+		if fence = sw.elector.LeaderFence(ctx); fence == nil {
+			sw.logger.Debug("Skipping scheduled batch check - not the leader")
+			return
+		}
+	}
+
 	sw.logger.Debug("Checking for scheduled batches")
 
 	// Execute scheduled batches (dry_run=false for production migrations)
-	if err := sw.orchestrator.ExecuteScheduledBatches(ctx, false); err != nil {
+	if err := sw.orchestrator.ExecuteScheduledBatches(ctx, false, fence); err != nil {
 		sw.logger.Error("Failed to execute scheduled batches", "error", err)
 	}
 }
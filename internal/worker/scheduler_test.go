@@ -3,11 +3,13 @@ package worker
 import (
 	"context"
 	"log/slog"
+	"os"
 	"testing"
 	"time"
 
 	"github.com/kuhlman-labs/github-migrator/internal/batch"
 	"github.com/kuhlman-labs/github-migrator/internal/config"
+	"github.com/kuhlman-labs/github-migrator/internal/leader"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
 )
@@ -205,6 +207,69 @@ func TestSchedulerWorker_Integration(t *testing.T) {
 	}
 }
 
+func TestSchedulerWorker_SkipsWhenNotLeader(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	ctx := context.Background()
+
+	pastTime := time.Now().Add(-5 * time.Minute)
+	testBatch := &models.Batch{
+		Name:            "Scheduled Batch",
+		Type:            "test",
+		Status:          "ready",
+		RepositoryCount: 1,
+		ScheduledAt:     &pastTime,
+		CreatedAt:       time.Now(),
+	}
+	if err := db.CreateBatch(ctx, testBatch); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	repo := createTestRepository("test/repo")
+	repo.Status = string(models.StatusDryRunComplete)
+	repo.BatchID = &testBatch.ID
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	orchestrator, err := batch.NewOrchestrator(batch.OrchestratorConfig{
+		Storage:  db,
+		Executor: &MockExecutor{},
+		Logger:   logger,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create orchestrator: %v", err)
+	}
+
+	// Another replica is the scheduler leader
+	if lock, err := db.AcquireLeaderLock(ctx, leader.LockBatchScheduler, "replica-b", time.Minute); err != nil || lock == nil {
+		t.Fatalf("AcquireLeaderLock() = %v, %v", lock, err)
+	}
+
+	elector, err := leader.NewElector(leader.ElectorConfig{
+		Storage:    db,
+		Logger:     logger,
+		Name:       leader.LockBatchScheduler,
+		InstanceID: "replica-a",
+	})
+	if err != nil {
+		t.Fatalf("NewElector() error: %v", err)
+	}
+
+	worker := NewSchedulerWorker(orchestrator, logger)
+	worker.SetElector(elector)
+	worker.checkScheduledBatches(ctx)
+
+	updatedBatch, err := db.GetBatch(ctx, testBatch.ID)
+	if err != nil {
+		t.Fatalf("Failed to get batch: %v", err)
+	}
+	if updatedBatch.Status != "ready" || updatedBatch.StartedAt != nil {
+		t.Errorf("Expected batch to be left for the leader, got status %s", updatedBatch.Status)
+	}
+}
+
 func TestSchedulerWorker_OnlyExecutesReadyBatches(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -268,7 +333,7 @@ func TestSchedulerWorker_OnlyExecutesReadyBatches(t *testing.T) {
 			}
 
 			// Execute scheduled batches
-			if err := orchestrator.ExecuteScheduledBatches(ctx, false); err != nil {
+			if err := orchestrator.ExecuteScheduledBatches(ctx, false, nil); err != nil {
 				t.Logf("ExecuteScheduledBatches error (expected for some): %v", err)
 			}
 
@@ -290,6 +355,60 @@ func TestSchedulerWorker_OnlyExecutesReadyBatches(t *testing.T) {
 	}
 }
 
+func TestSchedulerWorker_StaleLeaderCannotStartBatches(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	orchestrator, err := batch.NewOrchestrator(batch.OrchestratorConfig{
+		Storage:  db,
+		Executor: &MockExecutor{},
+		Logger:   slog.Default(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create orchestrator: %v", err)
+	}
+
+	ctx := context.Background()
+	pastTime := time.Now().Add(-time.Minute)
+	b := &models.Batch{Name: "Wave 1", Type: "test", Status: "ready", ScheduledAt: &pastTime, CreatedAt: time.Now()}
+	if err := db.CreateBatch(ctx, b); err != nil {
+		t.Fatalf("Failed to create batch: %v", err)
+	}
+	repo := createTestRepository("test/fenced")
+	repo.Status = string(models.StatusDryRunComplete)
+	repo.BatchID = &b.ID
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	// replica-a checked its fence, then stalled while replica-b took the lock over
+	lock, _ := db.AcquireLeaderLock(ctx, leader.LockBatchScheduler, "replica-a", time.Minute)
+	stale := &storage.LeaderFence{Name: leader.LockBatchScheduler, OwnerID: "replica-a", Token: lock.FencingToken}
+	if err := db.ReleaseLeaderLock(ctx, leader.LockBatchScheduler, "replica-a"); err != nil {
+		t.Fatalf("ReleaseLeaderLock() error = %v", err)
+	}
+	next, _ := db.AcquireLeaderLock(ctx, leader.LockBatchScheduler, "replica-b", time.Minute)
+
+	if err := orchestrator.ExecuteScheduledBatches(ctx, false, stale); err != nil {
+		t.Fatalf("ExecuteScheduledBatches failed: %v", err)
+	}
+	if updated, _ := db.GetBatch(ctx, b.ID); updated.Status != "ready" || updated.StartedAt != nil {
+		t.Fatalf("Expected the stale leader not to start the batch, got status %s", updated.Status)
+	}
+
+	current := &storage.LeaderFence{Name: leader.LockBatchScheduler, OwnerID: "replica-b", Token: next.FencingToken}
+	if err := orchestrator.ExecuteScheduledBatches(ctx, false, current); err != nil {
+		t.Fatalf("ExecuteScheduledBatches failed: %v", err)
+	}
+	if updated, _ := db.GetBatch(ctx, b.ID); updated.StartedAt == nil {
+		t.Error("Expected the current leader to start the batch")
+	}
+}
+
 func TestSchedulerWorker_IgnoresFutureBatches(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration test")
@@ -328,7 +447,7 @@ func TestSchedulerWorker_IgnoresFutureBatches(t *testing.T) {
 	}
 
 	// Execute scheduled batches
-	if err := orchestrator.ExecuteScheduledBatches(ctx, false); err != nil {
+	if err := orchestrator.ExecuteScheduledBatches(ctx, false, nil); err != nil {
 		t.Fatalf("ExecuteScheduledBatches failed: %v", err)
 	}
 
@@ -346,6 +465,178 @@ func TestSchedulerWorker_IgnoresFutureBatches(t *testing.T) {
 	}
 }
 
+func TestSchedulerWorker_RespectsBlackoutWindows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	orchestrator, err := batch.NewOrchestrator(batch.OrchestratorConfig{
+		Storage:  db,
+		Executor: &MockExecutor{},
+		Logger:   slog.Default(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create orchestrator: %v", err)
+	}
+
+	ctx := context.Background()
+	blackoutStart := time.Now().Add(-time.Hour)
+	blackoutEnd := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	deferredOrg, refusedOrg := "deferred", "refused"
+	for _, window := range []*models.MigrationWindow{
+		{Name: "Release freeze", Kind: models.MigrationWindowKindBlackout, Organization: &deferredOrg,
+			Policy: models.MigrationWindowPolicyDefer, TimeZone: "UTC", StartsAt: &blackoutStart, EndsAt: &blackoutEnd},
+		{Name: "Audit", Kind: models.MigrationWindowKindBlackout, Organization: &refusedOrg,
+			Policy: models.MigrationWindowPolicyRefuse, TimeZone: "UTC", StartsAt: &blackoutStart, EndsAt: &blackoutEnd},
+	} {
+		if err := db.CreateMigrationWindow(ctx, window); err != nil {
+			t.Fatalf("Failed to create migration window: %v", err)
+		}
+	}
+
+	pastTime := time.Now().Add(-10 * time.Minute)
+	batches := make(map[string]*models.Batch)
+	for _, org := range []string{"deferred", "refused"} {
+		b := &models.Batch{Name: org, Type: "test", Status: "ready", RepositoryCount: 1, ScheduledAt: &pastTime, CreatedAt: time.Now()}
+		if err := db.CreateBatch(ctx, b); err != nil {
+			t.Fatalf("Failed to create batch: %v", err)
+		}
+		repo := createTestRepository(org + "/repo")
+		repo.Status = string(models.StatusDryRunComplete)
+		repo.BatchID = &b.ID
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to create repository: %v", err)
+		}
+		batches[org] = b
+	}
+
+	if err := orchestrator.ExecuteScheduledBatches(ctx, false, nil); err != nil {
+		t.Fatalf("ExecuteScheduledBatches failed: %v", err)
+	}
+
+	deferred, _ := db.GetBatch(ctx, batches["deferred"].ID)
+	if deferred.StartedAt != nil || deferred.ScheduledAt == nil || !deferred.ScheduledAt.Equal(blackoutEnd) {
+		t.Errorf("Expected the batch deferred to %v, got started %v, scheduled %v", blackoutEnd, deferred.StartedAt, deferred.ScheduledAt)
+	}
+	refused, _ := db.GetBatch(ctx, batches["refused"].ID)
+	if refused.StartedAt != nil || refused.ScheduledAt != nil {
+		t.Errorf("Expected the batch unscheduled, got started %v, scheduled %v", refused.StartedAt, refused.ScheduledAt)
+	}
+}
+
+func TestSchedulerWorker_StartsBatchesWhenDependenciesComplete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	orchestrator, err := batch.NewOrchestrator(batch.OrchestratorConfig{
+		Storage:  db,
+		Executor: &MockExecutor{},
+		Logger:   slog.Default(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create orchestrator: %v", err)
+	}
+
+	ctx := context.Background()
+	startedAt := time.Now().Add(-time.Hour)
+	completed := &models.Batch{Name: "wave_1", Type: "wave", Status: models.BatchStatusCompleted, StartedAt: &startedAt, CreatedAt: time.Now()}
+	pending := &models.Batch{Name: "wave_a", Type: "wave", Status: models.BatchStatusReady, CreatedAt: time.Now()}
+	for _, b := range []*models.Batch{completed, pending} {
+		if err := db.CreateBatch(ctx, b); err != nil {
+			t.Fatalf("Failed to create batch: %v", err)
+		}
+	}
+
+	// Neither batch is scheduled; they are started by their dependencies alone
+	next := &models.Batch{Name: "wave_2", Type: "wave", Status: models.BatchStatusReady, DependsOnBatchID: &completed.ID, CreatedAt: time.Now()}
+	waiting := &models.Batch{Name: "wave_b", Type: "wave", Status: models.BatchStatusReady, DependsOnBatchID: &pending.ID, CreatedAt: time.Now()}
+	for _, b := range []*models.Batch{next, waiting} {
+		if err := db.CreateBatch(ctx, b); err != nil {
+			t.Fatalf("Failed to create batch: %v", err)
+		}
+		repo := createTestRepository("org/" + b.Name)
+		repo.Status = string(models.StatusDryRunComplete)
+		repo.BatchID = &b.ID
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to create repository: %v", err)
+		}
+	}
+
+	if err := orchestrator.ExecuteScheduledBatches(ctx, false, nil); err != nil {
+		t.Fatalf("ExecuteScheduledBatches failed: %v", err)
+	}
+
+	if updated, _ := db.GetBatch(ctx, next.ID); updated.StartedAt == nil {
+		t.Error("Expected the batch to start once the batch it depends on completed")
+	}
+	if updated, _ := db.GetBatch(ctx, waiting.ID); updated.StartedAt != nil {
+		t.Error("Expected the batch to wait for the batch it depends on")
+	}
+}
+
+func TestSchedulerWorker_StartsOnlyApprovedBatches(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	orchestrator, err := batch.NewOrchestrator(batch.OrchestratorConfig{
+		Storage:  db,
+		Executor: &MockExecutor{},
+		Logger:   slog.Default(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create orchestrator: %v", err)
+	}
+	orchestrator.SetRequireApproval(true)
+
+	ctx := context.Background()
+	past := time.Now().Add(-time.Hour)
+	approved := &models.Batch{Name: "approved", Type: "wave", Status: models.BatchStatusReady, ScheduledAt: &past, LastDryRunAt: &past, CreatedAt: time.Now()}
+	unapproved := &models.Batch{Name: "unapproved", Type: "wave", Status: models.BatchStatusReady, ScheduledAt: &past, LastDryRunAt: &past, CreatedAt: time.Now()}
+	for _, b := range []*models.Batch{approved, unapproved} {
+		if err := db.CreateBatch(ctx, b); err != nil {
+			t.Fatalf("Failed to create batch: %v", err)
+		}
+		repo := createTestRepository("org/" + b.Name)
+		repo.Status = string(models.StatusDryRunComplete)
+		repo.BatchID = &b.ID
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to create repository: %v", err)
+		}
+	}
+	if _, err := batch.RequestApproval(ctx, db, approved, "alice", nil); err != nil {
+		t.Fatalf("RequestApproval failed: %v", err)
+	}
+	if _, err := batch.ReviewApproval(ctx, db, approved, "bob", true, nil); err != nil {
+		t.Fatalf("ReviewApproval failed: %v", err)
+	}
+
+	if err := orchestrator.ExecuteScheduledBatches(ctx, false, nil); err != nil {
+		t.Fatalf("ExecuteScheduledBatches failed: %v", err)
+	}
+
+	if updated, _ := db.GetBatch(ctx, approved.ID); updated.StartedAt == nil {
+		t.Error("Expected the approved batch to start")
+	}
+	if updated, _ := db.GetBatch(ctx, unapproved.ID); updated.StartedAt != nil {
+		t.Error("Expected the batch without an approval to wait")
+	}
+	if latest, _ := batch.LatestApproval(ctx, db, approved.ID); latest == nil ||
+		latest.Action != models.BatchApprovalStarted || latest.Actor != batch.ApprovalActorScheduler {
+		t.Errorf("Expected the start to be recorded in the approval log, got %+v", latest)
+	}
+}
+
 // Helper types and functions
 
 type MockExecutor struct {
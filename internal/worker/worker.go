@@ -2,11 +2,15 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/kuhlman-labs/github-migrator/internal/calendar"
+	"github.com/kuhlman-labs/github-migrator/internal/leader"
 	"github.com/kuhlman-labs/github-migrator/internal/migration"
 	"github.com/kuhlman-labs/github-migrator/internal/models"
 	"github.com/kuhlman-labs/github-migrator/internal/storage"
@@ -19,12 +23,15 @@ type MigrationWorker struct {
 	logger          *slog.Logger
 	pollInterval    time.Duration
 	workers         int
+	instanceID      string
+	leaseTTL        time.Duration
 
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
 	mu     sync.RWMutex
-	active map[int64]bool // Track active migrations
+	active map[int64]bool               // Track active migrations
+	stops  map[int64]context.CancelFunc // Cancel active migrations whose lease was lost
 }
 
 // WorkerConfig configures the migration worker
@@ -34,6 +41,13 @@ type WorkerConfig struct {
 	Logger          *slog.Logger
 	PollInterval    time.Duration
 	Workers         int // Number of parallel migration workers
+
+	// InstanceID uniquely identifies this server replica as the owner of migration leases (default: hostname).
+	// Replicas sharing a database must use different IDs.
+	InstanceID string
+	// LeaseTTL is how long a migration lease stays valid without a heartbeat (default: 2 minutes).
+	// Another replica may take over a migration once its lease expires.
+	LeaseTTL time.Duration
 }
 
 // NewMigrationWorker creates a new migration worker
@@ -53,6 +67,10 @@ func NewMigrationWorker(cfg WorkerConfig) (*MigrationWorker, error) {
 	if cfg.Workers <= 0 {
 		cfg.Workers = 5
 	}
+	cfg.InstanceID = leader.ResolveInstanceID(cfg.InstanceID)
+	if cfg.LeaseTTL <= 0 {
+		cfg.LeaseTTL = 2 * time.Minute
+	}
 
 	return &MigrationWorker{
 		executorFactory: cfg.ExecutorFactory,
@@ -60,7 +78,10 @@ func NewMigrationWorker(cfg WorkerConfig) (*MigrationWorker, error) {
 		logger:          cfg.Logger,
 		pollInterval:    cfg.PollInterval,
 		workers:         cfg.Workers,
+		instanceID:      cfg.InstanceID,
+		leaseTTL:        cfg.LeaseTTL,
 		active:          make(map[int64]bool),
+		stops:           make(map[int64]context.CancelFunc),
 	}, nil
 }
 
@@ -77,12 +98,18 @@ func (w *MigrationWorker) Start(ctx context.Context) error {
 
 	w.logger.Info("Starting migration worker",
 		"poll_interval", w.pollInterval,
-		"workers", w.workers)
+		"workers", w.workers,
+		"instance_id", w.instanceID,
+		"lease_ttl", w.leaseTTL)
 
 	// Start the polling loop
 	w.wg.Add(1)
 	go w.pollLoop()
 
+	// Keep leases on running migrations alive
+	w.wg.Add(1)
+	go w.heartbeatLoop()
+
 	return nil
 }
 
@@ -112,6 +139,9 @@ func (w *MigrationWorker) pollLoop() {
 	ticker := time.NewTicker(w.pollInterval)
 	defer ticker.Stop()
 
+	// Adopt migrations left in flight by a previous run before taking new work
+	w.adoptInFlightMigrations()
+
 	// Process immediately on start
 	w.processQueuedRepositories()
 
@@ -121,11 +151,94 @@ func (w *MigrationWorker) pollLoop() {
 			w.logger.Info("Poll loop stopped")
 			return
 		case <-ticker.C:
+			// Take over migrations whose owner stopped heartbeating
+			w.adoptInFlightMigrations()
 			w.processQueuedRepositories()
 		}
 	}
 }
 
+// heartbeatLoop periodically extends the leases held by this worker and stops the
+// migrations whose lease another replica took over
+func (w *MigrationWorker) heartbeatLoop() {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.leaseTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-ticker.C:
+			renewed, err := w.storage.RenewMigrationLeases(context.Background(), w.instanceID, w.leaseTTL)
+			if err != nil {
+				w.logger.Warn("Failed to renew migration leases",
+					"instance_id", w.instanceID,
+					"error", err)
+				continue
+			}
+			w.logger.Debug("Renewed migration leases",
+				"instance_id", w.instanceID,
+				"count", renewed)
+			w.stopLostMigrations(context.Background())
+		}
+	}
+}
+
+// stopLostMigrations cancels the active migrations whose lease is no longer held by this
+// worker, so a migration another replica adopted is not driven by both
+func (w *MigrationWorker) stopLostMigrations(ctx context.Context) {
+	ids, err := w.storage.ListLeasedRepositoryIDs(ctx, w.instanceID)
+	if err != nil {
+		w.logger.Warn("Failed to list migration leases",
+			"instance_id", w.instanceID,
+			"error", err)
+		return
+	}
+	held := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		held[id] = true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for id, stop := range w.stops {
+		if held[id] {
+			continue
+		}
+		w.logger.Warn("Stopping migration whose lease was taken over by another replica",
+			"repo_id", id,
+			"instance_id", w.instanceID)
+		stop()
+	}
+}
+
+// ownershipCheck returns the check run before each checkpoint of a repository's migration.
+// It stops the migration if another replica now holds the lease.
+func (w *MigrationWorker) ownershipCheck(repo *models.Repository) migration.OwnershipCheck {
+	return func(ctx context.Context) error {
+		lease, err := w.storage.GetMigrationLease(ctx, repo.ID)
+		if err != nil {
+			// Ownership cannot be verified; the heartbeat catches a lost lease
+			w.logger.Warn("Failed to check migration lease",
+				"repo", repo.FullName,
+				"error", err)
+			return nil
+		}
+		if lease != nil && lease.OwnerID == w.instanceID {
+			return nil
+		}
+
+		w.mu.Lock()
+		if stop := w.stops[repo.ID]; stop != nil {
+			stop()
+		}
+		w.mu.Unlock()
+		return migration.ErrLeaseLost
+	}
+}
+
 // processQueuedRepositories fetches queued repositories and dispatches them to workers
 func (w *MigrationWorker) processQueuedRepositories() {
 	ctx := context.Background()
@@ -144,35 +257,71 @@ func (w *MigrationWorker) processQueuedRepositories() {
 		return
 	}
 
+	// Production migrations only start inside the migration windows; dry runs are not restricted
+	cal, err := calendar.Load(ctx, w.storage)
+	if err != nil {
+		w.logger.Error("Failed to load migration windows", "error", err)
+		return
+	}
+
 	// Fetch queued repositories (limit to available slots)
 	// include_details is required to load ADOProperties for correct strategy selection
 	filters := map[string]any{
-		"status": []string{
-			string(models.StatusQueuedForMigration),
-			string(models.StatusDryRunQueued),
-		},
-		"limit":           availableSlots,
+		"status":          queuedStatuses,
 		"order":           "priority DESC, created_at ASC", // High priority first, then FIFO
 		"include_details": true,                            // Load ADOProperties for strategy selection
 	}
-
-	repos, err := w.storage.ListRepositories(ctx, filters)
-	if err != nil {
-		w.logger.Error("Failed to fetch queued repositories", "error", err)
-		return
+	pageSize := availableSlots
+	if !cal.Empty() {
+		// With windows configured, repositories of closed organizations are skipped, so they
+		// must not use up the limit: page through the queue until the slots are filled
+		pageSize = max(availableSlots, queuedPageSize)
 	}
+	filters["limit"] = pageSize
+
+	now := time.Now()
+	closed := make(map[string]bool)
+	dispatched := 0
+	for offset := 0; dispatched < availableSlots; {
+		filters["offset"] = offset
+		repos, err := w.storage.ListRepositories(ctx, filters)
+		if err != nil {
+			w.logger.Error("Failed to fetch queued repositories", "error", err)
+			return
+		}
 
-	if len(repos) == 0 {
-		w.logger.Debug("No queued repositories found")
-		return
-	}
+		if len(repos) == 0 {
+			if offset == 0 {
+				w.logger.Debug("No queued repositories found")
+			}
+			return
+		}
+
+		w.logger.Info("Found queued repositories",
+			"count", len(repos),
+			"offset", offset,
+			"available_slots", availableSlots)
 
-	w.logger.Info("Found queued repositories",
-		"count", len(repos),
-		"available_slots", availableSlots)
+		claimed := w.dispatchQueued(ctx, repos, availableSlots-dispatched, cal, now, closed)
+		dispatched += claimed
 
-	// Dispatch each repository to a worker
+		// Dispatched repositories leave the queue, the others are still ahead of the next page
+		if cal.Empty() || len(repos) < pageSize {
+			return
+		}
+		offset += len(repos) - claimed
+	}
+}
+
+// dispatchQueued dispatches up to slots of the listed queued repositories to workers and returns
+// how many were dispatched. Production migrations outside the migration windows are skipped.
+func (w *MigrationWorker) dispatchQueued(ctx context.Context, repos []*models.Repository, slots int, cal *calendar.Calendar, now time.Time, closed map[string]bool) int {
+	dispatched := 0
 	for _, repo := range repos {
+		if dispatched >= slots {
+			break
+		}
+
 		// Check if already processing (shouldn't happen, but defensive)
 		w.mu.RLock()
 		if w.active[repo.ID] {
@@ -181,49 +330,125 @@ func (w *MigrationWorker) processQueuedRepositories() {
 		}
 		w.mu.RUnlock()
 
-		// Mark as active
-		w.mu.Lock()
-		w.active[repo.ID] = true
-		w.mu.Unlock()
+		if repo.Status == string(models.StatusQueuedForMigration) && w.outsideMigrationWindows(cal, repo, now, closed) {
+			continue
+		}
+
+		// Another replica may have picked the repository up first
+		if !w.claim(ctx, repo, queuedStatuses) {
+			continue
+		}
+		dispatched++
 
 		// Start migration in background
 		w.wg.Add(1)
-		go w.executeMigration(repo)
+		go w.executeMigration(w.begin(repo), repo)
+	}
+	return dispatched
+}
+
+// outsideMigrationWindows reports whether production migrations for a repository's organization
+// are closed by a blackout or outside the allowed windows. Decisions are cached per organization
+// in closed for the current poll.
+func (w *MigrationWorker) outsideMigrationWindows(cal *calendar.Calendar, repo *models.Repository, now time.Time, closed map[string]bool) bool {
+	org := repo.Organization()
+	isClosed, checked := closed[org]
+	if !checked {
+		decision := cal.Check([]string{org}, now)
+		isClosed = !decision.Open
+		closed[org] = isClosed
+		if isClosed {
+			w.logger.Debug("Holding queued migrations outside the migration windows",
+				"organization", org,
+				"reason", decision.Reason)
+		}
+	}
+	return isClosed
+}
+
+// claim acquires the migration lease for a repository on behalf of this worker.
+// The repository's status is re-read after the lease is acquired, since another
+// replica may have finished the migration between listing and claiming; the lease
+// is released again unless the status is still one of the expected statuses.
+func (w *MigrationWorker) claim(ctx context.Context, repo *models.Repository, statuses []string) bool {
+	acquired, err := w.storage.AcquireMigrationLease(ctx, repo.ID, w.instanceID, w.leaseTTL)
+	if err != nil {
+		w.logger.Error("Failed to acquire migration lease",
+			"repo", repo.FullName,
+			"error", err)
+		return false
+	}
+	if !acquired {
+		w.logger.Debug("Repository is leased by another replica",
+			"repo", repo.FullName,
+			"repo_id", repo.ID)
+		return false
+	}
+
+	current, err := w.storage.GetRepositoryByID(ctx, repo.ID)
+	if err != nil || current == nil || !slices.Contains(statuses, current.Status) {
+		w.releaseLease(ctx, repo)
+		return false
+	}
+	return true
+}
+
+// releaseLease releases this worker's lease on a repository
+func (w *MigrationWorker) releaseLease(ctx context.Context, repo *models.Repository) {
+	if err := w.storage.ReleaseMigrationLease(ctx, repo.ID, w.instanceID); err != nil {
+		w.logger.Warn("Failed to release migration lease",
+			"repo", repo.FullName,
+			"error", err)
 	}
 }
 
+// begin marks a claimed repository as active and returns the context its migration runs in.
+// The context is cancelled if the lease is lost. It does not derive from the worker's context,
+// since Stop waits for active migrations to complete.
+func (w *MigrationWorker) begin(repo *models.Repository) context.Context {
+	ctx, stop := context.WithCancel(context.Background())
+
+	w.mu.Lock()
+	w.active[repo.ID] = true
+	w.stops[repo.ID] = stop
+	w.mu.Unlock()
+
+	return migration.WithOwnershipCheck(ctx, w.ownershipCheck(repo))
+}
+
+// leaseLost reports whether a migration ended because another replica took over its lease.
+// Nothing is recorded for it, since the new owner drives the migration now.
+func (w *MigrationWorker) leaseLost(ctx context.Context, repo *models.Repository, err error) bool {
+	if !errors.Is(err, migration.ErrLeaseLost) && ctx.Err() == nil {
+		return false
+	}
+	w.logger.Warn("Migration stopped after another replica took over its lease",
+		"repo", repo.FullName,
+		"repo_id", repo.ID,
+		"instance_id", w.instanceID)
+	return true
+}
+
+// finish removes a repository from the active list and releases its lease
+func (w *MigrationWorker) finish(repo *models.Repository) {
+	w.mu.Lock()
+	delete(w.active, repo.ID)
+	if stop := w.stops[repo.ID]; stop != nil {
+		stop()
+		delete(w.stops, repo.ID)
+	}
+	w.mu.Unlock()
+
+	w.releaseLease(context.Background(), repo)
+}
+
 // executeMigration executes a single migration
-func (w *MigrationWorker) executeMigration(repo *models.Repository) {
+func (w *MigrationWorker) executeMigration(ctx context.Context, repo *models.Repository) {
 	defer w.wg.Done()
-	defer func() {
-		// Remove from active list
-		w.mu.Lock()
-		delete(w.active, repo.ID)
-		w.mu.Unlock()
-	}()
+	defer w.finish(repo)
 
 	// Recover from panics to prevent worker crashes and update repository status
-	defer func() {
-		if r := recover(); r != nil {
-			w.logger.Error("Migration panicked - recovering",
-				"repo", repo.FullName,
-				"panic", r)
-
-			// Update status to failed
-			ctx := context.Background()
-			dryRun := repo.Status == string(models.StatusDryRunInProgress)
-			failedStatus := models.StatusMigrationFailed
-			if dryRun {
-				failedStatus = models.StatusDryRunFailed
-			}
-			repo.Status = string(failedStatus)
-			if updateErr := w.storage.UpdateRepository(ctx, repo); updateErr != nil {
-				w.logger.Error("Failed to update repository status after panic",
-					"repo", repo.FullName,
-					"error", updateErr)
-			}
-		}
-	}()
+	defer w.recoverPanic(repo)
 
 	// Determine if this is a dry run
 	dryRun := repo.Status == string(models.StatusDryRunQueued)
@@ -234,28 +459,8 @@ func (w *MigrationWorker) executeMigration(repo *models.Repository) {
 		"dry_run", dryRun,
 		"has_batch", repo.BatchID != nil)
 
-	// Create context for this migration execution
-	ctx := context.Background()
-
 	// Fetch batch details if repository is part of a batch
-	var batch *models.Batch
-	if repo.BatchID != nil {
-		fetchedBatch, err := w.storage.GetBatch(ctx, *repo.BatchID)
-		if err != nil {
-			w.logger.Warn("Failed to fetch batch for repository",
-				"repo", repo.FullName,
-				"batch_id", *repo.BatchID,
-				"error", err)
-			// Continue without batch - repo will use its own settings or defaults
-		} else {
-			batch = fetchedBatch
-			w.logger.Debug("Fetched batch settings for repository",
-				"repo", repo.FullName,
-				"batch_name", batch.Name,
-				"destination_org", batch.DestinationOrg,
-				"exclude_releases", batch.ExcludeReleases)
-		}
-	}
+	batch := w.getBatch(ctx, repo)
 
 	// Update status to in-progress
 	statusUpdate := models.StatusMigratingContent
@@ -276,6 +481,9 @@ func (w *MigrationWorker) executeMigration(repo *models.Repository) {
 	// based on the repository's source type
 	err := w.executorFactory.ExecuteWithStrategy(ctx, repo, batch, dryRun)
 
+	if err != nil && w.leaseLost(ctx, repo, err) {
+		return
+	}
 	if err != nil {
 		w.logger.Error("Migration failed",
 			"repo", repo.FullName,
@@ -284,16 +492,7 @@ func (w *MigrationWorker) executeMigration(repo *models.Repository) {
 			"error", err)
 
 		// Update status to failed
-		failedStatus := models.StatusMigrationFailed
-		if dryRun {
-			failedStatus = models.StatusDryRunFailed
-		}
-		repo.Status = string(failedStatus)
-		if updateErr := w.storage.UpdateRepository(ctx, repo); updateErr != nil {
-			w.logger.Error("Failed to update repository status after failure",
-				"repo", repo.FullName,
-				"error", updateErr)
-		}
+		w.markFailed(ctx, repo, dryRun)
 	} else {
 		w.logger.Info("Migration completed successfully",
 			"repo", repo.FullName,
@@ -310,6 +509,169 @@ func (w *MigrationWorker) executeMigration(repo *models.Repository) {
 	}
 }
 
+// queuedPageSize is how many queued repositories are listed at a time when migration windows
+// may hold some of them back
+var queuedPageSize = 100
+
+// queuedStatuses are the statuses of repositories waiting for a worker
+var queuedStatuses = []string{
+	string(models.StatusQueuedForMigration),
+	string(models.StatusDryRunQueued),
+}
+
+// inFlightStatuses are the statuses of repositories whose migration is being executed.
+// Repositories in one of these without a live lease were orphaned by a restart or a crashed replica.
+var inFlightStatuses = []string{
+	string(models.StatusPreMigration),
+	string(models.StatusArchiveGenerating),
+	string(models.StatusMigratingContent),
+	string(models.StatusPostMigration),
+	string(models.StatusDryRunInProgress),
+}
+
+// adoptInFlightMigrations picks up migrations orphaned by a restart or by a replica
+// whose lease expired. Each one resumes from its last checkpoint, or is queued again
+// if nothing had been started remotely yet.
+func (w *MigrationWorker) adoptInFlightMigrations() {
+	ctx := context.Background()
+
+	// include_details is required to load ADOProperties for correct strategy selection
+	repos, err := w.storage.ListRepositories(ctx, map[string]any{
+		"status":          inFlightStatuses,
+		"include_details": true,
+	})
+	if err != nil {
+		w.logger.Error("Failed to fetch in-flight repositories", "error", err)
+		return
+	}
+
+	for _, repo := range repos {
+		w.mu.RLock()
+		if w.active[repo.ID] {
+			w.mu.RUnlock()
+			continue
+		}
+		w.mu.RUnlock()
+
+		// Migrations with a live lease are still running on another replica
+		if !w.claim(ctx, repo, inFlightStatuses) {
+			continue
+		}
+
+		migrationCtx := w.begin(repo)
+
+		w.logger.Info("Adopting interrupted migration",
+			"repo", repo.FullName,
+			"repo_id", repo.ID,
+			"status", repo.Status)
+
+		w.wg.Add(1)
+		go w.resumeMigration(migrationCtx, repo)
+	}
+}
+
+// resumeMigration resumes a single orphaned migration
+func (w *MigrationWorker) resumeMigration(ctx context.Context, repo *models.Repository) {
+	defer w.wg.Done()
+	defer w.finish(repo)
+	defer w.recoverPanic(repo)
+
+	dryRun := repo.Status == string(models.StatusDryRunInProgress)
+
+	w.logger.Info("Resuming interrupted migration",
+		"repo", repo.FullName,
+		"repo_id", repo.ID,
+		"status", repo.Status)
+
+	err := w.executorFactory.ResumeWithStrategy(ctx, repo, w.getBatch(ctx, repo))
+
+	switch {
+	case err != nil && w.leaseLost(ctx, repo, err):
+		return
+
+	case errors.Is(err, migration.ErrNoCheckpoint):
+		// Nothing was started remotely - queue the migration to run again from the start
+		queuedStatus := models.StatusQueuedForMigration
+		if dryRun {
+			queuedStatus = models.StatusDryRunQueued
+		}
+		repo.Status = string(queuedStatus)
+		if updateErr := w.storage.UpdateRepository(ctx, repo); updateErr != nil {
+			w.logger.Error("Failed to re-queue interrupted migration",
+				"repo", repo.FullName,
+				"error", updateErr)
+			return
+		}
+		w.logger.Info("Re-queued interrupted migration with no checkpoint",
+			"repo", repo.FullName,
+			"status", repo.Status)
+
+	case err != nil:
+		w.logger.Error("Resumed migration failed",
+			"repo", repo.FullName,
+			"repo_id", repo.ID,
+			"error", err)
+		w.markFailed(ctx, repo, dryRun)
+
+	default:
+		w.logger.Info("Resumed migration completed successfully",
+			"repo", repo.FullName,
+			"repo_id", repo.ID)
+	}
+}
+
+// getBatch fetches the repository's batch, or nil if it has none or the batch cannot be loaded.
+func (w *MigrationWorker) getBatch(ctx context.Context, repo *models.Repository) *models.Batch {
+	if repo.BatchID == nil {
+		return nil
+	}
+
+	batch, err := w.storage.GetBatch(ctx, *repo.BatchID)
+	if err != nil {
+		w.logger.Warn("Failed to fetch batch for repository",
+			"repo", repo.FullName,
+			"batch_id", *repo.BatchID,
+			"error", err)
+		// Continue without batch - repo will use its own settings or defaults
+		return nil
+	}
+	if batch != nil {
+		w.logger.Debug("Fetched batch settings for repository",
+			"repo", repo.FullName,
+			"batch_name", batch.Name,
+			"destination_org", batch.DestinationOrg,
+			"exclude_releases", batch.ExcludeReleases)
+	}
+	return batch
+}
+
+// markFailed sets the repository's status to the failed status for its migration type.
+func (w *MigrationWorker) markFailed(ctx context.Context, repo *models.Repository, dryRun bool) {
+	failedStatus := models.StatusMigrationFailed
+	if dryRun {
+		failedStatus = models.StatusDryRunFailed
+	}
+	repo.Status = string(failedStatus)
+	if updateErr := w.storage.UpdateRepository(ctx, repo); updateErr != nil {
+		w.logger.Error("Failed to update repository status after failure",
+			"repo", repo.FullName,
+			"error", updateErr)
+	}
+}
+
+// recoverPanic recovers from a panic during a migration and marks the repository as failed.
+// It must be deferred directly.
+func (w *MigrationWorker) recoverPanic(repo *models.Repository) {
+	if r := recover(); r != nil {
+		w.logger.Error("Migration panicked - recovering",
+			"repo", repo.FullName,
+			"panic", r)
+
+		dryRun := repo.Status == string(models.StatusDryRunInProgress)
+		w.markFailed(context.Background(), repo, dryRun)
+	}
+}
+
 // GetActiveCount returns the number of currently active migrations
 func (w *MigrationWorker) GetActiveCount() int {
 	w.mu.RLock()
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os"
 	"testing"
@@ -392,3 +393,291 @@ func TestMigrationWorker_IsActive(t *testing.T) {
 		}
 	}
 }
+
+// createBitbucketSource creates a Bitbucket source so migrations can resolve an executor
+func createBitbucketSource(t *testing.T, db *storage.Database) *models.Source {
+	t.Helper()
+
+	source := &models.Source{
+		Name:    "Bitbucket",
+		Type:    models.SourceConfigTypeBitbucket,
+		BaseURL: "https://bitbucket.example.com",
+		Token:   "bb-token",
+	}
+	if err := db.CreateSource(context.Background(), source); err != nil {
+		t.Fatalf("Failed to create source: %v", err)
+	}
+	return source
+}
+
+func TestMigrationWorker_AdoptInFlightMigrations(t *testing.T) {
+	worker, db, _ := setupTestWorker(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	source := createBitbucketSource(t, db)
+
+	// Repositories interrupted before anything started remotely are queued again
+	tests := []struct {
+		fullName   string
+		status     models.MigrationStatus
+		wantStatus models.MigrationStatus
+	}{
+		{"PROJ/migrating", models.StatusMigratingContent, models.StatusQueuedForMigration},
+		{"PROJ/pre-migration", models.StatusPreMigration, models.StatusQueuedForMigration},
+		{"PROJ/dry-run", models.StatusDryRunInProgress, models.StatusDryRunQueued},
+		{"PROJ/pending", models.StatusPending, models.StatusPending},
+	}
+	for _, tt := range tests {
+		repo := &models.Repository{
+			FullName:  tt.fullName,
+			Source:    models.SourceBitbucket,
+			SourceURL: "https://bitbucket.example.com/scm/proj/repo.git",
+			SourceID:  &source.ID,
+			Status:    string(tt.status),
+		}
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to save repository: %v", err)
+		}
+	}
+
+	worker.adoptInFlightMigrations()
+	worker.wg.Wait()
+
+	for _, tt := range tests {
+		repo, err := db.GetRepository(ctx, tt.fullName)
+		if err != nil || repo == nil {
+			t.Fatalf("Failed to load repository %s: %v", tt.fullName, err)
+		}
+		if repo.Status != string(tt.wantStatus) {
+			t.Errorf("%s status = %s, want %s", tt.fullName, repo.Status, tt.wantStatus)
+		}
+	}
+	if count := worker.GetActiveCount(); count != 0 {
+		t.Errorf("Expected no active migrations after adoption, got %d", count)
+	}
+}
+
+func TestMigrationWorker_SkipsRepositoriesLeasedByAnotherReplica(t *testing.T) {
+	worker, db, _ := setupTestWorker(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	queued := &models.Repository{
+		FullName:  "org/queued",
+		SourceURL: "https://github.com/org/queued",
+		Status:    string(models.StatusQueuedForMigration),
+	}
+	inFlight := &models.Repository{
+		FullName:  "org/in-flight",
+		SourceURL: "https://github.com/org/in-flight",
+		Status:    string(models.StatusMigratingContent),
+	}
+	for _, repo := range []*models.Repository{queued, inFlight} {
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to save repository: %v", err)
+		}
+		saved, _ := db.GetRepository(ctx, repo.FullName)
+		if acquired, err := db.AcquireMigrationLease(ctx, saved.ID, "other-replica", time.Minute); err != nil || !acquired {
+			t.Fatalf("AcquireMigrationLease() = %v, %v", acquired, err)
+		}
+	}
+
+	worker.adoptInFlightMigrations()
+	worker.processQueuedRepositories()
+	worker.wg.Wait()
+
+	if count := worker.GetActiveCount(); count != 0 {
+		t.Errorf("Expected no active migrations, got %d", count)
+	}
+	for _, repo := range []*models.Repository{queued, inFlight} {
+		updated, _ := db.GetRepository(ctx, repo.FullName)
+		if updated.Status != repo.Status {
+			t.Errorf("%s status = %s, want %s", repo.FullName, updated.Status, repo.Status)
+		}
+		lease, _ := db.GetMigrationLease(ctx, updated.ID)
+		if lease == nil || lease.OwnerID != "other-replica" {
+			t.Errorf("%s lease = %+v, want held by other-replica", repo.FullName, lease)
+		}
+	}
+}
+
+func TestMigrationWorker_HoldsQueuedMigrationsInBlackout(t *testing.T) {
+	worker, db, _ := setupTestWorker(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	org := "frozen"
+	start, end := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	if err := db.CreateMigrationWindow(ctx, &models.MigrationWindow{
+		Name: "Release freeze", Kind: models.MigrationWindowKindBlackout, Organization: &org,
+		Policy: models.MigrationWindowPolicyDefer, TimeZone: "UTC", StartsAt: &start, EndsAt: &end,
+	}); err != nil {
+		t.Fatalf("Failed to create migration window: %v", err)
+	}
+
+	queued := &models.Repository{
+		FullName:  "frozen/queued",
+		SourceURL: "https://github.com/frozen/queued",
+		Status:    string(models.StatusQueuedForMigration),
+	}
+	if err := db.SaveRepository(ctx, queued); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+
+	worker.processQueuedRepositories()
+	worker.wg.Wait()
+
+	updated, _ := db.GetRepository(ctx, queued.FullName)
+	if updated.Status != queued.Status {
+		t.Errorf("Expected the repository to stay queued, got %s", updated.Status)
+	}
+	if lease, _ := db.GetMigrationLease(ctx, updated.ID); lease != nil {
+		t.Errorf("Expected the repository not to be claimed during the blackout, got lease %+v", lease)
+	}
+}
+
+func TestMigrationWorker_PagesPastQueuedMigrationsInBlackout(t *testing.T) {
+	worker, db, _ := setupTestWorker(t)
+	defer func() { _ = db.Close() }()
+
+	defer func(size int) { queuedPageSize = size }(queuedPageSize)
+	queuedPageSize = 2
+
+	ctx := context.Background()
+
+	org := "frozen"
+	start, end := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	if err := db.CreateMigrationWindow(ctx, &models.MigrationWindow{
+		Name: "Release freeze", Kind: models.MigrationWindowKindBlackout, Organization: &org,
+		Policy: models.MigrationWindowPolicyDefer, TimeZone: "UTC", StartsAt: &start, EndsAt: &end,
+	}); err != nil {
+		t.Fatalf("Failed to create migration window: %v", err)
+	}
+
+	// More held repositories than fit in a page are ahead of the open one in the queue
+	for _, name := range []string{"frozen/one", "frozen/two", "frozen/three"} {
+		repo := &models.Repository{
+			FullName:  name,
+			SourceURL: "https://github.com/" + name,
+			Status:    string(models.StatusQueuedForMigration),
+			Priority:  1,
+		}
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to save repository: %v", err)
+		}
+	}
+	open := &models.Repository{
+		FullName:  "open/queued",
+		SourceURL: "https://github.com/open/queued",
+		Status:    string(models.StatusQueuedForMigration),
+	}
+	if err := db.SaveRepository(ctx, open); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+
+	worker.processQueuedRepositories()
+	worker.wg.Wait()
+
+	if updated, _ := db.GetRepository(ctx, open.FullName); updated.Status == open.Status {
+		t.Error("Expected the repository of an open organization to be dispatched")
+	}
+	if held, _ := db.GetRepository(ctx, "frozen/three"); held.Status != string(models.StatusQueuedForMigration) {
+		t.Errorf("Expected the repository to stay queued during the blackout, got %s", held.Status)
+	}
+}
+
+func TestMigrationWorker_AdoptsMigrationWithExpiredLease(t *testing.T) {
+	worker, db, _ := setupTestWorker(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	source := createBitbucketSource(t, db)
+	repo := &models.Repository{
+		FullName:  "PROJ/orphaned",
+		Source:    models.SourceBitbucket,
+		SourceURL: "https://bitbucket.example.com/scm/proj/orphaned.git",
+		SourceID:  &source.ID,
+		Status:    string(models.StatusMigratingContent),
+	}
+	if err := db.SaveRepository(ctx, repo); err != nil {
+		t.Fatalf("Failed to save repository: %v", err)
+	}
+	saved, _ := db.GetRepository(ctx, repo.FullName)
+
+	// The replica running this migration stopped heartbeating
+	if _, err := db.AcquireMigrationLease(ctx, saved.ID, "crashed-replica", -time.Second); err != nil {
+		t.Fatalf("AcquireMigrationLease() error: %v", err)
+	}
+
+	worker.adoptInFlightMigrations()
+	worker.wg.Wait()
+
+	// No checkpoint was recorded, so the migration is queued again
+	updated, _ := db.GetRepository(ctx, repo.FullName)
+	if updated.Status != string(models.StatusQueuedForMigration) {
+		t.Errorf("status = %s, want %s", updated.Status, models.StatusQueuedForMigration)
+	}
+
+	// The adopting worker releases its lease when it is done
+	if lease, _ := db.GetMigrationLease(ctx, saved.ID); lease != nil {
+		t.Errorf("Expected lease to be released, got %+v", lease)
+	}
+}
+
+func TestMigrationWorker_StopsMigrationWhoseLeaseWasLost(t *testing.T) {
+	worker, db, _ := setupTestWorker(t)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+
+	var repos []*models.Repository
+	for _, name := range []string{"org/kept", "org/lost"} {
+		repo := &models.Repository{
+			FullName:  name,
+			SourceURL: "https://github.com/" + name,
+			Status:    string(models.StatusMigratingContent),
+		}
+		if err := db.SaveRepository(ctx, repo); err != nil {
+			t.Fatalf("Failed to save repository: %v", err)
+		}
+		saved, _ := db.GetRepository(ctx, name)
+		if !worker.claim(ctx, saved, inFlightStatuses) {
+			t.Fatalf("Failed to claim %s", name)
+		}
+		repos = append(repos, saved)
+	}
+	kept, lost := repos[0], repos[1]
+	keptCtx := worker.begin(kept)
+	lostCtx := worker.begin(lost)
+	defer worker.finish(kept)
+	defer worker.finish(lost)
+
+	// The worker stalled long enough for another replica to take the migration over
+	if _, err := db.AcquireMigrationLease(ctx, lost.ID, worker.instanceID, -time.Second); err != nil {
+		t.Fatalf("AcquireMigrationLease() error: %v", err)
+	}
+	if acquired, err := db.AcquireMigrationLease(ctx, lost.ID, "other-replica", time.Minute); err != nil || !acquired {
+		t.Fatalf("AcquireMigrationLease(other-replica) = %v, %v", acquired, err)
+	}
+
+	worker.stopLostMigrations(ctx)
+	if lostCtx.Err() == nil {
+		t.Error("Expected the migration whose lease was lost to be cancelled")
+	}
+	if keptCtx.Err() != nil {
+		t.Error("Expected the migration whose lease is held to keep running")
+	}
+
+	// The next checkpoint must not be written by the old owner
+	if err := worker.ownershipCheck(lost)(ctx); !errors.Is(err, migration.ErrLeaseLost) {
+		t.Errorf("ownershipCheck() error = %v, want ErrLeaseLost", err)
+	}
+	if err := worker.ownershipCheck(kept)(ctx); err != nil {
+		t.Errorf("ownershipCheck() error = %v for a held lease", err)
+	}
+}
@@ -1,7 +1,8 @@
 import { useState, useEffect, useCallback } from 'react';
 import { UploadIcon, PlusIcon, RepoIcon } from '@primer/octicons-react';
 import { BorderedButton, PrimaryButton } from '../common/buttons';
-import type { Repository, Batch, RepositoryFilters } from '../../types';
+import type { Repository, Batch, RepositoryFilters, CompletionAction } from '../../types';
+import { parseCompletionActions } from '../../types';
 import { api } from '../../services/api';
 import { UnifiedFilterSidebar } from '../common/UnifiedFilterSidebar';
 import { ActiveFilterPills } from './ActiveFilterPills';
@@ -40,9 +41,10 @@ export function BatchBuilder({ batch, onClose, onSuccess }: BatchBuilderProps) {
 
   // Migration settings
   const [destinationOrg, setDestinationOrg] = useState('');
-  const [migrationAPI, setMigrationAPI] = useState<'GEI' | 'ELM'>('GEI');
+  const [migrationAPI, setMigrationAPI] = useState<'GEI' | 'ELM' | 'GIT'>('GEI');
   const [excludeReleases, setExcludeReleases] = useState(false);
   const [excludeAttachments, setExcludeAttachments] = useState(false);
+  const [completionActions, setCompletionActions] = useState<CompletionAction[]>([]);
   
   // Organization list for autocomplete
   const [organizations, setOrganizations] = useState<string[]>([]);
@@ -131,6 +133,7 @@ export function BatchBuilder({ batch, onClose, onSuccess }: BatchBuilderProps) {
       setMigrationAPI(batchData.migration_api || 'GEI');
       setExcludeReleases(batchData.exclude_releases || false);
       setExcludeAttachments(batchData.exclude_attachments || false);
+      setCompletionActions(parseCompletionActions(batchData.completion_actions));
     }
   }, [batch]);
 
@@ -509,6 +512,7 @@ export function BatchBuilder({ batch, onClose, onSuccess }: BatchBuilderProps) {
           migration_api: migrationAPI,
           exclude_releases: excludeReleases,
           exclude_attachments: excludeAttachments,
+          completion_actions: completionActions.join(','),
         });
         
         // Update repositories - add new ones, remove old ones
@@ -539,6 +543,7 @@ export function BatchBuilder({ batch, onClose, onSuccess }: BatchBuilderProps) {
           migration_api: migrationAPI,
           exclude_releases: excludeReleases,
           exclude_attachments: excludeAttachments,
+          completion_actions: completionActions.join(',') || undefined,
         });
         
         batchId = newBatch.id;
@@ -921,12 +926,14 @@ export function BatchBuilder({ batch, onClose, onSuccess }: BatchBuilderProps) {
             migrationAPI,
             excludeReleases,
             excludeAttachments,
+            completionActions,
           }}
           onMigrationSettingsChange={(settings) => {
             if (settings.destinationOrg !== undefined) setDestinationOrg(settings.destinationOrg);
             if (settings.migrationAPI !== undefined) setMigrationAPI(settings.migrationAPI);
             if (settings.excludeReleases !== undefined) setExcludeReleases(settings.excludeReleases);
             if (settings.excludeAttachments !== undefined) setExcludeAttachments(settings.excludeAttachments);
+            if (settings.completionActions !== undefined) setCompletionActions(settings.completionActions);
           }}
           showMigrationSettings={showMigrationSettings}
           setShowMigrationSettings={setShowMigrationSettings}
@@ -246,6 +246,156 @@ describe('BatchDetailHeader', () => {
     expect(screen.getByText('target-org')).toBeInTheDocument();
   });
 
+  it('should show completion actions in Migration Settings when set', () => {
+    const batchWithActions = { ...baseBatch, completion_actions: 'description,archive' };
+
+    render(
+      <BatchDetailHeader
+        batch={batchWithActions}
+        batchRepositories={baseRepositories}
+        onEdit={mockOnEdit}
+        onDelete={mockOnDelete}
+        onDryRun={mockOnDryRun}
+        onStart={mockOnStart}
+        onRetryFailed={mockOnRetryFailed}
+      />
+    );
+
+    expect(screen.getByText('After Migration:')).toBeInTheDocument();
+    expect(screen.getByText('Description, Archive source')).toBeInTheDocument();
+  });
+
+  it('should show the duration forecast in the Timeline', () => {
+    render(
+      <BatchDetailHeader
+        batch={baseBatch}
+        batchRepositories={baseRepositories}
+        forecast={{
+          repositories: 2,
+          workers: 1,
+          total_seconds: 5400,
+          wall_clock_seconds: 5400,
+          calibration_samples: 4,
+          calibration_factor: 1.2,
+        }}
+        onEdit={mockOnEdit}
+        onDelete={mockOnDelete}
+        onDryRun={mockOnDryRun}
+        onStart={mockOnStart}
+        onRetryFailed={mockOnRetryFailed}
+      />
+    );
+
+    expect(screen.getByText('FORECAST')).toBeInTheDocument();
+    expect(screen.getByText('Estimated Duration: 1h 30m 0s')).toBeInTheDocument();
+    expect(screen.getByText('2 repositories on 1 workers, calibrated by 4 past migrations')).toBeInTheDocument();
+    expect(screen.queryByText('No activity yet')).not.toBeInTheDocument();
+  });
+
+  it('should show a blocked dependency in the Timeline', () => {
+    render(
+      <BatchDetailHeader
+        batch={{ ...baseBatch, depends_on_batch_id: 7, max_dependency_failures: 2 }}
+        batchRepositories={baseRepositories}
+        dependency={{
+          batch_id: 7,
+          batch_name: 'Wave 2',
+          batch_status: 'completed_with_errors',
+          state: 'blocked',
+          failures: 3,
+          max_failures: 2,
+          reason: 'batch "Wave 2" it depends on has 3 failed migrations, more than the 2 allowed',
+        }}
+        onEdit={mockOnEdit}
+        onDelete={mockOnDelete}
+        onDryRun={mockOnDryRun}
+        onStart={mockOnStart}
+        onRetryFailed={mockOnRetryFailed}
+      />
+    );
+
+    expect(screen.getByText('BLOCKED')).toBeInTheDocument();
+    expect(screen.getByText('Starts after: Wave 2')).toBeInTheDocument();
+    expect(screen.queryByText('No activity yet')).not.toBeInTheDocument();
+  });
+
+  it('should offer approval instead of starting an unapproved batch', () => {
+    const onApprovalAction = vi.fn();
+    const readyBatch = { ...baseBatch, status: 'ready' as const, last_dry_run_at: '2024-01-02T10:00:00Z' };
+    render(
+      <BatchDetailHeader
+        batch={readyBatch}
+        batchRepositories={baseRepositories}
+        approvalRequired
+        onEdit={mockOnEdit}
+        onDelete={mockOnDelete}
+        onDryRun={mockOnDryRun}
+        onStart={mockOnStart}
+        onRetryFailed={mockOnRetryFailed}
+        onApprovalAction={onApprovalAction}
+      />
+    );
+
+    expect(screen.queryByText('Start Migration')).not.toBeInTheDocument();
+    fireEvent.click(screen.getByText('Request Approval'));
+    expect(onApprovalAction).toHaveBeenCalledWith(readyBatch, 'request');
+  });
+
+  it('should show a pending approval request with review actions', () => {
+    const onApprovalAction = vi.fn();
+    const readyBatch = { ...baseBatch, status: 'ready' as const, last_dry_run_at: '2024-01-02T10:00:00Z' };
+    render(
+      <BatchDetailHeader
+        batch={readyBatch}
+        batchRepositories={baseRepositories}
+        approvalRequired
+        approval={{
+          id: 1,
+          batch_id: 1,
+          action: 'requested',
+          actor: 'alice',
+          comment: 'CHG-1042',
+          dry_run_summary: { repositories: 2, succeeded: 1, failed: 1, not_run: 0, repository_ids: [1, 2] },
+          created_at: '2024-01-02T11:00:00Z',
+        }}
+        onEdit={mockOnEdit}
+        onDelete={mockOnDelete}
+        onDryRun={mockOnDryRun}
+        onStart={mockOnStart}
+        onRetryFailed={mockOnRetryFailed}
+        onApprovalAction={onApprovalAction}
+      />
+    );
+
+    expect(screen.getByText('AWAITING APPROVAL')).toBeInTheDocument();
+    expect(screen.getByText('Dry run: 1 succeeded, 1 failed, 0 not run')).toBeInTheDocument();
+    expect(screen.queryByText('Request Approval')).not.toBeInTheDocument();
+    expect(screen.queryByText('Start Migration')).not.toBeInTheDocument();
+    fireEvent.click(screen.getByText('Reject'));
+    expect(onApprovalAction).toHaveBeenCalledWith(readyBatch, 'reject');
+  });
+
+  it('should allow starting an approved batch', () => {
+    render(
+      <BatchDetailHeader
+        batch={{ ...baseBatch, status: 'ready' as const, last_dry_run_at: '2024-01-02T10:00:00Z' }}
+        batchRepositories={baseRepositories}
+        approvalRequired
+        approval={{ id: 2, batch_id: 1, action: 'approved', actor: 'bob', created_at: '2024-01-02T12:00:00Z' }}
+        onEdit={mockOnEdit}
+        onDelete={mockOnDelete}
+        onDryRun={mockOnDryRun}
+        onStart={mockOnStart}
+        onRetryFailed={mockOnRetryFailed}
+        onApprovalAction={vi.fn()}
+      />
+    );
+
+    expect(screen.getByText('APPROVED')).toBeInTheDocument();
+    fireEvent.click(screen.getByText('Start Migration'));
+    expect(mockOnStart).toHaveBeenCalledWith(1);
+  });
+
   it('should show Schedule & Timeline section', () => {
     render(
       <BatchDetailHeader
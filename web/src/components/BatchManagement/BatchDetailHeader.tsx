@@ -1,34 +1,46 @@
 import { ActionMenu, ActionList } from '@primer/react';
-import { GearIcon, ClockIcon, PencilIcon, TrashIcon, TriangleDownIcon, PlayIcon, SyncIcon, IterationsIcon, BeakerIcon } from '@primer/octicons-react';
+import { GearIcon, ClockIcon, PencilIcon, TrashIcon, TriangleDownIcon, PlayIcon, SyncIcon, IterationsIcon, BeakerIcon, CheckIcon, XIcon, ShieldCheckIcon } from '@primer/octicons-react';
 import { Button, SuccessButton, BorderedButton } from '../common/buttons';
-import type { Batch, Repository } from '../../types';
-import { formatBatchDuration, formatDryRunDuration } from '../../types';
+import type { Batch, BatchApproval, BatchDependency, MigrationForecast, Repository } from '../../types';
+import { COMPLETION_ACTIONS, formatBatchDuration, formatDryRunDuration, formatDurationSeconds, parseCompletionActions } from '../../types';
 import { StatusBadge } from '../common/StatusBadge';
 import { formatDate } from '../../utils/format';
 
 interface BatchDetailHeaderProps {
   batch: Batch;
   batchRepositories: Repository[];
+  forecast?: MigrationForecast | null;
+  dependency?: BatchDependency | null;
+  approval?: BatchApproval | null;
+  approvalRequired?: boolean;
   onEdit: (batch: Batch) => void;
   onDelete: (batch: Batch) => void;
   onDryRun: (batchId: number, onlyPending?: boolean) => void;
   onStart: (batchId: number, skipDryRun?: boolean) => void;
   onRetryFailed: () => void;
   onRollback?: (batch: Batch) => void;
+  onApprovalAction?: (batch: Batch, action: 'request' | 'approve' | 'reject') => void;
   dryRunButtonRef?: React.RefObject<HTMLButtonElement | null>;
 }
 
 export function BatchDetailHeader({
   batch,
   batchRepositories,
+  forecast,
+  dependency,
+  approval,
+  approvalRequired = false,
   onEdit,
   onDelete,
   onDryRun,
   onStart,
   onRetryFailed,
   onRollback,
+  onApprovalAction,
   dryRunButtonRef,
 }: BatchDetailHeaderProps) {
+  const completionActions = parseCompletionActions(batch.completion_actions);
+
   // Calculate counts for different states
   const pendingCount = batchRepositories.filter((r) => r.status === 'pending' || r.status === 'discovered').length;
   const dryRunCompleteCount = batchRepositories.filter((r) => r.status === 'dry_run_complete').length;
@@ -44,6 +56,12 @@ export function BatchDetailHeader({
   const hasFailedRepos = failedCount > 0;
   const isInProgress = batch.status === 'in_progress' || inProgressCount > 0;
 
+  // With two-person approval the migration starts only after someone else approves the request
+  const approvalState = approval?.action;
+  const canStart = !approvalRequired || approvalState === 'approved';
+  const canRequestApproval = approvalRequired && !!batch.last_dry_run_at &&
+    approvalState !== 'requested' && approvalState !== 'approved';
+
   // batchRepositories is used for calculating counts above
 
   return (
@@ -72,7 +90,7 @@ export function BatchDetailHeader({
           {/* Two-column layout for settings and timestamps */}
           <div className="mt-4 grid grid-cols-1 lg:grid-cols-2 gap-6 border-t border-gh-border-default pt-4">
             {/* Left Column: Migration Settings */}
-            {(batch.destination_org || batch.exclude_releases || batch.migration_api !== 'GEI') && (
+            {(batch.destination_org || batch.exclude_releases || batch.migration_api !== 'GEI' || completionActions.length > 0) && (
               <div>
                 <div className="flex items-center gap-2 mb-2">
                   <span style={{ color: 'var(--fgColor-muted)' }}>
@@ -92,7 +110,11 @@ export function BatchDetailHeader({
                     <div className="text-sm">
                       <span style={{ color: 'var(--fgColor-muted)' }}>Migration API:</span>
                       <div className="font-medium mt-0.5" style={{ color: 'var(--fgColor-default)' }}>
-                        {batch.migration_api === 'ELM' ? 'ELM (Enterprise Live Migrator)' : batch.migration_api}
+                        {batch.migration_api === 'ELM'
+                          ? 'ELM (Enterprise Live Migrator)'
+                          : batch.migration_api === 'GIT'
+                            ? 'GIT (Git mirror push, history only)'
+                            : batch.migration_api}
                       </div>
                     </div>
                   )}
@@ -103,6 +125,15 @@ export function BatchDetailHeader({
                       <div className="text-xs italic mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>Repo settings can override</div>
                     </div>
                   )}
+                  {completionActions.length > 0 && (
+                    <div className="text-sm">
+                      <span style={{ color: 'var(--fgColor-muted)' }}>After Migration:</span>
+                      <div className="font-medium mt-0.5" style={{ color: 'var(--fgColor-default)' }}>
+                        {COMPLETION_ACTIONS.filter((a) => completionActions.includes(a.value)).map((a) => a.label).join(', ')}
+                      </div>
+                      <div className="text-xs italic mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>Run on the source; reverted by rollback</div>
+                    </div>
+                  )}
                 </div>
               </div>
             )}
@@ -186,8 +217,80 @@ export function BatchDetailHeader({
                   </div>
                 )}
 
+                {/* Forecast for the repositories still to migrate */}
+                {forecast && forecast.repositories > 0 && (
+                  <div className="text-sm">
+                    <div className="flex items-center gap-2">
+                      <span className="text-xs font-medium px-1.5 py-0.5 rounded" style={{ 
+                        backgroundColor: 'var(--bgColor-muted)',
+                        color: 'var(--fgColor-muted)'
+                      }}>
+                        FORECAST
+                      </span>
+                    </div>
+                    <div className="font-medium mt-1" style={{ color: 'var(--fgColor-default)' }}>
+                      Estimated Duration: {formatDurationSeconds(forecast.wall_clock_seconds)}
+                    </div>
+                    <div className="text-xs italic mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>
+                      {forecast.repositories} {forecast.repositories === 1 ? 'repository' : 'repositories'} on {forecast.workers} workers
+                      {forecast.calibration_samples > 0
+                        ? `, calibrated by ${forecast.calibration_samples} past migrations`
+                        : ', uncalibrated until 3 migrations complete'}
+                    </div>
+                  </div>
+                )}
+
+                {/* Batch this one waits for before the scheduler starts it */}
+                {dependency && (
+                  <div className="text-sm">
+                    <div className="flex items-center gap-2">
+                      <span className="text-xs font-medium px-1.5 py-0.5 rounded" style={{ 
+                        backgroundColor: dependency.state === 'blocked' ? 'var(--bgColor-danger-muted)' : 'var(--bgColor-muted)',
+                        color: dependency.state === 'blocked' ? 'var(--fgColor-danger)' : 'var(--fgColor-muted)'
+                      }}>
+                        {dependency.state.toUpperCase()}
+                      </span>
+                    </div>
+                    <div className="font-medium mt-1" style={{ color: 'var(--fgColor-default)' }}>
+                      Starts after: {dependency.batch_name || `Batch ${dependency.batch_id}`}
+                    </div>
+                    <div className="text-xs italic mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>
+                      {dependency.max_failures !== undefined && dependency.max_failures !== null
+                        ? `At most ${dependency.max_failures} failed migrations allowed; ${dependency.reason}`
+                        : dependency.reason}
+                    </div>
+                  </div>
+                )}
+
+                {/* Latest step of the two-person approval of the production migration */}
+                {approvalRequired && approval && (
+                  <div className="text-sm">
+                    <div className="flex items-center gap-2">
+                      <span className="text-xs font-medium px-1.5 py-0.5 rounded" style={{ 
+                        backgroundColor: approval.action === 'rejected' ? 'var(--bgColor-danger-muted)' : approval.action === 'approved' ? 'var(--bgColor-success-muted)' : 'var(--bgColor-muted)',
+                        color: approval.action === 'rejected' ? 'var(--fgColor-danger)' : approval.action === 'approved' ? 'var(--fgColor-success)' : 'var(--fgColor-muted)'
+                      }}>
+                        {approval.action === 'requested' ? 'AWAITING APPROVAL' : approval.action.toUpperCase()}
+                      </span>
+                    </div>
+                    <div className="font-medium mt-1" style={{ color: 'var(--fgColor-default)' }}>
+                      {approval.action === 'requested' ? 'Requested' : approval.action === 'started' ? 'Started' : 'Reviewed'} by {approval.actor}: {formatDate(approval.created_at)}
+                    </div>
+                    {approval.dry_run_summary && (
+                      <div className="text-xs italic mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>
+                        Dry run: {approval.dry_run_summary.succeeded} succeeded, {approval.dry_run_summary.failed} failed, {approval.dry_run_summary.not_run} not run
+                      </div>
+                    )}
+                    {approval.comment && (
+                      <div className="text-xs italic mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>
+                        {approval.comment}
+                      </div>
+                    )}
+                  </div>
+                )}
+
                 {/* Show message if no timeline events yet */}
-                {!batch.scheduled_at && !batch.last_dry_run_at && !batch.started_at && (
+                {!batch.scheduled_at && !batch.last_dry_run_at && !batch.started_at && !(forecast && forecast.repositories > 0) && !dependency && !(approvalRequired && approval) && (
                   <div className="text-sm italic" style={{ color: 'var(--fgColor-muted)' }}>
                     No activity yet
                   </div>
@@ -267,7 +370,7 @@ export function BatchDetailHeader({
           )}
 
           {/* Start Migration Action */}
-          {batch.status === 'ready' && (
+          {batch.status === 'ready' && canStart && (
             <SuccessButton
               size="small"
               leadingVisual={PlayIcon}
@@ -278,7 +381,7 @@ export function BatchDetailHeader({
           )}
 
           {/* Start Migration for Pending Batches (with warning) */}
-          {batch.status === 'pending' && batchRepositories.length > 0 && (
+          {batch.status === 'pending' && batchRepositories.length > 0 && canStart && (
             <ActionMenu>
               <ActionMenu.Anchor>
                 <SuccessButton
@@ -299,6 +402,36 @@ export function BatchDetailHeader({
             </ActionMenu>
           )}
 
+          {/* Two-person approval of the production migration */}
+          {onApprovalAction && canRequestApproval && (batch.status === 'ready' || batch.status === 'pending') && batchRepositories.length > 0 && (
+            <Button
+              size="small"
+              leadingVisual={ShieldCheckIcon}
+              onClick={() => onApprovalAction(batch, 'request')}
+            >
+              Request Approval
+            </Button>
+          )}
+          {onApprovalAction && approvalRequired && approvalState === 'requested' && (
+            <>
+              <SuccessButton
+                size="small"
+                leadingVisual={CheckIcon}
+                onClick={() => onApprovalAction(batch, 'approve')}
+              >
+                Approve
+              </SuccessButton>
+              <Button
+                size="small"
+                variant="danger"
+                leadingVisual={XIcon}
+                onClick={() => onApprovalAction(batch, 'reject')}
+              >
+                Reject
+              </Button>
+            </>
+          )}
+
           {/* Retry Failed */}
           {hasFailedRepos && (
             <Button
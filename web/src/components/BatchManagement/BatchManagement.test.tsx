@@ -8,6 +8,10 @@ import * as useQueriesModule from '../../hooks/useQueries';
 vi.mock('../../hooks/useQueries', () => ({
   useBatches: vi.fn(),
   useBatchRepositories: vi.fn(),
+  useBatchForecast: vi.fn(),
+  useBatchDependency: vi.fn(),
+  useBatchApproval: vi.fn(),
+  useConfig: vi.fn(),
 }));
 
 // Mock child components
@@ -74,6 +78,23 @@ describe('BatchManagement', () => {
       data: { repositories: mockRepositories, total: 2 },
       refetch: vi.fn(),
     });
+
+    (useQueriesModule.useBatchForecast as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: null,
+    });
+
+    (useQueriesModule.useBatchDependency as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: null,
+    });
+
+    (useQueriesModule.useBatchApproval as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: null,
+      refetch: vi.fn(),
+    });
+
+    (useQueriesModule.useConfig as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: { source_type: 'github', auth_enabled: false },
+    });
   });
 
   it('should render the batch management page title', async () => {
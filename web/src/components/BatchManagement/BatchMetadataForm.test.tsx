@@ -23,6 +23,7 @@ describe('BatchMetadataForm', () => {
       migrationAPI: 'GEI' as const,
       excludeReleases: false,
       excludeAttachments: false,
+      completionActions: [],
     },
     onMigrationSettingsChange: mockOnMigrationSettingsChange,
     showMigrationSettings: false,
@@ -112,6 +113,16 @@ describe('BatchMetadataForm', () => {
     expect(mockOnMigrationSettingsChange).toHaveBeenCalledWith({ excludeReleases: true });
   });
 
+  it('should keep completion actions in run order when one is toggled', () => {
+    const settings = { ...defaultProps.migrationSettings, completionActions: ['archive' as const] };
+    render(<BatchMetadataForm {...defaultProps} migrationSettings={settings} showMigrationSettings={true} />);
+
+    expect(screen.getByRole('checkbox', { name: /Archive source/i })).toBeChecked();
+    fireEvent.click(screen.getByRole('checkbox', { name: /README notice/i }));
+
+    expect(mockOnMigrationSettingsChange).toHaveBeenCalledWith({ completionActions: ['readme_notice', 'archive'] });
+  });
+
   it('should render scheduled date input', () => {
     render(<BatchMetadataForm {...defaultProps} />);
 
@@ -202,6 +213,7 @@ describe('BatchMetadataForm', () => {
       migrationAPI: 'GEI' as const,
       excludeReleases: true,
       excludeAttachments: false,
+      completionActions: [],
     };
 
     render(<BatchMetadataForm {...defaultProps} migrationSettings={settings} />);
@@ -1,12 +1,15 @@
 import { ChevronDownIcon, RocketIcon } from '@primer/octicons-react';
 import { formatDateForInput } from '../../utils/format';
 import { SuccessButton, BorderedButton, PrimaryButton } from '../common/buttons';
+import { COMPLETION_ACTIONS } from '../../types';
+import type { CompletionAction } from '../../types';
 
 interface MigrationSettings {
   destinationOrg: string;
-  migrationAPI: 'GEI' | 'ELM';
+  migrationAPI: 'GEI' | 'ELM' | 'GIT';
   excludeReleases: boolean;
   excludeAttachments: boolean;
+  completionActions: CompletionAction[];
 }
 
 interface BatchMetadataFormProps {
@@ -48,15 +51,26 @@ export function BatchMetadataForm({
   onSave,
   onClose,
 }: BatchMetadataFormProps) {
-  const { destinationOrg, migrationAPI, excludeReleases, excludeAttachments } = migrationSettings;
+  const { destinationOrg, migrationAPI, excludeReleases, excludeAttachments, completionActions } = migrationSettings;
 
   const configuredSettingsCount = [
     destinationOrg ? 1 : 0,
     excludeReleases ? 1 : 0,
     excludeAttachments ? 1 : 0,
     migrationAPI !== 'GEI' ? 1 : 0,
+    completionActions.length > 0 ? 1 : 0,
   ].reduce((a, b) => a + b, 0);
 
+  const toggleCompletionAction = (action: CompletionAction, checked: boolean) => {
+    const selected = checked
+      ? [...completionActions, action]
+      : completionActions.filter((a) => a !== action);
+    // Keep the actions in the order they run
+    onMigrationSettingsChange({
+      completionActions: COMPLETION_ACTIONS.map((a) => a.value).filter((a) => selected.includes(a)),
+    });
+  };
+
   return (
     <div 
       className="flex-1 flex flex-col min-h-0"
@@ -198,7 +212,7 @@ export function BatchMetadataForm({
               </label>
               <select
                 value={migrationAPI}
-                onChange={(e) => onMigrationSettingsChange({ migrationAPI: e.target.value as 'GEI' | 'ELM' })}
+                onChange={(e) => onMigrationSettingsChange({ migrationAPI: e.target.value as 'GEI' | 'ELM' | 'GIT' })}
                 className="w-full px-2.5 py-1.5 text-sm rounded-lg focus:ring-2 focus:ring-blue-500 focus:border-transparent"
                 style={{
                   border: '1px solid var(--borderColor-default)',
@@ -208,7 +222,8 @@ export function BatchMetadataForm({
                 disabled={loading}
               >
                 <option value="GEI">GEI (GitHub Enterprise Importer)</option>
-                <option value="ELM">ELM (Enterprise Live Migrator) - Future</option>
+                <option value="ELM">ELM (Enterprise Live Migrator)</option>
+                <option value="GIT">GIT (Git mirror push, history only)</option>
               </select>
             </div>
 
@@ -243,6 +258,32 @@ export function BatchMetadataForm({
                 <span className="block mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>Skip file attachments (images, files attached to Issues/PRs) to reduce archive size (repo settings override)</span>
               </label>
             </div>
+
+            <div className="pt-1">
+              <span className="block text-xs font-semibold mb-1" style={{ color: 'var(--fgColor-default)' }}>
+                After Migration
+                <span className="ml-1 font-normal text-xs" style={{ color: 'var(--fgColor-muted)' }}>— Run on the source after a successful migration; reverted by rollback</span>
+              </span>
+              <div className="space-y-1.5">
+                {COMPLETION_ACTIONS.map((action) => (
+                  <div key={action.value} className="flex items-start gap-2">
+                    <input
+                      type="checkbox"
+                      id={`completion-action-${action.value}`}
+                      checked={completionActions.includes(action.value)}
+                      onChange={(e) => toggleCompletionAction(action.value, e.target.checked)}
+                      className="mt-0.5 h-4 w-4 rounded text-blue-600 focus:ring-2 focus:ring-blue-500"
+                      style={{ borderColor: 'var(--borderColor-default)' }}
+                      disabled={loading}
+                    />
+                    <label htmlFor={`completion-action-${action.value}`} className="text-xs cursor-pointer" style={{ color: 'var(--fgColor-default)' }}>
+                      <span className="font-semibold">{action.label}</span>
+                      <span className="block mt-0.5" style={{ color: 'var(--fgColor-muted)' }}>{action.description}</span>
+                    </label>
+                  </div>
+                ))}
+              </div>
+            </div>
           </div>
         )}
         </div>
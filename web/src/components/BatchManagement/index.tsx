@@ -1,14 +1,15 @@
 import { useEffect, useState, useMemo, useCallback, useRef } from 'react';
 import { useNavigate, useLocation, useSearchParams } from 'react-router-dom';
-import { Button, ProgressBar } from '@primer/react';
+import { Button, ProgressBar, FormControl, Textarea } from '@primer/react';
 import { PlusIcon } from '@primer/octicons-react';
 import { api } from '../../services/api';
 import type { Batch, Repository } from '../../types';
 import { RefreshIndicator } from '../common/RefreshIndicator';
 import { Pagination } from '../common/Pagination';
 import { ConfirmationDialog } from '../common/ConfirmationDialog';
+import { FormDialog } from '../common/FormDialog';
 import { useToast } from '../../contexts/ToastContext';
-import { useBatches, useBatchRepositories } from '../../hooks/useQueries';
+import { useBatches, useBatchApproval, useBatchDependency, useBatchForecast, useBatchRepositories, useConfig } from '../../hooks/useQueries';
 import { useBatchUpdateRepositoryStatus } from '../../hooks/useMutations';
 import { useDialogState } from '../../hooks/useDialogState';
 import { BatchListPanel } from './BatchListPanel';
@@ -31,6 +32,17 @@ interface RetryDialogData {
   message: string;
 }
 
+interface ApprovalDialogData {
+  batch: Batch;
+  action: 'request' | 'approve' | 'reject';
+}
+
+const APPROVAL_DIALOG_TEXT = {
+  request: { title: 'Request Approval', submitLabel: 'Request Approval', success: 'Approval requested' },
+  approve: { title: 'Approve Migration', submitLabel: 'Approve', success: 'Batch migration approved' },
+  reject: { title: 'Reject Migration', submitLabel: 'Reject', success: 'Batch migration rejected' },
+};
+
 type BatchTab = 'active' | 'completed';
 
 export function BatchManagement() {
@@ -59,6 +71,8 @@ export function BatchManagement() {
   const startDialog = useDialogState<StartDialogData>();
   const retryDialog = useDialogState<RetryDialogData>();
   const rollbackDialog = useDialogState<Batch>();
+  const approvalDialog = useDialogState<ApprovalDialogData>();
+  const [approvalComment, setApprovalComment] = useState('');
   
   // Mutations
   const batchUpdateStatus = useBatchUpdateRepositoryStatus();
@@ -102,6 +116,21 @@ export function BatchManagement() {
   
   const batchRepositories: Repository[] = batchRepoData?.repositories || [];
 
+  const { data: batchForecast } = useBatchForecast(selectedBatchId, {
+    refetchInterval: batchRepoPollingInterval
+  });
+
+  const { data: batchDependency } = useBatchDependency(selectedBatchId, {
+    refetchInterval: batchRepoPollingInterval
+  });
+
+  const { data: config } = useConfig();
+  const approvalRequired = config?.batch_approval_required ?? false;
+  const { data: batchApproval, refetch: refetchBatchApproval } = useBatchApproval(
+    approvalRequired ? selectedBatchId : null,
+    { refetchInterval: batchRepoPollingInterval }
+  );
+
   // Handle immediate refresh when navigating back from create/edit
   useEffect(() => {
     if (locationState?.refreshData) {
@@ -185,6 +214,35 @@ export function BatchManagement() {
     }
   };
 
+  const handleApprovalAction = (batch: Batch, action: ApprovalDialogData['action']) => {
+    setApprovalComment('');
+    approvalDialog.open({ batch, action });
+  };
+
+  const confirmApprovalAction = async () => {
+    const data = approvalDialog.data;
+    if (!data) return;
+
+    const comment = approvalComment.trim() || undefined;
+    try {
+      if (data.action === 'request') {
+        await api.requestBatchApproval(data.batch.id, comment);
+      } else if (data.action === 'approve') {
+        await api.approveBatch(data.batch.id, comment);
+      } else {
+        await api.rejectBatch(data.batch.id, comment || '');
+      }
+      showSuccess(APPROVAL_DIALOG_TEXT[data.action].success);
+      await refetchBatchApproval();
+      await refreshData();
+      approvalDialog.close();
+    } catch (error: unknown) {
+      const err = error as { response?: { data?: { error?: string } } };
+      showError(err.response?.data?.error || 'Failed to update batch approval');
+      approvalDialog.close();
+    }
+  };
+
   const handleRetryFailed = () => {
     if (!selectedBatch) return;
 
@@ -433,12 +491,17 @@ export function BatchManagement() {
               <BatchDetailHeader
                 batch={selectedBatch}
                 batchRepositories={batchRepositories}
+                forecast={batchForecast}
+                dependency={batchDependency}
+                approval={batchApproval}
+                approvalRequired={approvalRequired}
                 onEdit={handleEditBatch}
                 onDelete={handleDeleteBatch}
                 onDryRun={handleDryRunBatch}
                 onStart={handleStartBatch}
                 onRetryFailed={handleRetryFailed}
                 onRollback={handleRollbackBatch}
+                onApprovalAction={handleApprovalAction}
                 dryRunButtonRef={dryRunButtonRef}
               />
 
@@ -725,6 +788,34 @@ export function BatchManagement() {
         onCancel={rollbackDialog.close}
       />
 
+      {/* Two-Person Approval Dialog */}
+      {approvalDialog.data && (
+        <FormDialog
+          isOpen={approvalDialog.isOpen}
+          title={APPROVAL_DIALOG_TEXT[approvalDialog.data.action].title}
+          submitLabel={APPROVAL_DIALOG_TEXT[approvalDialog.data.action].submitLabel}
+          variant={approvalDialog.data.action === 'reject' ? 'danger' : 'primary'}
+          isSubmitDisabled={approvalDialog.data.action === 'reject' && !approvalComment.trim()}
+          onSubmit={confirmApprovalAction}
+          onCancel={approvalDialog.close}
+        >
+          <p className="text-sm mb-3" style={{ color: 'var(--fgColor-muted)' }}>
+            {approvalDialog.data.action === 'request'
+              ? <>Submit <strong>"{approvalDialog.data.batch.name}"</strong> with a summary of its dry run. Someone else must approve it before its migration can start.</>
+              : <>Review the migration of <strong>"{approvalDialog.data.batch.name}"</strong>. The requester cannot review their own request.</>}
+          </p>
+          <FormControl required={approvalDialog.data.action === 'reject'}>
+            <FormControl.Label>{approvalDialog.data.action === 'reject' ? 'Reason' : 'Comment'}</FormControl.Label>
+            <Textarea
+              value={approvalComment}
+              onChange={(e) => setApprovalComment(e.target.value)}
+              placeholder="e.g. change ticket or review notes"
+              block
+            />
+          </FormControl>
+        </FormDialog>
+      )}
+
     </div>
   );
 }
@@ -164,6 +164,26 @@ describe('ActionItemsPanel', () => {
     expect(screen.queryByText('Failed Dry Runs')).not.toBeInTheDocument();
     expect(screen.queryByText('Batches Ready to Start')).not.toBeInTheDocument();
     expect(screen.queryByText('Blocked Repositories')).not.toBeInTheDocument();
+    expect(screen.queryByText('Webhooks to Activate')).not.toBeInTheDocument();
+  });
+
+  it('renders repositories with webhooks waiting for activation', async () => {
+    const user = userEvent.setup();
+    const webhookActionItems = {
+      ...emptyActionItems,
+      pending_webhooks: [
+        { repository_id: 7, full_name: 'org/hooked-repo', destination_repository: 'dest-org/hooked-repo', pending_webhooks: 3 },
+      ],
+    };
+
+    render(<ActionItemsPanel actionItems={webhookActionItems} isLoading={false} />);
+
+    const section = screen.getByText('Webhooks to Activate').closest('button');
+    expect(section).toBeInTheDocument();
+    await user.click(section!);
+
+    expect(screen.getByText('org/hooked-repo')).toBeInTheDocument();
+    expect(screen.getByText('3 inactive webhooks on dest-org/hooked-repo')).toBeInTheDocument();
   });
 });
 
@@ -1,6 +1,6 @@
 import { useState } from 'react';
 import { Button, Label } from '@primer/react';
-import { AlertIcon, XCircleIcon, ClockIcon, ChevronDownIcon, ChevronRightIcon } from '@primer/octicons-react';
+import { AlertIcon, XCircleIcon, ClockIcon, ChevronDownIcon, ChevronRightIcon, WebhookIcon } from '@primer/octicons-react';
 import { DashboardActionItems } from '../../types';
 import { Link } from 'react-router-dom';
 import { formatDate } from '../../utils/format';
@@ -36,8 +36,11 @@ export function ActionItemsPanel({ actionItems, isLoading }: ActionItemsPanelPro
   const failedDryRunsCount = actionItems.failed_dry_runs.length;
   const readyBatchesCount = actionItems.ready_batches.length;
   const blockedReposCount = actionItems.blocked_repositories.length;
+  const pendingWebhooks = actionItems.pending_webhooks ?? [];
+  const pendingWebhooksCount = pendingWebhooks.length;
 
-  const totalActionItems = failedMigrationsCount + failedDryRunsCount + readyBatchesCount + blockedReposCount;
+  const totalActionItems =
+    failedMigrationsCount + failedDryRunsCount + readyBatchesCount + blockedReposCount + pendingWebhooksCount;
 
   // Hide the panel completely when there are no action items
   if (totalActionItems === 0) {
@@ -265,6 +268,47 @@ export function ActionItemsPanel({ actionItems, isLoading }: ActionItemsPanelPro
               </div>
             </CollapsibleActionSection>
           )}
+
+          {pendingWebhooksCount > 0 && (
+            <CollapsibleActionSection
+              title="Webhooks to Activate"
+              count={pendingWebhooksCount}
+              icon={<span style={{ color: 'var(--fgColor-attention)' }}><WebhookIcon size={16} /></span>}
+              variant="attention"
+              defaultExpanded={false}
+            >
+              <div className="space-y-2">
+                {pendingWebhooks.map((repo) => (
+                  <div
+                    key={repo.repository_id}
+                    className="flex items-center justify-between p-3 rounded border"
+                    style={{
+                      backgroundColor: 'var(--bgColor-muted)',
+                      borderColor: 'var(--borderColor-default)',
+                    }}
+                  >
+                    <div className="flex-1 min-w-0">
+                      <Link
+                        to={`/repository/${encodeURIComponent(repo.full_name)}`}
+                        className="font-medium hover:underline"
+                        style={{ color: 'var(--fgColor-accent)' }}
+                      >
+                        {repo.full_name}
+                      </Link>
+                      <div className="text-xs mt-1" style={{ color: 'var(--fgColor-muted)' }}>
+                        {repo.pending_webhooks} inactive {repo.pending_webhooks === 1 ? 'webhook' : 'webhooks'} on {repo.destination_repository}
+                      </div>
+                    </div>
+                    <Link to={`/repository/${encodeURIComponent(repo.full_name)}`}>
+                      <Button variant="default" size="small">
+                        View Details
+                      </Button>
+                    </Link>
+                  </div>
+                ))}
+              </div>
+            </CollapsibleActionSection>
+          )}
         </div>
       </div>
     </div>
@@ -0,0 +1,72 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { render, screen, fireEvent, waitFor } from '../../__tests__/test-utils';
+import { CodeownersSection } from './CodeownersSection';
+import { api } from '../../services/api';
+import type { CodeownersRewrite } from '../../types';
+
+vi.mock('../../services/api', () => ({
+  api: {
+    getCodeowners: vi.fn(),
+    rewriteCodeowners: vi.fn(),
+  },
+}));
+
+describe('CodeownersSection', () => {
+  const pullRequestRewrite: CodeownersRewrite = {
+    id: 1,
+    repository_id: 1,
+    mode: 'pull_request',
+    status: 'pull_request',
+    path: '.github/CODEOWNERS',
+    handles_rewritten: 4,
+    unmapped: '@carol,@org/frontend',
+    pull_request_number: 9,
+    pull_request_url: 'https://github.com/dest-org/repo/pull/9',
+    created_at: '2024-01-15T10:00:00Z',
+  };
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('shows the pull request and the handles without a mapping', async () => {
+    (api.getCodeowners as ReturnType<typeof vi.fn>).mockResolvedValue({ codeowners: pullRequestRewrite });
+
+    render(<CodeownersSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('CODEOWNERS'));
+
+    expect(await screen.findByText('Pull request #9')).toHaveAttribute('href', pullRequestRewrite.pull_request_url);
+    expect(screen.getByText(/rewrites 4 handles in/)).toBeInTheDocument();
+    expect(screen.getByText('@carol')).toBeInTheDocument();
+    expect(screen.getByText('@org/frontend')).toBeInTheDocument();
+  });
+
+  it('commits the rewritten file on request', async () => {
+    (api.getCodeowners as ReturnType<typeof vi.fn>).mockResolvedValue({ codeowners: null });
+    (api.rewriteCodeowners as ReturnType<typeof vi.fn>).mockResolvedValue({
+      codeowners: { ...pullRequestRewrite, mode: 'commit', status: 'committed', unmapped: undefined },
+    });
+
+    render(<CodeownersSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('CODEOWNERS'));
+    await waitFor(() => expect(api.getCodeowners).toHaveBeenCalledWith('org/repo'));
+    fireEvent.click(screen.getByText('Commit'));
+
+    await waitFor(() => expect(api.rewriteCodeowners).toHaveBeenCalledWith('org/repo', 'commit'));
+    expect(await screen.findByText(/on the default branch/)).toBeInTheDocument();
+  });
+
+  it('shows why the last rewrite failed', async () => {
+    (api.getCodeowners as ReturnType<typeof vi.fn>).mockResolvedValue({
+      codeowners: { ...pullRequestRewrite, status: 'failed', unmapped: undefined, error: 'Branch is protected' },
+    });
+
+    render(<CodeownersSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('CODEOWNERS'));
+
+    expect(await screen.findByText('Branch is protected')).toBeInTheDocument();
+  });
+});
@@ -0,0 +1,130 @@
+import { useCallback, useEffect, useState } from 'react';
+import { Link } from '@primer/react';
+import { GitCommitIcon, GitPullRequestIcon } from '@primer/octicons-react';
+import { Button } from '../common/buttons';
+import { api } from '../../services/api';
+import type { CodeownersRewrite, CodeownersRewriteMode } from '../../types';
+import { useToast } from '../../contexts/ToastContext';
+import { handleApiError } from '../../utils/errorHandler';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface CodeownersSectionProps {
+  fullName: string;
+}
+
+// Shows the last CODEOWNERS rewrite with the team and user mappings, and rewrites it (again)
+// on request either by committing to the default branch or by opening a pull request.
+export function CodeownersSection({ fullName }: CodeownersSectionProps) {
+  const { showSuccess, showError } = useToast();
+  const [rewrite, setRewrite] = useState<CodeownersRewrite | null>(null);
+  const [expanded, setExpanded] = useState(false);
+  const [rewriting, setRewriting] = useState<CodeownersRewriteMode | null>(null);
+
+  const loadRewrite = useCallback(async () => {
+    try {
+      const response = await api.getCodeowners(fullName);
+      setRewrite(response.codeowners);
+    } catch {
+      // Informational only; the section offers to rewrite CODEOWNERS either way
+    }
+  }, [fullName]);
+
+  useEffect(() => {
+    loadRewrite();
+  }, [loadRewrite]);
+
+  const handleRewrite = async (mode: CodeownersRewriteMode) => {
+    try {
+      setRewriting(mode);
+      const response = await api.rewriteCodeowners(fullName, mode);
+      const result = response.codeowners;
+      if (result?.status === 'committed') {
+        showSuccess(`Rewrote ${result.handles_rewritten} handles in ${result.path}`);
+      } else if (result?.status === 'pull_request') {
+        showSuccess(`Opened pull request #${result.pull_request_number}`);
+      } else if (result?.status === 'not_found') {
+        showSuccess('The repository has no CODEOWNERS file');
+      } else {
+        showSuccess('No CODEOWNERS handles needed rewriting');
+      }
+      setRewrite(result);
+    } catch (error) {
+      handleApiError(error, showError, 'Failed to rewrite CODEOWNERS');
+      await loadRewrite();
+    } finally {
+      setRewriting(null);
+    }
+  };
+
+  const unmapped = rewrite?.unmapped ? rewrite.unmapped.split(',') : [];
+  const status = rewrite?.status === 'failed' || unmapped.length > 0 ? 'warning' : 'passed';
+
+  return (
+    <CollapsibleValidationSection
+      id="codeowners"
+      title="CODEOWNERS"
+      status={status}
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-3 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <div className="flex items-center justify-between gap-4">
+          <p style={{ color: 'var(--fgColor-muted)' }}>
+            Rewrites team and user handles in CODEOWNERS with the team and user mappings so review requests reach the
+            destination owners.
+          </p>
+          <div className="flex gap-2">
+            <Button
+              variant="default"
+              size="small"
+              leadingVisual={GitCommitIcon}
+              onClick={() => handleRewrite('commit')}
+              disabled={rewriting !== null}
+            >
+              {rewriting === 'commit' ? 'Committing...' : 'Commit'}
+            </Button>
+            <Button
+              variant="primary"
+              size="small"
+              leadingVisual={GitPullRequestIcon}
+              onClick={() => handleRewrite('pull_request')}
+              disabled={rewriting !== null}
+            >
+              {rewriting === 'pull_request' ? 'Opening...' : 'Open Pull Request'}
+            </Button>
+          </div>
+        </div>
+
+        {rewrite?.status === 'committed' && (
+          <p>
+            Rewrote {rewrite.handles_rewritten} handles in <code>{rewrite.path}</code> on the default branch.
+          </p>
+        )}
+        {rewrite?.status === 'pull_request' && (
+          <p>
+            <Link href={rewrite.pull_request_url} target="_blank" rel="noopener noreferrer">
+              Pull request #{rewrite.pull_request_number}
+            </Link>{' '}
+            rewrites {rewrite.handles_rewritten} handles in <code>{rewrite.path}</code>.
+          </p>
+        )}
+        {rewrite?.status === 'no_changes' && <p>No CODEOWNERS handles needed rewriting.</p>}
+        {rewrite?.status === 'not_found' && <p>The repository has no CODEOWNERS file.</p>}
+        {rewrite?.status === 'failed' && <p style={{ color: 'var(--fgColor-danger)' }}>{rewrite.error}</p>}
+
+        {unmapped.length > 0 && (
+          <div>
+            <p style={{ color: 'var(--fgColor-muted)' }}>
+              No team or user mapping; left unchanged until they are mapped and CODEOWNERS is rewritten again:
+            </p>
+            <ul className="list-disc pl-5 font-mono text-xs">
+              {unmapped.map((handle) => (
+                <li key={handle}>{handle}</li>
+              ))}
+            </ul>
+          </div>
+        )}
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
@@ -0,0 +1,69 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { render, screen, fireEvent, waitFor } from '../../__tests__/test-utils';
+import { CollaboratorsSection } from './CollaboratorsSection';
+import { api } from '../../services/api';
+import type { CollaboratorGrant } from '../../types';
+
+vi.mock('../../services/api', () => ({
+  api: {
+    getCollaboratorGrants: vi.fn(),
+    grantCollaborators: vi.fn(),
+  },
+}));
+
+describe('CollaboratorsSection', () => {
+  const grants: CollaboratorGrant[] = [
+    {
+      id: 1,
+      repository_id: 1,
+      source_login: 'alice',
+      destination_login: 'alice_acme',
+      permission: 'write',
+      outside: false,
+      status: 'granted',
+      created_at: '2024-01-15T10:00:00Z',
+    },
+    {
+      id: 2,
+      repository_id: 1,
+      source_login: 'vendor',
+      destination_login: 'vendor_acme',
+      permission: 'read',
+      outside: true,
+      status: 'skipped',
+      reason: 'The destination does not allow outside collaborators',
+      created_at: '2024-01-15T10:00:00Z',
+    },
+  ];
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('lists the logged grants with their outcome', async () => {
+    (api.getCollaboratorGrants as ReturnType<typeof vi.fn>).mockResolvedValue({ grants });
+
+    render(<CollaboratorsSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('Direct Collaborators'));
+
+    expect(await screen.findByText('alice_acme')).toBeInTheDocument();
+    expect(screen.getByText('Granted')).toBeInTheDocument();
+    expect(screen.getByText('(outside)')).toBeInTheDocument();
+    expect(screen.getByText('The destination does not allow outside collaborators')).toBeInTheDocument();
+  });
+
+  it('grants members only on request', async () => {
+    (api.getCollaboratorGrants as ReturnType<typeof vi.fn>).mockResolvedValue({ grants: [] });
+    (api.grantCollaborators as ReturnType<typeof vi.fn>).mockResolvedValue({ grants: [grants[0]] });
+
+    render(<CollaboratorsSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('Direct Collaborators'));
+    expect(await screen.findByText('No collaborator permissions have been granted yet.')).toBeInTheDocument();
+    fireEvent.click(screen.getByText('Members Only'));
+
+    await waitFor(() => expect(api.grantCollaborators).toHaveBeenCalledWith('org/repo', 'members_only'));
+    expect(await screen.findByText('alice_acme')).toBeInTheDocument();
+  });
+});
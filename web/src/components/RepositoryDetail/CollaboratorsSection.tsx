@@ -0,0 +1,140 @@
+import { useCallback, useEffect, useState } from 'react';
+import { Label } from '@primer/react';
+import { PeopleIcon } from '@primer/octicons-react';
+import { Button } from '../common/buttons';
+import { api } from '../../services/api';
+import type { CollaboratorGrant, CollaboratorGrantMode, CollaboratorGrantStatus } from '../../types';
+import { useToast } from '../../contexts/ToastContext';
+import { handleApiError } from '../../utils/errorHandler';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface CollaboratorsSectionProps {
+  fullName: string;
+}
+
+const statusLabels: Record<CollaboratorGrantStatus, { text: string; variant: 'attention' | 'success' | 'secondary' | 'danger' }> = {
+  granted: { text: 'Granted', variant: 'success' },
+  invited: { text: 'Invited', variant: 'attention' },
+  unmapped: { text: 'No user mapping', variant: 'attention' },
+  skipped: { text: 'Skipped', variant: 'secondary' },
+  failed: { text: 'Failed', variant: 'danger' },
+};
+
+// Shows the direct collaborator permissions re-granted on the destination through the user
+// mappings, one row per source collaborator, and grants them (again) on request.
+export function CollaboratorsSection({ fullName }: CollaboratorsSectionProps) {
+  const { showSuccess, showError, showWarning } = useToast();
+  const [grants, setGrants] = useState<CollaboratorGrant[]>([]);
+  const [expanded, setExpanded] = useState(false);
+  const [granting, setGranting] = useState<CollaboratorGrantMode | null>(null);
+
+  const loadGrants = useCallback(async () => {
+    try {
+      const response = await api.getCollaboratorGrants(fullName);
+      setGrants(response.grants ?? []);
+    } catch {
+      // Informational only; the section offers to grant collaborators either way
+    }
+  }, [fullName]);
+
+  useEffect(() => {
+    loadGrants();
+  }, [loadGrants]);
+
+  const handleGrant = async (mode: CollaboratorGrantMode) => {
+    try {
+      setGranting(mode);
+      const response = await api.grantCollaborators(fullName, mode);
+      const result = response.grants ?? [];
+      const given = result.filter((g) => g.status === 'granted' || g.status === 'invited').length;
+      if (given < result.length) {
+        showWarning(`Granted ${given} of ${result.length} collaborators; review the rest below`);
+      } else {
+        showSuccess(`Granted ${given} collaborators`);
+      }
+      setGrants(result);
+    } catch (error) {
+      handleApiError(error, showError, 'Failed to grant collaborators');
+      await loadGrants();
+    } finally {
+      setGranting(null);
+    }
+  };
+
+  const needsAttention = grants.some((g) => g.status !== 'granted' && g.status !== 'invited');
+
+  return (
+    <CollapsibleValidationSection
+      id="collaborators"
+      title="Direct Collaborators"
+      status={needsAttention ? 'warning' : 'passed'}
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-3 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <div className="flex items-center justify-between gap-4">
+          <p style={{ color: 'var(--fgColor-muted)' }}>
+            Re-grants the source repository&apos;s direct collaborator permissions to the mapped destination users.
+            Outside collaborators can be left out for destinations that do not allow them, such as EMU enterprises.
+          </p>
+          <div className="flex gap-2">
+            <Button
+              variant="default"
+              size="small"
+              onClick={() => handleGrant('members_only')}
+              disabled={granting !== null}
+            >
+              {granting === 'members_only' ? 'Granting...' : 'Members Only'}
+            </Button>
+            <Button
+              variant="primary"
+              size="small"
+              leadingVisual={PeopleIcon}
+              onClick={() => handleGrant('all')}
+              disabled={granting !== null}
+            >
+              {granting === 'all' ? 'Granting...' : 'Grant All'}
+            </Button>
+          </div>
+        </div>
+
+        {grants.length === 0 ? (
+          <p style={{ color: 'var(--fgColor-muted)' }}>No collaborator permissions have been granted yet.</p>
+        ) : (
+          <table className="min-w-full text-xs">
+            <thead>
+              <tr className="text-left" style={{ color: 'var(--fgColor-muted)' }}>
+                <th className="py-1 pr-4">Source</th>
+                <th className="py-1 pr-4">Destination</th>
+                <th className="py-1 pr-4">Permission</th>
+                <th className="py-1">Status</th>
+              </tr>
+            </thead>
+            <tbody>
+              {grants.map((grant) => (
+                <tr key={grant.source_login} style={{ borderTop: '1px solid var(--borderColor-muted)' }}>
+                  <td className="py-1 pr-4 font-mono">
+                    {grant.source_login}
+                    {grant.outside && <span style={{ color: 'var(--fgColor-muted)' }}> (outside)</span>}
+                  </td>
+                  <td className="py-1 pr-4 font-mono">{grant.destination_login ?? '—'}</td>
+                  <td className="py-1 pr-4">{grant.permission}</td>
+                  <td className="py-1">
+                    <Label variant={statusLabels[grant.status].variant} size="small">
+                      {statusLabels[grant.status].text}
+                    </Label>
+                    {grant.reason && (
+                      <div className="mt-1" style={{ color: 'var(--fgColor-muted)' }}>
+                        {grant.reason}
+                      </div>
+                    )}
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        )}
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
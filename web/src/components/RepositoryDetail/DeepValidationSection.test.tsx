@@ -0,0 +1,61 @@
+import { describe, it, expect } from 'vitest';
+import { render, screen, fireEvent } from '../../__tests__/test-utils';
+import { DeepValidationSection } from './DeepValidationSection';
+import type { DeepValidationReport } from '../../types';
+
+describe('DeepValidationSection', () => {
+  const failedReport: DeepValidationReport = {
+    passed: false,
+    checked_at: '2024-01-15T10:00:00Z',
+    source_refs: 3,
+    dest_refs: 2,
+    refs_matched: 1,
+    ref_diffs: [
+      { ref: 'refs/heads/feature', status: 'sha_mismatch', source_sha: 'aaaaaaaaaaaa', dest_sha: 'bbbbbbbbbbbb' },
+      { ref: 'refs/tags/v1.0.0', status: 'missing_in_destination', source_sha: 'cccccccccccc' },
+    ],
+    counts: [
+      { field: 'issues', source: 10, destination: 10, match: true },
+      { field: 'releases', source: 3, destination: 2, match: false },
+      { field: 'release_assets', source: 0, destination: 0, match: false, skipped: 'releases were excluded from the migration' },
+    ],
+    lfs: { objects: 5, missing: 1, missing_oids: ['deadbeef'] },
+  };
+
+  it('expands a failed report and lists the ref differences', () => {
+    render(<DeepValidationSection report={failedReport} />);
+
+    expect(screen.getByText('Post-Migration Validation Found Differences')).toBeInTheDocument();
+    expect(screen.getByText('refs/heads/feature')).toBeInTheDocument();
+    expect(screen.getByText('SHA mismatch')).toBeInTheDocument();
+    expect(screen.getByText('Missing in destination')).toBeInTheDocument();
+  });
+
+  it('shows count comparisons and missing LFS objects', () => {
+    render(<DeepValidationSection report={failedReport} />);
+
+    expect(screen.getByText('Mismatch')).toBeInTheDocument();
+    expect(screen.getByText(/Skipped: releases were excluded/)).toBeInTheDocument();
+    expect(screen.getByText(/1 of 5 LFS objects are missing/)).toBeInTheDocument();
+    expect(screen.getByText('deadbeef')).toBeInTheDocument();
+  });
+
+  it('collapses a passed report until toggled', () => {
+    const passedReport: DeepValidationReport = {
+      ...failedReport,
+      passed: true,
+      refs_matched: 3,
+      dest_refs: 3,
+      ref_diffs: [],
+      counts: [],
+      lfs: undefined,
+    };
+    render(<DeepValidationSection report={passedReport} />);
+
+    expect(screen.getByText('Post-Migration Validation Passed')).toBeInTheDocument();
+    expect(screen.queryByText(/3 of 3 source refs/)).not.toBeInTheDocument();
+
+    fireEvent.click(screen.getByRole('button'));
+    expect(screen.getByText(/3 of 3 source refs/)).toBeInTheDocument();
+  });
+});
@@ -0,0 +1,143 @@
+import { useState } from 'react';
+import type { DeepValidationReport, RefDiffStatus } from '../../types';
+import { formatDate } from '../../utils/format';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface DeepValidationSectionProps {
+  report: DeepValidationReport;
+}
+
+const refStatusLabels: Record<RefDiffStatus, string> = {
+  missing_in_destination: 'Missing in destination',
+  extra_in_destination: 'Extra in destination',
+  sha_mismatch: 'SHA mismatch',
+};
+
+const countLabels: Record<string, string> = {
+  issues: 'Issues',
+  pull_requests: 'Pull requests',
+  releases: 'Releases',
+  release_assets: 'Release assets',
+};
+
+const shortSHA = (sha?: string) => (sha ? sha.substring(0, 10) : '—');
+
+export function DeepValidationSection({ report }: DeepValidationSectionProps) {
+  const [expanded, setExpanded] = useState(!report.passed);
+
+  const refDiffs = report.ref_diffs ?? [];
+  const counts = report.counts ?? [];
+  const errors = report.errors ?? [];
+  const status = report.passed ? 'passed' : refDiffs.length > 0 || (report.lfs?.missing ?? 0) > 0 ? 'blocking' : 'warning';
+
+  return (
+    <CollapsibleValidationSection
+      id="deep-validation"
+      title={report.passed ? 'Post-Migration Validation Passed' : 'Post-Migration Validation Found Differences'}
+      status={status}
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-4 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <p style={{ color: 'var(--fgColor-muted)' }}>
+          Checked {formatDate(report.checked_at)}. {report.refs_matched} of {report.source_refs} source refs match the
+          destination ({report.dest_refs} refs in the destination).
+        </p>
+
+        {refDiffs.length > 0 && (
+          <div>
+            <h4 className="font-semibold mb-2">Ref Differences ({refDiffs.length})</h4>
+            <div className="overflow-x-auto">
+              <table className="min-w-full text-xs">
+                <thead>
+                  <tr className="text-left" style={{ color: 'var(--fgColor-muted)' }}>
+                    <th className="py-1 pr-4">Ref</th>
+                    <th className="py-1 pr-4">Difference</th>
+                    <th className="py-1 pr-4">Source</th>
+                    <th className="py-1">Destination</th>
+                  </tr>
+                </thead>
+                <tbody>
+                  {refDiffs.map((diff) => (
+                    <tr key={diff.ref} style={{ borderTop: '1px solid var(--borderColor-muted)' }}>
+                      <td className="py-1 pr-4 font-mono break-all">{diff.ref}</td>
+                      <td className="py-1 pr-4">{refStatusLabels[diff.status] ?? diff.status}</td>
+                      <td className="py-1 pr-4 font-mono">{shortSHA(diff.source_sha)}</td>
+                      <td className="py-1 font-mono">{shortSHA(diff.dest_sha)}</td>
+                    </tr>
+                  ))}
+                </tbody>
+              </table>
+            </div>
+          </div>
+        )}
+
+        {counts.length > 0 && (
+          <div>
+            <h4 className="font-semibold mb-2">Artifact Counts</h4>
+            <table className="min-w-full text-xs">
+              <thead>
+                <tr className="text-left" style={{ color: 'var(--fgColor-muted)' }}>
+                  <th className="py-1 pr-4">Artifact</th>
+                  <th className="py-1 pr-4">Source</th>
+                  <th className="py-1 pr-4">Destination</th>
+                  <th className="py-1">Result</th>
+                </tr>
+              </thead>
+              <tbody>
+                {counts.map((count) => (
+                  <tr key={count.field} style={{ borderTop: '1px solid var(--borderColor-muted)' }}>
+                    <td className="py-1 pr-4">{countLabels[count.field] ?? count.field}</td>
+                    <td className="py-1 pr-4">{count.skipped ? '—' : count.source}</td>
+                    <td className="py-1 pr-4">{count.skipped ? '—' : count.destination}</td>
+                    <td
+                      className="py-1"
+                      style={{
+                        color: count.skipped
+                          ? 'var(--fgColor-muted)'
+                          : count.match
+                            ? 'var(--fgColor-success)'
+                            : 'var(--fgColor-attention)',
+                      }}
+                    >
+                      {count.skipped ? `Skipped: ${count.skipped}` : count.match ? 'Match' : 'Mismatch'}
+                    </td>
+                  </tr>
+                ))}
+              </tbody>
+            </table>
+          </div>
+        )}
+
+        {report.lfs && (
+          <div>
+            <h4 className="font-semibold mb-2">Git LFS Objects</h4>
+            <p>
+              {report.lfs.missing === 0
+                ? `All ${report.lfs.objects} LFS objects are present in the destination.`
+                : `${report.lfs.missing} of ${report.lfs.objects} LFS objects are missing from the destination.`}
+            </p>
+            {(report.lfs.missing_oids ?? []).length > 0 && (
+              <ul className="mt-1 font-mono text-xs break-all">
+                {report.lfs.missing_oids!.map((oid) => (
+                  <li key={oid}>{oid}</li>
+                ))}
+              </ul>
+            )}
+          </div>
+        )}
+
+        {errors.length > 0 && (
+          <div>
+            <h4 className="font-semibold mb-2">Checks That Could Not Run</h4>
+            <ul className="list-disc list-inside" style={{ color: 'var(--fgColor-danger)' }}>
+              {errors.map((error) => (
+                <li key={error}>{error}</li>
+              ))}
+            </ul>
+          </div>
+        )}
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
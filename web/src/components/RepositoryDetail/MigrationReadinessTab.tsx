@@ -7,6 +7,13 @@ import { api } from '../../services/api';
 import { Badge } from '../common/Badge';
 import { ConfirmationDialog } from '../common/ConfirmationDialog';
 import { ComplexityInfoModal } from '../common/ComplexityInfoModal';
+import { DeepValidationSection } from './DeepValidationSection';
+import { SettingsSyncSection } from './SettingsSyncSection';
+import { SecretsChecklistSection } from './SecretsChecklistSection';
+import { WebhooksSection } from './WebhooksSection';
+import { ReferencePullRequestSection } from './ReferencePullRequestSection';
+import { CodeownersSection } from './CodeownersSection';
+import { CollaboratorsSection } from './CollaboratorsSection';
 import { useUpdateRepository } from '../../hooks/useMutations';
 import { formatBytes } from '../../utils/format';
 import { useToast } from '../../contexts/ToastContext';
@@ -261,6 +268,18 @@ export function MigrationReadinessTab({
       addIfNonZero(breakdown.ado_service_hook_points, 'Service Hooks', 'text-yellow-600');
       addIfNonZero(breakdown.ado_many_prs_points, 'Many Pull Requests', 'text-yellow-600');
       addIfNonZero(breakdown.ado_branch_policy_points, 'Branch Policies', 'text-yellow-600');
+
+      // GitLab-specific factors
+      addIfNonZero(breakdown.gitlab_ci_points, 'GitLab CI Pipelines', 'text-red-600');
+      addIfNonZero(breakdown.gitlab_wiki_points, 'Wiki Pages', 'text-orange-600');
+      addIfNonZero(breakdown.gitlab_many_mrs_points, 'Many Merge Requests', 'text-yellow-600');
+      addIfNonZero(breakdown.gitlab_merge_rules_points, 'Merge Request Rules', 'text-yellow-600');
+
+      // Bitbucket-specific factors
+      addIfNonZero(breakdown.bitbucket_pipelines_points, 'Bitbucket Pipelines', 'text-red-600');
+      addIfNonZero(breakdown.bitbucket_many_prs_points, 'Many Pull Requests', 'text-yellow-600');
+      addIfNonZero(breakdown.bitbucket_branch_permissions_points, 'Branch Permissions', 'text-yellow-600');
+      addIfNonZero(breakdown.bitbucket_webhooks_points, 'Webhooks', 'text-yellow-600');
     }
 
     // Sort by points descending
@@ -282,6 +301,29 @@ export function MigrationReadinessTab({
 
   return (
     <div className="space-y-6">
+      {/* Deep post-migration validation results */}
+      {repository.deep_validation_report && (
+        <DeepValidationSection report={repository.deep_validation_report} />
+      )}
+
+      {/* Repository settings synced from the source */}
+      {repository.settings_sync_report && <SettingsSyncSection report={repository.settings_sync_report} />}
+
+      {/* Actions secrets recreated as placeholders on the destination */}
+      {repository.status === 'complete' && <SecretsChecklistSection fullName={repository.full_name} />}
+
+      {/* Webhooks recreated inactive on the destination */}
+      {repository.status === 'complete' && <WebhooksSection fullName={repository.full_name} />}
+
+      {/* Pull request rewriting references to other migrated repositories */}
+      {repository.status === 'complete' && <ReferencePullRequestSection fullName={repository.full_name} />}
+
+      {/* CODEOWNERS rewritten with the team and user mappings */}
+      {repository.status === 'complete' && <CodeownersSection fullName={repository.full_name} />}
+
+      {/* Direct collaborator permissions re-granted through the user mappings */}
+      {repository.status === 'complete' && <CollaboratorsSection fullName={repository.full_name} />}
+
       {/* Complexity Score Summary */}
       <div className="rounded-lg shadow-sm p-6" style={{ backgroundColor: 'var(--bgColor-default)', border: '1px solid var(--borderColor-default)' }}>
         <div className="space-y-4">
@@ -0,0 +1,72 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { render, screen, fireEvent, waitFor } from '../../__tests__/test-utils';
+import { ReferencePullRequestSection } from './ReferencePullRequestSection';
+import { api } from '../../services/api';
+import type { ReferencePullRequest } from '../../types';
+
+vi.mock('../../services/api', () => ({
+  api: {
+    getReferencePullRequest: vi.fn(),
+    openReferencePullRequest: vi.fn(),
+  },
+}));
+
+describe('ReferencePullRequestSection', () => {
+  const openPullRequest: ReferencePullRequest = {
+    id: 1,
+    repository_id: 1,
+    status: 'open',
+    number: 12,
+    url: 'https://github.com/dest-org/repo/pull/12',
+    branch: 'migration/rewrite-references',
+    files_changed: 2,
+    references_rewritten: 3,
+    unresolved: 'org/lib,org/tools',
+    created_at: '2024-01-15T10:00:00Z',
+  };
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('shows the opened pull request and the dependencies not migrated yet', async () => {
+    (api.getReferencePullRequest as ReturnType<typeof vi.fn>).mockResolvedValue({ reference_pr: openPullRequest });
+
+    render(<ReferencePullRequestSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('Cross-Repository References'));
+
+    expect(await screen.findByText('Pull request #12')).toHaveAttribute('href', openPullRequest.url);
+    expect(screen.getByText(/rewrites 3 references in 2 files/)).toBeInTheDocument();
+    expect(screen.getByText('org/lib')).toBeInTheDocument();
+    expect(screen.getByText('org/tools')).toBeInTheDocument();
+  });
+
+  it('opens the pull request on request', async () => {
+    (api.getReferencePullRequest as ReturnType<typeof vi.fn>).mockResolvedValue({ reference_pr: null });
+    (api.openReferencePullRequest as ReturnType<typeof vi.fn>).mockResolvedValue({
+      reference_pr: { ...openPullRequest, unresolved: undefined },
+    });
+
+    render(<ReferencePullRequestSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('Cross-Repository References'));
+    await waitFor(() => expect(api.getReferencePullRequest).toHaveBeenCalledWith('org/repo'));
+    fireEvent.click(screen.getByText('Open Pull Request'));
+
+    await waitFor(() => expect(api.openReferencePullRequest).toHaveBeenCalledWith('org/repo'));
+    expect(await screen.findByText('Pull request #12')).toBeInTheDocument();
+  });
+
+  it('shows why the last attempt failed', async () => {
+    (api.getReferencePullRequest as ReturnType<typeof vi.fn>).mockResolvedValue({
+      reference_pr: { ...openPullRequest, status: 'failed', number: undefined, url: undefined, unresolved: undefined, error: 'Reference already exists' },
+    });
+
+    render(<ReferencePullRequestSection fullName="org/repo" />);
+
+    fireEvent.click(screen.getByText('Cross-Repository References'));
+
+    expect(await screen.findByText('Reference already exists')).toBeInTheDocument();
+  });
+});
@@ -0,0 +1,105 @@
+import { useCallback, useEffect, useState } from 'react';
+import { Link } from '@primer/react';
+import { GitPullRequestIcon } from '@primer/octicons-react';
+import { Button } from '../common/buttons';
+import { api } from '../../services/api';
+import type { ReferencePullRequest } from '../../types';
+import { useToast } from '../../contexts/ToastContext';
+import { handleApiError } from '../../utils/errorHandler';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface ReferencePullRequestSectionProps {
+  fullName: string;
+}
+
+// Shows the pull request that rewrites submodule, workflow and manifest references to other
+// migrated repositories, and opens it (again) on request.
+export function ReferencePullRequestSection({ fullName }: ReferencePullRequestSectionProps) {
+  const { showSuccess, showError } = useToast();
+  const [pullRequest, setPullRequest] = useState<ReferencePullRequest | null>(null);
+  const [expanded, setExpanded] = useState(false);
+  const [opening, setOpening] = useState(false);
+
+  const loadPullRequest = useCallback(async () => {
+    try {
+      const response = await api.getReferencePullRequest(fullName);
+      setPullRequest(response.reference_pr);
+    } catch {
+      // Informational only; the section offers to open the pull request either way
+    }
+  }, [fullName]);
+
+  useEffect(() => {
+    loadPullRequest();
+  }, [loadPullRequest]);
+
+  const handleOpen = async () => {
+    try {
+      setOpening(true);
+      const response = await api.openReferencePullRequest(fullName);
+      const opened = response.reference_pr;
+      if (opened?.status === 'open') {
+        showSuccess(`Opened pull request #${opened.number}`);
+      } else {
+        showSuccess('No references to migrated repositories needed rewriting');
+      }
+      setPullRequest(opened);
+    } catch (error) {
+      handleApiError(error, showError, 'Failed to open reference pull request');
+      await loadPullRequest();
+    } finally {
+      setOpening(false);
+    }
+  };
+
+  const unresolved = pullRequest?.unresolved ? pullRequest.unresolved.split(',') : [];
+  const status = pullRequest?.status === 'failed' || unresolved.length > 0 ? 'warning' : 'passed';
+
+  return (
+    <CollapsibleValidationSection
+      id="reference-pr"
+      title="Cross-Repository References"
+      status={status}
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-3 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <div className="flex items-center justify-between gap-4">
+          <p style={{ color: 'var(--fgColor-muted)' }}>
+            Opens a pull request on the destination that points submodules, workflow <code>uses:</code> references and
+            package manifests at the new location of other migrated repositories.
+          </p>
+          <Button variant="primary" size="small" leadingVisual={GitPullRequestIcon} onClick={handleOpen} disabled={opening}>
+            {opening ? 'Opening...' : 'Open Pull Request'}
+          </Button>
+        </div>
+
+        {pullRequest?.status === 'open' && (
+          <p>
+            <Link href={pullRequest.url} target="_blank" rel="noopener noreferrer">
+              Pull request #{pullRequest.number}
+            </Link>{' '}
+            rewrites {pullRequest.references_rewritten} references in {pullRequest.files_changed} files.
+          </p>
+        )}
+        {pullRequest?.status === 'no_changes' && <p>No references to migrated repositories needed rewriting.</p>}
+        {pullRequest?.status === 'failed' && (
+          <p style={{ color: 'var(--fgColor-danger)' }}>{pullRequest.error}</p>
+        )}
+
+        {unresolved.length > 0 && (
+          <div>
+            <p style={{ color: 'var(--fgColor-muted)' }}>
+              Not migrated yet; open the pull request again once they are:
+            </p>
+            <ul className="list-disc pl-5 font-mono text-xs">
+              {unresolved.map((name) => (
+                <li key={name}>{name}</li>
+              ))}
+            </ul>
+          </div>
+        )}
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
@@ -0,0 +1,60 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { render, screen, fireEvent, waitFor } from '../../__tests__/test-utils';
+import { SecretsChecklistSection } from './SecretsChecklistSection';
+import { api } from '../../services/api';
+import type { RepositorySecretsResponse } from '../../types';
+
+vi.mock('../../services/api', () => ({
+  api: {
+    getRepositorySecrets: vi.fn(),
+    exportRepositorySecrets: vi.fn(),
+  },
+}));
+
+describe('SecretsChecklistSection', () => {
+  const response: RepositorySecretsResponse = {
+    secrets: [
+      { id: 1, repository_id: 1, environment: '', name: 'NPM_TOKEN', placeholder_created: true, recorded_at: '2024-01-15T10:00:00Z' },
+      { id: 2, repository_id: 1, environment: 'production', name: 'DEPLOY_KEY', placeholder_created: false, recorded_at: '2024-01-15T10:00:00Z' },
+    ],
+    total: 2,
+    placeholders: 1,
+  };
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('lists the secrets to re-enter', async () => {
+    (api.getRepositorySecrets as ReturnType<typeof vi.fn>).mockResolvedValue(response);
+
+    render(<SecretsChecklistSection fullName="org/repo" />);
+
+    expect(await screen.findByText('Secrets to Re-enter (2)')).toBeInTheDocument();
+    expect(screen.getByText('NPM_TOKEN')).toBeInTheDocument();
+    expect(screen.getByText('Environment: production')).toBeInTheDocument();
+    expect(screen.getByText('Placeholder created')).toBeInTheDocument();
+  });
+
+  it('renders nothing when the repository has no secrets', async () => {
+    (api.getRepositorySecrets as ReturnType<typeof vi.fn>).mockResolvedValue({ secrets: [], total: 0, placeholders: 0 });
+
+    render(<SecretsChecklistSection fullName="org/repo" />);
+
+    await waitFor(() => expect(api.getRepositorySecrets).toHaveBeenCalledWith('org/repo'));
+    expect(screen.queryByText(/Secrets to Re-enter/)).not.toBeInTheDocument();
+  });
+
+  it('exports the checklist as CSV', async () => {
+    (api.getRepositorySecrets as ReturnType<typeof vi.fn>).mockResolvedValue(response);
+    (api.exportRepositorySecrets as ReturnType<typeof vi.fn>).mockResolvedValue(new Blob(['csv']));
+    window.URL.createObjectURL = vi.fn(() => 'blob:secrets');
+    window.URL.revokeObjectURL = vi.fn();
+
+    render(<SecretsChecklistSection fullName="org/repo" />);
+
+    fireEvent.click(await screen.findByText('Export CSV'));
+
+    await waitFor(() => expect(api.exportRepositorySecrets).toHaveBeenCalledWith('org/repo', 'csv'));
+  });
+});
@@ -0,0 +1,98 @@
+import { useEffect, useState } from 'react';
+import { DownloadIcon } from '@primer/octicons-react';
+import { Button } from '../common/buttons';
+import { api } from '../../services/api';
+import type { RepositorySecret } from '../../types';
+import { useToast } from '../../contexts/ToastContext';
+import { handleApiError } from '../../utils/errorHandler';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface SecretsChecklistSectionProps {
+  fullName: string;
+}
+
+// Lists the Actions secrets recreated as placeholders on the destination, whose real
+// values have to be re-entered by hand after the migration.
+export function SecretsChecklistSection({ fullName }: SecretsChecklistSectionProps) {
+  const { showError } = useToast();
+  const [secrets, setSecrets] = useState<RepositorySecret[]>([]);
+  const [expanded, setExpanded] = useState(true);
+  const [exporting, setExporting] = useState(false);
+
+  useEffect(() => {
+    let cancelled = false;
+    api
+      .getRepositorySecrets(fullName)
+      .then((response) => {
+        if (!cancelled) setSecrets(response.secrets ?? []);
+      })
+      .catch(() => {
+        // The checklist is informational; the section stays hidden if it cannot be loaded
+      });
+    return () => {
+      cancelled = true;
+    };
+  }, [fullName]);
+
+  const handleExport = async () => {
+    try {
+      setExporting(true);
+      const blob = await api.exportRepositorySecrets(fullName, 'csv');
+      const url = window.URL.createObjectURL(blob);
+      const link = document.createElement('a');
+      link.href = url;
+      link.download = `${fullName.replace('/', '-')}-secrets.csv`;
+      document.body.appendChild(link);
+      link.click();
+      document.body.removeChild(link);
+      window.URL.revokeObjectURL(url);
+    } catch (error) {
+      handleApiError(error, showError, 'Failed to export secrets checklist');
+    } finally {
+      setExporting(false);
+    }
+  };
+
+  if (secrets.length === 0) {
+    return null;
+  }
+
+  return (
+    <CollapsibleValidationSection
+      id="secrets-checklist"
+      title={`Secrets to Re-enter (${secrets.length})`}
+      status="warning"
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-4 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <div className="flex items-center justify-between gap-4">
+          <p style={{ color: 'var(--fgColor-muted)' }}>
+            Secret values cannot be migrated. Enter the real value of each secret on the destination repository.
+          </p>
+          <Button variant="default" size="small" leadingVisual={DownloadIcon} onClick={handleExport} disabled={exporting}>
+            {exporting ? 'Exporting...' : 'Export CSV'}
+          </Button>
+        </div>
+        <table className="min-w-full text-xs">
+          <thead>
+            <tr className="text-left" style={{ color: 'var(--fgColor-muted)' }}>
+              <th className="py-1 pr-4">Secret</th>
+              <th className="py-1 pr-4">Scope</th>
+              <th className="py-1">Destination</th>
+            </tr>
+          </thead>
+          <tbody>
+            {secrets.map((secret) => (
+              <tr key={`${secret.environment}/${secret.name}`} style={{ borderTop: '1px solid var(--borderColor-muted)' }}>
+                <td className="py-1 pr-4 font-mono">{secret.name}</td>
+                <td className="py-1 pr-4">{secret.environment ? `Environment: ${secret.environment}` : 'Repository'}</td>
+                <td className="py-1">{secret.placeholder_created ? 'Placeholder created' : 'Already present or not created'}</td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
@@ -0,0 +1,39 @@
+import { describe, it, expect } from 'vitest';
+import { render, screen, fireEvent } from '../../__tests__/test-utils';
+import { SettingsSyncSection } from './SettingsSyncSection';
+import type { SettingsSyncReport } from '../../types';
+
+describe('SettingsSyncSection', () => {
+  const report: SettingsSyncReport = {
+    synced_at: '2024-01-15T10:00:00Z',
+    settings: [
+      { field: 'topics', source: ['go', 'payments'], destination: [], status: 'applied' },
+      { field: 'merge_methods', source: { merge_commit: false, squash: true }, destination: { merge_commit: true, squash: true }, status: 'applied' },
+      {
+        field: 'security_and_analysis',
+        source: { secret_scanning: 'enabled' },
+        destination: { secret_scanning: 'disabled' },
+        status: 'failed',
+        error: 'Secret scanning is not available for this repository',
+      },
+    ],
+  };
+
+  it('shows each setting with its source and destination values', () => {
+    render(<SettingsSyncSection report={report} />);
+
+    expect(screen.getByText('Repository Settings Sync')).toBeInTheDocument();
+    expect(screen.getByText('go, payments')).toBeInTheDocument();
+    expect(screen.getByText('merge_commit=false, squash=true')).toBeInTheDocument();
+    expect(screen.getByText('Secret scanning is not available for this repository')).toBeInTheDocument();
+  });
+
+  it('starts collapsed when every setting synced', () => {
+    render(<SettingsSyncSection report={{ ...report, settings: report.settings.slice(0, 1) }} />);
+
+    expect(screen.queryByText('go, payments')).not.toBeInTheDocument();
+    fireEvent.click(screen.getByText('Repository Settings Sync'));
+    expect(screen.getByText('go, payments')).toBeInTheDocument();
+    expect(screen.getByText('Synced')).toBeInTheDocument();
+  });
+});
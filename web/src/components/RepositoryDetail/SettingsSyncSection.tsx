@@ -0,0 +1,103 @@
+import { useState } from 'react';
+import { Label } from '@primer/react';
+import type { SettingsSyncReport, SettingsSyncStatus } from '../../types';
+import { formatDate } from '../../utils/format';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface SettingsSyncSectionProps {
+  report: SettingsSyncReport;
+}
+
+const settingLabels: Record<string, string> = {
+  description: 'Description',
+  homepage: 'Homepage',
+  topics: 'Topics',
+  merge_methods: 'Merge methods',
+  delete_branch_on_merge: 'Delete head branches',
+  security_and_analysis: 'Security and analysis',
+  custom_properties: 'Custom properties',
+};
+
+const statusLabels: Record<SettingsSyncStatus, { text: string; variant: 'attention' | 'success' | 'secondary' | 'danger' }> = {
+  applied: { text: 'Synced', variant: 'success' },
+  unchanged: { text: 'Already matched', variant: 'secondary' },
+  skipped: { text: 'Skipped', variant: 'attention' },
+  failed: { text: 'Failed', variant: 'danger' },
+};
+
+// Renders a setting value compactly: lists are comma-separated and objects are shown as key=value pairs
+const formatValue = (value: unknown): string => {
+  if (value === null || value === undefined || value === '') return '—';
+  if (Array.isArray(value)) return value.length > 0 ? value.join(', ') : '—';
+  if (typeof value === 'object') {
+    const entries = Object.entries(value as Record<string, unknown>);
+    return entries.length > 0 ? entries.map(([key, v]) => `${key}=${formatValue(v)}`).join(', ') : '—';
+  }
+  return String(value);
+};
+
+// Shows how each repository setting in the settings sync allow list compared on the source and
+// destination, and whether the destination was updated.
+export function SettingsSyncSection({ report }: SettingsSyncSectionProps) {
+  const settings = report.settings ?? [];
+  const errors = report.errors ?? [];
+  const failed = errors.length > 0 || settings.some((s) => s.status === 'failed');
+  const skipped = settings.some((s) => s.status === 'skipped');
+  const [expanded, setExpanded] = useState(failed);
+
+  return (
+    <CollapsibleValidationSection
+      id="settings-sync"
+      title="Repository Settings Sync"
+      status={failed || skipped ? 'warning' : 'passed'}
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-3 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <p style={{ color: 'var(--fgColor-muted)' }}>
+          Synced {formatDate(report.synced_at)}. Destination values are shown as they were before the sync.
+        </p>
+
+        {settings.length > 0 && (
+          <table className="min-w-full text-xs">
+            <thead>
+              <tr className="text-left" style={{ color: 'var(--fgColor-muted)' }}>
+                <th className="py-1 pr-4">Setting</th>
+                <th className="py-1 pr-4">Source</th>
+                <th className="py-1 pr-4">Destination</th>
+                <th className="py-1">Result</th>
+              </tr>
+            </thead>
+            <tbody>
+              {settings.map((setting) => (
+                <tr key={setting.field} style={{ borderTop: '1px solid var(--borderColor-muted)' }}>
+                  <td className="py-1 pr-4">{settingLabels[setting.field] ?? setting.field}</td>
+                  <td className="py-1 pr-4 break-all">{formatValue(setting.source)}</td>
+                  <td className="py-1 pr-4 break-all">{formatValue(setting.destination)}</td>
+                  <td className="py-1">
+                    <Label variant={statusLabels[setting.status].variant} size="small">
+                      {statusLabels[setting.status].text}
+                    </Label>
+                    {setting.error && (
+                      <div className="mt-1" style={{ color: 'var(--fgColor-muted)' }}>
+                        {setting.error}
+                      </div>
+                    )}
+                  </td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        )}
+
+        {errors.length > 0 && (
+          <ul className="list-disc list-inside" style={{ color: 'var(--fgColor-danger)' }}>
+            {errors.map((error) => (
+              <li key={error}>{error}</li>
+            ))}
+          </ul>
+        )}
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
@@ -0,0 +1,84 @@
+import { describe, it, expect, vi, beforeEach } from 'vitest';
+import { render, screen, fireEvent, waitFor } from '../../__tests__/test-utils';
+import { WebhooksSection } from './WebhooksSection';
+import { api } from '../../services/api';
+import type { RepositoryWebhooksResponse } from '../../types';
+
+vi.mock('../../services/api', () => ({
+  api: {
+    getRepositoryWebhooks: vi.fn(),
+    activateRepositoryWebhooks: vi.fn(),
+  },
+}));
+
+describe('WebhooksSection', () => {
+  const response: RepositoryWebhooksResponse = {
+    webhooks: [
+      {
+        id: 1,
+        repository_id: 1,
+        source_hook_id: 11,
+        destination_hook_id: 101,
+        url: 'https://ci.example.com/hook',
+        events: 'push,pull_request',
+        content_type: 'json',
+        insecure_ssl: false,
+        has_secret: true,
+        status: 'pending',
+        recorded_at: '2024-01-15T10:00:00Z',
+      },
+      {
+        id: 2,
+        repository_id: 1,
+        source_hook_id: 12,
+        url: 'https://old.example.com/hook',
+        events: 'issues',
+        content_type: 'form',
+        insecure_ssl: false,
+        has_secret: false,
+        status: 'failed',
+        error: 'Validation Failed',
+        recorded_at: '2024-01-15T10:00:00Z',
+      },
+    ],
+    total: 2,
+    pending: 1,
+  };
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  it('lists the recreated webhooks', async () => {
+    (api.getRepositoryWebhooks as ReturnType<typeof vi.fn>).mockResolvedValue(response);
+
+    render(<WebhooksSection fullName="org/repo" />);
+
+    expect(await screen.findByText('Webhooks (1 awaiting activation)')).toBeInTheDocument();
+    expect(screen.getByText('https://ci.example.com/hook')).toBeInTheDocument();
+    expect(screen.getByText('push, pull_request')).toBeInTheDocument();
+    expect(screen.getByText('Awaiting activation')).toBeInTheDocument();
+    expect(screen.getByText('Validation Failed')).toBeInTheDocument();
+  });
+
+  it('renders nothing when the repository has no webhooks', async () => {
+    (api.getRepositoryWebhooks as ReturnType<typeof vi.fn>).mockResolvedValue({ webhooks: [], total: 0, pending: 0 });
+
+    render(<WebhooksSection fullName="org/repo" />);
+
+    await waitFor(() => expect(api.getRepositoryWebhooks).toHaveBeenCalledWith('org/repo'));
+    expect(screen.queryByText(/awaiting activation/)).not.toBeInTheDocument();
+  });
+
+  it('activates the pending webhooks and reloads them', async () => {
+    (api.getRepositoryWebhooks as ReturnType<typeof vi.fn>).mockResolvedValue(response);
+    (api.activateRepositoryWebhooks as ReturnType<typeof vi.fn>).mockResolvedValue({ activated: 1, failed: [] });
+
+    render(<WebhooksSection fullName="org/repo" />);
+
+    fireEvent.click(await screen.findByText('Activate Webhooks'));
+
+    await waitFor(() => expect(api.activateRepositoryWebhooks).toHaveBeenCalledWith('org/repo'));
+    await waitFor(() => expect(api.getRepositoryWebhooks).toHaveBeenCalledTimes(2));
+  });
+});
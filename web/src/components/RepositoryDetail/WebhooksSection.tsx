@@ -0,0 +1,118 @@
+import { useCallback, useEffect, useState } from 'react';
+import { Label } from '@primer/react';
+import { PlayIcon } from '@primer/octicons-react';
+import { Button } from '../common/buttons';
+import { api } from '../../services/api';
+import type { RepositoryWebhook, WebhookStatus } from '../../types';
+import { useToast } from '../../contexts/ToastContext';
+import { handleApiError } from '../../utils/errorHandler';
+import { CollapsibleValidationSection } from './CollapsibleValidationSection';
+
+interface WebhooksSectionProps {
+  fullName: string;
+}
+
+const statusLabels: Record<WebhookStatus, { text: string; variant: 'attention' | 'success' | 'secondary' | 'danger' }> = {
+  pending: { text: 'Awaiting activation', variant: 'attention' },
+  active: { text: 'Active', variant: 'success' },
+  disabled: { text: 'Inactive on source', variant: 'secondary' },
+  failed: { text: 'Not created', variant: 'danger' },
+};
+
+// Lists the source webhooks recreated inactive on the destination. Hook secrets cannot be
+// migrated, so owners re-enter them on the destination before the hooks are activated.
+export function WebhooksSection({ fullName }: WebhooksSectionProps) {
+  const { showSuccess, showError, showWarning } = useToast();
+  const [webhooks, setWebhooks] = useState<RepositoryWebhook[]>([]);
+  const [expanded, setExpanded] = useState(true);
+  const [activating, setActivating] = useState(false);
+
+  const loadWebhooks = useCallback(async () => {
+    try {
+      const response = await api.getRepositoryWebhooks(fullName);
+      setWebhooks(response.webhooks ?? []);
+    } catch {
+      // The list is informational; the section stays hidden if it cannot be loaded
+    }
+  }, [fullName]);
+
+  useEffect(() => {
+    loadWebhooks();
+  }, [loadWebhooks]);
+
+  const handleActivate = async () => {
+    try {
+      setActivating(true);
+      const result = await api.activateRepositoryWebhooks(fullName);
+      if (result.failed.length > 0) {
+        showWarning(`Activated ${result.activated} webhooks; ${result.failed.length} could not be activated`);
+      } else {
+        showSuccess(`Activated ${result.activated} webhooks`);
+      }
+      await loadWebhooks();
+    } catch (error) {
+      handleApiError(error, showError, 'Failed to activate webhooks');
+    } finally {
+      setActivating(false);
+    }
+  };
+
+  if (webhooks.length === 0) {
+    return null;
+  }
+
+  const pending = webhooks.filter((webhook) => webhook.status === 'pending').length;
+
+  return (
+    <CollapsibleValidationSection
+      id="webhooks"
+      title={`Webhooks (${pending} awaiting activation)`}
+      status={pending > 0 ? 'warning' : 'passed'}
+      expanded={expanded}
+      onToggle={() => setExpanded(!expanded)}
+    >
+      <div className="space-y-4 text-sm" style={{ color: 'var(--fgColor-default)' }}>
+        <div className="flex items-center justify-between gap-4">
+          <p style={{ color: 'var(--fgColor-muted)' }}>
+            Webhooks were recreated inactive because their secrets cannot be migrated. Re-enter each secret on the
+            destination repository, then activate the webhooks.
+          </p>
+          {pending > 0 && (
+            <Button variant="primary" size="small" leadingVisual={PlayIcon} onClick={handleActivate} disabled={activating}>
+              {activating ? 'Activating...' : 'Activate Webhooks'}
+            </Button>
+          )}
+        </div>
+        <table className="min-w-full text-xs">
+          <thead>
+            <tr className="text-left" style={{ color: 'var(--fgColor-muted)' }}>
+              <th className="py-1 pr-4">URL</th>
+              <th className="py-1 pr-4">Events</th>
+              <th className="py-1">Status</th>
+            </tr>
+          </thead>
+          <tbody>
+            {webhooks.map((webhook) => (
+              <tr key={webhook.id} style={{ borderTop: '1px solid var(--borderColor-muted)' }}>
+                <td className="py-1 pr-4 font-mono break-all">
+                  {webhook.url}
+                  {webhook.error && (
+                    <div className="mt-0.5" style={{ color: 'var(--fgColor-danger)' }}>
+                      {webhook.error}
+                    </div>
+                  )}
+                </td>
+                <td className="py-1 pr-4">{webhook.events.split(',').join(', ')}</td>
+                <td className="py-1">
+                  <Label variant={statusLabels[webhook.status].variant} size="small">
+                    {statusLabels[webhook.status].text}
+                  </Label>
+                </td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      </div>
+    </CollapsibleValidationSection>
+  );
+}
@@ -22,7 +22,14 @@ interface SourceFormProps {
 
 // Get default base URL based on source type
 function getDefaultBaseUrl(type: SourceType): string {
-  return type === 'github' ? 'https://api.github.com' : 'https://dev.azure.com/';
+  switch (type) {
+    case 'github':
+      return 'https://api.github.com';
+    case 'gitlab':
+      return 'https://gitlab.com';
+    default:
+      return 'https://dev.azure.com/';
+  }
 }
 
 /**
@@ -225,6 +232,8 @@ export function SourceForm({ source, onSubmit, onCancel, isSubmitting }: SourceF
           >
             <Select.Option value="github">GitHub</Select.Option>
             <Select.Option value="azuredevops">Azure DevOps</Select.Option>
+            <Select.Option value="gitlab">GitLab</Select.Option>
+            <Select.Option value="bitbucket">Bitbucket Server / Data Center</Select.Option>
           </Select>
         </FormControl>
       )}
@@ -235,7 +244,7 @@ export function SourceForm({ source, onSubmit, onCancel, isSubmitting }: SourceF
         <TextInput
           value={formData.base_url}
           onChange={(e) => handleChange('base_url', e.target.value)}
-          placeholder={formData.type === 'github' ? 'https://api.github.com' : 'https://dev.azure.com/your-org'}
+          placeholder={formData.type === 'azuredevops' ? 'https://dev.azure.com/your-org' : getDefaultBaseUrl(formData.type)}
           block
         />
         {errors.base_url && (
@@ -244,7 +253,11 @@ export function SourceForm({ source, onSubmit, onCancel, isSubmitting }: SourceF
         <FormControl.Caption>
           {formData.type === 'github' 
             ? 'API endpoint (e.g., https://api.github.com for github.com or https://ghes.example.com/api/v3 for GHES)'
-            : 'Azure DevOps organization URL'}
+            : formData.type === 'gitlab'
+              ? 'GitLab instance URL (e.g., https://gitlab.com or https://gitlab.example.com)'
+              : formData.type === 'bitbucket'
+                ? 'Bitbucket instance URL (e.g., https://bitbucket.example.com)'
+                : 'Azure DevOps organization URL'}
         </FormControl.Caption>
       </FormControl>
 
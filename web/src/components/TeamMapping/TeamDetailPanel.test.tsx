@@ -410,6 +410,25 @@ describe('TeamDetailPanel', () => {
     expect(screen.getByText(/Last synced:/)).toBeInTheDocument();
   });
 
+  it('shows the parent of nested teams', () => {
+    (useTeamDetail as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: { ...mockTeamDetail, parent_slug: 'engineering' },
+      isLoading: false,
+      error: null,
+      refetch: mockRefetch,
+    });
+
+    render(
+      <TeamDetailPanel
+        org="source-org"
+        teamSlug="team-alpha"
+        onClose={mockOnClose}
+      />
+    );
+
+    expect(screen.getByText('Parent: engineering')).toBeInTheDocument();
+  });
+
   it('shows empty members message when no members', () => {
     const teamNoMembers = {
       ...mockTeamDetail,
@@ -190,6 +190,7 @@ export function TeamDetailPanel({ org, teamSlug, onClose, onEditMapping, onMigra
                 <SourceBadge sourceId={team.source_id} size="small" />
               )}
               <Label variant="accent">{team.privacy}</Label>
+              {team.parent_slug && <Label>Parent: {team.parent_slug}</Label>}
               <Label>{team.members.length} members</Label>
               <Label>{team.repositories.length} repos</Label>
             </div>
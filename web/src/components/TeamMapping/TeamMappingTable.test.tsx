@@ -10,6 +10,7 @@ vi.mock('../../hooks/useQueries', () => ({
   useTeamMigrationStatus: vi.fn(),
   useTeamSourceOrgs: vi.fn(),
   useTeamDetail: vi.fn(),
+  useTeamMembershipReport: vi.fn(),
 }));
 
 vi.mock('../../hooks/useMutations', () => ({
@@ -34,6 +35,7 @@ import {
   useTeamMigrationStatus,
   useTeamSourceOrgs,
   useTeamDetail,
+  useTeamMembershipReport,
 } from '../../hooks/useQueries';
 import {
   useUpdateTeamMapping,
@@ -141,6 +143,10 @@ describe('TeamMappingTable', () => {
       data: mockStats,
     });
 
+    (useTeamMembershipReport as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: undefined,
+    });
+
     (useTeamMigrationStatus as ReturnType<typeof vi.fn>).mockReturnValue({
       data: mockMigrationStatus,
     });
@@ -430,6 +436,31 @@ describe('TeamMappingTable', () => {
     expect(screen.getByText('4 Created')).toBeInTheDocument();
   });
 
+  it('lists teams that could not be nested under their source parent', () => {
+    const completedStatus = {
+      ...mockMigrationStatus,
+      progress: {
+        total_teams: 2,
+        processed_teams: 2,
+        created_teams: 2,
+        skipped_teams: 0,
+        failed_teams: 0,
+        total_repos_synced: 0,
+        hierarchy_warnings: ['source-org/web: parent team source-org/frontend has no mapped ancestor in dest-org; created at the top level'],
+        status: 'completed',
+      },
+    };
+
+    (useTeamMigrationStatus as ReturnType<typeof vi.fn>).mockReturnValue({
+      data: completedStatus,
+    });
+
+    render(<TeamMappingTable />);
+
+    expect(screen.getByText('Not nested under their source parent:')).toBeInTheDocument();
+    expect(screen.getByText(/source-org\/web: parent team source-org\/frontend/)).toBeInTheDocument();
+  });
+
   it('shows cancel button when migration is running', () => {
     const runningStatus = {
       ...mockMigrationStatus,
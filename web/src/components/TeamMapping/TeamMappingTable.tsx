@@ -31,7 +31,13 @@ import {
   EyeIcon,
   RocketIcon,
 } from '@primer/octicons-react';
-import { useTeamMappings, useTeamMappingStats, useTeamMigrationStatus, useTeamSourceOrgs } from '../../hooks/useQueries';
+import {
+  useTeamMappings,
+  useTeamMappingStats,
+  useTeamMigrationStatus,
+  useTeamSourceOrgs,
+  useTeamMembershipReport,
+} from '../../hooks/useQueries';
 import {
   useUpdateTeamMapping,
   useDeleteTeamMapping,
@@ -51,6 +57,7 @@ import { SourceTypeIcon } from '../common/SourceBadge';
 import { DiscoverySourceSelector } from '../common/DiscoverySourceSelector';
 import { useSourceSelection } from '../../hooks/useSourceSelection';
 import { TeamDetailPanel } from './TeamDetailPanel';
+import { TeamMembershipReport } from './TeamMembershipReport';
 import { useToast } from '../../contexts/ToastContext';
 import { handleApiError } from '../../utils/errorHandler';
 import { useSourceContext } from '../../contexts/SourceContext';
@@ -118,6 +125,9 @@ function MigrationProgress({
     skipped_teams: number;
     failed_teams: number;
     total_repos_synced: number;
+    members_added?: number;
+    unmapped_members?: number;
+    hierarchy_warnings?: string[];
     current_team?: string;
     status: string;
     errors?: string[];
@@ -220,6 +230,12 @@ function MigrationProgress({
               <Label variant="danger">{progress.failed_teams} Failed</Label>
             )}
             <Label variant="accent">{progress.total_repos_synced} Repo Permissions</Label>
+            {(progress.members_added ?? 0) > 0 && (
+              <Label variant="success">{progress.members_added} Members Added</Label>
+            )}
+            {(progress.unmapped_members ?? 0) > 0 && (
+              <Label variant="attention">{progress.unmapped_members} Unmapped Members</Label>
+            )}
           </>
         ) : executionStats && (
           <>
@@ -244,6 +260,22 @@ function MigrationProgress({
         )}
       </div>
 
+      {progress?.hierarchy_warnings && progress.hierarchy_warnings.length > 0 && (
+        <div className="mt-4">
+          <span className="font-semibold text-sm" style={{ color: 'var(--fgColor-attention)' }}>
+            Not nested under their source parent:
+          </span>
+          <ul
+            className="m-0 mt-1 pl-4 text-xs max-h-24 overflow-auto"
+            style={{ color: 'var(--fgColor-muted)' }}
+          >
+            {progress.hierarchy_warnings.map((warning, i) => (
+              <li key={i}>{warning}</li>
+            ))}
+          </ul>
+        </div>
+      )}
+
       {progress?.errors && progress.errors.length > 0 && (
         <div className="mt-4">
           <span className="font-semibold text-sm" style={{ color: 'var(--fgColor-danger)' }}>
@@ -288,6 +320,7 @@ export function TeamMappingTable() {
   const [editDestSlug, setEditDestSlug] = useState('');
   const [selectedTeam, setSelectedTeam] = useState<{ org: string; slug: string } | null>(null);
   const [dryRun, setDryRun] = useState(false);
+  const [syncMembers, setSyncMembers] = useState(false);
   const [discoverOrg, setDiscoverOrg] = useState('');
   const [discoverSourceId, setDiscoverSourceId] = useState<number | null>(null);
   
@@ -310,6 +343,10 @@ export function TeamMappingTable() {
 
   const { data: stats } = useTeamMappingStats(filters.sourceOrg || undefined, activeSource?.id);
   const { data: migrationStatus } = useTeamMigrationStatus();
+  const { data: membershipReport } = useTeamMembershipReport(
+    filters.sourceOrg || undefined,
+    migrationStatus?.is_running || false,
+  );
   const { data: sourceOrgsData } = useTeamSourceOrgs();
   const sourceOrgs = sourceOrgsData || [];
   const updateMapping = useUpdateTeamMapping();
@@ -428,12 +465,13 @@ export function TeamMappingTable() {
       await executeMigration.mutateAsync({
         source_org: filters.sourceOrg || undefined,
         dry_run: dryRun,
+        sync_members: syncMembers,
       });
       executeDialog.close();
     } catch {
       // Execute migration failed, mutation will show error
     }
-  }, [executeMigration, filters.sourceOrg, dryRun, executeDialog]);
+  }, [executeMigration, filters.sourceOrg, dryRun, syncMembers, executeDialog]);
 
   const handleCancel = useCallback(async () => {
     try {
@@ -502,6 +540,9 @@ export function TeamMappingTable() {
         onReset={handleReset}
       />
 
+      {/* Team Membership Report */}
+      <TeamMembershipReport report={membershipReport} />
+
       {/* Header with stats */}
       <div className="flex justify-between items-start flex-wrap gap-4">
         <div>
@@ -950,8 +991,9 @@ export function TeamMappingTable() {
           <div className="flex items-start gap-2">
             <AlertIcon size={16} className="flex-shrink-0 mt-0.5" />
             <div>
-              <strong>EMU/IdP Notice:</strong> Teams are created <strong>without members</strong>. 
-              If your destination is an EMU environment, manage team membership through your Identity Provider (IdP/SCIM). 
+              <strong>EMU/IdP Notice:</strong> Teams are created <strong>without members</strong> unless you add
+              them below. Teams whose destination membership is managed by your Identity Provider (EMU external
+              groups or IdP team synchronization) are always skipped; manage their membership through your IdP/SCIM.
               Repository permissions will be applied to teams.
             </div>
           </div>
@@ -966,6 +1008,14 @@ export function TeamMappingTable() {
             />
             <span>Dry run (preview changes without applying)</span>
           </label>
+          <label className="flex items-center gap-2 cursor-pointer mt-2">
+            <input
+              type="checkbox"
+              checked={syncMembers}
+              onChange={(e) => setSyncMembers(e.target.checked)}
+            />
+            <span>Add team members through their user mappings</span>
+          </label>
         </div>
 
         {filters.sourceOrg && (
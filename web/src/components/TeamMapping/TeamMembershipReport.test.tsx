@@ -0,0 +1,63 @@
+import { describe, it, expect } from 'vitest';
+import { render, screen } from '../../__tests__/test-utils';
+import { TeamMembershipReport } from './TeamMembershipReport';
+import type { TeamMembershipReport as MembershipReport } from '../../types';
+
+describe('TeamMembershipReport', () => {
+  const report: MembershipReport = {
+    teams: [
+      {
+        id: 1,
+        source_org: 'source-org',
+        source_team_slug: 'backend',
+        destination_org: 'dest-org',
+        destination_team_slug: 'backend',
+        status: 'synced',
+        members_added: 3,
+        maintainers_added: 1,
+        unmapped: 'carol,dave',
+        failed: 'erin-acme',
+        created_at: '2024-01-15T10:00:00Z',
+      },
+      {
+        id: 2,
+        source_org: 'source-org',
+        source_team_slug: 'platform',
+        destination_org: 'dest-org',
+        destination_team_slug: 'platform',
+        status: 'skipped',
+        reason: 'Membership of dest-org/platform is managed by the identity provider through EMU external group Engineering',
+        members_added: 0,
+        maintainers_added: 0,
+        created_at: '2024-01-15T10:00:00Z',
+      },
+    ],
+    summary: { synced: 1, skipped: 1, failed: 0, members_added: 3, unmapped_members: 2 },
+  };
+
+  it('renders nothing without synced teams', () => {
+    const { container } = render(
+      <TeamMembershipReport
+        report={{ teams: null, summary: { synced: 0, skipped: 0, failed: 0, members_added: 0, unmapped_members: 0 } }}
+      />
+    );
+    expect(container).toBeEmptyDOMElement();
+  });
+
+  it('shows added, unmapped and failed members', () => {
+    render(<TeamMembershipReport report={report} />);
+
+    expect(screen.getByText('3 Members Added')).toBeInTheDocument();
+    expect(screen.getByText('2 Unmapped Members')).toBeInTheDocument();
+    expect(screen.getByText('3 added (1 maintainers)')).toBeInTheDocument();
+    expect(screen.getByText('carol, dave')).toBeInTheDocument();
+    expect(screen.getByText('erin-acme')).toBeInTheDocument();
+  });
+
+  it('explains why identity provider managed teams were skipped', () => {
+    render(<TeamMembershipReport report={report} />);
+
+    expect(screen.getByText('1 Teams Skipped')).toBeInTheDocument();
+    expect(screen.getByText(/EMU external group Engineering/)).toBeInTheDocument();
+  });
+});
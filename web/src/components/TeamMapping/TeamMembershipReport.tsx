@@ -0,0 +1,72 @@
+import { Label } from '@primer/react';
+import type { TeamMembershipReport as MembershipReport, TeamMembershipSyncStatus } from '../../types';
+
+const statusVariants: Record<TeamMembershipSyncStatus, 'success' | 'attention' | 'danger'> = {
+  synced: 'success',
+  skipped: 'attention',
+  failed: 'danger',
+};
+
+// Lists the outcome of adding team members through their user mappings, including the members
+// that could not be mapped and the teams skipped because an identity provider manages them.
+export function TeamMembershipReport({ report }: { report?: MembershipReport }) {
+  if (!report?.teams || report.teams.length === 0) {
+    return null;
+  }
+
+  const { summary } = report;
+
+  return (
+    <div
+      className="p-4 rounded-lg mb-4"
+      style={{ backgroundColor: 'var(--bgColor-muted)', border: '1px solid var(--borderColor-default)' }}
+    >
+      <div className="font-semibold mb-2">Team Membership</div>
+      <div className="flex gap-3 flex-wrap mb-3">
+        <Label variant="success">{summary.members_added} Members Added</Label>
+        {summary.unmapped_members > 0 && (
+          <Label variant="attention">{summary.unmapped_members} Unmapped Members</Label>
+        )}
+        {summary.skipped > 0 && <Label variant="default">{summary.skipped} Teams Skipped</Label>}
+        {summary.failed > 0 && <Label variant="danger">{summary.failed} Teams Failed</Label>}
+      </div>
+
+      <ul className="m-0 pl-0 list-none text-sm max-h-64 overflow-auto">
+        {report.teams.map((team) => (
+          <li
+            key={`${team.source_org}/${team.source_team_slug}`}
+            className="py-2"
+            style={{ borderTop: '1px solid var(--borderColor-muted)' }}
+          >
+            <div className="flex items-center gap-2">
+              <span className="font-mono">
+                {team.source_org}/{team.source_team_slug} → {team.destination_org}/{team.destination_team_slug}
+              </span>
+              <Label variant={statusVariants[team.status]}>{team.status}</Label>
+              {team.status === 'synced' && (
+                <span style={{ color: 'var(--fgColor-muted)' }}>
+                  {team.members_added} added ({team.maintainers_added} maintainers)
+                </span>
+              )}
+            </div>
+            {team.reason && (
+              <div className="text-xs mt-1" style={{ color: 'var(--fgColor-muted)' }}>
+                {team.reason}
+              </div>
+            )}
+            {team.unmapped && (
+              <div className="text-xs mt-1" style={{ color: 'var(--fgColor-attention)' }}>
+                No user mapping: <span className="font-mono">{team.unmapped.split(',').join(', ')}</span>
+              </div>
+            )}
+            {team.failed && (
+              <div className="text-xs mt-1" style={{ color: 'var(--fgColor-danger)' }}>
+                Could not be added: <span className="font-mono">{team.failed.split(',').join(', ')}</span>
+              </div>
+            )}
+          </li>
+        ))}
+      </ul>
+    </div>
+  );
+}
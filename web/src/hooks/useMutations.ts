@@ -509,9 +509,11 @@ export function useExecuteTeamMigration() {
   const queryClient = useQueryClient();
   
   return useMutation({
-    mutationFn: (options?: { source_org?: string; source_team_slug?: string; dry_run?: boolean }) => api.executeTeamMigration(options),
+    mutationFn: (options?: { source_org?: string; source_team_slug?: string; dry_run?: boolean; sync_members?: boolean }) =>
+      api.executeTeamMigration(options),
     onSuccess: () => {
       queryClient.invalidateQueries({ queryKey: ['teamMigrationStatus'] });
+      queryClient.invalidateQueries({ queryKey: ['teamMembershipReport'] });
       queryClient.invalidateQueries({ queryKey: ['teamMappings'] });
       queryClient.invalidateQueries({ queryKey: ['teamMappingStats'] });
     },
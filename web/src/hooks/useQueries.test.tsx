@@ -11,6 +11,9 @@ import {
   useBatches,
   useBatch,
   useBatchRepositories,
+  useBatchForecast,
+  useBatchDependency,
+  useBatchApproval,
   useAnalytics,
   useMigrationHistory,
   useDiscoveryStatus,
@@ -41,6 +44,9 @@ vi.mock('../services/api', () => ({
     getRepositoryDetail: vi.fn(),
     listBatches: vi.fn(),
     getBatch: vi.fn(),
+    getBatchForecast: vi.fn(),
+    getBatchDependency: vi.fn(),
+    getBatchApproval: vi.fn(),
     getBatchRepositories: vi.fn(),
     getAnalyticsSummary: vi.fn(),
     getMigrationHistoryList: vi.fn(),
@@ -86,6 +92,9 @@ describe('useQueries hooks', () => {
     getRepositoryDetail: ReturnType<typeof vi.fn>;
     listBatches: ReturnType<typeof vi.fn>;
     getBatch: ReturnType<typeof vi.fn>;
+    getBatchForecast: ReturnType<typeof vi.fn>;
+    getBatchDependency: ReturnType<typeof vi.fn>;
+    getBatchApproval: ReturnType<typeof vi.fn>;
     getBatchRepositories: ReturnType<typeof vi.fn>;
     getAnalyticsSummary: ReturnType<typeof vi.fn>;
     getMigrationHistoryList: ReturnType<typeof vi.fn>;
@@ -411,6 +420,69 @@ describe('useQueries hooks', () => {
     });
   });
 
+  describe('useBatchForecast', () => {
+    it('should fetch the forecast for a batch', async () => {
+      const forecast = { repositories: 3, workers: 5, total_seconds: 900, wall_clock_seconds: 300 };
+      mockApi.getBatchForecast.mockResolvedValue(forecast);
+
+      const { result } = renderHook(() => useBatchForecast(1), {
+        wrapper: createWrapper(),
+      });
+
+      await waitFor(() => expect(result.current.isSuccess).toBe(true));
+      expect(result.current.data).toEqual(forecast);
+      expect(mockApi.getBatchForecast).toHaveBeenCalledWith(1);
+    });
+
+    it('should not fetch when batchId is null', () => {
+      const { result } = renderHook(() => useBatchForecast(null), {
+        wrapper: createWrapper(),
+      });
+
+      expect(result.current.fetchStatus).toBe('idle');
+      expect(mockApi.getBatchForecast).not.toHaveBeenCalled();
+    });
+  });
+
+  describe('useBatchDependency', () => {
+    it('should fetch the dependency of a batch', async () => {
+      const dependency = { batch_id: 1, batch_name: 'Wave 1', batch_status: 'completed', state: 'satisfied', failures: 0, reason: 'done' };
+      mockApi.getBatchDependency.mockResolvedValue(dependency);
+
+      const { result } = renderHook(() => useBatchDependency(2), {
+        wrapper: createWrapper(),
+      });
+
+      await waitFor(() => expect(result.current.isSuccess).toBe(true));
+      expect(result.current.data).toEqual(dependency);
+      expect(mockApi.getBatchDependency).toHaveBeenCalledWith(2);
+    });
+  });
+
+  describe('useBatchApproval', () => {
+    it('should fetch the latest approval step of a batch', async () => {
+      const approval = { id: 1, batch_id: 2, action: 'requested', actor: 'alice', created_at: '2024-01-01T00:00:00Z' };
+      mockApi.getBatchApproval.mockResolvedValue(approval);
+
+      const { result } = renderHook(() => useBatchApproval(2), {
+        wrapper: createWrapper(),
+      });
+
+      await waitFor(() => expect(result.current.isSuccess).toBe(true));
+      expect(result.current.data).toEqual(approval);
+      expect(mockApi.getBatchApproval).toHaveBeenCalledWith(2);
+    });
+
+    it('should not fetch when batchId is null', () => {
+      const { result } = renderHook(() => useBatchApproval(null), {
+        wrapper: createWrapper(),
+      });
+
+      expect(result.current.fetchStatus).toBe('idle');
+      expect(mockApi.getBatchApproval).not.toHaveBeenCalled();
+    });
+  });
+
   describe('useBatchRepositories', () => {
     it('should not fetch when batchId is null', () => {
       const { result } = renderHook(() => useBatchRepositories(null), {
@@ -6,6 +6,9 @@ import {
   Repository,
   Analytics,
   Batch,
+  BatchApproval,
+  BatchDependency,
+  MigrationForecast,
   MigrationHistoryEntry,
   RepositoryFilters,
   DashboardActionItems,
@@ -20,6 +23,7 @@ import {
   TeamMappingStats,
   TeamDetail,
   TeamMigrationStatusResponse,
+  TeamMembershipReport,
   DiscoveryProgress,
 } from '../types';
 
@@ -48,7 +52,7 @@ export function useOrganizations(options?: OrganizationQueryOptions) {
 
 // Config query
 export function useConfig() {
-  return useQuery<{ source_type: 'github' | 'azuredevops'; auth_enabled: boolean }, Error>({
+  return useQuery<{ source_type: 'github' | 'azuredevops'; auth_enabled: boolean; batch_approval_required?: boolean }, Error>({
     queryKey: ['config'],
     queryFn: () => api.getConfig(),
     staleTime: 5 * 60 * 1000, // Config rarely changes, cache for 5 minutes
@@ -158,6 +162,36 @@ export function useBatchRepositories(batchId: number | null, options?: PollingOp
   });
 }
 
+export function useBatchForecast(batchId: number | null, options?: PollingOptions) {
+  return useQuery<MigrationForecast | null, Error>({
+    queryKey: ['batchForecast', batchId],
+    queryFn: () => api.getBatchForecast(batchId!),
+    enabled: !!batchId,
+    refetchInterval: options?.refetchInterval,
+    refetchIntervalInBackground: options?.refetchIntervalInBackground ?? false,
+  });
+}
+
+export function useBatchDependency(batchId: number | null, options?: PollingOptions) {
+  return useQuery<BatchDependency | null, Error>({
+    queryKey: ['batchDependency', batchId],
+    queryFn: () => api.getBatchDependency(batchId!),
+    enabled: !!batchId,
+    refetchInterval: options?.refetchInterval,
+    refetchIntervalInBackground: options?.refetchIntervalInBackground ?? false,
+  });
+}
+
+export function useBatchApproval(batchId: number | null, options?: PollingOptions) {
+  return useQuery<BatchApproval | null, Error>({
+    queryKey: ['batchApproval', batchId],
+    queryFn: () => api.getBatchApproval(batchId!),
+    enabled: !!batchId,
+    refetchInterval: options?.refetchInterval,
+    refetchIntervalInBackground: options?.refetchIntervalInBackground ?? false,
+  });
+}
+
 // Migration history queries
 interface MigrationHistoryFilters {
   sourceId?: number;
@@ -327,6 +361,15 @@ export function useTeamMigrationStatus(enabled = true) {
   });
 }
 
+// Team membership report query, polled while a team migration is running
+export function useTeamMembershipReport(sourceOrg?: string, isRunning = false) {
+  return useQuery<TeamMembershipReport, Error>({
+    queryKey: ['teamMembershipReport', sourceOrg],
+    queryFn: () => api.getTeamMembershipReport(sourceOrg),
+    refetchInterval: isRunning ? 5000 : false,
+  });
+}
+
 // Setup status query
 export function useSetupStatus() {
   return useQuery<{ setup_completed: boolean; completed_at?: string }, Error>({
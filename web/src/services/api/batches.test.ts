@@ -48,6 +48,84 @@ describe('batchesApi', () => {
     });
   });
 
+  describe('getForecast', () => {
+    it('should return the forecast from the batch detail', async () => {
+      const forecast = { repositories: 2, workers: 5, total_seconds: 600, wall_clock_seconds: 300 };
+      mockClient.get.mockResolvedValue({ data: { batch: { id: 1 }, repositories: [], forecast } });
+
+      const result = await batchesApi.getForecast(1);
+
+      expect(mockClient.get).toHaveBeenCalledWith('/batches/1');
+      expect(result).toEqual(forecast);
+    });
+
+    it('should return null when the batch has no forecast', async () => {
+      mockClient.get.mockResolvedValue({ data: { batch: { id: 1 }, repositories: [] } });
+
+      expect(await batchesApi.getForecast(1)).toBeNull();
+    });
+  });
+
+  describe('getDependency', () => {
+    it('should return the dependency from the batch detail', async () => {
+      const dependency = { batch_id: 2, batch_name: 'Wave 2', batch_status: 'ready', state: 'waiting', failures: 0, reason: 'waiting' };
+      mockClient.get.mockResolvedValue({ data: { batch: { id: 3 }, repositories: [], dependency } });
+
+      expect(await batchesApi.getDependency(3)).toEqual(dependency);
+    });
+
+    it('should return null when the batch has no dependency', async () => {
+      mockClient.get.mockResolvedValue({ data: { batch: { id: 1 }, repositories: [] } });
+
+      expect(await batchesApi.getDependency(1)).toBeNull();
+    });
+  });
+
+  describe('getApproval', () => {
+    it('should return the latest approval step from the batch detail', async () => {
+      const approval = { id: 4, batch_id: 3, action: 'approved', actor: 'bob', created_at: '2024-01-01T00:00:00Z' };
+      mockClient.get.mockResolvedValue({ data: { batch: { id: 3 }, repositories: [], approval } });
+
+      expect(await batchesApi.getApproval(3)).toEqual(approval);
+    });
+
+    it('should return null when the batch was never submitted', async () => {
+      mockClient.get.mockResolvedValue({ data: { batch: { id: 1 }, repositories: [] } });
+
+      expect(await batchesApi.getApproval(1)).toBeNull();
+    });
+  });
+
+  describe('approval actions', () => {
+    it('should request approval with a comment', async () => {
+      mockClient.post.mockResolvedValue({ data: { id: 1, action: 'requested' } });
+
+      await batchesApi.requestApproval(3, 'CHG-1042');
+
+      expect(mockClient.post).toHaveBeenCalledWith('/batches/3/approval-request', { comment: 'CHG-1042' });
+    });
+
+    it('should approve and reject a batch', async () => {
+      mockClient.post.mockResolvedValue({ data: {} });
+
+      await batchesApi.approve(3);
+      await batchesApi.reject(3, 'dry run failures');
+
+      expect(mockClient.post).toHaveBeenCalledWith('/batches/3/approve', { comment: undefined });
+      expect(mockClient.post).toHaveBeenCalledWith('/batches/3/reject', { comment: 'dry run failures' });
+    });
+  });
+
+  describe('chain', () => {
+    it('should chain batches in order', async () => {
+      mockClient.post.mockResolvedValue({ data: { batches: [], message: 'Chained 3 batches' } });
+
+      await batchesApi.chain([1, 2, 3], 5);
+
+      expect(mockClient.post).toHaveBeenCalledWith('/batches/chain', { batch_ids: [1, 2, 3], max_dependency_failures: 5 });
+    });
+  });
+
   describe('create', () => {
     it('should create a new batch', async () => {
       const newBatch = { name: 'New Batch', description: 'Test batch' };
@@ -2,7 +2,7 @@
  * Batch-related API endpoints.
  */
 import { client } from './client';
-import type { Batch } from '../../types';
+import type { Batch, BatchApproval, BatchDependency, MigrationForecast } from '../../types';
 
 export const batchesApi = {
   async list(): Promise<Batch[]> {
@@ -15,6 +15,49 @@ export const batchesApi = {
     return data;
   },
 
+  async getForecast(id: number): Promise<MigrationForecast | null> {
+    const { data } = await client.get(`/batches/${id}`);
+    return data.forecast ?? null;
+  },
+
+  async getDependency(id: number): Promise<BatchDependency | null> {
+    const { data } = await client.get(`/batches/${id}`);
+    return data.dependency ?? null;
+  },
+
+  async getApproval(id: number): Promise<BatchApproval | null> {
+    const { data } = await client.get(`/batches/${id}`);
+    return data.approval ?? null;
+  },
+
+  async listApprovals(id: number): Promise<{ state: string; approval_required: boolean; approvals: BatchApproval[] }> {
+    const { data } = await client.get(`/batches/${id}/approvals`);
+    return data;
+  },
+
+  async requestApproval(id: number, comment?: string): Promise<BatchApproval> {
+    const { data } = await client.post(`/batches/${id}/approval-request`, { comment });
+    return data;
+  },
+
+  async approve(id: number, comment?: string): Promise<BatchApproval> {
+    const { data } = await client.post(`/batches/${id}/approve`, { comment });
+    return data;
+  },
+
+  async reject(id: number, comment: string): Promise<BatchApproval> {
+    const { data } = await client.post(`/batches/${id}/reject`, { comment });
+    return data;
+  },
+
+  async chain(batchIds: number[], maxDependencyFailures?: number): Promise<{ batches: Batch[]; message: string }> {
+    const { data } = await client.post('/batches/chain', {
+      batch_ids: batchIds,
+      max_dependency_failures: maxDependencyFailures,
+    });
+    return data;
+  },
+
   async create(batch: Partial<Batch>): Promise<Batch> {
     const { data } = await client.post('/batches', batch);
     return data;
@@ -9,6 +9,7 @@ export const configApi = {
   async getConfig(): Promise<{
     source_type: 'github' | 'azuredevops';
     auth_enabled: boolean;
+    batch_approval_required?: boolean;
   }> {
     const { data } = await client.get('/config');
     return data;
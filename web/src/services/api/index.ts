@@ -65,6 +65,18 @@ export const api = {
   getDependencyGraph: repositoriesApi.getDependencyGraph,
   exportDependencies: repositoriesApi.exportDependencies,
   exportRepositoryDependencies: repositoriesApi.exportRepositoryDependencies,
+  getRepositorySecrets: repositoriesApi.getSecrets,
+  exportRepositorySecrets: repositoriesApi.exportRepositorySecrets,
+  exportSecrets: repositoriesApi.exportSecrets,
+  getRepositoryWebhooks: repositoriesApi.getWebhooks,
+  activateRepositoryWebhooks: repositoriesApi.activateWebhooks,
+  bulkActivateWebhooks: repositoriesApi.bulkActivateWebhooks,
+  getReferencePullRequest: repositoriesApi.getReferencePullRequest,
+  openReferencePullRequest: repositoriesApi.openReferencePullRequest,
+  getCodeowners: repositoriesApi.getCodeowners,
+  rewriteCodeowners: repositoriesApi.rewriteCodeowners,
+  getCollaboratorGrants: repositoriesApi.getCollaboratorGrants,
+  grantCollaborators: repositoriesApi.grantCollaborators,
   markRepositoryRemediated: repositoriesApi.markRemediated,
   markRepositoryWontMigrate: repositoriesApi.markWontMigrate,
   batchUpdateRepositoryStatus: repositoriesApi.batchUpdateStatus,
@@ -81,6 +93,14 @@ export const api = {
   // Batches
   listBatches: batchesApi.list,
   getBatch: batchesApi.get,
+  getBatchForecast: batchesApi.getForecast,
+  getBatchDependency: batchesApi.getDependency,
+  chainBatches: batchesApi.chain,
+  getBatchApproval: batchesApi.getApproval,
+  listBatchApprovals: batchesApi.listApprovals,
+  requestBatchApproval: batchesApi.requestApproval,
+  approveBatch: batchesApi.approve,
+  rejectBatch: batchesApi.reject,
   createBatch: batchesApi.create,
   updateBatch: batchesApi.update,
   deleteBatch: batchesApi.delete,
@@ -163,6 +183,7 @@ export const api = {
   // Team Migration Execution
   executeTeamMigration: teamsApi.executeMigration,
   getTeamMigrationStatus: teamsApi.getMigrationStatus,
+  getTeamMembershipReport: teamsApi.getMembershipReport,
   cancelTeamMigration: teamsApi.cancelMigration,
   resetTeamMigrationStatus: teamsApi.resetMigrationStatus,
 
@@ -230,6 +230,129 @@ describe('repositoriesApi', () => {
     });
   });
 
+  describe('secrets checklist', () => {
+    it('should fetch the secrets to re-enter for a repository', async () => {
+      const response = { secrets: [], total: 0, placeholders: 0 };
+      mockClient.get.mockResolvedValue({ data: response });
+
+      const result = await repositoriesApi.getSecrets('org/repo');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/repositories/org%2Frepo/secrets');
+      expect(result).toEqual(response);
+    });
+
+    it('should export a repository checklist as CSV blob', async () => {
+      mockClient.get.mockResolvedValue({ data: new Blob() });
+
+      await repositoriesApi.exportRepositorySecrets('org/repo', 'csv');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/repositories/org%2Frepo/secrets/export', {
+        params: { format: 'csv' },
+        responseType: 'blob',
+      });
+    });
+
+    it('should export the checklist of all repositories', async () => {
+      mockClient.get.mockResolvedValue({ data: new Blob() });
+
+      await repositoriesApi.exportSecrets('json');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/secrets/export', {
+        params: { format: 'json' },
+        responseType: 'blob',
+      });
+    });
+  });
+
+  describe('webhooks', () => {
+    it('should fetch the recreated webhooks of a repository', async () => {
+      const response = { webhooks: [], total: 0, pending: 0 };
+      mockClient.get.mockResolvedValue({ data: response });
+
+      const result = await repositoriesApi.getWebhooks('org/repo');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/repositories/org%2Frepo/webhooks');
+      expect(result).toEqual(response);
+    });
+
+    it('should activate the pending webhooks of a repository', async () => {
+      mockClient.post.mockResolvedValue({ data: { activated: 2, failed: [] } });
+
+      const result = await repositoriesApi.activateWebhooks('org/repo');
+
+      expect(mockClient.post).toHaveBeenCalledWith('/repositories/org%2Frepo/webhooks/activate');
+      expect(result.activated).toBe(2);
+    });
+
+    it('should bulk-activate webhooks of the given repositories', async () => {
+      mockClient.post.mockResolvedValue({ data: { activated: 1, failed: [] } });
+
+      await repositoriesApi.bulkActivateWebhooks({ repository_ids: [1, 2] });
+
+      expect(mockClient.post).toHaveBeenCalledWith('/webhooks/activate', { repository_ids: [1, 2] });
+    });
+  });
+
+  describe('reference pull requests', () => {
+    it('should fetch the reference pull request of a repository', async () => {
+      mockClient.get.mockResolvedValue({ data: { reference_pr: null } });
+
+      const result = await repositoriesApi.getReferencePullRequest('org/repo');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/repositories/org%2Frepo/reference-pr');
+      expect(result.reference_pr).toBeNull();
+    });
+
+    it('should open a reference pull request', async () => {
+      mockClient.post.mockResolvedValue({ data: { reference_pr: { status: 'open', number: 4 } } });
+
+      const result = await repositoriesApi.openReferencePullRequest('org/repo');
+
+      expect(mockClient.post).toHaveBeenCalledWith('/repositories/org%2Frepo/reference-pr');
+      expect(result.reference_pr?.number).toBe(4);
+    });
+  });
+
+  describe('codeowners', () => {
+    it('should fetch the CODEOWNERS rewrite', async () => {
+      mockClient.get.mockResolvedValue({ data: { codeowners: null } });
+
+      const result = await repositoriesApi.getCodeowners('org/repo');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/repositories/org%2Frepo/codeowners');
+      expect(result.codeowners).toBeNull();
+    });
+
+    it('should rewrite CODEOWNERS with the given mode', async () => {
+      mockClient.post.mockResolvedValue({ data: { codeowners: { status: 'committed', handles_rewritten: 3 } } });
+
+      const result = await repositoriesApi.rewriteCodeowners('org/repo', 'commit');
+
+      expect(mockClient.post).toHaveBeenCalledWith('/repositories/org%2Frepo/codeowners/rewrite', { mode: 'commit' });
+      expect(result.codeowners?.handles_rewritten).toBe(3);
+    });
+  });
+
+  describe('collaborators', () => {
+    it('should fetch the collaborator grants', async () => {
+      mockClient.get.mockResolvedValue({ data: { grants: [] } });
+
+      const result = await repositoriesApi.getCollaboratorGrants('org/repo');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/repositories/org%2Frepo/collaborators');
+      expect(result.grants).toEqual([]);
+    });
+
+    it('should grant collaborators with the given mode', async () => {
+      mockClient.post.mockResolvedValue({ data: { grants: [{ source_login: 'alice', status: 'granted' }] } });
+
+      const result = await repositoriesApi.grantCollaborators('org/repo', 'members_only');
+
+      expect(mockClient.post).toHaveBeenCalledWith('/repositories/org%2Frepo/collaborators/grant', { mode: 'members_only' });
+      expect(result.grants[0].status).toBe('granted');
+    });
+  });
+
   describe('markRemediated', () => {
     it('should mark repository as remediated', async () => {
       mockClient.post.mockResolvedValue({ data: { success: true } });
@@ -10,6 +10,14 @@ import type {
   DependenciesResponse,
   DependentsResponse,
   DependencyGraphResponse,
+  RepositorySecretsResponse,
+  RepositoryWebhooksResponse,
+  WebhookActivationResult,
+  ReferencePullRequestResponse,
+  CodeownersRewriteMode,
+  CodeownersRewriteResponse,
+  CollaboratorGrantMode,
+  CollaboratorGrantsResponse,
 } from '../../types';
 
 export const repositoriesApi = {
@@ -105,6 +113,72 @@ export const repositoriesApi = {
     return data;
   },
 
+  async getSecrets(fullName: string): Promise<RepositorySecretsResponse> {
+    const { data } = await client.get(`/repositories/${encodeURIComponent(fullName)}/secrets`);
+    return data;
+  },
+
+  async exportRepositorySecrets(fullName: string, format: 'csv' | 'json'): Promise<Blob> {
+    const { data } = await client.get(`/repositories/${encodeURIComponent(fullName)}/secrets/export`, {
+      params: { format },
+      responseType: 'blob',
+    });
+    return data;
+  },
+
+  async exportSecrets(format: 'csv' | 'json'): Promise<Blob> {
+    const { data } = await client.get('/secrets/export', {
+      params: { format },
+      responseType: 'blob',
+    });
+    return data;
+  },
+
+  async getWebhooks(fullName: string): Promise<RepositoryWebhooksResponse> {
+    const { data } = await client.get(`/repositories/${encodeURIComponent(fullName)}/webhooks`);
+    return data;
+  },
+
+  async activateWebhooks(fullName: string): Promise<WebhookActivationResult> {
+    const { data } = await client.post(`/repositories/${encodeURIComponent(fullName)}/webhooks/activate`);
+    return data;
+  },
+
+  async bulkActivateWebhooks(request: { webhook_ids?: number[]; repository_ids?: number[] } = {}): Promise<WebhookActivationResult> {
+    const { data } = await client.post('/webhooks/activate', request);
+    return data;
+  },
+
+  async getReferencePullRequest(fullName: string): Promise<ReferencePullRequestResponse> {
+    const { data } = await client.get(`/repositories/${encodeURIComponent(fullName)}/reference-pr`);
+    return data;
+  },
+
+  async openReferencePullRequest(fullName: string): Promise<ReferencePullRequestResponse> {
+    const { data } = await client.post(`/repositories/${encodeURIComponent(fullName)}/reference-pr`);
+    return data;
+  },
+
+  async getCodeowners(fullName: string): Promise<CodeownersRewriteResponse> {
+    const { data } = await client.get(`/repositories/${encodeURIComponent(fullName)}/codeowners`);
+    return data;
+  },
+
+  async rewriteCodeowners(fullName: string, mode: CodeownersRewriteMode): Promise<CodeownersRewriteResponse> {
+    const { data } = await client.post(`/repositories/${encodeURIComponent(fullName)}/codeowners/rewrite`, { mode });
+    return data;
+  },
+
+  async getCollaboratorGrants(fullName: string): Promise<CollaboratorGrantsResponse> {
+    const { data } = await client.get(`/repositories/${encodeURIComponent(fullName)}/collaborators`);
+    return data;
+  },
+
+  async grantCollaborators(fullName: string, mode: CollaboratorGrantMode): Promise<CollaboratorGrantsResponse> {
+    const { data } = await client.post(`/repositories/${encodeURIComponent(fullName)}/collaborators/grant`, { mode });
+    return data;
+  },
+
   async markRemediated(fullName: string) {
     const { data } = await client.post(`/repositories/${encodeURIComponent(fullName)}/mark-remediated`);
     return data;
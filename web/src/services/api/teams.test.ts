@@ -276,6 +276,20 @@ describe('teamsApi', () => {
     });
   });
 
+  describe('getMembershipReport', () => {
+    it('should fetch the membership report for a source organization', async () => {
+      const mockData = { teams: [], summary: { synced: 0, skipped: 0, failed: 0, members_added: 0, unmapped_members: 0 } };
+      mockClient.get.mockResolvedValue({ data: mockData });
+
+      const result = await teamsApi.getMembershipReport('my-org');
+
+      expect(mockClient.get).toHaveBeenCalledWith('/team-mappings/membership-report', {
+        params: { source_org: 'my-org' },
+      });
+      expect(result).toEqual(mockData);
+    });
+  });
+
   describe('getMigrationStatus', () => {
     it('should get migration status', async () => {
       const mockStatus = { is_running: true, progress: 50 };
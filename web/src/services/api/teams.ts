@@ -9,6 +9,7 @@ import type {
   TeamMappingStats,
   TeamDetail,
   TeamMigrationStatusResponse,
+  TeamMembershipReport,
   ImportResult,
 } from '../../types';
 
@@ -137,7 +138,8 @@ export const teamsApi = {
     source_org?: string;
     source_team_slug?: string;
     dry_run?: boolean;
-  }): Promise<{ message: string; dry_run: boolean; source_org?: string }> {
+    sync_members?: boolean;
+  }): Promise<{ message: string; dry_run: boolean; sync_members: boolean; source_org?: string }> {
     const { data } = await client.post('/team-mappings/execute', options);
     return data;
   },
@@ -147,6 +149,13 @@ export const teamsApi = {
     return data;
   },
 
+  async getMembershipReport(sourceOrg?: string): Promise<TeamMembershipReport> {
+    const { data } = await client.get('/team-mappings/membership-report', {
+      params: sourceOrg ? { source_org: sourceOrg } : undefined,
+    });
+    return data;
+  },
+
   async cancelMigration(): Promise<{ message: string }> {
     const { data } = await client.post('/team-mappings/cancel');
     return data;
@@ -21,14 +21,98 @@ export interface Batch {
   dry_run_duration_seconds?: number;
   // Migration settings (batch-level defaults, repository settings take precedence)
   destination_org?: string;
-  migration_api?: 'GEI' | 'ELM';
+  migration_api?: 'GEI' | 'ELM' | 'GIT';
   exclude_releases?: boolean;
   exclude_attachments?: boolean;
+  // Delta sync: dry run pre-seeds, scheduled migration re-syncs changed refs
+  delta_sync?: boolean;
+  // Actions run on the source after a successful production migration (comma-separated)
+  completion_actions?: string;
+  // Dependency: the scheduler starts the batch after this batch completes its production migration
+  depends_on_batch_id?: number;
+  max_dependency_failures?: number;
   // Progress information (populated by backend for in-progress/completed batches)
   percent_complete?: number;
   completed_repos?: number;
 }
 
+// Actions a batch can run on the source repository after a successful production migration,
+// in the order they run
+export type CompletionAction = 'readme_notice' | 'description' | 'archive';
+
+export const COMPLETION_ACTIONS: { value: CompletionAction; label: string; description: string }[] = [
+  { value: 'readme_notice', label: 'README notice', description: 'Add a "moved to" banner to the top of the source README' },
+  { value: 'description', label: 'Description', description: 'Replace the source description with a "moved to" notice' },
+  { value: 'archive', label: 'Archive source', description: 'Archive the source repository (read-only on Azure DevOps)' },
+];
+
+// Helper function to parse a batch's comma-separated completion actions
+export function parseCompletionActions(value?: string): CompletionAction[] {
+  if (!value) {
+    return [];
+  }
+  return value
+    .split(',')
+    .map((action) => action.trim())
+    .filter((action): action is CompletionAction => COMPLETION_ACTIONS.some((a) => a.value === action));
+}
+
+// Estimated migration duration of a single repository
+export interface RepositoryForecast {
+  repository_id: number;
+  full_name: string;
+  estimated_seconds: number;
+  // history: the repository's latest migration or dry run; model: size-based estimate
+  basis: 'history' | 'model';
+}
+
+// Projected duration of migrating a batch's unmigrated repositories with the configured workers
+export interface MigrationForecast {
+  repositories: number;
+  workers: number;
+  total_seconds: number;
+  wall_clock_seconds: number;
+  calibration_samples: number;
+  calibration_factor: number;
+  longest?: RepositoryForecast;
+  estimates?: RepositoryForecast[];
+}
+
+// Whether a batch's dependency allows the scheduler to start it
+export interface BatchDependency {
+  batch_id: number;
+  batch_name: string;
+  batch_status: string;
+  state: 'waiting' | 'satisfied' | 'blocked';
+  failures: number;
+  max_failures?: number;
+  reason: string;
+}
+
+// State of a batch's dry run attached to its approval request
+export interface BatchDryRunSummary {
+  repositories: number;
+  succeeded: number;
+  failed: number;
+  not_run: number;
+  last_dry_run_at?: string;
+  failed_repositories?: string[];
+  repository_ids: number[];
+}
+
+// A step of the two-person approval a batch's production migration needs; the latest step is
+// the batch's approval state
+export interface BatchApproval {
+  id: number;
+  batch_id: number;
+  action: 'requested' | 'approved' | 'rejected' | 'started';
+  actor: string;
+  comment?: string;
+  dry_run_summary?: BatchDryRunSummary;
+  used_at?: string; // When a start used up an approval
+  created_at: string;
+}
+
 // Helper function to calculate batch duration in seconds
 export function getBatchDuration(batch: Batch): number | null {
   if (!batch.started_at || !batch.completed_at) {
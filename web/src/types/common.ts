@@ -120,11 +120,20 @@ export interface FailedRepository {
   batch_name?: string;
 }
 
+// Migrated repository whose recreated webhooks still wait for activation
+export interface PendingWebhookRepository {
+  repository_id: number;
+  full_name: string;
+  destination_repository: string;
+  pending_webhooks: number;
+}
+
 export interface DashboardActionItems {
   failed_migrations: FailedRepository[];
   failed_dry_runs: FailedRepository[];
   ready_batches: Batch[];
   blocked_repositories: Repository[];
+  pending_webhooks?: PendingWebhookRepository[];
 }
 
 // Setup types
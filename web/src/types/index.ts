@@ -7,6 +7,14 @@
 export type {
   Repository,
   ComplexityBreakdown,
+  DeepValidationReport,
+  RefDiff,
+  RefDiffStatus,
+  ArtifactCountComparison,
+  LFSValidation,
+  SettingsSyncStatus,
+  SettingsFieldSync,
+  SettingsSyncReport,
   RepositoryFilters,
   RepositoryListResponse,
   DependencyType,
@@ -20,13 +28,30 @@ export type {
   DependencyGraphStats,
   DependencyGraphResponse,
   DependencyExportRow,
+  RepositorySecret,
+  RepositorySecretsResponse,
+  WebhookStatus,
+  RepositoryWebhook,
+  RepositoryWebhooksResponse,
+  WebhookActivationResult,
+  ReferencePullRequestStatus,
+  ReferencePullRequest,
+  ReferencePullRequestResponse,
+  CodeownersRewriteMode,
+  CodeownersRewriteStatus,
+  CodeownersRewrite,
+  CodeownersRewriteResponse,
+  CollaboratorGrantMode,
+  CollaboratorGrantStatus,
+  CollaboratorGrant,
+  CollaboratorGrantsResponse,
   ImportedMigrationSettings,
   ImportedRepository,
 } from './repository';
 
 // Batch types
-export type { Batch, BatchStatus } from './batch';
-export { getBatchDuration, formatBatchDuration, formatDurationSeconds, getDryRunDuration, formatDryRunDuration } from './batch';
+export type { Batch, BatchApproval, BatchDependency, BatchDryRunSummary, BatchStatus, CompletionAction, MigrationForecast, RepositoryForecast } from './batch';
+export { getBatchDuration, formatBatchDuration, formatDurationSeconds, getDryRunDuration, formatDryRunDuration, COMPLETION_ACTIONS, parseCompletionActions } from './batch';
 
 // Migration types
 export type {
@@ -70,6 +95,9 @@ export type {
   TeamMigrationProgress,
   TeamMigrationExecutionStats,
   TeamMigrationStatusResponse,
+  TeamMembershipSyncStatus,
+  TeamMembershipSync,
+  TeamMembershipReport,
   TeamDetailMember,
   TeamDetailRepository,
   TeamDetailMapping,
@@ -102,6 +130,7 @@ export type {
   FeatureStats,
   FailedRepository,
   DashboardActionItems,
+  PendingWebhookRepository,
   SetupStatus,
   MaskedConfigData,
   SetupConfig,
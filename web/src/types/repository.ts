@@ -68,6 +68,9 @@ export interface Repository {
   destination_full_name?: string;
   source_migration_id?: number;
   is_source_locked: boolean;
+  // Delta sync pre-seed (cleared at cutover)
+  pre_seeded_at?: string;
+  pre_seed_commit_sha?: string;
   discovered_at: string;
   updated_at: string;
   migrated_at?: string;
@@ -138,6 +141,63 @@ export interface Repository {
   // Computed fields
   complexity_score?: number;
   complexity_breakdown?: ComplexityBreakdown;
+  // Post-migration validation
+  validation_status?: 'passed' | 'failed';
+  deep_validation_report?: DeepValidationReport;
+  settings_sync_report?: SettingsSyncReport;
+}
+
+export type RefDiffStatus = 'missing_in_destination' | 'extra_in_destination' | 'sha_mismatch';
+
+export interface RefDiff {
+  ref: string;
+  status: RefDiffStatus;
+  source_sha?: string;
+  dest_sha?: string;
+}
+
+export interface ArtifactCountComparison {
+  field: string;
+  source: number;
+  destination: number;
+  match: boolean;
+  skipped?: string;
+}
+
+export interface LFSValidation {
+  objects: number;
+  missing: number;
+  missing_oids?: string[];
+}
+
+// Result of deep post-migration validation (migration.deep_validation)
+export interface DeepValidationReport {
+  passed: boolean;
+  checked_at: string;
+  source_refs: number;
+  dest_refs: number;
+  refs_matched: number;
+  ref_diffs: RefDiff[];
+  counts: ArtifactCountComparison[];
+  lfs?: LFSValidation;
+  errors?: string[];
+}
+
+export type SettingsSyncStatus = 'applied' | 'unchanged' | 'skipped' | 'failed';
+
+export interface SettingsFieldSync {
+  field: string;
+  source: unknown;
+  destination: unknown; // Destination value before the sync
+  status: SettingsSyncStatus;
+  error?: string;
+}
+
+// Result of the post-migration settings sync (migration.settings_sync)
+export interface SettingsSyncReport {
+  synced_at: string;
+  settings: SettingsFieldSync[];
+  errors?: string[];
 }
 
 export interface ComplexityBreakdown {
@@ -175,6 +235,16 @@ export interface ComplexityBreakdown {
   ado_service_hook_points?: number;
   ado_many_prs_points?: number;
   ado_branch_policy_points?: number;
+  // GitLab specific breakdown
+  gitlab_ci_points?: number;
+  gitlab_wiki_points?: number;
+  gitlab_many_mrs_points?: number;
+  gitlab_merge_rules_points?: number;
+  // Bitbucket specific breakdown
+  bitbucket_pipelines_points?: number;
+  bitbucket_many_prs_points?: number;
+  bitbucket_branch_permissions_points?: number;
+  bitbucket_webhooks_points?: number;
 }
 
 export interface RepositoryFilters {
@@ -264,6 +334,122 @@ export interface DependencySummary {
   by_type: Record<string, number>;
 }
 
+// Entry in a repository's "secrets to re-enter" checklist. Actions secret values cannot be
+// migrated, so each secret is recreated on the destination as a named placeholder.
+export interface RepositorySecret {
+  id: number;
+  repository_id: number;
+  environment: string; // Empty for repository-level secrets
+  name: string;
+  placeholder_created: boolean;
+  recorded_at: string;
+}
+
+export interface RepositorySecretsResponse {
+  secrets: RepositorySecret[];
+  total: number;
+  placeholders: number;
+}
+
+export type WebhookStatus = 'pending' | 'active' | 'disabled' | 'failed';
+
+// Source webhook recreated on the destination. Hook secrets cannot be read back, so hooks
+// are created inactive and activated once their owners have re-entered the secret.
+export interface RepositoryWebhook {
+  id: number;
+  repository_id: number;
+  source_hook_id: number;
+  destination_hook_id?: number;
+  url: string;
+  events: string; // Comma-separated event names
+  content_type: string;
+  insecure_ssl: boolean;
+  has_secret: boolean;
+  status: WebhookStatus;
+  error?: string;
+  recorded_at: string;
+  activated_at?: string;
+}
+
+export interface RepositoryWebhooksResponse {
+  webhooks: RepositoryWebhook[];
+  total: number;
+  pending: number;
+}
+
+export interface WebhookActivationResult {
+  activated: number;
+  failed: { id: number; repository: string; url: string; error: string }[];
+}
+
+export type ReferencePullRequestStatus = 'open' | 'no_changes' | 'failed';
+
+// Pull request opened on a migrated repository that rewrites .gitmodules, workflow uses:
+// references and package manifests to the destination names of other migrated repositories.
+export interface ReferencePullRequest {
+  id: number;
+  repository_id: number;
+  status: ReferencePullRequestStatus;
+  number?: number;
+  url?: string;
+  branch: string;
+  files_changed: number;
+  references_rewritten: number;
+  unresolved?: string; // Comma-separated dependencies not migrated yet
+  error?: string;
+  created_at: string;
+}
+
+export interface ReferencePullRequestResponse {
+  reference_pr: ReferencePullRequest | null;
+}
+
+export type CodeownersRewriteMode = 'commit' | 'pull_request';
+
+export type CodeownersRewriteStatus = 'committed' | 'pull_request' | 'no_changes' | 'not_found' | 'failed';
+
+// CODEOWNERS rewrite on a migrated repository that maps team and user handles
+// to their destination teams and users.
+export interface CodeownersRewrite {
+  id: number;
+  repository_id: number;
+  mode: CodeownersRewriteMode;
+  status: CodeownersRewriteStatus;
+  path: string; // Empty when no CODEOWNERS file was found
+  handles_rewritten: number;
+  unmapped?: string; // Comma-separated handles without a mapping
+  pull_request_number?: number;
+  pull_request_url?: string;
+  error?: string;
+  created_at: string;
+}
+
+export interface CodeownersRewriteResponse {
+  codeowners: CodeownersRewrite | null;
+}
+
+export type CollaboratorGrantMode = 'all' | 'members_only';
+
+export type CollaboratorGrantStatus = 'granted' | 'invited' | 'unmapped' | 'skipped' | 'failed';
+
+// Re-grant of a source collaborator's direct repository permission on the destination
+// through their user mapping.
+export interface CollaboratorGrant {
+  id: number;
+  repository_id: number;
+  source_login: string;
+  destination_login?: string; // Missing when the user has no mapping
+  permission: string;
+  outside: boolean; // Outside collaborator on the source
+  status: CollaboratorGrantStatus;
+  reason?: string;
+  created_at: string;
+}
+
+export interface CollaboratorGrantsResponse {
+  grants: CollaboratorGrant[];
+}
+
 export interface DependenciesResponse {
   dependencies: RepositoryDependency[];
   summary: DependencySummary;
@@ -323,7 +509,7 @@ export interface DependencyExportRow {
 export interface ImportedMigrationSettings {
   destination_org?: string;
   destination_repo_name?: string;
-  migration_api?: 'GEI' | 'ELM';
+  migration_api?: 'GEI' | 'ELM' | 'GIT';
   exclude_releases?: boolean;
   exclude_attachments?: boolean;
   exclude_metadata?: boolean;
@@ -2,7 +2,7 @@
  * Types for multi-source configuration management.
  */
 
-export type SourceType = 'github' | 'azuredevops';
+export type SourceType = 'github' | 'azuredevops' | 'gitlab' | 'bitbucket';
 
 /**
  * Source represents a configured migration source.
@@ -9,6 +9,7 @@ export interface GitHubTeam {
   name: string;
   description?: string;
   privacy: string;
+  parent_slug?: string; // Parent team in the same organization
   full_slug: string;
 }
 
@@ -31,6 +32,7 @@ export interface TeamMapping {
   name: string;
   description?: string;
   privacy: string;
+  parent_slug?: string; // Parent team in the same organization
   source_id?: number; // Added for multi-source support
   destination_org?: string;
   destination_team_slug?: string;
@@ -69,6 +71,9 @@ export interface TeamMigrationProgress {
   skipped_teams: number;
   failed_teams: number;
   total_repos_synced: number;
+  members_added: number;
+  unmapped_members: number;
+  hierarchy_warnings?: string[]; // Teams created without their source parent, and why
   started_at: string;
   completed_at?: string;
   current_team?: string;
@@ -95,6 +100,36 @@ export interface TeamMigrationStatusResponse {
   mapping_stats: TeamMappingStats;
 }
 
+export type TeamMembershipSyncStatus = 'synced' | 'skipped' | 'failed';
+
+// Outcome of adding a source team's members to its destination team through their user mappings
+export interface TeamMembershipSync {
+  id: number;
+  source_id?: number;
+  source_org: string;
+  source_team_slug: string;
+  destination_org: string;
+  destination_team_slug: string;
+  status: TeamMembershipSyncStatus;
+  reason?: string; // Why the team was skipped or failed
+  members_added: number;
+  maintainers_added: number;
+  unmapped?: string; // Comma-separated source logins without a user mapping
+  failed?: string; // Comma-separated destination logins that could not be added
+  created_at: string;
+}
+
+export interface TeamMembershipReport {
+  teams: TeamMembershipSync[] | null;
+  summary: {
+    synced: number;
+    skipped: number;
+    failed: number;
+    members_added: number;
+    unmapped_members: number;
+  };
+}
+
 export interface TeamDetailMember {
   login: string;
   role: 'member' | 'maintainer';